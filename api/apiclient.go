@@ -34,6 +34,7 @@ import (
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/rpc"
 	"github.com/juju/juju/rpc/jsoncodec"
+	"github.com/juju/juju/tracing"
 )
 
 var logger = loggo.GetLogger("juju.api")
@@ -190,6 +191,9 @@ func open(
 	}
 
 	client := rpc.NewConn(jsoncodec.NewWebsocket(conn), observer.None())
+	if span := tracing.RootSpan(); span != nil {
+		client.TraceCalls(span)
+	}
 	client.Start()
 
 	bakeryClient := opts.BakeryClient
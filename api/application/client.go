@@ -11,6 +11,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api"
 	"github.com/juju/juju/api/base"
@@ -185,6 +186,32 @@ func (c *Client) DestroyUnits(unitNames ...string) error {
 	return c.facade.FacadeCall("DestroyUnits", params, nil)
 }
 
+// UnitHookRuns returns the hooks and actions most recently run by
+// the named unit, most recent first. It requires version 2 of the
+// Application facade.
+func (c *Client) UnitHookRuns(unitName string) ([]params.HookRun, error) {
+	if c.facade.BestAPIVersion() < 2 {
+		return nil, errors.NotSupportedf("UnitHookRuns on this controller")
+	}
+	if !names.IsValidUnit(unitName) {
+		return nil, errors.NotValidf("unit name %q", unitName)
+	}
+	var results params.HookRunsResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewUnitTag(unitName).String()}},
+	}
+	if err := c.facade.FacadeCall("UnitHookRuns", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, err
+	}
+	return results.Results[0].Runs, nil
+}
+
 // Destroy destroys a given application.
 func (c *Client) Destroy(application string) error {
 	params := params.ApplicationDestroy{
@@ -216,6 +243,25 @@ func (c *Client) Expose(application string) error {
 	return c.facade.FacadeCall("Expose", params, nil)
 }
 
+// ExposeTo changes the juju-managed firewall to expose the given
+// endpoints of the application, each only to its associated source
+// CIDRs. The empty endpoint name applies to all endpoints. The
+// settings are merged with any existing expose settings. It requires
+// version 2 of the Application facade.
+func (c *Client) ExposeTo(application string, endpoints map[string]params.ExposedEndpoint) error {
+	if len(endpoints) == 0 {
+		return c.Expose(application)
+	}
+	if c.facade.BestAPIVersion() < 2 {
+		return errors.NotSupportedf("exposing endpoints to CIDRs on this controller")
+	}
+	params := params.ApplicationExpose{
+		ApplicationName:  application,
+		ExposedEndpoints: endpoints,
+	}
+	return c.facade.FacadeCall("Expose", params, nil)
+}
+
 // Unexpose changes the juju-managed firewall to unexpose any ports that
 // were also explicitly marked by units as open.
 func (c *Client) Unexpose(application string) error {
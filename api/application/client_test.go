@@ -4,6 +4,7 @@
 package application_test
 
 import (
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6-unstable"
@@ -138,6 +139,65 @@ func (s *serviceSuite) TestServiceGetCharmURL(c *gc.C) {
 	c.Assert(called, jc.IsTrue)
 }
 
+func (s *serviceSuite) TestUnitHookRuns(c *gc.C) {
+	runs := []params.HookRun{{
+		Kind:     params.HookRunKindHook,
+		Name:     "install",
+		ExitCode: 1,
+	}}
+	var called bool
+	application.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "UnitHookRuns")
+		c.Assert(a, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{Tag: "unit-foo-0"}},
+		})
+		result := response.(*params.HookRunsResults)
+		result.Results = []params.HookRunsResult{{Runs: runs}}
+		return nil
+	})
+	result, err := s.client.UnitHookRuns("foo/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, runs)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *serviceSuite) TestUnitHookRunsInvalidUnit(c *gc.C) {
+	_, err := s.client.UnitHookRuns("foo")
+	c.Assert(err, gc.ErrorMatches, `unit name "foo" not valid`)
+}
+
+func (s *serviceSuite) TestExposeTo(c *gc.C) {
+	endpoints := map[string]params.ExposedEndpoint{
+		"": {ExposeToCIDRs: []string{"10.0.0.0/8"}},
+	}
+	var called bool
+	application.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "Expose")
+		c.Assert(a, jc.DeepEquals, params.ApplicationExpose{
+			ApplicationName:  "foo",
+			ExposedEndpoints: endpoints,
+		})
+		return nil
+	})
+	err := s.client.ExposeTo("foo", endpoints)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *serviceSuite) TestExposeToOldController(c *gc.C) {
+	application.PatchBestAPIVersion(s, s.client, 1)
+	application.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		c.Fatalf("unexpected call to %s", request)
+		return nil
+	})
+	err := s.client.ExposeTo("foo", map[string]params.ExposedEndpoint{
+		"": {ExposeToCIDRs: []string{"10.0.0.0/8"}},
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *serviceSuite) TestServiceSetCharm(c *gc.C) {
 	var called bool
 	application.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
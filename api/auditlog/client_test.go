@@ -0,0 +1,77 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/auditlog"
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type clientSuite struct {
+	gitjujutesting.IsolationSuite
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+func (s *clientSuite) TestAuditEntries(c *gc.C) {
+	since := time.Date(2016, 10, 1, 0, 0, 0, 0, time.UTC)
+	filter := params.AuditEntriesFilter{
+		UserTag:   "user-bob@local",
+		Operation: "Application",
+		Since:     &since,
+	}
+	entry := params.AuditEntry{
+		ModelTag:   "model-deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		Timestamp:  since.Add(time.Hour),
+		OriginType: "API request",
+		OriginName: "user-bob@local",
+		Operation:  "Application:v1 - Deploy",
+	}
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "AuditLog")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "AuditEntries")
+			c.Check(a, jc.DeepEquals, filter)
+			c.Assert(result, gc.FitsTypeOf, &params.AuditEntriesResult{})
+			*(result.(*params.AuditEntriesResult)) = params.AuditEntriesResult{
+				Entries: []params.AuditEntry{entry},
+			}
+			return nil
+		},
+	)
+
+	client := auditlog.NewClient(apiCaller)
+	entries, err := client.AuditEntries(filter)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, jc.DeepEquals, []params.AuditEntry{entry})
+}
+
+func (s *clientSuite) TestAuditEntriesError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			return errors.New("boom")
+		},
+	)
+
+	client := auditlog.NewClient(apiCaller)
+	_, err := client.AuditEntries(params.AuditEntriesFilter{})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
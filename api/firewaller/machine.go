@@ -108,9 +108,32 @@ func (m *Machine) ActiveSubnets() ([]names.SubnetTag, error) {
 	return tags, nil
 }
 
+// UnitEndpoint identifies the unit, and the endpoint of the unit, for
+// which a port range was opened. An empty endpoint name means that it
+// was opened for all of the unit's endpoints.
+type UnitEndpoint struct {
+	Unit     names.UnitTag
+	Endpoint string
+}
+
 // OpenedPorts returns a map of network.PortRange to unit tag for all opened
 // port ranges on the machine for the subnet matching given subnetTag.
 func (m *Machine) OpenedPorts(subnetTag names.SubnetTag) (map[network.PortRange]names.UnitTag, error) {
+	portRanges, err := m.OpenedPortRanges(subnetTag)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[network.PortRange]names.UnitTag)
+	for portRange, unitEndpoint := range portRanges {
+		result[portRange] = unitEndpoint.Unit
+	}
+	return result, nil
+}
+
+// OpenedPortRanges returns a map of network.PortRange to the unit and
+// endpoint for which it was opened, for all opened port ranges on the
+// machine for the subnet matching given subnetTag.
+func (m *Machine) OpenedPortRanges(subnetTag names.SubnetTag) (map[network.PortRange]UnitEndpoint, error) {
 	var results params.MachinePortsResults
 	var subnetTagAsString string
 	if subnetTag.Id() != "" {
@@ -133,13 +156,16 @@ func (m *Machine) OpenedPorts(subnetTag names.SubnetTag) (map[network.PortRange]
 		return nil, result.Error
 	}
 	// Convert string tags to names.UnitTag before returning.
-	endResult := make(map[network.PortRange]names.UnitTag)
+	endResult := make(map[network.PortRange]UnitEndpoint)
 	for _, ports := range result.Ports {
 		unitTag, err := names.ParseUnitTag(ports.UnitTag)
 		if err != nil {
 			return nil, err
 		}
-		endResult[ports.PortRange.NetworkPortRange()] = unitTag
+		endResult[ports.PortRange.NetworkPortRange()] = UnitEndpoint{
+			Unit:     unitTag,
+			Endpoint: ports.Endpoint,
+		}
 	}
 	return endResult, nil
 }
@@ -134,3 +134,18 @@ func (s *machineSuite) TestOpenedPorts(c *gc.C) {
 		network.PortRange{FromPort: 1234, ToPort: 1234, Protocol: "tcp"}: unitTag,
 	})
 }
+
+func (s *machineSuite) TestOpenedPortRanges(c *gc.C) {
+	unitTag := s.units[0].Tag().(names.UnitTag)
+
+	err := s.units[0].OpenPort("tcp", 1234)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.units[0].OpenEndpointPorts("url", "tcp", 80, 81)
+	c.Assert(err, jc.ErrorIsNil)
+	ports, err := s.apiMachine.OpenedPortRanges(names.SubnetTag{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ports, jc.DeepEquals, map[network.PortRange]firewaller.UnitEndpoint{
+		network.PortRange{FromPort: 80, ToPort: 81, Protocol: "tcp"}:     {Unit: unitTag, Endpoint: "url"},
+		network.PortRange{FromPort: 1234, ToPort: 1234, Protocol: "tcp"}: {Unit: unitTag},
+	})
+}
@@ -63,6 +63,20 @@ func (c *Client) ModelGetWithMetadata() (config.ConfigValues, error) {
 	return values, nil
 }
 
+// ModelLogsSize returns the approximate size, in bytes, of the
+// model's logs. It requires version 2 of the ModelConfig facade.
+func (c *Client) ModelLogsSize() (int64, error) {
+	if c.facade.BestAPIVersion() < 2 {
+		return 0, errors.NotSupportedf("model logs size on this controller")
+	}
+	result := params.ModelLogsSizeResult{}
+	err := c.facade.FacadeCall("ModelLogsSize", nil, &result)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	return result.Size, nil
+}
+
 // ModelSet sets the given key-value pairs in the model.
 func (c *Client) ModelSet(config map[string]interface{}) error {
 	args := params.ModelSet{Config: config}
@@ -75,6 +89,40 @@ func (c *Client) ModelUnset(keys ...string) error {
 	return c.facade.FacadeCall("ModelUnset", args, nil)
 }
 
+// SetLoggingOverride sets the logging config override for the agents
+// of the given machine, application or unit, without changing any
+// other entity's override. It requires version 2 of the ModelConfig
+// facade.
+func (c *Client) SetLoggingOverride(tag names.Tag, loggingConfig string) error {
+	return c.setLoggingOverride(tag, loggingConfig)
+}
+
+// UnsetLoggingOverride removes the logging config override for the
+// agents of the given machine, application or unit. It returns an
+// error satisfying params.IsCodeNotFound if there is no override for
+// the entity. It requires version 2 of the ModelConfig facade.
+func (c *Client) UnsetLoggingOverride(tag names.Tag) error {
+	return c.setLoggingOverride(tag, "")
+}
+
+func (c *Client) setLoggingOverride(tag names.Tag, loggingConfig string) error {
+	if c.facade.BestAPIVersion() < 2 {
+		return errors.NotSupportedf("logging overrides on this controller")
+	}
+	args := params.LoggingOverrides{
+		Overrides: []params.LoggingOverride{{
+			Tag:    tag.String(),
+			Config: loggingConfig,
+		}},
+	}
+	var result params.ErrorResults
+	err := c.facade.FacadeCall("SetLoggingOverrides", args, &result)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return result.OneError()
+}
+
 // ModelDefaults returns the default config values used when creating a new model.
 func (c *Client) ModelDefaults() (config.ConfigValues, error) {
 	result := params.ModelConfigResults{}
@@ -4,9 +4,11 @@
 package modelconfig_test
 
 import (
+	"github.com/juju/errors"
 	gitjujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
 
 	basetesting "github.com/juju/juju/api/base/testing"
 	"github.com/juju/juju/api/modelconfig"
@@ -74,6 +76,48 @@ func (s *modelconfigSuite) TestModelGetWithMetadata(c *gc.C) {
 	})
 }
 
+func (s *modelconfigSuite) TestModelLogsSize(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "ModelConfig")
+			c.Check(version, gc.Equals, 2)
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "ModelLogsSize")
+			c.Check(a, gc.IsNil)
+			c.Assert(result, gc.FitsTypeOf, &params.ModelLogsSizeResult{})
+			results := result.(*params.ModelLogsSizeResult)
+			results.Size = 1536
+			return nil
+		},
+		BestVersion: 2,
+	}
+	client := modelconfig.NewClient(apiCaller)
+	size, err := client.ModelLogsSize()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(size, gc.Equals, int64(1536))
+}
+
+func (s *modelconfigSuite) TestModelLogsSizeOldController(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Fatalf("unexpected call to %s", request)
+			return nil
+		},
+		BestVersion: 1,
+	}
+	client := modelconfig.NewClient(apiCaller)
+	_, err := client.ModelLogsSize()
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *modelconfigSuite) TestModelSet(c *gc.C) {
 	called := false
 	apiCaller := basetesting.APICallerFunc(
@@ -222,3 +266,80 @@ func (s *modelconfigSuite) TestUnsetModelDefaults(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(called, jc.IsTrue)
 }
+
+func (s *modelconfigSuite) TestSetLoggingOverride(c *gc.C) {
+	called := false
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "ModelConfig")
+			c.Check(version, gc.Equals, 2)
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "SetLoggingOverrides")
+			c.Check(a, jc.DeepEquals, params.LoggingOverrides{
+				Overrides: []params.LoggingOverride{{
+					Tag:    "unit-mysql-0",
+					Config: "juju=TRACE",
+				}}})
+			c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{Error: nil}},
+			}
+			called = true
+			return nil
+		},
+		BestVersion: 2,
+	}
+	client := modelconfig.NewClient(apiCaller)
+	err := client.SetLoggingOverride(names.NewUnitTag("mysql/0"), "juju=TRACE")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *modelconfigSuite) TestUnsetLoggingOverride(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(request, gc.Equals, "SetLoggingOverrides")
+			c.Check(a, jc.DeepEquals, params.LoggingOverrides{
+				Overrides: []params.LoggingOverride{{Tag: "unit-mysql-0"}},
+			})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{Error: &params.Error{
+					Message: "logging override for unit mysql/0 not found",
+					Code:    params.CodeNotFound,
+				}}},
+			}
+			return nil
+		},
+		BestVersion: 2,
+	}
+	client := modelconfig.NewClient(apiCaller)
+	err := client.UnsetLoggingOverride(names.NewUnitTag("mysql/0"))
+	c.Assert(err, jc.Satisfies, params.IsCodeNotFound)
+}
+
+func (s *modelconfigSuite) TestSetLoggingOverrideOldController(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Fatalf("unexpected call to %s", request)
+			return nil
+		},
+		BestVersion: 1,
+	}
+	client := modelconfig.NewClient(apiCaller)
+	err := client.SetLoggingOverride(names.NewUnitTag("mysql/0"), "juju=TRACE")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	err = client.UnsetLoggingOverride(names.NewUnitTag("mysql/0"))
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
@@ -147,3 +147,53 @@ func (c *Client) AddToUnit(storages []params.StorageAddParams) ([]params.ErrorRe
 	}
 	return out.Results, nil
 }
+
+// Resize requests that the specified storage instances be grown
+// to the specified sizes, in MiB. It requires version 4 of the
+// Storage facade.
+func (c *Client) Resize(storages []params.StorageResizeParams) ([]params.ErrorResult, error) {
+	if c.facade.BestAPIVersion() < 4 {
+		return nil, errors.NotSupportedf("resizing storage on this controller")
+	}
+	out := params.ErrorResults{}
+	in := params.StoragesResizeParams{Storages: storages}
+	err := c.facade.FacadeCall("Resize", in, &out)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return out.Results, nil
+}
+
+// CreateSnapshots requests snapshots of the volumes backing the
+// specified storage instances. It requires version 4 of the Storage
+// facade.
+func (c *Client) CreateSnapshots(tags []names.StorageTag) ([]params.VolumeSnapshotDetailsResult, error) {
+	if c.facade.BestAPIVersion() < 4 {
+		return nil, errors.NotSupportedf("storage snapshots on this controller")
+	}
+	entities := make([]params.Entity, len(tags))
+	for i, tag := range tags {
+		entities[i] = params.Entity{Tag: tag.String()}
+	}
+	var results params.VolumeSnapshotDetailsResults
+	if err := c.facade.FacadeCall("CreateSnapshots", params.Entities{Entities: entities}, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != len(tags) {
+		return nil, errors.Errorf("expected %d result(s), got %d", len(tags), len(results.Results))
+	}
+	return results.Results, nil
+}
+
+// ListSnapshots lists all volume snapshots in the model. It requires
+// version 4 of the Storage facade.
+func (c *Client) ListSnapshots() ([]params.VolumeSnapshotDetailsResult, error) {
+	if c.facade.BestAPIVersion() < 4 {
+		return nil, errors.NotSupportedf("storage snapshots on this controller")
+	}
+	var results params.VolumeSnapshotDetailsResults
+	if err := c.facade.FacadeCall("ListSnapshots", nil, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return results.Results, nil
+}
@@ -581,3 +581,144 @@ func (s *storageMockSuite) TestAddToUnitFacadeCallError(c *gc.C) {
 	c.Assert(errors.Cause(err), gc.ErrorMatches, msg)
 	c.Assert(found, gc.HasLen, 0)
 }
+
+func (s *storageMockSuite) TestResize(c *gc.C) {
+	storages := []params.StorageResizeParams{
+		{StorageTag: "storage-data-0", Size: 2048},
+		{StorageTag: "storage-data-1", Size: 4096},
+	}
+	expectedError := common.ServerError(errors.New("cannot shrink"))
+
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(version, gc.Equals, 4)
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "Resize")
+			c.Check(a, jc.DeepEquals, params.StoragesResizeParams{storages})
+			c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{}, {expectedError}},
+			}
+			return nil
+		},
+		BestVersion: 4,
+	}
+	storageClient := storage.NewClient(apiCaller)
+	r, err := storageClient.Resize(storages)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(r, jc.DeepEquals, []params.ErrorResult{{}, {expectedError}})
+}
+
+func (s *storageMockSuite) TestResizeOldController(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Fatalf("unexpected call to %s", request)
+			return nil
+		},
+		BestVersion: 3,
+	}
+	storageClient := storage.NewClient(apiCaller)
+	_, err := storageClient.Resize([]params.StorageResizeParams{
+		{StorageTag: "storage-data-0", Size: 2048},
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *storageMockSuite) TestCreateSnapshots(c *gc.C) {
+	expectedError := common.ServerError(errors.New("not provisioned"))
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(version, gc.Equals, 4)
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "CreateSnapshots")
+			c.Check(a, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{
+					{Tag: "storage-data-0"},
+					{Tag: "storage-data-1"},
+				},
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.VolumeSnapshotDetailsResults{})
+			*(result.(*params.VolumeSnapshotDetailsResults)) = params.VolumeSnapshotDetailsResults{
+				Results: []params.VolumeSnapshotDetailsResult{
+					{Result: &params.VolumeSnapshotDetails{Id: "0", VolumeTag: "volume-0"}},
+					{Error: expectedError},
+				},
+			}
+			return nil
+		},
+		BestVersion: 4,
+	}
+	storageClient := storage.NewClient(apiCaller)
+	results, err := storageClient.CreateSnapshots([]names.StorageTag{
+		names.NewStorageTag("data/0"),
+		names.NewStorageTag("data/1"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.VolumeSnapshotDetailsResult{
+		{Result: &params.VolumeSnapshotDetails{Id: "0", VolumeTag: "volume-0"}},
+		{Error: expectedError},
+	})
+}
+
+func (s *storageMockSuite) TestListSnapshots(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(version, gc.Equals, 4)
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "ListSnapshots")
+			c.Check(a, gc.IsNil)
+			c.Assert(result, gc.FitsTypeOf, &params.VolumeSnapshotDetailsResults{})
+			*(result.(*params.VolumeSnapshotDetailsResults)) = params.VolumeSnapshotDetailsResults{
+				Results: []params.VolumeSnapshotDetailsResult{
+					{Result: &params.VolumeSnapshotDetails{Id: "0", VolumeTag: "volume-0"}},
+				},
+			}
+			return nil
+		},
+		BestVersion: 4,
+	}
+	storageClient := storage.NewClient(apiCaller)
+	results, err := storageClient.ListSnapshots()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.VolumeSnapshotDetailsResult{
+		{Result: &params.VolumeSnapshotDetails{Id: "0", VolumeTag: "volume-0"}},
+	})
+}
+
+func (s *storageMockSuite) TestSnapshotsOldController(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Fatalf("unexpected call to %s", request)
+			return nil
+		},
+		BestVersion: 3,
+	}
+	storageClient := storage.NewClient(apiCaller)
+	_, err := storageClient.CreateSnapshots([]names.StorageTag{names.NewStorageTag("data/0")})
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+	_, err = storageClient.ListSnapshots()
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+}
@@ -242,6 +242,23 @@ func (u *Unit) Destroy() error {
 	return result.OneError()
 }
 
+// RecordHookRun records the execution of a hook or action by the unit.
+// It requires version 5 of the Uniter facade.
+func (u *Unit) RecordHookRun(run params.HookRun) error {
+	if u.st.BestAPIVersion() < 5 {
+		return errors.NotSupportedf("RecordHookRun on this controller")
+	}
+	var result params.ErrorResults
+	args := params.RecordHookRunArgs{
+		Args: []params.RecordHookRunArg{{Tag: u.tag.String(), Run: run}},
+	}
+	err := u.st.facade.FacadeCall("RecordHookRuns", args, &result)
+	if err != nil {
+		return err
+	}
+	return result.OneError()
+}
+
 // DestroyAllSubordinates destroys all subordinates of the unit.
 func (u *Unit) DestroyAllSubordinates() error {
 	var result params.ErrorResults
@@ -441,6 +458,29 @@ func (u *Unit) OpenPorts(protocol string, fromPort, toPort int) error {
 	return result.OneError()
 }
 
+// OpenEndpointPorts sets the policy of the port range with protocol
+// to be opened for the given endpoint only.
+func (u *Unit) OpenEndpointPorts(endpoint, protocol string, fromPort, toPort int) error {
+	if u.st.BestAPIVersion() < 5 {
+		return errors.NotSupportedf("opening ports for an endpoint")
+	}
+	var result params.ErrorResults
+	args := params.EntitiesPortRanges{
+		Entities: []params.EntityPortRange{{
+			Tag:      u.tag.String(),
+			Protocol: protocol,
+			FromPort: fromPort,
+			ToPort:   toPort,
+			Endpoint: endpoint,
+		}},
+	}
+	err := u.st.facade.FacadeCall("OpenPorts", args, &result)
+	if err != nil {
+		return err
+	}
+	return result.OneError()
+}
+
 // ClosePorts sets the policy of the port range with protocol to be
 // closed.
 func (u *Unit) ClosePorts(protocol string, fromPort, toPort int) error {
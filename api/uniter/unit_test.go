@@ -179,6 +179,26 @@ func (s *unitSuite) TestDestroy(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `unit "wordpress/0" not found`)
 }
 
+func (s *unitSuite) TestRecordHookRun(c *gc.C) {
+	started := time.Date(2016, 10, 1, 12, 0, 0, 0, time.UTC)
+	err := s.apiUnit.RecordHookRun(params.HookRun{
+		Kind:     params.HookRunKindAction,
+		Name:     "backup",
+		Started:  started,
+		Finished: started.Add(time.Minute),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	runs, err := s.wordpressUnit.HookRuns()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(runs, jc.DeepEquals, []state.HookRun{{
+		Kind:     "action",
+		Name:     "backup",
+		Started:  started,
+		Finished: started.Add(time.Minute),
+	}})
+}
+
 func (s *unitSuite) TestDestroyAllSubordinates(c *gc.C) {
 	c.Assert(s.wordpressUnit.Life(), gc.Equals, state.Alive)
 
@@ -401,6 +421,24 @@ func (s *unitSuite) TestOpenClosePortRanges(c *gc.C) {
 	c.Assert(ports, gc.HasLen, 0)
 }
 
+func (s *unitSuite) TestOpenEndpointPorts(c *gc.C) {
+	err := s.apiUnit.OpenEndpointPorts("url", "tcp", 80, 80)
+	c.Assert(err, jc.ErrorIsNil)
+
+	ports, err := s.wordpressMachine.OpenedPorts("")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ports.PortRangeEndpoints(), jc.DeepEquals, map[network.PortRange]string{
+		{Protocol: "tcp", FromPort: 80, ToPort: 80}: "url",
+	})
+
+	// Closing the range does not need the endpoint.
+	err = s.apiUnit.ClosePorts("tcp", 80, 80)
+	c.Assert(err, jc.ErrorIsNil)
+	opened, err := s.wordpressUnit.OpenedPorts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(opened, gc.HasLen, 0)
+}
+
 func (s *unitSuite) TestGetSetCharmURL(c *gc.C) {
 	// No charm URL set yet.
 	curl, ok := s.wordpressUnit.CharmURL()
@@ -16,8 +16,9 @@ import (
 	_ "github.com/juju/juju/apiserver/annotations" // ModelUser Write
 	_ "github.com/juju/juju/apiserver/application" // ModelUser Write
 	_ "github.com/juju/juju/apiserver/applicationscaler"
-	_ "github.com/juju/juju/apiserver/backups" // ModelUser Write
-	_ "github.com/juju/juju/apiserver/block"   // ModelUser Write
+	_ "github.com/juju/juju/apiserver/auditlog" // Controller superuser
+	_ "github.com/juju/juju/apiserver/backups"  // ModelUser Write
+	_ "github.com/juju/juju/apiserver/block"    // ModelUser Write
 	_ "github.com/juju/juju/apiserver/charmrevisionupdater"
 	_ "github.com/juju/juju/apiserver/charms" // ModelUser Write
 	_ "github.com/juju/juju/apiserver/cleaner"
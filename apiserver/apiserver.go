@@ -53,6 +53,7 @@ type Server struct {
 	authCtxt          *authContext
 	lastConnectionID  uint64
 	newObserver       observer.ObserverFactory
+	metricsHandler    http.Handler
 	connCount         int64
 }
 
@@ -76,6 +77,10 @@ type ServerConfig struct {
 	// notified of key events during API requests.
 	NewObserver observer.ObserverFactory
 
+	// MetricsHandler, if not nil, serves the controller's Prometheus
+	// metrics to authenticated clients at /metrics.
+	MetricsHandler http.Handler
+
 	// StatePool only exists to support testing.
 	StatePool *state.StatePool
 }
@@ -219,15 +224,16 @@ func newServer(s *state.State, lis *net.TCPListener, cfg ServerConfig) (_ *Serve
 	}
 
 	srv := &Server{
-		newObserver: cfg.NewObserver,
-		state:       s,
-		statePool:   stPool,
-		lis:         newChangeCertListener(lis, cfg.CertChanged, tlsConfig),
-		tag:         cfg.Tag,
-		dataDir:     cfg.DataDir,
-		logDir:      cfg.LogDir,
-		limiter:     utils.NewLimiter(loginRateLimit),
-		validator:   cfg.Validator,
+		newObserver:    cfg.NewObserver,
+		metricsHandler: cfg.MetricsHandler,
+		state:          s,
+		statePool:      stPool,
+		lis:            newChangeCertListener(lis, cfg.CertChanged, tlsConfig),
+		tag:            cfg.Tag,
+		dataDir:        cfg.DataDir,
+		logDir:         cfg.LogDir,
+		limiter:        utils.NewLimiter(loginRateLimit),
+		validator:      cfg.Validator,
 		adminAPIFactories: map[int]adminAPIFactory{
 			3: newAdminAPIV3,
 		},
@@ -398,6 +404,12 @@ func (srv *Server) endpoints() []apihttp.Endpoint {
 			srv.authCtxt.userAuth.CreateLocalLoginMacaroon,
 		},
 	)
+	if srv.metricsHandler != nil {
+		add("/metrics", &metricsHandler{
+			ctxt:    httpCtxt,
+			handler: srv.metricsHandler,
+		})
+	}
 	add("/api", mainAPIHandler)
 	// Serve the API at / (only) for backward compatiblity. Note that the
 	// pat muxer special-cases / so that it does not serve all
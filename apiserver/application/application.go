@@ -11,6 +11,7 @@ import (
 	"github.com/juju/loggo"
 	"gopkg.in/juju/charm.v6-unstable"
 	csparams "gopkg.in/juju/charmrepo.v2-unstable/csclient/params"
+	"gopkg.in/juju/names.v2"
 	goyaml "gopkg.in/yaml.v2"
 
 	"github.com/juju/juju/apiserver/common"
@@ -31,6 +32,7 @@ var (
 
 func init() {
 	common.RegisterStandardFacade("Application", 1, NewAPI)
+	common.RegisterStandardFacade("Application", 2, NewAPIV2)
 }
 
 // Application defines the methods on the application API end point.
@@ -64,6 +66,25 @@ func NewAPI(
 	}, nil
 }
 
+// APIV2 provides the Application API facade for version 2, which
+// adds UnitHookRuns and exposing endpoints to CIDRs.
+type APIV2 struct {
+	*API
+}
+
+// NewAPIV2 returns a new application API facade, version 2.
+func NewAPIV2(
+	st *state.State,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*APIV2, error) {
+	api, err := NewAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &APIV2{api}, nil
+}
+
 func (api *API) checkCanRead() error {
 	canRead, err := api.authorizer.HasPermission(description.ReadAccess, api.state.ModelTag())
 	if err != nil {
@@ -471,6 +492,20 @@ func (api *API) CharmRelations(p params.ApplicationCharmRelations) (params.Appli
 // Expose changes the juju-managed firewall to expose any ports that
 // were also explicitly marked by units as open.
 func (api *API) Expose(args params.ApplicationExpose) error {
+	if len(args.ExposedEndpoints) != 0 {
+		return errors.NotSupportedf("exposing endpoints to CIDRs in version 1 of the Application facade")
+	}
+	return api.expose(args)
+}
+
+// Expose changes the juju-managed firewall to expose any ports that
+// were also explicitly marked by units as open. Any given endpoints
+// are each exposed only to their associated CIDRs.
+func (api *APIV2) Expose(args params.ApplicationExpose) error {
+	return api.expose(args)
+}
+
+func (api *API) expose(args params.ApplicationExpose) error {
 	if err := api.checkCanWrite(); err != nil {
 		return err
 	}
@@ -481,7 +516,14 @@ func (api *API) Expose(args params.ApplicationExpose) error {
 	if err != nil {
 		return err
 	}
-	return svc.SetExposed()
+	if len(args.ExposedEndpoints) == 0 {
+		return svc.SetExposed()
+	}
+	endpoints := make(map[string]state.ExposedEndpoint, len(args.ExposedEndpoints))
+	for name, ep := range args.ExposedEndpoints {
+		endpoints[name] = state.ExposedEndpoint{ExposeToCIDRs: ep.ExposeToCIDRs}
+	}
+	return svc.MergeExposeSettings(endpoints)
 }
 
 // Unexpose changes the juju-managed firewall to unexpose any ports that
@@ -560,6 +602,53 @@ func (api *API) DestroyUnits(args params.DestroyApplicationUnits) error {
 	return common.DestroyErr("units", args.UnitNames, errs)
 }
 
+// UnitHookRuns returns the hooks and actions most recently run by
+// each given unit, most recent first.
+func (api *APIV2) UnitHookRuns(args params.Entities) (params.HookRunsResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.HookRunsResults{}, errors.Trace(err)
+	}
+	results := params.HookRunsResults{
+		Results: make([]params.HookRunsResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		runs, err := api.unitHookRuns(entity.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Runs = runs
+	}
+	return results, nil
+}
+
+func (api *API) unitHookRuns(tagString string) ([]params.HookRun, error) {
+	tag, err := names.ParseUnitTag(tagString)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	unit, err := api.state.Unit(tag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	runs, err := unit.HookRuns()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]params.HookRun, len(runs))
+	for i, run := range runs {
+		result[i] = params.HookRun{
+			Kind:     run.Kind,
+			Name:     run.Name,
+			Started:  run.Started,
+			Finished: run.Finished,
+			ExitCode: run.ExitCode,
+			Error:    run.Error,
+		}
+	}
+	return result, nil
+}
+
 // Destroy destroys a given application.
 func (api *API) Destroy(args params.ApplicationDestroy) error {
 	if err := api.checkCanWrite(); err != nil {
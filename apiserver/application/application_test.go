@@ -1818,7 +1818,7 @@ func (s *serviceSuite) TestServiceExpose(c *gc.C) {
 	c.Assert(svcs[1].IsExposed(), jc.IsTrue)
 	for i, t := range serviceExposeTests {
 		c.Logf("test %d. %s", i, t.about)
-		err = s.applicationAPI.Expose(params.ApplicationExpose{t.service})
+		err = s.applicationAPI.Expose(params.ApplicationExpose{ApplicationName: t.service})
 		if t.err != "" {
 			c.Assert(err, gc.ErrorMatches, t.err)
 		} else {
@@ -1830,6 +1830,49 @@ func (s *serviceSuite) TestServiceExpose(c *gc.C) {
 	}
 }
 
+func (s *serviceSuite) TestServiceExposeToCIDRs(c *gc.C) {
+	applicationAPIV2, err := application.NewAPIV2(s.State, nil, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	charm := s.AddTestingCharm(c, "dummy")
+	application := s.AddTestingService(c, "dummy-service", charm)
+	err = applicationAPIV2.Expose(params.ApplicationExpose{
+		ApplicationName: "dummy-service",
+		ExposedEndpoints: map[string]params.ExposedEndpoint{
+			"": {ExposeToCIDRs: []string{"10.0.0.0/8"}},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = application.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(application.IsExposed(), jc.IsTrue)
+	c.Assert(application.ExposedEndpoints(), jc.DeepEquals, map[string]state.ExposedEndpoint{
+		state.AllEndpoints: {ExposeToCIDRs: []string{"10.0.0.0/8"}},
+	})
+
+	err = applicationAPIV2.Expose(params.ApplicationExpose{
+		ApplicationName: "dummy-service",
+		ExposedEndpoints: map[string]params.ExposedEndpoint{
+			"": {ExposeToCIDRs: []string{"10.0.0.0"}},
+		},
+	})
+	c.Assert(err, gc.ErrorMatches, `CIDR "10.0.0.0" not valid`)
+}
+
+func (s *serviceSuite) TestServiceExposeToCIDRsV1(c *gc.C) {
+	charm := s.AddTestingCharm(c, "dummy")
+	application := s.AddTestingService(c, "dummy-service", charm)
+	err := s.applicationAPI.Expose(params.ApplicationExpose{
+		ApplicationName: "dummy-service",
+		ExposedEndpoints: map[string]params.ExposedEndpoint{
+			"": {ExposeToCIDRs: []string{"10.0.0.0/8"}},
+		},
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	err = application.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(application.IsExposed(), jc.IsFalse)
+}
+
 func (s *serviceSuite) setupServiceExpose(c *gc.C) {
 	charm := s.AddTestingCharm(c, "dummy")
 	serviceNames := []string{"dummy-service", "exposed-service"}
@@ -1870,7 +1913,7 @@ var serviceExposeTests = []struct {
 func (s *serviceSuite) assertServiceExpose(c *gc.C) {
 	for i, t := range serviceExposeTests {
 		c.Logf("test %d. %s", i, t.about)
-		err := s.applicationAPI.Expose(params.ApplicationExpose{t.service})
+		err := s.applicationAPI.Expose(params.ApplicationExpose{ApplicationName: t.service})
 		if t.err != "" {
 			c.Assert(err, gc.ErrorMatches, t.err)
 		} else {
@@ -1885,7 +1928,7 @@ func (s *serviceSuite) assertServiceExpose(c *gc.C) {
 func (s *serviceSuite) assertServiceExposeBlocked(c *gc.C, msg string) {
 	for i, t := range serviceExposeTests {
 		c.Logf("test %d. %s", i, t.about)
-		err := s.applicationAPI.Expose(params.ApplicationExpose{t.service})
+		err := s.applicationAPI.Expose(params.ApplicationExpose{ApplicationName: t.service})
 		s.AssertBlocked(c, err, msg)
 	}
 }
@@ -2084,6 +2127,40 @@ func (s *serviceSuite) TestDestroyPrincipalUnits(c *gc.C) {
 	s.assertDestroyPrincipalUnits(c, units)
 }
 
+func (s *serviceSuite) TestUnitHookRuns(c *gc.C) {
+	unit := s.Factory.MakeUnit(c, &factory.UnitParams{Application: s.application})
+	started := time.Date(2016, 10, 1, 12, 0, 0, 0, time.UTC)
+	err := unit.RecordHookRun(state.HookRun{
+		Kind:     "hook",
+		Name:     "install",
+		Started:  started,
+		Finished: started.Add(time.Second),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	applicationAPIV2, err := application.NewAPIV2(s.State, nil, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	results, err := applicationAPIV2.UnitHookRuns(params.Entities{
+		Entities: []params.Entity{
+			{Tag: unit.Tag().String()},
+			{Tag: "unit-foo-42"},
+			{Tag: "application-foo"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Assert(results.Results[0], jc.DeepEquals, params.HookRunsResult{
+		Runs: []params.HookRun{{
+			Kind:     "hook",
+			Name:     "install",
+			Started:  started,
+			Finished: started.Add(time.Second),
+		}},
+	})
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `unit "foo/42" not found`)
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, `"application-foo" is not a valid unit tag`)
+}
+
 func (s *serviceSuite) TestDestroySubordinateUnits(c *gc.C) {
 	wordpress := s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
 	wordpress0, err := wordpress.AddUnit()
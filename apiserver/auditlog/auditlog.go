@@ -0,0 +1,95 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package auditlog defines an API end point for reading the audit
+// entries recorded by the controller.
+package auditlog
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/audit"
+	"github.com/juju/juju/core/description"
+	"github.com/juju/juju/state"
+)
+
+func init() {
+	common.RegisterStandardFacade("AuditLog", 1, newFacade)
+}
+
+// API implements the AuditLog facade. It is only available to
+// controller administrators.
+type API struct {
+	backend Backend
+}
+
+func newFacade(st *state.State, _ facade.Resources, auth facade.Authorizer) (*API, error) {
+	return NewAPI(NewStateBackend(st), auth)
+}
+
+// NewAPI returns a new AuditLog API facade.
+func NewAPI(backend Backend, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	isAdmin, err := authorizer.HasPermission(description.SuperuserAccess, backend.ControllerTag())
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, errors.Trace(err)
+	}
+	if !isAdmin {
+		return nil, common.ErrPerm
+	}
+	return &API{backend: backend}, nil
+}
+
+// AuditEntries returns the audit entries that match the filter, most
+// recent first.
+func (api *API) AuditEntries(args params.AuditEntriesFilter) (params.AuditEntriesResult, error) {
+	var result params.AuditEntriesResult
+	filter := audit.Filter{
+		Operation: args.Operation,
+		Limit:     args.Limit,
+	}
+	if args.UserTag != "" {
+		tag, err := names.ParseUserTag(args.UserTag)
+		if err != nil {
+			return result, errors.Trace(err)
+		}
+		// Audit entries record the canonical tag of the
+		// authenticated user, e.g. "user-bob@local".
+		filter.OriginName = names.NewUserTag(tag.Canonical()).String()
+	}
+	if args.ModelTag != "" {
+		tag, err := names.ParseModelTag(args.ModelTag)
+		if err != nil {
+			return result, errors.Trace(err)
+		}
+		filter.ModelUUID = tag.Id()
+	}
+	if args.Since != nil {
+		filter.Since = *args.Since
+	}
+
+	entries, err := api.backend.AuditEntries(filter)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Entries = make([]params.AuditEntry, len(entries))
+	for i, entry := range entries {
+		result.Entries[i] = params.AuditEntry{
+			JujuServerVersion: entry.JujuServerVersion,
+			ModelTag:          names.NewModelTag(entry.ModelUUID).String(),
+			Timestamp:         entry.Timestamp,
+			RemoteAddress:     entry.RemoteAddress,
+			OriginType:        entry.OriginType,
+			OriginName:        entry.OriginName,
+			Operation:         entry.Operation,
+			Data:              entry.Data,
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,156 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/auditlog"
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/audit"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type auditLogSuite struct {
+	testing.IsolationSuite
+	backend    *mockBackend
+	authorizer apiservertesting.FakeAuthorizer
+}
+
+var _ = gc.Suite(&auditLogSuite{})
+
+func (s *auditLogSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("admin@local"),
+	}
+	s.backend = &mockBackend{
+		entries: []audit.AuditEntry{{
+			JujuServerVersion: version.MustParse("2.0.0"),
+			ModelUUID:         coretesting.ModelTag.Id(),
+			Timestamp:         time.Date(2016, 10, 1, 12, 0, 0, 0, time.UTC),
+			RemoteAddress:     "10.0.0.1",
+			OriginType:        "API request",
+			OriginName:        "user-bob@local",
+			Operation:         "Client:v1 - FullStatus",
+			Data:              map[string]interface{}{"request-body": "{}"},
+		}},
+	}
+}
+
+func (s *auditLogSuite) TestNewAPIRequiresClient(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := auditlog.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *auditLogSuite) TestNewAPIRequiresControllerAdmin(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("bob@local")
+	_, err := auditlog.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *auditLogSuite) TestAuditEntries(c *gc.C) {
+	api, err := auditlog.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	since := time.Date(2016, 9, 1, 0, 0, 0, 0, time.UTC)
+	result, err := api.AuditEntries(params.AuditEntriesFilter{
+		UserTag:   "user-bob@local",
+		ModelTag:  coretesting.ModelTag.String(),
+		Operation: "Client",
+		Since:     &since,
+		Limit:     10,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.backend.CheckCallNames(c, "ControllerTag", "AuditEntries")
+	s.backend.CheckCall(c, 1, "AuditEntries", audit.Filter{
+		OriginName: "user-bob@local",
+		ModelUUID:  coretesting.ModelTag.Id(),
+		Operation:  "Client",
+		Since:      since,
+		Limit:      10,
+	})
+	c.Assert(result, jc.DeepEquals, params.AuditEntriesResult{
+		Entries: []params.AuditEntry{{
+			JujuServerVersion: version.MustParse("2.0.0"),
+			ModelTag:          coretesting.ModelTag.String(),
+			Timestamp:         time.Date(2016, 10, 1, 12, 0, 0, 0, time.UTC),
+			RemoteAddress:     "10.0.0.1",
+			OriginType:        "API request",
+			OriginName:        "user-bob@local",
+			Operation:         "Client:v1 - FullStatus",
+			Data:              map[string]interface{}{"request-body": "{}"},
+		}},
+	})
+}
+
+func (s *auditLogSuite) TestAuditEntriesNoFilter(c *gc.C) {
+	api, err := auditlog.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := api.AuditEntries(params.AuditEntriesFilter{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.backend.CheckCall(c, 1, "AuditEntries", audit.Filter{})
+	c.Assert(result.Entries, gc.HasLen, 1)
+}
+
+func (s *auditLogSuite) TestAuditEntriesLocalUser(c *gc.C) {
+	api, err := auditlog.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = api.AuditEntries(params.AuditEntriesFilter{
+		UserTag: "user-bob",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.CheckCall(c, 1, "AuditEntries", audit.Filter{
+		OriginName: "user-bob@local",
+	})
+}
+
+func (s *auditLogSuite) TestAuditEntriesBadUserTag(c *gc.C) {
+	api, err := auditlog.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = api.AuditEntries(params.AuditEntriesFilter{
+		UserTag: "machine-0",
+	})
+	c.Assert(err, gc.ErrorMatches, `"machine-0" is not a valid user tag`)
+	s.backend.CheckCallNames(c, "ControllerTag")
+}
+
+func (s *auditLogSuite) TestAuditEntriesBackendError(c *gc.C) {
+	api, err := auditlog.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.SetErrors(errors.New("boom"))
+
+	_, err = api.AuditEntries(params.AuditEntriesFilter{})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type mockBackend struct {
+	testing.Stub
+	entries []audit.AuditEntry
+}
+
+func (b *mockBackend) AuditEntries(filter audit.Filter) ([]audit.AuditEntry, error) {
+	b.MethodCall(b, "AuditEntries", filter)
+	return b.entries, b.NextErr()
+}
+
+func (b *mockBackend) ControllerTag() names.ControllerTag {
+	b.MethodCall(b, "ControllerTag")
+	return names.NewControllerTag(coretesting.ModelTag.Id())
+}
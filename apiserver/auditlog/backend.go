@@ -0,0 +1,23 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog
+
+import (
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/audit"
+	"github.com/juju/juju/state"
+)
+
+// Backend defines the state functionality required by the AuditLog
+// facade.
+type Backend interface {
+	AuditEntries(audit.Filter) ([]audit.AuditEntry, error)
+	ControllerTag() names.ControllerTag
+}
+
+// NewStateBackend returns a Backend backed by the given state.
+func NewStateBackend(st *state.State) Backend {
+	return st
+}
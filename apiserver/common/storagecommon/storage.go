@@ -50,6 +50,14 @@ type StorageInterface interface {
 	// corresponding to the identfified machine and volume.
 	WatchVolumeAttachment(names.MachineTag, names.VolumeTag) state.NotifyWatcher
 
+	// WatchFilesystem watches for changes to the filesystem with the
+	// specified tag.
+	WatchFilesystem(names.FilesystemTag) state.NotifyWatcher
+
+	// WatchVolume watches for changes to the volume with the
+	// specified tag.
+	WatchVolume(names.VolumeTag) state.NotifyWatcher
+
 	// WatchBlockDevices watches for changes to block devices associated
 	// with the specified machine.
 	WatchBlockDevices(names.MachineTag) state.NotifyWatcher
@@ -133,6 +141,7 @@ func volumeStorageAttachmentInfo(
 	return &storage.StorageAttachmentInfo{
 		storage.StorageKindBlock,
 		devicePath,
+		volumeInfo.Size,
 	}, nil
 }
 
@@ -146,6 +155,10 @@ func filesystemStorageAttachmentInfo(
 	if err != nil {
 		return nil, errors.Annotate(err, "getting filesystem")
 	}
+	filesystemInfo, err := filesystem.Info()
+	if err != nil {
+		return nil, errors.Annotate(err, "getting filesystem info")
+	}
 	filesystemAttachment, err := st.FilesystemAttachment(machineTag, filesystem.FilesystemTag())
 	if err != nil {
 		return nil, errors.Annotate(err, "getting filesystem attachment")
@@ -157,6 +170,7 @@ func filesystemStorageAttachmentInfo(
 	return &storage.StorageAttachmentInfo{
 		storage.StorageKindFilesystem,
 		filesystemAttachmentInfo.MountPoint,
+		filesystemInfo.Size,
 	}, nil
 }
 
@@ -180,10 +194,12 @@ func WatchStorageAttachment(
 		if err != nil {
 			return nil, errors.Annotate(err, "getting storage volume")
 		}
-		// We need to watch both the volume attachment, and the
-		// machine's block devices. A volume attachment's block
-		// device could change (most likely, become present).
+		// We need to watch the volume, the volume attachment, and
+		// the machine's block devices. A volume attachment's block
+		// device could change (most likely, become present), and
+		// the volume's size changes when it is resized.
 		watchers = []state.NotifyWatcher{
+			st.WatchVolume(volume.VolumeTag()),
 			st.WatchVolumeAttachment(machineTag, volume.VolumeTag()),
 			// TODO(axw) 2015-09-30 #1501203
 			// We should filter the events to only those relevant
@@ -199,7 +215,10 @@ func WatchStorageAttachment(
 		if err != nil {
 			return nil, errors.Annotate(err, "getting storage filesystem")
 		}
+		// The filesystem is watched for changes in size,
+		// i.e. when it is grown after a resize.
 		watchers = []state.NotifyWatcher{
+			st.WatchFilesystem(filesystem.FilesystemTag()),
 			st.WatchFilesystemAttachment(machineTag, filesystem.FilesystemTag()),
 		}
 	default:
@@ -20,6 +20,7 @@ import (
 func init() {
 	// Version 0 is no longer supported.
 	common.RegisterStandardFacade("Firewaller", 3, NewFirewallerAPI)
+	common.RegisterStandardFacade("Firewaller", 4, NewFirewallerAPIV4)
 }
 
 // FirewallerAPI provides access to the Firewaller API facade.
@@ -197,6 +198,7 @@ func (f *FirewallerAPI) GetMachinePorts(args params.MachinePortsParams) (params.
 		}
 		if ports != nil {
 			portRangeMap := ports.AllPortRanges()
+			endpoints := ports.PortRangeEndpoints()
 			var portRanges []network.PortRange
 			for portRange := range portRangeMap {
 				portRanges = append(portRanges, portRange)
@@ -209,6 +211,7 @@ func (f *FirewallerAPI) GetMachinePorts(args params.MachinePortsParams) (params.
 					params.MachinePortRange{
 						UnitTag:   unitTag,
 						PortRange: params.FromNetworkPortRange(portRange),
+						Endpoint:  endpoints[portRange],
 					})
 			}
 		}
@@ -287,6 +290,58 @@ func (f *FirewallerAPI) GetExposed(args params.Entities) (params.BoolResults, er
 	return result, nil
 }
 
+// FirewallerAPIV4 provides access to version 4 of the Firewaller API
+// facade, which adds GetExposeInfo.
+type FirewallerAPIV4 struct {
+	*FirewallerAPI
+}
+
+// NewFirewallerAPIV4 creates a new server-side FirewallerAPIV4 facade.
+func NewFirewallerAPIV4(
+	st *state.State,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*FirewallerAPIV4, error) {
+	api, err := NewFirewallerAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &FirewallerAPIV4{api}, nil
+}
+
+// GetExposeInfo returns the exposed flag value and the per-endpoint
+// expose settings for each given application.
+func (f *FirewallerAPIV4) GetExposeInfo(args params.Entities) (params.ExposeInfoResults, error) {
+	result := params.ExposeInfoResults{
+		Results: make([]params.ExposeInfoResult, len(args.Entities)),
+	}
+	canAccess, err := f.accessService()
+	if err != nil {
+		return params.ExposeInfoResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseApplicationTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		service, err := f.getService(canAccess, tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result.Results[i].Exposed = service.IsExposed()
+		if endpoints := service.ExposedEndpoints(); len(endpoints) > 0 {
+			exposed := make(map[string]params.ExposedEndpoint, len(endpoints))
+			for name, ep := range endpoints {
+				exposed[name] = params.ExposedEndpoint{ExposeToCIDRs: ep.ExposeToCIDRs}
+			}
+			result.Results[i].ExposedEndpoints = exposed
+		}
+	}
+	return result, nil
+}
+
 // GetAssignedMachine returns the assigned machine tag (if any) for
 // each given unit.
 func (f *FirewallerAPI) GetAssignedMachine(args params.Entities) (params.StringResults, error) {
@@ -78,7 +78,8 @@ func (api *LoggerAPI) WatchLoggingConfig(arg params.Entities) params.NotifyWatch
 	return params.NotifyWatchResults{Results: result}
 }
 
-// LoggingConfig reports the logging configuration for the agents specified.
+// LoggingConfig reports the logging configuration for the agents specified,
+// taking into account any overrides for the agents' entities.
 func (api *LoggerAPI) LoggingConfig(arg params.Entities) params.StringResults {
 	if len(arg.Entities) == 0 {
 		return params.StringResults{}
@@ -94,7 +95,7 @@ func (api *LoggerAPI) LoggingConfig(arg params.Entities) params.StringResults {
 		err = common.ErrPerm
 		if api.authorizer.AuthOwner(tag) {
 			if configErr == nil {
-				results[i].Result = config.LoggingConfig()
+				results[i].Result = config.LoggingConfigFor(tag)
 				err = nil
 			} else {
 				err = configErr
@@ -144,3 +144,42 @@ func (s *loggerSuite) TestLoggingConfigForAgent(c *gc.C) {
 	c.Assert(result.Error, gc.IsNil)
 	c.Assert(result.Result, gc.Equals, newLoggingConfig)
 }
+
+func (s *loggerSuite) TestLoggingConfigForAgentWithOverride(c *gc.C) {
+	newLoggingConfig := "<root>=WARN;unit=INFO"
+	s.setLoggingConfig(c, newLoggingConfig)
+	overrides := s.rawMachine.Tag().String() + ":juju.worker=TRACE machine-12354:<root>=DEBUG"
+	err := s.State.UpdateModelConfig(map[string]interface{}{"logging-overrides": overrides}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: s.rawMachine.Tag().String()}},
+	}
+	results := s.logger.LoggingConfig(args)
+	c.Assert(results.Results, gc.HasLen, 1)
+	result := results.Results[0]
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.Result, gc.Equals, newLoggingConfig+";juju.worker=TRACE")
+}
+
+func (s *loggerSuite) TestLoggingConfigForUnitAgentWithOverrides(c *gc.C) {
+	newLoggingConfig := "<root>=WARN;unit=INFO"
+	s.setLoggingConfig(c, newLoggingConfig)
+	overrides := "application-germany:juju=DEBUG unit-germany-7:juju.worker.uniter=TRACE"
+	err := s.State.UpdateModelConfig(map[string]interface{}{"logging-overrides": overrides}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	unitTag := names.NewUnitTag("germany/7")
+	s.authorizer.Tag = unitTag
+	api, err := logger.NewLoggerAPI(s.State, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: unitTag.String()}},
+	}
+	results := api.LoggingConfig(args)
+	c.Assert(results.Results, gc.HasLen, 1)
+	result := results.Results[0]
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.Result, gc.Equals, newLoggingConfig+";juju=DEBUG;juju.worker.uniter=TRACE")
+}
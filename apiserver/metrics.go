@@ -0,0 +1,59 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"net/http"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/state"
+)
+
+// metricsHandler serves the controller's Prometheus metrics to
+// controller administrators and controller machine agents.
+type metricsHandler struct {
+	ctxt    httpContext
+	handler http.Handler
+}
+
+// ServeHTTP is part of the http.Handler interface.
+func (h *metricsHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != "GET" {
+		sendError(w, errors.MethodNotAllowedf("unsupported method: %q", req.Method))
+		return
+	}
+	st, entity, err := h.ctxt.stateForRequestAuthenticated(req)
+	if err != nil {
+		sendError(w, errors.Trace(err))
+		return
+	}
+	if err := checkMetricsAccess(st, entity); err != nil {
+		sendError(w, errors.Trace(err))
+		return
+	}
+	h.handler.ServeHTTP(w, req)
+}
+
+// checkMetricsAccess returns an error if the given entity may not
+// read the controller's metrics.
+func checkMetricsAccess(st *state.State, entity state.Entity) error {
+	switch tag := entity.Tag().(type) {
+	case names.UserTag:
+		isAdmin, err := st.IsControllerAdmin(tag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if isAdmin {
+			return nil
+		}
+	case names.MachineTag:
+		if machine, ok := entity.(*state.Machine); ok && machine.IsManager() {
+			return nil
+		}
+	}
+	return common.ErrPerm
+}
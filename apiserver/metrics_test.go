@@ -0,0 +1,95 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver_test
+
+import (
+	"encoding/json"
+	"net/http"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/provider/dummy"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type metricsSuite struct {
+	authHttpSuite
+}
+
+var _ = gc.Suite(&metricsSuite{})
+
+func (s *metricsSuite) metricsURL(c *gc.C) string {
+	u := s.baseURL(c)
+	u.Path = "/metrics"
+	return u.String()
+}
+
+func (s *metricsSuite) assertError(c *gc.C, resp *http.Response, status int, msg string) {
+	body := assertResponse(c, resp, status, params.ContentTypeJSON)
+	var jsonResp params.ErrorResult
+	err := json.Unmarshal(body, &jsonResp)
+	c.Assert(err, jc.ErrorIsNil, gc.Commentf("body: %s", body))
+	c.Assert(jsonResp.Error.Message, gc.Matches, msg)
+}
+
+func (s *metricsSuite) TestRequiresAuth(c *gc.C) {
+	resp := s.sendRequest(c, httpRequestParams{method: "GET", url: s.metricsURL(c)})
+	s.assertError(c, resp, http.StatusUnauthorized, "no credentials provided")
+}
+
+func (s *metricsSuite) TestRequiresGET(c *gc.C) {
+	resp := s.authRequest(c, httpRequestParams{method: "PUT", url: s.metricsURL(c)})
+	s.assertError(c, resp, http.StatusMethodNotAllowed, `unsupported method: "PUT"`)
+}
+
+func (s *metricsSuite) TestRequiresControllerAdmin(c *gc.C) {
+	resp := s.authRequest(c, httpRequestParams{method: "GET", url: s.metricsURL(c)})
+	s.assertError(c, resp, http.StatusUnauthorized, "permission denied")
+}
+
+func (s *metricsSuite) TestControllerAdmin(c *gc.C) {
+	resp := s.sendRequest(c, httpRequestParams{
+		method:   "GET",
+		url:      s.metricsURL(c),
+		tag:      s.AdminUserTag(c).String(),
+		password: "dummy-secret",
+	})
+	body := assertResponse(c, resp, http.StatusOK, "text/plain; charset=utf-8")
+	c.Assert(string(body), gc.Equals, dummy.DummyMetrics+"\n")
+}
+
+func (s *metricsSuite) TestControllerMachine(c *gc.C) {
+	const nonce = "noncey"
+	m, password := s.Factory.MakeMachineReturningPassword(c, &factory.MachineParams{
+		Jobs:  []state.MachineJob{state.JobManageModel},
+		Nonce: nonce,
+	})
+	resp := s.sendRequest(c, httpRequestParams{
+		method:   "GET",
+		url:      s.metricsURL(c),
+		tag:      m.Tag().String(),
+		password: password,
+		nonce:    nonce,
+	})
+	body := assertResponse(c, resp, http.StatusOK, "text/plain; charset=utf-8")
+	c.Assert(string(body), gc.Equals, dummy.DummyMetrics+"\n")
+}
+
+func (s *metricsSuite) TestHostMachine(c *gc.C) {
+	const nonce = "noncey"
+	m, password := s.Factory.MakeMachineReturningPassword(c, &factory.MachineParams{
+		Nonce: nonce,
+	})
+	resp := s.sendRequest(c, httpRequestParams{
+		method:   "GET",
+		url:      s.metricsURL(c),
+		tag:      m.Tag().String(),
+		password: password,
+		nonce:    nonce,
+	})
+	s.assertError(c, resp, http.StatusUnauthorized, "permission denied")
+}
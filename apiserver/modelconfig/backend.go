@@ -19,6 +19,8 @@ type Backend interface {
 	ModelConfigDefaultValues() (config.ConfigValues, error)
 	UpdateModelConfigDefaultValues(map[string]interface{}, []string) error
 	UpdateModelConfig(map[string]interface{}, []string, state.ValidateConfigFunc) error
+	ModelLogsSize() (int64, error)
+	SetLoggingOverride(names.Tag, string) error
 }
 
 type stateShim struct {
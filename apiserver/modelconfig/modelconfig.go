@@ -5,6 +5,7 @@ package modelconfig
 
 import (
 	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facade"
@@ -16,12 +17,22 @@ import (
 
 func init() {
 	common.RegisterStandardFacade("ModelConfig", 1, newFacade)
+	common.RegisterStandardFacade("ModelConfig", 2, newFacadeV2)
 }
 
+// logsSizeKey is the name of the read-only attribute, reported by
+// get-model-config, that holds the current size of the model's log
+// records. It may not be set.
+const logsSizeKey = "current-logs-size"
+
 func newFacade(st *state.State, _ facade.Resources, auth facade.Authorizer) (*ModelConfigAPI, error) {
 	return NewModelConfigAPI(NewStateBackend(st), auth)
 }
 
+func newFacadeV2(st *state.State, _ facade.Resources, auth facade.Authorizer) (*ModelConfigAPIV2, error) {
+	return NewModelConfigAPIV2(NewStateBackend(st), auth)
+}
+
 // ModelConfigAPI is the endpoint which implements the model config facade.
 type ModelConfigAPI struct {
 	backend Backend
@@ -42,6 +53,22 @@ func NewModelConfigAPI(backend Backend, authorizer facade.Authorizer) (*ModelCon
 	return client, nil
 }
 
+// ModelConfigAPIV2 implements version 2 of the model config facade,
+// which adds ModelLogsSize and SetLoggingOverrides.
+type ModelConfigAPIV2 struct {
+	*ModelConfigAPI
+}
+
+// NewModelConfigAPIV2 creates a new instance of version 2 of the
+// ModelConfig Facade.
+func NewModelConfigAPIV2(backend Backend, authorizer facade.Authorizer) (*ModelConfigAPIV2, error) {
+	api, err := NewModelConfigAPI(backend, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &ModelConfigAPIV2{api}, nil
+}
+
 func (c *ModelConfigAPI) checkCanWrite() error {
 	canWrite, err := c.auth.HasPermission(description.WriteAccess, c.backend.ModelTag())
 	if err != nil {
@@ -82,6 +109,22 @@ func (c *ModelConfigAPI) ModelGet() (params.ModelConfigResults, error) {
 	return result, nil
 }
 
+// ModelLogsSize returns the approximate size of the model's logs, which
+// get-model-config reports alongside the max-logs-age and max-logs-size
+// limits on them.
+func (c *ModelConfigAPIV2) ModelLogsSize() (params.ModelLogsSizeResult, error) {
+	result := params.ModelLogsSizeResult{}
+	if err := c.checkCanWrite(); err != nil {
+		return result, errors.Trace(err)
+	}
+	size, err := c.backend.ModelLogsSize()
+	if err != nil {
+		return result, errors.Annotate(err, "getting model logs size")
+	}
+	result.Size = size
+	return result, nil
+}
+
 // ModelSet implements the server-side part of the
 // set-model-config CLI command.
 func (c *ModelConfigAPI) ModelSet(args params.ModelSet) error {
@@ -92,6 +135,9 @@ func (c *ModelConfigAPI) ModelSet(args params.ModelSet) error {
 	if err := c.check.ChangeAllowed(); err != nil {
 		return errors.Trace(err)
 	}
+	if _, found := args.Config[logsSizeKey]; found {
+		return errors.Errorf("%s cannot be set", logsSizeKey)
+	}
 	// Make sure we don't allow changing agent-version.
 	checkAgentVersion := func(updateAttrs map[string]interface{}, removeAttrs []string, oldConfig *config.Config) error {
 		if v, found := updateAttrs["agent-version"]; found {
@@ -119,6 +165,31 @@ func (c *ModelConfigAPI) ModelUnset(args params.ModelUnset) error {
 	return c.backend.UpdateModelConfig(nil, args.Keys, nil)
 }
 
+// SetLoggingOverrides implements the server-side part of the
+// set-logging CLI command. Each override is changed on its own, so
+// concurrent changes to other entities' overrides are kept.
+func (c *ModelConfigAPIV2) SetLoggingOverrides(args params.LoggingOverrides) (params.ErrorResults, error) {
+	results := params.ErrorResults{Results: make([]params.ErrorResult, len(args.Overrides))}
+	if err := c.checkCanWrite(); err != nil {
+		return results, err
+	}
+	if err := c.check.ChangeAllowed(); err != nil {
+		return results, errors.Trace(err)
+	}
+	for i, arg := range args.Overrides {
+		results.Results[i].Error = common.ServerError(c.setLoggingOverride(arg))
+	}
+	return results, nil
+}
+
+func (c *ModelConfigAPIV2) setLoggingOverride(arg params.LoggingOverride) error {
+	tag, err := names.ParseTag(arg.Tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return c.backend.SetLoggingOverride(tag, arg.Config)
+}
+
 // ModelDefaults returns the default config values used when creating a new model.
 func (c *ModelConfigAPI) ModelDefaults() (params.ModelConfigResults, error) {
 	result := params.ModelConfigResults{}
@@ -25,6 +25,7 @@ type modelconfigSuite struct {
 	backend    *mockBackend
 	authorizer apiservertesting.FakeAuthorizer
 	api        *modelconfig.ModelConfigAPI
+	apiv2      *modelconfig.ModelConfigAPIV2
 }
 
 var _ = gc.Suite(&modelconfigSuite{})
@@ -46,10 +47,13 @@ func (s *modelconfigSuite) SetUpTest(c *gc.C) {
 			"attr":  {Value: "val", Source: "controller"},
 			"attr2": {Value: "val2", Source: "controller"},
 		},
+		logsSize: 1536,
 	}
 	var err error
 	s.api, err = modelconfig.NewModelConfigAPI(s.backend, &s.authorizer)
 	c.Assert(err, jc.ErrorIsNil)
+	s.apiv2, err = modelconfig.NewModelConfigAPIV2(s.backend, &s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
 }
 
 func (s *modelconfigSuite) TestModelGet(c *gc.C) {
@@ -62,6 +66,25 @@ func (s *modelconfigSuite) TestModelGet(c *gc.C) {
 	})
 }
 
+func (s *modelconfigSuite) TestModelGetIgnoresLogsSizeError(c *gc.C) {
+	s.backend.logsSizeErr = errors.New("boom")
+	result, err := s.api.ModelGet()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Config, gc.HasLen, 3)
+}
+
+func (s *modelconfigSuite) TestModelLogsSize(c *gc.C) {
+	result, err := s.apiv2.ModelLogsSize()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ModelLogsSizeResult{Size: 1536})
+}
+
+func (s *modelconfigSuite) TestModelLogsSizeError(c *gc.C) {
+	s.backend.logsSizeErr = errors.New("boom")
+	_, err := s.apiv2.ModelLogsSize()
+	c.Assert(err, gc.ErrorMatches, "getting model logs size: boom")
+}
+
 func (s *modelconfigSuite) assertConfigValue(c *gc.C, key string, expected interface{}) {
 	value, found := s.backend.cfg[key]
 	c.Assert(found, jc.IsTrue)
@@ -130,6 +153,32 @@ func (s *modelconfigSuite) TestModelSetCannotChangeAgentVersion(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *modelconfigSuite) TestModelSetCannotChangeLogsSize(c *gc.C) {
+	args := params.ModelSet{
+		map[string]interface{}{"current-logs-size": "0 B"},
+	}
+	err := s.api.ModelSet(args)
+	c.Assert(err, gc.ErrorMatches, "current-logs-size cannot be set")
+}
+
+func (s *modelconfigSuite) TestModelSetRoundTrip(c *gc.C) {
+	old, err := config.New(config.UseDefaults, dummy.SampleConfig().Merge(testing.Attrs{
+		"agent-version": "1.2.3.4",
+	}))
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.old = old
+
+	// The config returned by ModelGet can be passed back to ModelSet.
+	result, err := s.api.ModelGet()
+	c.Assert(err, jc.ErrorIsNil)
+	args := params.ModelSet{Config: make(map[string]interface{})}
+	for attr, val := range result.Config {
+		args.Config[attr] = val.Value
+	}
+	err = s.api.ModelSet(args)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *modelconfigSuite) TestModelUnset(c *gc.C) {
 	err := s.backend.UpdateModelConfig(map[string]interface{}{"abc": 123}, nil, nil)
 	c.Assert(err, jc.ErrorIsNil)
@@ -225,12 +274,45 @@ func (s *modelconfigSuite) TestUnsetModelDefaultsMissing(c *gc.C) {
 	c.Assert(result.OneError(), jc.ErrorIsNil)
 }
 
+func (s *modelconfigSuite) TestSetLoggingOverrides(c *gc.C) {
+	s.backend.overrides = map[string]string{"machine-0": "<root>=DEBUG"}
+	result, err := s.apiv2.SetLoggingOverrides(params.LoggingOverrides{
+		Overrides: []params.LoggingOverride{
+			{Tag: "unit-mysql-0", Config: "juju=TRACE"},
+			{Tag: "machine-0"},
+			{Tag: "application-wordpress"},
+			{Tag: "bad-tag", Config: "juju=TRACE"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 4)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[1].Error, gc.IsNil)
+	c.Assert(result.Results[2].Error, jc.Satisfies, params.IsCodeNotFound)
+	c.Assert(result.Results[3].Error, gc.ErrorMatches, `"bad-tag" is not a valid tag`)
+	c.Assert(s.backend.overrides, jc.DeepEquals, map[string]string{
+		"unit-mysql-0": "juju=TRACE",
+	})
+}
+
+func (s *modelconfigSuite) TestBlockSetLoggingOverrides(c *gc.C) {
+	s.blockAllChanges(c, "TestBlockSetLoggingOverrides")
+	_, err := s.apiv2.SetLoggingOverrides(params.LoggingOverrides{
+		Overrides: []params.LoggingOverride{{Tag: "unit-mysql-0", Config: "juju=TRACE"}},
+	})
+	s.assertBlocked(c, err, "TestBlockSetLoggingOverrides")
+	c.Assert(s.backend.overrides, gc.HasLen, 0)
+}
+
 type mockBackend struct {
 	cfg         config.ConfigValues
 	cfgDefaults config.ConfigValues
 	old         *config.Config
 	b           state.BlockType
 	msg         string
+	logsSize    int64
+	logsSizeErr error
+	overrides   map[string]string
 }
 
 func (m *mockBackend) ModelConfigValues() (config.ConfigValues, error) {
@@ -275,6 +357,25 @@ func (m *mockBackend) GetBlockForType(t state.BlockType) (state.Block, bool, err
 	}
 }
 
+func (m *mockBackend) ModelLogsSize() (int64, error) {
+	return m.logsSize, m.logsSizeErr
+}
+
+func (m *mockBackend) SetLoggingOverride(tag names.Tag, loggingConfig string) error {
+	if loggingConfig == "" {
+		if _, ok := m.overrides[tag.String()]; !ok {
+			return errors.NotFoundf("logging override for %s", names.ReadableString(tag))
+		}
+		delete(m.overrides, tag.String())
+		return nil
+	}
+	if m.overrides == nil {
+		m.overrides = make(map[string]string)
+	}
+	m.overrides[tag.String()] = loggingConfig
+	return nil
+}
+
 func (m *mockBackend) ModelTag() names.ModelTag {
 	return names.NewModelTag("deadbeef-2f18-4fd2-967d-db9663db7bea")
 }
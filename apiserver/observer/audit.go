@@ -8,11 +8,13 @@ import (
 	"time"
 
 	"github.com/juju/errors"
+	"github.com/juju/utils/set"
 	"github.com/juju/version"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/audit"
 	"github.com/juju/juju/rpc"
+	"github.com/juju/juju/tracing"
 )
 
 // Context defines things an Audit observer need know about to operate
@@ -25,6 +27,10 @@ type AuditContext struct {
 	// ModelUUID is the UUID of the model the audit observer is
 	// currently running on.
 	ModelUUID string
+
+	// ExcludeMethods holds the API methods, in the form
+	// Facade.Method or Facade.*, whose calls will not be audited.
+	ExcludeMethods []string
 }
 
 type ErrorHandler func(error)
@@ -34,6 +40,7 @@ func NewAudit(ctx *AuditContext, handleAuditEntry audit.AuditEntrySinkFn, errorH
 	return &Audit{
 		jujuServerVersion: ctx.JujuServerVersion,
 		modelUUID:         ctx.ModelUUID,
+		excludeMethods:    set.NewStrings(ctx.ExcludeMethods...),
 		errorHandler:      errorHandler,
 		handleAuditEntry:  handleAuditEntry,
 	}
@@ -44,6 +51,7 @@ func NewAudit(ctx *AuditContext, handleAuditEntry audit.AuditEntrySinkFn, errorH
 type Audit struct {
 	jujuServerVersion version.Number
 	modelUUID         string
+	excludeMethods    set.Strings
 	errorHandler      ErrorHandler
 	handleAuditEntry  audit.AuditEntrySinkFn
 
@@ -79,6 +87,7 @@ func (a *Audit) RPCObserver() rpc.Observer {
 	return &AuditRPCObserver{
 		jujuServerVersion: a.jujuServerVersion,
 		modelUUID:         a.modelUUID,
+		excludeMethods:    a.excludeMethods,
 		errorHandler:      a.errorHandler,
 		handleAuditEntry:  a.handleAuditEntry,
 		authenticatedTag:  a.state.authenticatedTag,
@@ -91,6 +100,7 @@ func (a *Audit) RPCObserver() rpc.Observer {
 type AuditRPCObserver struct {
 	jujuServerVersion version.Number
 	modelUUID         string
+	excludeMethods    set.Strings
 	errorHandler      ErrorHandler
 	handleAuditEntry  audit.AuditEntrySinkFn
 	authenticatedTag  string
@@ -99,12 +109,33 @@ type AuditRPCObserver struct {
 
 // ServerRequest implements Observer.
 func (a *AuditRPCObserver) ServerRequest(hdr *rpc.Header, body interface{}) {
+	req := hdr.Request
+	if a.excluded(req) {
+		return
+	}
 	auditEntry := a.boilerplateAuditEntry()
 	auditEntry.OriginName = a.authenticatedTag
 
 	auditEntry.OriginType = "API request"
-	auditEntry.Operation = rpcRequestToOperation(hdr.Request)
-	auditEntry.Data = map[string]interface{}{"request-body": body}
+	auditEntry.Operation = rpcRequestToOperation(req)
+	auditEntry.Data = map[string]interface{}{
+		"facade":  req.Type,
+		"version": req.Version,
+		"method":  req.Action,
+	}
+	if traceID := tracing.TraceIDFromHeaders(hdr.TraceContext); traceID != "" {
+		auditEntry.Data["trace-id"] = traceID
+	}
+	if body != nil {
+		// The request body is recorded as JSON, with secrets
+		// removed. If it can't be redacted, it isn't recorded.
+		requestBody, err := audit.RedactedJSON(body)
+		if err != nil {
+			a.errorHandler(errors.Annotate(err, "cannot record request body"))
+		} else {
+			auditEntry.Data["request-body"] = requestBody
+		}
+	}
 	err := a.handleAuditEntry(auditEntry)
 	if err != nil {
 		a.errorHandler(errors.Trace(err))
@@ -114,6 +145,13 @@ func (a *AuditRPCObserver) ServerRequest(hdr *rpc.Header, body interface{}) {
 // ServerReply implements Observer.
 func (a *AuditRPCObserver) ServerReply(rpc.Request, *rpc.Header, interface{}) {}
 
+// excluded reports whether calls to the given request's method
+// should not be audited.
+func (a *AuditRPCObserver) excluded(req rpc.Request) bool {
+	return a.excludeMethods.Contains(req.Type+"."+req.Action) ||
+		a.excludeMethods.Contains(req.Type+".*")
+}
+
 func (a *AuditRPCObserver) boilerplateAuditEntry() audit.AuditEntry {
 	return audit.AuditEntry{
 		JujuServerVersion: a.jujuServerVersion,
@@ -0,0 +1,157 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package observer_test
+
+import (
+	"net/http"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/observer"
+	"github.com/juju/juju/audit"
+	"github.com/juju/juju/rpc"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type auditSuite struct {
+	testing.IsolationSuite
+	entries []audit.AuditEntry
+	errors  []error
+}
+
+var _ = gc.Suite(&auditSuite{})
+
+func (s *auditSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.entries = nil
+	s.errors = nil
+}
+
+func (s *auditSuite) newRPCObserver(c *gc.C, excludeMethods ...string) rpc.Observer {
+	ctx := &observer.AuditContext{
+		JujuServerVersion: version.MustParse("2.0.0"),
+		ModelUUID:         coretesting.ModelTag.Id(),
+		ExcludeMethods:    excludeMethods,
+	}
+	handleEntry := func(entry audit.AuditEntry) error {
+		s.entries = append(s.entries, entry)
+		return nil
+	}
+	handleError := func(err error) {
+		s.errors = append(s.errors, err)
+	}
+	o := observer.NewAudit(ctx, handleEntry, handleError)
+	o.Join(&http.Request{RemoteAddr: "10.0.0.1:1234"}, 1)
+	o.Login(names.NewUserTag("bob@local"), coretesting.ModelTag, false, "user-bob@local")
+	return o.RPCObserver()
+}
+
+func request(facade string, version int, method string) *rpc.Header {
+	return &rpc.Header{
+		Request: rpc.Request{
+			Type:    facade,
+			Version: version,
+			Action:  method,
+		},
+	}
+}
+
+type setArgs struct {
+	Application string            `json:"application"`
+	Options     map[string]string `json:"options"`
+}
+
+func (s *auditSuite) TestServerRequestRecordsCall(c *gc.C) {
+	o := s.newRPCObserver(c)
+	o.ServerRequest(request("Application", 1, "Set"), setArgs{
+		Application: "mysql",
+		Options: map[string]string{
+			"admin-password": "hunter2",
+			"port":           "3306",
+		},
+	})
+
+	c.Assert(s.errors, gc.HasLen, 0)
+	c.Assert(s.entries, gc.HasLen, 1)
+	entry := s.entries[0]
+	c.Check(entry.OriginName, gc.Equals, "user-bob@local")
+	c.Check(entry.OriginType, gc.Equals, "API request")
+	c.Check(entry.RemoteAddress, gc.Equals, "10.0.0.1:1234")
+	c.Check(entry.Operation, gc.Equals, "Application:v1 - Set")
+	c.Check(entry.Data, jc.DeepEquals, map[string]interface{}{
+		"facade":       "Application",
+		"version":      1,
+		"method":       "Set",
+		"request-body": `{"application":"mysql","options":{"admin-password":"(redacted)","port":"3306"}}`,
+	})
+}
+
+func (s *auditSuite) TestServerRequestNoBody(c *gc.C) {
+	o := s.newRPCObserver(c)
+	o.ServerRequest(request("Application", 1, "Unknown"), nil)
+
+	c.Assert(s.entries, gc.HasLen, 1)
+	c.Check(s.entries[0].Data, jc.DeepEquals, map[string]interface{}{
+		"facade":  "Application",
+		"version": 1,
+		"method":  "Unknown",
+	})
+}
+
+func (s *auditSuite) TestServerRequestRecordsTraceID(c *gc.C) {
+	o := s.newRPCObserver(c)
+	hdr := request("Application", 1, "Unknown")
+	hdr.TraceContext = map[string]string{
+		"X-B3-TraceId": "00000000000004d2",
+		"X-B3-SpanId":  "000000000000162e",
+	}
+	o.ServerRequest(hdr, nil)
+
+	c.Assert(s.entries, gc.HasLen, 1)
+	c.Check(s.entries[0].Data["trace-id"], gc.Equals, "00000000000004d2")
+}
+
+func (s *auditSuite) TestServerRequestUnencodableBody(c *gc.C) {
+	o := s.newRPCObserver(c)
+	o.ServerRequest(request("Application", 1, "Set"), make(chan int))
+
+	c.Assert(s.entries, gc.HasLen, 1)
+	_, ok := s.entries[0].Data["request-body"]
+	c.Check(ok, jc.IsFalse)
+	c.Assert(s.errors, gc.HasLen, 1)
+	c.Check(s.errors[0], gc.ErrorMatches, "cannot record request body: .*")
+}
+
+func (s *auditSuite) TestServerRequestExcludedMethods(c *gc.C) {
+	o := s.newRPCObserver(c, "Client.FullStatus", "Pinger.*")
+	o.ServerRequest(request("Client", 1, "FullStatus"), struct{}{})
+	o.ServerRequest(request("Pinger", 1, "Ping"), struct{}{})
+	o.ServerRequest(request("Client", 1, "AddMachines"), struct{}{})
+
+	c.Assert(s.entries, gc.HasLen, 1)
+	c.Check(s.entries[0].Operation, gc.Equals, "Client:v1 - AddMachines")
+}
+
+func (s *auditSuite) TestServerRequestSinkError(c *gc.C) {
+	ctx := &observer.AuditContext{
+		JujuServerVersion: version.MustParse("2.0.0"),
+		ModelUUID:         coretesting.ModelTag.Id(),
+	}
+	handleEntry := func(audit.AuditEntry) error {
+		return errors.New("boom")
+	}
+	handleError := func(err error) {
+		s.errors = append(s.errors, err)
+	}
+	o := observer.NewAudit(ctx, handleEntry, handleError).RPCObserver()
+	o.ServerRequest(request("Client", 1, "AddMachines"), struct{}{})
+
+	c.Assert(s.errors, gc.HasLen, 1)
+	c.Check(s.errors[0], gc.ErrorMatches, "boom")
+}
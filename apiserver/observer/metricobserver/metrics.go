@@ -0,0 +1,63 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package metricobserver
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	apiMetricsNamespace = "juju"
+	apiMetricsSubsystem = "api"
+)
+
+// Metrics holds the Prometheus metrics recorded by Observers: the
+// number of active API connections, and the count and latency of
+// API requests, by facade and method.
+//
+// Metrics implements prometheus.Collector, so that it can be
+// registered with a Prometheus registry.
+type Metrics struct {
+	connections prometheus.Gauge
+	requests    *prometheus.CounterVec
+	latency     *prometheus.SummaryVec
+}
+
+// NewMetrics returns a new, unregistered, Metrics.
+func NewMetrics() *Metrics {
+	return &Metrics{
+		connections: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: apiMetricsNamespace,
+			Subsystem: apiMetricsSubsystem,
+			Name:      "connections",
+			Help:      "Number of active API connections.",
+		}),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: apiMetricsNamespace,
+			Subsystem: apiMetricsSubsystem,
+			Name:      "requests_total",
+			Help:      "Number of API requests served.",
+		}, []string{"facade", "version", "method", "error_code"}),
+		latency: prometheus.NewSummaryVec(prometheus.SummaryOpts{
+			Namespace: apiMetricsNamespace,
+			Subsystem: apiMetricsSubsystem,
+			Name:      "request_duration_seconds",
+			Help:      "Latency of API requests, in seconds.",
+		}, []string{"facade", "version", "method"}),
+	}
+}
+
+// Describe is part of the prometheus.Collector interface.
+func (m *Metrics) Describe(ch chan<- *prometheus.Desc) {
+	m.connections.Describe(ch)
+	m.requests.Describe(ch)
+	m.latency.Describe(ch)
+}
+
+// Collect is part of the prometheus.Collector interface.
+func (m *Metrics) Collect(ch chan<- prometheus.Metric) {
+	m.connections.Collect(ch)
+	m.requests.Collect(ch)
+	m.latency.Collect(ch)
+}
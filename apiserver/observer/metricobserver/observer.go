@@ -0,0 +1,106 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package metricobserver provides an implementation of
+// apiserver/observer.Observer that records Prometheus metrics
+// for API connections and requests.
+package metricobserver
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/observer"
+	"github.com/juju/juju/rpc"
+)
+
+// Config contains the configuration for Observers created by the
+// factory returned from NewObserverFactory.
+type Config struct {
+	// Clock is the clock used to time API requests.
+	Clock clock.Clock
+
+	// Metrics holds the metrics that the observers update.
+	Metrics *Metrics
+}
+
+// Validate returns an error if any field is invalid.
+func (config Config) Validate() error {
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Metrics == nil {
+		return errors.NotValidf("nil Metrics")
+	}
+	return nil
+}
+
+// NewObserverFactory returns a function that creates Observers that
+// record API metrics as configured.
+func NewObserverFactory(config Config) (observer.ObserverFactory, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Annotate(err, "validating config")
+	}
+	return func() observer.Observer {
+		return &Observer{
+			clock:   config.Clock,
+			metrics: config.Metrics,
+		}
+	}, nil
+}
+
+// Observer is an observer.Observer implementation that records
+// metrics for a single API connection.
+type Observer struct {
+	clock   clock.Clock
+	metrics *Metrics
+}
+
+// Login is part of the observer.Observer interface.
+func (*Observer) Login(names.Tag, names.ModelTag, bool, string) {}
+
+// Join is part of the observer.Observer interface.
+func (o *Observer) Join(*http.Request, uint64) {
+	o.metrics.connections.Inc()
+}
+
+// Leave is part of the observer.Observer interface.
+func (o *Observer) Leave() {
+	o.metrics.connections.Dec()
+}
+
+// RPCObserver is part of the observer.Observer interface.
+func (o *Observer) RPCObserver() rpc.Observer {
+	return &rpcObserver{
+		clock:   o.clock,
+		metrics: o.metrics,
+	}
+}
+
+// rpcObserver records the metrics for a single API request.
+type rpcObserver struct {
+	clock        clock.Clock
+	metrics      *Metrics
+	requestStart time.Time
+}
+
+// ServerRequest is part of the rpc.Observer interface.
+func (o *rpcObserver) ServerRequest(*rpc.Header, interface{}) {
+	o.requestStart = o.clock.Now()
+}
+
+// ServerReply is part of the rpc.Observer interface.
+func (o *rpcObserver) ServerReply(req rpc.Request, hdr *rpc.Header, _ interface{}) {
+	version := strconv.Itoa(req.Version)
+	o.metrics.requests.WithLabelValues(
+		req.Type, version, req.Action, hdr.ErrorCode,
+	).Inc()
+	o.metrics.latency.WithLabelValues(
+		req.Type, version, req.Action,
+	).Observe(o.clock.Now().Sub(o.requestStart).Seconds())
+}
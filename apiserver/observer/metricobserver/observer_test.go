@@ -0,0 +1,103 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package metricobserver
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/rpc"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type observerSuite struct {
+	testing.IsolationSuite
+
+	clock   *coretesting.Clock
+	metrics *Metrics
+}
+
+var _ = gc.Suite(&observerSuite{})
+
+func (s *observerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = coretesting.NewClock(time.Now())
+	s.metrics = NewMetrics()
+}
+
+func (s *observerSuite) newObserver(c *gc.C) *Observer {
+	factory, err := NewObserverFactory(Config{
+		Clock:   s.clock,
+		Metrics: s.metrics,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	o, ok := factory().(*Observer)
+	c.Assert(ok, jc.IsTrue)
+	return o
+}
+
+func (s *observerSuite) TestValidateConfig(c *gc.C) {
+	_, err := NewObserverFactory(Config{Metrics: s.metrics})
+	c.Assert(err, gc.ErrorMatches, "validating config: nil Clock not valid")
+	_, err = NewObserverFactory(Config{Clock: s.clock})
+	c.Assert(err, gc.ErrorMatches, "validating config: nil Metrics not valid")
+}
+
+func (s *observerSuite) TestConnections(c *gc.C) {
+	o1 := s.newObserver(c)
+	o2 := s.newObserver(c)
+	o1.Join(&http.Request{}, 1)
+	o2.Join(&http.Request{}, 2)
+	c.Assert(readMetric(c, s.metrics.connections).GetGauge().GetValue(), gc.Equals, float64(2))
+	o1.Leave()
+	c.Assert(readMetric(c, s.metrics.connections).GetGauge().GetValue(), gc.Equals, float64(1))
+}
+
+func (s *observerSuite) TestRequests(c *gc.C) {
+	o := s.newObserver(c)
+	req := rpc.Request{Type: "Client", Version: 1, Action: "FullStatus"}
+
+	rpcObserver := o.RPCObserver()
+	rpcObserver.ServerRequest(&rpc.Header{Request: req}, nil)
+	s.clock.Advance(2 * time.Second)
+	rpcObserver.ServerReply(req, &rpc.Header{Request: req}, nil)
+
+	rpcObserver = o.RPCObserver()
+	rpcObserver.ServerRequest(&rpc.Header{Request: req}, nil)
+	s.clock.Advance(time.Second)
+	rpcObserver.ServerReply(req, &rpc.Header{Request: req, ErrorCode: "unauthorized access"}, nil)
+
+	ok := readMetric(c, s.metrics.requests.WithLabelValues("Client", "1", "FullStatus", ""))
+	c.Assert(ok.GetCounter().GetValue(), gc.Equals, float64(1))
+	failed := readMetric(c, s.metrics.requests.WithLabelValues("Client", "1", "FullStatus", "unauthorized access"))
+	c.Assert(failed.GetCounter().GetValue(), gc.Equals, float64(1))
+
+	latency := readMetric(c, s.metrics.latency.WithLabelValues("Client", "1", "FullStatus"))
+	c.Assert(latency.GetSummary().GetSampleCount(), gc.Equals, uint64(2))
+	c.Assert(latency.GetSummary().GetSampleSum(), gc.Equals, float64(3))
+}
+
+func (s *observerSuite) TestCollector(c *gc.C) {
+	ch := make(chan *prometheus.Desc, 10)
+	s.metrics.Describe(ch)
+	close(ch)
+	var descs []*prometheus.Desc
+	for desc := range ch {
+		descs = append(descs, desc)
+	}
+	c.Assert(descs, gc.HasLen, 3)
+}
+
+func readMetric(c *gc.C, m prometheus.Metric) *dto.Metric {
+	var out dto.Metric
+	err := m.Write(&out)
+	c.Assert(err, jc.ErrorIsNil)
+	return &out
+}
@@ -13,6 +13,7 @@ import (
 
 	"github.com/juju/juju/rpc"
 	"github.com/juju/juju/rpc/jsoncodec"
+	"github.com/juju/juju/tracing"
 )
 
 // RequestObserver serves as a sink for API server requests and
@@ -110,9 +111,9 @@ func (n *rpcObserver) ServerRequest(hdr *rpc.Header, body interface{}) {
 	// Until secrets are removed, we only log the body of the requests at trace level
 	// which is below the default level of debug.
 	if n.logger.IsTraceEnabled() {
-		n.logger.Tracef("<- [%X] %s %s", n.id, n.tag, jsoncodec.DumpRequest(hdr, body))
+		n.logger.Tracef("<- [%X] %s%s %s", n.id, n.tag, traceSuffix(hdr), jsoncodec.DumpRequest(hdr, body))
 	} else {
-		n.logger.Debugf("<- [%X] %s %s", n.id, n.tag, jsoncodec.DumpRequest(hdr, "'params redacted'"))
+		n.logger.Debugf("<- [%X] %s%s %s", n.id, n.tag, traceSuffix(hdr), jsoncodec.DumpRequest(hdr, "'params redacted'"))
 	}
 }
 
@@ -126,12 +127,13 @@ func (n *rpcObserver) ServerReply(req rpc.Request, hdr *rpc.Header, body interfa
 	// Until secrets are removed, we only log the body of the requests at trace level
 	// which is below the default level of debug.
 	if n.logger.IsTraceEnabled() {
-		n.logger.Tracef("-> [%X] %s %s", n.id, n.tag, jsoncodec.DumpRequest(hdr, body))
+		n.logger.Tracef("-> [%X] %s%s %s", n.id, n.tag, traceSuffix(hdr), jsoncodec.DumpRequest(hdr, body))
 	} else {
 		n.logger.Debugf(
-			"-> [%X] %s %s %s %s[%q].%s",
+			"-> [%X] %s%s %s %s %s[%q].%s",
 			n.id,
 			n.tag,
+			traceSuffix(hdr),
 			time.Since(n.requestStart),
 			jsoncodec.DumpRequest(hdr, "'body redacted'"),
 			req.Type,
@@ -140,3 +142,13 @@ func (n *rpcObserver) ServerReply(req rpc.Request, hdr *rpc.Header, body interfa
 		)
 	}
 }
+
+// traceSuffix returns the trace ID of the request or reply with the
+// given header, formatted to follow the entity in a log record, or
+// the empty string if the request is not traced.
+func traceSuffix(hdr *rpc.Header) string {
+	if traceID := tracing.TraceIDFromHeaders(hdr.TraceContext); traceID != "" {
+		return " trace-id=" + traceID
+	}
+	return ""
+}
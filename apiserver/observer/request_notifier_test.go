@@ -0,0 +1,71 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package observer_test
+
+import (
+	"time"
+
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/observer"
+	"github.com/juju/juju/rpc"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type requestObserverSuite struct {
+	testing.IsolationSuite
+	writer loggo.TestWriter
+}
+
+var _ = gc.Suite(&requestObserverSuite{})
+
+func (s *requestObserverSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.writer.Clear()
+	c.Assert(loggo.RegisterWriter("request-observer-tests", &s.writer), jc.ErrorIsNil)
+	s.AddCleanup(func(*gc.C) {
+		loggo.RemoveWriter("request-observer-tests")
+	})
+}
+
+func (s *requestObserverSuite) newRPCObserver() rpc.Observer {
+	logger := loggo.GetLogger("juju.apiserver.observer.test")
+	logger.SetLogLevel(loggo.DEBUG)
+	o := observer.NewRequestObserver(observer.RequestObserverContext{
+		Clock:  coretesting.NewClock(time.Now()),
+		Logger: logger,
+	})
+	o.Login(names.NewUserTag("bob"), coretesting.ModelTag, false, "")
+	return o.RPCObserver()
+}
+
+func (s *requestObserverSuite) TestLogsTraceID(c *gc.C) {
+	o := s.newRPCObserver()
+	hdr := request("Application", 1, "Deploy")
+	hdr.TraceContext = map[string]string{
+		"X-B3-TraceId": "00000000000004d2",
+		"X-B3-SpanId":  "000000000000162e",
+	}
+	o.ServerRequest(hdr, nil)
+	o.ServerReply(hdr.Request, &rpc.Header{TraceContext: hdr.TraceContext}, nil)
+
+	c.Check(s.writer.Log(), jc.LogMatches, []jc.SimpleMessage{
+		{loggo.DEBUG, `<- \[0\] user-bob\S* trace-id=00000000000004d2 .*`},
+		{loggo.DEBUG, `-> \[0\] user-bob\S* trace-id=00000000000004d2 .*`},
+	})
+}
+
+func (s *requestObserverSuite) TestLogsUntraced(c *gc.C) {
+	o := s.newRPCObserver()
+	hdr := request("Application", 1, "Deploy")
+	o.ServerRequest(hdr, nil)
+
+	c.Check(s.writer.Log(), jc.LogMatches, []jc.SimpleMessage{
+		{loggo.DEBUG, `<- \[0\] user-bob\S* \{.*`},
+	})
+}
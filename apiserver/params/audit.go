@@ -0,0 +1,50 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import (
+	"time"
+
+	"github.com/juju/version"
+)
+
+// AuditEntriesFilter holds the arguments for a call to the
+// AuditEntries method of the AuditLog facade. Unset fields
+// select all entries.
+type AuditEntriesFilter struct {
+	// UserTag selects the entries triggered by the identified user.
+	UserTag string `json:"user-tag,omitempty"`
+
+	// ModelTag selects the entries written on the identified model.
+	ModelTag string `json:"model-tag,omitempty"`
+
+	// Operation selects the entries whose operation begins with
+	// the given value, e.g. "Application".
+	Operation string `json:"operation,omitempty"`
+
+	// Since selects the entries recorded at or after the given time.
+	Since *time.Time `json:"since,omitempty"`
+
+	// Limit is the maximum number of entries to return.
+	Limit int `json:"limit,omitempty"`
+}
+
+// AuditEntriesResult holds the results of a call to the AuditEntries
+// method of the AuditLog facade.
+type AuditEntriesResult struct {
+	// Entries holds the matching entries, most recent first.
+	Entries []AuditEntry `json:"entries"`
+}
+
+// AuditEntry holds a single audited event.
+type AuditEntry struct {
+	JujuServerVersion version.Number         `json:"juju-server-version"`
+	ModelTag          string                 `json:"model-tag"`
+	Timestamp         time.Time              `json:"timestamp"`
+	RemoteAddress     string                 `json:"remote-address"`
+	OriginType        string                 `json:"origin-type"`
+	OriginName        string                 `json:"origin-name"`
+	Operation         string                 `json:"operation"`
+	Data              map[string]interface{} `json:"data,omitempty"`
+}
@@ -0,0 +1,50 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import (
+	"time"
+)
+
+const (
+	// HookRunKindHook identifies the execution of a hook.
+	HookRunKindHook = "hook"
+
+	// HookRunKindAction identifies the execution of an action.
+	HookRunKindAction = "action"
+)
+
+// HookRun describes a single execution of a hook or action by a unit.
+type HookRun struct {
+	Kind     string    `json:"kind" yaml:"kind"`
+	Name     string    `json:"name" yaml:"name"`
+	Started  time.Time `json:"started" yaml:"started"`
+	Finished time.Time `json:"finished" yaml:"finished"`
+	ExitCode int       `json:"exit-code" yaml:"exit-code"`
+	Error    string    `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// RecordHookRunArg holds a hook run to record for the unit
+// identified by Tag.
+type RecordHookRunArg struct {
+	Tag string  `json:"tag"`
+	Run HookRun `json:"run"`
+}
+
+// RecordHookRunArgs holds the arguments for recording hook runs.
+type RecordHookRunArgs struct {
+	Args []RecordHookRunArg `json:"args"`
+}
+
+// HookRunsResult holds the hook runs recorded for a unit, most
+// recent first, or an error.
+type HookRunsResult struct {
+	Runs  []HookRun `json:"runs,omitempty"`
+	Error *Error    `json:"error,omitempty"`
+}
+
+// HookRunsResults holds the results of a HookRuns call.
+type HookRunsResults struct {
+	Results []HookRunsResult `json:"results"`
+}
@@ -22,6 +22,12 @@ type ModelConfigResults struct {
 	Config map[string]ConfigValue `json:"config"`
 }
 
+// ModelLogsSizeResult holds the result of a ModelLogsSize call.
+type ModelLogsSizeResult struct {
+	// Size is the approximate size, in bytes, of the model's logs.
+	Size int64 `json:"size"`
+}
+
 // ModelSet contains the arguments for ModelSet client API
 // call.
 type ModelSet struct {
@@ -34,6 +40,20 @@ type ModelUnset struct {
 	Keys []string `json:"keys"`
 }
 
+// LoggingOverrides contains the arguments for the SetLoggingOverrides
+// API call.
+type LoggingOverrides struct {
+	Overrides []LoggingOverride `json:"overrides"`
+}
+
+// LoggingOverride holds the logging config override for the agents
+// of a machine, application or unit. An empty Config removes the
+// override.
+type LoggingOverride struct {
+	Tag    string `json:"tag"`
+	Config string `json:"config,omitempty"`
+}
+
 // SetModelDefaults contains the arguments for SetModelDefaults
 // client API call.
 type SetModelDefaults struct {
@@ -238,6 +238,9 @@ type EntityPortRange struct {
 	Protocol string `json:"protocol"`
 	FromPort int    `json:"from-port"`
 	ToPort   int    `json:"to-port"`
+	// Endpoint holds the name of the endpoint for which to open the
+	// port range; if empty, it is opened for all endpoints.
+	Endpoint string `json:"endpoint,omitempty"`
 }
 
 // EntitiesPortRanges holds the parameters for making an OpenPorts or
@@ -409,6 +412,10 @@ type MachinePortRange struct {
 	UnitTag     string    `json:"unit-tag"`
 	RelationTag string    `json:"relation-tag"`
 	PortRange   PortRange `json:"port-range"`
+	// Endpoint holds the name of the unit's endpoint for which the
+	// port range was opened; if empty, it was opened for all
+	// endpoints.
+	Endpoint string `json:"endpoint,omitempty"`
 }
 
 // MachinePorts holds a machine and subnet tags. It's used when referring to
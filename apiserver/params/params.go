@@ -261,6 +261,32 @@ type ApplicationSetCharm struct {
 // ApplicationExpose holds the parameters for making the application Expose call.
 type ApplicationExpose struct {
 	ApplicationName string `json:"application"`
+
+	// ExposedEndpoints, if set, restricts the exposure of the
+	// application to the given endpoints and source CIDRs. The
+	// empty endpoint name applies to all endpoints.
+	ExposedEndpoints map[string]ExposedEndpoint `json:"exposed-endpoints,omitempty"`
+}
+
+// ExposedEndpoint holds the expose settings for an application
+// endpoint.
+type ExposedEndpoint struct {
+	// ExposeToCIDRs holds the source CIDRs from which the endpoint
+	// may be reached. If empty, it may be reached from anywhere.
+	ExposeToCIDRs []string `json:"expose-to-cidrs,omitempty"`
+}
+
+// ExposeInfoResult holds the result of a GetExposeInfo call for
+// a single application.
+type ExposeInfoResult struct {
+	Exposed          bool                       `json:"exposed,omitempty"`
+	ExposedEndpoints map[string]ExposedEndpoint `json:"exposed-endpoints,omitempty"`
+	Error            *Error                     `json:"error,omitempty"`
+}
+
+// ExposeInfoResults holds the results of a GetExposeInfo call.
+type ExposeInfoResults struct {
+	Results []ExposeInfoResult `json:"results"`
 }
 
 // ApplicationSet holds the parameters for an application Set
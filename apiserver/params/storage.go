@@ -3,7 +3,11 @@
 
 package params
 
-import "github.com/juju/juju/storage"
+import (
+	"time"
+
+	"github.com/juju/juju/storage"
+)
 
 // MachineBlockDevices holds a machine tag and the block devices present
 // on that machine.
@@ -94,6 +98,10 @@ type StorageAttachment struct {
 	Kind     StorageKind `json:"kind"`
 	Location string      `json:"location"`
 	Life     Life        `json:"life"`
+
+	// Size is the size of the storage attachment's underlying
+	// volume or filesystem, in MiB.
+	Size uint64 `json:"size,omitempty"`
 }
 
 // StorageAttachmentId identifies a storage attachment by the tags of the
@@ -210,6 +218,7 @@ type VolumeParams struct {
 	Attributes map[string]interface{}  `json:"attributes,omitempty"`
 	Tags       map[string]string       `json:"tags,omitempty"`
 	Attachment *VolumeAttachmentParams `json:"attachment,omitempty"`
+	SnapshotId string                  `json:"snapshot-id,omitempty"`
 }
 
 // VolumeAttachmentParams holds the parameters for creating a volume
@@ -270,6 +279,90 @@ type VolumeParamsResults struct {
 	Results []VolumeParamsResult `json:"results,omitempty"`
 }
 
+// VolumeResizeParams holds the parameters for resizing a volume.
+type VolumeResizeParams struct {
+	VolumeTag  string                  `json:"volume-tag"`
+	VolumeId   string                  `json:"volume-id"`
+	Size       uint64                  `json:"size"`
+	Provider   string                  `json:"provider"`
+	Attributes map[string]interface{}  `json:"attributes,omitempty"`
+	Attachment *VolumeAttachmentParams `json:"attachment,omitempty"`
+}
+
+// VolumeResizeParamsResult holds resizing parameters for a volume,
+// or an error.
+type VolumeResizeParamsResult struct {
+	Result VolumeResizeParams `json:"result"`
+	Error  *Error             `json:"error,omitempty"`
+}
+
+// VolumeResizeParamsResults holds resizing parameters for multiple volumes.
+type VolumeResizeParamsResults struct {
+	Results []VolumeResizeParamsResult `json:"results,omitempty"`
+}
+
+// VolumeSnapshotIds holds the IDs of volume snapshots.
+type VolumeSnapshotIds struct {
+	Ids []string `json:"ids"`
+}
+
+// VolumeSnapshotParams holds the parameters for taking a snapshot of
+// a volume.
+type VolumeSnapshotParams struct {
+	Id         string                 `json:"id"`
+	VolumeTag  string                 `json:"volume-tag"`
+	VolumeId   string                 `json:"volume-id"`
+	Provider   string                 `json:"provider"`
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+	Tags       map[string]string      `json:"tags,omitempty"`
+}
+
+// VolumeSnapshotParamsResult holds snapshot parameters for a volume,
+// or an error.
+type VolumeSnapshotParamsResult struct {
+	Result VolumeSnapshotParams `json:"result"`
+	Error  *Error               `json:"error,omitempty"`
+}
+
+// VolumeSnapshotParamsResults holds snapshot parameters for multiple
+// volumes.
+type VolumeSnapshotParamsResults struct {
+	Results []VolumeSnapshotParamsResult `json:"results,omitempty"`
+}
+
+// VolumeSnapshotInfo describes a volume snapshot taken by a storage
+// provider.
+type VolumeSnapshotInfo struct {
+	SnapshotId string `json:"snapshot-id"`
+	Size       uint64 `json:"size"`
+}
+
+// VolumeSnapshot describes a volume snapshot taken by a storage
+// provider.
+type VolumeSnapshot struct {
+	Id        string             `json:"id"`
+	VolumeTag string             `json:"volume-tag"`
+	Info      VolumeSnapshotInfo `json:"info"`
+}
+
+// VolumeSnapshots describes a set of volume snapshots.
+type VolumeSnapshots struct {
+	VolumeSnapshots []VolumeSnapshot `json:"volume-snapshots"`
+}
+
+// VolumeSnapshotStatus holds the status to set for a volume snapshot.
+type VolumeSnapshotStatus struct {
+	Id     string `json:"id"`
+	Status string `json:"status"`
+	Info   string `json:"info"`
+}
+
+// VolumeSnapshotStatuses holds the statuses to set for a set of
+// volume snapshots.
+type VolumeSnapshotStatuses struct {
+	Statuses []VolumeSnapshotStatus `json:"statuses"`
+}
+
 // VolumeAttachmentParamsResults holds provisioning parameters for a volume
 // attachment.
 type VolumeAttachmentParamsResult struct {
@@ -662,9 +755,66 @@ type StorageAddParams struct {
 
 	// Constraints are specified storage constraints.
 	Constraints StorageConstraints `json:"storage"`
+
+	// FromSnapshot is the ID of the volume snapshot from which the
+	// storage's volumes are to be created, if any.
+	FromSnapshot string `json:"from-snapshot,omitempty"`
 }
 
 // StoragesAddParams holds storage details to add to units dynamically.
 type StoragesAddParams struct {
 	Storages []StorageAddParams `json:"storages"`
 }
+
+// StorageResizeParams holds the details of a request to resize
+// a storage instance.
+type StorageResizeParams struct {
+	// StorageTag is the tag of the storage instance to resize.
+	StorageTag string `json:"storage-tag"`
+
+	// Size is the new size of the storage instance, in MiB.
+	Size uint64 `json:"size"`
+}
+
+// StoragesResizeParams holds the details of requests to resize
+// storage instances.
+type StoragesResizeParams struct {
+	Storages []StorageResizeParams `json:"storages"`
+}
+
+// VolumeSnapshotDetails describes a volume snapshot, and the storage
+// instance it was taken of.
+type VolumeSnapshotDetails struct {
+	// Id is the unique ID of the snapshot.
+	Id string `json:"id"`
+
+	// VolumeTag is the tag of the volume that the snapshot is of.
+	VolumeTag string `json:"volume-tag"`
+
+	// StorageTag is the tag of the storage instance that the
+	// volume was assigned to, if any.
+	StorageTag string `json:"storage-tag,omitempty"`
+
+	// Pool is the name of the storage pool that the volume was
+	// provisioned from.
+	Pool string `json:"pool"`
+
+	// Created is the time at which the snapshot was requested.
+	Created time.Time `json:"created"`
+
+	// Info describes the snapshot taken by the storage provider.
+	// Info is nil until the snapshot has been taken.
+	Info *VolumeSnapshotInfo `json:"info,omitempty"`
+}
+
+// VolumeSnapshotDetailsResult contains details about a volume snapshot,
+// or an error preventing retrieving those details.
+type VolumeSnapshotDetailsResult struct {
+	Result *VolumeSnapshotDetails `json:"result,omitempty"`
+	Error  *Error                 `json:"error,omitempty"`
+}
+
+// VolumeSnapshotDetailsResults holds volume snapshot details.
+type VolumeSnapshotDetailsResults struct {
+	Results []VolumeSnapshotDetailsResult `json:"results,omitempty"`
+}
@@ -28,6 +28,7 @@ func (s *restrictControllerSuite) SetUpSuite(c *gc.C) {
 func (s *restrictControllerSuite) TestAllowed(c *gc.C) {
 	s.assertMethod(c, "AllModelWatcher", 2, "Next")
 	s.assertMethod(c, "AllModelWatcher", 2, "Stop")
+	s.assertMethod(c, "AuditLog", 1, "AuditEntries")
 	s.assertMethod(c, "ModelManager", 2, "CreateModel")
 	s.assertMethod(c, "ModelManager", 2, "ListModels")
 	s.assertMethod(c, "Pinger", 1, "Ping")
@@ -4,6 +4,8 @@
 package apiserver
 
 import (
+	"github.com/opentracing/opentracing-go"
+
 	"github.com/juju/juju/rpc"
 	"github.com/juju/juju/rpc/rpcreflect"
 )
@@ -31,6 +33,17 @@ func (r *restrictedRoot) FindMethod(facadeName string, version int, methodName s
 	return r.Root.FindMethod(facadeName, version, methodName)
 }
 
+// FindTracedMethod implements rpc.TracingRoot.
+func (r *restrictedRoot) FindTracedMethod(facadeName string, version int, methodName string, parent opentracing.SpanContext) (rpcreflect.MethodCaller, error) {
+	if err := r.check(facadeName, methodName); err != nil {
+		return nil, err
+	}
+	if root, ok := r.Root.(rpc.TracingRoot); ok {
+		return root.FindTracedMethod(facadeName, version, methodName, parent)
+	}
+	return r.Root.FindMethod(facadeName, version, methodName)
+}
+
 // restrictAll blocks all API requests, returned a fixed error.
 func restrictAll(root rpc.Root, err error) *restrictedRoot {
 	return restrictRoot(root, func(string, string) error {
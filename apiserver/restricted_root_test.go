@@ -6,6 +6,7 @@ package apiserver_test
 import (
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
+	"github.com/opentracing/opentracing-go"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/apiserver"
@@ -40,6 +41,19 @@ func (r *restrictedRootSuite) TestDisallowedMethod(c *gc.C) {
 	c.Assert(caller, gc.IsNil)
 }
 
+func (r *restrictedRootSuite) TestTracedMethods(c *gc.C) {
+	root := r.root.(rpc.TracingRoot)
+	parent := opentracing.NoopTracer{}.StartSpan("request").Context()
+
+	caller, err := root.FindTracedMethod("Client", 1, "WatchAll", parent)
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(caller, gc.NotNil)
+
+	caller, err = root.FindTracedMethod("Client", 1, "FullStatus", parent)
+	c.Assert(err, gc.ErrorMatches, "blam")
+	c.Assert(caller, gc.IsNil)
+}
+
 func (r *restrictedRootSuite) TestMethodNonExistentVersion(c *gc.C) {
 	caller, err := r.root.FindMethod("Client", 99999999, "WatchAll")
 	c.Assert(err, gc.ErrorMatches, `unknown version .+`)
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/juju/errors"
+	"github.com/opentracing/opentracing-go"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/common"
@@ -180,33 +181,10 @@ func (r *apiRoot) FindMethod(rootName string, version int, methodName string) (r
 		}
 		// Now that we have the write lock, check one more time in case
 		// someone got the write lock before us.
-		factory, err := common.Facades.GetFactory(rootName, version)
+		objValue, err := newFacade(rootName, version, goType, r.facadeContext(id, r.state))
 		if err != nil {
-			// We don't check for IsNotFound here, because it
-			// should have already been handled in the GetType
-			// check.
 			return reflect.Value{}, err
 		}
-		obj, err := factory(r.facadeContext(id))
-		if err != nil {
-			return reflect.Value{}, err
-		}
-		objValue = reflect.ValueOf(obj)
-		if !objValue.Type().AssignableTo(goType) {
-			return reflect.Value{}, errors.Errorf(
-				"internal error, %s(%d) claimed to return %s but returned %T",
-				rootName, version, goType, obj)
-		}
-		if goType.Kind() == reflect.Interface {
-			// If the original function wanted to return an
-			// interface type, the indirection in the factory via
-			// an interface{} strips the original interface
-			// information off. So here we have to create the
-			// interface again, and assign it.
-			asInterface := reflect.New(goType).Elem()
-			asInterface.Set(objValue)
-			objValue = asInterface
-		}
 		r.objectCache[objKey] = objValue
 		return objValue, nil
 	}
@@ -216,10 +194,62 @@ func (r *apiRoot) FindMethod(rootName string, version int, methodName string) (r
 	}, nil
 }
 
-func (r *apiRoot) facadeContext(id string) *facadeContext {
+// FindTracedMethod implements rpc.TracingRoot. It is like FindMethod,
+// but the facade is created for the request, rather than shared with
+// other requests on the connection, so that the state it uses traces
+// its transactions under the given span context.
+func (r *apiRoot) FindTracedMethod(rootName string, version int, methodName string, parent opentracing.SpanContext) (rpcreflect.MethodCaller, error) {
+	goType, objMethod, err := lookupMethod(rootName, version, methodName)
+	if err != nil {
+		return nil, err
+	}
+	return &srvCaller{
+		creator: func(id string) (reflect.Value, error) {
+			st := r.state.TracedBy(parent)
+			return newFacade(rootName, version, goType, r.facadeContext(id, st))
+		},
+		objMethod: objMethod,
+	}, nil
+}
+
+// newFacade creates the facade with the given name and version, which
+// is expected to be of type goType, using the given context.
+func newFacade(rootName string, version int, goType reflect.Type, ctx facade.Context) (reflect.Value, error) {
+	factory, err := common.Facades.GetFactory(rootName, version)
+	if err != nil {
+		// We don't check for IsNotFound here, because it
+		// should have already been handled in the GetType
+		// check.
+		return reflect.Value{}, err
+	}
+	obj, err := factory(ctx)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	objValue := reflect.ValueOf(obj)
+	if !objValue.Type().AssignableTo(goType) {
+		return reflect.Value{}, errors.Errorf(
+			"internal error, %s(%d) claimed to return %s but returned %T",
+			rootName, version, goType, obj)
+	}
+	if goType.Kind() == reflect.Interface {
+		// If the original function wanted to return an
+		// interface type, the indirection in the factory via
+		// an interface{} strips the original interface
+		// information off. So here we have to create the
+		// interface again, and assign it.
+		asInterface := reflect.New(goType).Elem()
+		asInterface.Set(objValue)
+		objValue = asInterface
+	}
+	return objValue, nil
+}
+
+func (r *apiRoot) facadeContext(id string, st *state.State) *facadeContext {
 	return &facadeContext{
 		r:  r,
 		id: id,
+		st: st,
 	}
 }
 
@@ -227,6 +257,7 @@ func (r *apiRoot) facadeContext(id string) *facadeContext {
 type facadeContext struct {
 	r  *apiRoot
 	id string
+	st *state.State
 }
 
 func (ctx *facadeContext) Abort() <-chan struct{} {
@@ -242,7 +273,7 @@ func (ctx *facadeContext) Resources() facade.Resources {
 }
 
 func (ctx *facadeContext) State() *state.State {
-	return ctx.r.state
+	return ctx.st
 }
 
 func (ctx *facadeContext) ID() string {
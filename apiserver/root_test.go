@@ -10,12 +10,14 @@ import (
 	"time"
 
 	jc "github.com/juju/testing/checkers"
+	"github.com/opentracing/opentracing-go"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver"
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/rpc"
 	"github.com/juju/juju/rpc/rpcreflect"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/testing"
@@ -240,6 +242,45 @@ func (r *rootSuite) TestFindMethodCachesFacades(c *gc.C) {
 	assertCallResult(c, caller, "", "ALT-2")
 }
 
+func (r *rootSuite) TestFindTracedMethodUsesTracedState(c *gc.C) {
+	st := new(state.State)
+	srvRoot := apiserver.TestingAPIRoot(st).(rpc.TracingRoot)
+	defer common.Facades.Discard("my-counting-facade", 0)
+	var states []*state.State
+	newCounter := func(
+		st *state.State, _ facade.Resources, _ facade.Authorizer,
+	) (
+		*countingType, error,
+	) {
+		states = append(states, st)
+		return &countingType{count: int64(len(states)), id: ""}, nil
+	}
+	common.RegisterStandardFacade("my-counting-facade", 0, newCounter)
+	parent := opentracing.NoopTracer{}.StartSpan("request").Context()
+
+	// Each traced request gets a facade of its own, using a
+	// traced view of the state.
+	caller, err := srvRoot.FindTracedMethod("my-counting-facade", 0, "Count", parent)
+	c.Assert(err, jc.ErrorIsNil)
+	assertCallResult(c, caller, "", "1")
+	caller, err = srvRoot.FindTracedMethod("my-counting-facade", 0, "Count", parent)
+	c.Assert(err, jc.ErrorIsNil)
+	assertCallResult(c, caller, "", "2")
+	c.Assert(states, gc.HasLen, 2)
+	c.Check(states[0], gc.Not(gc.Equals), st)
+	c.Check(states[1], gc.Not(gc.Equals), states[0])
+
+	// Untraced requests still share the cached facade, which uses
+	// the state itself.
+	caller, err = srvRoot.FindMethod("my-counting-facade", 0, "Count")
+	c.Assert(err, jc.ErrorIsNil)
+	assertCallResult(c, caller, "", "3")
+	caller, err = srvRoot.FindMethod("my-counting-facade", 0, "Count")
+	c.Assert(err, jc.ErrorIsNil)
+	assertCallResult(c, caller, "", "3")
+	c.Check(states[2], gc.Equals, st)
+}
+
 func (r *rootSuite) TestFindMethodCachesFacadesWithId(c *gc.C) {
 	srvRoot := apiserver.TestingAPIRoot(nil)
 	defer common.Facades.Discard("my-counting-facade", 0)
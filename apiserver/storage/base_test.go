@@ -4,6 +4,8 @@
 package storage_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -25,6 +27,7 @@ type baseStorageSuite struct {
 	authorizer testing.FakeAuthorizer
 
 	api   *storage.API
+	apiv4 *storage.APIV4
 	state *mockState
 
 	storageTag      names.StorageTag
@@ -35,6 +38,7 @@ type baseStorageSuite struct {
 	volumeTag            names.VolumeTag
 	volume               *mockVolume
 	volumeAttachment     *mockVolumeAttachment
+	volumeSnapshot       *mockVolumeSnapshot
 	filesystemTag        names.FilesystemTag
 	filesystem           *mockFilesystem
 	filesystemAttachment *mockFilesystemAttachment
@@ -61,6 +65,8 @@ func (s *baseStorageSuite) SetUpTest(c *gc.C) {
 	var err error
 	s.api, err = storage.NewAPI(s.state, s.registry, s.poolManager, s.resources, s.authorizer)
 	c.Assert(err, jc.ErrorIsNil)
+	s.apiv4, err = storage.NewAPIV4(s.state, s.registry, s.poolManager, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
 }
 
 func (s *baseStorageSuite) assertCalls(c *gc.C, expectedCalls []string) {
@@ -84,6 +90,10 @@ const (
 	filesystemAttachmentsCall               = "filesystemAttachments"
 	allFilesystemsCall                      = "allFilesystems"
 	addStorageForUnitCall                   = "addStorageForUnit"
+	resizeStorageInstanceCall               = "resizeStorageInstance"
+	addStorageForUnitFromSnapshotCall       = "addStorageForUnitFromSnapshot"
+	createStorageSnapshotCall               = "createStorageSnapshot"
+	allVolumeSnapshotsCall                  = "allVolumeSnapshots"
 	getBlockForTypeCall                     = "getBlockForType"
 	volumeAttachmentCall                    = "volumeAttachment"
 )
@@ -116,6 +126,13 @@ func (s *baseStorageSuite) constructState() *mockState {
 		VolumeTag:  s.volumeTag,
 		MachineTag: s.machineTag,
 	}
+	s.volumeSnapshot = &mockVolumeSnapshot{
+		id:      "0",
+		volume:  s.volumeTag,
+		storage: &s.storageTag,
+		pool:    "ebs",
+		created: time.Date(2016, 11, 1, 0, 0, 0, 0, time.UTC),
+	}
 
 	s.blocks = make(map[state.BlockType]state.Block)
 	return &mockState{
@@ -224,6 +241,22 @@ func (s *baseStorageSuite) constructState() *mockState {
 			s.calls = append(s.calls, addStorageForUnitCall)
 			return nil
 		},
+		resizeStorageInstance: func(tag names.StorageTag, size uint64) error {
+			s.calls = append(s.calls, resizeStorageInstanceCall)
+			return nil
+		},
+		addStorageForUnitFromSnapshot: func(u names.UnitTag, name string, cons state.StorageConstraints, snapshotId string) error {
+			s.calls = append(s.calls, addStorageForUnitFromSnapshotCall)
+			return nil
+		},
+		createStorageSnapshot: func(tag names.StorageTag) (state.VolumeSnapshot, error) {
+			s.calls = append(s.calls, createStorageSnapshotCall)
+			return s.volumeSnapshot, nil
+		},
+		allVolumeSnapshots: func() ([]state.VolumeSnapshot, error) {
+			s.calls = append(s.calls, allVolumeSnapshotsCall)
+			return []state.VolumeSnapshot{s.volumeSnapshot}, nil
+		},
 		getBlockForType: func(t state.BlockType) (state.Block, bool, error) {
 			s.calls = append(s.calls, getBlockForTypeCall)
 			val, found := s.blocks[t]
@@ -4,6 +4,8 @@
 package storage_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/names.v2"
@@ -60,6 +62,10 @@ type mockState struct {
 	filesystemAttachments               func(filesystem names.FilesystemTag) ([]state.FilesystemAttachment, error)
 	allFilesystems                      func() ([]state.Filesystem, error)
 	addStorageForUnit                   func(u names.UnitTag, name string, cons state.StorageConstraints) error
+	resizeStorageInstance               func(names.StorageTag, uint64) error
+	addStorageForUnitFromSnapshot       func(u names.UnitTag, name string, cons state.StorageConstraints, snapshotId string) error
+	createStorageSnapshot               func(names.StorageTag) (state.VolumeSnapshot, error)
+	allVolumeSnapshots                  func() ([]state.VolumeSnapshot, error)
 	getBlockForType                     func(t state.BlockType) (state.Block, bool, error)
 	blockDevices                        func(names.MachineTag) ([]state.BlockDeviceInfo, error)
 }
@@ -156,6 +162,22 @@ func (st *mockState) AddStorageForUnit(u names.UnitTag, name string, cons state.
 	return st.addStorageForUnit(u, name, cons)
 }
 
+func (st *mockState) ResizeStorageInstance(tag names.StorageTag, size uint64) error {
+	return st.resizeStorageInstance(tag, size)
+}
+
+func (st *mockState) AddStorageForUnitFromSnapshot(u names.UnitTag, name string, cons state.StorageConstraints, snapshotId string) error {
+	return st.addStorageForUnitFromSnapshot(u, name, cons, snapshotId)
+}
+
+func (st *mockState) CreateStorageSnapshot(tag names.StorageTag) (state.VolumeSnapshot, error) {
+	return st.createStorageSnapshot(tag)
+}
+
+func (st *mockState) AllVolumeSnapshots() ([]state.VolumeSnapshot, error) {
+	return st.allVolumeSnapshots()
+}
+
 func (st *mockState) GetBlockForType(t state.BlockType) (state.Block, bool, error) {
 	return st.getBlockForType(t)
 }
@@ -212,6 +234,46 @@ func (m *mockVolume) Status() (status.StatusInfo, error) {
 	return status.StatusInfo{Status: status.StatusAttached}, nil
 }
 
+type mockVolumeSnapshot struct {
+	state.VolumeSnapshot
+	id      string
+	volume  names.VolumeTag
+	storage *names.StorageTag
+	pool    string
+	created time.Time
+	info    *state.VolumeSnapshotInfo
+}
+
+func (m *mockVolumeSnapshot) Id() string {
+	return m.id
+}
+
+func (m *mockVolumeSnapshot) Volume() names.VolumeTag {
+	return m.volume
+}
+
+func (m *mockVolumeSnapshot) StorageInstance() (names.StorageTag, error) {
+	if m.storage != nil {
+		return *m.storage, nil
+	}
+	return names.StorageTag{}, errors.NewNotAssigned(nil, "error from mock")
+}
+
+func (m *mockVolumeSnapshot) Pool() string {
+	return m.pool
+}
+
+func (m *mockVolumeSnapshot) Created() time.Time {
+	return m.created
+}
+
+func (m *mockVolumeSnapshot) Info() (state.VolumeSnapshotInfo, error) {
+	if m.info != nil {
+		return *m.info, nil
+	}
+	return state.VolumeSnapshotInfo{}, errors.NotProvisionedf("volume snapshot %q", m.id)
+}
+
 type mockFilesystem struct {
 	state.Filesystem
 	tag     names.FilesystemTag
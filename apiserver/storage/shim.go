@@ -22,6 +22,7 @@ import (
 
 func init() {
 	common.RegisterStandardFacade("Storage", 3, newAPI)
+	common.RegisterStandardFacade("Storage", 4, newAPIV4)
 }
 
 func newAPI(
@@ -38,6 +39,18 @@ func newAPI(
 	return NewAPI(getState(st), registry, pm, resources, authorizer)
 }
 
+func newAPIV4(
+	st *state.State,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*APIV4, error) {
+	api, err := newAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &APIV4{api}, nil
+}
+
 type storageAccess interface {
 	// StorageInstance is required for storage functionality.
 	StorageInstance(names.StorageTag) (state.StorageInstance, error)
@@ -111,6 +124,18 @@ type storageAccess interface {
 	// AddStorageForUnit is required for storage add functionality.
 	AddStorageForUnit(tag names.UnitTag, name string, cons state.StorageConstraints) error
 
+	// ResizeStorageInstance is required for storage resize functionality.
+	ResizeStorageInstance(tag names.StorageTag, size uint64) error
+
+	// AddStorageForUnitFromSnapshot is required for storage add functionality.
+	AddStorageForUnitFromSnapshot(tag names.UnitTag, name string, cons state.StorageConstraints, snapshotId string) error
+
+	// CreateStorageSnapshot is required for storage snapshot functionality.
+	CreateStorageSnapshot(tag names.StorageTag) (state.VolumeSnapshot, error)
+
+	// AllVolumeSnapshots is required for storage snapshot functionality.
+	AllVolumeSnapshots() ([]state.VolumeSnapshot, error)
+
 	// GetBlockForType is required to block operations.
 	GetBlockForType(t state.BlockType) (state.Block, bool, error)
 }
@@ -49,6 +49,28 @@ func NewAPI(
 		authorizer:  authorizer,
 	}, nil
 }
+
+// APIV4 implements version 4 of the Storage facade, which adds Resize,
+// CreateSnapshots and ListSnapshots.
+type APIV4 struct {
+	*API
+}
+
+// NewAPIV4 returns a new storage API facade, version 4.
+func NewAPIV4(
+	st storageAccess,
+	registry storage.ProviderRegistry,
+	pm poolmanager.PoolManager,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*APIV4, error) {
+	api, err := NewAPI(st, registry, pm, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &APIV4{api}, nil
+}
+
 func (api *API) checkCanRead() error {
 	canRead, err := api.authorizer.HasPermission(description.ReadAccess, api.storage.ModelTag())
 	if err != nil {
@@ -699,10 +721,113 @@ func (a *API) AddToUnit(args params.StoragesAddParams) (params.ErrorResults, err
 			continue
 		}
 
-		err = a.storage.AddStorageForUnit(u, one.StorageName, paramsToState(one.Constraints))
+		if one.FromSnapshot != "" {
+			err = a.storage.AddStorageForUnitFromSnapshot(
+				u, one.StorageName, paramsToState(one.Constraints), one.FromSnapshot,
+			)
+		} else {
+			err = a.storage.AddStorageForUnit(u, one.StorageName, paramsToState(one.Constraints))
+		}
 		if err != nil {
 			result[i] = params.ErrorResult{Error: common.ServerError(err)}
 		}
 	}
 	return params.ErrorResults{Results: result}, nil
 }
+
+// Resize requests that storage instances be grown to new sizes.
+// This method handles bulk resize operations and a failure on one
+// individual storage instance does not block remaining instances
+// from being processed.
+// A "CHANGE" block can block this operation.
+func (a *APIV4) Resize(args params.StoragesResizeParams) (params.ErrorResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	// Check if changes are allowed and the operation may proceed.
+	blockChecker := common.NewBlockChecker(a.storage)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	result := make([]params.ErrorResult, len(args.Storages))
+	for i, one := range args.Storages {
+		tag, err := names.ParseStorageTag(one.StorageTag)
+		if err != nil {
+			result[i].Error = common.ServerError(err)
+			continue
+		}
+		if err := a.storage.ResizeStorageInstance(tag, one.Size); err != nil {
+			result[i].Error = common.ServerError(err)
+		}
+	}
+	return params.ErrorResults{Results: result}, nil
+}
+
+// CreateSnapshots requests snapshots of the volumes backing the specified
+// storage instances. The snapshots are taken asynchronously by the
+// storage provisioner; the details of the pending snapshots are returned.
+// A "CHANGE" block can block this operation.
+func (a *APIV4) CreateSnapshots(args params.Entities) (params.VolumeSnapshotDetailsResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.VolumeSnapshotDetailsResults{}, errors.Trace(err)
+	}
+
+	// Check if changes are allowed and the operation may proceed.
+	blockChecker := common.NewBlockChecker(a.storage)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.VolumeSnapshotDetailsResults{}, errors.Trace(err)
+	}
+
+	results := make([]params.VolumeSnapshotDetailsResult, len(args.Entities))
+	for i, entity := range args.Entities {
+		tag, err := names.ParseStorageTag(entity.Tag)
+		if err != nil {
+			results[i].Error = common.ServerError(err)
+			continue
+		}
+		snapshot, err := a.storage.CreateStorageSnapshot(tag)
+		if err != nil {
+			results[i].Error = common.ServerError(err)
+			continue
+		}
+		results[i].Result = volumeSnapshotDetails(snapshot)
+	}
+	return params.VolumeSnapshotDetailsResults{Results: results}, nil
+}
+
+// ListSnapshots returns the details of all volume snapshots in the model.
+func (a *APIV4) ListSnapshots() (params.VolumeSnapshotDetailsResults, error) {
+	if err := a.checkCanRead(); err != nil {
+		return params.VolumeSnapshotDetailsResults{}, errors.Trace(err)
+	}
+	snapshots, err := a.storage.AllVolumeSnapshots()
+	if err != nil {
+		return params.VolumeSnapshotDetailsResults{}, common.ServerError(err)
+	}
+	results := make([]params.VolumeSnapshotDetailsResult, len(snapshots))
+	for i, snapshot := range snapshots {
+		results[i].Result = volumeSnapshotDetails(snapshot)
+	}
+	return params.VolumeSnapshotDetailsResults{Results: results}, nil
+}
+
+func volumeSnapshotDetails(s state.VolumeSnapshot) *params.VolumeSnapshotDetails {
+	details := &params.VolumeSnapshotDetails{
+		Id:        s.Id(),
+		VolumeTag: s.Volume().String(),
+		Pool:      s.Pool(),
+		Created:   s.Created(),
+	}
+	if storageTag, err := s.StorageInstance(); err == nil {
+		details.StorageTag = storageTag.String()
+	}
+	if info, err := s.Info(); err == nil {
+		details.Info = &params.VolumeSnapshotInfo{
+			SnapshotId: info.SnapshotId,
+			Size:       info.Size,
+		}
+	}
+	return details
+}
@@ -0,0 +1,71 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+type storageResizeSuite struct {
+	baseStorageSuite
+}
+
+var _ = gc.Suite(&storageResizeSuite{})
+
+func (s *storageResizeSuite) TestStorageResize(c *gc.C) {
+	var resized []names.StorageTag
+	s.state.resizeStorageInstance = func(tag names.StorageTag, size uint64) error {
+		s.calls = append(s.calls, resizeStorageInstanceCall)
+		c.Check(size, gc.Equals, uint64(2048))
+		resized = append(resized, tag)
+		return nil
+	}
+	results, err := s.apiv4.Resize(params.StoragesResizeParams{
+		Storages: []params.StorageResizeParams{{
+			StorageTag: s.storageTag.String(),
+			Size:       2048,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ErrorResult{{}})
+	c.Assert(resized, jc.DeepEquals, []names.StorageTag{s.storageTag})
+	s.assertCalls(c, []string{getBlockForTypeCall, resizeStorageInstanceCall})
+}
+
+func (s *storageResizeSuite) TestStorageResizeErrors(c *gc.C) {
+	s.state.resizeStorageInstance = func(tag names.StorageTag, size uint64) error {
+		s.calls = append(s.calls, resizeStorageInstanceCall)
+		return errors.New("boom")
+	}
+	results, err := s.apiv4.Resize(params.StoragesResizeParams{
+		Storages: []params.StorageResizeParams{{
+			StorageTag: "invalid-storage-tag",
+			Size:       2048,
+		}, {
+			StorageTag: s.storageTag.String(),
+			Size:       2048,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `"invalid-storage-tag" is not a valid .*tag`)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, "boom")
+	s.assertCalls(c, []string{getBlockForTypeCall, resizeStorageInstanceCall})
+}
+
+func (s *storageResizeSuite) TestStorageResizeBlocked(c *gc.C) {
+	s.blockAllChanges(c, "TestStorageResizeBlocked")
+	_, err := s.apiv4.Resize(params.StoragesResizeParams{
+		Storages: []params.StorageResizeParams{{
+			StorageTag: s.storageTag.String(),
+			Size:       2048,
+		}},
+	})
+	s.assertBlocked(c, err, "TestStorageResizeBlocked")
+}
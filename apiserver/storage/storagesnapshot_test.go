@@ -0,0 +1,98 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+type storageSnapshotSuite struct {
+	baseStorageSuite
+}
+
+var _ = gc.Suite(&storageSnapshotSuite{})
+
+func (s *storageSnapshotSuite) TestCreateSnapshots(c *gc.C) {
+	var snapshotted []names.StorageTag
+	s.state.createStorageSnapshot = func(tag names.StorageTag) (state.VolumeSnapshot, error) {
+		s.calls = append(s.calls, createStorageSnapshotCall)
+		snapshotted = append(snapshotted, tag)
+		if tag != s.storageTag {
+			return nil, errors.New("boom")
+		}
+		return s.volumeSnapshot, nil
+	}
+	results, err := s.apiv4.CreateSnapshots(params.Entities{
+		Entities: []params.Entity{
+			{Tag: s.storageTag.String()},
+			{Tag: "invalid-storage-tag"},
+			{Tag: "storage-data-1"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Assert(results.Results[0], jc.DeepEquals, params.VolumeSnapshotDetailsResult{
+		Result: &params.VolumeSnapshotDetails{
+			Id:         "0",
+			VolumeTag:  s.volumeTag.String(),
+			StorageTag: s.storageTag.String(),
+			Pool:       "ebs",
+			Created:    time.Date(2016, 11, 1, 0, 0, 0, 0, time.UTC),
+		},
+	})
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `"invalid-storage-tag" is not a valid .*tag`)
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, "boom")
+	c.Assert(snapshotted, jc.DeepEquals, []names.StorageTag{
+		s.storageTag, names.NewStorageTag("data/1"),
+	})
+	s.assertCalls(c, []string{getBlockForTypeCall, createStorageSnapshotCall, createStorageSnapshotCall})
+}
+
+func (s *storageSnapshotSuite) TestCreateSnapshotsBlocked(c *gc.C) {
+	s.blockAllChanges(c, "TestCreateSnapshotsBlocked")
+	_, err := s.apiv4.CreateSnapshots(params.Entities{
+		Entities: []params.Entity{{Tag: s.storageTag.String()}},
+	})
+	s.assertBlocked(c, err, "TestCreateSnapshotsBlocked")
+}
+
+func (s *storageSnapshotSuite) TestListSnapshots(c *gc.C) {
+	s.volumeSnapshot.info = &state.VolumeSnapshotInfo{
+		SnapshotId: "snap-0",
+		Size:       1024,
+	}
+	results, err := s.apiv4.ListSnapshots()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.VolumeSnapshotDetailsResult{{
+		Result: &params.VolumeSnapshotDetails{
+			Id:         "0",
+			VolumeTag:  s.volumeTag.String(),
+			StorageTag: s.storageTag.String(),
+			Pool:       "ebs",
+			Created:    time.Date(2016, 11, 1, 0, 0, 0, 0, time.UTC),
+			Info: &params.VolumeSnapshotInfo{
+				SnapshotId: "snap-0",
+				Size:       1024,
+			},
+		},
+	}})
+	s.assertCalls(c, []string{allVolumeSnapshotsCall})
+}
+
+func (s *storageSnapshotSuite) TestListSnapshotsError(c *gc.C) {
+	s.state.allVolumeSnapshots = func() ([]state.VolumeSnapshot, error) {
+		s.calls = append(s.calls, allVolumeSnapshotsCall)
+		return nil, errors.New("boom")
+	}
+	_, err := s.apiv4.ListSnapshots()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
@@ -4,6 +4,8 @@
 package storageprovisioner
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 
@@ -14,6 +16,7 @@ import (
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/stateenvirons"
+	"github.com/juju/juju/status"
 	"github.com/juju/juju/storage/poolmanager"
 )
 
@@ -24,6 +27,7 @@ import (
 
 func init() {
 	common.RegisterStandardFacade("StorageProvisioner", 3, newStorageProvisionerAPI)
+	common.RegisterStandardFacade("StorageProvisioner", 4, newStorageProvisionerAPIV4)
 }
 
 func newStorageProvisionerAPI(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*StorageProvisionerAPI, error) {
@@ -36,6 +40,14 @@ func newStorageProvisionerAPI(st *state.State, resources facade.Resources, autho
 	return NewStorageProvisionerAPI(stateShim{st}, resources, authorizer, registry, pm)
 }
 
+func newStorageProvisionerAPIV4(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*StorageProvisionerAPIV4, error) {
+	api, err := newStorageProvisionerAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &StorageProvisionerAPIV4{api}, nil
+}
+
 type Backend interface {
 	state.EntityFinder
 	state.ModelAccessor
@@ -52,10 +64,13 @@ type Backend interface {
 	WatchMachineFilesystems(names.MachineTag) state.StringsWatcher
 	WatchMachineFilesystemAttachments(names.MachineTag) state.StringsWatcher
 	WatchModelVolumes() state.StringsWatcher
+	WatchModelVolumeResizes() state.StringsWatcher
 	WatchEnvironVolumeAttachments() state.StringsWatcher
 	WatchMachineVolumes(names.MachineTag) state.StringsWatcher
+	WatchMachineVolumeResizes(names.MachineTag) state.StringsWatcher
 	WatchMachineVolumeAttachments(names.MachineTag) state.StringsWatcher
 	WatchVolumeAttachment(names.MachineTag, names.VolumeTag) state.NotifyWatcher
+	WatchVolumeSnapshots() state.StringsWatcher
 
 	StorageInstance(names.StorageTag) (state.StorageInstance, error)
 
@@ -65,6 +80,7 @@ type Backend interface {
 	Volume(names.VolumeTag) (state.Volume, error)
 	VolumeAttachment(names.MachineTag, names.VolumeTag) (state.VolumeAttachment, error)
 	VolumeAttachments(names.VolumeTag) ([]state.VolumeAttachment, error)
+	VolumeSnapshot(string) (state.VolumeSnapshot, error)
 
 	RemoveFilesystem(names.FilesystemTag) error
 	RemoveFilesystemAttachment(names.MachineTag, names.FilesystemTag) error
@@ -75,6 +91,8 @@ type Backend interface {
 	SetFilesystemAttachmentInfo(names.MachineTag, names.FilesystemTag, state.FilesystemAttachmentInfo) error
 	SetVolumeInfo(names.VolumeTag, state.VolumeInfo) error
 	SetVolumeAttachmentInfo(names.MachineTag, names.VolumeTag, state.VolumeAttachmentInfo) error
+	SetVolumeSnapshotInfo(string, state.VolumeSnapshotInfo) error
+	SetVolumeSnapshotStatus(string, status.Status, string, map[string]interface{}, *time.Time) error
 }
 
 type stateShim struct {
@@ -4,6 +4,8 @@
 package storageprovisioner
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"gopkg.in/juju/names.v2"
@@ -12,8 +14,10 @@ import (
 	"github.com/juju/juju/apiserver/common/storagecommon"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/watcher"
+	"github.com/juju/juju/status"
 	"github.com/juju/juju/storage"
 	"github.com/juju/juju/storage/poolmanager"
 )
@@ -173,6 +177,29 @@ func NewStorageProvisionerAPI(
 	}, nil
 }
 
+// StorageProvisionerAPIV4 provides version 4 of the StorageProvisioner
+// API facade, which adds the methods for resizing volumes and taking
+// volume snapshots.
+type StorageProvisionerAPIV4 struct {
+	*StorageProvisionerAPI
+}
+
+// NewStorageProvisionerAPIV4 creates a new server-side StorageProvisioner
+// API facade, version 4.
+func NewStorageProvisionerAPIV4(
+	st Backend,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+	registry storage.ProviderRegistry,
+	poolManager poolmanager.PoolManager,
+) (*StorageProvisionerAPIV4, error) {
+	api, err := NewStorageProvisionerAPI(st, resources, authorizer, registry, poolManager)
+	if err != nil {
+		return nil, err
+	}
+	return &StorageProvisionerAPIV4{api}, nil
+}
+
 // WatchBlockDevices watches for changes to the specified machines' block devices.
 func (s *StorageProvisionerAPI) WatchBlockDevices(args params.Entities) (params.NotifyWatchResults, error) {
 	canAccess, err := s.getBlockDevicesAuthFunc()
@@ -260,6 +287,49 @@ func (s *StorageProvisionerAPI) WatchFilesystems(args params.Entities) (params.S
 	return s.watchStorageEntities(args, s.st.WatchModelFilesystems, s.st.WatchMachineFilesystems)
 }
 
+// WatchVolumeResizes watches for changes to volumes scoped to the
+// entity with the tag passed to NewState, so that pending resize
+// requests may be acted upon.
+func (s *StorageProvisionerAPIV4) WatchVolumeResizes(args params.Entities) (params.StringsWatchResults, error) {
+	return s.watchStorageEntities(args, s.st.WatchModelVolumeResizes, s.st.WatchMachineVolumeResizes)
+}
+
+// WatchVolumeSnapshots watches for the creation of, and changes to,
+// volume snapshots in the model. Only the model's storage provisioner
+// may watch volume snapshots.
+func (s *StorageProvisionerAPIV4) WatchVolumeSnapshots(args params.Entities) (params.StringsWatchResults, error) {
+	canAccess, err := s.getScopeAuthFunc()
+	if err != nil {
+		return params.StringsWatchResults{}, common.ServerError(common.ErrPerm)
+	}
+	results := params.StringsWatchResults{
+		Results: make([]params.StringsWatchResult, len(args.Entities)),
+	}
+	one := func(arg params.Entity) (string, []string, error) {
+		tag, err := names.ParseModelTag(arg.Tag)
+		if err != nil || !canAccess(tag) {
+			return "", nil, common.ErrPerm
+		}
+		w := s.st.WatchVolumeSnapshots()
+		if changes, ok := <-w.Changes(); ok {
+			return s.resources.Register(w), changes, nil
+		}
+		return "", nil, watcher.EnsureErr(w)
+	}
+	for i, arg := range args.Entities {
+		var result params.StringsWatchResult
+		id, changes, err := one(arg)
+		if err != nil {
+			result.Error = common.ServerError(err)
+		} else {
+			result.StringsWatcherId = id
+			result.Changes = changes
+		}
+		results.Results[i] = result
+	}
+	return results, nil
+}
+
 func (s *StorageProvisionerAPI) watchStorageEntities(
 	args params.Entities,
 	watchEnvironStorage func() state.StringsWatcher,
@@ -622,6 +692,169 @@ func (s *StorageProvisionerAPI) VolumeParams(args params.Entities) (params.Volum
 	return results, nil
 }
 
+// VolumeResizeParams returns the parameters for resizing the volumes
+// with the specified tags. An error satisfying params.IsCodeNotFound
+// is returned for volumes that have no pending resize request.
+func (s *StorageProvisionerAPIV4) VolumeResizeParams(args params.Entities) (params.VolumeResizeParamsResults, error) {
+	canAccess, err := s.getStorageEntityAuthFunc()
+	if err != nil {
+		return params.VolumeResizeParamsResults{}, err
+	}
+	results := params.VolumeResizeParamsResults{
+		Results: make([]params.VolumeResizeParamsResult, len(args.Entities)),
+	}
+	one := func(arg params.Entity) (params.VolumeResizeParams, error) {
+		tag, err := names.ParseVolumeTag(arg.Tag)
+		if err != nil || !canAccess(tag) {
+			return params.VolumeResizeParams{}, common.ErrPerm
+		}
+		volume, err := s.st.Volume(tag)
+		if errors.IsNotFound(err) {
+			return params.VolumeResizeParams{}, common.ErrPerm
+		} else if err != nil {
+			return params.VolumeResizeParams{}, err
+		}
+		size, ok := volume.RequestedSize()
+		if !ok {
+			return params.VolumeResizeParams{}, errors.NotFoundf("resize request for volume %q", tag.Id())
+		}
+		volumeInfo, err := volume.Info()
+		if err != nil {
+			return params.VolumeResizeParams{}, err
+		}
+		providerType, cfg, err := storagecommon.StoragePoolConfig(
+			volumeInfo.Pool, s.poolManager, s.registry,
+		)
+		if err != nil {
+			return params.VolumeResizeParams{}, errors.Trace(err)
+		}
+		resizeParams := params.VolumeResizeParams{
+			VolumeTag:  tag.String(),
+			VolumeId:   volumeInfo.VolumeId,
+			Size:       size,
+			Provider:   string(providerType),
+			Attributes: cfg.Attrs(),
+		}
+		volumeAttachments, err := s.st.VolumeAttachments(tag)
+		if err != nil {
+			return params.VolumeResizeParams{}, err
+		}
+		if len(volumeAttachments) == 1 {
+			// Some providers can only resize volumes by way of
+			// the machine they are attached to.
+			volumeAttachment := volumeAttachments[0]
+			machineTag := volumeAttachment.Machine()
+			instanceId, err := s.st.MachineInstanceId(machineTag)
+			if err != nil {
+				return params.VolumeResizeParams{}, err
+			}
+			var readOnly bool
+			if info, err := volumeAttachment.Info(); err == nil {
+				readOnly = info.ReadOnly
+			}
+			resizeParams.Attachment = &params.VolumeAttachmentParams{
+				tag.String(),
+				machineTag.String(),
+				volumeInfo.VolumeId,
+				string(instanceId),
+				string(providerType),
+				readOnly,
+			}
+		}
+		return resizeParams, nil
+	}
+	for i, arg := range args.Entities {
+		var result params.VolumeResizeParamsResult
+		resizeParams, err := one(arg)
+		if err != nil {
+			result.Error = common.ServerError(err)
+		} else {
+			result.Result = resizeParams
+		}
+		results.Results[i] = result
+	}
+	return results, nil
+}
+
+// VolumeSnapshotParams returns the parameters for taking the volume
+// snapshots with the specified IDs. An error satisfying
+// params.IsCodeNotFound is returned for snapshots that have already
+// been taken.
+func (s *StorageProvisionerAPIV4) VolumeSnapshotParams(args params.VolumeSnapshotIds) (params.VolumeSnapshotParamsResults, error) {
+	if !s.authorizer.AuthModelManager() {
+		return params.VolumeSnapshotParamsResults{}, common.ErrPerm
+	}
+	modelCfg, err := s.st.ModelConfig()
+	if err != nil {
+		return params.VolumeSnapshotParamsResults{}, err
+	}
+	controllerCfg, err := s.st.ControllerConfig()
+	if err != nil {
+		return params.VolumeSnapshotParamsResults{}, err
+	}
+	results := params.VolumeSnapshotParamsResults{
+		Results: make([]params.VolumeSnapshotParamsResult, len(args.Ids)),
+	}
+	one := func(id string) (params.VolumeSnapshotParams, error) {
+		snapshot, err := s.st.VolumeSnapshot(id)
+		if err != nil {
+			return params.VolumeSnapshotParams{}, err
+		}
+		if _, err := snapshot.Info(); err == nil {
+			return params.VolumeSnapshotParams{}, errors.NotFoundf("pending volume snapshot %q", id)
+		}
+		volume, err := s.st.Volume(snapshot.Volume())
+		if err != nil {
+			return params.VolumeSnapshotParams{}, err
+		}
+		volumeInfo, err := volume.Info()
+		if err != nil {
+			return params.VolumeSnapshotParams{}, err
+		}
+		storageInstance, err := storagecommon.MaybeAssignedStorageInstance(
+			volume.StorageInstance,
+			s.st.StorageInstance,
+		)
+		if err != nil {
+			return params.VolumeSnapshotParams{}, err
+		}
+		volumeParams, err := storagecommon.VolumeParams(
+			volume, storageInstance, modelCfg.UUID(), controllerCfg.ControllerUUID(),
+			modelCfg, s.poolManager, s.registry,
+		)
+		if err != nil {
+			return params.VolumeSnapshotParams{}, err
+		}
+		// The snapshot's ID is recorded in its tags, so that the
+		// provider may find a snapshot that was taken but not
+		// recorded in state.
+		snapshotTags := make(map[string]string)
+		for k, v := range volumeParams.Tags {
+			snapshotTags[k] = v
+		}
+		snapshotTags[tags.JujuVolumeSnapshot] = id
+		return params.VolumeSnapshotParams{
+			Id:         id,
+			VolumeTag:  volumeParams.VolumeTag,
+			VolumeId:   volumeInfo.VolumeId,
+			Provider:   volumeParams.Provider,
+			Attributes: volumeParams.Attributes,
+			Tags:       snapshotTags,
+		}, nil
+	}
+	for i, id := range args.Ids {
+		var result params.VolumeSnapshotParamsResult
+		snapshotParams, err := one(id)
+		if err != nil {
+			result.Error = common.ServerError(err)
+		} else {
+			result.Result = snapshotParams
+		}
+		results.Results[i] = result
+	}
+	return results, nil
+}
+
 // FilesystemParams returns the parameters for creating the filesystems
 // with the specified tags.
 func (s *StorageProvisionerAPI) FilesystemParams(args params.Entities) (params.FilesystemParamsResults, error) {
@@ -943,6 +1176,14 @@ func (s *StorageProvisionerAPI) SetVolumeInfo(args params.Volumes) (params.Error
 		} else if !canAccessVolume(volumeTag) {
 			return common.ErrPerm
 		}
+		if volume, err := s.st.Volume(volumeTag); err == nil {
+			if oldInfo, err := volume.Info(); err == nil {
+				// The volume has already been provisioned, and its
+				// info is being updated (e.g. after a resize). The
+				// pool is immutable, and unknown to the worker.
+				volumeInfo.Pool = oldInfo.Pool
+			}
+		}
 		err = s.st.SetVolumeInfo(volumeTag, volumeInfo)
 		if errors.IsNotFound(err) {
 			return common.ErrPerm
@@ -956,6 +1197,42 @@ func (s *StorageProvisionerAPI) SetVolumeInfo(args params.Volumes) (params.Error
 	return results, nil
 }
 
+// SetVolumeSnapshotInfo records the details of newly taken volume
+// snapshots.
+func (s *StorageProvisionerAPIV4) SetVolumeSnapshotInfo(args params.VolumeSnapshots) (params.ErrorResults, error) {
+	if !s.authorizer.AuthModelManager() {
+		return params.ErrorResults{}, common.ErrPerm
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.VolumeSnapshots)),
+	}
+	for i, arg := range args.VolumeSnapshots {
+		err := s.st.SetVolumeSnapshotInfo(arg.Id, state.VolumeSnapshotInfo{
+			SnapshotId: arg.Info.SnapshotId,
+			Size:       arg.Info.Size,
+		})
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// SetVolumeSnapshotStatus sets the status of pending volume snapshots,
+// so that failures to take them are visible to the user.
+func (s *StorageProvisionerAPIV4) SetVolumeSnapshotStatus(args params.VolumeSnapshotStatuses) (params.ErrorResults, error) {
+	if !s.authorizer.AuthModelManager() {
+		return params.ErrorResults{}, common.ErrPerm
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Statuses)),
+	}
+	now := time.Now()
+	for i, arg := range args.Statuses {
+		err := s.st.SetVolumeSnapshotStatus(arg.Id, status.Status(arg.Status), arg.Info, nil, &now)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
 // SetFilesystemInfo records the details of newly provisioned filesystems.
 func (s *StorageProvisionerAPI) SetFilesystemInfo(args params.Filesystems) (params.ErrorResults, error) {
 	canAccessFilesystem, err := s.getStorageEntityAuthFunc()
@@ -972,6 +1249,13 @@ func (s *StorageProvisionerAPI) SetFilesystemInfo(args params.Filesystems) (para
 		} else if !canAccessFilesystem(filesystemTag) {
 			return common.ErrPerm
 		}
+		if filesystem, err := s.st.Filesystem(filesystemTag); err == nil {
+			if oldInfo, err := filesystem.Info(); err == nil {
+				// The filesystem has already been provisioned, and
+				// its info is being updated (e.g. after a resize).
+				filesystemInfo.Pool = oldInfo.Pool
+			}
+		}
 		err = s.st.SetFilesystemInfo(filesystemTag, filesystemInfo)
 		if errors.IsNotFound(err) {
 			return common.ErrPerm
@@ -22,6 +22,7 @@ import (
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/stateenvirons"
 	statetesting "github.com/juju/juju/state/testing"
+	"github.com/juju/juju/status"
 	"github.com/juju/juju/storage"
 	"github.com/juju/juju/storage/poolmanager"
 	"github.com/juju/juju/testing"
@@ -38,6 +39,7 @@ type provisionerSuite struct {
 	resources  *common.Resources
 	authorizer *apiservertesting.FakeAuthorizer
 	api        *storageprovisioner.StorageProvisionerAPI
+	apiv4      *storageprovisioner.StorageProvisionerAPIV4
 }
 
 func (s *provisionerSuite) SetUpTest(c *gc.C) {
@@ -61,6 +63,8 @@ func (s *provisionerSuite) SetUpTest(c *gc.C) {
 	backend := storageprovisioner.NewStateBackend(s.State)
 	s.api, err = storageprovisioner.NewStorageProvisionerAPI(backend, s.resources, s.authorizer, registry, pm)
 	c.Assert(err, jc.ErrorIsNil)
+	s.apiv4, err = storageprovisioner.NewStorageProvisionerAPIV4(backend, s.resources, s.authorizer, registry, pm)
+	c.Assert(err, jc.ErrorIsNil)
 }
 
 func (s *provisionerSuite) TestNewStorageProvisionerAPINonMachine(c *gc.C) {
@@ -409,6 +413,104 @@ func (s *provisionerSuite) TestVolumeParamsEmptyArgs(c *gc.C) {
 	c.Assert(results.Results, gc.HasLen, 0)
 }
 
+func (s *provisionerSuite) TestVolumeResizeParams(c *gc.C) {
+	s.setupVolumes(c)
+	err := s.State.ResizeVolume(names.NewVolumeTag("0/0"), 2048)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.apiv4.VolumeResizeParams(params.Entities{
+		Entities: []params.Entity{
+			{"volume-0-0"},
+			{"volume-2"},
+			{"volume-42"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.VolumeResizeParamsResults{
+		Results: []params.VolumeResizeParamsResult{
+			{Result: params.VolumeResizeParams{
+				VolumeTag: "volume-0-0",
+				VolumeId:  "abc",
+				Size:      2048,
+				Provider:  "machinescoped",
+				Attachment: &params.VolumeAttachmentParams{
+					MachineTag: "machine-0",
+					VolumeTag:  "volume-0-0",
+					VolumeId:   "abc",
+					Provider:   "machinescoped",
+					InstanceId: "inst-id",
+				},
+			}},
+			{Error: &params.Error{Message: `resize request for volume "2" not found`, Code: "not found"}},
+			{Error: &params.Error{Message: "permission denied", Code: "unauthorized access"}},
+		},
+	})
+}
+
+func (s *provisionerSuite) TestWatchVolumeResizes(c *gc.C) {
+	s.setupVolumes(c)
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+
+	args := params.Entities{Entities: []params.Entity{
+		{"machine-0"},
+		{s.State.ModelTag().String()},
+		{"machine-42"}},
+	}
+	result, err := s.apiv4.WatchVolumeResizes(args)
+	c.Assert(err, jc.ErrorIsNil)
+	sort.Strings(result.Results[1].Changes)
+	c.Assert(result, jc.DeepEquals, params.StringsWatchResults{
+		Results: []params.StringsWatchResult{
+			{StringsWatcherId: "1", Changes: []string{"0/0"}},
+			{StringsWatcherId: "2", Changes: []string{"1", "2", "3", "4"}},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	c.Assert(s.resources.Count(), gc.Equals, 2)
+	v0Watcher := s.resources.Get("1")
+	defer statetesting.AssertStop(c, v0Watcher)
+	v1Watcher := s.resources.Get("2")
+	defer statetesting.AssertStop(c, v1Watcher)
+
+	wc := statetesting.NewStringsWatcherC(c, s.State, v0Watcher.(state.StringsWatcher))
+	wc.AssertNoChange()
+	err = s.State.ResizeVolume(names.NewVolumeTag("0/0"), 2048)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChangeInSingleEvent("0/0")
+}
+
+func (s *provisionerSuite) TestSetVolumeInfoResized(c *gc.C) {
+	s.setupVolumes(c)
+	err := s.State.ResizeVolume(names.NewVolumeTag("0/0"), 2048)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.api.SetVolumeInfo(params.Volumes{
+		Volumes: []params.Volume{{
+			VolumeTag: "volume-0-0",
+			Info: params.VolumeInfo{
+				VolumeId:   "abc",
+				HardwareId: "123",
+				Size:       2048,
+				Persistent: true,
+			},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{{}},
+	})
+
+	volume, err := s.State.Volume(names.NewVolumeTag("0/0"))
+	c.Assert(err, jc.ErrorIsNil)
+	info, err := volume.Info()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.Size, gc.Equals, uint64(2048))
+	c.Assert(info.Pool, gc.Equals, "machinescoped")
+	_, ok := volume.RequestedSize()
+	c.Assert(ok, jc.IsFalse)
+}
+
 func (s *provisionerSuite) TestFilesystemParams(c *gc.C) {
 	s.setupFilesystems(c)
 	results, err := s.api.FilesystemParams(params.Entities{
@@ -671,6 +773,143 @@ func (s *provisionerSuite) TestSetFilesystemAttachmentInfo(c *gc.C) {
 	})
 }
 
+func (s *provisionerSuite) TestWatchVolumeSnapshots(c *gc.C) {
+	s.setupVolumes(c)
+	_, err := s.State.CreateVolumeSnapshot(names.NewVolumeTag("2"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+
+	args := params.Entities{Entities: []params.Entity{
+		{"machine-0"},
+		{s.State.ModelTag().String()},
+	}}
+	result, err := s.apiv4.WatchVolumeSnapshots(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.StringsWatchResults{
+		Results: []params.StringsWatchResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{StringsWatcherId: "1", Changes: []string{"0"}},
+		},
+	})
+
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+	w := s.resources.Get("1")
+	defer statetesting.AssertStop(c, w)
+
+	wc := statetesting.NewStringsWatcherC(c, s.State, w.(state.StringsWatcher))
+	wc.AssertNoChange()
+	_, err = s.State.CreateVolumeSnapshot(names.NewVolumeTag("2"))
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChangeInSingleEvent("1")
+}
+
+func (s *provisionerSuite) TestVolumeSnapshotParams(c *gc.C) {
+	s.setupVolumes(c)
+	_, err := s.State.CreateVolumeSnapshot(names.NewVolumeTag("2"))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.CreateVolumeSnapshot(names.NewVolumeTag("2"))
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetVolumeSnapshotInfo("1", state.VolumeSnapshotInfo{SnapshotId: "snap-1"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.apiv4.VolumeSnapshotParams(params.VolumeSnapshotIds{
+		Ids: []string{"0", "1", "42"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.VolumeSnapshotParamsResults{
+		Results: []params.VolumeSnapshotParamsResult{
+			{Result: params.VolumeSnapshotParams{
+				Id:        "0",
+				VolumeTag: "volume-2",
+				VolumeId:  "def",
+				Provider:  "environscoped",
+				Tags: map[string]string{
+					tags.JujuController:     testing.ModelTag.Id(),
+					tags.JujuModel:          testing.ModelTag.Id(),
+					tags.JujuVolumeSnapshot: "0",
+				},
+			}},
+			{Error: &params.Error{
+				Code:    params.CodeNotFound,
+				Message: `pending volume snapshot "1" not found`,
+			}},
+			{Error: &params.Error{
+				Code:    params.CodeNotFound,
+				Message: `volume snapshot "42" not found`,
+			}},
+		},
+	})
+}
+
+func (s *provisionerSuite) TestSetVolumeSnapshotInfo(c *gc.C) {
+	s.setupVolumes(c)
+	_, err := s.State.CreateVolumeSnapshot(names.NewVolumeTag("2"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.apiv4.SetVolumeSnapshotInfo(params.VolumeSnapshots{
+		VolumeSnapshots: []params.VolumeSnapshot{{
+			Id:        "0",
+			VolumeTag: "volume-2",
+			Info:      params.VolumeSnapshotInfo{SnapshotId: "snap-0", Size: 4096},
+		}, {
+			Id:        "42",
+			VolumeTag: "volume-2",
+			Info:      params.VolumeSnapshotInfo{SnapshotId: "snap-42", Size: 4096},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{
+				Code:    params.CodeNotFound,
+				Message: `cannot set info for volume snapshot "42": volume snapshot "42" not found`,
+			}},
+		},
+	})
+
+	snapshot, err := s.State.VolumeSnapshot("0")
+	c.Assert(err, jc.ErrorIsNil)
+	info, err := snapshot.Info()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info, jc.DeepEquals, state.VolumeSnapshotInfo{SnapshotId: "snap-0", Size: 4096})
+}
+
+func (s *provisionerSuite) TestSetVolumeSnapshotStatus(c *gc.C) {
+	s.setupVolumes(c)
+	_, err := s.State.CreateVolumeSnapshot(names.NewVolumeTag("2"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.apiv4.SetVolumeSnapshotStatus(params.VolumeSnapshotStatuses{
+		Statuses: []params.VolumeSnapshotStatus{{
+			Id:     "0",
+			Status: "error",
+			Info:   "snapshot quota exceeded",
+		}, {
+			Id:     "42",
+			Status: "error",
+			Info:   "snapshot quota exceeded",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{
+				Code:    params.CodeNotFound,
+				Message: `volume snapshot "42" not found`,
+			}},
+		},
+	})
+
+	snapshot, err := s.State.VolumeSnapshot("0")
+	c.Assert(err, jc.ErrorIsNil)
+	statusInfo, err := snapshot.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(statusInfo.Status, gc.Equals, status.StatusError)
+	c.Assert(statusInfo.Message, gc.Equals, "snapshot quota exceeded")
+}
+
 func (s *provisionerSuite) TestWatchVolumes(c *gc.C) {
 	s.setupVolumes(c)
 	s.factory.MakeMachine(c, nil)
@@ -29,6 +29,7 @@ var logger = loggo.GetLogger("juju.apiserver.uniter")
 
 func init() {
 	common.RegisterStandardFacade("Uniter", 4, NewUniterAPIV4)
+	common.RegisterStandardFacade("Uniter", 5, NewUniterAPIV5)
 }
 
 // UniterAPIV3 implements the API version 3, used by the uniter worker.
@@ -140,6 +141,21 @@ func NewUniterAPIV4(st *state.State, resources facade.Resources, authorizer faca
 	}, nil
 }
 
+// UniterAPIV5 implements the API version 5, used by the uniter worker,
+// which adds RecordHookRuns.
+type UniterAPIV5 struct {
+	*UniterAPIV3
+}
+
+// NewUniterAPIV5 creates a new instance of the Uniter API, version 5.
+func NewUniterAPIV5(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*UniterAPIV5, error) {
+	api, err := NewUniterAPIV4(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV5{api}, nil
+}
+
 // AllMachinePorts returns all opened port ranges for each given
 // machine (on all networks).
 func (u *UniterAPIV3) AllMachinePorts(args params.Entities) (params.MachinePortsResults, error) {
@@ -469,6 +485,42 @@ func (u *UniterAPIV3) Destroy(args params.Entities) (params.ErrorResults, error)
 	return result, nil
 }
 
+// RecordHookRuns records the execution of hooks and actions by
+// each given unit.
+func (u *UniterAPIV5) RecordHookRuns(args params.RecordHookRunArgs) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	for i, arg := range args.Args {
+		tag, err := names.ParseUnitTag(arg.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		err = common.ErrPerm
+		if canAccess(tag) {
+			var unit *state.Unit
+			unit, err = u.getUnit(tag)
+			if err == nil {
+				err = unit.RecordHookRun(state.HookRun{
+					Kind:     arg.Run.Kind,
+					Name:     arg.Run.Name,
+					Started:  arg.Run.Started,
+					Finished: arg.Run.Finished,
+					ExitCode: arg.Run.ExitCode,
+					Error:    arg.Run.Error,
+				})
+			}
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
 // DestroyAllSubordinates destroys all subordinates of each given unit.
 func (u *UniterAPIV3) DestroyAllSubordinates(args params.Entities) (params.ErrorResults, error) {
 	result := params.ErrorResults{
@@ -734,7 +786,9 @@ func (u *UniterAPIV3) OpenPorts(args params.EntitiesPortRanges) (params.ErrorRes
 		if canAccess(tag) {
 			var unit *state.Unit
 			unit, err = u.getUnit(tag)
-			if err == nil {
+			if err == nil && entity.Endpoint != "" {
+				err = unit.OpenEndpointPorts(entity.Endpoint, entity.Protocol, entity.FromPort, entity.ToPort)
+			} else if err == nil {
 				err = unit.OpenPorts(entity.Protocol, entity.FromPort, entity.ToPort)
 			}
 		}
@@ -673,6 +673,45 @@ func (s *uniterSuite) TestDestroy(c *gc.C) {
 	c.Assert(err, jc.Satisfies, errors.IsNotFound)
 }
 
+func (s *uniterSuite) TestRecordHookRuns(c *gc.C) {
+	started := time.Date(2016, 10, 1, 12, 0, 0, 0, time.UTC)
+	run := params.HookRun{
+		Kind:     params.HookRunKindHook,
+		Name:     "config-changed",
+		Started:  started,
+		Finished: started.Add(time.Second),
+		ExitCode: 1,
+		Error:    "exit status 1",
+	}
+	args := params.RecordHookRunArgs{Args: []params.RecordHookRunArg{
+		{Tag: "unit-mysql-0", Run: run},
+		{Tag: "unit-wordpress-0", Run: run},
+		{Tag: "unit-foo-42", Run: run},
+	}}
+	uniterAPIV5, err := uniter.NewUniterAPIV5(s.State, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	result, err := uniterAPIV5.RecordHookRuns(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{apiservertesting.ErrUnauthorized},
+			{nil},
+			{apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	runs, err := s.wordpressUnit.HookRuns()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(runs, jc.DeepEquals, []state.HookRun{{
+		Kind:     "hook",
+		Name:     "config-changed",
+		Started:  started,
+		Finished: started.Add(time.Second),
+		ExitCode: 1,
+		Error:    "exit status 1",
+	}})
+}
+
 func (s *uniterSuite) TestDestroyAllSubordinates(c *gc.C) {
 	// Add two subordinates to wordpressUnit.
 	_, _, loggingSub := s.addRelatedService(c, "wordpress", "logging", s.wordpressUnit)
@@ -882,6 +921,24 @@ func (s *uniterSuite) TestOpenPorts(c *gc.C) {
 	})
 }
 
+func (s *uniterSuite) TestOpenEndpointPorts(c *gc.C) {
+	args := params.EntitiesPortRanges{Entities: []params.EntityPortRange{
+		{Tag: "unit-wordpress-0", Protocol: "tcp", FromPort: 80, ToPort: 80, Endpoint: "url"},
+		{Tag: "unit-wordpress-0", Protocol: "tcp", FromPort: 81, ToPort: 81, Endpoint: "foo"},
+	}}
+	result, err := s.uniter.OpenPorts(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[1].Error, gc.ErrorMatches, `application "wordpress" has no "foo" relation`)
+
+	ports, err := s.machine0.OpenedPorts("")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ports.PortRangeEndpoints(), jc.DeepEquals, map[network.PortRange]string{
+		{Protocol: "tcp", FromPort: 80, ToPort: 80}: "url",
+	})
+}
+
 func (s *uniterSuite) TestClosePorts(c *gc.C) {
 	// Open port udp:4321 in advance on wordpressUnit.
 	err := s.wordpressUnit.OpenPorts("udp", 4321, 5000)
@@ -0,0 +1,48 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package audit
+
+import (
+	"strings"
+	"time"
+)
+
+// Filter selects audit entries. Unset fields select all entries.
+type Filter struct {
+	// OriginName selects the entries triggered by the named origin,
+	// e.g. "user-bob@local".
+	OriginName string
+
+	// ModelUUID selects the entries written on the identified model.
+	ModelUUID string
+
+	// Operation selects the entries whose operation begins with the
+	// given value, e.g. "Application" or "Client:v1 - FullStatus".
+	Operation string
+
+	// Since selects the entries recorded at or after the given time.
+	Since time.Time
+
+	// Limit is the maximum number of entries to select. If it is
+	// zero then there is no limit.
+	Limit int
+}
+
+// Match returns true if the entry is selected by the filter. Limit
+// is not considered.
+func (f Filter) Match(e AuditEntry) bool {
+	if f.OriginName != "" && e.OriginName != f.OriginName {
+		return false
+	}
+	if f.ModelUUID != "" && e.ModelUUID != f.ModelUUID {
+		return false
+	}
+	if !strings.HasPrefix(e.Operation, f.Operation) {
+		return false
+	}
+	if e.Timestamp.Before(f.Since) {
+		return false
+	}
+	return true
+}
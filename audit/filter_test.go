@@ -0,0 +1,52 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package audit_test
+
+import (
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/audit"
+)
+
+type filterSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&filterSuite{})
+
+func (s *filterSuite) TestMatchZero(c *gc.C) {
+	var filter audit.Filter
+	c.Check(filter.Match(validEntry()), jc.IsTrue)
+}
+
+func (s *filterSuite) TestMatchOriginName(c *gc.C) {
+	entry := validEntry()
+	c.Check(audit.Filter{OriginName: entry.OriginName}.Match(entry), jc.IsTrue)
+	c.Check(audit.Filter{OriginName: "user-mallory"}.Match(entry), jc.IsFalse)
+}
+
+func (s *filterSuite) TestMatchModelUUID(c *gc.C) {
+	entry := validEntry()
+	c.Check(audit.Filter{ModelUUID: entry.ModelUUID}.Match(entry), jc.IsTrue)
+	c.Check(audit.Filter{ModelUUID: "deadbeef-0bad-400d-8000-4b1d0d06f00d"}.Match(entry), jc.IsFalse)
+}
+
+func (s *filterSuite) TestMatchOperationPrefix(c *gc.C) {
+	entry := validEntry()
+	entry.Operation = "Client:v1 - FullStatus"
+	c.Check(audit.Filter{Operation: "Client"}.Match(entry), jc.IsTrue)
+	c.Check(audit.Filter{Operation: "Client:v1 - FullStatus"}.Match(entry), jc.IsTrue)
+	c.Check(audit.Filter{Operation: "Application"}.Match(entry), jc.IsFalse)
+}
+
+func (s *filterSuite) TestMatchSince(c *gc.C) {
+	entry := validEntry()
+	c.Check(audit.Filter{Since: entry.Timestamp}.Match(entry), jc.IsTrue)
+	c.Check(audit.Filter{Since: entry.Timestamp.Add(-time.Second)}.Match(entry), jc.IsTrue)
+	c.Check(audit.Filter{Since: entry.Timestamp.Add(time.Second)}.Match(entry), jc.IsFalse)
+}
@@ -0,0 +1,82 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// RedactedValue replaces the values of redacted fields.
+const RedactedValue = "(redacted)"
+
+// RedactedKeys holds the fragments of object keys whose values must
+// not be recorded in audit entries. Keys are matched without regard
+// to case, and a key is redacted if it contains any of the fragments,
+// so that charm settings such as "admin-password" are covered as
+// well as API parameters. Settings passed as YAML documents, such as
+// "config-yaml" and "settings-yaml", are redacted whole, as secrets
+// within them cannot be told apart.
+var RedactedKeys = []string{
+	"credential",
+	"macaroon",
+	"password",
+	"private-key",
+	"secret",
+	"token",
+	"-yaml",
+}
+
+// RedactedJSON returns the JSON encoding of v, with the value of every
+// object key that matches RedactedKeys replaced by RedactedValue.
+func RedactedJSON(v interface{}) (string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var generic interface{}
+	if err := decoder.Decode(&generic); err != nil {
+		return "", errors.Trace(err)
+	}
+	data, err = json.Marshal(redact(generic))
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return string(data), nil
+}
+
+// redact replaces, in place, the values of redacted keys found
+// anywhere within v, which must have been decoded from JSON.
+func redact(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if redactedKey(key) {
+				v[key] = RedactedValue
+			} else {
+				v[key] = redact(value)
+			}
+		}
+	case []interface{}:
+		for i, value := range v {
+			v[i] = redact(value)
+		}
+	}
+	return v
+}
+
+func redactedKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, fragment := range RedactedKeys {
+		if strings.Contains(key, fragment) {
+			return true
+		}
+	}
+	return false
+}
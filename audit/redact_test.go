@@ -0,0 +1,82 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package audit_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/audit"
+)
+
+type redactSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&redactSuite{})
+
+func (s *redactSuite) TestRedactedJSON(c *gc.C) {
+	type user struct {
+		Name     string `json:"name"`
+		Password string `json:"password"`
+	}
+	body := struct {
+		Users    []user                 `json:"users"`
+		Settings map[string]interface{} `json:"settings"`
+		Count    int64                  `json:"count"`
+	}{
+		Users: []user{{Name: "bob", Password: "hunter2"}},
+		Settings: map[string]interface{}{
+			"Admin-Password": "sekrit",
+			"port":           8080,
+			"nested": map[string]interface{}{
+				"aws-secret-key": "AKIA",
+				"region":         "us-east-1",
+			},
+		},
+		Count: 9007199254740993,
+	}
+	redacted, err := audit.RedactedJSON(body)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(redacted, gc.Equals, `{`+
+		`"count":9007199254740993,`+
+		`"settings":{"Admin-Password":"(redacted)","nested":{"aws-secret-key":"(redacted)","region":"us-east-1"},"port":8080},`+
+		`"users":[{"name":"bob","password":"(redacted)"}]`+
+		`}`)
+}
+
+func (s *redactSuite) TestRedactedJSONRedactsWholeValue(c *gc.C) {
+	body := map[string]interface{}{
+		"tag": "cloudcred-aws_bob_default",
+		"credential": map[string]interface{}{
+			"auth-type": "access-key",
+			"attrs":     map[string]string{"access-key": "AKIA"},
+		},
+	}
+	redacted, err := audit.RedactedJSON(body)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(redacted, gc.Equals, `{"credential":"(redacted)","tag":"cloudcred-aws_bob_default"}`)
+}
+
+func (s *redactSuite) TestRedactedJSONRedactsYAML(c *gc.C) {
+	body := map[string]interface{}{
+		"application":   "mysql",
+		"config-yaml":   "mysql:\n  root-password: sekrit\n",
+		"Settings-YAML": "mysql:\n  admin-key: AKIA\n",
+	}
+	redacted, err := audit.RedactedJSON(body)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(redacted, gc.Equals, `{"Settings-YAML":"(redacted)","application":"mysql","config-yaml":"(redacted)"}`)
+}
+
+func (s *redactSuite) TestRedactedJSONNonObject(c *gc.C) {
+	redacted, err := audit.RedactedJSON(struct{}{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(redacted, gc.Equals, `{}`)
+
+	redacted, err = audit.RedactedJSON(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(redacted, gc.Equals, `null`)
+}
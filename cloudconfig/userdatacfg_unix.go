@@ -482,7 +482,46 @@ juju-goroutines () {
   jujuAgentCall $agent debug/pprof/goroutine?debug=1
 }
 
+juju-metrics () {
+  if [ "$#" -gt 1 ]; then
+    echo "expected no args (for machine agent) or one (unit agent)"
+    return 1
+  fi
+  local agent=$(jujuMachineAgentName)
+  if [ "$#" -eq 1 ]; then
+    agent=$1
+  fi
+  jujuAgentCall $agent introspection/metrics
+}
+
+juju-engine-report () {
+  if [ "$#" -gt 1 ]; then
+    echo "expected no args (for machine agent) or one (unit agent)"
+    return 1
+  fi
+  local agent=$(jujuMachineAgentName)
+  if [ "$#" -eq 1 ]; then
+    agent=$1
+  fi
+  jujuAgentCall $agent depengine
+}
+
+juju-bounce-worker () {
+  if [ "$#" -lt 1 ] || [ "$#" -gt 2 ]; then
+    echo "expected a worker name, and optionally an agent (defaults to machine agent)"
+    return 1
+  fi
+  local agent=$(jujuMachineAgentName)
+  if [ "$#" -eq 2 ]; then
+    agent=$2
+  fi
+  echo -e "POST /depengine/bounce?name=$1 HTTP/1.0\r\n" | socat abstract-connect:jujud-$agent STDIO
+}
+
 export -f jujuAgentCall
 export -f jujuMachineAgentName
 export -f juju-goroutines
+export -f juju-metrics
+export -f juju-engine-report
+export -f juju-bounce-worker
 `
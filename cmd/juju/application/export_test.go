@@ -32,6 +32,13 @@ func NewAddUnitCommandForTest(api serviceAddUnitAPI) cmd.Command {
 	})
 }
 
+// NewShowUnitCommandForTest returns a ShowUnitCommand with the api provided as specified.
+func NewShowUnitCommandForTest(api showUnitAPI) cmd.Command {
+	return modelcmd.Wrap(&showUnitCommand{
+		api: api,
+	})
+}
+
 type Patcher interface {
 	PatchValue(dest, value interface{})
 }
@@ -4,10 +4,15 @@
 package application
 
 import (
+	"net"
+	"strings"
+
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 
 	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/modelcmd"
 )
@@ -19,8 +24,22 @@ var usageExposeDetails = `
 Adjusts the firewall rules and any relevant security mechanisms of the
 cloud to allow public access to the application.
 
+By default the application's open ports may be reached from anywhere.
+The --to-cidrs option restricts access to the given comma-separated
+list of source CIDRs, and the --endpoints option records that the
+restriction applies to the given comma-separated list of application
+endpoints only. Running expose again merges the new settings with any
+existing ones; unexpose removes all of them.
+
+A port opened for an endpoint (see open-port --endpoint) may be reached
+from the CIDRs given for that endpoint and for all endpoints, and is not
+opened if there are settings for neither. A port opened for all
+endpoints may be reached from the CIDRs given for any endpoint.
+
 Examples:
     juju expose wordpress
+    juju expose wordpress --to-cidrs 10.0.0.0/8,192.168.0.0/16
+    juju expose mysql --endpoints db --to-cidrs 10.0.0.0/8
 
 See also: 
     unexpose`[1:]
@@ -34,6 +53,11 @@ func NewExposeCommand() cmd.Command {
 type exposeCommand struct {
 	modelcmd.ModelCommandBase
 	ApplicationName string
+	Endpoints       []string
+	ToCIDRs         []string
+
+	endpoints string
+	toCIDRs   string
 }
 
 func (c *exposeCommand) Info() *cmd.Info {
@@ -45,17 +69,58 @@ func (c *exposeCommand) Info() *cmd.Info {
 	}
 }
 
+func (c *exposeCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.StringVar(&c.endpoints, "endpoints", "", "Comma-separated list of endpoints the settings apply to")
+	f.StringVar(&c.toCIDRs, "to-cidrs", "", "Comma-separated list of source CIDRs allowed to reach the application")
+}
+
 func (c *exposeCommand) Init(args []string) error {
 	if len(args) == 0 {
 		return errors.New("no application name specified")
 	}
 	c.ApplicationName = args[0]
+	c.Endpoints = splitList(c.endpoints)
+	c.ToCIDRs = splitList(c.toCIDRs)
+	for _, cidr := range c.ToCIDRs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return errors.NotValidf("CIDR %q", cidr)
+		}
+	}
 	return cmd.CheckEmpty(args[1:])
 }
 
+// splitList splits a comma-separated list, ignoring empty items.
+func splitList(list string) []string {
+	var items []string
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// exposedEndpoints returns the expose settings requested on the
+// command line, or nil if there are none.
+func (c *exposeCommand) exposedEndpoints() map[string]params.ExposedEndpoint {
+	if len(c.Endpoints) == 0 && len(c.ToCIDRs) == 0 {
+		return nil
+	}
+	endpoint := params.ExposedEndpoint{ExposeToCIDRs: c.ToCIDRs}
+	if len(c.Endpoints) == 0 {
+		return map[string]params.ExposedEndpoint{"": endpoint}
+	}
+	endpoints := make(map[string]params.ExposedEndpoint)
+	for _, name := range c.Endpoints {
+		endpoints[name] = endpoint
+	}
+	return endpoints
+}
+
 type serviceExposeAPI interface {
 	Close() error
 	Expose(serviceName string) error
+	ExposeTo(serviceName string, endpoints map[string]params.ExposedEndpoint) error
 	Unexpose(serviceName string) error
 }
 
@@ -75,5 +140,11 @@ func (c *exposeCommand) Run(_ *cmd.Context) error {
 		return err
 	}
 	defer client.Close()
-	return block.ProcessBlockedError(client.Expose(c.ApplicationName), block.BlockChange)
+	endpoints := c.exposedEndpoints()
+	if endpoints == nil {
+		err = client.Expose(c.ApplicationName)
+	} else {
+		err = client.ExposeTo(c.ApplicationName, endpoints)
+	}
+	return block.ProcessBlockedError(err, block.BlockChange)
 }
@@ -0,0 +1,167 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+var usageShowUnitSummary = `
+Displays information about a unit.`[1:]
+
+var usageShowUnitDetails = `
+With --hooks, a summary of the hooks and actions recently run by the unit
+is displayed: for each hook or action, the number of times it was run and
+failed, how long it took, and when and how it last finished. The unit's
+agent retains a bounded history of runs, so only recent executions are
+included.
+
+Examples:
+    juju show-unit --hooks mysql/0
+    juju show-unit --hooks --format json mysql/0
+
+See also:
+    debug-hooks
+    status-history`
+
+// NewShowUnitCommand returns a command that displays information about a unit.
+func NewShowUnitCommand() cmd.Command {
+	return modelcmd.Wrap(&showUnitCommand{})
+}
+
+// showUnitCommand displays information about a unit.
+type showUnitCommand struct {
+	modelcmd.ModelCommandBase
+	unitName string
+	hooks    bool
+	out      cmd.Output
+	api      showUnitAPI
+}
+
+// showUnitAPI defines the methods on the client API that the
+// show-unit command calls.
+type showUnitAPI interface {
+	Close() error
+	UnitHookRuns(unitName string) ([]params.HookRun, error)
+}
+
+func (c *showUnitCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "show-unit",
+		Args:    "<unit name>",
+		Purpose: usageShowUnitSummary,
+		Doc:     usageShowUnitDetails,
+	}
+}
+
+func (c *showUnitCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "yaml", output.DefaultFormatters)
+	f.BoolVar(&c.hooks, "hooks", false, "Show a summary of the hooks and actions run by the unit")
+}
+
+func (c *showUnitCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no unit name specified")
+	}
+	c.unitName = args[0]
+	if !names.IsValidUnit(c.unitName) {
+		return errors.Errorf("invalid unit name %q", c.unitName)
+	}
+	if !c.hooks {
+		return errors.New("nothing to show: specify --hooks")
+	}
+	return cmd.CheckEmpty(args[1:])
+}
+
+func (c *showUnitCommand) getAPI() (showUnitAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return application.NewClient(root), nil
+}
+
+// Run fetches the hooks recently run by the unit, and
+// writes a summary of them.
+func (c *showUnitCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	runs, err := client.UnitHookRuns(c.unitName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return c.out.Write(ctx, map[string]interface{}{
+		c.unitName: map[string]interface{}{
+			"hooks": summariseHookRuns(runs),
+		},
+	})
+}
+
+// hookRunSummary summarises the runs of a single hook or action.
+type hookRunSummary struct {
+	Kind            string `yaml:"kind" json:"kind"`
+	Runs            int    `yaml:"runs" json:"runs"`
+	Failures        int    `yaml:"failures" json:"failures"`
+	AverageDuration string `yaml:"average-duration" json:"average-duration"`
+	MaxDuration     string `yaml:"max-duration" json:"max-duration"`
+	LastFinished    string `yaml:"last-finished" json:"last-finished"`
+	LastExitCode    int    `yaml:"last-exit-code" json:"last-exit-code"`
+	LastError       string `yaml:"last-error,omitempty" json:"last-error,omitempty"`
+}
+
+// summariseHookRuns returns a summary of the supplied runs, which
+// are expected to be ordered most recent first, keyed by hook or
+// action name.
+func summariseHookRuns(runs []params.HookRun) map[string]hookRunSummary {
+	type accumulator struct {
+		hookRunSummary
+		total, max time.Duration
+	}
+	accumulators := make(map[string]*accumulator)
+	for _, run := range runs {
+		acc, ok := accumulators[run.Name]
+		if !ok {
+			acc = &accumulator{hookRunSummary: hookRunSummary{
+				Kind:         run.Kind,
+				LastFinished: run.Finished.UTC().Format(time.RFC3339),
+				LastExitCode: run.ExitCode,
+				LastError:    run.Error,
+			}}
+			accumulators[run.Name] = acc
+		}
+		duration := run.Finished.Sub(run.Started)
+		acc.Runs++
+		acc.total += duration
+		if duration > acc.max {
+			acc.max = duration
+		}
+		if run.Error != "" || run.ExitCode != 0 {
+			acc.Failures++
+		}
+	}
+	summary := make(map[string]hookRunSummary)
+	for name, acc := range accumulators {
+		acc.AverageDuration = (acc.total / time.Duration(acc.Runs)).String()
+		acc.MaxDuration = acc.max.String()
+		summary[name] = acc.hookRunSummary
+	}
+	return summary
+}
@@ -0,0 +1,129 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	goyaml "gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/application"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ShowUnitSuite struct {
+	coretesting.FakeJujuXDGDataHomeSuite
+	api *fakeShowUnitAPI
+}
+
+var _ = gc.Suite(&ShowUnitSuite{})
+
+func (s *ShowUnitSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	t0 := time.Date(2016, 10, 1, 12, 0, 0, 0, time.UTC)
+	s.api = &fakeShowUnitAPI{runs: []params.HookRun{{
+		Kind:     params.HookRunKindHook,
+		Name:     "config-changed",
+		Started:  t0.Add(time.Hour),
+		Finished: t0.Add(time.Hour + 3*time.Second),
+		ExitCode: 1,
+		Error:    "exit status 1",
+	}, {
+		Kind:     params.HookRunKindAction,
+		Name:     "backup",
+		Started:  t0.Add(time.Minute),
+		Finished: t0.Add(2 * time.Minute),
+	}, {
+		Kind:     params.HookRunKindHook,
+		Name:     "config-changed",
+		Started:  t0,
+		Finished: t0.Add(time.Second),
+	}}}
+}
+
+func (s *ShowUnitSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no unit name specified",
+	}, {
+		args: []string{"--hooks", "mysql"},
+		err:  `invalid unit name "mysql"`,
+	}, {
+		args: []string{"mysql/0"},
+		err:  "nothing to show: specify --hooks",
+	}, {
+		args: []string{"--hooks", "mysql/0", "extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		err := coretesting.InitCommand(application.NewShowUnitCommandForTest(s.api), test.args)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *ShowUnitSuite) TestShowUnitHooks(c *gc.C) {
+	ctx, err := coretesting.RunCommand(c, application.NewShowUnitCommandForTest(s.api), "--hooks", "mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.unitName, gc.Equals, "mysql/0")
+	c.Assert(s.api.closed, jc.IsTrue)
+
+	var out map[string]interface{}
+	err = goyaml.Unmarshal([]byte(coretesting.Stdout(ctx)), &out)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, jc.DeepEquals, map[string]interface{}{
+		"mysql/0": map[interface{}]interface{}{
+			"hooks": map[interface{}]interface{}{
+				"config-changed": map[interface{}]interface{}{
+					"kind":             "hook",
+					"runs":             2,
+					"failures":         1,
+					"average-duration": "2s",
+					"max-duration":     "3s",
+					"last-finished":    "2016-10-01T13:00:03Z",
+					"last-exit-code":   1,
+					"last-error":       "exit status 1",
+				},
+				"backup": map[interface{}]interface{}{
+					"kind":             "action",
+					"runs":             1,
+					"failures":         0,
+					"average-duration": "1m0s",
+					"max-duration":     "1m0s",
+					"last-finished":    "2016-10-01T12:02:00Z",
+					"last-exit-code":   0,
+				},
+			},
+		},
+	})
+}
+
+func (s *ShowUnitSuite) TestShowUnitHooksError(c *gc.C) {
+	s.api.err = errors.New("boom")
+	_, err := coretesting.RunCommand(c, application.NewShowUnitCommandForTest(s.api), "--hooks", "mysql/0")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type fakeShowUnitAPI struct {
+	runs     []params.HookRun
+	err      error
+	unitName string
+	closed   bool
+}
+
+func (f *fakeShowUnitAPI) Close() error {
+	f.closed = true
+	return nil
+}
+
+func (f *fakeShowUnitAPI) UnitHookRuns(unitName string) ([]params.HookRun, error) {
+	f.unitName = unitName
+	return f.runs, f.err
+}
@@ -25,6 +25,16 @@ clouds:
       london:
         endpoint: https://london.mycloud.com:35574/v3.0/
 
+A cloud of remote LXD hosts, each of which is an availability zone, is
+defined with a comma-separated list of the hosts' HTTPS endpoints, and
+uses a certificate credential trusted by each host:
+
+clouds:
+  mylab:
+    type: lxd
+    auth-types: [ certificate ]
+    endpoint: https://10.0.0.2:8443,https://10.0.0.3:8443
+
 If the named cloud already exists, the `[1:] + "`--replace`" + ` option is required to 
 overwrite its configuration.
 Known cloud types: azure, cloudsigma, ec2, gce, joyent, lxd, maas, manual,
@@ -10,6 +10,7 @@ import (
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 	"gopkg.in/juju/charm.v6-unstable/hooks"
 	"gopkg.in/juju/names.v2"
 
@@ -26,13 +27,26 @@ func newDebugHooksCommand() cmd.Command {
 type debugHooksCommand struct {
 	sshCommand
 	hooks []string
+	step  bool
 }
 
 const debugHooksDoc = `
 Interactively debug a hook remotely on an application unit.
 
+With --step, no tmux session is started. Instead, the unit agent pauses
+before running each operation (hooks, actions and "juju run" commands),
+shows the operation and the remote state that caused it to be chosen,
+and waits for you to decide whether to run or skip it; if the operation
+fails, you can choose to retry it. Disconnecting lets the unit agent
+continue as normal.
+
 See the "juju help ssh" for information about SSH related options
 accepted by the debug-hooks command.
+
+Examples:
+
+    juju debug-hooks mysql/0 install config-changed
+    juju debug-hooks --step mysql/0
 `
 
 func (c *debugHooksCommand) Info() *cmd.Info {
@@ -44,6 +58,11 @@ func (c *debugHooksCommand) Info() *cmd.Info {
 	}
 }
 
+func (c *debugHooksCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.sshCommand.SetFlags(f)
+	f.BoolVar(&c.step, "step", false, "Step through every operation run by the unit agent")
+}
+
 func (c *debugHooksCommand) Init(args []string) error {
 	if len(args) < 1 {
 		return errors.Errorf("no unit name specified")
@@ -61,6 +80,9 @@ func (c *debugHooksCommand) Init(args []string) error {
 			break
 		}
 	}
+	if c.step && len(c.hooks) > 0 {
+		return errors.Errorf("hook names cannot be specified with --step")
+	}
 	return nil
 }
 
@@ -131,7 +153,11 @@ func (c *debugHooksCommand) Run(ctx *cmd.Context) error {
 		return err
 	}
 	debugctx := unitdebug.NewHooksContext(c.Target)
-	script := base64.StdEncoding.EncodeToString([]byte(unitdebug.ClientScript(debugctx, c.hooks)))
+	clientScript := unitdebug.ClientScript(debugctx, c.hooks)
+	if c.step {
+		clientScript = unitdebug.StepClientScript(debugctx)
+	}
+	script := base64.StdEncoding.EncodeToString([]byte(clientScript))
 	innercmd := fmt.Sprintf(`F=$(mktemp); echo %s | base64 -d > $F; . $F`, script)
 	args := []string{fmt.Sprintf("sudo /bin/bash -c '%s'", innercmd)}
 	c.Args = args
@@ -153,8 +153,11 @@ func (m main) Run(args []string) int {
 		return 0
 	}
 
+	stopTracing := startTracing(ctx, args)
 	jcmd := NewJujuCommand(ctx)
-	return cmd.Main(jcmd, ctx, args[1:])
+	code := cmd.Main(jcmd, ctx, args[1:])
+	stopTracing(code)
+	return code
 }
 
 func (m main) maybeWarnJuju1x() (newInstall bool) {
@@ -306,6 +309,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(model.NewSetModelDefaultsCommand())
 	r.Register(model.NewUnsetModelDefaultsCommand())
 	r.Register(model.NewSetCommand())
+	r.Register(model.NewSetLoggingCommand())
 	r.Register(model.NewUnsetCommand())
 	r.Register(model.NewRetryProvisioningCommand())
 	r.Register(model.NewDestroyCommand())
@@ -338,6 +342,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(application.NewDeployCommand())
 	r.Register(application.NewExposeCommand())
 	r.Register(application.NewUnexposeCommand())
+	r.Register(application.NewShowUnitCommand())
 	r.Register(application.NewServiceGetConstraintsCommand())
 	r.Register(application.NewServiceSetConstraintsCommand())
 
@@ -350,7 +355,10 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(storage.NewListCommand())
 	r.Register(storage.NewPoolCreateCommand())
 	r.Register(storage.NewPoolListCommand())
+	r.Register(storage.NewResizeCommand())
 	r.Register(storage.NewShowCommand())
+	r.Register(storage.NewSnapshotCommand())
+	r.Register(storage.NewSnapshotListCommand())
 
 	// Manage spaces
 	r.Register(space.NewAddCommand())
@@ -381,6 +389,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(controller.NewRemoveBlocksCommand())
 	r.Register(controller.NewShowControllerCommand())
 	r.Register(controller.NewGetConfigCommand())
+	r.Register(controller.NewAuditLogCommand())
 
 	// Debug Metrics
 	r.Register(metricsdebug.New())
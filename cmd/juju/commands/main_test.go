@@ -419,6 +419,7 @@ var commandNames = []string{
 	"agree",
 	"agreements",
 	"allocate",
+	"audit-log",
 	"autoload-credentials",
 	"backups",
 	"block",
@@ -483,6 +484,7 @@ var commandNames = []string{
 	"list-spaces",
 	"list-storage",
 	"list-storage-pools",
+	"list-storage-snapshots",
 	"list-subnets",
 	"list-users",
 	"login",
@@ -507,6 +509,7 @@ var commandNames = []string{
 	"remove-ssh-key",
 	"remove-ssh-keys",
 	"remove-unit", // alias for destroy-unit
+	"resize-storage",
 	"resolved",
 	"restore-backup",
 	"retry-provisioning",
@@ -520,6 +523,7 @@ var commandNames = []string{
 	"set-constraints",
 	"set-default-credential",
 	"set-default-region",
+	"set-logging",
 	"set-meter-status",
 	"set-model-config",
 	"set-model-constraints",
@@ -540,13 +544,16 @@ var commandNames = []string{
 	"show-model",
 	"show-status",
 	"show-storage",
+	"show-unit",
 	"show-user",
+	"snapshot-storage",
 	"spaces",
 	"ssh",
 	"status",
 	"status-history",
 	"storage",
 	"storage-pools",
+	"storage-snapshots",
 	"subnets",
 	"switch",
 	"sync-tools",
@@ -6,6 +6,7 @@ package commands
 import (
 	"encoding/base64"
 	"fmt"
+	"io"
 	"strconv"
 	"strings"
 	"time"
@@ -64,6 +65,18 @@ the unit.
 in the model.  If you specify --all you cannot provide additional
 targets.
 
+The --timeout applies to each target separately: the commands on a target
+are considered to have failed if they have not completed within the timeout
+of starting on that target.
+
+When running on more than one target with the default format, the output
+of each target is written as soon as that target completes, with each line
+prefixed by the target's machine or unit id. The yaml and json formats
+write the results of all targets once they have all completed, including
+the ReturnCode of the commands on each target.
+
+juju run exits with a non-zero status if the commands fail on any target.
+
 Since juju run creates actions, you can query for the status of commands
 started with juju run by calling "juju show-action-status --name juju-run".
 `
@@ -85,7 +98,7 @@ func (c *runCommand) SetFlags(f *gnuflag.FlagSet) {
 		"default": cmd.FormatYaml,
 	})
 	f.BoolVar(&c.all, "all", false, "Run the commands on all the machines")
-	f.DurationVar(&c.timeout, "timeout", 5*time.Minute, "How long to wait before the remote command is considered to have failed")
+	f.DurationVar(&c.timeout, "timeout", 5*time.Minute, "How long to wait on each target before the remote command is considered to have failed")
 	f.Var(cmd.NewStringsValue(nil, &c.machines), "machine", "One or more machine ids")
 	f.Var(cmd.NewStringsValue(nil, &c.services), "application", "One or more application names")
 	f.Var(cmd.NewStringsValue(nil, &c.units), "unit", "One or more unit ids")
@@ -177,14 +190,25 @@ func ConvertActionResults(result params.ActionResult, query actionQuery) map[str
 		}
 	}
 	if res, ok := result.Output["Code"].(string); ok {
-		code, err := strconv.Atoi(res)
-		if err == nil && code != 0 {
+		if code, err := strconv.Atoi(res); err == nil {
 			values["ReturnCode"] = code
 		}
 	}
 	return values
 }
 
+// resultFailed reports whether the converted results of running
+// the commands on a target indicate that they failed.
+func resultFailed(result params.ActionResult, values map[string]interface{}) bool {
+	if _, ok := values["Error"]; ok {
+		return true
+	}
+	if code, ok := values["ReturnCode"].(int); ok && code != 0 {
+		return true
+	}
+	return result.Status == params.ActionFailed
+}
+
 func (c *runCommand) Run(ctx *cmd.Context) error {
 	client, err := getRunAPIClient(c)
 	if err != nil {
@@ -210,15 +234,18 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 		return block.ProcessBlockedError(err, block.BlockChange)
 	}
 
+	failed := 0
 	actionsToQuery := []actionQuery{}
 	for _, result := range runResults {
 		if result.Error != nil {
 			fmt.Fprintf(ctx.GetStderr(), "couldn't queue one action: %v", result.Error)
+			failed++
 			continue
 		}
 		actionTag, err := names.ParseActionTag(result.Action.Tag)
 		if err != nil {
 			fmt.Fprintf(ctx.GetStderr(), "got invalid action tag %v for receiver %v", result.Action.Tag, result.Action.Receiver)
+			failed++
 			continue
 		}
 
@@ -248,6 +275,12 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 		return errors.New("no actions were successfully enqueued, aborting")
 	}
 
+	// When running on several targets with the default format, the
+	// output of each target is written once that target completes,
+	// rather than once all targets have completed. Actions only report
+	// their output on completion, so it cannot be written any sooner.
+	perTarget := c.out.Name() == "default" && len(runResults) > 1
+
 	values := []interface{}{}
 	for len(actionsToQuery) > 0 {
 		actionResults, err := client.Actions(entities(actionsToQuery))
@@ -265,10 +298,20 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 				}
 			}
 
-			values = append(values, ConvertActionResults(result, actionsToQuery[i]))
+			converted := ConvertActionResults(result, actionsToQuery[i])
+			if resultFailed(result, converted) {
+				failed++
+			}
+			if perTarget {
+				writeCompleted(ctx, actionsToQuery[i].receiver.tag.Id(), converted)
+			}
+			values = append(values, converted)
 		}
 
 		actionsToQuery = newActionsToQuery
+		if len(actionsToQuery) == 0 {
+			break
+		}
 
 		// TODO: use a watcher instead of sleeping
 		// this should be easier once we implement action grouping
@@ -277,7 +320,7 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 
 	// If we are just dealing with one result, AND we are using the default
 	// format, then pretend we were running it locally.
-	if len(values) == 1 && c.out.Name() == "default" {
+	if len(values) == 1 && len(runResults) == 1 && c.out.Name() == "default" {
 		result, ok := values[0].(map[string]interface{})
 		if !ok {
 			return errors.New("couldn't read action output")
@@ -298,7 +341,48 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 		return nil
 	}
 
-	return c.out.Write(ctx, values)
+	if !perTarget {
+		if err := c.out.Write(ctx, values); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if failed > 0 {
+		return errors.Errorf("commands failed on %d of %d targets", failed, len(runResults))
+	}
+	return nil
+}
+
+// writeCompleted writes the converted results of running the commands
+// on the identified target once they have completed, with each line of
+// output prefixed by the target so that the output of several targets
+// can be told apart.
+func writeCompleted(ctx *cmd.Context, target string, result map[string]interface{}) {
+	prefixLines(ctx.Stdout, target, formatOutput(result, "Stdout"))
+	prefixLines(ctx.Stderr, target, formatOutput(result, "Stderr"))
+	if res, ok := result["Error"].(string); ok {
+		fmt.Fprintf(ctx.Stderr, "%s: ERROR %s\n", target, res)
+	}
+	if res, ok := result["Message"].(string); ok && res != "" {
+		fmt.Fprintf(ctx.Stderr, "%s: %s\n", target, res)
+	}
+	if code, ok := result["ReturnCode"].(int); ok && code != 0 {
+		fmt.Fprintf(ctx.Stderr, "%s: exited with code %d\n", target, code)
+	}
+}
+
+func prefixLines(w io.Writer, prefix string, output []byte) {
+	if len(output) == 0 {
+		return
+	}
+	for _, line := range strings.SplitAfter(string(output), "\n") {
+		if line == "" {
+			continue
+		}
+		if !strings.HasSuffix(line, "\n") {
+			line += "\n"
+		}
+		fmt.Fprintf(w, "%s: %s", prefix, line)
+	}
 }
 
 type actionReceiver struct {
@@ -221,6 +221,18 @@ func (s *RunSuite) TestConvertRunResults(c *gc.C) {
 			"Message":    "msg",
 			"ReturnCode": 42,
 		},
+	}, {
+		message: "a zero return code is included",
+		results: makeActionResult(mockResponse{
+			machineTag: "machine-1",
+			code:       "0",
+		}, "action-"+validUUID),
+		query: makeActionQuery(validUUID, "MachineId", names.NewMachineTag("1")),
+		expected: map[string]interface{}{
+			"MachineId":  "1",
+			"Stdout":     "",
+			"ReturnCode": 0,
+		},
 	}} {
 		c.Log(fmt.Sprintf("%v: %s", i, test.message))
 		result := ConvertActionResults(test.results, test.query)
@@ -323,12 +335,68 @@ func (s *RunSuite) TestAllMachines(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 
 	context, err := testing.RunCommand(c, newRunCommand(), "--format=json", "--all", "hostname")
-	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(err, gc.ErrorMatches, "commands failed on 1 of 3 targets")
 
 	c.Check(testing.Stdout(context), gc.Equals, buff.String())
 	c.Check(testing.Stderr(context), gc.Equals, "")
 }
 
+func (s *RunSuite) TestNonZeroReturnCodeFails(c *gc.C) {
+	mock := s.setupMockAPI()
+	mock.setResponse("0", mockResponse{
+		code:       "0",
+		machineTag: "machine-0",
+	})
+	mock.setResponse("unit/0", mockResponse{
+		code:    "3",
+		unitTag: "unit-unit-0",
+	})
+	mock.actionResponses = map[string]params.ActionResult{
+		mock.receiverIdMap["0"]:      mock.runResponses["0"],
+		mock.receiverIdMap["unit/0"]: mock.runResponses["unit/0"],
+	}
+
+	context, err := testing.RunCommand(c, newRunCommand(),
+		"--format=json", "--machine=0", "--unit=unit/0", "hostname",
+	)
+	c.Assert(err, gc.ErrorMatches, "commands failed on 1 of 2 targets")
+	c.Check(testing.Stdout(context), jc.Contains, `"ReturnCode":0`)
+	c.Check(testing.Stdout(context), jc.Contains, `"ReturnCode":3`)
+}
+
+func (s *RunSuite) TestPerTargetOutput(c *gc.C) {
+	mock := s.setupMockAPI()
+	mock.setResponse("0", mockResponse{
+		stdout:     "megatron\nstarscream",
+		code:       "0",
+		machineTag: "machine-0",
+	})
+	mock.setResponse("unit/0", mockResponse{
+		stdout:  "bumblebee\n",
+		stderr:  "oops\n",
+		code:    "2",
+		unitTag: "unit-unit-0",
+	})
+	mock.actionResponses = map[string]params.ActionResult{
+		mock.receiverIdMap["0"]:      mock.runResponses["0"],
+		mock.receiverIdMap["unit/0"]: mock.runResponses["unit/0"],
+	}
+
+	context, err := testing.RunCommand(c, newRunCommand(),
+		"--machine=0", "--unit=unit/0", "hostname",
+	)
+	c.Assert(err, gc.ErrorMatches, "commands failed on 1 of 2 targets")
+	c.Check(testing.Stdout(context), gc.Equals, ""+
+		"0: megatron\n"+
+		"0: starscream\n"+
+		"unit/0: bumblebee\n",
+	)
+	c.Check(testing.Stderr(context), gc.Equals, ""+
+		"unit/0: oops\n"+
+		"unit/0: exited with code 2\n",
+	)
+}
+
 func (s *RunSuite) TestBlockAllMachines(c *gc.C) {
 	mock := s.setupMockAPI()
 	// Block operation
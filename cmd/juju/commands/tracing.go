@@ -0,0 +1,77 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/utils/clock"
+	"github.com/opentracing/opentracing-go"
+
+	"github.com/juju/juju/juju/osenv"
+	"github.com/juju/juju/tracing"
+)
+
+// startTracing starts tracing the command run with the given
+// arguments if the JUJU_TRACE environment variable is set, so that
+// every API call made by the command is recorded under a single trace
+// whose ID is reported to the user. The returned function must be
+// called with the command's exit code once it has finished; it
+// flushes the recorded spans to the collector.
+func startTracing(ctx *cmd.Context, args []string) func(code int) {
+	noop := func(int) {}
+	url := os.Getenv(osenv.JujuTraceEnvKey)
+	if enabled, err := strconv.ParseBool(url); err == nil {
+		if !enabled {
+			return noop
+		}
+		url = tracing.DefaultCollectorURL
+	}
+	if url == "" {
+		return noop
+	}
+
+	collector, err := tracing.NewCollector(tracing.CollectorConfig{
+		Exporter:      tracing.NewZipkinExporter(url, "juju"),
+		Clock:         clock.WallClock,
+		FlushInterval: tracing.DefaultFlushInterval,
+		MaxBuffered:   tracing.DefaultMaxBuffered,
+	})
+	if err != nil {
+		logger.Warningf("cannot start tracing: %v", err)
+		return noop
+	}
+	tracer, err := tracing.NewTracer(tracing.Config{
+		Recorder: collector,
+		Clock:    clock.WallClock,
+	})
+	if err != nil {
+		logger.Warningf("cannot start tracing: %v", err)
+		collector.Kill()
+		return noop
+	}
+	opentracing.SetGlobalTracer(tracer)
+
+	name := filepath.Base(args[0])
+	if len(args) > 1 {
+		name += " " + args[1]
+	}
+	span := tracer.StartSpan(name, opentracing.Tag{Key: "args", Value: strings.Join(args[1:], " ")})
+	tracing.SetRootSpan(span)
+	ctx.Infof("trace ID: %s", tracing.TraceID(span.Context()))
+
+	return func(code int) {
+		tracing.SetRootSpan(nil)
+		span.SetTag("exit-code", code)
+		span.Finish()
+		collector.Kill()
+		if err := collector.Wait(); err != nil {
+			logger.Warningf("stopping tracing: %v", err)
+		}
+	}
+}
@@ -0,0 +1,244 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/auditlog"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+// defaultAuditLogLimit is the number of entries shown when --limit
+// is not specified.
+const defaultAuditLogLimit = 100
+
+// NewAuditLogCommand returns a command that displays the controller's
+// audit log.
+func NewAuditLogCommand() cmd.Command {
+	return modelcmd.WrapController(&auditLogCommand{clock: clock.WallClock})
+}
+
+// auditLogCommand displays the audit entries recorded by the
+// controller, most recent first.
+type auditLogCommand struct {
+	modelcmd.ControllerCommandBase
+	api   auditLogAPI
+	clock clock.Clock
+	out   cmd.Output
+
+	user      string
+	model     string
+	since     string
+	operation string
+	limit     int
+}
+
+const auditLogHelpDoc = `
+Displays the audit entries recorded by the controller, most recent
+first. Only controller administrators may view the audit log.
+
+Entries may be filtered by the user that triggered them, the model they
+were recorded against, and by operation. The operation filter matches
+any operation beginning with the given value, so "Application" selects
+all calls to the Application facade.
+
+The --since option accepts either a duration, such as "2h" or "30m",
+meaning that long ago, or an RFC 3339 timestamp.
+
+Examples:
+
+    juju audit-log
+    juju audit-log --user bob --since 24h
+    juju audit-log -m mymodel --operation Application
+    juju audit-log --since 2016-10-01T00:00:00Z --limit 0 --format json
+
+See also: controllers
+`
+
+// Info implements Command.Info.
+func (c *auditLogCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "audit-log",
+		Purpose: "Displays the controller's audit log.",
+		Doc:     strings.TrimSpace(auditLogHelpDoc),
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *auditLogCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.StringVar(&c.user, "user", "", "Show only entries triggered by this user")
+	f.StringVar(&c.model, "m", "", "Show only entries for this model")
+	f.StringVar(&c.model, "model", "", "")
+	f.StringVar(&c.since, "since", "", "Show only entries recorded since this duration ago or time")
+	f.StringVar(&c.operation, "operation", "", "Show only entries whose operation begins with this value")
+	f.IntVar(&c.limit, "limit", defaultAuditLogLimit, "The maximum number of entries to show (0 for no limit)")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatAuditLogTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *auditLogCommand) Init(args []string) error {
+	if c.user != "" && !names.IsValidUser(c.user) {
+		return errors.NotValidf("user %q", c.user)
+	}
+	if c.limit < 0 {
+		return errors.NotValidf("negative limit")
+	}
+	if c.since != "" {
+		if _, err := c.parseSince(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return cmd.CheckEmpty(args)
+}
+
+// parseSince interprets the --since value as either a duration before
+// the current time, or an RFC 3339 timestamp.
+func (c *auditLogCommand) parseSince() (time.Time, error) {
+	if d, err := time.ParseDuration(c.since); err == nil {
+		if d < 0 {
+			return time.Time{}, errors.NotValidf("negative duration %q", c.since)
+		}
+		return c.clock.Now().Add(-d), nil
+	}
+	t, err := time.Parse(time.RFC3339, c.since)
+	if err != nil {
+		return time.Time{}, errors.Errorf("--since %q is neither a duration nor an RFC 3339 time", c.since)
+	}
+	return t, nil
+}
+
+type auditLogAPI interface {
+	Close() error
+	AuditEntries(params.AuditEntriesFilter) ([]params.AuditEntry, error)
+}
+
+func (c *auditLogCommand) getAPI() (auditLogAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return auditlog.NewClient(root), nil
+}
+
+// Run implements Command.Run.
+func (c *auditLogCommand) Run(ctx *cmd.Context) error {
+	filter := params.AuditEntriesFilter{
+		Operation: c.operation,
+		Limit:     c.limit,
+	}
+	if c.user != "" {
+		filter.UserTag = names.NewUserTag(c.user).String()
+	}
+	if c.model != "" {
+		uuids, err := c.ModelUUIDs([]string{c.model})
+		if err != nil {
+			return errors.Trace(err)
+		}
+		filter.ModelTag = names.NewModelTag(uuids[0]).String()
+	}
+	if c.since != "" {
+		since, err := c.parseSince()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		filter.Since = &since
+	}
+
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	entries, err := client.AuditEntries(filter)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	details := make([]auditEntryDetails, len(entries))
+	for i, entry := range entries {
+		details[i] = auditEntryDetailsFromParams(entry)
+	}
+	return c.out.Write(ctx, details)
+}
+
+// auditEntryDetails holds a single audit entry, formatted for output.
+type auditEntryDetails struct {
+	Timestamp         time.Time              `yaml:"timestamp" json:"timestamp"`
+	Model             string                 `yaml:"model-uuid" json:"model-uuid"`
+	User              string                 `yaml:"user,omitempty" json:"user,omitempty"`
+	OriginType        string                 `yaml:"origin-type" json:"origin-type"`
+	OriginName        string                 `yaml:"origin-name" json:"origin-name"`
+	RemoteAddress     string                 `yaml:"remote-address" json:"remote-address"`
+	Operation         string                 `yaml:"operation" json:"operation"`
+	JujuServerVersion string                 `yaml:"juju-server-version" json:"juju-server-version"`
+	Data              map[string]interface{} `yaml:"data,omitempty" json:"data,omitempty"`
+}
+
+func auditEntryDetailsFromParams(entry params.AuditEntry) auditEntryDetails {
+	details := auditEntryDetails{
+		Timestamp:         entry.Timestamp.UTC(),
+		Model:             entry.ModelTag,
+		OriginType:        entry.OriginType,
+		OriginName:        entry.OriginName,
+		RemoteAddress:     entry.RemoteAddress,
+		Operation:         entry.Operation,
+		JujuServerVersion: entry.JujuServerVersion.String(),
+		Data:              entry.Data,
+	}
+	if tag, err := names.ParseModelTag(entry.ModelTag); err == nil {
+		details.Model = tag.Id()
+	}
+	if tag, err := names.ParseUserTag(entry.OriginName); err == nil {
+		details.User = tag.Canonical()
+	}
+	return details
+}
+
+// formatAuditLogTabular writes a tabular summary of the audit entries.
+func formatAuditLogTabular(writer io.Writer, value interface{}) error {
+	entries, ok := value.([]auditEntryDetails)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", entries, value)
+	}
+	if len(entries) == 0 {
+		fmt.Fprint(writer, "No audit entries found.")
+		return nil
+	}
+	tw := output.TabWriter(writer)
+	fmt.Fprint(tw, "TIME\tMODEL\tUSER\tADDRESS\tOPERATION\n")
+	for _, entry := range entries {
+		user := entry.User
+		if user == "" {
+			user = entry.OriginName
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n",
+			entry.Timestamp.Format(time.RFC3339),
+			entry.Model,
+			user,
+			entry.RemoteAddress,
+			entry.Operation,
+		)
+	}
+	tw.Flush()
+	return nil
+}
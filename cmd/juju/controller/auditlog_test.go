@@ -0,0 +1,172 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/controller"
+	"github.com/juju/juju/testing"
+)
+
+type AuditLogSuite struct {
+	baseControllerSuite
+	api   *fakeAuditLogAPI
+	clock *testing.Clock
+}
+
+var _ = gc.Suite(&AuditLogSuite{})
+
+var auditLogNow = time.Date(2016, 10, 2, 12, 0, 0, 0, time.UTC)
+
+func (s *AuditLogSuite) SetUpTest(c *gc.C) {
+	s.baseControllerSuite.SetUpTest(c)
+	s.createTestClientStore(c)
+	s.clock = testing.NewClock(auditLogNow)
+	s.api = &fakeAuditLogAPI{
+		entries: []params.AuditEntry{{
+			JujuServerVersion: version.MustParse("2.0.0"),
+			ModelTag:          "model-def",
+			Timestamp:         time.Date(2016, 10, 2, 11, 30, 0, 0, time.UTC),
+			RemoteAddress:     "10.0.0.1",
+			OriginType:        "API request",
+			OriginName:        "user-bob@local",
+			Operation:         "Application:v1 - Deploy",
+			Data:              map[string]interface{}{"request-body": "{}"},
+		}},
+	}
+}
+
+func (s *AuditLogSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	command := controller.NewAuditLogCommandForTest(s.api, s.clock, s.store)
+	return testing.RunCommand(c, command, args...)
+}
+
+func (s *AuditLogSuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}, {
+		args: []string{"--user", "not/valid"},
+		err:  `user "not/valid" not valid`,
+	}, {
+		args: []string{"--limit", "-1"},
+		err:  `negative limit not valid`,
+	}, {
+		args: []string{"--since", "yesterday"},
+		err:  `--since "yesterday" is neither a duration nor an RFC 3339 time`,
+	}, {
+		args: []string{"--since", "-1h"},
+		err:  `negative duration "-1h" not valid`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		command := controller.NewAuditLogCommandForTest(s.api, s.clock, s.store)
+		err := testing.InitCommand(command, test.args)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *AuditLogSuite) TestDefaultFilter(c *gc.C) {
+	_, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCalls(c, []gitjujutesting.StubCall{
+		{"AuditEntries", []interface{}{params.AuditEntriesFilter{Limit: 100}}},
+		{"Close", nil},
+	})
+}
+
+func (s *AuditLogSuite) TestFilter(c *gc.C) {
+	_, err := s.run(c,
+		"--user", "bob",
+		"--model", "my-model",
+		"--since", "2h",
+		"--operation", "Application",
+		"--limit", "0",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	since := auditLogNow.Add(-2 * time.Hour)
+	s.api.CheckCall(c, 0, "AuditEntries", params.AuditEntriesFilter{
+		UserTag:   "user-bob",
+		ModelTag:  "model-def",
+		Operation: "Application",
+		Since:     &since,
+	})
+}
+
+func (s *AuditLogSuite) TestFilterSinceTime(c *gc.C) {
+	_, err := s.run(c, "--since", "2016-10-01T00:00:00Z")
+	c.Assert(err, jc.ErrorIsNil)
+	since := time.Date(2016, 10, 1, 0, 0, 0, 0, time.UTC)
+	s.api.CheckCall(c, 0, "AuditEntries", params.AuditEntriesFilter{
+		Since: &since,
+		Limit: 100,
+	})
+}
+
+func (s *AuditLogSuite) TestTabular(c *gc.C) {
+	ctx, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, ""+
+		"TIME                  MODEL  USER       ADDRESS   OPERATION\n"+
+		"2016-10-02T11:30:00Z  def    bob@local  10.0.0.1  Application:v1 - Deploy\n"+
+		"\n")
+}
+
+func (s *AuditLogSuite) TestTabularNoEntries(c *gc.C) {
+	s.api.entries = nil
+	ctx, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, "No audit entries found.\n")
+}
+
+func (s *AuditLogSuite) TestJSON(c *gc.C) {
+	ctx, err := s.run(c, "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, `[{`+
+		`"timestamp":"2016-10-02T11:30:00Z",`+
+		`"model-uuid":"def",`+
+		`"user":"bob@local",`+
+		`"origin-type":"API request",`+
+		`"origin-name":"user-bob@local",`+
+		`"remote-address":"10.0.0.1",`+
+		`"operation":"Application:v1 - Deploy",`+
+		`"juju-server-version":"2.0.0",`+
+		`"data":{"request-body":"{}"}`+
+		"}]\n")
+}
+
+func (s *AuditLogSuite) TestAPIError(c *gc.C) {
+	s.api.SetErrors(errors.New("permission denied"))
+	_, err := s.run(c)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+type fakeAuditLogAPI struct {
+	gitjujutesting.Stub
+	entries []params.AuditEntry
+}
+
+func (f *fakeAuditLogAPI) AuditEntries(filter params.AuditEntriesFilter) ([]params.AuditEntry, error) {
+	f.MethodCall(f, "AuditEntries", filter)
+	if err := f.NextErr(); err != nil {
+		return nil, err
+	}
+	return f.entries, nil
+}
+
+func (f *fakeAuditLogAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
@@ -26,6 +26,14 @@ func NewSetCommandForTest(api SetModelAPI) cmd.Command {
 	return modelcmd.Wrap(cmd)
 }
 
+// NewSetLoggingCommandForTest returns a SetLoggingCommand with the api provided as specified.
+func NewSetLoggingCommandForTest(api SetLoggingAPI) cmd.Command {
+	cmd := &setLoggingCommand{
+		api: api,
+	}
+	return modelcmd.Wrap(cmd)
+}
+
 // NewUnsetCommandForTest returns an UnsetCommand with the api provided as specified.
 func NewUnsetCommandForTest(api UnsetModelAPI) cmd.Command {
 	cmd := &unsetCommand{
@@ -5,7 +5,9 @@ package model_test
 
 import (
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/testing"
 )
@@ -27,6 +29,7 @@ func (s *fakeEnvSuite) SetUpTest(c *gc.C) {
 			"attr":  {Value: "foo", Source: "default"},
 			"attr2": {Value: "bar", Source: "controller"},
 		},
+		logsSize: 1536,
 	}
 }
 
@@ -36,6 +39,8 @@ type fakeEnvAPI struct {
 	defaults      config.ConfigValues
 	err           error
 	keys          []string
+	logsSize      int64
+	logsSizeErr   error
 }
 
 func (f *fakeEnvAPI) Close() error {
@@ -54,6 +59,10 @@ func (f *fakeEnvAPI) ModelGetWithMetadata() (config.ConfigValues, error) {
 	return result, nil
 }
 
+func (f *fakeEnvAPI) ModelLogsSize() (int64, error) {
+	return f.logsSize, f.logsSizeErr
+}
+
 func (f *fakeEnvAPI) ModelDefaults() (config.ConfigValues, error) {
 	return f.defaults, nil
 }
@@ -91,3 +100,28 @@ func (f *fakeEnvAPI) ModelUnset(keys ...string) error {
 	f.keys = keys
 	return f.err
 }
+
+func (f *fakeEnvAPI) SetLoggingOverride(tag names.Tag, loggingConfig string) error {
+	if f.err != nil {
+		return f.err
+	}
+	current, _ := f.values[config.LoggingOverridesKey].(string)
+	overrides, err := config.ParseLoggingOverrides(current)
+	if err != nil {
+		return err
+	}
+	if loggingConfig == "" {
+		if _, ok := overrides[tag.String()]; !ok {
+			return &params.Error{Code: params.CodeNotFound}
+		}
+		delete(overrides, tag.String())
+	} else {
+		overrides[tag.String()] = loggingConfig
+	}
+	f.values[config.LoggingOverridesKey] = config.FormatLoggingOverrides(overrides)
+	return nil
+}
+
+func (f *fakeEnvAPI) UnsetLoggingOverride(tag names.Tag) error {
+	return f.SetLoggingOverride(tag, "")
+}
@@ -10,6 +10,7 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/dustin/go-humanize"
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
@@ -37,6 +38,9 @@ const getModelHelpDoc = `
 By default, all configuration (keys and values) for the model are
 displayed if a key is not specified.
 By default, the model is the current model.
+The read-only current-logs-size value reports the approximate size of
+the model's logs, which may be limited with the max-logs-age and
+max-logs-size keys.
 
 Examples:
 
@@ -76,8 +80,18 @@ type GetModelAPI interface {
 	Close() error
 	ModelGet() (map[string]interface{}, error)
 	ModelGetWithMetadata() (config.ConfigValues, error)
+	ModelLogsSize() (int64, error)
 }
 
+const (
+	// logsSizeKey is the name of the read-only value reporting the
+	// current size of the model's logs.
+	logsSizeKey = "current-logs-size"
+
+	// logsSizeSource is the source reported for logsSizeKey.
+	logsSizeSource = "status"
+)
+
 func (c *getCommand) getAPI() (GetModelAPI, error) {
 	if c.api != nil {
 		return c.api, nil
@@ -117,6 +131,23 @@ func (c *getCommand) Run(ctx *cmd.Context) error {
 		}
 	}
 
+	if c.key == "" || c.key == logsSizeKey {
+		// The size of the model's logs is reported alongside the
+		// config, but is not part of it, so failing to get it
+		// should not stop the config being shown.
+		logsSize, err := client.ModelLogsSize()
+		if errors.IsNotSupported(err) {
+			logger.Debugf("cannot get model logs size: %v", err)
+		} else if err != nil {
+			logger.Warningf("cannot get model logs size: %v", err)
+		} else {
+			attrs[logsSizeKey] = config.ConfigValue{
+				Value:  humanize.IBytes(uint64(logsSize)),
+				Source: logsSizeSource,
+			}
+		}
+	}
+
 	if c.key != "" {
 		if value, found := attrs[c.key]; found {
 			err := cmd.FormatYaml(ctx.Stdout, value.Value)
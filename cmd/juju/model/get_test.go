@@ -5,6 +5,7 @@ package model_test
 
 import (
 	"github.com/juju/cmd"
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
@@ -56,6 +57,9 @@ func (s *GetSuite) TestAllValuesYAML(c *gc.C) {
 
 	output := testing.Stdout(context)
 	expected := "" +
+		"current-logs-size:\n" +
+		"  value: 1.5 KiB\n" +
+		"  source: status\n" +
 		"running:\n" +
 		"  value: true\n" +
 		"  source: model\n" +
@@ -70,7 +74,7 @@ func (s *GetSuite) TestAllValuesJSON(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 
 	output := testing.Stdout(context)
-	expected := `{"running":{"Value":true,"Source":"model"},"special":{"Value":"special value","Source":"model"}}` + "\n"
+	expected := `{"current-logs-size":{"Value":"1.5 KiB","Source":"status"},"running":{"Value":true,"Source":"model"},"special":{"Value":"special value","Source":"model"}}` + "\n"
 	c.Assert(output, gc.Equals, expected)
 }
 
@@ -78,6 +82,43 @@ func (s *GetSuite) TestAllValuesTabular(c *gc.C) {
 	context, err := s.run(c)
 	c.Assert(err, jc.ErrorIsNil)
 
+	output := testing.Stdout(context)
+	expected := "" +
+		"ATTRIBUTE          FROM    VALUE\n" +
+		"current-logs-size  status  1.5 KiB\n" +
+		"running            model   true\n" +
+		"special            model   special value\n" +
+		"\n"
+	c.Assert(output, gc.Equals, expected)
+}
+
+func (s *GetSuite) TestLogsSize(c *gc.C) {
+	context, err := s.run(c, "current-logs-size")
+	c.Assert(err, jc.ErrorIsNil)
+
+	output := testing.Stdout(context)
+	c.Assert(output, gc.Equals, "1.5 KiB\n")
+}
+
+func (s *GetSuite) TestLogsSizeError(c *gc.C) {
+	s.fake.logsSizeErr = errors.New("boom")
+	context, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+
+	output := testing.Stdout(context)
+	expected := "" +
+		"ATTRIBUTE  FROM   VALUE\n" +
+		"running    model  true\n" +
+		"special    model  special value\n" +
+		"\n"
+	c.Assert(output, gc.Equals, expected)
+}
+
+func (s *GetSuite) TestLogsSizeNotSupported(c *gc.C) {
+	s.fake.logsSizeErr = errors.NotSupportedf("model logs size on this controller")
+	context, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+
 	output := testing.Stdout(context)
 	expected := "" +
 		"ATTRIBUTE  FROM   VALUE\n" +
@@ -85,4 +126,5 @@ func (s *GetSuite) TestAllValuesTabular(c *gc.C) {
 		"special    model  special value\n" +
 		"\n"
 	c.Assert(output, gc.Equals, expected)
+	c.Check(c.GetTestLog(), gc.Not(jc.Contains), "WARNING")
 }
@@ -0,0 +1,145 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/modelconfig"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+func NewSetLoggingCommand() cmd.Command {
+	return modelcmd.Wrap(&setLoggingCommand{})
+}
+
+// setLoggingCommand overrides the model's logging config for a
+// single machine, application or unit.
+type setLoggingCommand struct {
+	modelcmd.ModelCommandBase
+	api           SetLoggingAPI
+	entity        names.Tag
+	loggingConfig string
+	reset         bool
+}
+
+const setLoggingHelpDoc = `
+Overrides the model's logging-config for the agents of a single machine,
+application or unit. Overrides for a unit take precedence over overrides
+for its application, and both take precedence over the model's
+logging-config. The overrides are stored in the model's logging-overrides
+configuration key.
+
+Examples:
+
+    juju set-logging mysql/0 juju.worker.uniter=TRACE
+    juju set-logging wordpress '<root>=DEBUG;juju.worker=INFO'
+    juju set-logging 0/lxd/1 DEBUG
+    juju set-logging --reset mysql/0
+
+See also:
+    set-model-config
+    get-model-config
+`
+
+func (c *setLoggingCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "set-logging",
+		Args:    "<machine>|<application>|<unit> [<logging config>]",
+		Purpose: "Overrides logging configuration for a machine, application or unit.",
+		Doc:     setLoggingHelpDoc,
+	}
+}
+
+func (c *setLoggingCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.BoolVar(&c.reset, "reset", false, "Remove the logging override")
+}
+
+func (c *setLoggingCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no machine, application or unit specified")
+	}
+	entity, err := parseLoggingEntity(args[0])
+	if err != nil {
+		return errors.Trace(err)
+	}
+	c.entity = entity
+	args = args[1:]
+	if c.reset {
+		return cmd.CheckEmpty(args)
+	}
+	if len(args) == 0 {
+		return errors.New("no logging config specified")
+	}
+	loggingConfig, err := loggo.ParseConfigString(args[0])
+	if err != nil {
+		return errors.Annotate(err, "invalid logging config")
+	}
+	if len(loggingConfig) == 0 {
+		return errors.New("no logging config specified")
+	}
+	// Spaces separate entries in logging-overrides, so store the
+	// logging config in its normalised form, which has none.
+	c.loggingConfig = loggingConfig.String()
+	return cmd.CheckEmpty(args[1:])
+}
+
+// parseLoggingEntity returns the tag of the machine, application or
+// unit with the given id.
+func parseLoggingEntity(id string) (names.Tag, error) {
+	switch {
+	case names.IsValidMachine(id):
+		return names.NewMachineTag(id), nil
+	case names.IsValidUnit(id):
+		return names.NewUnitTag(id), nil
+	case names.IsValidApplication(id):
+		return names.NewApplicationTag(id), nil
+	}
+	return nil, errors.Errorf("invalid machine, application or unit %q", id)
+}
+
+// SetLoggingAPI defines the API methods that the set-logging command
+// uses.
+type SetLoggingAPI interface {
+	Close() error
+	SetLoggingOverride(tag names.Tag, loggingConfig string) error
+	UnsetLoggingOverride(tag names.Tag) error
+}
+
+func (c *setLoggingCommand) getAPI() (SetLoggingAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	api, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Annotate(err, "opening API connection")
+	}
+	return modelconfig.NewClient(api), nil
+}
+
+func (c *setLoggingCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if c.reset {
+		err = client.UnsetLoggingOverride(c.entity)
+		if params.IsCodeNotFound(err) {
+			ctx.Infof("no logging override for %s", names.ReadableString(c.entity))
+			return nil
+		}
+	} else {
+		err = client.SetLoggingOverride(c.entity, c.loggingConfig)
+	}
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
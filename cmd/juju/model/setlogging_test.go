@@ -0,0 +1,97 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/cmd/juju/model"
+	"github.com/juju/juju/testing"
+)
+
+type SetLoggingSuite struct {
+	fakeEnvSuite
+}
+
+var _ = gc.Suite(&SetLoggingSuite{})
+
+func (s *SetLoggingSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	command := model.NewSetLoggingCommandForTest(s.fake)
+	return testing.RunCommand(c, command, args...)
+}
+
+func (s *SetLoggingSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args       []string
+		errorMatch string
+	}{{
+		errorMatch: "no machine, application or unit specified",
+	}, {
+		args:       []string{"mysql/0"},
+		errorMatch: "no logging config specified",
+	}, {
+		args:       []string{"bad_name", "DEBUG"},
+		errorMatch: `invalid machine, application or unit "bad_name"`,
+	}, {
+		args:       []string{"mysql/0", "juju=LOUD"},
+		errorMatch: `invalid logging config: unknown severity level "LOUD"`,
+	}, {
+		args:       []string{"mysql/0", "DEBUG", "extra"},
+		errorMatch: `unrecognized args: \["extra"\]`,
+	}, {
+		args:       []string{"--reset", "mysql/0", "DEBUG"},
+		errorMatch: `unrecognized args: \["DEBUG"\]`,
+	}} {
+		c.Logf("test %d", i)
+		err := testing.InitCommand(model.NewSetLoggingCommandForTest(s.fake), test.args)
+		c.Check(err, gc.ErrorMatches, test.errorMatch)
+	}
+}
+
+func (s *SetLoggingSuite) TestSetsOverrides(c *gc.C) {
+	_, err := s.run(c, "mysql/0", "juju.worker.uniter=TRACE; juju=DEBUG")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.values["logging-overrides"], gc.Equals, "unit-mysql-0:juju=DEBUG;juju.worker.uniter=TRACE")
+
+	s.fake.values["logging-overrides"] = "unit-mysql-0:<root>=DEBUG"
+	_, err = s.run(c, "0/lxd/1", "DEBUG")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.run(c, "wordpress", "juju=INFO")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.run(c, "mysql/0", "juju=TRACE")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.values["logging-overrides"], gc.Equals, "application-wordpress:juju=INFO machine-0-lxd-1:<root>=DEBUG unit-mysql-0:juju=TRACE")
+}
+
+func (s *SetLoggingSuite) TestReset(c *gc.C) {
+	s.fake.values["logging-overrides"] = "machine-0:<root>=DEBUG unit-mysql-0:juju=TRACE"
+	_, err := s.run(c, "--reset", "mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.values["logging-overrides"], gc.Equals, "machine-0:<root>=DEBUG")
+}
+
+func (s *SetLoggingSuite) TestResetNoOverride(c *gc.C) {
+	s.fake.values["logging-overrides"] = "machine-0:<root>=DEBUG"
+	ctx, err := s.run(c, "--reset", "mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(testing.Stderr(ctx), gc.Equals, "no logging override for unit mysql/0\n")
+	c.Check(s.fake.values["logging-overrides"], gc.Equals, "machine-0:<root>=DEBUG")
+}
+
+func (s *SetLoggingSuite) TestBlockedError(c *gc.C) {
+	s.fake.err = common.OperationBlockedError("TestBlockedError")
+	_, err := s.run(c, "mysql/0", "DEBUG")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Check(c.GetTestLog(), jc.Contains, "TestBlockedError")
+}
+
+func (s *SetLoggingSuite) TestNotSupported(c *gc.C) {
+	s.fake.err = errors.NotSupportedf("logging overrides on this controller")
+	_, err := s.run(c, "mysql/0", "DEBUG")
+	c.Assert(err, gc.ErrorMatches, "logging overrides on this controller not supported")
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 	"github.com/juju/utils/set"
 	"gopkg.in/juju/names.v2"
 
@@ -72,6 +73,11 @@ Examples:
       juju add-storage u/0 data=1 
     or
       juju add-storage u/0 data 
+
+    # Add 1 storage instance for "data" storage to unit u/0,
+    # creating its volume from volume snapshot 2:
+
+      juju add-storage u/0 data --from-snapshot 2
 `
 	addCommandAgs = `
 <unit name> <storage directive> ...
@@ -90,7 +96,17 @@ type addCommand struct {
 	// storageCons is a map of storage constraints, keyed on the storage name
 	// defined in charm storage metadata.
 	storageCons map[string]storage.Constraints
-	newAPIFunc  func() (StorageAddAPI, error)
+
+	// fromSnapshot is the ID of the volume snapshot from which
+	// the storage's volumes should be created, if any.
+	fromSnapshot string
+	newAPIFunc   func() (StorageAddAPI, error)
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *addCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.StorageCommandBase.SetFlags(f)
+	f.StringVar(&c.fromSnapshot, "from-snapshot", "", "Create the storage's volumes from the specified volume snapshot")
 }
 
 // Init implements Command.Init.
@@ -192,6 +208,7 @@ func (c *addCommand) createStorageAddParams() []params.StorageAddParams {
 					&cons.Size,
 					&cons.Count,
 				},
+				FromSnapshot: c.fromSnapshot,
 			})
 	}
 
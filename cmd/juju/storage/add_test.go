@@ -106,6 +106,18 @@ func (s *addSuite) TestAddSuccess(c *gc.C) {
 	}
 }
 
+func (s *addSuite) TestAddFromSnapshot(c *gc.C) {
+	var added []params.StorageAddParams
+	s.mockAPI.addToUnitFunc = func(storages []params.StorageAddParams) ([]params.ErrorResult, error) {
+		added = storages
+		return make([]params.ErrorResult, len(storages)), nil
+	}
+	s.args = []string{"tst/123", "data", "--from-snapshot", "2"}
+	s.assertAddOutput(c, "added \"data\"\n", "")
+	c.Assert(added, gc.HasLen, 1)
+	c.Assert(added[0].FromSnapshot, gc.Equals, "2")
+}
+
 func (s *addSuite) TestAddOperationAborted(c *gc.C) {
 	s.args = []string{"tst/123", "data=676"}
 	s.mockAPI.addToUnitFunc = func(storages []params.StorageAddParams) ([]params.ErrorResult, error) {
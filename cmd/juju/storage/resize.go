@@ -0,0 +1,117 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"fmt"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewResizeCommand returns a command used to resize storage.
+func NewResizeCommand() cmd.Command {
+	cmd := &resizeCommand{}
+	cmd.newAPIFunc = func() (StorageResizeAPI, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+const (
+	resizeCommandDoc = `
+Grow the volume underlying a storage instance to a new size.
+
+SIZE is a floating point number and multiplier from the set
+(M, G, T, P, E, Z, Y), which are all treated as powers of 1024.
+Storage may only be grown; the new size must be larger than the
+current size.
+
+Filesystem storage may only be resized if the filesystem is backed
+by a volume. The volume is grown by the storage provider, and then the
+filesystem is grown to fill the volume by the machine that it is
+attached to. Once resized, the "storage-resized" hook is run for the
+unit that owns the storage.
+
+Examples:
+    # Grow storage instance "data/0" to 20GiB:
+
+      juju resize-storage data/0 20G
+`
+	resizeCommandArgs = `<storage ID> <size>`
+)
+
+// resizeCommand resizes storage instances.
+type resizeCommand struct {
+	StorageCommandBase
+	storageTag names.StorageTag
+	size       uint64
+	newAPIFunc func() (StorageResizeAPI, error)
+}
+
+// Init implements Command.Init.
+func (c *resizeCommand) Init(args []string) (err error) {
+	if len(args) != 2 {
+		return errors.New("resize-storage requires a storage ID and a size")
+	}
+	if !names.IsValidStorage(args[0]) {
+		return errors.NotValidf("storage ID %q", args[0])
+	}
+	c.storageTag = names.NewStorageTag(args[0])
+	c.size, err = utils.ParseSize(args[1])
+	if err != nil {
+		return errors.Annotate(err, "cannot parse size")
+	}
+	if c.size == 0 {
+		return errors.New("size must be greater than zero")
+	}
+	return nil
+}
+
+// Info implements Command.Info.
+func (c *resizeCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "resize-storage",
+		Purpose: "Grows storage to a new size.",
+		Doc:     resizeCommandDoc,
+		Args:    resizeCommandArgs,
+	}
+}
+
+// Run implements Command.Run.
+func (c *resizeCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	results, err := api.Resize([]params.StorageResizeParams{{
+		StorageTag: c.storageTag.String(),
+		Size:       c.size,
+	}})
+	if err != nil {
+		return err
+	}
+	if len(results) != 1 {
+		return errors.Errorf("expected 1 result, got %d", len(results))
+	}
+	if err := results[0].Error; err != nil {
+		return err
+	}
+	fmt.Fprintf(ctx.Stdout, "resizing %q to %dMiB\n", c.storageTag.Id(), c.size)
+	return nil
+}
+
+// StorageResizeAPI defines the API methods that the storage resize
+// command uses.
+type StorageResizeAPI interface {
+	Close() error
+	Resize(storages []params.StorageResizeParams) ([]params.ErrorResult, error)
+}
@@ -0,0 +1,89 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/storage"
+	"github.com/juju/juju/testing"
+)
+
+type resizeSuite struct {
+	SubStorageSuite
+	mockAPI *mockResizeAPI
+}
+
+var _ = gc.Suite(&resizeSuite{})
+
+func (s *resizeSuite) SetUpTest(c *gc.C) {
+	s.SubStorageSuite.SetUpTest(c)
+	s.mockAPI = &mockResizeAPI{}
+}
+
+func (s *resizeSuite) runResize(c *gc.C, args ...string) (*cmd.Context, error) {
+	return testing.RunCommand(c, storage.NewResizeCommandForTest(s.mockAPI, s.store), args...)
+}
+
+func (s *resizeSuite) TestResizeArgs(c *gc.C) {
+	for i, t := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "resize-storage requires a storage ID and a size",
+	}, {
+		args: []string{"data/0"},
+		err:  "resize-storage requires a storage ID and a size",
+	}, {
+		args: []string{"data", "10G"},
+		err:  `storage ID "data" not valid`,
+	}, {
+		args: []string{"data/0", "lots"},
+		err:  `cannot parse size: .*`,
+	}, {
+		args: []string{"data/0", "0"},
+		err:  "size must be greater than zero",
+	}} {
+		c.Logf("test %d: %q", i, t.args)
+		_, err := s.runResize(c, t.args...)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+	c.Assert(s.mockAPI.calls, gc.HasLen, 0)
+}
+
+func (s *resizeSuite) TestResize(c *gc.C) {
+	ctx, err := s.runResize(c, "data/0", "20G")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, "resizing \"data/0\" to 20480MiB\n")
+	c.Assert(s.mockAPI.calls, jc.DeepEquals, [][]params.StorageResizeParams{{{
+		StorageTag: "storage-data-0",
+		Size:       20480,
+	}}})
+}
+
+func (s *resizeSuite) TestResizeError(c *gc.C) {
+	s.mockAPI.err = common.ServerError(errors.New("cannot shrink volume"))
+	_, err := s.runResize(c, "data/0", "1G")
+	c.Assert(err, gc.ErrorMatches, "cannot shrink volume")
+}
+
+type mockResizeAPI struct {
+	calls [][]params.StorageResizeParams
+	err   *params.Error
+}
+
+func (s *mockResizeAPI) Close() error {
+	return nil
+}
+
+func (s *mockResizeAPI) Resize(storages []params.StorageResizeParams) ([]params.ErrorResult, error) {
+	s.calls = append(s.calls, storages)
+	return []params.ErrorResult{{Error: s.err}}, nil
+}
@@ -0,0 +1,272 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+// NewSnapshotCommand returns a command used to snapshot storage.
+func NewSnapshotCommand() cmd.Command {
+	cmd := &snapshotCommand{}
+	cmd.newAPIFunc = func() (StorageSnapshotAPI, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+const (
+	snapshotCommandDoc = `
+Take a snapshot of the volumes underlying one or more storage instances.
+
+Snapshots are taken by the storage provider, and may be used to create
+new storage instances with "juju add-storage --from-snapshot". Only
+model-scoped volumes, such as those provided by cloud block storage,
+may be snapshotted.
+
+Examples:
+    # Snapshot storage instances "data/0" and "data/1":
+
+      juju snapshot-storage data/0 data/1
+
+See also:
+    storage-snapshots
+    add-storage
+`
+	snapshotCommandArgs = `<storage ID> [...]`
+)
+
+// snapshotCommand takes snapshots of storage instances.
+type snapshotCommand struct {
+	StorageCommandBase
+	storageTags []names.StorageTag
+	newAPIFunc  func() (StorageSnapshotAPI, error)
+}
+
+// Init implements Command.Init.
+func (c *snapshotCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("snapshot-storage requires at least one storage ID")
+	}
+	c.storageTags = make([]names.StorageTag, len(args))
+	for i, arg := range args {
+		if !names.IsValidStorage(arg) {
+			return errors.NotValidf("storage ID %q", arg)
+		}
+		c.storageTags[i] = names.NewStorageTag(arg)
+	}
+	return nil
+}
+
+// Info implements Command.Info.
+func (c *snapshotCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "snapshot-storage",
+		Purpose: "Takes snapshots of storage.",
+		Doc:     snapshotCommandDoc,
+		Args:    snapshotCommandArgs,
+	}
+}
+
+// Run implements Command.Run.
+func (c *snapshotCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	results, err := api.CreateSnapshots(c.storageTags)
+	if err != nil {
+		return errors.Annotate(err, "cannot snapshot storage")
+	}
+	var failed bool
+	for i, result := range results {
+		storageId := c.storageTags[i].Id()
+		if result.Error != nil {
+			ctx.Infof("failed to snapshot %q: %v", storageId, result.Error)
+			failed = true
+			continue
+		}
+		fmt.Fprintf(ctx.Stdout, "snapshotting %q (snapshot %s)\n", storageId, result.Result.Id)
+	}
+	if failed {
+		return cmd.ErrSilent
+	}
+	return nil
+}
+
+// StorageSnapshotAPI defines the API methods that the storage snapshot
+// command uses.
+type StorageSnapshotAPI interface {
+	Close() error
+	CreateSnapshots([]names.StorageTag) ([]params.VolumeSnapshotDetailsResult, error)
+}
+
+// NewSnapshotListCommand returns a command used to list storage snapshots.
+func NewSnapshotListCommand() cmd.Command {
+	cmd := &snapshotListCommand{}
+	cmd.newAPIFunc = func() (StorageSnapshotListAPI, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+const snapshotListCommandDoc = `
+List the volume snapshots in the model.
+
+A snapshot's size is reported once the storage provider has taken
+the snapshot; until then, the snapshot is reported as pending.
+
+Examples:
+    juju storage-snapshots
+    juju storage-snapshots --format yaml
+
+See also:
+    snapshot-storage
+`
+
+// snapshotListCommand lists volume snapshots.
+type snapshotListCommand struct {
+	StorageCommandBase
+	out        cmd.Output
+	newAPIFunc func() (StorageSnapshotListAPI, error)
+}
+
+// Info implements Command.Info.
+func (c *snapshotListCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "storage-snapshots",
+		Purpose: "Lists storage snapshots.",
+		Doc:     snapshotListCommandDoc,
+		Aliases: []string{"list-storage-snapshots"},
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *snapshotListCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.StorageCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatSnapshotListTabular,
+	})
+}
+
+// Run implements Command.Run.
+func (c *snapshotListCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	results, err := api.ListSnapshots()
+	if err != nil {
+		return errors.Annotate(err, "cannot list storage snapshots")
+	}
+	snapshots := make(map[string]SnapshotInfo)
+	for _, result := range results {
+		if result.Error != nil {
+			ctx.Infof("%v", result.Error)
+			continue
+		}
+		id, info, err := convertToSnapshotInfo(result.Result)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		snapshots[id] = info
+	}
+	if len(snapshots) == 0 {
+		ctx.Infof("No storage snapshots to display.")
+		return nil
+	}
+	return c.out.Write(ctx, snapshots)
+}
+
+// StorageSnapshotListAPI defines the API methods that the storage
+// snapshot list command uses.
+type StorageSnapshotListAPI interface {
+	Close() error
+	ListSnapshots() ([]params.VolumeSnapshotDetailsResult, error)
+}
+
+// SnapshotInfo defines the serialization behaviour of a volume snapshot.
+type SnapshotInfo struct {
+	Volume     string    `yaml:"volume" json:"volume"`
+	Storage    string    `yaml:"storage,omitempty" json:"storage,omitempty"`
+	Pool       string    `yaml:"pool" json:"pool"`
+	Created    time.Time `yaml:"created" json:"created"`
+	ProviderId string    `yaml:"provider-id,omitempty" json:"provider-id,omitempty"`
+	Size       uint64    `yaml:"size,omitempty" json:"size,omitempty"`
+}
+
+func convertToSnapshotInfo(details *params.VolumeSnapshotDetails) (string, SnapshotInfo, error) {
+	volumeTag, err := names.ParseVolumeTag(details.VolumeTag)
+	if err != nil {
+		return "", SnapshotInfo{}, errors.Trace(err)
+	}
+	info := SnapshotInfo{
+		Volume:  volumeTag.Id(),
+		Pool:    details.Pool,
+		Created: details.Created,
+	}
+	if details.StorageTag != "" {
+		storageTag, err := names.ParseStorageTag(details.StorageTag)
+		if err != nil {
+			return "", SnapshotInfo{}, errors.Trace(err)
+		}
+		info.Storage = storageTag.Id()
+	}
+	if details.Info != nil {
+		info.ProviderId = details.Info.SnapshotId
+		info.Size = details.Info.Size
+	}
+	return details.Id, info, nil
+}
+
+// formatSnapshotListTabular returns a tabular summary of volume snapshots.
+func formatSnapshotListTabular(writer io.Writer, value interface{}) error {
+	snapshots, ok := value.(map[string]SnapshotInfo)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", snapshots, value)
+	}
+	tw := output.TabWriter(writer)
+	print := func(values ...string) {
+		fmt.Fprintln(tw, strings.Join(values, "\t"))
+	}
+	print("ID", "VOLUME", "STORAGE", "POOL", "PROVIDER-ID", "SIZE", "CREATED")
+
+	ids := make([]string, 0, len(snapshots))
+	for id := range snapshots {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		info := snapshots[id]
+		size := "pending"
+		if info.ProviderId != "" {
+			size = humanize.IBytes(info.Size * humanize.MiByte)
+		}
+		print(
+			id, info.Volume, info.Storage, info.Pool, info.ProviderId, size,
+			info.Created.UTC().Format(time.RFC3339),
+		)
+	}
+	return tw.Flush()
+}
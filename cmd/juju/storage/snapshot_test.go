@@ -0,0 +1,128 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/storage"
+	"github.com/juju/juju/testing"
+)
+
+type snapshotSuite struct {
+	SubStorageSuite
+	mockAPI *mockSnapshotAPI
+}
+
+var _ = gc.Suite(&snapshotSuite{})
+
+func (s *snapshotSuite) SetUpTest(c *gc.C) {
+	s.SubStorageSuite.SetUpTest(c)
+	s.mockAPI = &mockSnapshotAPI{}
+}
+
+func (s *snapshotSuite) runSnapshot(c *gc.C, args ...string) (*cmd.Context, error) {
+	return testing.RunCommand(c, storage.NewSnapshotCommandForTest(s.mockAPI, s.store), args...)
+}
+
+func (s *snapshotSuite) runSnapshotList(c *gc.C, args ...string) (*cmd.Context, error) {
+	return testing.RunCommand(c, storage.NewSnapshotListCommandForTest(s.mockAPI, s.store), args...)
+}
+
+func (s *snapshotSuite) TestSnapshotArgs(c *gc.C) {
+	_, err := s.runSnapshot(c)
+	c.Assert(err, gc.ErrorMatches, "snapshot-storage requires at least one storage ID")
+	_, err = s.runSnapshot(c, "data")
+	c.Assert(err, gc.ErrorMatches, `storage ID "data" not valid`)
+	c.Assert(s.mockAPI.calls, gc.HasLen, 0)
+}
+
+func (s *snapshotSuite) TestSnapshot(c *gc.C) {
+	s.mockAPI.results = []params.VolumeSnapshotDetailsResult{
+		{Result: &params.VolumeSnapshotDetails{Id: "0"}},
+		{Error: common.ServerError(errors.New("no volume"))},
+	}
+	ctx, err := s.runSnapshot(c, "data/0", "data/1")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(testing.Stdout(ctx), gc.Equals, "snapshotting \"data/0\" (snapshot 0)\n")
+	c.Assert(testing.Stderr(ctx), gc.Equals, "failed to snapshot \"data/1\": no volume\n")
+	c.Assert(s.mockAPI.calls, jc.DeepEquals, [][]names.StorageTag{{
+		names.NewStorageTag("data/0"),
+		names.NewStorageTag("data/1"),
+	}})
+}
+
+func (s *snapshotSuite) TestSnapshotList(c *gc.C) {
+	created := time.Date(2016, 11, 1, 0, 0, 0, 0, time.UTC)
+	s.mockAPI.results = []params.VolumeSnapshotDetailsResult{{
+		Result: &params.VolumeSnapshotDetails{
+			Id:         "0",
+			VolumeTag:  "volume-0",
+			StorageTag: "storage-data-0",
+			Pool:       "ebs",
+			Created:    created,
+			Info: &params.VolumeSnapshotInfo{
+				SnapshotId: "snap-0123",
+				Size:       1024,
+			},
+		},
+	}, {
+		Result: &params.VolumeSnapshotDetails{
+			Id:        "1",
+			VolumeTag: "volume-1",
+			Pool:      "ebs",
+			Created:   created,
+		},
+	}}
+	ctx, err := s.runSnapshotList(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, `
+ID  VOLUME  STORAGE  POOL  PROVIDER-ID  SIZE     CREATED
+0   0       data/0   ebs   snap-0123    1.0GiB   2016-11-01T00:00:00Z
+1   1                ebs                pending  2016-11-01T00:00:00Z
+
+`[1:])
+}
+
+func (s *snapshotSuite) TestSnapshotListEmpty(c *gc.C) {
+	ctx, err := s.runSnapshotList(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, "")
+	c.Assert(testing.Stderr(ctx), gc.Equals, "No storage snapshots to display.\n")
+}
+
+func (s *snapshotSuite) TestSnapshotNotSupported(c *gc.C) {
+	s.mockAPI.err = errors.NotSupportedf("storage snapshots on this controller")
+	_, err := s.runSnapshot(c, "data/0")
+	c.Assert(err, gc.ErrorMatches, "cannot snapshot storage: storage snapshots on this controller not supported")
+	_, err = s.runSnapshotList(c)
+	c.Assert(err, gc.ErrorMatches, "cannot list storage snapshots: storage snapshots on this controller not supported")
+}
+
+type mockSnapshotAPI struct {
+	calls   [][]names.StorageTag
+	results []params.VolumeSnapshotDetailsResult
+	err     error
+}
+
+func (s *mockSnapshotAPI) Close() error {
+	return nil
+}
+
+func (s *mockSnapshotAPI) CreateSnapshots(tags []names.StorageTag) ([]params.VolumeSnapshotDetailsResult, error) {
+	s.calls = append(s.calls, tags)
+	return s.results, s.err
+}
+
+func (s *mockSnapshotAPI) ListSnapshots() ([]params.VolumeSnapshotDetailsResult, error) {
+	return s.results, s.err
+}
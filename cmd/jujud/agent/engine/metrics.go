@@ -0,0 +1,57 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package engine
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Metrics is a dependency.EngineMetrics implementation that counts
+// the starts and stops of each manifold's workers, and exposes them
+// as Prometheus metrics. A single Metrics may be shared by several
+// engines.
+type Metrics struct {
+	starts *prometheus.CounterVec
+	stops  *prometheus.CounterVec
+}
+
+// NewMetrics returns a new, unregistered, Metrics.
+func NewMetrics() *Metrics {
+	return &Metrics{
+		starts: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "juju",
+			Subsystem: "dependency_engine",
+			Name:      "worker_starts_total",
+			Help:      "Number of times each manifold's worker has been started.",
+		}, []string{"worker"}),
+		stops: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "juju",
+			Subsystem: "dependency_engine",
+			Name:      "worker_stops_total",
+			Help:      "Number of times each manifold's worker has stopped, or failed to start.",
+		}, []string{"worker"}),
+	}
+}
+
+// WorkerStarted is part of the dependency.EngineMetrics interface.
+func (m *Metrics) WorkerStarted(name string) {
+	m.starts.WithLabelValues(name).Inc()
+}
+
+// WorkerStopped is part of the dependency.EngineMetrics interface.
+func (m *Metrics) WorkerStopped(name string, _ error) {
+	m.stops.WithLabelValues(name).Inc()
+}
+
+// Describe is part of the prometheus.Collector interface.
+func (m *Metrics) Describe(ch chan<- *prometheus.Desc) {
+	m.starts.Describe(ch)
+	m.stops.Describe(ch)
+}
+
+// Collect is part of the prometheus.Collector interface.
+func (m *Metrics) Collect(ch chan<- prometheus.Metric) {
+	m.starts.Collect(ch)
+	m.stops.Collect(ch)
+}
@@ -0,0 +1,52 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package engine
+
+import (
+	"errors"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/dependency"
+)
+
+type MetricsSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&MetricsSuite{})
+
+var _ dependency.EngineMetrics = (*Metrics)(nil)
+
+func (*MetricsSuite) TestCounts(c *gc.C) {
+	metrics := NewMetrics()
+	metrics.WorkerStarted("foo")
+	metrics.WorkerStopped("foo", errors.New("boom"))
+	metrics.WorkerStarted("foo")
+	metrics.WorkerStarted("bar")
+	metrics.WorkerStopped("bar", nil)
+
+	c.Check(counterValue(c, metrics.starts, "foo"), gc.Equals, float64(2))
+	c.Check(counterValue(c, metrics.starts, "bar"), gc.Equals, float64(1))
+	c.Check(counterValue(c, metrics.stops, "foo"), gc.Equals, float64(1))
+	c.Check(counterValue(c, metrics.stops, "bar"), gc.Equals, float64(1))
+}
+
+func (*MetricsSuite) TestDescribe(c *gc.C) {
+	ch := make(chan *prometheus.Desc, 10)
+	NewMetrics().Describe(ch)
+	close(ch)
+	c.Check(ch, gc.HasLen, 2)
+}
+
+func counterValue(c *gc.C, counters *prometheus.CounterVec, worker string) float64 {
+	var out dto.Metric
+	err := counters.WithLabelValues(worker).Write(&out)
+	c.Assert(err, jc.ErrorIsNil)
+	return out.GetCounter().GetValue()
+}
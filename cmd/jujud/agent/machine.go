@@ -31,6 +31,7 @@ import (
 	"github.com/juju/utils/symlink"
 	"github.com/juju/utils/voyeur"
 	"github.com/juju/version"
+	"github.com/opentracing/opentracing-go"
 	"gopkg.in/juju/charmrepo.v2-unstable"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/mgo.v2"
@@ -45,6 +46,7 @@ import (
 	apiprovisioner "github.com/juju/juju/api/provisioner"
 	"github.com/juju/juju/apiserver"
 	"github.com/juju/juju/apiserver/observer"
+	"github.com/juju/juju/apiserver/observer/metricobserver"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/audit"
 	"github.com/juju/juju/cert"
@@ -59,6 +61,8 @@ import (
 	"github.com/juju/juju/instance"
 	jujunames "github.com/juju/juju/juju/names"
 	"github.com/juju/juju/juju/paths"
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/syslog"
 	"github.com/juju/juju/mongo"
 	"github.com/juju/juju/service"
 	"github.com/juju/juju/service/common"
@@ -66,11 +70,13 @@ import (
 	"github.com/juju/juju/state/multiwatcher"
 	"github.com/juju/juju/state/stateenvirons"
 	"github.com/juju/juju/storage/looputil"
+	"github.com/juju/juju/tracing"
 	"github.com/juju/juju/upgrades"
 	jujuversion "github.com/juju/juju/version"
 	"github.com/juju/juju/watcher"
 	"github.com/juju/juju/worker"
 	"github.com/juju/juju/worker/apicaller"
+	"github.com/juju/juju/worker/auditforwarder"
 	"github.com/juju/juju/worker/certupdater"
 	"github.com/juju/juju/worker/conv2state"
 	"github.com/juju/juju/worker/dblogpruner"
@@ -444,6 +450,7 @@ func (a *MachineAgent) makeEngineCreator(previousAgentVersion version.Number) fu
 			WorstError:  cmdutil.MoreImportantError,
 			ErrorDelay:  3 * time.Second,
 			BounceDelay: 10 * time.Millisecond,
+			Metrics:     engineMetrics,
 		}
 		engine, err := dependency.NewEngine(config)
 		if err != nil {
@@ -465,6 +472,8 @@ func (a *MachineAgent) makeEngineCreator(previousAgentVersion version.Number) fu
 			NewDeployContext:     newDeployContext,
 			Clock:                clock.WallClock,
 			ValidateMigration:    a.validateMigration,
+			MetricsHandler:       metricsHandler(),
+			Engine:               engine,
 		})
 		if err := dependency.Install(engine, manifolds); err != nil {
 			if err := worker.Stop(engine); err != nil {
@@ -957,6 +966,21 @@ func (a *MachineAgent) startStateWorkers(st *state.State) (worker.Worker, error)
 			a.startWorkerAfterUpgrade(singularRunner, "txnpruner", func() (worker.Worker, error) {
 				return txnpruner.New(st, time.Hour*2), nil
 			})
+
+			controllerConfig, err := st.ControllerConfig()
+			if err != nil {
+				return nil, errors.Annotate(err, "cannot read controller config")
+			}
+			if syslogConfig, ok := controllerConfig.AuditSyslog(); ok {
+				a.startWorkerAfterUpgrade(singularRunner, "auditforwarder", func() (worker.Worker, error) {
+					return newAuditForwarder(st, m.MachineTag(), syslogConfig)
+				})
+			}
+			if collectorURL := controllerConfig.TracingCollectorURL(); collectorURL != "" {
+				a.startWorkerAfterUpgrade(runner, "tracer", func() (worker.Worker, error) {
+					return newTracingWorker(collectorURL, m.MachineTag())
+				})
+			}
 		default:
 			return nil, errors.Errorf("unknown job type %q", job)
 		}
@@ -978,6 +1002,7 @@ func (a *MachineAgent) startModelWorkers(uuid string) (worker.Worker, error) {
 		Filter:      model.IgnoreErrRemoved,
 		ErrorDelay:  3 * time.Second,
 		BounceDelay: 10 * time.Millisecond,
+		Metrics:     engineMetrics,
 	})
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -1065,22 +1090,28 @@ func (a *MachineAgent) newApiserverWorker(st *state.State, certChanged chan para
 		return nil, errors.Annotate(err, "cannot fetch the controller config")
 	}
 
+	newObserver, err := newObserverFn(
+		controllerConfig,
+		clock.WallClock,
+		jujuversion.Current,
+		agentConfig.Model().Id(),
+		newAuditEntrySink(st, logDir),
+		auditErrorHandler,
+	)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot create RPC observer factory")
+	}
+
 	server, err := apiserver.NewServer(st, listener, apiserver.ServerConfig{
-		Cert:        cert,
-		Key:         key,
-		Tag:         tag,
-		DataDir:     dataDir,
-		LogDir:      logDir,
-		Validator:   a.limitLogins,
-		CertChanged: certChanged,
-		NewObserver: newObserverFn(
-			controllerConfig,
-			clock.WallClock,
-			jujuversion.Current,
-			agentConfig.Model().Id(),
-			newAuditEntrySink(st, logDir),
-			auditErrorHandler,
-		),
+		Cert:           cert,
+		Key:            key,
+		Tag:            tag,
+		DataDir:        dataDir,
+		LogDir:         logDir,
+		Validator:      a.limitLogins,
+		CertChanged:    certChanged,
+		NewObserver:    newObserver,
+		MetricsHandler: metricsHandler(),
 	})
 	if err != nil {
 		return nil, errors.Annotate(err, "cannot start api server worker")
@@ -1120,7 +1151,7 @@ func newObserverFn(
 	modelUUID string,
 	persistAuditEntry audit.AuditEntrySinkFn,
 	auditErrorHandler observer.ErrorHandler,
-) observer.ObserverFactory {
+) (observer.ObserverFactory, error) {
 
 	var observerFactories []observer.ObserverFactory
 
@@ -1134,6 +1165,16 @@ func newObserverFn(
 		return observer.NewRequestObserver(ctx)
 	})
 
+	// Metrics observer
+	metricObserver, err := metricobserver.NewObserverFactory(metricobserver.Config{
+		Clock:   clock,
+		Metrics: apiMetrics,
+	})
+	if err != nil {
+		return nil, errors.Annotate(err, "creating metric observer factory")
+	}
+	observerFactories = append(observerFactories, metricObserver)
+
 	// Auditing observer
 	// TODO(katco): Auditing needs feature tests (lp:1604551)
 	if controllerConfig.AuditingEnabled() {
@@ -1141,15 +1182,84 @@ func newObserverFn(
 			ctx := &observer.AuditContext{
 				JujuServerVersion: jujuServerVersion,
 				ModelUUID:         modelUUID,
+				ExcludeMethods:    controllerConfig.AuditLogExcludeMethods(),
 			}
 			return observer.NewAudit(ctx, persistAuditEntry, auditErrorHandler)
 		})
 	}
 
-	return observer.ObserverFactoryMultiplexer(observerFactories...)
+	return observer.ObserverFactoryMultiplexer(observerFactories...), nil
 
 }
 
+// newAuditForwarder returns a worker that forwards the controller's
+// audit entries to the given syslog server. Progress is tracked in
+// the database, so that entries recorded while the server cannot be
+// reached are sent once it can.
+func newAuditForwarder(st *state.State, tag names.MachineTag, syslogConfig syslog.RawConfig) (worker.Worker, error) {
+	cursor, err := state.NewAllLastSentLogTracker(st, "audit-syslog")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	w, err := auditforwarder.New(auditforwarder.Config{
+		Backend: st,
+		Cursor:  cursor,
+		Origin:  logfwd.OriginForMachineAgent(tag, st.ControllerUUID(), st.ModelUUID(), jujuversion.Current),
+		OpenSink: func() (auditforwarder.Sink, error) {
+			client, err := syslog.Open(syslogConfig)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			return client, nil
+		},
+		Clock:        clock.WallClock,
+		PollInterval: auditforwarder.DefaultPollInterval,
+		BatchSize:    auditforwarder.DefaultBatchSize,
+		SettleDelay:  auditforwarder.DefaultSettleDelay,
+	})
+	if err != nil {
+		cursor.Close()
+		return nil, errors.Trace(err)
+	}
+	return cmdutil.NewCloseWorker(logger, w, cursor), nil
+}
+
+// newTracingWorker installs a tracer as the global opentracing tracer,
+// so that the API requests and state transactions handled by this agent
+// are traced, and exports the recorded spans to the given collector.
+// The global tracer is reset when the worker stops.
+func newTracingWorker(collectorURL string, tag names.MachineTag) (worker.Worker, error) {
+	collector, err := tracing.NewCollector(tracing.CollectorConfig{
+		Exporter:      tracing.NewZipkinExporter(collectorURL, "jujud-"+tag.String()),
+		Clock:         clock.WallClock,
+		FlushInterval: tracing.DefaultFlushInterval,
+		MaxBuffered:   tracing.DefaultMaxBuffered,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	tracer, err := tracing.NewTracer(tracing.Config{
+		Recorder: collector,
+		Clock:    clock.WallClock,
+	})
+	if err != nil {
+		collector.Kill()
+		return nil, errors.Trace(err)
+	}
+	opentracing.SetGlobalTracer(tracer)
+	return cmdutil.NewCloseWorker(logger, collector, globalTracerResetter{}), nil
+}
+
+// globalTracerResetter is an io.Closer that restores the default,
+// no-op, global opentracing tracer.
+type globalTracerResetter struct{}
+
+// Close is part of the io.Closer interface.
+func (globalTracerResetter) Close() error {
+	opentracing.SetGlobalTracer(opentracing.NoopTracer{})
+	return nil
+}
+
 // limitLogins is called by the API server for each login attempt.
 // it returns an error if upgrades or restore are running.
 func (a *MachineAgent) limitLogins(req params.LoginRequest) error {
@@ -4,6 +4,7 @@
 package machine
 
 import (
+	"net/http"
 	"runtime"
 	"time"
 
@@ -126,6 +127,15 @@ type ManifoldsConfig struct {
 	// migration process to check that the agent will be ok when
 	// connected to the new target controller.
 	ValidateMigration func(base.APICaller) error
+
+	// MetricsHandler, if not nil, is served by the introspection
+	// worker to report the agent's Prometheus metrics.
+	MetricsHandler http.Handler
+
+	// Engine, if not nil, is the dependency engine that runs the
+	// manifolds. The introspection worker reports on its state, and
+	// allows its workers to be bounced.
+	Engine introspection.Engine
 }
 
 // Manifolds returns a set of co-configured manifolds covering the
@@ -153,6 +163,11 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 		}
 		return err
 	}
+	introspectionHandlers := make(map[string]http.Handler)
+	if config.MetricsHandler != nil {
+		introspectionHandlers[introspection.MetricsPath] = config.MetricsHandler
+	}
+
 	var externalUpdateProxyFunc func(proxy.Settings) error
 	if runtime.GOOS == "linux" {
 		externalUpdateProxyFunc = lxd.ConfigureLXDProxies
@@ -168,6 +183,8 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 		introspectionName: introspection.Manifold(introspection.ManifoldConfig{
 			AgentName:  agentName,
 			WorkerFunc: introspection.NewWorker,
+			Handlers:   introspectionHandlers,
+			Engine:     config.Engine,
 		}),
 
 		// The termination worker returns ErrTerminateAgent if a
@@ -455,7 +472,7 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			APICallerName: apiCallerName,
 			Sinks: []logforwarder.LogSinkSpec{{
 				Name:   "juju-log-forward",
-				OpenFn: sinks.OpenSyslog,
+				OpenFn: sinks.Open,
 			}},
 		})),
 	}
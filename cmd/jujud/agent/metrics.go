@@ -0,0 +1,44 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package agent
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/juju/juju/apiserver/observer/metricobserver"
+	"github.com/juju/juju/cmd/jujud/agent/engine"
+	"github.com/juju/juju/state"
+)
+
+var (
+	// apiMetrics records the API requests served by this process's
+	// API server, if any.
+	apiMetrics = metricobserver.NewMetrics()
+
+	// engineMetrics records the worker starts and stops of all the
+	// dependency engines run by this process.
+	engineMetrics = engine.NewMetrics()
+
+	// txnMetrics records the durations of the Mongo transactions
+	// run by this process, if it is a controller.
+	txnMetrics = state.TxnMetrics()
+
+	registerMetricsOnce sync.Once
+)
+
+// metricsHandler registers the process's metrics with the default
+// Prometheus registry, if that has not already been done, and
+// returns a handler that serves them. Each jujud process normally
+// runs a single agent, but tests may run several in one process.
+func metricsHandler() http.Handler {
+	registerMetricsOnce.Do(func() {
+		prometheus.MustRegister(apiMetrics)
+		prometheus.MustRegister(engineMetrics)
+		prometheus.MustRegister(txnMetrics)
+	})
+	return prometheus.Handler()
+}
@@ -137,24 +137,26 @@ func (a *UnitAgent) Run(ctx *cmd.Context) error {
 
 // APIWorkers returns a dependency.Engine running the unit agent's responsibilities.
 func (a *UnitAgent) APIWorkers() (worker.Worker, error) {
-	manifolds := unitManifolds(unit.ManifoldsConfig{
-		Agent:               agent.APIHostPortsSetter{a},
-		LogSource:           a.bufferedLogs,
-		LeadershipGuarantee: 30 * time.Second,
-		AgentConfigChanged:  a.configChangedVal,
-		ValidateMigration:   a.validateMigration,
-	})
-
 	config := dependency.EngineConfig{
 		IsFatal:     cmdutil.IsFatal,
 		WorstError:  cmdutil.MoreImportantError,
 		ErrorDelay:  3 * time.Second,
 		BounceDelay: 10 * time.Millisecond,
+		Metrics:     engineMetrics,
 	}
 	engine, err := dependency.NewEngine(config)
 	if err != nil {
 		return nil, err
 	}
+	manifolds := unitManifolds(unit.ManifoldsConfig{
+		Agent:               agent.APIHostPortsSetter{a},
+		LogSource:           a.bufferedLogs,
+		LeadershipGuarantee: 30 * time.Second,
+		AgentConfigChanged:  a.configChangedVal,
+		ValidateMigration:   a.validateMigration,
+		MetricsHandler:      metricsHandler(),
+		Engine:              engine,
+	})
 	if err := dependency.Install(engine, manifolds); err != nil {
 		if err := worker.Stop(engine); err != nil {
 			logger.Errorf("while stopping engine with bad manifolds: %v", err)
@@ -4,6 +4,7 @@
 package unit
 
 import (
+	"net/http"
 	"time"
 
 	"github.com/juju/errors"
@@ -58,6 +59,15 @@ type ManifoldsConfig struct {
 	// migration process to check that the agent will be ok when
 	// connected to the new target controller.
 	ValidateMigration func(base.APICaller) error
+
+	// MetricsHandler, if not nil, is served by the introspection
+	// worker to report the agent's Prometheus metrics.
+	MetricsHandler http.Handler
+
+	// Engine, if not nil, is the dependency engine that runs the
+	// manifolds. The introspection worker reports on its state, and
+	// allows its workers to be bounced.
+	Engine introspection.Engine
 }
 
 // Manifolds returns a set of co-configured manifolds covering the various
@@ -81,6 +91,16 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 		return err
 	}
 
+	// hookRuns records the hooks and actions run by the uniter, so
+	// that they can be reported by the introspection worker.
+	hookRuns := uniter.NewHookRuns()
+	introspectionHandlers := map[string]http.Handler{
+		hookRunsPath: hookRuns,
+	}
+	if config.MetricsHandler != nil {
+		introspectionHandlers[introspection.MetricsPath] = config.MetricsHandler
+	}
+
 	return dependency.Manifolds{
 
 		// The agent manifold references the enclosing agent, and is the
@@ -93,6 +113,8 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 		introspectionName: introspection.Manifold(introspection.ManifoldConfig{
 			AgentName:  agentName,
 			WorkerFunc: introspection.NewWorker,
+			Handlers:   introspectionHandlers,
+			Engine:     config.Engine,
 		}),
 
 		// The api-config-watcher manifold monitors the API server
@@ -227,6 +249,7 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			LeadershipTrackerName: leadershipTrackerName,
 			CharmDirName:          charmDirName,
 			HookRetryStrategyName: hookRetryStrategyName,
+			HookRuns:              hookRuns,
 		})),
 
 		// TODO (mattyw) should be added to machine agent.
@@ -297,3 +320,7 @@ const (
 	metricCollectName = "metric-collect"
 	metricSenderName  = "metric-sender"
 )
+
+// hookRunsPath is the path at which the introspection worker reports
+// the hooks and actions recently run by the uniter.
+const hookRunsPath = "/hookruns"
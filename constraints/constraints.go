@@ -20,16 +20,30 @@ import (
 // The following constants list the supported constraint attribute names, as defined
 // by the fields in the Value struct.
 const (
-	Arch         = "arch"
-	Container    = "container"
-	CpuCores     = "cpu-cores"
-	CpuPower     = "cpu-power"
-	Mem          = "mem"
-	RootDisk     = "root-disk"
-	Tags         = "tags"
-	InstanceType = "instance-type"
-	Spaces       = "spaces"
-	VirtType     = "virt-type"
+	Arch           = "arch"
+	Container      = "container"
+	CpuCores       = "cpu-cores"
+	CpuPower       = "cpu-power"
+	Mem            = "mem"
+	RootDisk       = "root-disk"
+	Tags           = "tags"
+	InstanceType   = "instance-type"
+	Spaces         = "spaces"
+	VirtType       = "virt-type"
+	Zones          = "zones"
+	PlacementGroup = "placement-group"
+)
+
+// The following constants list the placement group strategies that
+// may be specified with the placement-group constraint.
+const (
+	// PlacementGroupSpread requests that machines be placed on
+	// distinct underlying hardware.
+	PlacementGroupSpread = "spread"
+
+	// PlacementGroupCluster requests that machines be placed close
+	// together, for low-latency networking between them.
+	PlacementGroupCluster = "cluster"
 )
 
 // Value describes a user's requirements of the hardware on which units
@@ -83,6 +97,15 @@ type Value struct {
 	// VirtType, if not nil or empty, indicates that a machine must run the named
 	// virtual type. Only valid for clouds with multi-hypervisor support.
 	VirtType *string `json:"virt-type,omitempty" yaml:"virt-type,omitempty"`
+
+	// Zones, if not nil, holds a list of availability zones limiting
+	// where the machine can be located.
+	Zones *[]string `json:"zones,omitempty" yaml:"zones,omitempty"`
+
+	// PlacementGroup, if not nil or empty, indicates that the machines
+	// of an application must be placed in a group using the named
+	// strategy. Only valid for clouds with placement group support.
+	PlacementGroup *string `json:"placement-group,omitempty" yaml:"placement-group,omitempty"`
 }
 
 // fieldNames records a mapping from the constraint tag to struct field name.
@@ -172,6 +195,18 @@ func (v *Value) HasVirtType() bool {
 	return v.VirtType != nil && *v.VirtType != ""
 }
 
+// HasZones returns true if the constraints.Value specifies availability
+// zones.
+func (v *Value) HasZones() bool {
+	return v.Zones != nil && len(*v.Zones) > 0
+}
+
+// HasPlacementGroup returns true if the constraints.Value specifies a
+// placement group strategy.
+func (v *Value) HasPlacementGroup() bool {
+	return v.PlacementGroup != nil && *v.PlacementGroup != ""
+}
+
 // String expresses a constraints.Value in the language in which it was specified.
 func (v Value) String() string {
 	var strs []string
@@ -215,6 +250,13 @@ func (v Value) String() string {
 	if v.VirtType != nil {
 		strs = append(strs, "virt-type="+string(*v.VirtType))
 	}
+	if v.Zones != nil {
+		s := strings.Join(*v.Zones, ",")
+		strs = append(strs, "zones="+s)
+	}
+	if v.PlacementGroup != nil {
+		strs = append(strs, "placement-group="+*v.PlacementGroup)
+	}
 	return strings.Join(strs, " ")
 }
 
@@ -256,6 +298,14 @@ func (v Value) GoString() string {
 	if v.VirtType != nil {
 		values = append(values, fmt.Sprintf("VirtType: %q", *v.VirtType))
 	}
+	if v.Zones != nil && *v.Zones != nil {
+		values = append(values, fmt.Sprintf("Zones: %q", *v.Zones))
+	} else if v.Zones != nil {
+		values = append(values, "Zones: (*[]string)(nil)")
+	}
+	if v.PlacementGroup != nil {
+		values = append(values, fmt.Sprintf("PlacementGroup: %q", *v.PlacementGroup))
+	}
 	return fmt.Sprintf("{%s}", strings.Join(values, ", "))
 }
 
@@ -397,6 +447,10 @@ func (v *Value) setRaw(raw string) error {
 		err = v.setSpaces(str)
 	case VirtType:
 		err = v.setVirtType(str)
+	case Zones:
+		err = v.setZones(str)
+	case PlacementGroup:
+		err = v.setPlacementGroup(str)
 	default:
 		return errors.Errorf("unknown constraint %q", name)
 	}
@@ -449,6 +503,10 @@ func (v *Value) UnmarshalYAML(unmarshal func(interface{}) error) error {
 			}
 		case VirtType:
 			v.VirtType = &vstr
+		case Zones:
+			v.Zones, err = parseYamlStrings("zones", val)
+		case PlacementGroup:
+			v.PlacementGroup = &vstr
 		default:
 			return errors.Errorf("unknown constraint value: %v", k)
 		}
@@ -573,6 +631,22 @@ func (v *Value) setVirtType(str string) error {
 	return nil
 }
 
+func (v *Value) setPlacementGroup(str string) error {
+	if v.PlacementGroup != nil {
+		return errors.Errorf("already set")
+	}
+	v.PlacementGroup = &str
+	return nil
+}
+
+func (v *Value) setZones(str string) error {
+	if v.Zones != nil {
+		return errors.Errorf("already set")
+	}
+	v.Zones = parseCommaDelimited(str)
+	return nil
+}
+
 func parseUint64(str string) (*uint64, error) {
 	var value uint64
 	if str != "" {
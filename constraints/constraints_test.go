@@ -307,19 +307,52 @@ var parseConstraintsTests = []struct {
 		err:     `bad "virt-type" constraint: already set`,
 	},
 
+	// zones
+	{
+		summary: "single zone",
+		args:    []string{"zones=az1"},
+	}, {
+		summary: "multiple zones",
+		args:    []string{"zones=az1,az2"},
+	}, {
+		summary: "no zones",
+		args:    []string{"zones="},
+	}, {
+		summary: "double set zones together",
+		args:    []string{"zones=az1 zones=az2"},
+		err:     `bad "zones" constraint: already set`,
+	}, {
+		summary: "double set zones separately",
+		args:    []string{"zones=az1", "zones="},
+		err:     `bad "zones" constraint: already set`,
+	},
+
+	// placement-group
+	{
+		summary: "set placement-group spread",
+		args:    []string{"placement-group=spread"},
+	}, {
+		summary: "set placement-group empty",
+		args:    []string{"placement-group="},
+	}, {
+		summary: "double set placement-group",
+		args:    []string{"placement-group=spread", "placement-group=cluster"},
+		err:     `bad "placement-group" constraint: already set`,
+	},
+
 	// Everything at once.
 	{
 		summary: "kitchen sink together",
 		args: []string{
 			"root-disk=8G mem=2T  arch=i386  cpu-cores=4096 cpu-power=9001 container=lxd " +
 				"tags=foo,bar spaces=space1,^space2 instance-type=foo",
-			"virt-type=kvm"},
+			"virt-type=kvm zones=az1,az2 placement-group=spread"},
 	}, {
 		summary: "kitchen sink separately",
 		args: []string{
 			"root-disk=8G", "mem=2T", "cpu-cores=4096", "cpu-power=9001", "arch=armhf",
 			"container=lxd", "tags=foo,bar", "spaces=space1,^space2",
-			"instance-type=foo", "virt-type=kvm"},
+			"instance-type=foo", "virt-type=kvm", "zones=az1,az2", "placement-group=spread"},
 	},
 }
 
@@ -492,16 +525,23 @@ var constraintsRoundtripTests = []roundTrip{
 	{"Spaces3", constraints.Value{Spaces: &[]string{"space1", "^space2"}}},
 	{"InstanceType1", constraints.Value{InstanceType: strp("")}},
 	{"InstanceType2", constraints.Value{InstanceType: strp("foo")}},
+	{"Zones1", constraints.Value{Zones: nil}},
+	{"Zones2", constraints.Value{Zones: &[]string{}}},
+	{"Zones3", constraints.Value{Zones: &[]string{"az1", "az2"}}},
+	{"PlacementGroup1", constraints.Value{PlacementGroup: strp("")}},
+	{"PlacementGroup2", constraints.Value{PlacementGroup: strp("cluster")}},
 	{"All", constraints.Value{
-		Arch:         strp("i386"),
-		Container:    ctypep("lxd"),
-		CpuCores:     uint64p(4096),
-		CpuPower:     uint64p(9001),
-		Mem:          uint64p(18000000000),
-		RootDisk:     uint64p(24000000000),
-		Tags:         &[]string{"foo", "bar"},
-		Spaces:       &[]string{"space1", "^space2"},
-		InstanceType: strp("foo"),
+		Arch:           strp("i386"),
+		Container:      ctypep("lxd"),
+		CpuCores:       uint64p(4096),
+		CpuPower:       uint64p(9001),
+		Mem:            uint64p(18000000000),
+		RootDisk:       uint64p(24000000000),
+		Tags:           &[]string{"foo", "bar"},
+		Spaces:         &[]string{"space1", "^space2"},
+		InstanceType:   strp("foo"),
+		Zones:          &[]string{"az1", "az2"},
+		PlacementGroup: strp("spread"),
 	}},
 }
 
@@ -579,6 +619,24 @@ func (s *ConstraintsSuite) TestHasInstanceType(c *gc.C) {
 	c.Check(cons.HasInstanceType(), jc.IsTrue)
 }
 
+func (s *ConstraintsSuite) TestHasZones(c *gc.C) {
+	cons := constraints.MustParse("arch=amd64")
+	c.Check(cons.HasZones(), jc.IsFalse)
+	cons = constraints.MustParse("zones=")
+	c.Check(cons.HasZones(), jc.IsFalse)
+	cons = constraints.MustParse("zones=az1")
+	c.Check(cons.HasZones(), jc.IsTrue)
+}
+
+func (s *ConstraintsSuite) TestHasPlacementGroup(c *gc.C) {
+	cons := constraints.MustParse("arch=amd64")
+	c.Check(cons.HasPlacementGroup(), jc.IsFalse)
+	cons = constraints.MustParse("placement-group=")
+	c.Check(cons.HasPlacementGroup(), jc.IsFalse)
+	cons = constraints.MustParse("placement-group=spread")
+	c.Check(cons.HasPlacementGroup(), jc.IsTrue)
+}
+
 const initialWithoutCons = "root-disk=8G mem=4G arch=amd64 cpu-power=1000 cpu-cores=4 spaces=space1,^space2 tags=foo container=lxd instance-type=bar"
 
 var withoutTests = []struct {
@@ -646,7 +704,7 @@ func (s *ConstraintsSuite) TestWithoutError(c *gc.C) {
 func (s *ConstraintsSuite) TestAttributesWithValues(c *gc.C) {
 	for i, consStr := range []string{
 		"",
-		"root-disk=8G mem=4G arch=amd64 cpu-power=1000 cpu-cores=4 instance-type=foo tags=foo,bar spaces=space1,^space2",
+		"root-disk=8G mem=4G arch=amd64 cpu-power=1000 cpu-cores=4 instance-type=foo tags=foo,bar spaces=space1,^space2 zones=az1,az2",
 	} {
 		c.Logf("test %d", i)
 		cons := constraints.MustParse(consStr)
@@ -695,6 +753,11 @@ func (s *ConstraintsSuite) TestAttributesWithValues(c *gc.C) {
 		} else {
 			assertMissing("instance-type")
 		}
+		if cons.Zones != nil {
+			c.Check(obtained["zones"], gc.DeepEquals, *cons.Zones)
+		} else {
+			assertMissing("zones")
+		}
 	}
 }
 
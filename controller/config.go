@@ -5,6 +5,7 @@ package controller
 
 import (
 	"net/url"
+	"regexp"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -13,6 +14,7 @@ import (
 	"gopkg.in/macaroon-bakery.v1/bakery"
 
 	"github.com/juju/juju/cert"
+	"github.com/juju/juju/logfwd/syslog"
 )
 
 var logger = loggo.GetLogger("juju.controller")
@@ -25,6 +27,29 @@ const (
 	// auditing information.
 	AuditingEnabled = "auditing-enabled"
 
+	// AuditLogExcludeMethods is a list of API methods, in the form
+	// Facade.Method or Facade.*, whose calls will not be recorded in
+	// the audit log. Calls to all other methods are recorded, whether
+	// or not they change anything.
+	AuditLogExcludeMethods = "audit-log-exclude-methods"
+
+	// AuditSyslogHost is the host-port of the syslog server to which
+	// audit entries are forwarded. Forwarding is disabled if it is
+	// not set.
+	AuditSyslogHost = "audit-syslog-host"
+
+	// AuditSyslogCACert is the CA certificate (x.509, PEM-encoded)
+	// used to validate the audit syslog server's certificate.
+	AuditSyslogCACert = "audit-syslog-ca-cert"
+
+	// AuditSyslogClientCert is the client certificate (x.509,
+	// PEM-encoded) used to connect to the audit syslog server.
+	AuditSyslogClientCert = "audit-syslog-client-cert"
+
+	// AuditSyslogClientKey is the client private key (x.509,
+	// PEM-encoded) used to connect to the audit syslog server.
+	AuditSyslogClientKey = "audit-syslog-client-key"
+
 	// StatePort is the port used for mongo connections.
 	StatePort = "state-port"
 
@@ -43,6 +68,11 @@ const (
 	// NumaControlPolicyKey stores the value for this setting
 	SetNumaControlPolicyKey = "set-numa-control-policy"
 
+	// TracingCollectorURL is the URL of the Zipkin-compatible collector
+	// to which the controller sends API request tracing spans. Tracing
+	// is disabled if it is not set.
+	TracingCollectorURL = "tracing-collector-url"
+
 	// Attribute Defaults
 
 	// DefaultAuditingEnabled contains the default value for the
@@ -60,16 +90,46 @@ const (
 	DefaultAPIPort int = 17070
 )
 
+// DefaultAuditLogExcludeMethods holds the API methods that are not
+// audited when AuditLogExcludeMethods is not set. These are the
+// watchers, and the read-only calls most often made by clients and
+// agents. Other read-only calls are audited, as the audit log does
+// not know which methods change state; they can be excluded by
+// setting AuditLogExcludeMethods.
+var DefaultAuditLogExcludeMethods = []string{
+	"AllModelWatcher.*",
+	"AllWatcher.*",
+	"Client.FullStatus",
+	"EntityWatcher.*",
+	"FilesystemAttachmentsWatcher.*",
+	"MigrationStatusWatcher.*",
+	"ModelConfig.ModelGet",
+	"ModelConfig.ModelLogsSize",
+	"ModelManager.ListModels",
+	"ModelManager.ModelInfo",
+	"NotifyWatcher.*",
+	"Pinger.Ping",
+	"RelationUnitsWatcher.*",
+	"StringsWatcher.*",
+	"VolumeAttachmentsWatcher.*",
+}
+
 // ControllerOnlyConfigAttributes are attributes which are only relevant
 // for a controller, never a model.
 var ControllerOnlyConfigAttributes = []string{
 	ApiPort,
+	AuditLogExcludeMethods,
+	AuditSyslogHost,
+	AuditSyslogCACert,
+	AuditSyslogClientCert,
+	AuditSyslogClientKey,
 	StatePort,
 	CACertKey,
 	ControllerUUIDKey,
 	IdentityURL,
 	IdentityPublicKey,
 	SetNumaControlPolicyKey,
+	TracingCollectorURL,
 }
 
 // ControllerOnlyAttribute returns true if the specified attribute name
@@ -160,6 +220,36 @@ func (c Config) AuditingEnabled() bool {
 	return false
 }
 
+// AuditLogExcludeMethods returns the API methods, in the form
+// Facade.Method or Facade.*, whose calls are not audited. If the
+// setting is absent, DefaultAuditLogExcludeMethods is returned.
+func (c Config) AuditLogExcludeMethods() []string {
+	switch v := c[AuditLogExcludeMethods].(type) {
+	case []string:
+		return v
+	case []interface{}:
+		methods := make([]string, len(v))
+		for i, method := range v {
+			methods[i], _ = method.(string)
+		}
+		return methods
+	}
+	return DefaultAuditLogExcludeMethods
+}
+
+// AuditSyslog returns the configuration for forwarding audit entries
+// to a syslog server, and whether forwarding is enabled.
+func (c Config) AuditSyslog() (syslog.RawConfig, bool) {
+	cfg := syslog.RawConfig{
+		Host:       c.asString(AuditSyslogHost),
+		CACert:     c.asString(AuditSyslogCACert),
+		ClientCert: c.asString(AuditSyslogClientCert),
+		ClientKey:  c.asString(AuditSyslogClientKey),
+	}
+	cfg.Enabled = cfg.Host != ""
+	return cfg, cfg.Enabled
+}
+
 // ControllerUUID returns the uuid for the model's controller.
 func (c Config) ControllerUUID() string {
 	return c.mustString(ControllerUUIDKey)
@@ -203,6 +293,12 @@ func (c Config) NumaCtlPreference() bool {
 	return DefaultNumaControlPolicy
 }
 
+// TracingCollectorURL returns the URL of the collector to which
+// tracing spans are sent, or the empty string if tracing is disabled.
+func (c Config) TracingCollectorURL() string {
+	return c.asString(TracingCollectorURL)
+}
+
 // Validate ensures that config is a valid configuration.
 func Validate(c Config) error {
 	if v, ok := c[IdentityURL].(string); ok {
@@ -223,6 +319,30 @@ func Validate(c Config) error {
 		}
 	}
 
+	if _, ok := c[AuditLogExcludeMethods]; ok {
+		for _, method := range c.AuditLogExcludeMethods() {
+			if !validAuditLogExcludeMethod.MatchString(method) {
+				return errors.Errorf("invalid %s value %q, expected Facade.Method or Facade.*", AuditLogExcludeMethods, method)
+			}
+		}
+	}
+
+	if cfg, ok := c.AuditSyslog(); ok {
+		if err := cfg.Validate(); err != nil {
+			return errors.Annotate(err, "invalid audit syslog config")
+		}
+	}
+
+	if v, ok := c[TracingCollectorURL].(string); ok {
+		u, err := url.Parse(v)
+		if err != nil {
+			return errors.Annotate(err, "invalid tracing collector URL")
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return errors.Errorf("tracing collector URL needs to be http or https")
+		}
+	}
+
 	caCert, caCertOK := c.CACert()
 	if !caCertOK {
 		return errors.Errorf("missing CA certificate")
@@ -238,6 +358,8 @@ func Validate(c Config) error {
 	return nil
 }
 
+var validAuditLogExcludeMethod = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9]*\.(\*|[A-Za-z][A-Za-z0-9]*)$`)
+
 // GenerateControllerCertAndKey makes sure that the config has a CACert and
 // CAPrivateKey, generates and returns new certificate and key.
 func GenerateControllerCertAndKey(caCert, caKey string, hostAddresses []string) (string, string, error) {
@@ -246,16 +368,28 @@ func GenerateControllerCertAndKey(caCert, caKey string, hostAddresses []string)
 
 var configChecker = schema.FieldMap(schema.Fields{
 	AuditingEnabled:         schema.Bool(),
+	AuditLogExcludeMethods:  schema.List(schema.String()),
+	AuditSyslogHost:         schema.String(),
+	AuditSyslogCACert:       schema.String(),
+	AuditSyslogClientCert:   schema.String(),
+	AuditSyslogClientKey:    schema.String(),
 	ApiPort:                 schema.ForceInt(),
 	StatePort:               schema.ForceInt(),
 	IdentityURL:             schema.String(),
 	IdentityPublicKey:       schema.String(),
 	SetNumaControlPolicyKey: schema.Bool(),
+	TracingCollectorURL:     schema.String(),
 }, schema.Defaults{
 	ApiPort:                 DefaultAPIPort,
 	AuditingEnabled:         DefaultAuditingEnabled,
+	AuditLogExcludeMethods:  schema.Omit,
+	AuditSyslogHost:         schema.Omit,
+	AuditSyslogCACert:       schema.Omit,
+	AuditSyslogClientCert:   schema.Omit,
+	AuditSyslogClientKey:    schema.Omit,
 	StatePort:               DefaultStatePort,
 	IdentityURL:             schema.Omit,
 	IdentityPublicKey:       schema.Omit,
 	SetNumaControlPolicyKey: DefaultNumaControlPolicy,
+	TracingCollectorURL:     schema.Omit,
 })
@@ -14,6 +14,7 @@ import (
 
 	"github.com/juju/juju/cert"
 	"github.com/juju/juju/controller"
+	"github.com/juju/juju/logfwd/syslog"
 	"github.com/juju/juju/testing"
 )
 
@@ -72,3 +73,86 @@ func (s *ConfigSuite) TestGenerateControllerCertAndKey(c *gc.C) {
 		c.Assert(sanIPs, jc.SameContents, test.sanValues)
 	}
 }
+
+func (s *ConfigSuite) TestAuditLogExcludeMethodsDefault(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ModelTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AuditLogExcludeMethods(), jc.DeepEquals, controller.DefaultAuditLogExcludeMethods)
+}
+
+func (s *ConfigSuite) TestAuditLogExcludeMethods(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ModelTag.Id(), testing.CACert, map[string]interface{}{
+		controller.AuditLogExcludeMethods: []interface{}{"Client.FullStatus", "Pinger.*"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AuditLogExcludeMethods(), jc.DeepEquals, []string{"Client.FullStatus", "Pinger.*"})
+}
+
+func (s *ConfigSuite) TestAuditLogExcludeMethodsEmpty(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ModelTag.Id(), testing.CACert, map[string]interface{}{
+		controller.AuditLogExcludeMethods: []interface{}{},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AuditLogExcludeMethods(), gc.HasLen, 0)
+}
+
+func (s *ConfigSuite) TestAuditLogExcludeMethodsInvalid(c *gc.C) {
+	for _, method := range []string{"FullStatus", "Client.", ".FullStatus", "Client.Full.Status", "*.Next"} {
+		_, err := controller.NewConfig(testing.ModelTag.Id(), testing.CACert, map[string]interface{}{
+			controller.AuditLogExcludeMethods: []interface{}{method},
+		})
+		c.Check(err, gc.ErrorMatches, `invalid audit-log-exclude-methods value ".*", expected Facade.Method or Facade.\*`)
+	}
+}
+
+func (s *ConfigSuite) TestAuditSyslogDisabled(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ModelTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok := cfg.AuditSyslog()
+	c.Assert(ok, jc.IsFalse)
+}
+
+func (s *ConfigSuite) TestAuditSyslog(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ModelTag.Id(), testing.CACert, map[string]interface{}{
+		controller.AuditSyslogHost:       "siem.example.com:6514",
+		controller.AuditSyslogCACert:     testing.CACert,
+		controller.AuditSyslogClientCert: testing.ServerCert,
+		controller.AuditSyslogClientKey:  testing.ServerKey,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	syslogCfg, ok := cfg.AuditSyslog()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(syslogCfg, jc.DeepEquals, syslog.RawConfig{
+		Enabled:    true,
+		Host:       "siem.example.com:6514",
+		CACert:     testing.CACert,
+		ClientCert: testing.ServerCert,
+		ClientKey:  testing.ServerKey,
+	})
+}
+
+func (s *ConfigSuite) TestAuditSyslogInvalid(c *gc.C) {
+	_, err := controller.NewConfig(testing.ModelTag.Id(), testing.CACert, map[string]interface{}{
+		controller.AuditSyslogHost: "siem.example.com:6514",
+	})
+	c.Assert(err, gc.ErrorMatches, "invalid audit syslog config: validating TLS config: .*")
+}
+
+func (s *ConfigSuite) TestTracingCollectorURL(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ModelTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.TracingCollectorURL(), gc.Equals, "")
+
+	cfg, err = controller.NewConfig(testing.ModelTag.Id(), testing.CACert, map[string]interface{}{
+		controller.TracingCollectorURL: "http://localhost:9411/api/v2/spans",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.TracingCollectorURL(), gc.Equals, "http://localhost:9411/api/v2/spans")
+}
+
+func (s *ConfigSuite) TestTracingCollectorURLInvalid(c *gc.C) {
+	_, err := controller.NewConfig(testing.ModelTag.Id(), testing.CACert, map[string]interface{}{
+		controller.TracingCollectorURL: "localhost:9411",
+	})
+	c.Assert(err, gc.ErrorMatches, "tracing collector URL needs to be http or https")
+}
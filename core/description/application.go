@@ -32,6 +32,8 @@ type application struct {
 	Exposed_    bool `yaml:"exposed,omitempty"`
 	MinUnits_   int  `yaml:"min-units,omitempty"`
 
+	ExposedEndpoints_ map[string]*exposedEndpoint `yaml:"exposed-endpoints,omitempty"`
+
 	Status_        *status `yaml:"status"`
 	StatusHistory_ `yaml:"status-history"`
 
@@ -61,6 +63,7 @@ type ApplicationArgs struct {
 	CharmModifiedVersion int
 	ForceCharm           bool
 	Exposed              bool
+	ExposedEndpoints     map[string]ExposedEndpointArgs
 	MinUnits             int
 	Settings             map[string]interface{}
 	Leader               string
@@ -88,6 +91,14 @@ func newApplication(args ApplicationArgs) *application {
 		StatusHistory_:        newStatusHistory(),
 	}
 	app.setUnits(nil)
+	if len(args.ExposedEndpoints) > 0 {
+		app.ExposedEndpoints_ = make(map[string]*exposedEndpoint)
+		for name, ep := range args.ExposedEndpoints {
+			app.ExposedEndpoints_[name] = &exposedEndpoint{
+				ExposeToCIDRs_: ep.ExposeToCIDRs,
+			}
+		}
+	}
 	if len(args.StorageConstraints) > 0 {
 		app.StorageConstraints_ = make(map[string]*storageconstraint)
 		for key, value := range args.StorageConstraints {
@@ -142,6 +153,15 @@ func (s *application) Exposed() bool {
 	return s.Exposed_
 }
 
+// ExposedEndpoints implements Application.
+func (s *application) ExposedEndpoints() map[string]ExposedEndpoint {
+	result := make(map[string]ExposedEndpoint)
+	for name, ep := range s.ExposedEndpoints_ {
+		result[name] = ep
+	}
+	return result
+}
+
 // MinUnits implements Application.
 func (s *application) MinUnits() int {
 	return s.MinUnits_
@@ -314,6 +334,7 @@ func importApplicationV1(source map[string]interface{}) (*application, error) {
 		"charm-mod-version":   schema.Int(),
 		"force-charm":         schema.Bool(),
 		"exposed":             schema.Bool(),
+		"exposed-endpoints":   schema.StringMap(schema.StringMap(schema.Any())),
 		"min-units":           schema.Int(),
 		"status":              schema.StringMap(schema.Any()),
 		"settings":            schema.StringMap(schema.Any()),
@@ -328,6 +349,7 @@ func importApplicationV1(source map[string]interface{}) (*application, error) {
 		"subordinate":         false,
 		"force-charm":         false,
 		"exposed":             false,
+		"exposed-endpoints":   schema.Omit,
 		"min-units":           int64(0),
 		"leader":              "",
 		"metrics-creds":       "",
@@ -373,6 +395,14 @@ func importApplicationV1(source map[string]interface{}) (*application, error) {
 		result.Constraints_ = constraints
 	}
 
+	if endpointsMap, ok := valid["exposed-endpoints"]; ok {
+		endpoints, err := importExposedEndpoints(endpointsMap.(map[string]interface{}))
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		result.ExposedEndpoints_ = endpoints
+	}
+
 	if constraintsMap, ok := valid["storage-constraints"]; ok {
 		constraints, err := importStorageConstraints(constraintsMap.(map[string]interface{}))
 		if err != nil {
@@ -403,3 +433,45 @@ func importApplicationV1(source map[string]interface{}) (*application, error) {
 
 	return result, nil
 }
+
+// ExposedEndpointArgs is an argument struct used to specify the expose
+// settings of an application endpoint.
+type ExposedEndpointArgs struct {
+	ExposeToCIDRs []string
+}
+
+type exposedEndpoint struct {
+	ExposeToCIDRs_ []string `yaml:"expose-to-cidrs,omitempty"`
+}
+
+// ExposeToCIDRs implements ExposedEndpoint.
+func (e *exposedEndpoint) ExposeToCIDRs() []string {
+	return e.ExposeToCIDRs_
+}
+
+func importExposedEndpoints(sourceMap map[string]interface{}) (map[string]*exposedEndpoint, error) {
+	fields := schema.Fields{
+		"expose-to-cidrs": schema.List(schema.String()),
+	}
+	defaults := schema.Defaults{
+		"expose-to-cidrs": schema.Omit,
+	}
+	checker := schema.FieldMap(fields, defaults)
+
+	result := make(map[string]*exposedEndpoint)
+	for name, value := range sourceMap {
+		coerced, err := checker.Coerce(value, nil)
+		if err != nil {
+			return nil, errors.Annotatef(err, "exposed endpoint %q schema check failed", name)
+		}
+		valid := coerced.(map[string]interface{})
+		ep := &exposedEndpoint{}
+		if cidrs, ok := valid["expose-to-cidrs"]; ok {
+			for _, cidr := range cidrs.([]interface{}) {
+				ep.ExposeToCIDRs_ = append(ep.ExposeToCIDRs_, cidr.(string))
+			}
+		}
+		result[name] = ep
+	}
+	return result, nil
+}
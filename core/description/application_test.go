@@ -229,6 +229,28 @@ func (s *ApplicationSerializationSuite) TestStorageConstraints(c *gc.C) {
 	c.Check(second.Count(), gc.Equals, uint64(7))
 }
 
+func (s *ApplicationSerializationSuite) TestExposedEndpoints(c *gc.C) {
+	args := minimalApplicationArgs()
+	args.Exposed = true
+	args.ExposedEndpoints = map[string]ExposedEndpointArgs{
+		"":   {ExposeToCIDRs: []string{"10.0.0.0/8", "192.168.0.0/16"}},
+		"db": {},
+	}
+	initial := minimalApplication(args)
+
+	application := s.exportImport(c, initial)
+
+	endpoints := application.ExposedEndpoints()
+	c.Assert(endpoints, gc.HasLen, 2)
+	c.Check(endpoints[""].ExposeToCIDRs(), jc.DeepEquals, []string{"10.0.0.0/8", "192.168.0.0/16"})
+	c.Check(endpoints["db"].ExposeToCIDRs(), gc.HasLen, 0)
+}
+
+func (s *ApplicationSerializationSuite) TestNoExposedEndpoints(c *gc.C) {
+	application := s.exportImport(c, minimalApplication())
+	c.Assert(application.ExposedEndpoints(), gc.HasLen, 0)
+}
+
 func (s *ApplicationSerializationSuite) TestLeaderValid(c *gc.C) {
 	args := minimalApplicationArgs()
 	args.Leader = "ubuntu/1"
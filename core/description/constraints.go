@@ -22,6 +22,9 @@ type ConstraintsArgs struct {
 	Tags   []string
 
 	VirtType string
+	Zones    []string
+
+	PlacementGroup string
 }
 
 func newConstraints(args ConstraintsArgs) *constraints {
@@ -35,6 +38,11 @@ func newConstraints(args ConstraintsArgs) *constraints {
 	copy(tags, args.Tags)
 	spaces := make([]string, len(args.Spaces))
 	copy(spaces, args.Spaces)
+	var zones []string
+	if len(args.Zones) > 0 {
+		zones = make([]string, len(args.Zones))
+		copy(zones, args.Zones)
+	}
 	return &constraints{
 		Version:       1,
 		Architecture_: args.Architecture,
@@ -47,6 +55,9 @@ func newConstraints(args ConstraintsArgs) *constraints {
 		Spaces_:       spaces,
 		Tags_:         tags,
 		VirtType_:     args.VirtType,
+		Zones_:        zones,
+
+		PlacementGroup_: args.PlacementGroup,
 	}
 }
 
@@ -64,7 +75,10 @@ type constraints struct {
 	Spaces_ []string `yaml:"spaces,omitempty"`
 	Tags_   []string `yaml:"tags,omitempty"`
 
-	VirtType_ string `yaml:"virt-type,omitempty"`
+	VirtType_ string   `yaml:"virt-type,omitempty"`
+	Zones_    []string `yaml:"zones,omitempty"`
+
+	PlacementGroup_ string `yaml:"placement-group,omitempty"`
 }
 
 // Architecture implements Constraints.
@@ -127,6 +141,21 @@ func (c *constraints) VirtType() string {
 	return c.VirtType_
 }
 
+// PlacementGroup implements Constraints.
+func (c *constraints) PlacementGroup() string {
+	return c.PlacementGroup_
+}
+
+// Zones implements Constraints.
+func (c *constraints) Zones() []string {
+	var zones []string
+	if count := len(c.Zones_); count > 0 {
+		zones = make([]string, count)
+		copy(zones, c.Zones_)
+	}
+	return zones
+}
+
 func importConstraints(source map[string]interface{}) (*constraints, error) {
 	version, err := getVersion(source)
 	if err != nil {
@@ -161,6 +190,9 @@ func importConstraintsV1(source map[string]interface{}) (*constraints, error) {
 		"tags":   schema.List(schema.String()),
 
 		"virt-type": schema.String(),
+		"zones":     schema.List(schema.String()),
+
+		"placement-group": schema.String(),
 	}
 	// Some values don't have to be there.
 	defaults := schema.Defaults{
@@ -176,6 +208,9 @@ func importConstraintsV1(source map[string]interface{}) (*constraints, error) {
 		"tags":   schema.Omit,
 
 		"virt-type": "",
+		"zones":     schema.Omit,
+
+		"placement-group": "",
 	}
 	checker := schema.FieldMap(fields, defaults)
 
@@ -201,6 +236,9 @@ func importConstraintsV1(source map[string]interface{}) (*constraints, error) {
 		Tags_:   convertToStringSlice(valid["tags"]),
 
 		VirtType_: valid["virt-type"].(string),
+		Zones_:    convertToStringSlice(valid["zones"]),
+
+		PlacementGroup_: valid["placement-group"].(string),
 	}, nil
 }
 
@@ -219,5 +257,7 @@ func (c ConstraintsArgs) empty() bool {
 		c.RootDisk == 0 &&
 		c.Spaces == nil &&
 		c.Tags == nil &&
-		c.VirtType == ""
+		c.VirtType == "" &&
+		c.Zones == nil &&
+		c.PlacementGroup == ""
 }
@@ -72,6 +72,24 @@ func (s *ConstraintsSerializationSuite) TestNewConstraintsWithVirt(c *gc.C) {
 	c.Assert(instance.VirtType(), gc.Equals, args.VirtType)
 }
 
+func (s *ConstraintsSerializationSuite) TestNewConstraintsWithZones(c *gc.C) {
+	args := s.allArgs()
+	args.Zones = []string{"az1", "az2"}
+	instance := newConstraints(args)
+	args.Zones[0] = "weird"
+	zones := instance.Zones()
+	c.Assert(zones, jc.DeepEquals, []string{"az1", "az2"})
+	zones[0] = "weird"
+	c.Assert(instance.Zones(), jc.DeepEquals, []string{"az1", "az2"})
+}
+
+func (s *ConstraintsSerializationSuite) TestNewConstraintsWithPlacementGroup(c *gc.C) {
+	args := s.allArgs()
+	args.PlacementGroup = "spread"
+	instance := newConstraints(args)
+	c.Assert(instance.PlacementGroup(), gc.Equals, "spread")
+}
+
 func (s *ConstraintsSerializationSuite) TestNewConstraintsEmpty(c *gc.C) {
 	instance := newConstraints(ConstraintsArgs{})
 	c.Assert(instance, gc.IsNil)
@@ -82,6 +100,7 @@ func (s *ConstraintsSerializationSuite) TestEmptyTagsAndSpaces(c *gc.C) {
 	// We actually want them to be nil, not empty slices.
 	c.Assert(instance.Tags(), gc.IsNil)
 	c.Assert(instance.Spaces(), gc.IsNil)
+	c.Assert(instance.Zones(), gc.IsNil)
 }
 
 func (s *ConstraintsSerializationSuite) TestEmptyVirt(c *gc.C) {
@@ -111,3 +130,15 @@ func (s *ConstraintsSerializationSuite) TestParsingSerializedVirt(c *gc.C) {
 	args.VirtType = "kvm"
 	s.assertParsingSerializedConstraints(c, newConstraints(args))
 }
+
+func (s *ConstraintsSerializationSuite) TestParsingSerializedZones(c *gc.C) {
+	args := s.allArgs()
+	args.Zones = []string{"az1", "az2"}
+	s.assertParsingSerializedConstraints(c, newConstraints(args))
+}
+
+func (s *ConstraintsSerializationSuite) TestParsingSerializedPlacementGroup(c *gc.C) {
+	args := s.allArgs()
+	args.PlacementGroup = "cluster"
+	s.assertParsingSerializedConstraints(c, newConstraints(args))
+}
@@ -94,6 +94,9 @@ type Model interface {
 	Volumes() []Volume
 	AddVolume(VolumeArgs) Volume
 
+	VolumeSnapshots() []VolumeSnapshot
+	AddVolumeSnapshot(VolumeSnapshotArgs) VolumeSnapshot
+
 	Filesystems() []Filesystem
 	AddFilesystem(FilesystemArgs) Filesystem
 
@@ -197,6 +200,9 @@ type PortRange interface {
 	FromPort() int
 	ToPort() int
 	Protocol() string
+	// Endpoint returns the name of the unit's endpoint for which the
+	// range was opened, or "" if it was opened for all endpoints.
+	Endpoint() string
 }
 
 // CloudInstance holds information particular to a machine
@@ -228,6 +234,8 @@ type Constraints interface {
 	Tags() []string
 
 	VirtType() string
+	Zones() []string
+	PlacementGroup() string
 }
 
 // Status represents an agent, application, or workload status.
@@ -254,6 +262,10 @@ type Application interface {
 	CharmModifiedVersion() int
 	ForceCharm() bool
 	Exposed() bool
+	// ExposedEndpoints returns the expose settings of the exposed
+	// application, keyed by endpoint name; "" holds the settings for
+	// all endpoints.
+	ExposedEndpoints() map[string]ExposedEndpoint
 	MinUnits() int
 
 	Settings() map[string]interface{}
@@ -270,6 +282,14 @@ type Application interface {
 	Validate() error
 }
 
+// ExposedEndpoint represents the expose settings of an application
+// endpoint.
+type ExposedEndpoint interface {
+	// ExposeToCIDRs returns the CIDRs from which the endpoint may be
+	// reached; if empty, it may be reached from anywhere.
+	ExposeToCIDRs() []string
+}
+
 // Unit represents an instance of an application in a model.
 type Unit interface {
 	HasAnnotations
@@ -423,6 +443,8 @@ type Volume interface {
 	Provisioned() bool
 
 	Size() uint64
+	RequestedSize() uint64
+	SnapshotID() string
 	Pool() string
 
 	HardwareID() string
@@ -433,6 +455,23 @@ type Volume interface {
 	AddAttachment(VolumeAttachmentArgs) VolumeAttachment
 }
 
+// VolumeSnapshot represents a point-in-time snapshot of a volume.
+type VolumeSnapshot interface {
+	HasStatus
+
+	ID() string
+	Volume() names.VolumeTag
+	Storage() names.StorageTag
+	Pool() string
+	Created() time.Time
+
+	Provisioned() bool
+	SnapshotID() string
+	Size() uint64
+
+	Validate() error
+}
+
 // VolumeAttachment represents a volume attached to a machine.
 type VolumeAttachment interface {
 	Machine() names.MachineTag
@@ -52,6 +52,7 @@ func NewModel(args ModelArgs) Model {
 	m.setSSHHostKeys(nil)
 	m.setActions(nil)
 	m.setVolumes(nil)
+	m.setVolumeSnapshots(nil)
 	m.setFilesystems(nil)
 	m.setStorages(nil)
 	m.setStoragePools(nil)
@@ -139,10 +140,11 @@ type model struct {
 	CloudRegion_     string `yaml:"cloud-region,omitempty"`
 	CloudCredential_ string `yaml:"cloud-credential,omitempty"`
 
-	Volumes_      volumes      `yaml:"volumes"`
-	Filesystems_  filesystems  `yaml:"filesystems"`
-	Storages_     storages     `yaml:"storages"`
-	StoragePools_ storagepools `yaml:"storage-pools"`
+	Volumes_         volumes         `yaml:"volumes"`
+	VolumeSnapshots_ volumeSnapshots `yaml:"volume-snapshots"`
+	Filesystems_     filesystems     `yaml:"filesystems"`
+	Storages_        storages        `yaml:"storages"`
+	StoragePools_    storagepools    `yaml:"storage-pools"`
 }
 
 func (m *model) Tag() names.ModelTag {
@@ -490,6 +492,29 @@ func (m *model) setVolumes(volumeList []*volume) {
 	}
 }
 
+// VolumeSnapshots implements Model.
+func (m *model) VolumeSnapshots() []VolumeSnapshot {
+	var result []VolumeSnapshot
+	for _, snapshot := range m.VolumeSnapshots_.Snapshots_ {
+		result = append(result, snapshot)
+	}
+	return result
+}
+
+// AddVolumeSnapshot implements Model.
+func (m *model) AddVolumeSnapshot(args VolumeSnapshotArgs) VolumeSnapshot {
+	snapshot := newVolumeSnapshot(args)
+	m.VolumeSnapshots_.Snapshots_ = append(m.VolumeSnapshots_.Snapshots_, snapshot)
+	return snapshot
+}
+
+func (m *model) setVolumeSnapshots(snapshotList []*volumeSnapshot) {
+	m.VolumeSnapshots_ = volumeSnapshots{
+		Version:    1,
+		Snapshots_: snapshotList,
+	}
+}
+
 // Filesystems implements Model.
 func (m *model) Filesystems() []Filesystem {
 	var result []Filesystem
@@ -671,6 +696,13 @@ func (m *model) validateStorage(allMachineIDs, allApplications, allUnits set.Str
 			}
 		}
 	}
+	// Snapshots may outlive the volumes and storage they were taken
+	// of, so only their own fields are validated.
+	for i, snapshot := range m.VolumeSnapshots_.Snapshots_ {
+		if err := snapshot.Validate(); err != nil {
+			return errors.Annotatef(err, "volume snapshot[%d]", i)
+		}
+	}
 	for i, filesystem := range m.Filesystems_.Filesystems_ {
 		if err := filesystem.Validate(); err != nil {
 			return errors.Annotatef(err, "filesystem[%d]", i)
@@ -883,6 +915,7 @@ func importModelV1(source map[string]interface{}) (*model, error) {
 		"subnets":          schema.StringMap(schema.Any()),
 		"linklayerdevices": schema.StringMap(schema.Any()),
 		"volumes":          schema.StringMap(schema.Any()),
+		"volume-snapshots": schema.StringMap(schema.Any()),
 		"filesystems":      schema.StringMap(schema.Any()),
 		"storages":         schema.StringMap(schema.Any()),
 		"storage-pools":    schema.StringMap(schema.Any()),
@@ -893,6 +926,9 @@ func importModelV1(source map[string]interface{}) (*model, error) {
 		"latest-tools": schema.Omit,
 		"blocks":       schema.Omit,
 		"cloud-region": schema.Omit,
+		// Volume snapshots were added after the initial model
+		// description.
+		"volume-snapshots": schema.Omit,
 	}
 	addAnnotationSchema(fields, defaults)
 	addConstraintsSchema(fields, defaults)
@@ -1020,6 +1056,16 @@ func importModelV1(source map[string]interface{}) (*model, error) {
 	}
 	result.setVolumes(volumes)
 
+	if snapshotsMap, ok := valid["volume-snapshots"]; ok {
+		snapshots, err := importVolumeSnapshots(snapshotsMap.(map[string]interface{}))
+		if err != nil {
+			return nil, errors.Annotate(err, "volume snapshots")
+		}
+		result.setVolumeSnapshots(snapshots)
+	} else {
+		result.setVolumeSnapshots(nil)
+	}
+
 	filesystems, err := importFilesystems(valid["filesystems"].(map[string]interface{}))
 	if err != nil {
 		return nil, errors.Annotate(err, "filesystems")
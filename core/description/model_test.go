@@ -681,6 +681,53 @@ func (s *ModelSerializationSuite) TestVolumes(c *gc.C) {
 	c.Assert(model.Volumes(), jc.DeepEquals, volumes)
 }
 
+func (s *ModelSerializationSuite) TestVolumeSnapshotValidation(c *gc.C) {
+	model := NewModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	snapshot := model.AddVolumeSnapshot(VolumeSnapshotArgs{
+		ID:          "1",
+		Volume:      names.NewVolumeTag("1234"),
+		Provisioned: true,
+	})
+	snapshot.SetStatus(minimalStatusArgs())
+	err := model.Validate()
+	c.Assert(err, gc.ErrorMatches, `volume snapshot\[0\]: provisioned volume snapshot "1" missing snapshot id not valid`)
+}
+
+func (s *ModelSerializationSuite) TestVolumeSnapshots(c *gc.C) {
+	initial := NewModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	snapshot := initial.AddVolumeSnapshot(testVolumeSnapshotArgs())
+	snapshot.SetStatus(minimalStatusArgs())
+	snapshots := initial.VolumeSnapshots()
+	c.Assert(snapshots, gc.HasLen, 1)
+	c.Assert(snapshots[0], gc.Equals, snapshot)
+
+	bytes, err := yaml.Marshal(initial)
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := Deserialize(bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.VolumeSnapshots(), jc.DeepEquals, snapshots)
+}
+
+func (s *ModelSerializationSuite) TestVolumeSnapshotsMissing(c *gc.C) {
+	initial := NewModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	bytes, err := yaml.Marshal(initial)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Models serialized before volume snapshots were
+	// described have no volume-snapshots key.
+	var source map[string]interface{}
+	err = yaml.Unmarshal(bytes, &source)
+	c.Assert(err, jc.ErrorIsNil)
+	delete(source, "volume-snapshots")
+	bytes, err = yaml.Marshal(source)
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := Deserialize(bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.VolumeSnapshots(), gc.HasLen, 0)
+}
+
 func (s *ModelSerializationSuite) TestFilesystemValidation(c *gc.C) {
 	model := NewModel(ModelArgs{Owner: names.NewUserTag("owner")})
 	model.AddFilesystem(testFilesystemArgs())
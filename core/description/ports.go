@@ -131,6 +131,7 @@ type portRange struct {
 	FromPort_ int    `yaml:"from-port"`
 	ToPort_   int    `yaml:"to-port"`
 	Protocol_ string `yaml:"protocol"`
+	Endpoint_ string `yaml:"endpoint,omitempty"`
 }
 
 // PortRangeArgs is an argument struct used to create a PortRange. This is only
@@ -140,6 +141,7 @@ type PortRangeArgs struct {
 	FromPort int
 	ToPort   int
 	Protocol string
+	Endpoint string
 }
 
 func newPortRange(args PortRangeArgs) *portRange {
@@ -148,6 +150,7 @@ func newPortRange(args PortRangeArgs) *portRange {
 		FromPort_: args.FromPort,
 		ToPort_:   args.ToPort,
 		Protocol_: args.Protocol,
+		Endpoint_: args.Endpoint,
 	}
 }
 
@@ -175,6 +178,11 @@ func (p *portRange) Protocol() string {
 	return p.Protocol_
 }
 
+// Endpoint implements PortRange.
+func (p *portRange) Endpoint() string {
+	return p.Endpoint_
+}
+
 func importPortRanges(source map[string]interface{}) ([]*portRange, error) {
 	checker := versionedChecker("opened-ports")
 	coerced, err := checker.Coerce(source, nil)
@@ -220,9 +228,13 @@ func importPortRangeV1(source map[string]interface{}) (*portRange, error) {
 		"from-port": schema.Int(),
 		"to-port":   schema.Int(),
 		"protocol":  schema.String(),
+		"endpoint":  schema.String(),
 	}
-
-	checker := schema.FieldMap(fields, nil) // no defaults
+	// Some values don't have to be there.
+	defaults := schema.Defaults{
+		"endpoint": "",
+	}
+	checker := schema.FieldMap(fields, defaults)
 
 	coerced, err := checker.Coerce(source, nil)
 	if err != nil {
@@ -237,5 +249,6 @@ func importPortRangeV1(source map[string]interface{}) (*portRange, error) {
 		FromPort_: int(valid["from-port"].(int64)),
 		ToPort_:   int(valid["to-port"].(int64)),
 		Protocol_: valid["protocol"].(string),
+		Endpoint_: valid["endpoint"].(string),
 	}, nil
 }
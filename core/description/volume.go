@@ -15,15 +15,17 @@ type volumes struct {
 }
 
 type volume struct {
-	ID_          string `yaml:"id"`
-	Binding_     string `yaml:"binding,omitempty"`
-	StorageID_   string `yaml:"storage-id,omitempty"`
-	Provisioned_ bool   `yaml:"provisioned"`
-	Size_        uint64 `yaml:"size"`
-	Pool_        string `yaml:"pool,omitempty"`
-	HardwareID_  string `yaml:"hardware-id,omitempty"`
-	VolumeID_    string `yaml:"volume-id,omitempty"`
-	Persistent_  bool   `yaml:"persistent"`
+	ID_            string `yaml:"id"`
+	Binding_       string `yaml:"binding,omitempty"`
+	StorageID_     string `yaml:"storage-id,omitempty"`
+	Provisioned_   bool   `yaml:"provisioned"`
+	Size_          uint64 `yaml:"size"`
+	RequestedSize_ uint64 `yaml:"requested-size,omitempty"`
+	SnapshotID_    string `yaml:"snapshot-id,omitempty"`
+	Pool_          string `yaml:"pool,omitempty"`
+	HardwareID_    string `yaml:"hardware-id,omitempty"`
+	VolumeID_      string `yaml:"volume-id,omitempty"`
+	Persistent_    bool   `yaml:"persistent"`
 
 	Status_        *status `yaml:"status"`
 	StatusHistory_ `yaml:"status-history"`
@@ -47,15 +49,19 @@ type volumeAttachment struct {
 
 // VolumeArgs is an argument struct used to add a volume to the Model.
 type VolumeArgs struct {
-	Tag         names.VolumeTag
-	Storage     names.StorageTag
-	Binding     names.Tag
-	Provisioned bool
-	Size        uint64
-	Pool        string
-	HardwareID  string
-	VolumeID    string
-	Persistent  bool
+	Tag           names.VolumeTag
+	Storage       names.StorageTag
+	Binding       names.Tag
+	Provisioned   bool
+	Size          uint64
+	RequestedSize uint64
+	// SnapshotID is the provider ID of the volume snapshot from
+	// which an unprovisioned volume is to be created.
+	SnapshotID string
+	Pool       string
+	HardwareID string
+	VolumeID   string
+	Persistent bool
 }
 
 func newVolume(args VolumeArgs) *volume {
@@ -64,6 +70,8 @@ func newVolume(args VolumeArgs) *volume {
 		StorageID_:     args.Storage.Id(),
 		Provisioned_:   args.Provisioned,
 		Size_:          args.Size,
+		RequestedSize_: args.RequestedSize,
+		SnapshotID_:    args.SnapshotID,
 		Pool_:          args.Pool,
 		HardwareID_:    args.HardwareID,
 		VolumeID_:      args.VolumeID,
@@ -112,6 +120,16 @@ func (v *volume) Size() uint64 {
 	return v.Size_
 }
 
+// RequestedSize implements Volume.
+func (v *volume) RequestedSize() uint64 {
+	return v.RequestedSize_
+}
+
+// SnapshotID implements Volume.
+func (v *volume) SnapshotID() string {
+	return v.SnapshotID_
+}
+
 // Pool implements Volume.
 func (v *volume) Pool() string {
 	return v.Pool_
@@ -227,26 +245,30 @@ var volumeDeserializationFuncs = map[int]volumeDeserializationFunc{
 
 func importVolumeV1(source map[string]interface{}) (*volume, error) {
 	fields := schema.Fields{
-		"id":          schema.String(),
-		"storage-id":  schema.String(),
-		"binding":     schema.String(),
-		"provisioned": schema.Bool(),
-		"size":        schema.ForceUint(),
-		"pool":        schema.String(),
-		"hardware-id": schema.String(),
-		"volume-id":   schema.String(),
-		"persistent":  schema.Bool(),
-		"status":      schema.StringMap(schema.Any()),
-		"attachments": schema.StringMap(schema.Any()),
+		"id":             schema.String(),
+		"storage-id":     schema.String(),
+		"binding":        schema.String(),
+		"provisioned":    schema.Bool(),
+		"size":           schema.ForceUint(),
+		"requested-size": schema.ForceUint(),
+		"snapshot-id":    schema.String(),
+		"pool":           schema.String(),
+		"hardware-id":    schema.String(),
+		"volume-id":      schema.String(),
+		"persistent":     schema.Bool(),
+		"status":         schema.StringMap(schema.Any()),
+		"attachments":    schema.StringMap(schema.Any()),
 	}
 
 	defaults := schema.Defaults{
-		"storage-id":  "",
-		"binding":     "",
-		"pool":        "",
-		"hardware-id": "",
-		"volume-id":   "",
-		"attachments": schema.Omit,
+		"storage-id":     "",
+		"binding":        "",
+		"requested-size": uint64(0),
+		"snapshot-id":    "",
+		"pool":           "",
+		"hardware-id":    "",
+		"volume-id":      "",
+		"attachments":    schema.Omit,
 	}
 	addStatusHistorySchema(fields)
 	checker := schema.FieldMap(fields, defaults)
@@ -264,6 +286,8 @@ func importVolumeV1(source map[string]interface{}) (*volume, error) {
 		Binding_:       valid["binding"].(string),
 		Provisioned_:   valid["provisioned"].(bool),
 		Size_:          valid["size"].(uint64),
+		RequestedSize_: valid["requested-size"].(uint64),
+		SnapshotID_:    valid["snapshot-id"].(string),
 		Pool_:          valid["pool"].(string),
 		HardwareID_:    valid["hardware-id"].(string),
 		VolumeID_:      valid["volume-id"].(string),
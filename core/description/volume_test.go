@@ -43,6 +43,8 @@ func testVolumeMap() map[interface{}]interface{} {
 		"binding":        "machine-42",
 		"provisioned":    true,
 		"size":           int(20 * gig),
+		"requested-size": int(30 * gig),
+		"snapshot-id":    "snap-1",
 		"pool":           "swimming",
 		"hardware-id":    "a hardware id",
 		"volume-id":      "some volume id",
@@ -64,15 +66,17 @@ func testVolume() *volume {
 
 func testVolumeArgs() VolumeArgs {
 	return VolumeArgs{
-		Tag:         names.NewVolumeTag("1234"),
-		Storage:     names.NewStorageTag("test/1"),
-		Binding:     names.NewMachineTag("42"),
-		Provisioned: true,
-		Size:        20 * gig,
-		Pool:        "swimming",
-		HardwareID:  "a hardware id",
-		VolumeID:    "some volume id",
-		Persistent:  true,
+		Tag:           names.NewVolumeTag("1234"),
+		Storage:       names.NewStorageTag("test/1"),
+		Binding:       names.NewMachineTag("42"),
+		Provisioned:   true,
+		Size:          20 * gig,
+		RequestedSize: 30 * gig,
+		SnapshotID:    "snap-1",
+		Pool:          "swimming",
+		HardwareID:    "a hardware id",
+		VolumeID:      "some volume id",
+		Persistent:    true,
 	}
 }
 
@@ -86,6 +90,8 @@ func (s *VolumeSerializationSuite) TestNewVolume(c *gc.C) {
 	c.Check(binding, gc.Equals, names.NewMachineTag("42"))
 	c.Check(volume.Provisioned(), jc.IsTrue)
 	c.Check(volume.Size(), gc.Equals, 20*gig)
+	c.Check(volume.RequestedSize(), gc.Equals, 30*gig)
+	c.Check(volume.SnapshotID(), gc.Equals, "snap-1")
 	c.Check(volume.Pool(), gc.Equals, "swimming")
 	c.Check(volume.HardwareID(), gc.Equals, "a hardware id")
 	c.Check(volume.VolumeID(), gc.Equals, "some volume id")
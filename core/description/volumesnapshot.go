@@ -0,0 +1,216 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/schema"
+	"gopkg.in/juju/names.v2"
+)
+
+type volumeSnapshots struct {
+	Version    int               `yaml:"version"`
+	Snapshots_ []*volumeSnapshot `yaml:"snapshots"`
+}
+
+type volumeSnapshot struct {
+	ID_          string    `yaml:"id"`
+	VolumeID_    string    `yaml:"volume-id"`
+	StorageID_   string    `yaml:"storage-id,omitempty"`
+	Pool_        string    `yaml:"pool"`
+	Created_     time.Time `yaml:"created"`
+	Provisioned_ bool      `yaml:"provisioned"`
+	SnapshotID_  string    `yaml:"snapshot-id,omitempty"`
+	Size_        uint64    `yaml:"size,omitempty"`
+
+	Status_ *status `yaml:"status"`
+}
+
+// VolumeSnapshotArgs is an argument struct used to add a volume snapshot
+// to the Model.
+type VolumeSnapshotArgs struct {
+	ID          string
+	Volume      names.VolumeTag
+	Storage     names.StorageTag
+	Pool        string
+	Created     time.Time
+	Provisioned bool
+	SnapshotID  string
+	Size        uint64
+}
+
+func newVolumeSnapshot(args VolumeSnapshotArgs) *volumeSnapshot {
+	return &volumeSnapshot{
+		ID_:          args.ID,
+		VolumeID_:    args.Volume.Id(),
+		StorageID_:   args.Storage.Id(),
+		Pool_:        args.Pool,
+		Created_:     args.Created.UTC(),
+		Provisioned_: args.Provisioned,
+		SnapshotID_:  args.SnapshotID,
+		Size_:        args.Size,
+	}
+}
+
+// ID implements VolumeSnapshot.
+func (s *volumeSnapshot) ID() string {
+	return s.ID_
+}
+
+// Volume implements VolumeSnapshot.
+func (s *volumeSnapshot) Volume() names.VolumeTag {
+	return names.NewVolumeTag(s.VolumeID_)
+}
+
+// Storage implements VolumeSnapshot.
+func (s *volumeSnapshot) Storage() names.StorageTag {
+	if s.StorageID_ == "" {
+		return names.StorageTag{}
+	}
+	return names.NewStorageTag(s.StorageID_)
+}
+
+// Pool implements VolumeSnapshot.
+func (s *volumeSnapshot) Pool() string {
+	return s.Pool_
+}
+
+// Created implements VolumeSnapshot.
+func (s *volumeSnapshot) Created() time.Time {
+	return s.Created_
+}
+
+// Provisioned implements VolumeSnapshot.
+func (s *volumeSnapshot) Provisioned() bool {
+	return s.Provisioned_
+}
+
+// SnapshotID implements VolumeSnapshot.
+func (s *volumeSnapshot) SnapshotID() string {
+	return s.SnapshotID_
+}
+
+// Size implements VolumeSnapshot.
+func (s *volumeSnapshot) Size() uint64 {
+	return s.Size_
+}
+
+// Status implements VolumeSnapshot.
+func (s *volumeSnapshot) Status() Status {
+	// To avoid typed nils check nil here.
+	if s.Status_ == nil {
+		return nil
+	}
+	return s.Status_
+}
+
+// SetStatus implements VolumeSnapshot.
+func (s *volumeSnapshot) SetStatus(args StatusArgs) {
+	s.Status_ = newStatus(args)
+}
+
+// Validate implements VolumeSnapshot.
+func (s *volumeSnapshot) Validate() error {
+	if s.ID_ == "" {
+		return errors.NotValidf("volume snapshot missing id")
+	}
+	if s.VolumeID_ == "" {
+		return errors.NotValidf("volume snapshot %q missing volume", s.ID_)
+	}
+	if s.Status_ == nil {
+		return errors.NotValidf("volume snapshot %q missing status", s.ID_)
+	}
+	if s.Provisioned_ && s.SnapshotID_ == "" {
+		return errors.NotValidf("provisioned volume snapshot %q missing snapshot id", s.ID_)
+	}
+	return nil
+}
+
+func importVolumeSnapshots(source map[string]interface{}) ([]*volumeSnapshot, error) {
+	checker := versionedChecker("snapshots")
+	coerced, err := checker.Coerce(source, nil)
+	if err != nil {
+		return nil, errors.Annotatef(err, "volume snapshots version schema check failed")
+	}
+	valid := coerced.(map[string]interface{})
+
+	version := int(valid["version"].(int64))
+	importFunc, ok := volumeSnapshotDeserializationFuncs[version]
+	if !ok {
+		return nil, errors.NotValidf("version %d", version)
+	}
+	sourceList := valid["snapshots"].([]interface{})
+	return importVolumeSnapshotList(sourceList, importFunc)
+}
+
+func importVolumeSnapshotList(sourceList []interface{}, importFunc volumeSnapshotDeserializationFunc) ([]*volumeSnapshot, error) {
+	result := make([]*volumeSnapshot, 0, len(sourceList))
+	for i, value := range sourceList {
+		source, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, errors.Errorf("unexpected value for volume snapshot %d, %T", i, value)
+		}
+		snapshot, err := importFunc(source)
+		if err != nil {
+			return nil, errors.Annotatef(err, "volume snapshot %d", i)
+		}
+		result = append(result, snapshot)
+	}
+	return result, nil
+}
+
+type volumeSnapshotDeserializationFunc func(map[string]interface{}) (*volumeSnapshot, error)
+
+var volumeSnapshotDeserializationFuncs = map[int]volumeSnapshotDeserializationFunc{
+	1: importVolumeSnapshotV1,
+}
+
+func importVolumeSnapshotV1(source map[string]interface{}) (*volumeSnapshot, error) {
+	fields := schema.Fields{
+		"id":          schema.String(),
+		"volume-id":   schema.String(),
+		"storage-id":  schema.String(),
+		"pool":        schema.String(),
+		"created":     schema.Time(),
+		"provisioned": schema.Bool(),
+		"snapshot-id": schema.String(),
+		"size":        schema.ForceUint(),
+		"status":      schema.StringMap(schema.Any()),
+	}
+
+	defaults := schema.Defaults{
+		"storage-id":  "",
+		"snapshot-id": "",
+		"size":        uint64(0),
+	}
+	checker := schema.FieldMap(fields, defaults)
+
+	coerced, err := checker.Coerce(source, nil)
+	if err != nil {
+		return nil, errors.Annotatef(err, "volume snapshot v1 schema check failed")
+	}
+	valid := coerced.(map[string]interface{})
+	// From here we know that the map returned from the schema coercion
+	// contains fields of the right type.
+	result := &volumeSnapshot{
+		ID_:          valid["id"].(string),
+		VolumeID_:    valid["volume-id"].(string),
+		StorageID_:   valid["storage-id"].(string),
+		Pool_:        valid["pool"].(string),
+		Created_:     valid["created"].(time.Time).UTC(),
+		Provisioned_: valid["provisioned"].(bool),
+		SnapshotID_:  valid["snapshot-id"].(string),
+		Size_:        valid["size"].(uint64),
+	}
+
+	status, err := importStatus(valid["status"].(map[string]interface{}))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result.Status_ = status
+
+	return result, nil
+}
@@ -0,0 +1,116 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/yaml.v2"
+)
+
+type VolumeSnapshotSerializationSuite struct {
+	SliceSerializationSuite
+}
+
+var _ = gc.Suite(&VolumeSnapshotSerializationSuite{})
+
+func (s *VolumeSnapshotSerializationSuite) SetUpTest(c *gc.C) {
+	s.SliceSerializationSuite.SetUpTest(c)
+	s.importName = "volume snapshots"
+	s.sliceName = "snapshots"
+	s.importFunc = func(m map[string]interface{}) (interface{}, error) {
+		return importVolumeSnapshots(m)
+	}
+	s.testFields = func(m map[string]interface{}) {
+		m["snapshots"] = []interface{}{}
+	}
+}
+
+func testVolumeSnapshotArgs() VolumeSnapshotArgs {
+	return VolumeSnapshotArgs{
+		ID:          "42",
+		Volume:      names.NewVolumeTag("1234"),
+		Storage:     names.NewStorageTag("test/1"),
+		Pool:        "swimming",
+		Created:     time.Date(2016, 10, 1, 12, 0, 0, 0, time.UTC),
+		Provisioned: true,
+		SnapshotID:  "snap-1",
+		Size:        20 * gig,
+	}
+}
+
+func testVolumeSnapshot() *volumeSnapshot {
+	s := newVolumeSnapshot(testVolumeSnapshotArgs())
+	s.SetStatus(minimalStatusArgs())
+	return s
+}
+
+func (s *VolumeSnapshotSerializationSuite) TestNewVolumeSnapshot(c *gc.C) {
+	snapshot := testVolumeSnapshot()
+
+	c.Check(snapshot.ID(), gc.Equals, "42")
+	c.Check(snapshot.Volume(), gc.Equals, names.NewVolumeTag("1234"))
+	c.Check(snapshot.Storage(), gc.Equals, names.NewStorageTag("test/1"))
+	c.Check(snapshot.Pool(), gc.Equals, "swimming")
+	c.Check(snapshot.Created(), gc.Equals, time.Date(2016, 10, 1, 12, 0, 0, 0, time.UTC))
+	c.Check(snapshot.Provisioned(), jc.IsTrue)
+	c.Check(snapshot.SnapshotID(), gc.Equals, "snap-1")
+	c.Check(snapshot.Size(), gc.Equals, 20*gig)
+	c.Check(snapshot.Status(), jc.DeepEquals, minimalStatus())
+	c.Check(snapshot.Validate(), jc.ErrorIsNil)
+}
+
+func (s *VolumeSnapshotSerializationSuite) TestValidateMissingStatus(c *gc.C) {
+	snapshot := newVolumeSnapshot(testVolumeSnapshotArgs())
+	err := snapshot.Validate()
+	c.Check(err, gc.ErrorMatches, `volume snapshot "42" missing status not valid`)
+}
+
+func (s *VolumeSnapshotSerializationSuite) TestValidateMissingVolume(c *gc.C) {
+	snapshot := newVolumeSnapshot(VolumeSnapshotArgs{ID: "42"})
+	err := snapshot.Validate()
+	c.Check(err, gc.ErrorMatches, `volume snapshot "42" missing volume not valid`)
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *VolumeSnapshotSerializationSuite) exportImport(c *gc.C, snapshot *volumeSnapshot) *volumeSnapshot {
+	initial := volumeSnapshots{
+		Version:    1,
+		Snapshots_: []*volumeSnapshot{snapshot},
+	}
+
+	bytes, err := yaml.Marshal(initial)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var source map[string]interface{}
+	err = yaml.Unmarshal(bytes, &source)
+	c.Assert(err, jc.ErrorIsNil)
+
+	snapshots, err := importVolumeSnapshots(source)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshots, gc.HasLen, 1)
+	return snapshots[0]
+}
+
+func (s *VolumeSnapshotSerializationSuite) TestParsingSerializedData(c *gc.C) {
+	original := testVolumeSnapshot()
+	snapshot := s.exportImport(c, original)
+	c.Assert(snapshot, jc.DeepEquals, original)
+}
+
+func (s *VolumeSnapshotSerializationSuite) TestParsingPending(c *gc.C) {
+	original := newVolumeSnapshot(VolumeSnapshotArgs{
+		ID:      "42",
+		Volume:  names.NewVolumeTag("1234"),
+		Pool:    "swimming",
+		Created: time.Date(2016, 10, 1, 12, 0, 0, 0, time.UTC),
+	})
+	original.SetStatus(minimalStatusArgs())
+	snapshot := s.exportImport(c, original)
+	c.Assert(snapshot, jc.DeepEquals, original)
+}
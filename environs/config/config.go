@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -22,6 +23,8 @@ import (
 	"github.com/juju/juju/controller"
 	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/juju/osenv"
+	"github.com/juju/juju/logfwd/gelf"
+	"github.com/juju/juju/logfwd/httpjson"
 	"github.com/juju/juju/logfwd/syslog"
 )
 
@@ -121,10 +124,69 @@ const (
 	// forwarding.
 	LogFwdSyslogClientKey = "syslog-client-key"
 
+	// LogFwdSink selects the sink to which logs are forwarded: one
+	// of "syslog" (the default), "http" or "gelf".
+	LogFwdSink = "logforward-sink"
+
+	// LogFwdHTTPURL sets the URL to which batches of log records are
+	// POSTed by the "http" log forwarding sink.
+	LogFwdHTTPURL = "logforward-http-url"
+
+	// LogFwdHTTPFormat sets the request body format of the "http" log
+	// forwarding sink: "json" (the default) or "elasticsearch".
+	LogFwdHTTPFormat = "logforward-http-format"
+
+	// LogFwdGELFAddress sets the hostname:port of the GELF input used
+	// by the "gelf" log forwarding sink.
+	LogFwdGELFAddress = "logforward-gelf-address"
+
+	// LogFwdGELFProtocol sets the transport protocol of the "gelf" log
+	// forwarding sink: "udp" (the default) or "tcp".
+	LogFwdGELFProtocol = "logforward-gelf-protocol"
+
+	// LogFwdLevel sets the minimum level of forwarded log records.
+	LogFwdLevel = "logforward-level"
+
+	// LogFwdIncludeEntity restricts log forwarding to the records of
+	// the listed entities. The value is a comma- or space-separated
+	// list of entity tags, each of which may end with '*'.
+	LogFwdIncludeEntity = "logforward-include-entity"
+
+	// LogFwdExcludeEntity prevents forwarding of the records of the
+	// listed entities, in the same format as LogFwdIncludeEntity.
+	LogFwdExcludeEntity = "logforward-exclude-entity"
+
+	// LogFwdIncludeModule restricts log forwarding to the records of
+	// the listed logging modules and their submodules. The value is a
+	// comma- or space-separated list of module names.
+	LogFwdIncludeModule = "logforward-include-module"
+
+	// LogFwdExcludeModule prevents forwarding of the records of the
+	// listed logging modules and their submodules, in the same format
+	// as LogFwdIncludeModule.
+	LogFwdExcludeModule = "logforward-exclude-module"
+
+	// LoggingOverridesKey is an optional space-separated list of
+	// <entity-tag>:<logging-config> entries, overriding the model's
+	// logging config for individual machines, applications and units.
+	LoggingOverridesKey = "logging-overrides"
+
+	// MaxLogsAgeKey is the key for the age beyond which the model's
+	// log records are pruned, overriding the controller-wide default.
+	MaxLogsAgeKey = "max-logs-age"
+
+	// MaxLogsSizeKey is the key for the maximum size of the model's
+	// log records, beyond which the oldest are pruned.
+	MaxLogsSizeKey = "max-logs-size"
+
 	// AutomaticallyRetryHooks determines whether the uniter will
 	// automatically retry a hook that has failed
 	AutomaticallyRetryHooks = "automatically-retry-hooks"
 
+	// HookTimeoutKey is the key for the maximum time a hook may run
+	// before it is killed and the unit put into an error state.
+	HookTimeoutKey = "hook-timeout"
+
 	//
 	// Deprecated Settings Attributes
 	//
@@ -431,9 +493,21 @@ func Validate(cfg, old *Config) error {
 		}
 	}
 
-	if lfCfg, ok := cfg.LogFwdSyslog(); ok {
+	if v, ok := cfg.defined[LoggingOverridesKey].(string); ok {
+		if _, err := ParseLoggingOverrides(v); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	if v, ok := cfg.defined[LogFwdLevel].(string); ok && v != "" {
+		if _, ok := loggo.ParseLevel(v); !ok {
+			return errors.NotValidf("%s %q", LogFwdLevel, v)
+		}
+	}
+
+	if lfCfg, ok := cfg.LogFwd(); ok {
 		if err := lfCfg.Validate(); err != nil {
-			return errors.Annotate(err, "invalid syslog forwarding config")
+			return errors.Trace(err)
 		}
 	}
 
@@ -441,6 +515,32 @@ func Validate(cfg, old *Config) error {
 		return errors.Errorf("uuid: expected UUID, got string(%q)", uuid)
 	}
 
+	if v, ok := cfg.defined[HookTimeoutKey].(string); ok && v != "" {
+		timeout, err := time.ParseDuration(v)
+		if err != nil {
+			return errors.Annotate(err, "invalid hook-timeout in model configuration")
+		}
+		if timeout < 0 {
+			return errors.Errorf("negative hook-timeout %q in model configuration", v)
+		}
+	}
+
+	if v, ok := cfg.defined[MaxLogsAgeKey].(string); ok && v != "" {
+		age, err := time.ParseDuration(v)
+		if err != nil {
+			return errors.Annotate(err, "invalid max-logs-age in model configuration")
+		}
+		if age < 0 {
+			return errors.Errorf("negative max-logs-age %q in model configuration", v)
+		}
+	}
+
+	if v, ok := cfg.defined[MaxLogsSizeKey].(string); ok && v != "" {
+		if _, err := utils.ParseSize(v); err != nil {
+			return errors.Annotate(err, "invalid max-logs-size in model configuration")
+		}
+	}
+
 	// Ensure the resource tags have the expected k=v format.
 	if _, err := cfg.resourceTags(); err != nil {
 		return errors.Annotate(err, "validating resource tags")
@@ -677,6 +777,57 @@ func (c *Config) LogFwdSyslog() (*syslog.RawConfig, bool) {
 	return &lfCfg, true
 }
 
+// LogFwd returns the log forwarding config, covering the selected
+// sink and the config of each supported sink.
+func (c *Config) LogFwd() (*LogFwdConfig, bool) {
+	lfCfg := LogFwdConfig{
+		Sink: LogFwdSinkSyslog,
+	}
+	syslogCfg, partial := c.LogFwdSyslog()
+	if partial {
+		lfCfg.Enabled = syslogCfg.Enabled
+		lfCfg.Syslog = *syslogCfg
+	}
+
+	for key, field := range map[string]*string{
+		LogFwdSink:         &lfCfg.Sink,
+		LogFwdHTTPURL:      &lfCfg.HTTP.URL,
+		LogFwdHTTPFormat:   &lfCfg.HTTP.Format,
+		LogFwdGELFAddress:  &lfCfg.GELF.Address,
+		LogFwdGELFProtocol: &lfCfg.GELF.Protocol,
+	} {
+		if s, ok := c.defined[key]; ok && s != "" {
+			partial = true
+			*field = s.(string)
+		}
+	}
+
+	for key, field := range map[string]*[]string{
+		LogFwdIncludeEntity: &lfCfg.Filter.IncludeEntity,
+		LogFwdExcludeEntity: &lfCfg.Filter.ExcludeEntity,
+		LogFwdIncludeModule: &lfCfg.Filter.IncludeModule,
+		LogFwdExcludeModule: &lfCfg.Filter.ExcludeModule,
+	} {
+		if s, ok := c.defined[key]; ok && s != "" {
+			partial = true
+			*field = splitLogFwdList(s.(string))
+		}
+	}
+	if s, ok := c.defined[LogFwdLevel]; ok && s != "" {
+		partial = true
+		// The level is checked in Validate.
+		lfCfg.Filter.MinLevel, _ = loggo.ParseLevel(s.(string))
+	}
+
+	if !partial {
+		return nil, false
+	}
+	lfCfg.Syslog.Enabled = lfCfg.Enabled && lfCfg.Sink == LogFwdSinkSyslog
+	lfCfg.HTTP.Enabled = lfCfg.Enabled && lfCfg.Sink == LogFwdSinkHTTP
+	lfCfg.GELF.Enabled = lfCfg.Enabled && lfCfg.Sink == LogFwdSinkGELF
+	return &lfCfg, true
+}
+
 // FirewallMode returns whether the firewall should
 // manage ports per machine, globally, or not at all.
 // (FwInstance, FwGlobal, or FwNone).
@@ -763,6 +914,31 @@ func (c *Config) AutomaticallyRetryHooks() bool {
 	}
 }
 
+// MaxLogsAge returns the age beyond which the model's log records are
+// pruned. Zero, the default, means that the controller's limit applies.
+func (c *Config) MaxLogsAge() time.Duration {
+	// Value has already been validated.
+	age, _ := time.ParseDuration(c.asString(MaxLogsAgeKey))
+	return age
+}
+
+// MaxLogsSizeMB returns the maximum size, in MiB, of the model's log
+// records. Zero, the default, means that the model's logs are limited
+// only by the controller's limit on the size of all logs.
+func (c *Config) MaxLogsSizeMB() int {
+	// Value has already been validated.
+	size, _ := utils.ParseSize(c.asString(MaxLogsSizeKey))
+	return int(size)
+}
+
+// HookTimeout returns the maximum time a hook may run before it is
+// killed. Zero, the default, means that hooks may run indefinitely.
+func (c *Config) HookTimeout() time.Duration {
+	// Value has already been validated.
+	timeout, _ := time.ParseDuration(c.asString(HookTimeoutKey))
+	return timeout
+}
+
 // ProvisionerHarvestMode reports the harvesting methodology the
 // provisioner should take.
 func (c *Config) ProvisionerHarvestMode() HarvestMode {
@@ -924,6 +1100,16 @@ var alwaysOptional = schema.Defaults{
 	LogFwdSyslogCACert:     schema.Omit,
 	LogFwdSyslogClientCert: schema.Omit,
 	LogFwdSyslogClientKey:  schema.Omit,
+	LogFwdSink:             schema.Omit,
+	LogFwdHTTPURL:          schema.Omit,
+	LogFwdHTTPFormat:       schema.Omit,
+	LogFwdGELFAddress:      schema.Omit,
+	LogFwdGELFProtocol:     schema.Omit,
+	LogFwdLevel:            schema.Omit,
+	LogFwdIncludeEntity:    schema.Omit,
+	LogFwdExcludeEntity:    schema.Omit,
+	LogFwdIncludeModule:    schema.Omit,
+	LogFwdExcludeModule:    schema.Omit,
 
 	// Storage related config.
 	// Environ providers will specify their own defaults.
@@ -931,6 +1117,7 @@ var alwaysOptional = schema.Defaults{
 
 	"firewall-mode":              schema.Omit,
 	"logging-config":             schema.Omit,
+	LoggingOverridesKey:          schema.Omit,
 	ProvisionerHarvestModeKey:    schema.Omit,
 	HttpProxyKey:                 schema.Omit,
 	HttpsProxyKey:                schema.Omit,
@@ -955,6 +1142,9 @@ var alwaysOptional = schema.Defaults{
 	"disable-network-management": schema.Omit,
 	IgnoreMachineAddresses:       schema.Omit,
 	AutomaticallyRetryHooks:      schema.Omit,
+	HookTimeoutKey:               schema.Omit,
+	MaxLogsAgeKey:                schema.Omit,
+	MaxLogsSizeKey:               schema.Omit,
 	"test-mode":                  schema.Omit,
 }
 
@@ -1201,6 +1391,21 @@ global or per instance security groups.`,
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	HookTimeoutKey: {
+		Description: `The maximum time a hook may run before it is killed and the unit goes into an error state, e.g. "30m". Charms may declare their own hook-timeout in metadata.yaml, which takes precedence. (default: no limit)`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	MaxLogsAgeKey: {
+		Description: `The age beyond which the model's log records are pruned, e.g. "72h". (default: the controller's limit)`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	MaxLogsSizeKey: {
+		Description: `The maximum size of the model's log records, e.g. "512M", beyond which the oldest are pruned. (default: no limit other than the controller's limit on all logs)`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
 	"image-metadata-url": {
 		Description: "The URL at which the metadata used to locate OS image ids is located",
 		Type:        environschema.Tstring,
@@ -1216,6 +1421,11 @@ global or per instance security groups.`,
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	LoggingOverridesKey: {
+		Description: `A space-separated list of <entity-tag>:<logging-config> entries overriding logging-config for individual machines, applications and units`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
 	NameKey: {
 		Description: "The name of the current model",
 		Type:        environschema.Tstring,
@@ -1271,6 +1481,59 @@ global or per instance security groups.`,
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	LogFwdSink: {
+		Description: `The sink to which logs are forwarded (default syslog).`,
+		Type:        environschema.Tstring,
+		Values:      []interface{}{LogFwdSinkSyslog, LogFwdSinkHTTP, LogFwdSinkGELF},
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdHTTPURL: {
+		Description: `The URL to which the http log forwarding sink POSTs log records.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdHTTPFormat: {
+		Description: `The request format of the http log forwarding sink (default json).`,
+		Type:        environschema.Tstring,
+		Values:      []interface{}{httpjson.FormatJSON, httpjson.FormatElasticsearch},
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdGELFAddress: {
+		Description: `The hostname:port of the GELF input used by the gelf log forwarding sink.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdGELFProtocol: {
+		Description: `The transport protocol of the gelf log forwarding sink (default udp). Records sent over udp may be lost in transit.`,
+		Type:        environschema.Tstring,
+		Values:      []interface{}{gelf.ProtocolUDP, gelf.ProtocolTCP},
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdLevel: {
+		Description: `The minimum level of forwarded log records, e.g. WARNING.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdIncludeEntity: {
+		Description: `The entity tags whose log records are forwarded, e.g. "unit-mysql-* machine-0".`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdExcludeEntity: {
+		Description: `The entity tags whose log records are not forwarded.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdIncludeModule: {
+		Description: `The logging modules whose log records are forwarded, e.g. "juju.worker".`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdExcludeModule: {
+		Description: `The logging modules whose log records are not forwarded.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
 	"ssl-hostname-verification": {
 		Description: "Whether SSL hostname verification is enabled (default true)",
 		Type:        environschema.Tbool,
@@ -19,6 +19,7 @@ import (
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charmrepo.v2-unstable"
 	"gopkg.in/juju/environschema.v1"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/juju/osenv"
@@ -368,6 +369,54 @@ var configTests = []configTest{
 			"logging-config": "foo=bar",
 		}),
 		err: `unknown severity level "bar"`,
+	}, {
+		about:       "Valid hook timeout",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"hook-timeout": "30m",
+		}),
+	}, {
+		about:       "Invalid hook timeout",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"hook-timeout": "soon",
+		}),
+		err: `invalid hook-timeout in model configuration: time: invalid duration "?soon"?`,
+	}, {
+		about:       "Negative hook timeout",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"hook-timeout": "-5m",
+		}),
+		err: `negative hook-timeout "-5m" in model configuration`,
+	}, {
+		about:       "Valid log limits",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"max-logs-age":  "72h",
+			"max-logs-size": "512M",
+		}),
+	}, {
+		about:       "Invalid max logs age",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"max-logs-age": "forever",
+		}),
+		err: `invalid max-logs-age in model configuration: time: invalid duration "?forever"?`,
+	}, {
+		about:       "Negative max logs age",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"max-logs-age": "-1h",
+		}),
+		err: `negative max-logs-age "-1h" in model configuration`,
+	}, {
+		about:       "Invalid max logs size",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"max-logs-size": "lots",
+		}),
+		err: `invalid max-logs-size in model configuration: .*`,
 	}, {
 		about:       "Sample configuration",
 		useDefaults: config.UseDefaults,
@@ -531,6 +580,127 @@ var configTests = []configTest{
 			"syslog-client-cert": testing.ServerCert,
 			"syslog-client-key":  testing.ServerKey,
 		}),
+	}, {
+		about:       "Valid http log forwarding config values",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"type":                   "my-type",
+			"name":                   "my-name",
+			"logforward-enabled":     true,
+			"logforward-sink":        "http",
+			"logforward-http-url":    "https://es.example.com:9200/juju/logs/_bulk",
+			"logforward-http-format": "elasticsearch",
+		}),
+	}, {
+		about:       "Missing http log forwarding URL",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"type":               "my-type",
+			"name":               "my-name",
+			"logforward-enabled": true,
+			"logforward-sink":    "http",
+		}),
+		err: `invalid HTTP forwarding config: empty URL not valid`,
+	}, {
+		about:       "Invalid http log forwarding URL for unselected sink",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"type":                "my-type",
+			"name":                "my-name",
+			"logforward-http-url": "ftp://logs.example.com/",
+		}),
+		err: `invalid HTTP forwarding config: URL scheme "ftp" not valid`,
+	}, {
+		about:       "Valid gelf log forwarding config values",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"type":                     "my-type",
+			"name":                     "my-name",
+			"logforward-enabled":       true,
+			"logforward-sink":          "gelf",
+			"logforward-gelf-address":  "graylog.example.com:12201",
+			"logforward-gelf-protocol": "tcp",
+		}),
+	}, {
+		about:       "Missing gelf log forwarding address",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"type":               "my-type",
+			"name":               "my-name",
+			"logforward-enabled": true,
+			"logforward-sink":    "gelf",
+		}),
+		err: `invalid GELF forwarding config: Address "" not valid`,
+	}, {
+		about:       "Valid log forwarding filter values",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"type":                      "my-type",
+			"name":                      "my-name",
+			"logforward-enabled":        true,
+			"syslog-host":               "localhost:1234",
+			"syslog-ca-cert":            testing.CACert,
+			"syslog-client-cert":        testing.ServerCert,
+			"syslog-client-key":         testing.ServerKey,
+			"logforward-level":          "WARNING",
+			"logforward-include-entity": "unit-mysql-*, machine-0",
+			"logforward-exclude-entity": "unit-mysql-1",
+			"logforward-include-module": "juju.worker",
+			"logforward-exclude-module": "juju.worker.uniter juju.worker.leadership",
+		}),
+	}, {
+		about:       "Invalid log forwarding level",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"type":             "my-type",
+			"name":             "my-name",
+			"logforward-level": "LOUD",
+		}),
+		err: `logforward-level "LOUD" not valid`,
+	}, {
+		about:       "Invalid log forwarding entity filter",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"type":                      "my-type",
+			"name":                      "my-name",
+			"logforward-exclude-entity": "unit-*-0",
+		}),
+		err: `invalid log forwarding filter: entity pattern "unit-\*-0" not valid`,
+	}, {
+		about:       "Valid logging overrides",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"type":              "my-type",
+			"name":              "my-name",
+			"logging-overrides": "unit-mysql-0:juju.worker.uniter=TRACE application-wordpress:DEBUG machine-0-lxd-1:juju=INFO",
+		}),
+	}, {
+		about:       "Invalid logging override entity",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"type":              "my-type",
+			"name":              "my-name",
+			"logging-overrides": "user-bob:DEBUG",
+		}),
+		err: `logging override entity "user-bob" not valid`,
+	}, {
+		about:       "Invalid logging override config",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"type":              "my-type",
+			"name":              "my-name",
+			"logging-overrides": "unit-mysql-0:juju=LOUD",
+		}),
+		err: `logging override for "unit-mysql-0": unknown severity level "LOUD"`,
+	}, {
+		about:       "Duplicate logging override",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"type":              "my-type",
+			"name":              "my-name",
+			"logging-overrides": "machine-0:DEBUG machine-0:TRACE",
+		}),
+		err: `logging override for "machine-0" specified more than once`,
 	},
 }
 
@@ -633,6 +803,53 @@ func (test configTest) check(c *gc.C, home *gitjujutesting.FakeHome) {
 		c.Check(lfCfg.ClientKey, gc.Equals, "")
 	}
 
+	fwdCfg, hasFwdCfg := cfg.LogFwd()
+	c.Assert(hasFwdCfg, gc.Equals, hasLogCfg || test.attrs["logforward-sink"] != nil ||
+		test.attrs["logforward-http-url"] != nil || test.attrs["logforward-gelf-address"] != nil ||
+		test.attrs["logforward-level"] != nil || test.attrs["logforward-include-entity"] != nil)
+	if hasFwdCfg {
+		sink, _ := test.attrs["logforward-sink"].(string)
+		if sink == "" {
+			sink = config.LogFwdSinkSyslog
+		}
+		c.Check(fwdCfg.Sink, gc.Equals, sink)
+		enabled, _ := test.attrs["logforward-enabled"].(bool)
+		c.Check(fwdCfg.Enabled, gc.Equals, enabled)
+		c.Check(fwdCfg.Syslog.Enabled, gc.Equals, enabled && sink == config.LogFwdSinkSyslog)
+		c.Check(fwdCfg.HTTP.Enabled, gc.Equals, enabled && sink == config.LogFwdSinkHTTP)
+		c.Check(fwdCfg.GELF.Enabled, gc.Equals, enabled && sink == config.LogFwdSinkGELF)
+		url, _ := test.attrs["logforward-http-url"].(string)
+		c.Check(fwdCfg.HTTP.URL, gc.Equals, url)
+		format, _ := test.attrs["logforward-http-format"].(string)
+		c.Check(fwdCfg.HTTP.Format, gc.Equals, format)
+		address, _ := test.attrs["logforward-gelf-address"].(string)
+		c.Check(fwdCfg.GELF.Address, gc.Equals, address)
+		protocol, _ := test.attrs["logforward-gelf-protocol"].(string)
+		c.Check(fwdCfg.GELF.Protocol, gc.Equals, protocol)
+		if v, _ := test.attrs["logforward-level"].(string); v != "" {
+			level, ok := loggo.ParseLevel(v)
+			c.Assert(ok, jc.IsTrue)
+			c.Check(fwdCfg.Filter.MinLevel, gc.Equals, level)
+		} else {
+			c.Check(fwdCfg.Filter.MinLevel, gc.Equals, loggo.UNSPECIFIED)
+		}
+		for key, field := range map[string][]string{
+			"logforward-include-entity": fwdCfg.Filter.IncludeEntity,
+			"logforward-exclude-entity": fwdCfg.Filter.ExcludeEntity,
+			"logforward-include-module": fwdCfg.Filter.IncludeModule,
+			"logforward-exclude-module": fwdCfg.Filter.ExcludeModule,
+		} {
+			v, _ := test.attrs[key].(string)
+			if v == "" {
+				c.Check(field, gc.HasLen, 0)
+				continue
+			}
+			c.Check(field, jc.DeepEquals, strings.FieldsFunc(v, func(r rune) bool {
+				return r == ',' || r == ' '
+			}))
+		}
+	}
+
 	if v, ok := test.attrs["ssl-hostname-verification"]; ok {
 		c.Assert(cfg.SSLHostnameVerification(), gc.Equals, v)
 	}
@@ -950,6 +1167,56 @@ func (s *ConfigSuite) TestLoggingConfigFromEnvironment(c *gc.C) {
 	c.Assert(config.LoggingConfig(), gc.Equals, "<root>=INFO;unit=DEBUG")
 }
 
+func (s *ConfigSuite) TestLoggingOverrides(c *gc.C) {
+	s.addJujuFiles(c)
+	config := newTestConfig(c, testing.Attrs{
+		"logging-overrides": "unit-mysql-0:juju.worker.uniter=TRACE application-mysql:DEBUG"})
+	c.Assert(config.LoggingOverrides(), jc.DeepEquals, map[string]string{
+		"unit-mysql-0":      "juju.worker.uniter=TRACE",
+		"application-mysql": "<root>=DEBUG",
+	})
+}
+
+func (s *ConfigSuite) TestLoggingConfigFor(c *gc.C) {
+	s.addJujuFiles(c)
+	config := newTestConfig(c, testing.Attrs{
+		"logging-config":    "<root>=WARNING",
+		"logging-overrides": "unit-mysql-0:juju.worker.uniter=TRACE application-mysql:juju=DEBUG machine-1:<root>=INFO"})
+	for i, test := range []struct {
+		tag      names.Tag
+		expected string
+	}{{
+		tag:      names.NewMachineTag("0"),
+		expected: "<root>=WARNING;unit=DEBUG",
+	}, {
+		tag:      names.NewMachineTag("1"),
+		expected: "<root>=WARNING;unit=DEBUG;<root>=INFO",
+	}, {
+		tag:      names.NewUnitTag("mysql/1"),
+		expected: "<root>=WARNING;unit=DEBUG;juju=DEBUG",
+	}, {
+		tag:      names.NewUnitTag("mysql/0"),
+		expected: "<root>=WARNING;unit=DEBUG;juju=DEBUG;juju.worker.uniter=TRACE",
+	}, {
+		tag:      names.NewUnitTag("wordpress/0"),
+		expected: "<root>=WARNING;unit=DEBUG",
+	}} {
+		c.Logf("test %d: %s", i, test.tag)
+		c.Check(config.LoggingConfigFor(test.tag), gc.Equals, test.expected)
+	}
+}
+
+func (s *ConfigSuite) TestFormatLoggingOverrides(c *gc.C) {
+	formatted := config.FormatLoggingOverrides(map[string]string{
+		"unit-mysql-0": "juju.worker.uniter=TRACE",
+		"machine-0":    "<root>=DEBUG",
+	})
+	c.Assert(formatted, gc.Equals, "machine-0:<root>=DEBUG unit-mysql-0:juju.worker.uniter=TRACE")
+	overrides, err := config.ParseLoggingOverrides(formatted)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(overrides, gc.HasLen, 2)
+}
+
 func (s *ConfigSuite) TestAutoHookRetryDefault(c *gc.C) {
 	config := newTestConfig(c, testing.Attrs{})
 	c.Assert(config.AutomaticallyRetryHooks(), gc.Equals, true)
@@ -967,6 +1234,31 @@ func (s *ConfigSuite) TestAutoHookRetryTrueEnv(c *gc.C) {
 	c.Assert(config.AutomaticallyRetryHooks(), gc.Equals, true)
 }
 
+func (s *ConfigSuite) TestHookTimeoutDefault(c *gc.C) {
+	config := newTestConfig(c, testing.Attrs{})
+	c.Assert(config.HookTimeout(), gc.Equals, time.Duration(0))
+}
+
+func (s *ConfigSuite) TestHookTimeout(c *gc.C) {
+	config := newTestConfig(c, testing.Attrs{
+		"hook-timeout": "1h30m"})
+	c.Assert(config.HookTimeout(), gc.Equals, 90*time.Minute)
+}
+
+func (s *ConfigSuite) TestLogLimitsDefault(c *gc.C) {
+	config := newTestConfig(c, testing.Attrs{})
+	c.Assert(config.MaxLogsAge(), gc.Equals, time.Duration(0))
+	c.Assert(config.MaxLogsSizeMB(), gc.Equals, 0)
+}
+
+func (s *ConfigSuite) TestLogLimits(c *gc.C) {
+	config := newTestConfig(c, testing.Attrs{
+		"max-logs-age":  "36h",
+		"max-logs-size": "2G"})
+	c.Assert(config.MaxLogsAge(), gc.Equals, 36*time.Hour)
+	c.Assert(config.MaxLogsSizeMB(), gc.Equals, 2048)
+}
+
 func (s *ConfigSuite) TestProxyValuesWithFallback(c *gc.C) {
 	s.addJujuFiles(c)
 
@@ -0,0 +1,77 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package config
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/gelf"
+	"github.com/juju/juju/logfwd/httpjson"
+	"github.com/juju/juju/logfwd/syslog"
+)
+
+// These are the supported values of the LogFwdSink attribute.
+const (
+	LogFwdSinkSyslog = "syslog"
+	LogFwdSinkHTTP   = "http"
+	LogFwdSinkGELF   = "gelf"
+)
+
+// LogFwdConfig holds a model's log forwarding config: whether log
+// forwarding is enabled, which sink records are forwarded to, and the
+// config for each of the supported sinks. Only the selected sink's
+// config has Enabled set.
+type LogFwdConfig struct {
+	// Enabled is true if the log forwarding feature is enabled.
+	Enabled bool
+
+	// Sink is the name of the selected sink.
+	Sink string
+
+	// Syslog is the config for the syslog sink.
+	Syslog syslog.RawConfig
+
+	// HTTP is the config for the HTTP JSON sink.
+	HTTP httpjson.RawConfig
+
+	// GELF is the config for the GELF sink.
+	GELF gelf.RawConfig
+
+	// Filter selects the records that are forwarded.
+	Filter logfwd.Filter
+}
+
+// Validate ensures that the config is currently valid.
+func (cfg LogFwdConfig) Validate() error {
+	switch cfg.Sink {
+	case LogFwdSinkSyslog, LogFwdSinkHTTP, LogFwdSinkGELF:
+	default:
+		return errors.NotValidf("log forwarding sink %q", cfg.Sink)
+	}
+	if err := cfg.Syslog.Validate(); err != nil {
+		return errors.Annotate(err, "invalid syslog forwarding config")
+	}
+	if err := cfg.HTTP.Validate(); err != nil {
+		return errors.Annotate(err, "invalid HTTP forwarding config")
+	}
+	if err := cfg.GELF.Validate(); err != nil {
+		return errors.Annotate(err, "invalid GELF forwarding config")
+	}
+	if err := cfg.Filter.Validate(); err != nil {
+		return errors.Annotate(err, "invalid log forwarding filter")
+	}
+	return nil
+}
+
+// splitLogFwdList splits a comma- or space-separated list of log
+// forwarding filter values.
+func splitLogFwdList(s string) []string {
+	return strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || unicode.IsSpace(r)
+	})
+}
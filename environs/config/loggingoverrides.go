@@ -0,0 +1,91 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package config
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/names.v2"
+)
+
+// ParseLoggingOverrides parses the value of the LoggingOverridesKey
+// attribute: a space-separated list of <entity-tag>:<logging-config>
+// entries, where each entity is a machine, application or unit. The
+// result maps entity tag strings to normalised logging config strings.
+func ParseLoggingOverrides(s string) (map[string]string, error) {
+	overrides := make(map[string]string)
+	for _, entry := range strings.Fields(s) {
+		parts := strings.SplitN(entry, ":", 2)
+		if len(parts) != 2 || parts[1] == "" {
+			return nil, errors.NotValidf("logging override %q", entry)
+		}
+		tag, err := names.ParseTag(parts[0])
+		if err != nil {
+			return nil, errors.NotValidf("logging override entity %q", parts[0])
+		}
+		switch tag.(type) {
+		case names.MachineTag, names.ApplicationTag, names.UnitTag:
+		default:
+			return nil, errors.NotValidf("logging override entity %q", parts[0])
+		}
+		loggingConfig, err := loggo.ParseConfigString(parts[1])
+		if err != nil {
+			return nil, errors.Annotatef(err, "logging override for %q", parts[0])
+		}
+		if _, ok := overrides[parts[0]]; ok {
+			return nil, errors.Errorf("logging override for %q specified more than once", parts[0])
+		}
+		// Normalise the config so that a bare level such as "DEBUG"
+		// can be appended to another logging config.
+		overrides[parts[0]] = loggingConfig.String()
+	}
+	return overrides, nil
+}
+
+// FormatLoggingOverrides returns the LoggingOverridesKey attribute
+// value for the given overrides, with entries sorted by entity tag.
+func FormatLoggingOverrides(overrides map[string]string) string {
+	tags := make([]string, 0, len(overrides))
+	for tag := range overrides {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+	entries := make([]string, len(tags))
+	for i, tag := range tags {
+		entries[i] = tag + ":" + overrides[tag]
+	}
+	return strings.Join(entries, " ")
+}
+
+// LoggingOverrides returns the per-entity logging config overrides,
+// keyed by entity tag string.
+func (c *Config) LoggingOverrides() map[string]string {
+	// The value is checked in Validate.
+	overrides, _ := ParseLoggingOverrides(c.asString(LoggingOverridesKey))
+	return overrides
+}
+
+// LoggingConfigFor returns the configuration string for the loggers of
+// the agent with the given tag: the model's logging config followed by
+// any override for the unit's application and then any override for
+// the entity itself, so that the most specific setting wins.
+func (c *Config) LoggingConfigFor(tag names.Tag) string {
+	loggingConfig := c.LoggingConfig()
+	overrides := c.LoggingOverrides()
+	if unitTag, ok := tag.(names.UnitTag); ok {
+		appName, err := names.UnitApplication(unitTag.Id())
+		if err == nil {
+			if override, ok := overrides[names.NewApplicationTag(appName).String()]; ok {
+				loggingConfig += ";" + override
+			}
+		}
+	}
+	if override, ok := overrides[tag.String()]; ok {
+		loggingConfig += ";" + override
+	}
+	return loggingConfig
+}
@@ -35,6 +35,16 @@ const (
 	// the service or unit that owns the Juju storage instance
 	// that an IaaS storage resource is assigned to.
 	JujuStorageOwner = JujuTagPrefix + "storage-owner"
+
+	// JujuVolumeSnapshot is the tag name used for identifying
+	// the Juju volume snapshot that an IaaS snapshot resource
+	// was taken for.
+	JujuVolumeSnapshot = JujuTagPrefix + "volume-snapshot"
+
+	// JujuPlacementGroup is the tag name used for identifying
+	// the provider placement group that a machine instance was
+	// started in.
+	JujuPlacementGroup = JujuTagPrefix + "placement-group"
 )
 
 // ResourceTagger is an interface that can provide resource tags.
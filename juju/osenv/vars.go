@@ -43,6 +43,11 @@ const (
 	// timestamps to be written in RFC3339 format.
 	JujuStatusIsoTimeEnvKey = "JUJU_STATUS_ISO_TIME"
 
+	// JujuTraceEnvKey is the env var which, if set, causes the client's
+	// API calls to be traced. Its value is the URL of the trace collector
+	// to send spans to, or "true" to use a collector on the local machine.
+	JujuTraceEnvKey = "JUJU_TRACE"
+
 	// XDGDataHome is a path where data for the running user
 	// should be stored according to the xdg standard.
 	XDGDataHome = "XDG_DATA_HOME"
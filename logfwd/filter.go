@@ -0,0 +1,127 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logfwd
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/names.v2"
+)
+
+// Filter selects the log records that are forwarded. Its fields
+// mirror the filters supported by debug-log. The zero value matches
+// every record.
+type Filter struct {
+	// MinLevel is the minimum level of the matched records.
+	MinLevel loggo.Level
+
+	// IncludeEntity lists the tags of the entities whose records are
+	// matched. Tags may finish with a '*' to match a prefix, e.g.
+	// unit-mysql-*. If none are set then all entities are included.
+	IncludeEntity []string
+
+	// ExcludeEntity lists the tags of the entities whose records are
+	// not matched. As with IncludeEntity the values may finish with
+	// a '*'.
+	ExcludeEntity []string
+
+	// IncludeModule lists the logging modules whose records are
+	// matched, including their submodules. If none are set then all
+	// modules are included.
+	IncludeModule []string
+
+	// ExcludeModule lists the logging modules whose records are not
+	// matched, including their submodules.
+	ExcludeModule []string
+}
+
+// Validate ensures that the filter is correct.
+func (f Filter) Validate() error {
+	if f.MinLevel < loggo.UNSPECIFIED || f.MinLevel > loggo.CRITICAL {
+		return errors.NotValidf("MinLevel %d", f.MinLevel)
+	}
+	for _, entities := range [][]string{f.IncludeEntity, f.ExcludeEntity} {
+		for _, entity := range entities {
+			if entity == "" || strings.Contains(strings.TrimSuffix(entity, "*"), "*") {
+				return errors.NotValidf("entity pattern %q", entity)
+			}
+		}
+	}
+	for _, modules := range [][]string{f.IncludeModule, f.ExcludeModule} {
+		for _, module := range modules {
+			if module == "" {
+				return errors.NotValidf("empty module")
+			}
+		}
+	}
+	return nil
+}
+
+// Match returns true if the record is selected by the filter.
+func (f Filter) Match(rec Record) bool {
+	if rec.Level < f.MinLevel {
+		return false
+	}
+	if len(f.IncludeEntity) > 0 || len(f.ExcludeEntity) > 0 {
+		tag := originTag(rec.Origin)
+		if len(f.IncludeEntity) > 0 && !matchEntity(f.IncludeEntity, tag) {
+			return false
+		}
+		if matchEntity(f.ExcludeEntity, tag) {
+			return false
+		}
+	}
+	module := rec.Location.Module
+	if len(f.IncludeModule) > 0 && !matchModule(f.IncludeModule, module) {
+		return false
+	}
+	if matchModule(f.ExcludeModule, module) {
+		return false
+	}
+	return true
+}
+
+// originTag returns the string form of the tag of the entity that
+// created a record, or "" if there is no such entity.
+func originTag(origin Origin) string {
+	if origin.Type.ValidateName(origin.Name) != nil {
+		return ""
+	}
+	switch origin.Type {
+	case OriginTypeUser:
+		return names.NewUserTag(origin.Name).String()
+	case OriginTypeMachine:
+		return names.NewMachineTag(origin.Name).String()
+	case OriginTypeUnit:
+		return names.NewUnitTag(origin.Name).String()
+	}
+	return ""
+}
+
+func matchEntity(patterns []string, tag string) bool {
+	if tag == "" {
+		return false
+	}
+	for _, pattern := range patterns {
+		if strings.HasSuffix(pattern, "*") {
+			if strings.HasPrefix(tag, strings.TrimSuffix(pattern, "*")) {
+				return true
+			}
+		} else if tag == pattern {
+			return true
+		}
+	}
+	return false
+}
+
+func matchModule(modules []string, module string) bool {
+	for _, m := range modules {
+		if module == m || strings.HasPrefix(module, m+".") {
+			return true
+		}
+	}
+	return false
+}
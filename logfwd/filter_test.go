@@ -0,0 +1,165 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logfwd_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd"
+)
+
+type FilterSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&FilterSuite{})
+
+func (s *FilterSuite) TestValidateZero(c *gc.C) {
+	var filter logfwd.Filter
+
+	err := filter.Validate()
+
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *FilterSuite) TestValidateFull(c *gc.C) {
+	filter := logfwd.Filter{
+		MinLevel:      loggo.WARNING,
+		IncludeEntity: []string{"unit-mysql-*", "machine-0"},
+		ExcludeEntity: []string{"unit-mysql-1"},
+		IncludeModule: []string{"juju.worker"},
+		ExcludeModule: []string{"juju.worker.uniter"},
+	}
+
+	err := filter.Validate()
+
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *FilterSuite) TestValidateBadLevel(c *gc.C) {
+	filter := logfwd.Filter{
+		MinLevel: loggo.CRITICAL + 1,
+	}
+
+	err := filter.Validate()
+
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, `MinLevel 7 not valid`)
+}
+
+func (s *FilterSuite) TestValidateBadEntity(c *gc.C) {
+	filter := logfwd.Filter{
+		ExcludeEntity: []string{"unit-*-0"},
+	}
+
+	err := filter.Validate()
+
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, `entity pattern "unit-\*-0" not valid`)
+}
+
+func (s *FilterSuite) TestValidateEmptyModule(c *gc.C) {
+	filter := logfwd.Filter{
+		IncludeModule: []string{""},
+	}
+
+	err := filter.Validate()
+
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, `empty module not valid`)
+}
+
+func (s *FilterSuite) TestMatchZero(c *gc.C) {
+	var filter logfwd.Filter
+
+	c.Check(filter.Match(validRecord), jc.IsTrue)
+}
+
+func (s *FilterSuite) TestMatchLevel(c *gc.C) {
+	filter := logfwd.Filter{
+		MinLevel: loggo.WARNING,
+	}
+	rec := validRecord
+
+	for level, expected := range map[loggo.Level]bool{
+		loggo.TRACE:    false,
+		loggo.DEBUG:    false,
+		loggo.INFO:     false,
+		loggo.WARNING:  true,
+		loggo.ERROR:    true,
+		loggo.CRITICAL: true,
+	} {
+		c.Logf("trying %s", level)
+		rec.Level = level
+		c.Check(filter.Match(rec), gc.Equals, expected)
+	}
+}
+
+func (s *FilterSuite) TestMatchEntity(c *gc.C) {
+	filter := logfwd.Filter{
+		IncludeEntity: []string{"unit-mysql-*", "machine-0"},
+		ExcludeEntity: []string{"unit-mysql-1"},
+	}
+	rec := validRecord
+
+	for _, test := range []struct {
+		originType logfwd.OriginType
+		name       string
+		expected   bool
+	}{
+		{logfwd.OriginTypeUnit, "mysql/0", true},
+		{logfwd.OriginTypeUnit, "mysql/1", false},
+		{logfwd.OriginTypeUnit, "wordpress/0", false},
+		{logfwd.OriginTypeMachine, "0", true},
+		{logfwd.OriginTypeMachine, "1", false},
+		{logfwd.OriginTypeUser, "a-user", false},
+		{logfwd.OriginTypeUnknown, "", false},
+	} {
+		c.Logf("trying %s %q", test.originType, test.name)
+		rec.Origin.Type = test.originType
+		rec.Origin.Name = test.name
+		c.Check(filter.Match(rec), gc.Equals, test.expected)
+	}
+}
+
+func (s *FilterSuite) TestMatchExcludeEntityOnly(c *gc.C) {
+	filter := logfwd.Filter{
+		ExcludeEntity: []string{"unit-*"},
+	}
+	rec := validRecord
+
+	rec.Origin.Type = logfwd.OriginTypeUnit
+	rec.Origin.Name = "mysql/0"
+	c.Check(filter.Match(rec), jc.IsFalse)
+
+	rec.Origin.Type = logfwd.OriginTypeUnknown
+	rec.Origin.Name = ""
+	c.Check(filter.Match(rec), jc.IsTrue)
+}
+
+func (s *FilterSuite) TestMatchModule(c *gc.C) {
+	filter := logfwd.Filter{
+		IncludeModule: []string{"juju.worker"},
+		ExcludeModule: []string{"juju.worker.uniter"},
+	}
+	rec := validRecord
+
+	for module, expected := range map[string]bool{
+		"juju.worker":                 true,
+		"juju.worker.logforwarder":    true,
+		"juju.worker.uniter":          false,
+		"juju.worker.uniter.operator": false,
+		"juju.workerish":              false,
+		"juju.apiserver":              false,
+		"":                            false,
+	} {
+		c.Logf("trying %q", module)
+		rec.Location.Module = module
+		c.Check(filter.Match(rec), gc.Equals, expected)
+	}
+}
@@ -0,0 +1,259 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package gelf
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/json"
+	"io"
+	"net"
+	"time"
+	"unicode/utf8"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+
+	"github.com/juju/juju/logfwd"
+)
+
+const (
+	// dialTimeout is the time allowed to connect to the GELF input.
+	dialTimeout = 30 * time.Second
+
+	// maxDatagramSize is the largest UDP datagram we will send. Larger
+	// messages are split into chunks of at most this size, including
+	// the chunk header.
+	maxDatagramSize = 8192
+
+	// maxChunks is the largest number of chunks that a GELF input
+	// will reassemble into a single message.
+	maxChunks = 128
+
+	// chunkHeaderSize is the size of the header preceding each chunk:
+	// two magic bytes, an 8-byte message ID, the sequence number and
+	// the sequence count.
+	chunkHeaderSize = 12
+
+	// maxMessageSize is the largest message that can be sent over
+	// UDP, in maxChunks chunks.
+	maxMessageSize = maxChunks * (maxDatagramSize - chunkHeaderSize)
+
+	// truncatedSuffix is appended to messages that have been
+	// truncated to fit in maxMessageSize.
+	truncatedSuffix = "... (truncated)"
+)
+
+var logger = loggo.GetLogger("juju.logfwd.gelf")
+
+var chunkMagic = []byte{0x1e, 0x0f}
+
+// Client is the wrapper around a connection to a GELF input.
+type Client struct {
+	// Conn is the connection over which messages are sent.
+	Conn net.Conn
+
+	// Protocol is the transport protocol of Conn.
+	Protocol string
+}
+
+// Open connects to a remote GELF input and wraps that connection
+// in a new client.
+func Open(cfg RawConfig) (*Client, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	protocol := cfg.protocol()
+	conn, err := net.DialTimeout(protocol, cfg.address(), dialTimeout)
+	if err != nil {
+		return nil, errors.Annotate(err, "opening client connection")
+	}
+	client := &Client{
+		Conn:     conn,
+		Protocol: protocol,
+	}
+	return client, nil
+}
+
+// Close closes the client's connection.
+func (client Client) Close() error {
+	err := client.Conn.Close()
+	return errors.Trace(err)
+}
+
+// Send sends the records to the remote GELF input. Records that can
+// never be sent, such as those with an unsupported level, are logged
+// and dropped rather than failing the whole batch, as the batch would
+// otherwise be retried forever. Delivery over UDP is best-effort:
+// datagrams lost in transit are not detected or resent.
+func (client Client) Send(records []logfwd.Record) error {
+	for _, rec := range records {
+		data, err := client.encode(rec)
+		if err != nil {
+			logger.Errorf("dropping log record %d: %v", rec.ID, err)
+			continue
+		}
+		if client.Protocol == ProtocolTCP {
+			err = writeStream(client.Conn, data)
+		} else {
+			err = writeDatagrams(client.Conn, data)
+		}
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// encode returns the JSON-encoded GELF message for the record. Over
+// UDP, a message that is too large to send is truncated to fit.
+func (client Client) encode(rec logfwd.Record) ([]byte, error) {
+	msg, err := messageFromRecord(rec)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if client.Protocol == ProtocolTCP || len(data) <= maxMessageSize {
+		return data, nil
+	}
+
+	// Each byte removed from the message shortens the encoded
+	// message by at least one byte, so removing the excess and
+	// room for the suffix is enough.
+	excess := len(data) - maxMessageSize + len(truncatedSuffix)
+	if excess >= len(msg.ShortMessage) {
+		return nil, errors.Errorf("message too large (%d bytes)", len(data))
+	}
+	end := len(msg.ShortMessage) - excess
+	for end > 0 && !utf8.RuneStart(msg.ShortMessage[end]) {
+		end--
+	}
+	logger.Warningf("truncating log record %d of %d bytes", rec.ID, len(data))
+	msg.ShortMessage = msg.ShortMessage[:end] + truncatedSuffix
+	data, err = json.Marshal(msg)
+	return data, errors.Trace(err)
+}
+
+// writeStream writes a single message to a TCP stream. Messages
+// are delimited by a null byte, and so must not contain one; the
+// JSON encoding guarantees that.
+func writeStream(w io.Writer, data []byte) error {
+	_, err := w.Write(append(data, 0))
+	return errors.Trace(err)
+}
+
+// writeDatagrams writes a single message as one UDP datagram, or as
+// a sequence of chunked datagrams if it is too large for one.
+func writeDatagrams(w io.Writer, data []byte) error {
+	if len(data) <= maxDatagramSize {
+		_, err := w.Write(data)
+		return errors.Trace(err)
+	}
+	chunks, err := chunk(data)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, c := range chunks {
+		if _, err := w.Write(c); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// chunk splits the message into GELF chunks, each prefixed with the
+// chunk header.
+func chunk(data []byte) ([][]byte, error) {
+	const payloadSize = maxDatagramSize - chunkHeaderSize
+	count := (len(data) + payloadSize - 1) / payloadSize
+	if count > maxChunks {
+		return nil, errors.Errorf("message too large (%d bytes)", len(data))
+	}
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return nil, errors.Annotate(err, "generating message ID")
+	}
+	chunks := make([][]byte, 0, count)
+	for seq := 0; seq < count; seq++ {
+		end := (seq + 1) * payloadSize
+		if end > len(data) {
+			end = len(data)
+		}
+		var buf bytes.Buffer
+		buf.Write(chunkMagic)
+		buf.Write(id)
+		buf.WriteByte(byte(seq))
+		buf.WriteByte(byte(count))
+		buf.Write(data[seq*payloadSize : end])
+		chunks = append(chunks, buf.Bytes())
+	}
+	return chunks, nil
+}
+
+// message is a GELF 1.1 message. Fields prefixed with an underscore
+// are "additional" fields, which GELF inputs store as-is.
+type message struct {
+	Version      string  `json:"version"`
+	Host         string  `json:"host"`
+	ShortMessage string  `json:"short_message"`
+	Timestamp    float64 `json:"timestamp"`
+	Level        int     `json:"level"`
+
+	RecordID        int64  `json:"_juju_record_id"`
+	Module          string `json:"_juju_module,omitempty"`
+	Location        string `json:"_juju_location,omitempty"`
+	ControllerUUID  string `json:"_juju_controller_uuid"`
+	ModelUUID       string `json:"_juju_model_uuid"`
+	OriginType      string `json:"_juju_origin_type"`
+	OriginName      string `json:"_juju_origin_name,omitempty"`
+	SoftwareName    string `json:"_juju_software_name,omitempty"`
+	SoftwareVersion string `json:"_juju_software_version,omitempty"`
+}
+
+func messageFromRecord(rec logfwd.Record) (message, error) {
+	msg := message{
+		Version:        "1.1",
+		Host:           rec.Origin.Hostname,
+		ShortMessage:   rec.Message,
+		Timestamp:      float64(rec.Timestamp.UnixNano()) / float64(time.Second),
+		RecordID:       rec.ID,
+		Module:         rec.Location.Module,
+		Location:       rec.Location.String(),
+		ControllerUUID: rec.Origin.ControllerUUID,
+		ModelUUID:      rec.Origin.ModelUUID,
+		OriginType:     rec.Origin.Type.String(),
+		OriginName:     rec.Origin.Name,
+		SoftwareName:   rec.Origin.Software.Name,
+	}
+	if rec.Origin.Software.Name != "" {
+		msg.SoftwareVersion = rec.Origin.Software.Version.String()
+	}
+	// GELF requires both host and short_message to be non-empty.
+	if msg.Host == "" {
+		msg.Host = rec.Origin.ModelUUID
+	}
+	if msg.ShortMessage == "" {
+		msg.ShortMessage = "-"
+	}
+
+	// GELF levels are syslog severities.
+	switch rec.Level {
+	case loggo.CRITICAL:
+		msg.Level = 2
+	case loggo.ERROR:
+		msg.Level = 3
+	case loggo.WARNING:
+		msg.Level = 4
+	case loggo.INFO:
+		msg.Level = 6
+	case loggo.DEBUG, loggo.TRACE:
+		msg.Level = 7
+	default:
+		return msg, errors.Errorf("unsupported log level %q", rec.Level)
+	}
+	return msg, nil
+}
@@ -0,0 +1,267 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package gelf_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/gelf"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ClientSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ClientSuite{})
+
+func (s *ClientSuite) listenUDP(c *gc.C) net.PacketConn {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(*gc.C) { conn.Close() })
+	return conn
+}
+
+func (s *ClientSuite) open(c *gc.C, addr, protocol string) *gelf.Client {
+	client, err := gelf.Open(gelf.RawConfig{
+		Enabled:  true,
+		Address:  addr,
+		Protocol: protocol,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(*gc.C) { client.Close() })
+	return client
+}
+
+func readDatagram(c *gc.C, conn net.PacketConn) []byte {
+	buf := make([]byte, 65536)
+	err := conn.SetReadDeadline(time.Now().Add(coretesting.LongWait))
+	c.Assert(err, jc.ErrorIsNil)
+	n, _, err := conn.ReadFrom(buf)
+	c.Assert(err, jc.ErrorIsNil)
+	return buf[:n]
+}
+
+func (s *ClientSuite) TestOpenInvalidConfig(c *gc.C) {
+	_, err := gelf.Open(gelf.RawConfig{Enabled: true})
+	c.Check(err, gc.ErrorMatches, `Address "" not valid`)
+}
+
+func (s *ClientSuite) TestSendUDP(c *gc.C) {
+	conn := s.listenUDP(c)
+	client := s.open(c, conn.LocalAddr().String(), gelf.ProtocolUDP)
+
+	err := client.Send([]logfwd.Record{newRecord(10)})
+	c.Assert(err, jc.ErrorIsNil)
+
+	var msg map[string]interface{}
+	err = json.Unmarshal(readDatagram(c, conn), &msg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(msg, jc.DeepEquals, map[string]interface{}{
+		"version":                "1.1",
+		"host":                   "machine-99.deadbeef-2f18-4fd2-967d-db9663db7bea",
+		"short_message":          "(╯°□°)╯︵ ┻━┻",
+		"timestamp":              12345.5,
+		"level":                  3.0,
+		"_juju_record_id":        10.0,
+		"_juju_module":           "juju.x.y",
+		"_juju_location":         "x/y/spam.go:42",
+		"_juju_controller_uuid":  "9f484882-2f18-4fd2-967d-db9663db7bea",
+		"_juju_model_uuid":       "deadbeef-2f18-4fd2-967d-db9663db7bea",
+		"_juju_origin_type":      "machine",
+		"_juju_origin_name":      "99",
+		"_juju_software_name":    "jujud-machine-agent",
+		"_juju_software_version": "1.2.3",
+	})
+}
+
+func (s *ClientSuite) TestSendUDPChunked(c *gc.C) {
+	conn := s.listenUDP(c)
+	client := s.open(c, conn.LocalAddr().String(), gelf.ProtocolUDP)
+	rec := newRecord(10)
+	rec.Message = strings.Repeat("x", 20000)
+
+	err := client.Send([]logfwd.Record{rec})
+	c.Assert(err, jc.ErrorIsNil)
+
+	var data []byte
+	var id []byte
+	for seq := 0; seq < 3; seq++ {
+		chunk := readDatagram(c, conn)
+		c.Assert(len(chunk) <= 8192, jc.IsTrue)
+		c.Check(chunk[:2], jc.DeepEquals, []byte{0x1e, 0x0f})
+		if id == nil {
+			id = chunk[2:10]
+		}
+		c.Check(chunk[2:10], jc.DeepEquals, id)
+		c.Check(int(chunk[10]), gc.Equals, seq)
+		c.Check(int(chunk[11]), gc.Equals, 3)
+		data = append(data, chunk[12:]...)
+	}
+	var msg map[string]interface{}
+	err = json.Unmarshal(data, &msg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(msg["short_message"], gc.Equals, rec.Message)
+}
+
+func (s *ClientSuite) TestSendUDPTruncated(c *gc.C) {
+	conn := s.listenUDP(c)
+	client := s.open(c, conn.LocalAddr().String(), gelf.ProtocolUDP)
+	rec := newRecord(10)
+	rec.Message = strings.Repeat("x", 2*1024*1024)
+
+	err := client.Send([]logfwd.Record{rec})
+	c.Assert(err, jc.ErrorIsNil)
+
+	var data []byte
+	for seq := 0; seq < 128; seq++ {
+		chunk := readDatagram(c, conn)
+		c.Check(int(chunk[10]), gc.Equals, seq)
+		c.Check(int(chunk[11]), gc.Equals, 128)
+		data = append(data, chunk[12:]...)
+	}
+	var msg map[string]interface{}
+	err = json.Unmarshal(data, &msg)
+	c.Assert(err, jc.ErrorIsNil)
+	short, ok := msg["short_message"].(string)
+	c.Assert(ok, jc.IsTrue)
+	c.Check(strings.HasPrefix(short, "xxxx"), jc.IsTrue)
+	c.Check(strings.HasSuffix(short, "... (truncated)"), jc.IsTrue)
+}
+
+func (s *ClientSuite) TestSendDropsUnsupportedLevel(c *gc.C) {
+	conn := s.listenUDP(c)
+	client := s.open(c, conn.LocalAddr().String(), gelf.ProtocolUDP)
+	bad := newRecord(10)
+	bad.Level = loggo.UNSPECIFIED
+	good := newRecord(11)
+
+	err := client.Send([]logfwd.Record{bad, good})
+	c.Assert(err, jc.ErrorIsNil)
+
+	var msg map[string]interface{}
+	err = json.Unmarshal(readDatagram(c, conn), &msg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(msg["_juju_record_id"], gc.Equals, float64(11))
+}
+
+func (s *ClientSuite) TestSendTCP(c *gc.C) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	c.Assert(err, jc.ErrorIsNil)
+	defer listener.Close()
+	received := make(chan [][]byte, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			close(received)
+			return
+		}
+		defer conn.Close()
+		var msgs [][]byte
+		reader := bufio.NewReader(conn)
+		for len(msgs) < 2 {
+			data, err := reader.ReadBytes(0)
+			if err != nil {
+				break
+			}
+			msgs = append(msgs, data)
+		}
+		received <- msgs
+	}()
+	client := s.open(c, listener.Addr().String(), gelf.ProtocolTCP)
+
+	err = client.Send([]logfwd.Record{newRecord(10), newRecord(11)})
+	c.Assert(err, jc.ErrorIsNil)
+
+	var msgs [][]byte
+	select {
+	case msgs = <-received:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for messages")
+	}
+	c.Assert(msgs, gc.HasLen, 2)
+	for i, data := range msgs {
+		c.Check(bytes.HasSuffix(data, []byte{0}), jc.IsTrue)
+		var msg map[string]interface{}
+		err := json.Unmarshal(data[:len(data)-1], &msg)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(msg["_juju_record_id"], gc.Equals, float64(10+i))
+	}
+}
+
+func (s *ClientSuite) TestSendLogLevels(c *gc.C) {
+	conn := s.listenUDP(c)
+	client := s.open(c, conn.LocalAddr().String(), gelf.ProtocolUDP)
+	rec := newRecord(10)
+
+	levels := map[loggo.Level]float64{
+		loggo.CRITICAL: 2,
+		loggo.ERROR:    3,
+		loggo.WARNING:  4,
+		loggo.INFO:     6,
+		loggo.DEBUG:    7,
+		loggo.TRACE:    7,
+	}
+	for level, expected := range levels {
+		c.Logf("trying %s -> %v", level, expected)
+		rec.Level = level
+
+		err := client.Send([]logfwd.Record{rec})
+		c.Assert(err, jc.ErrorIsNil)
+
+		var msg map[string]interface{}
+		err = json.Unmarshal(readDatagram(c, conn), &msg)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(msg["level"], gc.Equals, expected)
+	}
+}
+
+func (s *ClientSuite) TestSendEmptyMessage(c *gc.C) {
+	conn := s.listenUDP(c)
+	client := s.open(c, conn.LocalAddr().String(), gelf.ProtocolUDP)
+	rec := newRecord(10)
+	rec.Message = ""
+	rec.Origin.Hostname = ""
+
+	err := client.Send([]logfwd.Record{rec})
+	c.Assert(err, jc.ErrorIsNil)
+
+	var msg map[string]interface{}
+	err = json.Unmarshal(readDatagram(c, conn), &msg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(msg["short_message"], gc.Equals, "-")
+	c.Check(msg["host"], gc.Equals, "deadbeef-2f18-4fd2-967d-db9663db7bea")
+}
+
+func newRecord(id int64) logfwd.Record {
+	tag := names.NewMachineTag("99")
+	cID := "9f484882-2f18-4fd2-967d-db9663db7bea"
+	mID := "deadbeef-2f18-4fd2-967d-db9663db7bea"
+	ver := version.MustParse("1.2.3")
+	return logfwd.Record{
+		ID:        id,
+		Origin:    logfwd.OriginForMachineAgent(tag, cID, mID, ver),
+		Timestamp: time.Unix(12345, 500000000),
+		Level:     loggo.ERROR,
+		Location: logfwd.SourceLocation{
+			Module:   "juju.x.y",
+			Filename: "x/y/spam.go",
+			Line:     42,
+		},
+		Message: "(╯°□°)╯︵ ┻━┻",
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package gelf
+
+import (
+	"net"
+
+	"github.com/juju/errors"
+)
+
+// These are the supported transport protocols.
+const (
+	// ProtocolUDP sends each message as one or more (chunked)
+	// UDP datagrams.
+	ProtocolUDP = "udp"
+
+	// ProtocolTCP sends null-byte delimited messages over a
+	// TCP stream.
+	ProtocolTCP = "tcp"
+)
+
+// defaultPort is the conventional port for GELF inputs.
+const defaultPort = "12201"
+
+// RawConfig holds the raw configuration data for a connection to a
+// GELF forwarding target.
+type RawConfig struct {
+	// Enabled is true if the log forwarding feature is enabled.
+	Enabled bool
+
+	// Address is the host-port of the GELF input. The format is:
+	//
+	//   [domain-or-ip-addr] or [domain-or-ip-addr][:port]
+	//
+	// If the port is not set then the default GELF port (12201)
+	// will be used.
+	Address string
+
+	// Protocol is the transport protocol used to send messages:
+	// either ProtocolUDP or ProtocolTCP. If empty, ProtocolUDP is
+	// used.
+	Protocol string
+}
+
+// Validate ensures that the config is currently valid.
+func (cfg RawConfig) Validate() error {
+	host, _, err := net.SplitHostPort(cfg.Address)
+	if err != nil {
+		host = cfg.Address
+	}
+	if host == "" && cfg.Enabled {
+		return errors.NotValidf("Address %q", cfg.Address)
+	}
+	switch cfg.Protocol {
+	case "", ProtocolUDP, ProtocolTCP:
+	default:
+		return errors.NotValidf("Protocol %q", cfg.Protocol)
+	}
+	return nil
+}
+
+func (cfg RawConfig) address() string {
+	if _, _, err := net.SplitHostPort(cfg.Address); err == nil {
+		return cfg.Address
+	}
+	return net.JoinHostPort(cfg.Address, defaultPort)
+}
+
+func (cfg RawConfig) protocol() string {
+	if cfg.Protocol == "" {
+		return ProtocolUDP
+	}
+	return cfg.Protocol
+}
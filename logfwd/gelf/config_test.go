@@ -0,0 +1,73 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package gelf_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd/gelf"
+)
+
+type ConfigSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ConfigSuite{})
+
+func (s *ConfigSuite) TestRawValidateFull(c *gc.C) {
+	cfg := gelf.RawConfig{
+		Enabled:  true,
+		Address:  "graylog.example.com:12201",
+		Protocol: gelf.ProtocolTCP,
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *ConfigSuite) TestRawValidateWithoutPort(c *gc.C) {
+	cfg := gelf.RawConfig{
+		Enabled: true,
+		Address: "graylog.example.com",
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *ConfigSuite) TestRawValidateZeroValue(c *gc.C) {
+	var cfg gelf.RawConfig
+	err := cfg.Validate()
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *ConfigSuite) TestRawValidateMissingAddress(c *gc.C) {
+	cfg := gelf.RawConfig{
+		Enabled: true,
+		Address: ":12201",
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, `Address ":12201" not valid`)
+}
+
+func (s *ConfigSuite) TestRawValidateBadProtocol(c *gc.C) {
+	cfg := gelf.RawConfig{
+		Enabled:  true,
+		Address:  "graylog.example.com",
+		Protocol: "http",
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, `Protocol "http" not valid`)
+}
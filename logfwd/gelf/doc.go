@@ -0,0 +1,9 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// The gelf package holds the tools needed to perform log forwarding
+// from Juju to a remote host that accepts the Graylog Extended Log
+// Format (GELF 1.1), over either UDP or TCP.
+//
+// See http://docs.graylog.org/en/latest/pages/gelf.html.
+package gelf
@@ -0,0 +1,193 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package httpjson
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/logfwd"
+)
+
+// defaultTimeout is the time allowed for a single batch of records
+// to be sent and acknowledged.
+const defaultTimeout = 30 * time.Second
+
+// Doer exposes the underlying functionality needed by Client.
+type Doer interface {
+	// Do sends the HTTP request and returns the response.
+	Do(*http.Request) (*http.Response, error)
+}
+
+// Client sends log records to a remote HTTP endpoint.
+type Client struct {
+	// URL is the URL to which records are POSTed.
+	URL string
+
+	// Format is the format of the request body.
+	Format string
+
+	// Doer is used to send the HTTP requests.
+	Doer Doer
+}
+
+// Open returns a new client for the HTTP endpoint described by the
+// given config.
+func Open(cfg RawConfig) (*Client, error) {
+	client, err := OpenForDoer(cfg, &http.Client{Timeout: defaultTimeout})
+	return client, errors.Trace(err)
+}
+
+// OpenForDoer returns a new client for the HTTP endpoint described
+// by the given config, which sends requests using the given Doer.
+func OpenForDoer(cfg RawConfig, doer Doer) (*Client, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	client := &Client{
+		URL:    cfg.URL,
+		Format: cfg.format(),
+		Doer:   doer,
+	}
+	return client, nil
+}
+
+// Close is part of the logforwarder.SendCloser interface. There is
+// no persistent connection to close.
+func (client Client) Close() error {
+	return nil
+}
+
+// Send sends the records to the remote HTTP endpoint in a single
+// request. An error is returned if the request is not accepted, in
+// which case none of the records should be considered sent.
+func (client Client) Send(records []logfwd.Record) error {
+	if len(records) == 0 {
+		return nil
+	}
+	var body bytes.Buffer
+	var contentType string
+	switch client.Format {
+	case FormatElasticsearch:
+		contentType = "application/x-ndjson"
+		if err := writeBulk(&body, records); err != nil {
+			return errors.Trace(err)
+		}
+	default:
+		contentType = "application/json"
+		if err := writeArray(&body, records); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	req, err := http.NewRequest("POST", client.URL, &body)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	req.Header.Set("Content-Type", contentType)
+	resp, err := client.Doer.Do(req)
+	if err != nil {
+		return errors.Annotate(err, "sending records")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return errors.Errorf("sending records: %s", resp.Status)
+	}
+	if client.Format == FormatElasticsearch {
+		if err := checkBulkResponse(resp.Body); err != nil {
+			return errors.Annotate(err, "sending records")
+		}
+	}
+	return nil
+}
+
+// document is the JSON representation of a single log record.
+type document struct {
+	ID              int64     `json:"id"`
+	Timestamp       time.Time `json:"timestamp"`
+	Level           string    `json:"level"`
+	Message         string    `json:"message"`
+	Module          string    `json:"module,omitempty"`
+	Location        string    `json:"location,omitempty"`
+	ControllerUUID  string    `json:"controller-uuid"`
+	ModelUUID       string    `json:"model-uuid"`
+	Hostname        string    `json:"hostname,omitempty"`
+	OriginType      string    `json:"origin-type"`
+	OriginName      string    `json:"origin-name,omitempty"`
+	SoftwareName    string    `json:"software-name,omitempty"`
+	SoftwareVersion string    `json:"software-version,omitempty"`
+}
+
+func documentFromRecord(rec logfwd.Record) document {
+	doc := document{
+		ID:             rec.ID,
+		Timestamp:      rec.Timestamp.UTC(),
+		Level:          rec.Level.String(),
+		Message:        rec.Message,
+		Module:         rec.Location.Module,
+		Location:       rec.Location.String(),
+		ControllerUUID: rec.Origin.ControllerUUID,
+		ModelUUID:      rec.Origin.ModelUUID,
+		Hostname:       rec.Origin.Hostname,
+		OriginType:     rec.Origin.Type.String(),
+		OriginName:     rec.Origin.Name,
+		SoftwareName:   rec.Origin.Software.Name,
+	}
+	if rec.Origin.Software.Name != "" {
+		doc.SoftwareVersion = rec.Origin.Software.Version.String()
+	}
+	return doc
+}
+
+// writeArray writes the records to w as a JSON array of documents.
+func writeArray(w io.Writer, records []logfwd.Record) error {
+	docs := make([]document, len(records))
+	for i, rec := range records {
+		docs[i] = documentFromRecord(rec)
+	}
+	return errors.Trace(json.NewEncoder(w).Encode(docs))
+}
+
+// writeBulk writes the records to w in the newline-delimited format
+// expected by the Elasticsearch bulk API: an "index" action line
+// followed by the document, for each record.
+func writeBulk(w io.Writer, records []logfwd.Record) error {
+	enc := json.NewEncoder(w)
+	action := map[string]interface{}{"index": struct{}{}}
+	for _, rec := range records {
+		if err := enc.Encode(action); err != nil {
+			return errors.Trace(err)
+		}
+		if err := enc.Encode(documentFromRecord(rec)); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// checkBulkResponse returns an error if the Elasticsearch bulk API
+// response body reports that any of the actions failed. The bulk API
+// responds with 200 OK even if individual actions fail.
+func checkBulkResponse(r io.Reader) error {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var resp struct {
+		Errors bool `json:"errors"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return errors.Annotate(err, "parsing bulk response")
+	}
+	if resp.Errors {
+		return errors.New("bulk request reported errors")
+	}
+	return nil
+}
@@ -0,0 +1,189 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package httpjson_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/httpjson"
+)
+
+type ClientSuite struct {
+	testing.IsolationSuite
+
+	server   *httptest.Server
+	requests []*http.Request
+	bodies   [][]byte
+	status   int
+	response string
+}
+
+var _ = gc.Suite(&ClientSuite{})
+
+func (s *ClientSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+
+	s.requests = nil
+	s.bodies = nil
+	s.status = http.StatusOK
+	s.response = `{"took":1,"errors":false,"items":[]}`
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := ioutil.ReadAll(req.Body)
+		s.requests = append(s.requests, req)
+		s.bodies = append(s.bodies, body)
+		w.WriteHeader(s.status)
+		w.Write([]byte(s.response))
+	}))
+	s.AddCleanup(func(*gc.C) { s.server.Close() })
+}
+
+func (s *ClientSuite) open(c *gc.C, format string) *httpjson.Client {
+	client, err := httpjson.Open(httpjson.RawConfig{
+		Enabled: true,
+		URL:     s.server.URL + "/logs",
+		Format:  format,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return client
+}
+
+func (s *ClientSuite) TestOpenDefaultsFormat(c *gc.C) {
+	client := s.open(c, "")
+	c.Check(client.URL, gc.Equals, s.server.URL+"/logs")
+	c.Check(client.Format, gc.Equals, httpjson.FormatJSON)
+}
+
+func (s *ClientSuite) TestOpenInvalidConfig(c *gc.C) {
+	_, err := httpjson.Open(httpjson.RawConfig{Enabled: true})
+	c.Check(err, gc.ErrorMatches, `empty URL not valid`)
+}
+
+func (s *ClientSuite) TestSendJSON(c *gc.C) {
+	client := s.open(c, httpjson.FormatJSON)
+
+	err := client.Send([]logfwd.Record{newRecord(10), newRecord(11)})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.requests, gc.HasLen, 1)
+	c.Check(s.requests[0].Method, gc.Equals, "POST")
+	c.Check(s.requests[0].URL.Path, gc.Equals, "/logs")
+	c.Check(s.requests[0].Header.Get("Content-Type"), gc.Equals, "application/json")
+
+	var docs []map[string]interface{}
+	err = json.Unmarshal(s.bodies[0], &docs)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(docs, gc.HasLen, 2)
+	c.Check(docs[0], jc.DeepEquals, map[string]interface{}{
+		"id":               10.0,
+		"timestamp":        "1970-01-01T03:25:45Z",
+		"level":            "ERROR",
+		"message":          "(╯°□°)╯︵ ┻━┻",
+		"module":           "juju.x.y",
+		"location":         "x/y/spam.go:42",
+		"controller-uuid":  "9f484882-2f18-4fd2-967d-db9663db7bea",
+		"model-uuid":       "deadbeef-2f18-4fd2-967d-db9663db7bea",
+		"hostname":         "machine-99.deadbeef-2f18-4fd2-967d-db9663db7bea",
+		"origin-type":      "machine",
+		"origin-name":      "99",
+		"software-name":    "jujud-machine-agent",
+		"software-version": "1.2.3",
+	})
+	c.Check(docs[1]["id"], gc.Equals, 11.0)
+}
+
+func (s *ClientSuite) TestSendElasticsearch(c *gc.C) {
+	client := s.open(c, httpjson.FormatElasticsearch)
+
+	err := client.Send([]logfwd.Record{newRecord(10), newRecord(11)})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.requests, gc.HasLen, 1)
+	c.Check(s.requests[0].Header.Get("Content-Type"), gc.Equals, "application/x-ndjson")
+
+	var lines []map[string]interface{}
+	scanner := bufio.NewScanner(bytes.NewReader(s.bodies[0]))
+	for scanner.Scan() {
+		var line map[string]interface{}
+		err := json.Unmarshal(scanner.Bytes(), &line)
+		c.Assert(err, jc.ErrorIsNil)
+		lines = append(lines, line)
+	}
+	c.Assert(scanner.Err(), jc.ErrorIsNil)
+	c.Assert(lines, gc.HasLen, 4)
+	c.Check(lines[0], jc.DeepEquals, map[string]interface{}{
+		"index": map[string]interface{}{},
+	})
+	c.Check(lines[1]["id"], gc.Equals, 10.0)
+	c.Check(lines[2], jc.DeepEquals, lines[0])
+	c.Check(lines[3]["id"], gc.Equals, 11.0)
+}
+
+func (s *ClientSuite) TestSendEmpty(c *gc.C) {
+	client := s.open(c, httpjson.FormatJSON)
+
+	err := client.Send(nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.requests, gc.HasLen, 0)
+}
+
+func (s *ClientSuite) TestSendErrorStatus(c *gc.C) {
+	s.status = http.StatusServiceUnavailable
+	client := s.open(c, httpjson.FormatJSON)
+
+	err := client.Send([]logfwd.Record{newRecord(10)})
+
+	c.Check(err, gc.ErrorMatches, `sending records: 503 Service Unavailable`)
+}
+
+func (s *ClientSuite) TestSendElasticsearchItemErrors(c *gc.C) {
+	s.response = `{"took":1,"errors":true,"items":[]}`
+	client := s.open(c, httpjson.FormatElasticsearch)
+
+	err := client.Send([]logfwd.Record{newRecord(10)})
+
+	c.Check(err, gc.ErrorMatches, `sending records: bulk request reported errors`)
+}
+
+func (s *ClientSuite) TestSendJSONIgnoresResponseBody(c *gc.C) {
+	s.response = `not json`
+	client := s.open(c, httpjson.FormatJSON)
+
+	err := client.Send([]logfwd.Record{newRecord(10)})
+
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func newRecord(id int64) logfwd.Record {
+	tag := names.NewMachineTag("99")
+	cID := "9f484882-2f18-4fd2-967d-db9663db7bea"
+	mID := "deadbeef-2f18-4fd2-967d-db9663db7bea"
+	ver := version.MustParse("1.2.3")
+	return logfwd.Record{
+		ID:        id,
+		Origin:    logfwd.OriginForMachineAgent(tag, cID, mID, ver),
+		Timestamp: time.Unix(12345, 0),
+		Level:     loggo.ERROR,
+		Location: logfwd.SourceLocation{
+			Module:   "juju.x.y",
+			Filename: "x/y/spam.go",
+			Line:     42,
+		},
+		Message: "(╯°□°)╯︵ ┻━┻",
+	}
+}
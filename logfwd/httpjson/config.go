@@ -0,0 +1,79 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package httpjson
+
+import (
+	"net/url"
+
+	"github.com/juju/errors"
+)
+
+// These are the supported formats for the request body.
+const (
+	// FormatJSON causes each batch of records to be sent as a single
+	// JSON array of documents.
+	FormatJSON = "json"
+
+	// FormatElasticsearch causes each batch of records to be sent
+	// as an Elasticsearch bulk API request.
+	FormatElasticsearch = "elasticsearch"
+)
+
+// RawConfig holds the raw configuration data for a connection to an
+// HTTP log forwarding target.
+type RawConfig struct {
+	// Enabled is true if the log forwarding feature is enabled.
+	Enabled bool
+
+	// URL is the URL to which batches of records are POSTed. The
+	// scheme must be either "http" or "https". For the Elasticsearch
+	// format this is the bulk API endpoint, e.g.
+	//
+	//   https://es.example.com:9200/juju/logs/_bulk
+	URL string
+
+	// Format is the format of the request body: either FormatJSON
+	// or FormatElasticsearch. If empty, FormatJSON is used.
+	Format string
+}
+
+// Validate ensures that the config is currently valid.
+func (cfg RawConfig) Validate() error {
+	if err := cfg.validateURL(); err != nil {
+		return errors.Trace(err)
+	}
+	switch cfg.Format {
+	case "", FormatJSON, FormatElasticsearch:
+	default:
+		return errors.NotValidf("Format %q", cfg.Format)
+	}
+	return nil
+}
+
+func (cfg RawConfig) validateURL() error {
+	if cfg.URL == "" {
+		if cfg.Enabled {
+			return errors.NotValidf("empty URL")
+		}
+		return nil
+	}
+	u, err := url.Parse(cfg.URL)
+	if err != nil {
+		return errors.NewNotValid(err, "invalid URL")
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return errors.NotValidf("URL scheme %q", u.Scheme)
+	}
+	if u.Host == "" {
+		return errors.NotValidf("URL %q without host", cfg.URL)
+	}
+	return nil
+}
+
+func (cfg RawConfig) format() string {
+	if cfg.Format == "" {
+		return FormatJSON
+	}
+	return cfg.Format
+}
@@ -0,0 +1,8 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// The httpjson package holds the tools needed to perform log forwarding
+// from Juju to a remote HTTP endpoint, as batches of JSON documents.
+// Both generic JSON webhooks and the Elasticsearch bulk API are
+// supported.
+package httpjson
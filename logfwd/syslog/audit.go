@@ -0,0 +1,113 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package syslog
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/rfc/rfc5424"
+	"github.com/juju/rfc/rfc5424/sdelements"
+
+	"github.com/juju/juju/audit"
+	"github.com/juju/juju/logfwd"
+)
+
+// AuditAppName is the RFC 5424 APP-NAME of forwarded audit entries,
+// which allows them to be routed separately from forwarded logs.
+const AuditAppName = "juju-audit"
+
+var logger = loggo.GetLogger("juju.logfwd.syslog")
+
+// formatAuditEntry is messageFromAuditEntry, patched in tests.
+var formatAuditEntry = messageFromAuditEntry
+
+// SendAudit sends the audit entries to the remote syslog host. The
+// origin identifies the controller agent forwarding them. Entries that
+// cannot be formatted as syslog messages are logged and skipped, as
+// sending them again would never succeed.
+func (client Client) SendAudit(origin logfwd.Origin, entries []audit.AuditEntry) error {
+	for _, entry := range entries {
+		msg, err := formatAuditEntry(origin, entry)
+		if err != nil {
+			logger.Errorf("dropping audit entry %q at %v: %v", entry.Operation, entry.Timestamp, err)
+			continue
+		}
+		if err := client.Sender.Send(msg); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+func messageFromAuditEntry(origin logfwd.Origin, entry audit.AuditEntry) (rfc5424.Message, error) {
+	pen := sdelements.PrivateEnterpriseNumber(origin.Software.PrivateEnterpriseNumber)
+	msg := rfc5424.Message{
+		Header: rfc5424.Header{
+			Priority: rfc5424.Priority{
+				Severity: rfc5424.SeverityInformational,
+				Facility: rfc5424.FacilityUser,
+			},
+			Timestamp: rfc5424.Timestamp{entry.Timestamp},
+			Hostname: rfc5424.Hostname{
+				FQDN: origin.Hostname,
+			},
+			AppName: AuditAppName,
+		},
+		StructuredData: rfc5424.StructuredData{
+			&sdelements.Origin{
+				EnterpriseID: sdelements.OriginEnterpriseID{
+					Number: pen,
+				},
+				SoftwareName:    origin.Software.Name,
+				SoftwareVersion: origin.Software.Version,
+			},
+			&sdelements.Private{
+				Name: "model",
+				PEN:  pen,
+				Data: []rfc5424.StructuredDataParam{{
+					Name:  "controller-uuid",
+					Value: rfc5424.StructuredDataParamValue(origin.ControllerUUID),
+				}, {
+					Name:  "model-uuid",
+					Value: rfc5424.StructuredDataParamValue(entry.ModelUUID),
+				}},
+			},
+			&sdelements.Private{
+				Name: "audit",
+				PEN:  pen,
+				Data: []rfc5424.StructuredDataParam{{
+					Name:  "origin-type",
+					Value: rfc5424.StructuredDataParamValue(entry.OriginType),
+				}, {
+					Name:  "origin-name",
+					Value: rfc5424.StructuredDataParamValue(entry.OriginName),
+				}, {
+					Name:  "remote-address",
+					Value: rfc5424.StructuredDataParamValue(entry.RemoteAddress),
+				}, {
+					Name:  "operation",
+					Value: rfc5424.StructuredDataParamValue(entry.Operation),
+				}, {
+					Name:  "server-version",
+					Value: rfc5424.StructuredDataParamValue(entry.JujuServerVersion.String()),
+				}},
+			},
+		},
+		Msg: auditMessage(entry),
+	}
+	if err := msg.Validate(); err != nil {
+		return msg, errors.Trace(err)
+	}
+	return msg, nil
+}
+
+// auditMessage returns the free-form message for the audit entry:
+// its operation, followed by the (redacted) request body if one was
+// recorded.
+func auditMessage(entry audit.AuditEntry) string {
+	if body, ok := entry.Data["request-body"].(string); ok && body != "" {
+		return entry.Operation + " " + body
+	}
+	return entry.Operation
+}
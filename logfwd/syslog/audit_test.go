@@ -0,0 +1,162 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package syslog_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/rfc/rfc5424"
+	"github.com/juju/rfc/rfc5424/sdelements"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/audit"
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/syslog"
+)
+
+type AuditSuite struct {
+	testing.IsolationSuite
+
+	stub   *testing.Stub
+	sender *stubSender
+	origin logfwd.Origin
+	entry  audit.AuditEntry
+}
+
+var _ = gc.Suite(&AuditSuite{})
+
+func (s *AuditSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+
+	s.stub = &testing.Stub{}
+	s.sender = &stubSender{stub: s.stub}
+	s.origin = logfwd.OriginForMachineAgent(
+		names.NewMachineTag("0"),
+		"9f484882-2f18-4fd2-967d-db9663db7bea",
+		"deadbeef-2f18-4fd2-967d-db9663db7bea",
+		version.MustParse("1.2.3"),
+	)
+	s.entry = audit.AuditEntry{
+		JujuServerVersion: version.MustParse("1.2.3"),
+		ModelUUID:         "cafef00d-2f18-4fd2-967d-db9663db7bea",
+		Timestamp:         time.Unix(12345, 0).UTC(),
+		RemoteAddress:     "10.0.0.1:1234",
+		OriginType:        "API request",
+		OriginName:        "user-bob@local",
+		Operation:         "Application:v1 - Set",
+		Data: map[string]interface{}{
+			"facade":       "Application",
+			"version":      1,
+			"method":       "Set",
+			"request-body": `{"application":"mysql"}`,
+		},
+	}
+}
+
+func (s *AuditSuite) TestSendAudit(c *gc.C) {
+	client := syslog.Client{Sender: s.sender}
+
+	err := client.SendAudit(s.origin, []audit.AuditEntry{s.entry})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "Send")
+	s.stub.CheckCall(c, 0, "Send", rfc5424.Message{
+		Header: rfc5424.Header{
+			Priority: rfc5424.Priority{
+				Severity: rfc5424.SeverityInformational,
+				Facility: rfc5424.FacilityUser,
+			},
+			Timestamp: rfc5424.Timestamp{time.Unix(12345, 0).UTC()},
+			Hostname: rfc5424.Hostname{
+				FQDN: "machine-0.deadbeef-2f18-4fd2-967d-db9663db7bea",
+			},
+			AppName: "juju-audit",
+		},
+		StructuredData: rfc5424.StructuredData{
+			&sdelements.Origin{
+				EnterpriseID: sdelements.OriginEnterpriseID{
+					Number: 28978,
+				},
+				SoftwareName:    "jujud-machine-agent",
+				SoftwareVersion: version.MustParse("1.2.3"),
+			},
+			&sdelements.Private{
+				Name: "model",
+				PEN:  28978,
+				Data: []rfc5424.StructuredDataParam{{
+					Name:  "controller-uuid",
+					Value: "9f484882-2f18-4fd2-967d-db9663db7bea",
+				}, {
+					Name:  "model-uuid",
+					Value: "cafef00d-2f18-4fd2-967d-db9663db7bea",
+				}},
+			},
+			&sdelements.Private{
+				Name: "audit",
+				PEN:  28978,
+				Data: []rfc5424.StructuredDataParam{{
+					Name:  "origin-type",
+					Value: "API request",
+				}, {
+					Name:  "origin-name",
+					Value: "user-bob@local",
+				}, {
+					Name:  "remote-address",
+					Value: "10.0.0.1:1234",
+				}, {
+					Name:  "operation",
+					Value: "Application:v1 - Set",
+				}, {
+					Name:  "server-version",
+					Value: "1.2.3",
+				}},
+			},
+		},
+		Msg: `Application:v1 - Set {"application":"mysql"}`,
+	})
+}
+
+func (s *AuditSuite) TestSendAuditNoRequestBody(c *gc.C) {
+	client := syslog.Client{Sender: s.sender}
+	delete(s.entry.Data, "request-body")
+
+	err := client.SendAudit(s.origin, []audit.AuditEntry{s.entry})
+	c.Assert(err, jc.ErrorIsNil)
+
+	msg := s.stub.Calls()[0].Args[0].(rfc5424.Message)
+	c.Check(msg.Msg, gc.Equals, "Application:v1 - Set")
+}
+
+func (s *AuditSuite) TestSendAuditSkipsUnformattable(c *gc.C) {
+	client := syslog.Client{Sender: s.sender}
+	s.PatchValue(syslog.FormatAuditEntry, func(origin logfwd.Origin, entry audit.AuditEntry) (rfc5424.Message, error) {
+		if entry.Operation == "bad" {
+			return rfc5424.Message{}, errors.New("invalid")
+		}
+		return rfc5424.Message{Msg: entry.Operation}, nil
+	})
+	bad := s.entry
+	bad.Operation = "bad"
+
+	err := client.SendAudit(s.origin, []audit.AuditEntry{bad, s.entry})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "Send")
+	msg := s.stub.Calls()[0].Args[0].(rfc5424.Message)
+	c.Check(msg.Msg, gc.Equals, "Application:v1 - Set")
+}
+
+func (s *AuditSuite) TestSendAuditStopsOnError(c *gc.C) {
+	client := syslog.Client{Sender: s.sender}
+	s.stub.SetErrors(errors.New("boom"))
+
+	err := client.SendAudit(s.origin, []audit.AuditEntry{s.entry, s.entry})
+	c.Assert(err, gc.ErrorMatches, "boom")
+	s.stub.CheckCallNames(c, "Send")
+}
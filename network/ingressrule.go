@@ -0,0 +1,118 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package network
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// AllSourceCIDR is the source CIDR which allows ingress from
+// anywhere; it is used for rules that do not specify any
+// source CIDRs.
+const AllSourceCIDR = "0.0.0.0/0"
+
+// IngressRule represents a range of ports and the source CIDRs
+// from which traffic is allowed to reach those ports.
+type IngressRule struct {
+	PortRange
+
+	// SourceCIDRs holds the sorted CIDRs from which ingress is
+	// allowed.
+	SourceCIDRs []string
+}
+
+// NewIngressRule returns an IngressRule for the given port range and
+// source CIDRs. If no source CIDRs are given, ingress is allowed from
+// anywhere.
+func NewIngressRule(portRange PortRange, sourceCIDRs ...string) (IngressRule, error) {
+	if err := portRange.Validate(); err != nil {
+		return IngressRule{}, errors.Trace(err)
+	}
+	if len(sourceCIDRs) == 0 {
+		sourceCIDRs = []string{AllSourceCIDR}
+	}
+	seen := make(map[string]bool)
+	cidrs := make([]string, 0, len(sourceCIDRs))
+	for _, cidr := range sourceCIDRs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return IngressRule{}, errors.NotValidf("source CIDR %q", cidr)
+		}
+		if seen[cidr] {
+			continue
+		}
+		seen[cidr] = true
+		cidrs = append(cidrs, cidr)
+	}
+	sort.Strings(cidrs)
+	return IngressRule{
+		PortRange:   portRange,
+		SourceCIDRs: cidrs,
+	}, nil
+}
+
+// MustNewIngressRule returns an IngressRule for the given port range
+// and source CIDRs, panicking if they are not valid.
+func MustNewIngressRule(portRange PortRange, sourceCIDRs ...string) IngressRule {
+	rule, err := NewIngressRule(portRange, sourceCIDRs...)
+	if err != nil {
+		panic(err)
+	}
+	return rule
+}
+
+// OpenToAll reports whether the rule allows ingress from anywhere.
+func (r IngressRule) OpenToAll() bool {
+	for _, cidr := range r.SourceCIDRs {
+		if cidr == AllSourceCIDR {
+			return true
+		}
+	}
+	return len(r.SourceCIDRs) == 0
+}
+
+func (r IngressRule) String() string {
+	if len(r.SourceCIDRs) == 0 {
+		return r.PortRange.String()
+	}
+	return fmt.Sprintf("%s from %s", r.PortRange, strings.Join(r.SourceCIDRs, ","))
+}
+
+func (r IngressRule) GoString() string {
+	return r.String()
+}
+
+// IngressRulesFromPortRanges returns rules that allow ingress from
+// anywhere to each of the given port ranges.
+func IngressRulesFromPortRanges(portRanges []PortRange) []IngressRule {
+	rules := make([]IngressRule, len(portRanges))
+	for i, portRange := range portRanges {
+		rules[i] = IngressRule{
+			PortRange:   portRange,
+			SourceCIDRs: []string{AllSourceCIDR},
+		}
+	}
+	return rules
+}
+
+type ingressRuleSlice []IngressRule
+
+func (r ingressRuleSlice) Len() int      { return len(r) }
+func (r ingressRuleSlice) Swap(i, j int) { r[i], r[j] = r[j], r[i] }
+func (r ingressRuleSlice) Less(i, j int) bool {
+	if r[i].PortRange != r[j].PortRange {
+		return portRangeSlice{r[i].PortRange, r[j].PortRange}.Less(0, 1)
+	}
+	return r[i].String() < r[j].String()
+}
+
+// SortIngressRules sorts the given rules, first by port range, then
+// by source CIDRs.
+func SortIngressRules(rules []IngressRule) {
+	sort.Sort(ingressRuleSlice(rules))
+}
@@ -0,0 +1,72 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package network_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/testing"
+)
+
+type IngressRuleSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&IngressRuleSuite{})
+
+func (*IngressRuleSuite) TestNewIngressRuleDefaultsToAll(c *gc.C) {
+	rule, err := network.NewIngressRule(network.MustParsePortRange("80/tcp"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rule.SourceCIDRs, jc.DeepEquals, []string{"0.0.0.0/0"})
+	c.Assert(rule.OpenToAll(), jc.IsTrue)
+	c.Assert(rule.String(), gc.Equals, "80/tcp from 0.0.0.0/0")
+}
+
+func (*IngressRuleSuite) TestNewIngressRuleSortsSourceCIDRs(c *gc.C) {
+	rule, err := network.NewIngressRule(
+		network.MustParsePortRange("8000-8080/tcp"),
+		"192.168.0.0/16", "10.0.0.0/8", "192.168.0.0/16",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rule.SourceCIDRs, jc.DeepEquals, []string{"10.0.0.0/8", "192.168.0.0/16"})
+	c.Assert(rule.OpenToAll(), jc.IsFalse)
+	c.Assert(rule.String(), gc.Equals, "8000-8080/tcp from 10.0.0.0/8,192.168.0.0/16")
+}
+
+func (*IngressRuleSuite) TestNewIngressRuleInvalid(c *gc.C) {
+	_, err := network.NewIngressRule(network.PortRange{80, 80, "icmp"})
+	c.Assert(err, gc.ErrorMatches, `invalid protocol "icmp", expected "tcp" or "udp"`)
+
+	_, err = network.NewIngressRule(network.MustParsePortRange("80/tcp"), "10.0.0.0")
+	c.Assert(err, gc.ErrorMatches, `source CIDR "10.0.0.0" not valid`)
+}
+
+func (*IngressRuleSuite) TestIngressRulesFromPortRanges(c *gc.C) {
+	rules := network.IngressRulesFromPortRanges([]network.PortRange{
+		network.MustParsePortRange("80/tcp"),
+		network.MustParsePortRange("53/udp"),
+	})
+	c.Assert(rules, jc.DeepEquals, []network.IngressRule{
+		network.MustNewIngressRule(network.MustParsePortRange("80/tcp")),
+		network.MustNewIngressRule(network.MustParsePortRange("53/udp")),
+	})
+}
+
+func (*IngressRuleSuite) TestSortIngressRules(c *gc.C) {
+	rules := []network.IngressRule{
+		network.MustNewIngressRule(network.MustParsePortRange("80/tcp"), "10.0.0.0/8"),
+		network.MustNewIngressRule(network.MustParsePortRange("53/udp")),
+		network.MustNewIngressRule(network.MustParsePortRange("80/tcp")),
+		network.MustNewIngressRule(network.MustParsePortRange("22/tcp")),
+	}
+	network.SortIngressRules(rules)
+	c.Assert(rules, jc.DeepEquals, []network.IngressRule{
+		network.MustNewIngressRule(network.MustParsePortRange("22/tcp")),
+		network.MustNewIngressRule(network.MustParsePortRange("80/tcp")),
+		network.MustNewIngressRule(network.MustParsePortRange("80/tcp"), "10.0.0.0/8"),
+		network.MustNewIngressRule(network.MustParsePortRange("53/udp")),
+	})
+}
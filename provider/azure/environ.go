@@ -438,6 +438,8 @@ func (env *azureEnviron) ConstraintsValidator() (constraints.Validator, error) {
 		constraints.CpuPower,
 		constraints.Tags,
 		constraints.VirtType,
+		constraints.Zones,
+		constraints.PlacementGroup,
 	})
 	validator.RegisterVocabulary(
 		constraints.Arch,
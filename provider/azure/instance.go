@@ -183,6 +183,13 @@ func (inst *azureInstance) primaryNetworkAddress() (jujunetwork.Address, error)
 
 // OpenPorts is specified in the Instance interface.
 func (inst *azureInstance) OpenPorts(machineId string, ports []jujunetwork.PortRange) error {
+	return inst.OpenIngressRules(machineId, jujunetwork.IngressRulesFromPortRanges(ports))
+}
+
+// OpenIngressRules is specified in the instance.IngressRuleFirewaller
+// interface. Azure security rules have a single source address prefix,
+// so a security rule is created for each of each rule's source CIDRs.
+func (inst *azureInstance) OpenIngressRules(machineId string, rules []jujunetwork.IngressRule) error {
 	nsgClient := network.SecurityGroupsClient{inst.env.network}
 	securityRuleClient := network.SecurityRulesClient{inst.env.network}
 	primaryNetworkAddress, err := inst.primaryNetworkAddress()
@@ -212,73 +219,88 @@ func (inst *azureInstance) OpenPorts(machineId string, ports []jujunetwork.PortR
 	// NSG in memory, so we can easily tell which priorities are available.
 	vmName := resourceName(names.NewMachineTag(machineId))
 	prefix := instanceNetworkSecurityRulePrefix(instance.Id(vmName))
-	for _, ports := range ports {
-		ruleName := securityRuleName(prefix, ports)
-
-		// Check if the rule already exists; OpenPorts must be idempotent.
-		var found bool
-		for _, rule := range securityRules {
-			if to.String(rule.Name) == ruleName {
-				found = true
-				break
+	for _, ingressRule := range rules {
+		ports := ingressRule.PortRange
+		for _, sourceCIDR := range ingressRuleSourceCIDRs(ingressRule) {
+			ruleName := ingressSecurityRuleName(prefix, ports, sourceCIDR)
+
+			// Check if the rule already exists; OpenPorts must be idempotent.
+			var found bool
+			for _, rule := range securityRules {
+				if to.String(rule.Name) == ruleName {
+					found = true
+					break
+				}
 			}
-		}
-		if found {
-			logger.Debugf("security rule %q already exists", ruleName)
-			continue
-		}
-		logger.Debugf("creating security rule %q", ruleName)
+			if found {
+				logger.Debugf("security rule %q already exists", ruleName)
+				continue
+			}
+			logger.Debugf("creating security rule %q", ruleName)
 
-		priority, err := nextSecurityRulePriority(nsg, securityRuleInternalMax+1, securityRuleMax)
-		if err != nil {
-			return errors.Annotatef(err, "getting security rule priority for %s", ports)
-		}
+			priority, err := nextSecurityRulePriority(nsg, securityRuleInternalMax+1, securityRuleMax)
+			if err != nil {
+				return errors.Annotatef(err, "getting security rule priority for %s", ports)
+			}
 
-		var protocol network.SecurityRuleProtocol
-		switch ports.Protocol {
-		case "tcp":
-			protocol = network.TCP
-		case "udp":
-			protocol = network.UDP
-		default:
-			return errors.Errorf("invalid protocol %q", ports.Protocol)
-		}
+			var protocol network.SecurityRuleProtocol
+			switch ports.Protocol {
+			case "tcp":
+				protocol = network.TCP
+			case "udp":
+				protocol = network.UDP
+			default:
+				return errors.Errorf("invalid protocol %q", ports.Protocol)
+			}
 
-		var portRange string
-		if ports.FromPort != ports.ToPort {
-			portRange = fmt.Sprintf("%d-%d", ports.FromPort, ports.ToPort)
-		} else {
-			portRange = fmt.Sprint(ports.FromPort)
-		}
+			var portRange string
+			if ports.FromPort != ports.ToPort {
+				portRange = fmt.Sprintf("%d-%d", ports.FromPort, ports.ToPort)
+			} else {
+				portRange = fmt.Sprint(ports.FromPort)
+			}
 
-		rule := network.SecurityRule{
-			Properties: &network.SecurityRulePropertiesFormat{
-				Description:              to.StringPtr(ports.String()),
-				Protocol:                 protocol,
-				SourcePortRange:          to.StringPtr("*"),
-				DestinationPortRange:     to.StringPtr(portRange),
-				SourceAddressPrefix:      to.StringPtr("*"),
-				DestinationAddressPrefix: to.StringPtr(primaryNetworkAddress.Value),
-				Access:    network.Allow,
-				Priority:  to.Int32Ptr(priority),
-				Direction: network.Inbound,
-			},
-		}
-		if err := inst.env.callAPI(func() (autorest.Response, error) {
-			return securityRuleClient.CreateOrUpdate(
-				inst.env.resourceGroup, securityGroupName, ruleName, rule,
-				nil, // abort channel
-			)
-		}); err != nil {
-			return errors.Annotatef(err, "creating security rule for %s", ports)
+			description := ports.String()
+			sourceAddressPrefix := "*"
+			if sourceCIDR != jujunetwork.AllSourceCIDR {
+				description = fmt.Sprintf("%s from %s", ports, sourceCIDR)
+				sourceAddressPrefix = sourceCIDR
+			}
+			rule := network.SecurityRule{
+				Properties: &network.SecurityRulePropertiesFormat{
+					Description:              to.StringPtr(description),
+					Protocol:                 protocol,
+					SourcePortRange:          to.StringPtr("*"),
+					DestinationPortRange:     to.StringPtr(portRange),
+					SourceAddressPrefix:      to.StringPtr(sourceAddressPrefix),
+					DestinationAddressPrefix: to.StringPtr(primaryNetworkAddress.Value),
+					Access:    network.Allow,
+					Priority:  to.Int32Ptr(priority),
+					Direction: network.Inbound,
+				},
+			}
+			if err := inst.env.callAPI(func() (autorest.Response, error) {
+				return securityRuleClient.CreateOrUpdate(
+					inst.env.resourceGroup, securityGroupName, ruleName, rule,
+					nil, // abort channel
+				)
+			}); err != nil {
+				return errors.Annotatef(err, "creating security rule for %s", ports)
+			}
+			securityRules = append(securityRules, rule)
 		}
-		securityRules = append(securityRules, rule)
 	}
 	return nil
 }
 
 // ClosePorts is specified in the Instance interface.
 func (inst *azureInstance) ClosePorts(machineId string, ports []jujunetwork.PortRange) error {
+	return inst.CloseIngressRules(machineId, jujunetwork.IngressRulesFromPortRanges(ports))
+}
+
+// CloseIngressRules is specified in the instance.IngressRuleFirewaller
+// interface.
+func (inst *azureInstance) CloseIngressRules(machineId string, rules []jujunetwork.IngressRule) error {
 	securityRuleClient := network.SecurityRulesClient{inst.env.network}
 	securityGroupName := internalSecurityGroupName
 
@@ -286,20 +308,22 @@ func (inst *azureInstance) ClosePorts(machineId string, ports []jujunetwork.Port
 	// on changes made by the provisioner.
 	vmName := resourceName(names.NewMachineTag(machineId))
 	prefix := instanceNetworkSecurityRulePrefix(instance.Id(vmName))
-	for _, ports := range ports {
-		ruleName := securityRuleName(prefix, ports)
-		logger.Debugf("deleting security rule %q", ruleName)
-		var result autorest.Response
-		if err := inst.env.callAPI(func() (autorest.Response, error) {
-			var err error
-			result, err = securityRuleClient.Delete(
-				inst.env.resourceGroup, securityGroupName, ruleName,
-				nil, // abort channel
-			)
-			return result, err
-		}); err != nil {
-			if result.Response == nil || result.StatusCode != http.StatusNotFound {
-				return errors.Annotatef(err, "deleting security rule %q", ruleName)
+	for _, ingressRule := range rules {
+		for _, sourceCIDR := range ingressRuleSourceCIDRs(ingressRule) {
+			ruleName := ingressSecurityRuleName(prefix, ingressRule.PortRange, sourceCIDR)
+			logger.Debugf("deleting security rule %q", ruleName)
+			var result autorest.Response
+			if err := inst.env.callAPI(func() (autorest.Response, error) {
+				var err error
+				result, err = securityRuleClient.Delete(
+					inst.env.resourceGroup, securityGroupName, ruleName,
+					nil, // abort channel
+				)
+				return result, err
+			}); err != nil {
+				if result.Response == nil || result.StatusCode != http.StatusNotFound {
+					return errors.Annotatef(err, "deleting security rule %q", ruleName)
+				}
 			}
 		}
 	}
@@ -308,6 +332,55 @@ func (inst *azureInstance) ClosePorts(machineId string, ports []jujunetwork.Port
 
 // Ports is specified in the Instance interface.
 func (inst *azureInstance) Ports(machineId string) (ports []jujunetwork.PortRange, err error) {
+	rules, err := inst.instanceSecurityRules(machineId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, rule := range rules {
+		ports = append(ports, rule.portRanges...)
+	}
+	return ports, nil
+}
+
+// IngressRules is specified in the instance.IngressRuleFirewaller
+// interface.
+func (inst *azureInstance) IngressRules(machineId string) ([]jujunetwork.IngressRule, error) {
+	rules, err := inst.instanceSecurityRules(machineId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var portRanges []jujunetwork.PortRange
+	sourceCIDRs := make(map[jujunetwork.PortRange][]string)
+	for _, rule := range rules {
+		for _, portRange := range rule.portRanges {
+			if _, ok := sourceCIDRs[portRange]; !ok {
+				portRanges = append(portRanges, portRange)
+			}
+			sourceCIDRs[portRange] = append(sourceCIDRs[portRange], rule.sourceCIDR)
+		}
+	}
+	ingressRules := make([]jujunetwork.IngressRule, len(portRanges))
+	for i, portRange := range portRanges {
+		ingressRule, err := jujunetwork.NewIngressRule(portRange, sourceCIDRs[portRange]...)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ingressRules[i] = ingressRule
+	}
+	jujunetwork.SortIngressRules(ingressRules)
+	return ingressRules, nil
+}
+
+// instanceSecurityRule holds the port ranges and source CIDR of a
+// security rule opened for an instance.
+type instanceSecurityRule struct {
+	portRanges []jujunetwork.PortRange
+	sourceCIDR string
+}
+
+// instanceSecurityRules returns the security rules opened for the
+// instance started with the given machine id.
+func (inst *azureInstance) instanceSecurityRules(machineId string) ([]instanceSecurityRule, error) {
 	nsgClient := network.SecurityGroupsClient{inst.env.network}
 	securityGroupName := internalSecurityGroupName
 	var nsg network.SecurityGroup
@@ -322,6 +395,7 @@ func (inst *azureInstance) Ports(machineId string) (ports []jujunetwork.PortRang
 		return nil, nil
 	}
 
+	var rules []instanceSecurityRule
 	vmName := resourceName(names.NewMachineTag(machineId))
 	prefix := instanceNetworkSecurityRulePrefix(instance.Id(vmName))
 	for _, rule := range *nsg.Properties.SecurityRules {
@@ -343,6 +417,7 @@ func (inst *azureInstance) Ports(machineId string) (ports []jujunetwork.PortRang
 			portRange.FromPort = 0
 			portRange.ToPort = 65535
 		} else {
+			var err error
 			portRange, err = jujunetwork.ParsePortRange(
 				*rule.Properties.DestinationPortRange,
 			)
@@ -363,12 +438,18 @@ func (inst *azureInstance) Ports(machineId string) (ports []jujunetwork.PortRang
 		default:
 			protocols = []string{"tcp", "udp"}
 		}
+		sourceCIDR := to.String(rule.Properties.SourceAddressPrefix)
+		if sourceCIDR == "" || sourceCIDR == "*" {
+			sourceCIDR = jujunetwork.AllSourceCIDR
+		}
+		instanceRule := instanceSecurityRule{sourceCIDR: sourceCIDR}
 		for _, protocol := range protocols {
 			portRange.Protocol = protocol
-			ports = append(ports, portRange)
+			instanceRule.portRanges = append(instanceRule.portRanges, portRange)
 		}
+		rules = append(rules, instanceRule)
 	}
-	return ports, nil
+	return rules, nil
 }
 
 // deleteInstanceNetworkSecurityRules deletes network security rules in the
@@ -435,3 +516,25 @@ func securityRuleName(prefix string, ports jujunetwork.PortRange) string {
 	}
 	return ruleName
 }
+
+// ingressSecurityRuleName returns the security rule name for the given
+// port range and source CIDR, and prefix returned by
+// instanceNetworkSecurityRulePrefix. Rules open to all sources have the
+// name returned by securityRuleName.
+func ingressSecurityRuleName(prefix string, ports jujunetwork.PortRange, sourceCIDR string) string {
+	ruleName := securityRuleName(prefix, ports)
+	if sourceCIDR != jujunetwork.AllSourceCIDR {
+		// Security rule names may not contain "/".
+		ruleName += "-" + strings.Replace(sourceCIDR, "/", "_", -1)
+	}
+	return ruleName
+}
+
+// ingressRuleSourceCIDRs returns the source CIDRs of the given rule,
+// which is open to all sources if it has none.
+func ingressRuleSourceCIDRs(rule jujunetwork.IngressRule) []string {
+	if len(rule.SourceCIDRs) == 0 {
+		return []string{jujunetwork.AllSourceCIDR}
+	}
+	return rule.SourceCIDRs
+}
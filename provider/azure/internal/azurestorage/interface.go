@@ -34,6 +34,19 @@ type BlobStorageClient interface {
 	//
 	// See https://godoc.org/github.com/Azure/azure-sdk-for-go/storage#BlobStorageClient.DeleteBlobIfExists
 	DeleteBlobIfExists(container, name string, extraHeaders map[string]string) (bool, error)
+
+	// CopyBlob starts a blob copy operation and waits for the operation
+	// to complete. sourceBlob parameter must be a canonical URL to the
+	// blob (can be obtained using GetBlobURL method).
+	//
+	// See https://godoc.org/github.com/Azure/azure-sdk-for-go/storage#BlobStorageClient.CopyBlob
+	CopyBlob(container, name, sourceBlob string) error
+
+	// GetBlobURL gets the canonical URL to the blob with the specified
+	// name in the specified container.
+	//
+	// See https://godoc.org/github.com/Azure/azure-sdk-for-go/storage#BlobStorageClient.GetBlobURL
+	GetBlobURL(container, name string) string
 }
 
 // NewClientFunc is the type of the NewClient function.
@@ -15,6 +15,7 @@ type MockStorageClient struct {
 
 	ListBlobsFunc          func(container string, _ storage.ListBlobsParameters) (storage.BlobListResponse, error)
 	DeleteBlobIfExistsFunc func(container, name string) (bool, error)
+	CopyBlobFunc           func(container, name, sourceBlob string) error
 }
 
 // NewClient exists to satisfy users who want a NewClientFunc.
@@ -48,3 +49,15 @@ func (c *MockStorageClient) DeleteBlobIfExists(container, name string, headers m
 	}
 	return false, c.NextErr()
 }
+
+func (c *MockStorageClient) CopyBlob(container, name, sourceBlob string) error {
+	c.MethodCall(c, "CopyBlob", container, name, sourceBlob)
+	if c.CopyBlobFunc != nil {
+		return c.CopyBlobFunc(container, name, sourceBlob)
+	}
+	return c.NextErr()
+}
+
+func (c *MockStorageClient) GetBlobURL(container, name string) string {
+	return "https://blob.storage/" + container + "/" + name
+}
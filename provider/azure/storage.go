@@ -15,6 +15,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/schema"
 	"github.com/juju/utils"
+	"github.com/juju/utils/set"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/environs"
@@ -121,6 +122,9 @@ func (e *azureStorageProvider) FilesystemSource(providerConfig *storage.Config)
 	return nil, errors.NotSupportedf("filesystems")
 }
 
+var _ storage.VolumeResizer = (*azureVolumeSource)(nil)
+var _ storage.VolumeSnapshotter = (*azureVolumeSource)(nil)
+
 type azureVolumeSource struct {
 	env *azureEnviron
 }
@@ -209,6 +213,16 @@ func (v *azureVolumeSource) createVolume(
 	dataDiskName := p.Tag.String()
 	vhdURI := dataDisksRoot + dataDiskName + vhdExtension
 
+	createOption := compute.Empty
+	if p.SnapshotId != "" {
+		// Volumes are restored from a snapshot by copying the
+		// snapshot's VHD, and attaching the copy.
+		if err := v.copySnapshotBlob(p.SnapshotId, dataDiskName); err != nil {
+			return nil, nil, errors.Annotatef(err, "restoring snapshot %q", p.SnapshotId)
+		}
+		createOption = compute.Attach
+	}
+
 	sizeInGib := mibToGib(p.Size)
 	dataDisk := compute.DataDisk{
 		Lun:          to.Int32Ptr(lun),
@@ -216,7 +230,7 @@ func (v *azureVolumeSource) createVolume(
 		Name:         to.StringPtr(dataDiskName),
 		Vhd:          &compute.VirtualHardDisk{to.StringPtr(vhdURI)},
 		Caching:      compute.ReadWrite,
-		CreateOption: compute.Empty,
+		CreateOption: createOption,
 	}
 
 	var dataDisks []compute.DataDisk
@@ -249,6 +263,18 @@ func (v *azureVolumeSource) createVolume(
 	return &volume, &volumeAttachment, nil
 }
 
+// copySnapshotBlob copies the VHD blob for the snapshot with the given ID
+// to the VHD blob for the named data disk.
+func (v *azureVolumeSource) copySnapshotBlob(snapshotId, dataDiskName string) error {
+	client, err := v.env.getStorageClient()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	blobsClient := client.GetBlobService()
+	sourceURL := blobsClient.GetBlobURL(dataDiskVHDContainer, snapshotId+vhdExtension)
+	return blobsClient.CopyBlob(dataDiskVHDContainer, dataDiskName+vhdExtension, sourceURL)
+}
+
 // ListVolumes is specified on the storage.VolumeSource interface.
 func (v *azureVolumeSource) ListVolumes() ([]string, error) {
 	blobs, err := v.listBlobs()
@@ -574,6 +600,180 @@ func (v *azureVolumeSource) detachVolume(
 	return false
 }
 
+// CreateVolumeSnapshots is specified on the storage.VolumeSnapshotter
+// interface.
+//
+// Azure data disks are VHD page blobs, so a snapshot is taken by copying
+// the volume's blob to a new blob in the same container. The snapshot
+// blobs are named such that they are not mistaken for volumes.
+func (v *azureVolumeSource) CreateVolumeSnapshots(params []storage.VolumeSnapshotParams) ([]storage.CreateVolumeSnapshotsResult, error) {
+	blobs, err := v.listBlobs()
+	if err != nil {
+		return nil, errors.Annotate(err, "listing volumes")
+	}
+	sizes := make(map[string]uint64)
+	snapshotBlobs := make(set.Strings)
+	for _, blob := range blobs {
+		volumeId, ok := blobVolumeId(blob)
+		if !ok {
+			// A snapshot whose copy did not fail may be reused,
+			// rather than copying the volume again.
+			switch blob.Properties.CopyStatus {
+			case "failed", "aborted":
+			default:
+				snapshotBlobs.Add(blob.Name)
+			}
+			continue
+		}
+		sizes[volumeId] = uint64(blob.Properties.ContentLength / (1024 * 1024))
+	}
+
+	client, err := v.env.getStorageClient()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	blobsClient := client.GetBlobService()
+	results := make([]storage.CreateVolumeSnapshotsResult, len(params))
+	for i, p := range params {
+		size, ok := sizes[p.VolumeId]
+		if !ok {
+			results[i].Error = errors.NotFoundf("volume %s", p.VolumeId)
+			continue
+		}
+		snapshotId := "snapshot-" + p.Id
+		sourceURL := blobsClient.GetBlobURL(dataDiskVHDContainer, p.VolumeId+vhdExtension)
+		if snapshotBlobs.Contains(snapshotId + vhdExtension) {
+			logger.Debugf("found existing snapshot %q for snapshot request %s", snapshotId, p.Id)
+		} else if err := blobsClient.CopyBlob(
+			dataDiskVHDContainer, snapshotId+vhdExtension, sourceURL,
+		); err != nil {
+			results[i].Error = errors.Annotatef(
+				err, "creating snapshot %s of volume %s", p.Id, p.Volume.Id(),
+			)
+			continue
+		}
+		results[i].VolumeSnapshot = &storage.VolumeSnapshot{
+			Id:     p.Id,
+			Volume: p.Volume,
+			VolumeSnapshotInfo: storage.VolumeSnapshotInfo{
+				SnapshotId: snapshotId,
+				Size:       size,
+			},
+		}
+	}
+	return results, nil
+}
+
+// ResizeVolumes is specified on the storage.VolumeResizer interface.
+//
+// Azure data disks can only be resized by updating the virtual machine
+// they are attached to, so each volume must be attached. Azure will
+// refuse to resize a disk attached to a running virtual machine; the
+// error is reported against the volume so that the resize may be
+// retried once the machine has been deallocated.
+func (v *azureVolumeSource) ResizeVolumes(params []storage.ResizeVolumeParams) ([]storage.ResizeVolumesResult, error) {
+	results := make([]storage.ResizeVolumesResult, len(params))
+	instanceIds := make([]instance.Id, len(params))
+	var attachedInstanceIds []instance.Id
+	for i, p := range params {
+		if p.Attachment == nil {
+			results[i].Error = errors.NotSupportedf(
+				"resizing unattached volume %s", p.Tag.Id(),
+			)
+			continue
+		}
+		instanceIds[i] = p.Attachment.InstanceId
+		attachedInstanceIds = append(attachedInstanceIds, p.Attachment.InstanceId)
+	}
+	if len(attachedInstanceIds) == 0 {
+		return results, nil
+	}
+	virtualMachines, err := v.virtualMachines(attachedInstanceIds)
+	if err != nil {
+		return nil, errors.Annotate(err, "getting virtual machines")
+	}
+
+	// Update VirtualMachine objects in-memory,
+	// and then perform the updates all at once.
+	changed := make(map[instance.Id]bool, len(virtualMachines))
+	for i, p := range params {
+		if results[i].Error != nil {
+			continue
+		}
+		vm, ok := virtualMachines[p.Attachment.InstanceId]
+		if !ok {
+			continue
+		}
+		if vm.err != nil {
+			results[i].Error = vm.err
+			continue
+		}
+		size, updated, err := v.resizeVolume(vm.vm, p)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "resizing volume %s", p.Tag.Id())
+			continue
+		}
+		results[i].Size = size
+		if updated {
+			changed[p.Attachment.InstanceId] = true
+		}
+	}
+	for _, instanceId := range instanceIds {
+		if !changed[instanceId] {
+			delete(virtualMachines, instanceId)
+		}
+	}
+
+	updateResults, err := v.updateVirtualMachines(virtualMachines, instanceIds)
+	if err != nil {
+		return nil, errors.Annotate(err, "updating virtual machines")
+	}
+	for i, err := range updateResults {
+		if results[i].Error != nil || err == nil {
+			continue
+		}
+		results[i] = storage.ResizeVolumesResult{Error: err}
+	}
+	return results, nil
+}
+
+func (v *azureVolumeSource) resizeVolume(
+	vm *compute.VirtualMachine,
+	p storage.ResizeVolumeParams,
+) (size uint64, updated bool, _ error) {
+	sizeInGib := mibToGib(p.Size)
+	if sizeInGib > volumeSizeMaxGiB {
+		return 0, false, errors.Errorf(
+			"%d GiB exceeds the maximum of %d GiB",
+			sizeInGib, volumeSizeMaxGiB,
+		)
+	}
+	var dataDisks []compute.DataDisk
+	if vm.Properties.StorageProfile.DataDisks != nil {
+		dataDisks = *vm.Properties.StorageProfile.DataDisks
+	}
+	for i, disk := range dataDisks {
+		if to.String(disk.Name) != p.VolumeId {
+			continue
+		}
+		currentSize := uint64(to.Int32(disk.DiskSizeGB))
+		if sizeInGib < currentSize {
+			return 0, false, errors.Errorf(
+				"cannot shrink volume from %d GiB to %d GiB",
+				currentSize, sizeInGib,
+			)
+		}
+		if sizeInGib == currentSize {
+			return gibToMib(currentSize), false, nil
+		}
+		dataDisks[i].DiskSizeGB = to.Int32Ptr(int32(sizeInGib))
+		return gibToMib(sizeInGib), true, nil
+	}
+	return 0, false, errors.NotFoundf(
+		"volume %s attached to instance %s", p.VolumeId, p.Attachment.InstanceId,
+	)
+}
+
 type maybeVirtualMachine struct {
 	vm  *compute.VirtualMachine
 	err error
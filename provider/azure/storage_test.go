@@ -377,6 +377,107 @@ func (s *storageSuite) TestDescribeVolumes(c *gc.C) {
 	c.Assert(results[3].Error, gc.ErrorMatches, "volume-42 not found")
 }
 
+func (s *storageSuite) TestCreateVolumeSnapshots(c *gc.C) {
+	s.storageClient.ListBlobsFunc = func(
+		container string,
+		params azurestorage.ListBlobsParameters,
+	) (azurestorage.BlobListResponse, error) {
+		return azurestorage.BlobListResponse{
+			Blobs: []azurestorage.Blob{{
+				Name: "volume-0.vhd",
+				Properties: azurestorage.BlobProperties{
+					ContentLength: 1024 * 1024 * 1024, // 1GiB
+				},
+			}},
+		}, nil
+	}
+
+	volumeSource := s.volumeSource(c)
+	s.sender = azuretesting.Senders{
+		s.accountsSender(),
+		s.accountKeysSender(),
+	}
+	results, err := volumeSource.(storage.VolumeSnapshotter).CreateVolumeSnapshots([]storage.VolumeSnapshotParams{{
+		Id:       "0",
+		Volume:   names.NewVolumeTag("0"),
+		VolumeId: "volume-0",
+	}, {
+		Id:       "1",
+		Volume:   names.NewVolumeTag("42"),
+		VolumeId: "volume-42",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0], jc.DeepEquals, storage.CreateVolumeSnapshotsResult{
+		VolumeSnapshot: &storage.VolumeSnapshot{
+			Id:     "0",
+			Volume: names.NewVolumeTag("0"),
+			VolumeSnapshotInfo: storage.VolumeSnapshotInfo{
+				SnapshotId: "snapshot-0",
+				Size:       1024,
+			},
+		},
+	})
+	c.Assert(results[1].Error, gc.ErrorMatches, "volume volume-42 not found")
+	s.storageClient.CheckCallNames(c, "NewClient", "ListBlobs", "NewClient", "CopyBlob")
+	s.storageClient.CheckCall(
+		c, 3, "CopyBlob", "datavhds", "snapshot-0.vhd",
+		"https://blob.storage/datavhds/volume-0.vhd",
+	)
+}
+
+func (s *storageSuite) TestCreateVolumeSnapshotsExisting(c *gc.C) {
+	s.storageClient.ListBlobsFunc = func(
+		container string,
+		params azurestorage.ListBlobsParameters,
+	) (azurestorage.BlobListResponse, error) {
+		return azurestorage.BlobListResponse{
+			Blobs: []azurestorage.Blob{{
+				Name: "volume-0.vhd",
+				Properties: azurestorage.BlobProperties{
+					ContentLength: 1024 * 1024 * 1024, // 1GiB
+				},
+			}, {
+				Name: "snapshot-0.vhd",
+				Properties: azurestorage.BlobProperties{
+					CopyStatus: "success",
+				},
+			}, {
+				Name: "snapshot-1.vhd",
+				Properties: azurestorage.BlobProperties{
+					CopyStatus: "failed",
+				},
+			}},
+		}, nil
+	}
+
+	volumeSource := s.volumeSource(c)
+	s.sender = azuretesting.Senders{
+		s.accountsSender(),
+		s.accountKeysSender(),
+	}
+	results, err := volumeSource.(storage.VolumeSnapshotter).CreateVolumeSnapshots([]storage.VolumeSnapshotParams{{
+		Id:       "0",
+		Volume:   names.NewVolumeTag("0"),
+		VolumeId: "volume-0",
+	}, {
+		Id:       "1",
+		Volume:   names.NewVolumeTag("0"),
+		VolumeId: "volume-0",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].VolumeSnapshot.SnapshotId, gc.Equals, "snapshot-0")
+	c.Assert(results[1].Error, jc.ErrorIsNil)
+	c.Assert(results[1].VolumeSnapshot.SnapshotId, gc.Equals, "snapshot-1")
+	s.storageClient.CheckCallNames(c, "NewClient", "ListBlobs", "NewClient", "CopyBlob")
+	s.storageClient.CheckCall(
+		c, 3, "CopyBlob", "datavhds", "snapshot-1.vhd",
+		"https://blob.storage/datavhds/volume-0.vhd",
+	)
+}
+
 func (s *storageSuite) TestDestroyVolumes(c *gc.C) {
 	volumeSource := s.volumeSource(c)
 	s.sender = azuretesting.Senders{
@@ -631,3 +732,85 @@ func (s *storageSuite) TestDetachVolumes(c *gc.C) {
 	virtualMachines[0].Properties.StorageProfile.DataDisks = &machine0DataDisks
 	assertRequestBody(c, s.requests[3], &virtualMachines[0])
 }
+
+func (s *storageSuite) TestResizeVolumes(c *gc.C) {
+	// machine-0 has two data disks: volume-0 (1 GiB) and volume-1 (2 GiB)
+	machine0DataDisks := []compute.DataDisk{{
+		Lun:        to.Int32Ptr(0),
+		Name:       to.StringPtr("volume-0"),
+		DiskSizeGB: to.Int32Ptr(1),
+	}, {
+		Lun:        to.Int32Ptr(1),
+		Name:       to.StringPtr("volume-1"),
+		DiskSizeGB: to.Int32Ptr(2),
+	}}
+
+	makeParams := func(volume string, sizeInMib uint64) storage.ResizeVolumeParams {
+		return storage.ResizeVolumeParams{
+			Tag:      names.NewVolumeTag(volume),
+			VolumeId: "volume-" + volume,
+			Size:     sizeInMib,
+			Provider: "azure",
+			Attachment: &storage.VolumeAttachmentParams{
+				AttachmentParams: storage.AttachmentParams{
+					Provider:   "azure",
+					Machine:    names.NewMachineTag("0"),
+					InstanceId: "machine-0",
+				},
+				Volume:   names.NewVolumeTag(volume),
+				VolumeId: "volume-" + volume,
+			},
+		}
+	}
+	params := []storage.ResizeVolumeParams{
+		makeParams("0", 4096),
+		makeParams("1", 1024),
+		{Tag: names.NewVolumeTag("2"), VolumeId: "volume-2", Size: 1024},
+	}
+
+	virtualMachines := []compute.VirtualMachine{{
+		Name: to.StringPtr("machine-0"),
+		Properties: &compute.VirtualMachineProperties{
+			StorageProfile: &compute.StorageProfile{DataDisks: &machine0DataDisks},
+		},
+	}}
+
+	// There should be a couple of API calls to list instances,
+	// and one update for the modified instance.
+	nics := []network.Interface{
+		makeNetworkInterface("nic-0", "machine-0"),
+	}
+	nicsSender := azuretesting.NewSenderWithValue(network.InterfaceListResult{
+		Value: &nics,
+	})
+	nicsSender.PathPattern = `.*/Microsoft\.Network/networkInterfaces`
+	virtualMachinesSender := azuretesting.NewSenderWithValue(compute.VirtualMachineListResult{
+		Value: &virtualMachines,
+	})
+	virtualMachinesSender.PathPattern = `.*/Microsoft\.Compute/virtualMachines`
+	updateVirtualMachine0Sender := azuretesting.NewSenderWithValue(&compute.VirtualMachine{})
+	updateVirtualMachine0Sender.PathPattern = `.*/Microsoft\.Compute/virtualMachines/machine-0`
+	volumeSource := s.volumeSource(c)
+	s.sender = azuretesting.Senders{
+		nicsSender,
+		virtualMachinesSender,
+		updateVirtualMachine0Sender,
+	}
+
+	results, err := volumeSource.(storage.VolumeResizer).ResizeVolumes(params)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, len(params))
+
+	c.Check(results[0], jc.DeepEquals, storage.ResizeVolumesResult{Size: 4096})
+	c.Check(results[1].Error, gc.ErrorMatches, "resizing volume 1: cannot shrink volume from 2 GiB to 1 GiB")
+	c.Check(results[2].Error, gc.ErrorMatches, "resizing unattached volume 2 not supported")
+
+	// Validate HTTP request bodies.
+	c.Assert(s.requests, gc.HasLen, 3)
+	c.Assert(s.requests[0].Method, gc.Equals, "GET") // list NICs
+	c.Assert(s.requests[1].Method, gc.Equals, "GET") // list virtual machines
+	c.Assert(s.requests[2].Method, gc.Equals, "PUT") // update machine-0
+
+	machine0DataDisks[0].DiskSizeGB = to.Int32Ptr(4)
+	assertRequestBody(c, s.requests[2], &virtualMachines[0])
+}
@@ -12,6 +12,8 @@ var unsupportedConstraints = []string{
 	constraints.InstanceType,
 	constraints.Tags,
 	constraints.VirtType,
+	constraints.Zones,
+	constraints.PlacementGroup,
 }
 
 // ConstraintsValidator returns a Validator instance which
@@ -6,6 +6,10 @@ package common
 import (
 	"sort"
 
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+
+	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/instance"
 )
@@ -133,6 +137,62 @@ func AvailabilityZoneAllocations(env ZonedEnviron, group []instance.Id) ([]Avail
 
 var internalAvailabilityZoneAllocations = AvailabilityZoneAllocations
 
+// FilterZoneAllocations returns the subset of the specified zone
+// allocations whose zone names satisfy the zones constraint, preserving
+// their order. If the constraints do not specify zones, the allocations
+// are returned unchanged. An error satisfying errors.IsNotFound is
+// returned if no allocation satisfies the constraint.
+func FilterZoneAllocations(
+	zoneInstances []AvailabilityZoneInstances, cons constraints.Value,
+) ([]AvailabilityZoneInstances, error) {
+	if !cons.HasZones() {
+		return zoneInstances, nil
+	}
+	allowed := set.NewStrings(*cons.Zones...)
+	var result []AvailabilityZoneInstances
+	for _, zi := range zoneInstances {
+		if allowed.Contains(zi.ZoneName) {
+			result = append(result, zi)
+		}
+	}
+	if len(result) == 0 {
+		return nil, errors.NotFoundf(
+			"available zone matching constraint zones=%v", allowed.SortedValues(),
+		)
+	}
+	return result, nil
+}
+
+// ValidateZoneConstraint returns an error if the specified zone is not
+// permitted by the zones constraint. An empty zone is always permitted.
+func ValidateZoneConstraint(zone string, cons constraints.Value) error {
+	if zone == "" || !cons.HasZones() {
+		return nil
+	}
+	for _, z := range *cons.Zones {
+		if z == zone {
+			return nil
+		}
+	}
+	return errors.Errorf(
+		"placement zone %q does not satisfy constraint zones=%v", zone, *cons.Zones,
+	)
+}
+
+// AvailabilityZoneNames returns the names of all availability zones in
+// the environment, for use as a constraints vocabulary.
+func AvailabilityZoneNames(env ZonedEnviron) ([]string, error) {
+	zones, err := env.AvailabilityZones()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	names := make([]string, len(zones))
+	for i, zone := range zones {
+		names[i] = zone.Name()
+	}
+	return names, nil
+}
+
 // DistributeInstances is a common function for implement the
 // state.InstanceDistributor policy based on availability zone
 // spread.
@@ -6,9 +6,11 @@ package common_test
 import (
 	"fmt"
 
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/provider/common"
@@ -254,3 +256,43 @@ func (s *AvailabilityZoneSuite) TestDistributeInstances(c *gc.C) {
 		c.Assert(eligible, jc.SameContents, test.eligible)
 	}
 }
+
+func (s *AvailabilityZoneSuite) TestFilterZoneAllocationsNoConstraint(c *gc.C) {
+	zoneInstances := []common.AvailabilityZoneInstances{{ZoneName: "az1"}, {ZoneName: "az2"}}
+	filtered, err := common.FilterZoneAllocations(zoneInstances, constraints.Value{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(filtered, jc.DeepEquals, zoneInstances)
+}
+
+func (s *AvailabilityZoneSuite) TestFilterZoneAllocations(c *gc.C) {
+	zoneInstances := []common.AvailabilityZoneInstances{
+		{ZoneName: "az1"}, {ZoneName: "az2"}, {ZoneName: "az3"},
+	}
+	filtered, err := common.FilterZoneAllocations(zoneInstances, constraints.MustParse("zones=az3,az1"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(filtered, jc.DeepEquals, []common.AvailabilityZoneInstances{
+		{ZoneName: "az1"}, {ZoneName: "az3"},
+	})
+}
+
+func (s *AvailabilityZoneSuite) TestFilterZoneAllocationsNoMatch(c *gc.C) {
+	zoneInstances := []common.AvailabilityZoneInstances{{ZoneName: "az1"}}
+	_, err := common.FilterZoneAllocations(zoneInstances, constraints.MustParse("zones=az9"))
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, `available zone matching constraint zones=\[az9\] not found`)
+}
+
+func (s *AvailabilityZoneSuite) TestValidateZoneConstraint(c *gc.C) {
+	cons := constraints.MustParse("zones=az1,az2")
+	c.Assert(common.ValidateZoneConstraint("", cons), jc.ErrorIsNil)
+	c.Assert(common.ValidateZoneConstraint("az2", cons), jc.ErrorIsNil)
+	c.Assert(common.ValidateZoneConstraint("az3", constraints.Value{}), jc.ErrorIsNil)
+	err := common.ValidateZoneConstraint("az3", cons)
+	c.Assert(err, gc.ErrorMatches, `placement zone "az3" does not satisfy constraint zones=\[az1 az2\]`)
+}
+
+func (s *AvailabilityZoneSuite) TestAvailabilityZoneNames(c *gc.C) {
+	names, err := common.AvailabilityZoneNames(&s.env)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(names, jc.DeepEquals, []string{"az0", "az1", "az2"})
+}
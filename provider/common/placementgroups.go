@@ -0,0 +1,56 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/tags"
+)
+
+// PlacementGroupName returns the name of the provider placement group
+// for the given application in the model with the given UUID.
+func PlacementGroupName(modelUUID, application string) string {
+	return fmt.Sprintf("juju-%s-%s", modelUUID, application)
+}
+
+// PlacementGroupPrefix returns the prefix of the names of all provider
+// placement groups created for applications in the model with the
+// given UUID.
+func PlacementGroupPrefix(modelUUID string) string {
+	return PlacementGroupName(modelUUID, "")
+}
+
+// InstancePlacementGroup returns the name of the placement group that
+// the instance being started with the given parameters should be placed
+// in, and the placement group strategy. Placement groups are created
+// per application, so the empty string is returned if the constraints
+// do not specify a placement group, or no application's units are
+// deployed to the machine.
+func InstancePlacementGroup(modelUUID string, args environs.StartInstanceParams) (name, strategy string) {
+	if !args.Constraints.HasPlacementGroup() || args.InstanceConfig == nil {
+		return "", ""
+	}
+	for _, unitName := range strings.Fields(args.InstanceConfig.Tags[tags.JujuUnitsDeployed]) {
+		if !names.IsValidUnit(unitName) {
+			continue
+		}
+		application, err := names.UnitApplication(unitName)
+		if err != nil {
+			continue
+		}
+		return PlacementGroupName(modelUUID, application), *args.Constraints.PlacementGroup
+	}
+	logger.Warningf(
+		"ignoring constraint %s=%s: no units are assigned to machine %s",
+		constraints.PlacementGroup, *args.Constraints.PlacementGroup,
+		args.InstanceConfig.MachineId,
+	)
+	return "", ""
+}
@@ -0,0 +1,60 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common_test
+
+import (
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cloudconfig/instancecfg"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/provider/common"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type PlacementGroupSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&PlacementGroupSuite{})
+
+const placementGroupModelUUID = "deadbeef-0bad-400d-8000-4b1d0d06f00d"
+
+func (*PlacementGroupSuite) startInstanceParams(cons, units string) environs.StartInstanceParams {
+	return environs.StartInstanceParams{
+		Constraints: constraints.MustParse(cons),
+		InstanceConfig: &instancecfg.InstanceConfig{
+			MachineId: "0",
+			Tags:      map[string]string{tags.JujuUnitsDeployed: units},
+		},
+	}
+}
+
+func (s *PlacementGroupSuite) TestInstancePlacementGroup(c *gc.C) {
+	args := s.startInstanceParams("placement-group=spread", "cassandra/0 cassandra/1")
+	name, strategy := common.InstancePlacementGroup(placementGroupModelUUID, args)
+	c.Assert(name, gc.Equals, "juju-"+placementGroupModelUUID+"-cassandra")
+	c.Assert(strategy, gc.Equals, "spread")
+}
+
+func (s *PlacementGroupSuite) TestInstancePlacementGroupNoConstraint(c *gc.C) {
+	args := s.startInstanceParams("mem=4G", "cassandra/0")
+	name, strategy := common.InstancePlacementGroup(placementGroupModelUUID, args)
+	c.Assert(name, gc.Equals, "")
+	c.Assert(strategy, gc.Equals, "")
+}
+
+func (s *PlacementGroupSuite) TestInstancePlacementGroupNoUnits(c *gc.C) {
+	args := s.startInstanceParams("placement-group=cluster", "")
+	name, strategy := common.InstancePlacementGroup(placementGroupModelUUID, args)
+	c.Assert(name, gc.Equals, "")
+	c.Assert(strategy, gc.Equals, "")
+}
+
+func (s *PlacementGroupSuite) TestPlacementGroupPrefix(c *gc.C) {
+	prefix := common.PlacementGroupPrefix(placementGroupModelUUID)
+	c.Assert(prefix, gc.Equals, "juju-"+placementGroupModelUUID+"-")
+	c.Assert(common.PlacementGroupName(placementGroupModelUUID, "kafka"), gc.Equals, prefix+"kafka")
+}
@@ -5,6 +5,7 @@ package ec2
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/juju/schema"
 	"gopkg.in/juju/environschema.v1"
@@ -26,6 +27,12 @@ var configSchema = environschema.Fields{
 		Group:       environschema.AccountGroup,
 		Immutable:   true,
 	},
+	"spot-max-price": {
+		Description: "Run spot instances, bidding at most this price in USD per instance hour (optional). On-demand instances are run when spot capacity is not available at that price.",
+		Example:     "0.05",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
 }
 
 var configFields = func() schema.Fields {
@@ -37,8 +44,9 @@ var configFields = func() schema.Fields {
 }()
 
 var configDefaults = schema.Defaults{
-	"vpc-id":       "",
-	"vpc-id-force": false,
+	"vpc-id":         "",
+	"vpc-id-force":   false,
+	"spot-max-price": "",
 }
 
 type environConfig struct {
@@ -54,6 +62,10 @@ func (c *environConfig) forceVPCID() bool {
 	return c.attrs["vpc-id-force"].(bool)
 }
 
+func (c *environConfig) spotMaxPrice() string {
+	return c.attrs["spot-max-price"].(string)
+}
+
 func (p environProvider) newConfig(cfg *config.Config) (*environConfig, error) {
 	valid, err := p.Validate(cfg, nil)
 	if err != nil {
@@ -100,6 +112,12 @@ func validateConfig(cfg, old *config.Config) (*environConfig, error) {
 		return nil, fmt.Errorf("cannot use vpc-id-force without specifying vpc-id as well")
 	}
 
+	if price := ecfg.spotMaxPrice(); price != "" {
+		if value, err := strconv.ParseFloat(price, 64); err != nil || value <= 0 {
+			return nil, fmt.Errorf("spot-max-price: %q is not a valid price", price)
+		}
+	}
+
 	if old != nil {
 		attrs := old.UnknownAttrs()
 
@@ -248,6 +248,33 @@ var configTests = []configTest{
 		change:     attrs{},
 		vpcID:      "vpc-foo",
 		forceVPCID: true,
+	}, {
+		config: attrs{
+			"spot-max-price": "0.05",
+		},
+		expect: attrs{
+			"spot-max-price": "0.05",
+		},
+	}, {
+		config: attrs{
+			"spot-max-price": "cheap",
+		},
+		err: `.*spot-max-price: "cheap" is not a valid price`,
+	}, {
+		config: attrs{
+			"spot-max-price": "0",
+		},
+		err: `.*spot-max-price: "0" is not a valid price`,
+	}, {
+		config: attrs{
+			"spot-max-price": "0.05",
+		},
+		change: attrs{
+			"spot-max-price": "",
+		},
+		expect: attrs{
+			"spot-max-price": "",
+		},
 	}, {
 		config:       attrs{},
 		firewallMode: config.FwInstance,
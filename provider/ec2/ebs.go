@@ -4,6 +4,7 @@
 package ec2
 
 import (
+	"fmt"
 	"regexp"
 	"sync"
 	"time"
@@ -40,6 +41,11 @@ const (
 	// Specifies whether the volume should be encrypted.
 	EBS_Encrypted = "encrypted"
 
+	// The ARN or ID of the AWS KMS key used to encrypt the
+	// volume. Only valid for encrypted volumes; if unspecified,
+	// the account's default EBS key is used.
+	EBS_KMSKeyId = "kms-key-id"
+
 	volumeTypeMagnetic        = "magnetic"         // standard
 	volumeTypeSsd             = "ssd"              // gp2
 	volumeTypeProvisionedIops = "provisioned-iops" // io1
@@ -145,6 +151,7 @@ var ebsConfigFields = schema.Fields{
 	),
 	EBS_IOPS:      schema.ForceInt(),
 	EBS_Encrypted: schema.Bool(),
+	EBS_KMSKeyId:  schema.String(),
 }
 
 var ebsConfigChecker = schema.FieldMap(
@@ -153,6 +160,7 @@ var ebsConfigChecker = schema.FieldMap(
 		EBS_VolumeType: volumeTypeMagnetic,
 		EBS_IOPS:       schema.Omit,
 		EBS_Encrypted:  false,
+		EBS_KMSKeyId:   schema.Omit,
 	},
 )
 
@@ -160,6 +168,7 @@ type ebsConfig struct {
 	volumeType string
 	iops       int
 	encrypted  bool
+	kmsKeyId   string
 }
 
 func newEbsConfig(attrs map[string]interface{}) (*ebsConfig, error) {
@@ -169,11 +178,13 @@ func newEbsConfig(attrs map[string]interface{}) (*ebsConfig, error) {
 	}
 	coerced := out.(map[string]interface{})
 	iops, _ := coerced[EBS_IOPS].(int)
+	kmsKeyId, _ := coerced[EBS_KMSKeyId].(string)
 	volumeType := coerced[EBS_VolumeType].(string)
 	ebsConfig := &ebsConfig{
 		volumeType: volumeType,
 		iops:       iops,
 		encrypted:  coerced[EBS_Encrypted].(bool),
+		kmsKeyId:   kmsKeyId,
 	}
 	switch ebsConfig.volumeType {
 	case volumeTypeMagnetic:
@@ -188,6 +199,9 @@ func newEbsConfig(attrs map[string]interface{}) (*ebsConfig, error) {
 	} else if ebsConfig.iops == 0 && ebsConfig.volumeType == volumeTypeIo1 {
 		return nil, errors.Errorf("volume type is %q, IOPS unspecified or zero", volumeTypeIo1)
 	}
+	if ebsConfig.kmsKeyId != "" && !ebsConfig.encrypted {
+		return nil, errors.Errorf("KMS key specified, but volume is not encrypted")
+	}
 	return ebsConfig, nil
 }
 
@@ -197,6 +211,12 @@ func (e *ebsProvider) ValidateConfig(cfg *storage.Config) error {
 	return errors.Trace(err)
 }
 
+// SupportsVolumeEncryption is defined on the VolumeEncryptionProvider
+// interface.
+func (e *ebsProvider) SupportsVolumeEncryption() bool {
+	return true
+}
+
 // Supports is defined on the Provider interface.
 func (e *ebsProvider) Supports(k storage.StorageKind) bool {
 	return k == storage.StorageKindBlock
@@ -243,15 +263,17 @@ type ebsVolumeSource struct {
 }
 
 var _ storage.VolumeSource = (*ebsVolumeSource)(nil)
+var _ storage.VolumeResizer = (*ebsVolumeSource)(nil)
 
-// parseVolumeOptions uses storage volume parameters to make a struct used to create volumes.
-func parseVolumeOptions(size uint64, attrs map[string]interface{}) (_ ec2.CreateVolume, _ error) {
+// parseVolumeOptions uses storage volume parameters to make a struct used
+// to create volumes, and returns the KMS key to encrypt the volume with.
+func parseVolumeOptions(size uint64, attrs map[string]interface{}) (_ ec2.CreateVolume, kmsKeyId string, _ error) {
 	ebsConfig, err := newEbsConfig(attrs)
 	if err != nil {
-		return ec2.CreateVolume{}, errors.Trace(err)
+		return ec2.CreateVolume{}, "", errors.Trace(err)
 	}
 	if ebsConfig.iops > maxProvisionedIopsSizeRatio {
-		return ec2.CreateVolume{}, errors.Errorf(
+		return ec2.CreateVolume{}, "", errors.Errorf(
 			"specified IOPS ratio is %d/GiB, maximum is %d/GiB",
 			ebsConfig.iops, maxProvisionedIopsSizeRatio,
 		)
@@ -269,7 +291,7 @@ func parseVolumeOptions(size uint64, attrs map[string]interface{}) (_ ec2.Create
 		Encrypted:  ebsConfig.encrypted,
 		IOPS:       int64(iops),
 	}
-	return vol, nil
+	return vol, ebsConfig.kmsKeyId, nil
 }
 
 // CreateVolumes is specified on the storage.VolumeSource interface.
@@ -338,13 +360,24 @@ func (v *ebsVolumeSource) createVolume(p storage.VolumeParams, instances instanc
 		// because we need to know what its AZ is.
 		return nil, nil, errors.Trace(err)
 	}
-	vol, _ := parseVolumeOptions(p.Size, p.Attributes)
+	vol, kmsKeyId, _ := parseVolumeOptions(p.Size, p.Attributes)
 	vol.AvailZone = inst.AvailZone
-	resp, err := v.env.ec2.CreateVolume(vol)
+	vol.SnapshotId = p.SnapshotId
+	var size int
+	if kmsKeyId != "" {
+		// The EC2 client library does not support specifying
+		// the KMS key, so we make the request ourselves.
+		volumeId, size, err = v.env.query.createEncryptedVolume(vol, kmsKeyId)
+	} else {
+		resp, createErr := v.env.ec2.CreateVolume(vol)
+		if createErr == nil {
+			volumeId, size = resp.Id, resp.Size
+		}
+		err = createErr
+	}
 	if err != nil {
 		return nil, nil, errors.Trace(err)
 	}
-	volumeId = resp.Id
 
 	// Tag.
 	resourceTags := make(map[string]string)
@@ -360,7 +393,7 @@ func (v *ebsVolumeSource) createVolume(p storage.VolumeParams, instances instanc
 		p.Tag,
 		storage.VolumeInfo{
 			VolumeId:   volumeId,
-			Size:       gibToMib(uint64(resp.Size)),
+			Size:       gibToMib(uint64(size)),
 			Persistent: true,
 		},
 	}
@@ -570,7 +603,7 @@ func destroyVolume(client *ec2.EC2, volumeId string) (err error) {
 
 // ValidateVolumeParams is specified on the storage.VolumeSource interface.
 func (v *ebsVolumeSource) ValidateVolumeParams(params storage.VolumeParams) error {
-	vol, err := parseVolumeOptions(params.Size, params.Attributes)
+	vol, _, err := parseVolumeOptions(params.Size, params.Attributes)
 	if err != nil {
 		return err
 	}
@@ -601,6 +634,133 @@ func (v *ebsVolumeSource) ValidateVolumeParams(params storage.VolumeParams) erro
 	return nil
 }
 
+// ResizeVolumes is specified on the storage.VolumeResizer interface.
+func (v *ebsVolumeSource) ResizeVolumes(params []storage.ResizeVolumeParams) ([]storage.ResizeVolumesResult, error) {
+	results := make([]storage.ResizeVolumesResult, len(params))
+	for i, p := range params {
+		size, err := v.resizeVolume(p)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "resizing volume %s", p.Tag.Id())
+			continue
+		}
+		results[i].Size = size
+	}
+	return results, nil
+}
+
+func (v *ebsVolumeSource) resizeVolume(p storage.ResizeVolumeParams) (uint64, error) {
+	if err := v.ValidateVolumeParams(storage.VolumeParams{
+		Tag:        p.Tag,
+		Size:       p.Size,
+		Provider:   p.Provider,
+		Attributes: p.Attributes,
+	}); err != nil {
+		return 0, errors.Trace(err)
+	}
+	resp, err := v.env.ec2.Volumes([]string{p.VolumeId}, nil)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	if len(resp.Volumes) != 1 {
+		return 0, errors.NotFoundf("volume %q", p.VolumeId)
+	}
+	currentSize := uint64(resp.Volumes[0].Size)
+	sizeInGib := mibToGib(p.Size)
+	if sizeInGib < currentSize {
+		return 0, errors.Errorf("cannot shrink volume from %d GiB to %d GiB", currentSize, sizeInGib)
+	} else if sizeInGib == currentSize {
+		return gibToMib(currentSize), nil
+	}
+	// The EC2 client library does not support modifying
+	// volumes, so we make the request ourselves.
+	targetSize, err := v.env.query.modifyVolumeSize(p.VolumeId, int(sizeInGib))
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	return gibToMib(uint64(targetSize)), nil
+}
+
+// CreateVolumeSnapshots is specified on the storage.VolumeSnapshotter
+// interface.
+func (v *ebsVolumeSource) CreateVolumeSnapshots(params []storage.VolumeSnapshotParams) ([]storage.CreateVolumeSnapshotsResult, error) {
+	results := make([]storage.CreateVolumeSnapshotsResult, len(params))
+	for i, p := range params {
+		snapshot, err := v.createVolumeSnapshot(p)
+		if err != nil {
+			results[i].Error = errors.Annotatef(
+				err, "creating snapshot %s of volume %s", p.Id, p.Volume.Id(),
+			)
+			continue
+		}
+		results[i].VolumeSnapshot = snapshot
+	}
+	return results, nil
+}
+
+func (v *ebsVolumeSource) createVolumeSnapshot(p storage.VolumeSnapshotParams) (*storage.VolumeSnapshot, error) {
+	resp, err := v.env.ec2.Volumes([]string{p.VolumeId}, nil)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(resp.Volumes) != 1 {
+		return nil, errors.NotFoundf("volume %q", p.VolumeId)
+	}
+	size := uint64(resp.Volumes[0].Size)
+
+	// The description identifies the Juju snapshot, and is set
+	// atomically with the snapshot's creation; a snapshot taken
+	// previously for the same request is reused, rather than
+	// taking another.
+	description := fmt.Sprintf("snapshot %s of %s", p.Id, resourceName(p.Volume, v.envName))
+	snapshotId, err := v.findVolumeSnapshot(p.VolumeId, description)
+	if errors.IsNotFound(err) {
+		snapshotResp, err := v.env.ec2.CreateSnapshot(p.VolumeId, description)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		snapshotId = snapshotResp.Snapshot.Id
+	} else if err != nil {
+		return nil, errors.Annotate(err, "finding existing snapshot")
+	} else {
+		logger.Debugf("found existing snapshot %q for snapshot request %s", snapshotId, p.Id)
+	}
+
+	resourceTags := make(map[string]string)
+	for k, v := range p.ResourceTags {
+		resourceTags[k] = v
+	}
+	resourceTags[tagName] = resourceName(p.Volume, v.envName)
+	if err := tagResources(v.env.ec2, resourceTags, snapshotId); err != nil {
+		return nil, errors.Annotate(err, "tagging snapshot")
+	}
+	return &storage.VolumeSnapshot{
+		Id:     p.Id,
+		Volume: p.Volume,
+		VolumeSnapshotInfo: storage.VolumeSnapshotInfo{
+			SnapshotId: snapshotId,
+			Size:       gibToMib(size),
+		},
+	}, nil
+}
+
+// findVolumeSnapshot returns the ID of a snapshot of the specified
+// volume with the given description, which is not in an error state.
+func (v *ebsVolumeSource) findVolumeSnapshot(volumeId, description string) (string, error) {
+	filter := ec2.NewFilter()
+	filter.Add("volume-id", volumeId)
+	filter.Add("description", description)
+	resp, err := v.env.ec2.Snapshots(nil, filter)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	for _, snapshot := range resp.Snapshots {
+		if snapshot.Status != "error" {
+			return snapshot.Id, nil
+		}
+	}
+	return "", errors.NotFoundf("snapshot %q of volume %q", description, volumeId)
+}
+
 // AttachVolumes is specified on the storage.VolumeSource interface.
 func (v *ebsVolumeSource) AttachVolumes(attachParams []storage.VolumeAttachmentParams) ([]storage.AttachVolumesResult, error) {
 	// We need the virtualisation types for each instance we are
@@ -112,12 +112,40 @@ func (s *ebsSuite) TestValidateConfigUnknownConfig(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil) // unknown attrs ignored
 }
 
+func (s *ebsSuite) TestValidateConfigKMSKeyIdWithoutEncrypted(c *gc.C) {
+	p := s.ebsProvider(c)
+	cfg, err := storage.NewConfig("foo", ec2.EBS_ProviderType, map[string]interface{}{
+		"kms-key-id": "alias/juju",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = p.ValidateConfig(cfg)
+	c.Assert(err, gc.ErrorMatches, "KMS key specified, but volume is not encrypted")
+}
+
+func (s *ebsSuite) TestValidateConfigEncryptedKMSKeyId(c *gc.C) {
+	p := s.ebsProvider(c)
+	cfg, err := storage.NewConfig("foo", ec2.EBS_ProviderType, map[string]interface{}{
+		"encrypted":  true,
+		"kms-key-id": "alias/juju",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = p.ValidateConfig(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *ebsSuite) TestSupports(c *gc.C) {
 	p := s.ebsProvider(c)
 	c.Assert(p.Supports(storage.StorageKindBlock), jc.IsTrue)
 	c.Assert(p.Supports(storage.StorageKindFilesystem), jc.IsFalse)
 }
 
+func (s *ebsSuite) TestSupportsVolumeEncryption(c *gc.C) {
+	p := s.ebsProvider(c)
+	encrypter, ok := p.(storage.VolumeEncryptionProvider)
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(encrypter.SupportsVolumeEncryption(), jc.IsTrue)
+}
+
 func (s *ebsSuite) volumeSource(c *gc.C, cfg *storage.Config) storage.VolumeSource {
 	p := s.ebsProvider(c)
 	vs, err := p.VolumeSource(cfg)
@@ -344,6 +372,81 @@ func (s *ebsSuite) TestVolumeTypeAliases(c *gc.C) {
 	}
 }
 
+func (s *ebsSuite) TestCreateVolumesKMSKeyId(c *gc.C) {
+	instanceIdRunning := s.srv.ec2srv.NewInstances(1, "m1.medium", imageId, ec2test.Running, nil)[0]
+	vs := s.volumeSource(c, nil)
+	results, err := vs.CreateVolumes([]storage.VolumeParams{{
+		Tag:      names.NewVolumeTag("0"),
+		Size:     10 * 1000,
+		Provider: ec2.EBS_ProviderType,
+		Attributes: map[string]interface{}{
+			"encrypted":  true,
+			"kms-key-id": "alias/juju",
+		},
+		Attachment: &storage.VolumeAttachmentParams{
+			AttachmentParams: storage.AttachmentParams{
+				InstanceId: instance.Id(instanceIdRunning),
+			},
+		},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].Volume.VolumeId, gc.Equals, "vol-0")
+
+	ec2Vols, err := ec2.StorageEC2(vs).Volumes(nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ec2Vols.Volumes, gc.HasLen, 1)
+	c.Assert(ec2Vols.Volumes[0].Encrypted, jc.IsTrue)
+}
+
+func (s *ebsSuite) TestCreateVolumesFromSnapshot(c *gc.C) {
+	instanceIdRunning := s.srv.ec2srv.NewInstances(1, "m1.medium", imageId, ec2test.Running, nil)[0]
+	vs := s.volumeSource(c, nil)
+	results, err := vs.CreateVolumes([]storage.VolumeParams{{
+		Tag:        names.NewVolumeTag("0"),
+		Size:       10 * 1000,
+		Provider:   ec2.EBS_ProviderType,
+		SnapshotId: "snap-0",
+		Attachment: &storage.VolumeAttachmentParams{
+			AttachmentParams: storage.AttachmentParams{
+				InstanceId: instance.Id(instanceIdRunning),
+			},
+		},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+
+	ec2Vols, err := ec2.StorageEC2(vs).Volumes(nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ec2Vols.Volumes, gc.HasLen, 1)
+	c.Assert(ec2Vols.Volumes[0].SnapshotId, gc.Equals, "snap-0")
+}
+
+func (s *ebsSuite) TestResizeVolumes(c *gc.C) {
+	vs := s.volumeSource(c, nil)
+	s.assertCreateVolumes(c, vs, "")
+
+	results, err := vs.(storage.VolumeResizer).ResizeVolumes([]storage.ResizeVolumeParams{{
+		// Resizing to the current size is a no-op.
+		Tag:      names.NewVolumeTag("0"),
+		VolumeId: "vol-0",
+		Size:     10 * 1024,
+		Provider: ec2.EBS_ProviderType,
+	}, {
+		Tag:      names.NewVolumeTag("1"),
+		VolumeId: "vol-1",
+		Size:     1024,
+		Provider: ec2.EBS_ProviderType,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].Size, gc.Equals, uint64(10*1024))
+	c.Assert(results[1].Error, gc.ErrorMatches, "resizing volume 1: cannot shrink volume from 20 GiB to 1 GiB")
+}
+
 func (s *ebsSuite) TestDestroyVolumesNotFoundReturnsNil(c *gc.C) {
 	vs := s.volumeSource(c, nil)
 	results, err := vs.DestroyVolumes([]string{"vol-42"})
@@ -59,6 +59,7 @@ type environ struct {
 	cloud environs.CloudSpec
 	ec2   *ec2.EC2
 	s3    *s3.S3
+	query *queryClient
 
 	// ecfgMutex protects the *Unlocked fields below.
 	ecfgMutex    sync.Mutex
@@ -161,6 +162,15 @@ func (e *environ) ConstraintsValidator() (constraints.Validator, error) {
 		instTypeNames[i] = itype.Name
 	}
 	validator.RegisterVocabulary(constraints.InstanceType, instTypeNames)
+	validator.RegisterVocabulary(constraints.PlacementGroup, []string{
+		constraints.PlacementGroupSpread,
+		constraints.PlacementGroupCluster,
+	})
+	zoneNames, err := common.AvailabilityZoneNames(e)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	validator.RegisterVocabulary(constraints.Zones, zoneNames)
 	return validator, nil
 }
 
@@ -259,7 +269,11 @@ func (e *environ) parsePlacement(placement string) (*ec2Placement, error) {
 // PrecheckInstance is defined on the state.Prechecker interface.
 func (e *environ) PrecheckInstance(series string, cons constraints.Value, placement string) error {
 	if placement != "" {
-		if _, err := e.parsePlacement(placement); err != nil {
+		p, err := e.parsePlacement(placement)
+		if err != nil {
+			return err
+		}
+		if err := common.ValidateZoneConstraint(p.availabilityZone.Name, cons); err != nil {
 			return err
 		}
 	}
@@ -360,6 +374,9 @@ func (e *environ) StartInstance(args environs.StartInstanceParams) (_ *environs.
 		if placement.availabilityZone.State != availableState {
 			return nil, errors.Errorf("availability zone %q is %s", placement.availabilityZone.Name, placement.availabilityZone.State)
 		}
+		if err := common.ValidateZoneConstraint(placement.availabilityZone.Name, args.Constraints); err != nil {
+			return nil, errors.Trace(err)
+		}
 		availabilityZones = append(availabilityZones, placement.availabilityZone.Name)
 	}
 
@@ -380,6 +397,10 @@ func (e *environ) StartInstance(args environs.StartInstanceParams) (_ *environs.
 		if err != nil {
 			return nil, err
 		}
+		zoneInstances, err = common.FilterZoneAllocations(zoneInstances, args.Constraints)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
 		for _, z := range zoneInstances {
 			availabilityZones = append(availabilityZones, z.ZoneName)
 		}
@@ -449,6 +470,7 @@ func (e *environ) StartInstance(args environs.StartInstanceParams) (_ *environs.
 	}
 
 	var instResp *ec2.RunInstancesResp
+	var spot bool
 	commonRunArgs := &ec2.RunInstances{
 		MinCount:            1,
 		MaxCount:            1,
@@ -459,6 +481,16 @@ func (e *environ) StartInstance(args environs.StartInstanceParams) (_ *environs.
 		ImageId:             spec.Image.Id,
 	}
 
+	// If the application's machines are constrained to a placement
+	// group, start the instance in the application's group.
+	if groupName, strategy := common.InstancePlacementGroup(e.uuid(), args); groupName != "" {
+		if err := ensurePlacementGroup(e, groupName, strategy); err != nil {
+			return nil, errors.Trace(err)
+		}
+		commonRunArgs.PlacementGroupName = groupName
+		args.InstanceConfig.Tags[tags.JujuPlacementGroup] = groupName
+	}
+
 	haveVPCID := isVPCIDSet(e.ecfg().vpcID())
 
 	for _, zone := range availabilityZones {
@@ -499,7 +531,7 @@ func (e *environ) StartInstance(args environs.StartInstanceParams) (_ *environs.
 			logger.Infof("selected subnet %q in zone %q", runArgs.SubnetId, zone)
 		}
 
-		instResp, err = runInstances(e.ec2, runArgs)
+		instResp, spot, err = e.runInstance(runArgs)
 		if err == nil || !isZoneOrSubnetConstrainedError(err) {
 			break
 		}
@@ -532,6 +564,10 @@ func (e *environ) StartInstance(args environs.StartInstanceParams) (_ *environs.
 		names.NewMachineTag(args.InstanceConfig.MachineId), e.Config().Name(),
 	)
 	args.InstanceConfig.Tags[tagName] = instanceName
+	if spot {
+		args.InstanceConfig.Tags[tagLifecycle] = lifecycleSpot
+		inst.Instance.Tags = append(inst.Instance.Tags, ec2.Tag{tagLifecycle, lifecycleSpot})
+	}
 	if err := tagResources(e.ec2, args.InstanceConfig.Tags, string(inst.Id())); err != nil {
 		return nil, errors.Annotate(err, "tagging instance")
 	}
@@ -706,6 +742,7 @@ func (e *environ) Instances(ids []instance.Id) ([]instance.Instance, error) {
 	if err == environs.ErrPartialInstances {
 		for _, inst := range insts {
 			if inst != nil {
+				e.setSpotInterruptions(insts)
 				return insts, environs.ErrPartialInstances
 			}
 		}
@@ -714,6 +751,7 @@ func (e *environ) Instances(ids []instance.Id) ([]instance.Instance, error) {
 	if err != nil {
 		return nil, err
 	}
+	e.setSpotInterruptions(insts)
 	return insts, nil
 }
 
@@ -1023,6 +1061,12 @@ func (e *environ) Destroy() error {
 	if err := e.cleanEnvironmentSecurityGroups(); err != nil {
 		return errors.Annotate(err, "cannot delete environment security groups")
 	}
+	if err := e.deleteModelPlacementGroups(); err != nil {
+		// Placement groups are not supported in all regions, or
+		// may not be permitted by the credential's policy, so a
+		// failure to delete them does not prevent destruction.
+		logger.Warningf("cannot delete placement groups: %v", err)
+	}
 	return nil
 }
 
@@ -1086,36 +1130,49 @@ func (e *environ) allControllerManagedVolumes(controllerUUID string) ([]string,
 }
 
 func portsToIPPerms(ports []network.PortRange) []ec2.IPPerm {
-	ipPerms := make([]ec2.IPPerm, len(ports))
-	for i, p := range ports {
+	return rulesToIPPerms(network.IngressRulesFromPortRanges(ports))
+}
+
+func rulesToIPPerms(rules []network.IngressRule) []ec2.IPPerm {
+	ipPerms := make([]ec2.IPPerm, len(rules))
+	for i, r := range rules {
+		sourceIPs := r.SourceCIDRs
+		if len(sourceIPs) == 0 {
+			sourceIPs = []string{network.AllSourceCIDR}
+		}
 		ipPerms[i] = ec2.IPPerm{
-			Protocol:  p.Protocol,
-			FromPort:  p.FromPort,
-			ToPort:    p.ToPort,
-			SourceIPs: []string{"0.0.0.0/0"},
+			Protocol:  r.Protocol,
+			FromPort:  r.FromPort,
+			ToPort:    r.ToPort,
+			SourceIPs: sourceIPs,
 		}
 	}
 	return ipPerms
 }
 
 func (e *environ) openPortsInGroup(name string, ports []network.PortRange) error {
-	if len(ports) == 0 {
+	return e.openRulesInGroup(name, network.IngressRulesFromPortRanges(ports))
+}
+
+func (e *environ) openRulesInGroup(name string, rules []network.IngressRule) error {
+	if len(rules) == 0 {
 		return nil
 	}
-	// Give permissions for anyone to access the given ports.
+	// Give permissions for the rules' source CIDRs to access the
+	// given ports.
 	g, err := e.groupByName(name)
 	if err != nil {
 		return err
 	}
-	ipPerms := portsToIPPerms(ports)
+	ipPerms := rulesToIPPerms(rules)
 	_, err = e.ec2.AuthorizeSecurityGroup(g, ipPerms)
 	if err != nil && ec2ErrCode(err) == "InvalidPermission.Duplicate" {
-		if len(ports) == 1 {
+		if len(rules) == 1 {
 			return nil
 		}
-		// If there's more than one port and we get a duplicate error,
-		// then we go through authorizing each port individually,
-		// otherwise the ports that were *not* duplicates will have
+		// If there's more than one rule and we get a duplicate error,
+		// then we go through authorizing each rule individually,
+		// otherwise the rules that were *not* duplicates will have
 		// been ignored
 		for i := range ipPerms {
 			_, err := e.ec2.AuthorizeSecurityGroup(g, ipPerms[i:i+1])
@@ -1132,70 +1189,114 @@ func (e *environ) openPortsInGroup(name string, ports []network.PortRange) error
 }
 
 func (e *environ) closePortsInGroup(name string, ports []network.PortRange) error {
-	if len(ports) == 0 {
+	return e.closeRulesInGroup(name, network.IngressRulesFromPortRanges(ports))
+}
+
+func (e *environ) closeRulesInGroup(name string, rules []network.IngressRule) error {
+	if len(rules) == 0 {
 		return nil
 	}
-	// Revoke permissions for anyone to access the given ports.
-	// Note that ec2 allows the revocation of permissions that aren't
-	// granted, so this is naturally idempotent.
+	// Revoke permissions for the rules' source CIDRs to access the
+	// given ports. Note that ec2 allows the revocation of permissions
+	// that aren't granted, so this is naturally idempotent.
 	g, err := e.groupByName(name)
 	if err != nil {
 		return err
 	}
-	_, err = e.ec2.RevokeSecurityGroup(g, portsToIPPerms(ports))
+	_, err = e.ec2.RevokeSecurityGroup(g, rulesToIPPerms(rules))
 	if err != nil {
 		return fmt.Errorf("cannot close ports: %v", err)
 	}
 	return nil
 }
 
+// portsInGroup returns the port ranges open to anywhere in the
+// named group.
 func (e *environ) portsInGroup(name string) (ports []network.PortRange, err error) {
+	rules, err := e.rulesInGroup(name)
+	if err != nil {
+		return nil, err
+	}
+	for _, rule := range rules {
+		if len(rule.SourceCIDRs) != 1 || !rule.OpenToAll() {
+			continue
+		}
+		ports = append(ports, rule.PortRange)
+	}
+	network.SortPortRanges(ports)
+	return ports, nil
+}
+
+func (e *environ) rulesInGroup(name string) (rules []network.IngressRule, err error) {
 	group, err := e.groupInfoByName(name)
 	if err != nil {
 		return nil, err
 	}
 	for _, p := range group.IPPerms {
-		if len(p.SourceIPs) != 1 {
-			logger.Errorf("expected exactly one IP permission, found: %v", p)
+		if len(p.SourceIPs) == 0 {
 			continue
 		}
-		ports = append(ports, network.PortRange{
+		portRange := network.PortRange{
 			Protocol: p.Protocol,
 			FromPort: p.FromPort,
 			ToPort:   p.ToPort,
-		})
+		}
+		rule, err := network.NewIngressRule(portRange, p.SourceIPs...)
+		if err != nil {
+			logger.Errorf("unexpected IP permission %v: %v", p, err)
+			continue
+		}
+		rules = append(rules, rule)
 	}
-	network.SortPortRanges(ports)
-	return ports, nil
+	network.SortIngressRules(rules)
+	return rules, nil
 }
 
 func (e *environ) OpenPorts(ports []network.PortRange) error {
+	return e.OpenIngressRules(network.IngressRulesFromPortRanges(ports))
+}
+
+func (e *environ) ClosePorts(ports []network.PortRange) error {
+	return e.CloseIngressRules(network.IngressRulesFromPortRanges(ports))
+}
+
+func (e *environ) Ports() ([]network.PortRange, error) {
+	if e.Config().FirewallMode() != config.FwGlobal {
+		return nil, errors.Errorf("invalid firewall mode %q for retrieving ports from model", e.Config().FirewallMode())
+	}
+	return e.portsInGroup(e.globalGroupName())
+}
+
+// OpenIngressRules is part of the environs.IngressRuleFirewaller interface.
+func (e *environ) OpenIngressRules(rules []network.IngressRule) error {
 	if e.Config().FirewallMode() != config.FwGlobal {
 		return errors.Errorf("invalid firewall mode %q for opening ports on model", e.Config().FirewallMode())
 	}
-	if err := e.openPortsInGroup(e.globalGroupName(), ports); err != nil {
+	if err := e.openRulesInGroup(e.globalGroupName(), rules); err != nil {
 		return errors.Trace(err)
 	}
-	logger.Infof("opened ports in global group: %v", ports)
+	logger.Infof("opened ports in global group: %v", rules)
 	return nil
 }
 
-func (e *environ) ClosePorts(ports []network.PortRange) error {
+// CloseIngressRules is part of the environs.IngressRuleFirewaller interface.
+func (e *environ) CloseIngressRules(rules []network.IngressRule) error {
 	if e.Config().FirewallMode() != config.FwGlobal {
 		return errors.Errorf("invalid firewall mode %q for closing ports on model", e.Config().FirewallMode())
 	}
-	if err := e.closePortsInGroup(e.globalGroupName(), ports); err != nil {
+	if err := e.closeRulesInGroup(e.globalGroupName(), rules); err != nil {
 		return errors.Trace(err)
 	}
-	logger.Infof("closed ports in global group: %v", ports)
+	logger.Infof("closed ports in global group: %v", rules)
 	return nil
 }
 
-func (e *environ) Ports() ([]network.PortRange, error) {
+// IngressRules is part of the environs.IngressRuleFirewaller interface.
+func (e *environ) IngressRules() ([]network.IngressRule, error) {
 	if e.Config().FirewallMode() != config.FwGlobal {
 		return nil, errors.Errorf("invalid firewall mode %q for retrieving ports from model", e.Config().FirewallMode())
 	}
-	return e.portsInGroup(e.globalGroupName())
+	return e.rulesInGroup(e.globalGroupName())
 }
 
 func (*environ) Provider() environs.EnvironProvider {
@@ -1270,6 +1371,7 @@ func (e *environ) terminateInstances(ids []instance.Id) error {
 	// LP#1568654
 	defer func() {
 		e.deleteSecurityGroupsForInstances(ids)
+		e.deletePlacementGroupsForInstances(ids)
 	}()
 
 	// TODO (anastasiamac 2016-04-7) instance termination would benefit
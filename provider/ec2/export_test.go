@@ -59,6 +59,19 @@ var (
 
 const VPCIDNone = vpcIDNone
 
+// PatchRunSpotInstance replaces the function used to run spot
+// instances with f.
+func PatchRunSpotInstance(
+	patcher interface {
+		PatchValue(dest, value interface{})
+	},
+	f func(e *ec2.EC2, ri *ec2.RunInstances, maxPrice string) (*ec2.RunInstancesResp, error),
+) {
+	patcher.PatchValue(&runSpotInstance, func(e *environ, ri *ec2.RunInstances, maxPrice string) (*ec2.RunInstancesResp, error) {
+		return f(e.ec2, ri, maxPrice)
+	})
+}
+
 // BucketStorage returns a storage instance addressing
 // an arbitrary s3 bucket.
 func BucketStorage(b *s3.Bucket) storage.Storage {
@@ -18,6 +18,11 @@ type ec2Instance struct {
 	e *environ
 
 	*ec2.Instance
+
+	// spotInterruption holds the status code of the instance's spot
+	// request if EC2 has given notice that it will interrupt the
+	// instance, and is empty otherwise.
+	spotInterruption string
 }
 
 func (inst *ec2Instance) String() string {
@@ -43,13 +48,40 @@ func (inst *ec2Instance) Status() instance.InstanceStatus {
 	default:
 		jujuStatus = status.StatusEmpty
 	}
+	message := inst.State.Name
+	if inst.lifecycle() == lifecycleSpot {
+		switch inst.State.Name {
+		case "shutting-down", "terminated", "stopping", "stopped":
+			// Juju does not stop spot instances itself, other
+			// than when the machine is being removed, so this
+			// means EC2 has reclaimed the instance.
+			message = fmt.Sprintf("spot instance interrupted (%s)", inst.State.Name)
+		default:
+			if inst.spotInterruption != "" {
+				message = fmt.Sprintf("spot instance to be interrupted in two minutes (%s)", inst.spotInterruption)
+			} else {
+				message += " (spot)"
+			}
+		}
+	}
 	return instance.InstanceStatus{
 		Status:  jujuStatus,
-		Message: inst.State.Name,
+		Message: message,
 	}
 
 }
 
+// lifecycle returns the lifecycle of the instance recorded when it was
+// started, which is empty for on-demand instances.
+func (inst *ec2Instance) lifecycle() string {
+	for _, tag := range inst.Tags {
+		if tag.Key == tagLifecycle {
+			return tag.Value
+		}
+	}
+	return ""
+}
+
 // Addresses implements network.Addresses() returning generic address
 // details for the instance, and requerying the ec2 api if required.
 func (inst *ec2Instance) Addresses() ([]network.Address, error) {
@@ -75,40 +107,60 @@ func (inst *ec2Instance) Addresses() ([]network.Address, error) {
 }
 
 func (inst *ec2Instance) OpenPorts(machineId string, ports []network.PortRange) error {
+	return inst.OpenIngressRules(machineId, network.IngressRulesFromPortRanges(ports))
+}
+
+func (inst *ec2Instance) ClosePorts(machineId string, ports []network.PortRange) error {
+	return inst.CloseIngressRules(machineId, network.IngressRulesFromPortRanges(ports))
+}
+
+func (inst *ec2Instance) Ports(machineId string) ([]network.PortRange, error) {
+	if inst.e.Config().FirewallMode() != config.FwInstance {
+		return nil, fmt.Errorf("invalid firewall mode %q for retrieving ports from instance",
+			inst.e.Config().FirewallMode())
+	}
+	name := inst.e.machineGroupName(machineId)
+	ranges, err := inst.e.portsInGroup(name)
+	if err != nil {
+		return nil, err
+	}
+	return ranges, nil
+}
+
+// OpenIngressRules is part of the instance.IngressRuleFirewaller interface.
+func (inst *ec2Instance) OpenIngressRules(machineId string, rules []network.IngressRule) error {
 	if inst.e.Config().FirewallMode() != config.FwInstance {
 		return fmt.Errorf("invalid firewall mode %q for opening ports on instance",
 			inst.e.Config().FirewallMode())
 	}
 	name := inst.e.machineGroupName(machineId)
-	if err := inst.e.openPortsInGroup(name, ports); err != nil {
+	if err := inst.e.openRulesInGroup(name, rules); err != nil {
 		return err
 	}
-	logger.Infof("opened ports in security group %s: %v", name, ports)
+	logger.Infof("opened ports in security group %s: %v", name, rules)
 	return nil
 }
 
-func (inst *ec2Instance) ClosePorts(machineId string, ports []network.PortRange) error {
+// CloseIngressRules is part of the instance.IngressRuleFirewaller interface.
+func (inst *ec2Instance) CloseIngressRules(machineId string, rules []network.IngressRule) error {
 	if inst.e.Config().FirewallMode() != config.FwInstance {
 		return fmt.Errorf("invalid firewall mode %q for closing ports on instance",
 			inst.e.Config().FirewallMode())
 	}
 	name := inst.e.machineGroupName(machineId)
-	if err := inst.e.closePortsInGroup(name, ports); err != nil {
+	if err := inst.e.closeRulesInGroup(name, rules); err != nil {
 		return err
 	}
-	logger.Infof("closed ports in security group %s: %v", name, ports)
+	logger.Infof("closed ports in security group %s: %v", name, rules)
 	return nil
 }
 
-func (inst *ec2Instance) Ports(machineId string) ([]network.PortRange, error) {
+// IngressRules is part of the instance.IngressRuleFirewaller interface.
+func (inst *ec2Instance) IngressRules(machineId string) ([]network.IngressRule, error) {
 	if inst.e.Config().FirewallMode() != config.FwInstance {
 		return nil, fmt.Errorf("invalid firewall mode %q for retrieving ports from instance",
 			inst.e.Config().FirewallMode())
 	}
 	name := inst.e.machineGroupName(machineId)
-	ranges, err := inst.e.portsInGroup(name)
-	if err != nil {
-		return nil, err
-	}
-	return ranges, nil
+	return inst.e.rulesInGroup(name)
 }
@@ -889,6 +889,29 @@ func (t *localServerSuite) TestStartInstanceDistributionErrors(c *gc.C) {
 	c.Assert(errors.Cause(err), gc.Equals, dgErr)
 }
 
+func (t *localServerSuite) TestStartInstanceZonesConstraint(c *gc.C) {
+	env := t.prepareAndBootstrap(c)
+
+	mock := mockAvailabilityZoneAllocations{
+		result: []common.AvailabilityZoneInstances{
+			{ZoneName: "test-impaired"}, {ZoneName: "test-available"},
+		},
+	}
+	t.PatchValue(ec2.AvailabilityZoneAllocations, mock.AvailabilityZoneAllocations)
+
+	params := environs.StartInstanceParams{
+		ControllerUUID: t.ControllerUUID,
+		Constraints:    constraints.MustParse("zones=test-available"),
+	}
+	result, err := testing.StartInstanceWithParams(env, "1", params)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ec2.InstanceEC2(result.Instance).AvailZone, gc.Equals, "test-available")
+
+	params.Constraints = constraints.MustParse("zones=test-unknown")
+	_, err = testing.StartInstanceWithParams(env, "1", params)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
 func (t *localServerSuite) TestStartInstanceDistribution(c *gc.C) {
 	env := t.prepareAndBootstrap(c)
 
@@ -962,6 +985,61 @@ func (t *localServerSuite) testStartInstanceAvailZoneAllConstrained(c *gc.C, run
 	c.Assert(azArgs, gc.DeepEquals, []string{"az1", "az2"})
 }
 
+func (t *localServerSuite) setSpotMaxPrice(c *gc.C, env environs.Environ, price string) {
+	cfg, err := env.Config().Apply(map[string]interface{}{
+		"spot-max-price": price,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = env.SetConfig(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (t *localServerSuite) TestStartInstanceSpot(c *gc.C) {
+	env := t.prepareAndBootstrap(c)
+	t.setSpotMaxPrice(c, env, "0.05")
+
+	var maxPrices []string
+	ec2.PatchRunSpotInstance(t, func(e *amzec2.EC2, ri *amzec2.RunInstances, maxPrice string) (*amzec2.RunInstancesResp, error) {
+		maxPrices = append(maxPrices, maxPrice)
+		return e.RunInstances(ri)
+	})
+	inst, _ := testing.AssertStartInstance(c, env, t.ControllerUUID, "1")
+	c.Assert(maxPrices, jc.DeepEquals, []string{"0.05"})
+	c.Assert(inst.Status().Message, gc.Matches, `.* \(spot\)`)
+
+	instances, err := env.Instances([]instance.Id{inst.Id()})
+	c.Assert(err, jc.ErrorIsNil)
+	var lifecycle string
+	for _, tag := range ec2.InstanceEC2(instances[0]).Tags {
+		if tag.Key == "juju-instance-lifecycle" {
+			lifecycle = tag.Value
+		}
+	}
+	c.Assert(lifecycle, gc.Equals, "spot")
+}
+
+func (t *localServerSuite) TestStartInstanceSpotFallback(c *gc.C) {
+	env := t.prepareAndBootstrap(c)
+	t.setSpotMaxPrice(c, env, "0.001")
+
+	ec2.PatchRunSpotInstance(t, func(e *amzec2.EC2, ri *amzec2.RunInstances, maxPrice string) (*amzec2.RunInstancesResp, error) {
+		return nil, &amzec2.Error{Code: "SpotMaxPriceTooLow", Message: "too low"}
+	})
+	inst, _ := testing.AssertStartInstance(c, env, t.ControllerUUID, "1")
+	c.Assert(inst.Status().Message, gc.Not(gc.Matches), `.* \(spot\)`)
+}
+
+func (t *localServerSuite) TestStartInstanceSpotError(c *gc.C) {
+	env := t.prepareAndBootstrap(c)
+	t.setSpotMaxPrice(c, env, "0.05")
+
+	ec2.PatchRunSpotInstance(t, func(e *amzec2.EC2, ri *amzec2.RunInstances, maxPrice string) (*amzec2.RunInstancesResp, error) {
+		return nil, &amzec2.Error{Code: "AuthFailure", Message: "denied"}
+	})
+	_, _, _, err := testing.StartInstance(env, t.ControllerUUID, "1")
+	c.Assert(err, gc.ErrorMatches, `cannot run instances: denied \(AuthFailure\)`)
+}
+
 // addTestingSubnets adds a testing default VPC with 3 subnets in the EC2 test
 // server: 2 of the subnets are in the "test-available" AZ, the remaining - in
 // "test-unavailable". Returns a slice with the IDs of the created subnets.
@@ -1220,6 +1298,34 @@ func (t *localServerSuite) TestPrecheckInstanceAvailZoneUnknown(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `invalid availability zone "test-unknown"`)
 }
 
+func (t *localServerSuite) TestPrecheckInstanceAvailZoneConstraint(c *gc.C) {
+	env := t.Prepare(c)
+	placement := "zone=test-available"
+	cons := constraints.MustParse("zones=test-impaired")
+	err := env.PrecheckInstance(series.LatestLts(), cons, placement)
+	c.Assert(err, gc.ErrorMatches, `placement zone "test-available" does not satisfy constraint zones=\[test-impaired\]`)
+}
+
+func (t *localServerSuite) TestConstraintsValidatorVocabZones(c *gc.C) {
+	env := t.Prepare(c)
+	validator, err := env.ConstraintsValidator()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = validator.Validate(constraints.MustParse("zones=test-available"))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = validator.Validate(constraints.MustParse("zones=test-unknown"))
+	c.Assert(err, gc.ErrorMatches, "invalid constraint value: zones=test-unknown\nvalid values are:.*")
+}
+
+func (t *localServerSuite) TestConstraintsValidatorVocabPlacementGroup(c *gc.C) {
+	env := t.Prepare(c)
+	validator, err := env.ConstraintsValidator()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = validator.Validate(constraints.MustParse("placement-group=spread"))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = validator.Validate(constraints.MustParse("placement-group=partition"))
+	c.Assert(err, gc.ErrorMatches, "invalid constraint value: placement-group=partition\nvalid values are:.*")
+}
+
 func (t *localServerSuite) TestValidateImageMetadata(c *gc.C) {
 	env := t.Prepare(c)
 	params, err := env.(simplestreams.MetadataValidator).MetadataLookupParams("test")
@@ -0,0 +1,219 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ec2
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/retry"
+	"github.com/juju/utils/clock"
+	"gopkg.in/amz.v3/ec2"
+
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/provider/common"
+)
+
+// placementGroupStrategies maps the strategies of the placement-group
+// constraint to EC2 placement group strategies.
+var placementGroupStrategies = map[string]string{
+	constraints.PlacementGroupSpread:  "spread",
+	constraints.PlacementGroupCluster: "cluster",
+}
+
+// placementGroup holds the details of an EC2 placement group.
+type placementGroup struct {
+	Name     string `xml:"groupName"`
+	Strategy string `xml:"strategy"`
+	State    string `xml:"state"`
+}
+
+type placementGroupsResp struct {
+	RequestId string           `xml:"requestId"`
+	Groups    []placementGroup `xml:"placementGroupSet>item"`
+}
+
+type simpleQueryResp struct {
+	RequestId string `xml:"requestId"`
+	Return    bool   `xml:"return"`
+}
+
+// createPlacementGroup creates a placement group with the given name
+// and strategy.
+func (c *queryClient) createPlacementGroup(name, strategy string) error {
+	params := map[string]string{
+		"Action":    "CreatePlacementGroup",
+		"GroupName": name,
+		"Strategy":  strategy,
+	}
+	var resp simpleQueryResp
+	return c.query(params, &resp)
+}
+
+// placementGroups returns the placement groups with the given names,
+// or all placement groups if no names are specified.
+func (c *queryClient) placementGroups(names ...string) ([]placementGroup, error) {
+	params := map[string]string{
+		"Action": "DescribePlacementGroups",
+	}
+	for i, name := range names {
+		params[fmt.Sprintf("GroupName.%d", i+1)] = name
+	}
+	var resp placementGroupsResp
+	if err := c.query(params, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Groups, nil
+}
+
+// deletePlacementGroup deletes the placement group with the given name.
+func (c *queryClient) deletePlacementGroup(name string) error {
+	params := map[string]string{
+		"Action":    "DeletePlacementGroup",
+		"GroupName": name,
+	}
+	var resp simpleQueryResp
+	return c.query(params, &resp)
+}
+
+var ensurePlacementGroup = _ensurePlacementGroup
+
+// ensurePlacementGroup creates the placement group with the given name
+// and placement-group constraint strategy, if it does not already exist.
+func _ensurePlacementGroup(e *environ, name, strategy string) error {
+	ec2Strategy, ok := placementGroupStrategies[strategy]
+	if !ok {
+		return errors.NotValidf("placement group strategy %q", strategy)
+	}
+	groups, err := e.query.placementGroups(name)
+	if err != nil && ec2ErrCode(err) != "InvalidPlacementGroup.Unknown" {
+		return errors.Annotatef(err, "querying placement group %q", name)
+	}
+	if len(groups) > 0 {
+		if groups[0].Strategy != ec2Strategy {
+			return errors.Errorf(
+				"placement group %q has strategy %q, not %q",
+				name, groups[0].Strategy, ec2Strategy,
+			)
+		}
+		return nil
+	}
+	logger.Debugf("creating placement group %q with strategy %q", name, ec2Strategy)
+	err = e.query.createPlacementGroup(name, ec2Strategy)
+	if err != nil && ec2ErrCode(err) != "InvalidPlacementGroup.Duplicate" {
+		return errors.Annotatef(err, "creating placement group %q", name)
+	}
+	return nil
+}
+
+var deletePlacementGroupInsistently = func(c *queryClient, name string, clock clock.Clock) error {
+	var lastErr error
+	err := retry.Call(retry.CallArgs{
+		Attempts:    30,
+		Delay:       time.Second,
+		MaxDelay:    time.Minute,
+		BackoffFunc: retry.DoubleDelay,
+		Clock:       clock,
+		Func: func() error {
+			err := c.deletePlacementGroup(name)
+			if err == nil || ec2ErrCode(err) == "InvalidPlacementGroup.Unknown" {
+				return nil
+			}
+			return errors.Trace(err)
+		},
+		NotifyFunc: func(err error, attempt int) {
+			lastErr = err
+			logger.Infof("deleting placement group %q, attempt %d", name, attempt)
+		},
+	})
+	if err != nil {
+		return errors.Annotatef(lastErr, "cannot delete placement group %q: consider deleting it manually", name)
+	}
+	return nil
+}
+
+// deletePlacementGroupsForInstances deletes the placement groups of the
+// specified terminated instances, if no other instances remain in them.
+// A placement group is created for an application when its first
+// machine is started, and deleted along with its last machine.
+func (e *environ) deletePlacementGroupsForInstances(ids []instance.Id) {
+	if len(ids) == 0 {
+		return
+	}
+	strInstIds := make([]string, len(ids))
+	for i, id := range ids {
+		strInstIds[i] = string(id)
+	}
+	filter := ec2.NewFilter()
+	filter.Add("instance-state-name", "shutting-down", "terminated")
+	resp, err := e.ec2.Instances(strInstIds, filter)
+	if err != nil {
+		logger.Errorf("cannot determine placement groups to delete: %v", err)
+		return
+	}
+	groupNames := make(map[string]bool)
+	for _, res := range resp.Reservations {
+		for _, inst := range res.Instances {
+			for _, tag := range inst.Tags {
+				if tag.Key == tags.JujuPlacementGroup {
+					groupNames[tag.Value] = true
+				}
+			}
+		}
+	}
+	for name := range groupNames {
+		inUse, err := e.placementGroupInUse(name)
+		if err != nil {
+			logger.Errorf("cannot determine whether placement group %q is in use: %v", name, err)
+			continue
+		}
+		if inUse {
+			continue
+		}
+		if err := deletePlacementGroupInsistently(e.query, name, clock.WallClock); err != nil {
+			logger.Errorf("provider failure: %v", err)
+		}
+	}
+}
+
+// placementGroupInUse reports whether any instances, other than those
+// that are terminating, are in the placement group with the given name.
+func (e *environ) placementGroupInUse(name string) (bool, error) {
+	filter := ec2.NewFilter()
+	filter.Add("placement-group-name", name)
+	filter.Add("instance-state-name", "pending", "running", "stopping", "stopped")
+	resp, err := e.ec2.Instances(nil, filter)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	for _, res := range resp.Reservations {
+		if len(res.Instances) > 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// deleteModelPlacementGroups deletes all placement groups created for
+// the model's applications.
+func (e *environ) deleteModelPlacementGroups() error {
+	groups, err := e.query.placementGroups()
+	if err != nil {
+		return errors.Annotate(err, "listing placement groups")
+	}
+	prefix := common.PlacementGroupPrefix(e.uuid())
+	for _, group := range groups {
+		if !strings.HasPrefix(group.Name, prefix) {
+			continue
+		}
+		if err := deletePlacementGroupInsistently(e.query, group.Name, clock.WallClock); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ec2
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type placementGroupSuite struct {
+	queryServerSuite
+}
+
+var _ = gc.Suite(&placementGroupSuite{})
+
+const describePlacementGroupsResponse = `
+<DescribePlacementGroupsResponse xmlns="http://ec2.amazonaws.com/doc/2016-11-15/">
+  <requestId>d4904fd9-82c2-4ea5-adfe-a9cc3EXAMPLE</requestId>
+  <placementGroupSet>
+    <item>
+      <groupName>juju-group</groupName>
+      <strategy>spread</strategy>
+      <state>available</state>
+    </item>
+  </placementGroupSet>
+</DescribePlacementGroupsResponse>`
+
+const noPlacementGroupsResponse = `
+<DescribePlacementGroupsResponse xmlns="http://ec2.amazonaws.com/doc/2016-11-15/">
+  <requestId>d4904fd9-82c2-4ea5-adfe-a9cc3EXAMPLE</requestId>
+  <placementGroupSet/>
+</DescribePlacementGroupsResponse>`
+
+func (s *placementGroupSuite) TestCreatePlacementGroup(c *gc.C) {
+	s.response = `<CreatePlacementGroupResponse><return>true</return></CreatePlacementGroupResponse>`
+	err := s.client.createPlacementGroup("juju-group", "cluster")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.queries, gc.HasLen, 1)
+	c.Check(s.queries[0].Get("Action"), gc.Equals, "CreatePlacementGroup")
+	c.Check(s.queries[0].Get("GroupName"), gc.Equals, "juju-group")
+	c.Check(s.queries[0].Get("Strategy"), gc.Equals, "cluster")
+}
+
+func (s *placementGroupSuite) TestPlacementGroups(c *gc.C) {
+	s.response = describePlacementGroupsResponse
+	groups, err := s.client.placementGroups("juju-group")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(groups, jc.DeepEquals, []placementGroup{{
+		Name:     "juju-group",
+		Strategy: "spread",
+		State:    "available",
+	}})
+	c.Assert(s.queries, gc.HasLen, 1)
+	c.Check(s.queries[0].Get("Action"), gc.Equals, "DescribePlacementGroups")
+	c.Check(s.queries[0].Get("GroupName.1"), gc.Equals, "juju-group")
+}
+
+func (s *placementGroupSuite) TestDeletePlacementGroup(c *gc.C) {
+	s.response = `<DeletePlacementGroupResponse><return>true</return></DeletePlacementGroupResponse>`
+	err := s.client.deletePlacementGroup("juju-group")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.queries, gc.HasLen, 1)
+	c.Check(s.queries[0].Get("Action"), gc.Equals, "DeletePlacementGroup")
+	c.Check(s.queries[0].Get("GroupName"), gc.Equals, "juju-group")
+}
+
+func (s *placementGroupSuite) TestEnsurePlacementGroupExists(c *gc.C) {
+	s.response = describePlacementGroupsResponse
+	err := ensurePlacementGroup(&environ{query: s.client}, "juju-group", "spread")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.queries, gc.HasLen, 1)
+}
+
+func (s *placementGroupSuite) TestEnsurePlacementGroupStrategyMismatch(c *gc.C) {
+	s.response = describePlacementGroupsResponse
+	err := ensurePlacementGroup(&environ{query: s.client}, "juju-group", "cluster")
+	c.Assert(err, gc.ErrorMatches, `placement group "juju-group" has strategy "spread", not "cluster"`)
+}
+
+func (s *placementGroupSuite) TestEnsurePlacementGroupCreates(c *gc.C) {
+	s.response = noPlacementGroupsResponse
+	err := ensurePlacementGroup(&environ{query: s.client}, "juju-group", "cluster")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.queries, gc.HasLen, 2)
+	c.Check(s.queries[1].Get("Action"), gc.Equals, "CreatePlacementGroup")
+	c.Check(s.queries[1].Get("Strategy"), gc.Equals, "cluster")
+}
+
+func (s *placementGroupSuite) TestEnsurePlacementGroupInvalidStrategy(c *gc.C) {
+	err := ensurePlacementGroup(&environ{query: s.client}, "juju-group", "partition")
+	c.Assert(err, gc.ErrorMatches, `placement group strategy "partition" not valid`)
+	c.Assert(s.queries, gc.HasLen, 0)
+}
@@ -35,7 +35,7 @@ func (p environProvider) Open(args environs.OpenParams) (environs.Environ, error
 	e.name = args.Config.Name()
 
 	var err error
-	e.ec2, e.s3, err = awsClients(args.Cloud)
+	e.ec2, e.s3, e.query, err = awsClients(args.Cloud)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -46,9 +46,9 @@ func (p environProvider) Open(args environs.OpenParams) (environs.Environ, error
 	return e, nil
 }
 
-func awsClients(cloud environs.CloudSpec) (*ec2.EC2, *s3.S3, error) {
+func awsClients(cloud environs.CloudSpec) (*ec2.EC2, *s3.S3, *queryClient, error) {
 	if err := validateCloudSpec(cloud); err != nil {
-		return nil, nil, errors.Annotate(err, "validating cloud spec")
+		return nil, nil, nil, errors.Annotate(err, "validating cloud spec")
 	}
 
 	credentialAttrs := cloud.Credential.Attributes()
@@ -62,7 +62,8 @@ func awsClients(cloud environs.CloudSpec) (*ec2.EC2, *s3.S3, error) {
 	// TODO(axw) define region in terms of EC2 and S3 endpoints.
 	region := aws.Regions[cloud.Region]
 	signer := aws.SignV4Factory(region.Name, "ec2")
-	return ec2.New(auth, region, signer), s3.New(auth, region), nil
+	query := newQueryClient(auth, region, signer)
+	return ec2.New(auth, region, signer), s3.New(auth, region), query, nil
 }
 
 // PrepareConfig is specified in the EnvironProvider interface.
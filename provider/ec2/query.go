@@ -0,0 +1,160 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ec2
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/amz.v3/aws"
+	"gopkg.in/amz.v3/ec2"
+)
+
+// queryAPIVersion is the EC2 API version used by queryClient.
+const queryAPIVersion = "2016-11-15"
+
+// queryTimeout is the time after which a queryClient request that has
+// not completed is abandoned.
+const queryTimeout = time.Minute
+
+// queryClient makes the EC2 API requests needed for features, such
+// as spot instances, which are not supported by the EC2 client
+// library.
+type queryClient struct {
+	auth   aws.Auth
+	region aws.Region
+	sign   aws.Signer
+	client *http.Client
+}
+
+func newQueryClient(auth aws.Auth, region aws.Region, sign aws.Signer) *queryClient {
+	return &queryClient{
+		auth:   auth,
+		region: region,
+		sign:   sign,
+		client: &http.Client{Timeout: queryTimeout},
+	}
+}
+
+type queryErrorResp struct {
+	RequestId string `xml:"RequestID"`
+	Errors    []struct {
+		Code    string
+		Message string
+	} `xml:"Errors>Error"`
+}
+
+// query makes a signed EC2 API request with the given parameters,
+// decoding the XML response into resp.
+func (c *queryClient) query(params map[string]string, resp interface{}) error {
+	endpoint, err := url.Parse(c.region.EC2Endpoint)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if endpoint.Path == "" {
+		endpoint.Path = "/"
+	}
+	values := make(url.Values)
+	for k, v := range params {
+		values.Set(k, v)
+	}
+	values.Set("Version", queryAPIVersion)
+	endpoint.RawQuery = values.Encode()
+
+	req, err := http.NewRequest("GET", endpoint.String(), nil)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := c.sign(req, c.auth); err != nil {
+		return errors.Annotate(err, "signing request")
+	}
+	r, err := c.client.Do(req)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer r.Body.Close()
+	if r.StatusCode != http.StatusOK {
+		return queryError(r)
+	}
+	return errors.Trace(xml.NewDecoder(r.Body).Decode(resp))
+}
+
+// queryError returns an *ec2.Error for the given failed response.
+func queryError(r *http.Response) error {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var resp queryErrorResp
+	if err := xml.Unmarshal(body, &resp); err != nil || len(resp.Errors) == 0 {
+		return &ec2.Error{
+			Code:    r.Status,
+			Message: string(body),
+		}
+	}
+	return &ec2.Error{
+		Code:    resp.Errors[0].Code,
+		Message: resp.Errors[0].Message,
+	}
+}
+
+type createVolumeResp struct {
+	RequestId string `xml:"requestId"`
+	VolumeId  string `xml:"volumeId"`
+	Size      int    `xml:"size"`
+}
+
+// createEncryptedVolume creates an encrypted volume as described by
+// vol, using the KMS key with the given ARN or ID. It returns the ID
+// and size in GiB of the new volume.
+func (c *queryClient) createEncryptedVolume(vol ec2.CreateVolume, kmsKeyId string) (string, int, error) {
+	params := map[string]string{
+		"Action":           "CreateVolume",
+		"AvailabilityZone": vol.AvailZone,
+		"Encrypted":        "true",
+		"KmsKeyId":         kmsKeyId,
+	}
+	if vol.VolumeSize > 0 {
+		params["Size"] = fmt.Sprint(vol.VolumeSize)
+	}
+	if vol.SnapshotId != "" {
+		params["SnapshotId"] = vol.SnapshotId
+	}
+	if vol.VolumeType != "" {
+		params["VolumeType"] = vol.VolumeType
+	}
+	if vol.IOPS > 0 {
+		params["Iops"] = fmt.Sprint(vol.IOPS)
+	}
+	var resp createVolumeResp
+	if err := c.query(params, &resp); err != nil {
+		return "", 0, err
+	}
+	return resp.VolumeId, resp.Size, nil
+}
+
+type modifyVolumeResp struct {
+	RequestId  string `xml:"requestId"`
+	TargetSize int    `xml:"volumeModification>targetSize"`
+}
+
+// modifyVolumeSize requests that the volume with the given ID be grown
+// to the given size in GiB. It returns the target size of the volume.
+func (c *queryClient) modifyVolumeSize(volumeId string, size int) (int, error) {
+	params := map[string]string{
+		"Action":   "ModifyVolume",
+		"VolumeId": volumeId,
+		"Size":     fmt.Sprint(size),
+	}
+	var resp modifyVolumeResp
+	if err := c.query(params, &resp); err != nil {
+		return 0, err
+	}
+	return resp.TargetSize, nil
+}
@@ -0,0 +1,118 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ec2
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	"gopkg.in/amz.v3/aws"
+	amzec2 "gopkg.in/amz.v3/ec2"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/testing"
+)
+
+// queryServerSuite runs a server to which queryClient requests are
+// made, recording the queries and responding with the given response.
+type queryServerSuite struct {
+	testing.BaseSuite
+
+	server   *httptest.Server
+	queries  []url.Values
+	response string
+	status   int
+	client   *queryClient
+}
+
+func (s *queryServerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.queries = nil
+	s.status = http.StatusOK
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.queries = append(s.queries, r.URL.Query())
+		w.WriteHeader(s.status)
+		w.Write([]byte(s.response))
+	}))
+	s.AddCleanup(func(*gc.C) { s.server.Close() })
+	region := aws.Region{Name: "querytest", EC2Endpoint: s.server.URL}
+	s.client = newQueryClient(testAuth, region, aws.SignV4Factory(region.Name, "ec2"))
+}
+
+type querySuite struct {
+	queryServerSuite
+}
+
+var _ = gc.Suite(&querySuite{})
+
+func (s *querySuite) TestCreateEncryptedVolume(c *gc.C) {
+	s.response = `<?xml version="1.0" encoding="UTF-8"?>
+<CreateVolumeResponse xmlns="http://ec2.amazonaws.com/doc/2016-11-15/">
+  <requestId>59dbff89-35bd-4eac-99ed-be587EXAMPLE</requestId>
+  <volumeId>vol-1</volumeId>
+  <size>80</size>
+</CreateVolumeResponse>`
+	volumeId, size, err := s.client.createEncryptedVolume(amzec2.CreateVolume{
+		AvailZone:  "us-east-1a",
+		VolumeSize: 80,
+		VolumeType: "gp2",
+	}, "alias/juju")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(volumeId, gc.Equals, "vol-1")
+	c.Assert(size, gc.Equals, 80)
+	c.Assert(s.queries, gc.HasLen, 1)
+	for key, value := range map[string]string{
+		"Action":           "CreateVolume",
+		"AvailabilityZone": "us-east-1a",
+		"Size":             "80",
+		"VolumeType":       "gp2",
+		"Encrypted":        "true",
+		"KmsKeyId":         "alias/juju",
+	} {
+		c.Check(s.queries[0].Get(key), gc.Equals, value, gc.Commentf("%s", key))
+	}
+	c.Check(s.queries[0].Get("Iops"), gc.Equals, "")
+}
+
+func (s *querySuite) TestModifyVolumeSize(c *gc.C) {
+	s.response = `<?xml version="1.0" encoding="UTF-8"?>
+<ModifyVolumeResponse xmlns="http://ec2.amazonaws.com/doc/2016-11-15/">
+  <requestId>5jkdf074-37ed-4004-8671-a78ee82bf1cbEXAMPLE</requestId>
+  <volumeModification>
+    <targetSize>200</targetSize>
+    <originalSize>100</originalSize>
+    <modificationState>modifying</modificationState>
+    <volumeId>vol-1</volumeId>
+  </volumeModification>
+</ModifyVolumeResponse>`
+	size, err := s.client.modifyVolumeSize("vol-1", 200)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(size, gc.Equals, 200)
+	c.Assert(s.queries, gc.HasLen, 1)
+	c.Assert(s.queries[0].Get("Action"), gc.Equals, "ModifyVolume")
+	c.Assert(s.queries[0].Get("VolumeId"), gc.Equals, "vol-1")
+	c.Assert(s.queries[0].Get("Size"), gc.Equals, "200")
+	c.Assert(s.queries[0].Get("Version"), gc.Equals, queryAPIVersion)
+}
+
+func (s *querySuite) TestQueryTimeout(c *gc.C) {
+	c.Assert(s.client.client.Timeout, gc.Equals, queryTimeout)
+
+	done := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-done
+	}))
+	defer server.Close()
+	defer close(done)
+	region := aws.Region{Name: "querytest", EC2Endpoint: server.URL}
+	client := newQueryClient(testAuth, region, aws.SignV4Factory(region.Name, "ec2"))
+	client.client.Timeout = 10 * time.Millisecond
+
+	var resp struct{}
+	err := client.query(map[string]string{"Action": "DescribeVolumes"}, &resp)
+	c.Assert(err, gc.ErrorMatches, ".*(Client.Timeout|timeout).*")
+}
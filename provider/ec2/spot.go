@@ -0,0 +1,329 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ec2
+
+import (
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"gopkg.in/amz.v3/ec2"
+
+	"github.com/juju/juju/instance"
+)
+
+const (
+	// tagLifecycle is the tag recording the lifecycle of instances
+	// which are not on-demand instances.
+	tagLifecycle = "juju-instance-lifecycle"
+
+	// lifecycleSpot is the value of the tagLifecycle tag for spot
+	// instances.
+	lifecycleSpot = "spot"
+)
+
+// spotRequestAttempt is the strategy used to wait for a spot
+// instance request to be fulfilled before giving up and falling
+// back to an on-demand instance.
+var spotRequestAttempt = utils.AttemptStrategy{
+	Total: 2 * time.Minute,
+	Delay: 5 * time.Second,
+}
+
+// spotInstanceRequest holds the details of a spot instance request.
+type spotInstanceRequest struct {
+	Id            string `xml:"spotInstanceRequestId"`
+	State         string `xml:"state"`
+	StatusCode    string `xml:"status>code"`
+	StatusMessage string `xml:"status>message"`
+	InstanceId    string `xml:"instanceId"`
+}
+
+type spotInstanceRequestsResp struct {
+	RequestId string                `xml:"requestId"`
+	Requests  []spotInstanceRequest `xml:"spotInstanceRequestSet>item"`
+}
+
+// requestSpotInstance requests a one-time spot instance with the
+// launch specification from ri, bidding at most maxPrice.
+func (c *queryClient) requestSpotInstance(ri *ec2.RunInstances, maxPrice string) (*spotInstanceRequest, error) {
+	params := map[string]string{
+		"Action":                           "RequestSpotInstances",
+		"SpotPrice":                        maxPrice,
+		"InstanceCount":                    "1",
+		"Type":                             "one-time",
+		"LaunchSpecification.ImageId":      ri.ImageId,
+		"LaunchSpecification.InstanceType": ri.InstanceType,
+	}
+	if len(ri.UserData) > 0 {
+		params["LaunchSpecification.UserData"] = base64.StdEncoding.EncodeToString(ri.UserData)
+	}
+	if ri.AvailZone != "" {
+		params["LaunchSpecification.Placement.AvailabilityZone"] = ri.AvailZone
+	}
+	if ri.PlacementGroupName != "" {
+		params["LaunchSpecification.Placement.GroupName"] = ri.PlacementGroupName
+	}
+	if ri.SubnetId != "" {
+		params["LaunchSpecification.SubnetId"] = ri.SubnetId
+	}
+	for i, g := range ri.SecurityGroups {
+		params[fmt.Sprintf("LaunchSpecification.SecurityGroupId.%d", i+1)] = g.Id
+	}
+	addBlockDeviceMappingParams(params, "LaunchSpecification.", ri.BlockDeviceMappings)
+	var resp spotInstanceRequestsResp
+	if err := c.query(params, &resp); err != nil {
+		return nil, err
+	}
+	if len(resp.Requests) != 1 {
+		return nil, errors.Errorf("expected 1 spot instance request, got %d", len(resp.Requests))
+	}
+	return &resp.Requests[0], nil
+}
+
+// addBlockDeviceMappingParams adds the query parameters for the given
+// block device mappings, including the root disk's, with the given
+// prefix, as RunInstances does.
+func addBlockDeviceMappingParams(params map[string]string, prefix string, mappings []ec2.BlockDeviceMapping) {
+	for i, m := range mappings {
+		mappingPrefix := fmt.Sprintf("%sBlockDeviceMapping.%d.", prefix, i+1)
+		params[mappingPrefix+"DeviceName"] = m.DeviceName
+		if m.VirtualName != "" {
+			params[mappingPrefix+"VirtualName"] = m.VirtualName
+		}
+		if m.SnapshotId != "" {
+			params[mappingPrefix+"Ebs.SnapshotId"] = m.SnapshotId
+		}
+		if m.VolumeType != "" {
+			params[mappingPrefix+"Ebs.VolumeType"] = m.VolumeType
+		}
+		if m.IOPS != 0 {
+			params[mappingPrefix+"Ebs.Iops"] = fmt.Sprint(m.IOPS)
+		}
+		if m.VolumeSize > 0 {
+			params[mappingPrefix+"Ebs.VolumeSize"] = fmt.Sprint(m.VolumeSize)
+		}
+		if m.DeleteOnTermination {
+			params[mappingPrefix+"Ebs.DeleteOnTermination"] = "true"
+		}
+	}
+}
+
+// spotInstanceRequest returns the current state of the spot instance
+// request with the given id.
+func (c *queryClient) spotInstanceRequest(id string) (*spotInstanceRequest, error) {
+	params := map[string]string{
+		"Action":                  "DescribeSpotInstanceRequests",
+		"SpotInstanceRequestId.1": id,
+	}
+	var resp spotInstanceRequestsResp
+	if err := c.query(params, &resp); err != nil {
+		return nil, err
+	}
+	if len(resp.Requests) != 1 {
+		return nil, errors.NotFoundf("spot instance request %q", id)
+	}
+	return &resp.Requests[0], nil
+}
+
+// instanceSpotRequests returns the spot instance requests fulfilled
+// by the instances with the given ids.
+func (c *queryClient) instanceSpotRequests(instanceIds []string) ([]spotInstanceRequest, error) {
+	params := map[string]string{
+		"Action":        "DescribeSpotInstanceRequests",
+		"Filter.1.Name": "instance-id",
+	}
+	for i, id := range instanceIds {
+		params[fmt.Sprintf("Filter.1.Value.%d", i+1)] = id
+	}
+	var resp spotInstanceRequestsResp
+	if err := c.query(params, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Requests, nil
+}
+
+// cancelSpotInstanceRequest cancels the spot instance request with
+// the given id.
+func (c *queryClient) cancelSpotInstanceRequest(id string) error {
+	params := map[string]string{
+		"Action":                  "CancelSpotInstanceRequests",
+		"SpotInstanceRequestId.1": id,
+	}
+	var resp spotInstanceRequestsResp
+	return c.query(params, &resp)
+}
+
+// spotUnavailableError is returned by runSpotInstance when a spot
+// instance could not be obtained at the requested price.
+type spotUnavailableError struct {
+	code    string
+	message string
+}
+
+func (e *spotUnavailableError) Error() string {
+	if e.message == "" {
+		return fmt.Sprintf("spot instance not available (%s)", e.code)
+	}
+	return fmt.Sprintf("spot instance not available (%s): %s", e.code, e.message)
+}
+
+// isSpotUnavailableError reports whether the error indicates that a
+// spot instance could not be obtained, in which case an on-demand
+// instance should be run instead.
+func isSpotUnavailableError(err error) bool {
+	switch err := errors.Cause(err).(type) {
+	case *spotUnavailableError:
+		return true
+	case *ec2.Error:
+		switch err.Code {
+		case "MaxSpotInstanceCountExceeded", "InsufficientInstanceCapacity", "SpotMaxPriceTooLow":
+			return true
+		}
+	}
+	return false
+}
+
+// spotRequestFailed reports whether the spot instance request will
+// not be fulfilled.
+func spotRequestFailed(req *spotInstanceRequest) bool {
+	switch req.State {
+	case "cancelled", "failed", "closed":
+		return true
+	}
+	switch req.StatusCode {
+	case "capacity-not-available", "capacity-oversubscribed", "price-too-low",
+		"bad-parameters", "constraint-not-fulfillable", "az-group-constraint",
+		"launch-group-constraint", "placement-group-constraint", "system-error":
+		return true
+	}
+	return false
+}
+
+var runSpotInstance = _runSpotInstance
+
+// runSpotInstance requests a spot instance with the launch
+// specification from ri, waiting for the request to be fulfilled. If
+// it is not fulfilled, the request is cancelled and an error
+// satisfying isSpotUnavailableError is returned.
+func _runSpotInstance(e *environ, ri *ec2.RunInstances, maxPrice string) (*ec2.RunInstancesResp, error) {
+	req, err := e.query.requestSpotInstance(ri, maxPrice)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	logger.Infof("requested spot instance (request %q) at max price %s", req.Id, maxPrice)
+	for a := spotRequestAttempt.Start(); req.InstanceId == "" && a.Next(); {
+		if spotRequestFailed(req) {
+			break
+		}
+		next, err := e.query.spotInstanceRequest(req.Id)
+		if errors.IsNotFound(err) {
+			// Requests are eventually consistent.
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		req = next
+	}
+	if req.InstanceId == "" {
+		if err := e.query.cancelSpotInstanceRequest(req.Id); err != nil {
+			logger.Warningf("cannot cancel spot instance request %q: %v", req.Id, err)
+		}
+		// The request may have been fulfilled before it was
+		// cancelled, in which case we use the instance rather than
+		// leave it running unknown to Juju.
+		if final, err := e.query.spotInstanceRequest(req.Id); err == nil {
+			req = final
+		}
+	}
+	if req.InstanceId == "" {
+		code := req.StatusCode
+		if code == "" {
+			code = req.State
+		}
+		return nil, &spotUnavailableError{code, req.StatusMessage}
+	}
+
+	var instances []ec2.Instance
+	for a := shortAttempt.Start(); a.Next(); {
+		resp, err := e.ec2.Instances([]string{req.InstanceId}, nil)
+		if err == nil {
+			if len(resp.Reservations) > 0 {
+				instances = resp.Reservations[0].Instances
+			}
+			break
+		}
+		if !isNotFoundError(err) || !a.HasNext() {
+			return nil, errors.Annotatef(err, "getting spot instance %q", req.InstanceId)
+		}
+	}
+	if len(instances) != 1 {
+		return nil, errors.Errorf("expected 1 spot instance %q, got %d", req.InstanceId, len(instances))
+	}
+	return &ec2.RunInstancesResp{Instances: instances}, nil
+}
+
+// runInstance runs a single instance with the given arguments. If
+// spot-max-price is configured, a spot instance is requested first,
+// falling back to an on-demand instance if spot capacity is not
+// available at that price. It reports whether a spot instance was
+// started.
+func (e *environ) runInstance(ri *ec2.RunInstances) (resp *ec2.RunInstancesResp, spot bool, err error) {
+	if maxPrice := e.ecfg().spotMaxPrice(); maxPrice != "" {
+		resp, err = runSpotInstance(e, ri, maxPrice)
+		if err == nil {
+			return resp, true, nil
+		}
+		if !isSpotUnavailableError(err) {
+			return nil, false, err
+		}
+		logger.Warningf("%v; running on-demand instance instead", err)
+	}
+	resp, err = runInstances(e.ec2, ri)
+	return resp, false, err
+}
+
+// spotInterruptionCodes holds the spot instance request status codes
+// which EC2 sets when it gives the two-minute warning that it is about
+// to interrupt the instance.
+var spotInterruptionCodes = map[string]bool{
+	"marked-for-termination": true,
+	"marked-for-stop":        true,
+}
+
+// setSpotInterruptions records, on those of the given instances which
+// are spot instances, whether EC2 has given notice that it is about to
+// interrupt them. It is called whenever instances are polled, so that
+// the notice is shown in the machine's instance status. Errors are
+// logged rather than returned, as the notice is informational.
+func (e *environ) setSpotInterruptions(insts []instance.Instance) {
+	spotInsts := make(map[string]*ec2Instance)
+	var ids []string
+	for _, inst := range insts {
+		ec2Inst, ok := inst.(*ec2Instance)
+		if !ok || ec2Inst.lifecycle() != lifecycleSpot {
+			continue
+		}
+		spotInsts[ec2Inst.InstanceId] = ec2Inst
+		ids = append(ids, ec2Inst.InstanceId)
+	}
+	if len(ids) == 0 {
+		return
+	}
+	reqs, err := e.query.instanceSpotRequests(ids)
+	if err != nil {
+		logger.Warningf("cannot get spot instance requests for %v: %v", ids, err)
+		return
+	}
+	for _, req := range reqs {
+		inst, ok := spotInsts[req.InstanceId]
+		if !ok || !spotInterruptionCodes[req.StatusCode] {
+			continue
+		}
+		inst.spotInterruption = req.StatusCode
+		logger.Warningf("spot instance %q will be interrupted (%s)", req.InstanceId, req.StatusCode)
+	}
+}
@@ -0,0 +1,203 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ec2
+
+import (
+	"net/http"
+
+	jc "github.com/juju/testing/checkers"
+	amzec2 "gopkg.in/amz.v3/ec2"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/instance"
+)
+
+type spotSuite struct {
+	queryServerSuite
+}
+
+var _ = gc.Suite(&spotSuite{})
+
+const spotRequestResponse = `
+<RequestSpotInstancesResponse xmlns="http://ec2.amazonaws.com/doc/2016-11-15/">
+  <requestId>req-1</requestId>
+  <spotInstanceRequestSet>
+    <item>
+      <spotInstanceRequestId>sir-1</spotInstanceRequestId>
+      <state>active</state>
+      <status>
+        <code>fulfilled</code>
+        <message>Your spot request is fulfilled.</message>
+      </status>
+      <instanceId>i-1</instanceId>
+    </item>
+  </spotInstanceRequestSet>
+</RequestSpotInstancesResponse>`
+
+func (s *spotSuite) TestRequestSpotInstance(c *gc.C) {
+	s.response = spotRequestResponse
+	req, err := s.client.requestSpotInstance(&amzec2.RunInstances{
+		ImageId:            "ami-1",
+		InstanceType:       "m3.medium",
+		UserData:           []byte("hello"),
+		AvailZone:          "us-east-1a",
+		PlacementGroupName: "juju-group",
+		SecurityGroups:     []amzec2.SecurityGroup{{Id: "sg-1"}, {Id: "sg-2"}},
+		BlockDeviceMappings: []amzec2.BlockDeviceMapping{
+			{DeviceName: "/dev/sda1", VolumeSize: 8, VolumeType: "gp2", DeleteOnTermination: true},
+			{DeviceName: "/dev/sdb", VirtualName: "ephemeral0"},
+			{DeviceName: "/dev/sdf", SnapshotId: "snap-1", VolumeType: "io1", IOPS: 100},
+		},
+	}, "0.05")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(req, jc.DeepEquals, &spotInstanceRequest{
+		Id:            "sir-1",
+		State:         "active",
+		StatusCode:    "fulfilled",
+		StatusMessage: "Your spot request is fulfilled.",
+		InstanceId:    "i-1",
+	})
+
+	c.Assert(s.queries, gc.HasLen, 1)
+	query := s.queries[0]
+	for key, value := range map[string]string{
+		"Action":                           "RequestSpotInstances",
+		"Version":                          queryAPIVersion,
+		"SpotPrice":                        "0.05",
+		"InstanceCount":                    "1",
+		"Type":                             "one-time",
+		"LaunchSpecification.ImageId":      "ami-1",
+		"LaunchSpecification.InstanceType": "m3.medium",
+		"LaunchSpecification.UserData":     "aGVsbG8=",
+		"LaunchSpecification.Placement.AvailabilityZone":                   "us-east-1a",
+		"LaunchSpecification.Placement.GroupName":                          "juju-group",
+		"LaunchSpecification.SecurityGroupId.1":                            "sg-1",
+		"LaunchSpecification.SecurityGroupId.2":                            "sg-2",
+		"LaunchSpecification.BlockDeviceMapping.1.DeviceName":              "/dev/sda1",
+		"LaunchSpecification.BlockDeviceMapping.1.Ebs.VolumeSize":          "8",
+		"LaunchSpecification.BlockDeviceMapping.1.Ebs.VolumeType":          "gp2",
+		"LaunchSpecification.BlockDeviceMapping.1.Ebs.DeleteOnTermination": "true",
+		"LaunchSpecification.BlockDeviceMapping.2.DeviceName":              "/dev/sdb",
+		"LaunchSpecification.BlockDeviceMapping.2.VirtualName":             "ephemeral0",
+		"LaunchSpecification.BlockDeviceMapping.3.DeviceName":              "/dev/sdf",
+		"LaunchSpecification.BlockDeviceMapping.3.Ebs.SnapshotId":          "snap-1",
+		"LaunchSpecification.BlockDeviceMapping.3.Ebs.VolumeType":          "io1",
+		"LaunchSpecification.BlockDeviceMapping.3.Ebs.Iops":                "100",
+	} {
+		c.Check(query.Get(key), gc.Equals, value, gc.Commentf("%s", key))
+	}
+	c.Check(query.Get("LaunchSpecification.SubnetId"), gc.Equals, "")
+}
+
+func (s *spotSuite) TestSpotInstanceRequest(c *gc.C) {
+	s.response = spotRequestResponse
+	req, err := s.client.spotInstanceRequest("sir-1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(req.InstanceId, gc.Equals, "i-1")
+	c.Assert(s.queries, gc.HasLen, 1)
+	c.Assert(s.queries[0].Get("Action"), gc.Equals, "DescribeSpotInstanceRequests")
+	c.Assert(s.queries[0].Get("SpotInstanceRequestId.1"), gc.Equals, "sir-1")
+}
+
+func (s *spotSuite) TestRequestSpotInstanceError(c *gc.C) {
+	s.status = http.StatusBadRequest
+	s.response = `
+<Response>
+  <Errors>
+    <Error>
+      <Code>SpotMaxPriceTooLow</Code>
+      <Message>Your max price is too low.</Message>
+    </Error>
+  </Errors>
+  <RequestID>req-1</RequestID>
+</Response>`
+	_, err := s.client.requestSpotInstance(&amzec2.RunInstances{}, "0.001")
+	c.Assert(err, gc.ErrorMatches, `Your max price is too low. \(SpotMaxPriceTooLow\)`)
+	c.Assert(isSpotUnavailableError(err), jc.IsTrue)
+}
+
+func (s *spotSuite) TestSpotRequestFailed(c *gc.C) {
+	for _, t := range []struct {
+		req    spotInstanceRequest
+		failed bool
+	}{
+		{spotInstanceRequest{State: "open", StatusCode: "pending-evaluation"}, false},
+		{spotInstanceRequest{State: "open", StatusCode: "pending-fulfillment"}, false},
+		{spotInstanceRequest{State: "open", StatusCode: "capacity-not-available"}, true},
+		{spotInstanceRequest{State: "open", StatusCode: "price-too-low"}, true},
+		{spotInstanceRequest{State: "cancelled"}, true},
+		{spotInstanceRequest{State: "active", StatusCode: "fulfilled"}, false},
+	} {
+		c.Check(spotRequestFailed(&t.req), gc.Equals, t.failed, gc.Commentf("%+v", t.req))
+	}
+}
+
+func (s *spotSuite) TestSpotInstanceStatus(c *gc.C) {
+	inst := &ec2Instance{Instance: &amzec2.Instance{
+		State: amzec2.InstanceState{Name: "running"},
+		Tags:  []amzec2.Tag{{tagLifecycle, lifecycleSpot}},
+	}}
+	c.Assert(inst.Status().Message, gc.Equals, "running (spot)")
+
+	inst.spotInterruption = "marked-for-termination"
+	c.Assert(inst.Status().Message, gc.Equals, "spot instance to be interrupted in two minutes (marked-for-termination)")
+
+	inst.State.Name = "terminated"
+	c.Assert(inst.Status().Message, gc.Equals, "spot instance interrupted (terminated)")
+
+	inst.Tags = nil
+	c.Assert(inst.Status().Message, gc.Equals, "terminated")
+}
+
+func (s *spotSuite) TestSetSpotInterruptions(c *gc.C) {
+	s.response = `
+<DescribeSpotInstanceRequestsResponse xmlns="http://ec2.amazonaws.com/doc/2016-11-15/">
+  <requestId>req-1</requestId>
+  <spotInstanceRequestSet>
+    <item>
+      <spotInstanceRequestId>sir-1</spotInstanceRequestId>
+      <state>active</state>
+      <status>
+        <code>marked-for-termination</code>
+      </status>
+      <instanceId>i-1</instanceId>
+    </item>
+    <item>
+      <spotInstanceRequestId>sir-2</spotInstanceRequestId>
+      <state>active</state>
+      <status>
+        <code>fulfilled</code>
+      </status>
+      <instanceId>i-2</instanceId>
+    </item>
+  </spotInstanceRequestSet>
+</DescribeSpotInstanceRequestsResponse>`
+	env := &environ{query: s.client}
+	spotTags := []amzec2.Tag{{tagLifecycle, lifecycleSpot}}
+	inst1 := &ec2Instance{e: env, Instance: &amzec2.Instance{InstanceId: "i-1", Tags: spotTags}}
+	inst2 := &ec2Instance{e: env, Instance: &amzec2.Instance{InstanceId: "i-2", Tags: spotTags}}
+	inst3 := &ec2Instance{e: env, Instance: &amzec2.Instance{InstanceId: "i-3"}}
+
+	env.setSpotInterruptions([]instance.Instance{inst1, nil, inst2, inst3})
+	c.Assert(inst1.spotInterruption, gc.Equals, "marked-for-termination")
+	c.Assert(inst2.spotInterruption, gc.Equals, "")
+	c.Assert(inst3.spotInterruption, gc.Equals, "")
+
+	// Only the spot instances are looked up.
+	c.Assert(s.queries, gc.HasLen, 1)
+	query := s.queries[0]
+	c.Assert(query.Get("Action"), gc.Equals, "DescribeSpotInstanceRequests")
+	c.Assert(query.Get("Filter.1.Name"), gc.Equals, "instance-id")
+	c.Assert(query.Get("Filter.1.Value.1"), gc.Equals, "i-1")
+	c.Assert(query.Get("Filter.1.Value.2"), gc.Equals, "i-2")
+	c.Assert(query.Get("Filter.1.Value.3"), gc.Equals, "")
+}
+
+func (s *spotSuite) TestSetSpotInterruptionsNoSpotInstances(c *gc.C) {
+	env := &environ{query: s.client}
+	env.setSpotInterruptions([]instance.Instance{
+		&ec2Instance{e: env, Instance: &amzec2.Instance{InstanceId: "i-1"}},
+	})
+	c.Assert(s.queries, gc.HasLen, 0)
+}
@@ -0,0 +1,80 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package external
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/schema"
+	"gopkg.in/juju/environschema.v1"
+
+	"github.com/juju/juju/environs/config"
+)
+
+const (
+	cfgAllowInsecureHTTP = "allow-insecure-http"
+)
+
+var configSchema = environschema.Fields{
+	cfgAllowInsecureHTTP: {
+		Description: "Whether to allow a plugin endpoint with a plain http URL. Requests to plugins carry the cloud credential, so this should only be set for plugins reached over a trusted network.",
+		Type:        environschema.Tbool,
+		Immutable:   true,
+	},
+}
+
+var configFields = func() schema.Fields {
+	fs, _, err := configSchema.ValidationSchema()
+	if err != nil {
+		panic(err)
+	}
+	return fs
+}()
+
+var configDefaults = schema.Defaults{
+	cfgAllowInsecureHTTP: false,
+}
+
+var configImmutableFields = []string{
+	cfgAllowInsecureHTTP,
+}
+
+type environConfig struct {
+	*config.Config
+	attrs map[string]interface{}
+}
+
+// newValidConfig builds a new environConfig from the provided Config,
+// filling in default values, if any. It returns an error if the
+// resulting configuration is not valid.
+func newValidConfig(cfg, old *config.Config) (*environConfig, error) {
+	if err := config.Validate(cfg, old); err != nil {
+		return nil, errors.Trace(err)
+	}
+	attrs, err := cfg.ValidateUnknownAttrs(configFields, configDefaults)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if old != nil {
+		oldEcfg, err := newValidConfig(old, nil)
+		if err != nil {
+			return nil, errors.Annotatef(err, "invalid base config")
+		}
+		for _, attr := range configImmutableFields {
+			oldv, newv := oldEcfg.attrs[attr], attrs[attr]
+			if oldv != newv {
+				return nil, errors.Errorf(
+					"%s: cannot change from %v to %v",
+					attr, oldv, newv,
+				)
+			}
+		}
+	}
+	return &environConfig{Config: cfg, attrs: attrs}, nil
+}
+
+// allowInsecureHTTP reports whether a plugin endpoint with a plain
+// http URL is allowed.
+func (c *environConfig) allowInsecureHTTP() bool {
+	return c.attrs[cfgAllowInsecureHTTP].(bool)
+}
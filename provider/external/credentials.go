@@ -0,0 +1,52 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package external
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/cloud"
+)
+
+type environProviderCredentials struct{}
+
+// CredentialSchemas is part of the environs.ProviderCredentials interface.
+// Credentials are passed to the plugin as they are, so the plugin
+// determines which of the auth types it accepts.
+func (environProviderCredentials) CredentialSchemas() map[cloud.AuthType]cloud.CredentialSchema {
+	return map[cloud.AuthType]cloud.CredentialSchema{
+		cloud.EmptyAuthType: {},
+		cloud.UserPassAuthType: {{
+			"username", cloud.CredentialAttr{
+				Description: "account username",
+			},
+		}, {
+			"password", cloud.CredentialAttr{
+				Description: "account password",
+				Hidden:      true,
+			},
+		}},
+		cloud.AccessKeyAuthType: {{
+			"access-key", cloud.CredentialAttr{
+				Description: "access key",
+			},
+		}, {
+			"secret-key", cloud.CredentialAttr{
+				Description: "secret key",
+				Hidden:      true,
+			},
+		}},
+		cloud.OAuth2AuthType: {{
+			"token", cloud.CredentialAttr{
+				Description: "bearer token",
+				Hidden:      true,
+			},
+		}},
+	}
+}
+
+// DetectCredentials is part of the environs.ProviderCredentials interface.
+func (environProviderCredentials) DetectCredentials() (*cloud.CloudCredential, error) {
+	return nil, errors.NotFoundf("credentials")
+}
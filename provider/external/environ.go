@@ -0,0 +1,158 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package external
+
+import (
+	"sync"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/provider/common"
+	"github.com/juju/juju/provider/external/plugin"
+)
+
+type environ struct {
+	cloud  environs.CloudSpec
+	caller plugin.Caller
+
+	lock sync.Mutex
+	cfg  *config.Config
+}
+
+var _ environs.Environ = (*environ)(nil)
+
+func newEnviron(spec environs.CloudSpec, ecfg *environConfig) (*environ, error) {
+	caller, err := plugin.NewCaller(spec.Endpoint, ecfg.allowInsecureHTTP())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &environ{
+		cloud:  spec,
+		caller: caller,
+		cfg:    ecfg.Config,
+	}, nil
+}
+
+// call calls the named plugin method with the given parameters,
+// decoding its result into result.
+func (env *environ) call(method string, params, result interface{}) error {
+	req := &plugin.Request{
+		Method: method,
+		Cloud: plugin.Cloud{
+			Name:     env.cloud.Name,
+			Region:   env.cloud.Region,
+			Endpoint: env.cloud.Endpoint,
+		},
+		ModelUUID: env.Config().UUID(),
+		Params:    params,
+	}
+	if env.cloud.Credential != nil {
+		req.Credential = plugin.Credential{
+			AuthType:   string(env.cloud.Credential.AuthType()),
+			Attributes: env.cloud.Credential.Attributes(),
+		}
+	}
+	return env.caller.Call(req, result)
+}
+
+// Provider implements environs.Environ.
+func (*environ) Provider() environs.EnvironProvider {
+	return providerInstance
+}
+
+// SetConfig implements environs.Environ.
+func (env *environ) SetConfig(cfg *config.Config) error {
+	env.lock.Lock()
+	defer env.lock.Unlock()
+	ecfg, err := newValidConfig(cfg, env.cfg)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	env.cfg = ecfg.Config
+	return nil
+}
+
+// Config implements environs.Environ.
+func (env *environ) Config() *config.Config {
+	env.lock.Lock()
+	defer env.lock.Unlock()
+	return env.cfg
+}
+
+// PrepareForBootstrap implements environs.Environ.
+func (env *environ) PrepareForBootstrap(ctx environs.BootstrapContext) error {
+	return nil
+}
+
+// Create implements environs.Environ.
+func (env *environ) Create(environs.CreateParams) error {
+	return nil
+}
+
+// Bootstrap implements environs.Environ.
+func (env *environ) Bootstrap(ctx environs.BootstrapContext, args environs.BootstrapParams) (*environs.BootstrapResult, error) {
+	return common.Bootstrap(ctx, env, args)
+}
+
+// ControllerInstances implements environs.Environ. Plugins report
+// the tags that instances were started with, which identify the
+// controller instances.
+func (env *environ) ControllerInstances(controllerUUID string) ([]instance.Id, error) {
+	instances, err := env.allInstances()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var ids []instance.Id
+	for _, inst := range instances {
+		if inst.info.Tags[tags.JujuIsController] == "true" && inst.info.Tags[tags.JujuController] == controllerUUID {
+			ids = append(ids, inst.Id())
+		}
+	}
+	if len(ids) == 0 {
+		return nil, environs.ErrNotBootstrapped
+	}
+	return ids, nil
+}
+
+// Destroy implements environs.Environ.
+func (env *environ) Destroy() error {
+	return common.Destroy(env)
+}
+
+// DestroyController implements environs.Environ. Plugins only report
+// the resources of the model that a request is made for, so hosted
+// models must have been destroyed before the controller model.
+func (env *environ) DestroyController(controllerUUID string) error {
+	return env.Destroy()
+}
+
+// PrecheckInstance implements environs.Environ. Plugins validate the
+// constraints and placement when starting instances.
+func (env *environ) PrecheckInstance(series string, cons constraints.Value, placement string) error {
+	return nil
+}
+
+// ConstraintsValidator implements environs.Environ. The unsupported
+// constraints and constraint vocabularies are those reported by the
+// plugin; plugins that do not report them accept all constraints.
+func (env *environ) ConstraintsValidator() (constraints.Validator, error) {
+	validator := constraints.NewValidator()
+	var result plugin.ConstraintsResult
+	err := env.call(plugin.MethodConstraints, nil, &result)
+	if errors.IsNotSupported(err) {
+		return validator, nil
+	} else if err != nil {
+		return nil, errors.Annotate(err, "getting plugin constraints")
+	}
+	validator.RegisterUnsupported(result.Unsupported)
+	for name, values := range result.Vocabulary {
+		validator.RegisterVocabulary(name, values)
+	}
+	return validator, nil
+}
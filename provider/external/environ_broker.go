@@ -0,0 +1,185 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package external
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/utils/arch"
+
+	"github.com/juju/juju/cloudconfig/instancecfg"
+	"github.com/juju/juju/cloudconfig/providerinit"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/provider/external/plugin"
+	"github.com/juju/juju/tools"
+)
+
+// MaintainInstance implements environs.InstanceBroker.
+func (*environ) MaintainInstance(args environs.StartInstanceParams) error {
+	return nil
+}
+
+// StartInstance implements environs.InstanceBroker.
+func (env *environ) StartInstance(args environs.StartInstanceParams) (*environs.StartInstanceResult, error) {
+	instanceArch, err := startInstanceArch(args)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	instanceTools, err := args.Tools.Match(tools.Filter{Arch: instanceArch})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := args.InstanceConfig.SetTools(instanceTools); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := instancecfg.FinishInstanceConfig(args.InstanceConfig, env.Config()); err != nil {
+		return nil, errors.Trace(err)
+	}
+	userData, err := providerinit.ComposeUserData(args.InstanceConfig, nil, externalRenderer{})
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot make user data")
+	}
+
+	params := plugin.StartInstanceParams{
+		ControllerUUID: args.ControllerUUID,
+		MachineId:      args.InstanceConfig.MachineId,
+		Series:         args.InstanceConfig.Series,
+		Arch:           instanceArch,
+		Constraints:    args.Constraints.String(),
+		Placement:      args.Placement,
+		UserData:       userData,
+		Tags:           args.InstanceConfig.Tags,
+	}
+	var result plugin.StartInstanceResult
+	if err := env.call(plugin.MethodStartInstance, params, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	inst := &environInstance{env, result.Instance}
+	logger.Infof("started instance %q", inst.Id())
+
+	hc := &instance.HardwareCharacteristics{Arch: &instanceArch}
+	if result.Hardware != nil {
+		hc = hardwareCharacteristics(*result.Hardware)
+		if hc.Arch == nil {
+			hc.Arch = &instanceArch
+		}
+	}
+	return &environs.StartInstanceResult{
+		Instance: inst,
+		Hardware: hc,
+	}, nil
+}
+
+// startInstanceArch returns the architecture of the instance to start:
+// the one required by the constraints if any, otherwise amd64 if there
+// are tools for it, and otherwise the first architecture with tools.
+func startInstanceArch(args environs.StartInstanceParams) (string, error) {
+	if args.Constraints.Arch != nil {
+		return *args.Constraints.Arch, nil
+	}
+	arches := args.Tools.Arches()
+	if len(arches) == 0 {
+		return "", errors.New("no tools available")
+	}
+	for _, a := range arches {
+		if a == arch.AMD64 {
+			return a, nil
+		}
+	}
+	return arches[0], nil
+}
+
+func hardwareCharacteristics(hw plugin.Hardware) *instance.HardwareCharacteristics {
+	var hc instance.HardwareCharacteristics
+	if hw.Arch != "" {
+		hc.Arch = &hw.Arch
+	}
+	if hw.Mem != 0 {
+		hc.Mem = &hw.Mem
+	}
+	if hw.RootDisk != 0 {
+		hc.RootDisk = &hw.RootDisk
+	}
+	if hw.CpuCores != 0 {
+		hc.CpuCores = &hw.CpuCores
+	}
+	if hw.CpuPower != 0 {
+		hc.CpuPower = &hw.CpuPower
+	}
+	if hw.AvailabilityZone != "" {
+		hc.AvailabilityZone = &hw.AvailabilityZone
+	}
+	return &hc
+}
+
+// StopInstances implements environs.InstanceBroker.
+func (env *environ) StopInstances(ids ...instance.Id) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	params := plugin.InstanceIdsParams{Ids: make([]string, len(ids))}
+	for i, id := range ids {
+		params.Ids[i] = string(id)
+	}
+	return errors.Trace(env.call(plugin.MethodStopInstances, params, nil))
+}
+
+// AllInstances implements environs.InstanceBroker.
+func (env *environ) AllInstances() ([]instance.Instance, error) {
+	instances, err := env.allInstances()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]instance.Instance, len(instances))
+	for i, inst := range instances {
+		result[i] = inst
+	}
+	return result, nil
+}
+
+func (env *environ) allInstances() ([]*environInstance, error) {
+	var result plugin.InstancesResult
+	if err := env.call(plugin.MethodAllInstances, nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	instances := make([]*environInstance, len(result.Instances))
+	for i, info := range result.Instances {
+		instances[i] = &environInstance{env, info}
+	}
+	return instances, nil
+}
+
+// Instances implements environs.Environ.
+func (env *environ) Instances(ids []instance.Id) ([]instance.Instance, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	params := plugin.InstanceIdsParams{Ids: make([]string, len(ids))}
+	for i, id := range ids {
+		params.Ids[i] = string(id)
+	}
+	var result plugin.InstancesResult
+	if err := env.call(plugin.MethodInstances, params, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	byId := make(map[instance.Id]*environInstance)
+	for _, info := range result.Instances {
+		byId[instance.Id(info.Id)] = &environInstance{env, info}
+	}
+	instances := make([]instance.Instance, len(ids))
+	var found int
+	for i, id := range ids {
+		if inst, ok := byId[id]; ok {
+			instances[i] = inst
+			found++
+		}
+	}
+	switch found {
+	case 0:
+		return nil, environs.ErrNoInstances
+	case len(ids):
+		return instances, nil
+	}
+	return instances, environs.ErrPartialInstances
+}
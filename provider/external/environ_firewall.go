@@ -0,0 +1,79 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package external
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/provider/external/plugin"
+)
+
+// OpenPorts implements environs.Firewaller.
+func (env *environ) OpenPorts(ports []network.PortRange) error {
+	return errors.Trace(env.openPorts("", ports))
+}
+
+// ClosePorts implements environs.Firewaller.
+func (env *environ) ClosePorts(ports []network.PortRange) error {
+	return errors.Trace(env.closePorts("", ports))
+}
+
+// Ports implements environs.Firewaller.
+func (env *environ) Ports() ([]network.PortRange, error) {
+	ports, err := env.ports("")
+	return ports, errors.Trace(err)
+}
+
+// openPorts opens ports on the instance with the given ID, or for
+// all instances if the ID is empty.
+func (env *environ) openPorts(instanceId string, ports []network.PortRange) error {
+	params := plugin.PortsParams{
+		InstanceId: instanceId,
+		Ports:      toPluginPorts(ports),
+	}
+	return errors.Trace(env.call(plugin.MethodOpenPorts, params, nil))
+}
+
+// closePorts closes ports on the instance with the given ID, or for
+// all instances if the ID is empty.
+func (env *environ) closePorts(instanceId string, ports []network.PortRange) error {
+	params := plugin.PortsParams{
+		InstanceId: instanceId,
+		Ports:      toPluginPorts(ports),
+	}
+	return errors.Trace(env.call(plugin.MethodClosePorts, params, nil))
+}
+
+// ports returns the ports open on the instance with the given ID, or
+// for all instances if the ID is empty.
+func (env *environ) ports(instanceId string) ([]network.PortRange, error) {
+	var result plugin.PortsResult
+	params := plugin.PortsParams{InstanceId: instanceId}
+	if err := env.call(plugin.MethodPorts, params, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	ports := make([]network.PortRange, len(result.Ports))
+	for i, p := range result.Ports {
+		ports[i] = network.PortRange{
+			FromPort: p.FromPort,
+			ToPort:   p.ToPort,
+			Protocol: p.Protocol,
+		}
+	}
+	network.SortPortRanges(ports)
+	return ports, nil
+}
+
+func toPluginPorts(ports []network.PortRange) []plugin.PortRange {
+	result := make([]plugin.PortRange, len(ports))
+	for i, p := range ports {
+		result[i] = plugin.PortRange{
+			FromPort: p.FromPort,
+			ToPort:   p.ToPort,
+			Protocol: p.Protocol,
+		}
+	}
+	return result
+}
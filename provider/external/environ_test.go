@@ -0,0 +1,264 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package external_test
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/arch"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/cloudconfig/instancecfg"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/imagemetadata"
+	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/provider/external/plugin"
+	"github.com/juju/juju/status"
+	"github.com/juju/juju/testing"
+	coretools "github.com/juju/juju/tools"
+)
+
+type environSuite struct {
+	baseSuite
+}
+
+var _ = gc.Suite(&environSuite{})
+
+func (s *environSuite) startInstanceParams(c *gc.C) environs.StartInstanceParams {
+	machineTag := names.NewMachineTag("1")
+	apiInfo := &api.Info{
+		Addrs:    []string{"localhost:17070"},
+		CACert:   testing.CACert,
+		Password: "admin",
+		Tag:      machineTag,
+		ModelTag: testing.ModelTag,
+	}
+	icfg, err := instancecfg.NewInstanceConfig(
+		machineTag.Id(), "yanonce", imagemetadata.ReleasedStream,
+		"xenial", true, apiInfo,
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	icfg.Tags = map[string]string{tags.JujuModel: s.config.UUID()}
+
+	var toolsList coretools.List
+	for _, a := range []string{arch.ARM64, arch.AMD64} {
+		toolsList = append(toolsList, &coretools.Tools{
+			Version: version.Binary{
+				Number: version.MustParse("2.0.0"),
+				Arch:   a,
+				Series: "xenial",
+			},
+			URL: fmt.Sprintf("http://example.com/tools/juju-2.0.0-xenial-%s.tgz", a),
+		})
+	}
+	return environs.StartInstanceParams{
+		ControllerUUID: testing.FakeControllerConfig().ControllerUUID(),
+		InstanceConfig: icfg,
+		Tools:          toolsList,
+		Constraints:    constraints.MustParse("mem=2G"),
+	}
+}
+
+func (s *environSuite) TestStartInstance(c *gc.C) {
+	s.plugin.results[plugin.MethodStartInstance] = plugin.StartInstanceResult{
+		Instance: plugin.Instance{
+			Id:     "i-1",
+			Status: "pending",
+			Addresses: []plugin.Address{
+				{Value: "10.0.0.1"},
+				{Value: "203.0.113.1", Scope: "public"},
+			},
+		},
+		Hardware: &plugin.Hardware{Mem: 2048, CpuCores: 1},
+	}
+	result, err := s.env.StartInstance(s.startInstanceParams(c))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Instance.Id(), gc.Equals, instance.Id("i-1"))
+	c.Assert(result.Instance.Status(), jc.DeepEquals, instance.InstanceStatus{
+		Status:  status.StatusPending,
+		Message: "pending",
+	})
+	addrs, err := result.Instance.Addresses()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(addrs, jc.DeepEquals, []network.Address{
+		network.NewScopedAddress("10.0.0.1", network.ScopeCloudLocal),
+		network.NewScopedAddress("203.0.113.1", network.ScopePublic),
+	})
+	c.Assert(*result.Hardware.Arch, gc.Equals, arch.AMD64)
+	c.Assert(*result.Hardware.Mem, gc.Equals, uint64(2048))
+	c.Assert(*result.Hardware.CpuCores, gc.Equals, uint64(1))
+
+	var params plugin.StartInstanceParams
+	req := s.plugin.lastParams(c, &params)
+	c.Assert(req.Method, gc.Equals, "start-instance")
+	c.Assert(req.ModelUUID, gc.Equals, s.config.UUID())
+	c.Assert(req.Cloud, jc.DeepEquals, plugin.Cloud{
+		Name:     "tiny",
+		Region:   "north",
+		Endpoint: s.spec.Endpoint,
+	})
+	c.Assert(req.Credential, jc.DeepEquals, plugin.Credential{
+		AuthType: "access-key",
+		Attributes: map[string]string{
+			"access-key": "key",
+			"secret-key": "secret",
+		},
+	})
+	c.Assert(params.MachineId, gc.Equals, "1")
+	c.Assert(params.Series, gc.Equals, "xenial")
+	c.Assert(params.Arch, gc.Equals, arch.AMD64)
+	c.Assert(params.Constraints, gc.Equals, "mem=2048M")
+	c.Assert(params.Tags[tags.JujuModel], gc.Equals, s.config.UUID())
+	c.Assert(string(params.UserData), jc.HasPrefix, "#cloud-config\n")
+	c.Assert(string(params.UserData), jc.Contains, "juju-2.0.0-xenial-amd64.tgz")
+}
+
+func (s *environSuite) TestStartInstanceArchConstraint(c *gc.C) {
+	args := s.startInstanceParams(c)
+	args.Constraints = constraints.MustParse("arch=arm64")
+	_, err := s.env.StartInstance(args)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var params plugin.StartInstanceParams
+	s.plugin.lastParams(c, &params)
+	c.Assert(params.Arch, gc.Equals, arch.ARM64)
+	c.Assert(string(params.UserData), jc.Contains, "juju-2.0.0-xenial-arm64.tgz")
+}
+
+func (s *environSuite) TestStartInstanceError(c *gc.C) {
+	s.plugin.errors[plugin.MethodStartInstance] = &plugin.Error{
+		Code:    plugin.CodeNotValid,
+		Message: `placement "zone=nowhere" not valid`,
+	}
+	_, err := s.env.StartInstance(s.startInstanceParams(c))
+	c.Assert(err, gc.ErrorMatches, `placement "zone=nowhere" not valid`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *environSuite) TestInstances(c *gc.C) {
+	s.plugin.results[plugin.MethodInstances] = plugin.InstancesResult{
+		Instances: []plugin.Instance{{Id: "i-2", Status: "running"}},
+	}
+	instances, err := s.env.Instances([]instance.Id{"i-1", "i-2"})
+	c.Assert(err, gc.Equals, environs.ErrPartialInstances)
+	c.Assert(instances[0], gc.IsNil)
+	c.Assert(instances[1].Id(), gc.Equals, instance.Id("i-2"))
+	c.Assert(instances[1].Status().Status, gc.Equals, status.StatusRunning)
+
+	var params plugin.InstanceIdsParams
+	s.plugin.lastParams(c, &params)
+	c.Assert(params.Ids, jc.DeepEquals, []string{"i-1", "i-2"})
+
+	_, err = s.env.Instances([]instance.Id{"i-3"})
+	c.Assert(err, gc.Equals, environs.ErrNoInstances)
+}
+
+func (s *environSuite) TestControllerInstances(c *gc.C) {
+	controllerUUID := testing.FakeControllerConfig().ControllerUUID()
+	s.plugin.results[plugin.MethodAllInstances] = plugin.InstancesResult{
+		Instances: []plugin.Instance{{
+			Id: "i-0",
+			Tags: map[string]string{
+				tags.JujuIsController: "true",
+				tags.JujuController:   controllerUUID,
+			},
+		}, {
+			Id:   "i-1",
+			Tags: map[string]string{tags.JujuController: controllerUUID},
+		}},
+	}
+	ids, err := s.env.ControllerInstances(controllerUUID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ids, jc.DeepEquals, []instance.Id{"i-0"})
+
+	_, err = s.env.ControllerInstances("other")
+	c.Assert(err, gc.Equals, environs.ErrNotBootstrapped)
+}
+
+func (s *environSuite) TestStopInstances(c *gc.C) {
+	err := s.env.StopInstances("i-1", "i-2")
+	c.Assert(err, jc.ErrorIsNil)
+	var params plugin.InstanceIdsParams
+	req := s.plugin.lastParams(c, &params)
+	c.Assert(req.Method, gc.Equals, "stop-instances")
+	c.Assert(params.Ids, jc.DeepEquals, []string{"i-1", "i-2"})
+}
+
+func (s *environSuite) TestInstancePorts(c *gc.C) {
+	s.plugin.results[plugin.MethodInstances] = plugin.InstancesResult{
+		Instances: []plugin.Instance{{Id: "i-1"}},
+	}
+	instances, err := s.env.Instances([]instance.Id{"i-1"})
+	c.Assert(err, jc.ErrorIsNil)
+	inst := instances[0]
+
+	err = inst.OpenPorts("1", []network.PortRange{{80, 80, "tcp"}})
+	c.Assert(err, jc.ErrorIsNil)
+	var params plugin.PortsParams
+	req := s.plugin.lastParams(c, &params)
+	c.Assert(req.Method, gc.Equals, "open-ports")
+	c.Assert(params, jc.DeepEquals, plugin.PortsParams{
+		InstanceId: "i-1",
+		Ports:      []plugin.PortRange{{80, 80, "tcp"}},
+	})
+
+	s.plugin.results[plugin.MethodPorts] = plugin.PortsResult{
+		Ports: []plugin.PortRange{{443, 443, "tcp"}, {53, 53, "udp"}, {80, 80, "tcp"}},
+	}
+	ports, err := inst.Ports("1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ports, jc.DeepEquals, []network.PortRange{
+		{80, 80, "tcp"}, {443, 443, "tcp"}, {53, 53, "udp"},
+	})
+}
+
+func (s *environSuite) TestGlobalPorts(c *gc.C) {
+	err := s.env.ClosePorts([]network.PortRange{{22, 22, "tcp"}})
+	c.Assert(err, jc.ErrorIsNil)
+	var params plugin.PortsParams
+	req := s.plugin.lastParams(c, &params)
+	c.Assert(req.Method, gc.Equals, "close-ports")
+	c.Assert(params, jc.DeepEquals, plugin.PortsParams{
+		Ports: []plugin.PortRange{{22, 22, "tcp"}},
+	})
+}
+
+func (s *environSuite) TestConstraintsValidator(c *gc.C) {
+	s.plugin.results["constraints"] = plugin.ConstraintsResult{
+		Unsupported: []string{"tags"},
+		Vocabulary: map[string][]interface{}{
+			"instance-type": {"small", "large"},
+		},
+	}
+	validator, err := s.env.ConstraintsValidator()
+	c.Assert(err, jc.ErrorIsNil)
+	req := s.plugin.lastParams(c, nil)
+	c.Assert(req.Method, gc.Equals, "constraints")
+
+	unsupported, err := validator.Validate(constraints.MustParse("tags=foo mem=1G"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unsupported, jc.SameContents, []string{"tags"})
+	_, err = validator.Validate(constraints.MustParse("instance-type=huge"))
+	c.Assert(err, gc.ErrorMatches, "invalid constraint value: instance-type=huge\nvalid values are:.*")
+}
+
+func (s *environSuite) TestConstraintsValidatorNotSupported(c *gc.C) {
+	s.plugin.errors["constraints"] = &plugin.Error{
+		Code:    plugin.CodeNotSupported,
+		Message: "constraints not supported",
+	}
+	validator, err := s.env.ConstraintsValidator()
+	c.Assert(err, jc.ErrorIsNil)
+	unsupported, err := validator.Validate(constraints.MustParse("tags=foo"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unsupported, gc.HasLen, 0)
+}
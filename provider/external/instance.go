@@ -0,0 +1,71 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package external
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/provider/external/plugin"
+	"github.com/juju/juju/status"
+)
+
+type environInstance struct {
+	env  *environ
+	info plugin.Instance
+}
+
+var _ instance.Instance = (*environInstance)(nil)
+
+// Id implements instance.Instance.
+func (inst *environInstance) Id() instance.Id {
+	return instance.Id(inst.info.Id)
+}
+
+// Status implements instance.Instance.
+func (inst *environInstance) Status() instance.InstanceStatus {
+	jujuStatus := status.StatusEmpty
+	switch s := status.Status(inst.info.Status); s {
+	case status.StatusPending,
+		status.StatusAllocating,
+		status.StatusRunning,
+		status.StatusProvisioningError:
+		jujuStatus = s
+	}
+	message := inst.info.Message
+	if message == "" {
+		message = inst.info.Status
+	}
+	return instance.InstanceStatus{
+		Status:  jujuStatus,
+		Message: message,
+	}
+}
+
+// Addresses implements instance.Instance. The scope of addresses
+// reported without one is derived from the address.
+func (inst *environInstance) Addresses() ([]network.Address, error) {
+	addresses := make([]network.Address, len(inst.info.Addresses))
+	for i, addr := range inst.info.Addresses {
+		addresses[i] = network.NewScopedAddress(addr.Value, network.Scope(addr.Scope))
+	}
+	return addresses, nil
+}
+
+// OpenPorts implements instance.Instance.
+func (inst *environInstance) OpenPorts(machineId string, ports []network.PortRange) error {
+	return errors.Trace(inst.env.openPorts(inst.info.Id, ports))
+}
+
+// ClosePorts implements instance.Instance.
+func (inst *environInstance) ClosePorts(machineId string, ports []network.PortRange) error {
+	return errors.Trace(inst.env.closePorts(inst.info.Id, ports))
+}
+
+// Ports implements instance.Instance.
+func (inst *environInstance) Ports(machineId string) ([]network.PortRange, error) {
+	ports, err := inst.env.ports(inst.info.Id)
+	return ports, errors.Trace(err)
+}
@@ -0,0 +1,170 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package plugin
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils"
+)
+
+var logger = loggo.GetLogger("juju.provider.external.plugin")
+
+// callTimeout is the maximum time that a call to a plugin may take.
+// Calls that take longer fail, and executable plugins are killed.
+var callTimeout = 10 * time.Minute
+
+// Caller calls methods on a plugin.
+type Caller interface {
+	// Call sends the request to the plugin, and decodes the result
+	// into result, which may be nil if the method has no result.
+	Call(req *Request, result interface{}) error
+}
+
+// NewCaller returns a Caller for the plugin at the given endpoint,
+// which must be an https URL, a file URL, or an absolute path to an
+// executable. Requests carry the cloud credential, so an http URL
+// is accepted only if allowInsecureHTTP is true.
+func NewCaller(endpoint string, allowInsecureHTTP bool) (Caller, error) {
+	if filepath.IsAbs(endpoint) {
+		return &execCaller{endpoint}, nil
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, errors.NotValidf("plugin endpoint %q", endpoint)
+	}
+	switch u.Scheme {
+	case "http":
+		if !allowInsecureHTTP {
+			return nil, errors.NotValidf("insecure plugin endpoint %q", endpoint)
+		}
+		fallthrough
+	case "https":
+		client := *utils.GetValidatingHTTPClient()
+		client.Timeout = callTimeout
+		return &httpCaller{endpoint, &client}, nil
+	case "file":
+		if !filepath.IsAbs(u.Path) {
+			return nil, errors.NotValidf("plugin endpoint %q", endpoint)
+		}
+		return &execCaller{u.Path}, nil
+	}
+	return nil, errors.NotValidf("plugin endpoint %q", endpoint)
+}
+
+type httpCaller struct {
+	url    string
+	client *http.Client
+}
+
+// Call implements Caller.
+func (c *httpCaller) Call(req *Request, result interface{}) error {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	resp, err := c.client.Post(c.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return errors.Annotatef(err, "calling plugin method %q", req.Method)
+	}
+	defer resp.Body.Close()
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Annotatef(err, "reading plugin response")
+	}
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf(
+			"calling plugin method %q: %s: %s",
+			req.Method, resp.Status, strings.TrimSpace(string(respBody)),
+		)
+	}
+	return errors.Trace(decodeResponse(req.Method, respBody, result))
+}
+
+type execCaller struct {
+	path string
+}
+
+// Call implements Caller.
+func (c *execCaller) Call(req *Request, result interface{}) error {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(c.path, req.Method)
+	cmd.Stdin = bytes.NewReader(body)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Start(); err != nil {
+		return errors.Annotatef(err, "calling plugin method %q", req.Method)
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- cmd.Wait()
+	}()
+	select {
+	case err = <-done:
+	case <-time.After(callTimeout):
+		if err := cmd.Process.Kill(); err != nil {
+			logger.Warningf("killing plugin %q: %v", c.path, err)
+		}
+		<-done
+		return errors.Errorf("calling plugin method %q: timed out after %v", req.Method, callTimeout)
+	}
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			err = errors.New(msg)
+		}
+		return errors.Annotatef(err, "calling plugin method %q", req.Method)
+	}
+	return errors.Trace(decodeResponse(req.Method, stdout.Bytes(), result))
+}
+
+func decodeResponse(method string, body []byte, result interface{}) error {
+	var resp Response
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return errors.Annotatef(err, "decoding plugin response for method %q", method)
+	}
+	if resp.Error != nil {
+		return ToError(resp.Error)
+	}
+	if result == nil || len(resp.Result) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(resp.Result, result); err != nil {
+		return errors.Annotatef(err, "decoding plugin result for method %q", method)
+	}
+	return nil
+}
+
+// ToError returns the error corresponding to the given plugin error,
+// or nil if it is nil. Errors with known codes are converted so that
+// they satisfy errors.IsNotFound and friends.
+func ToError(e *Error) error {
+	if e == nil {
+		return nil
+	}
+	switch e.Code {
+	case CodeNotFound:
+		return errors.NewNotFound(nil, e.Message)
+	case CodeNotSupported:
+		return errors.NewNotSupported(nil, e.Message)
+	case CodeNotValid:
+		return errors.NewNotValid(nil, e.Message)
+	case CodeUnauthorized:
+		return errors.NewUnauthorized(nil, e.Message)
+	}
+	return e
+}
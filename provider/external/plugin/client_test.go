@@ -0,0 +1,149 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package plugin_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/provider/external/plugin"
+	"github.com/juju/juju/testing"
+)
+
+type clientSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+func (s *clientSuite) TestNewCallerInvalidEndpoint(c *gc.C) {
+	for _, endpoint := range []string{"", "plugin", "ftp://example.com", "file:plugin"} {
+		_, err := plugin.NewCaller(endpoint, true)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+	}
+}
+
+func (s *clientSuite) TestNewCallerInsecureEndpoint(c *gc.C) {
+	_, err := plugin.NewCaller("http://example.com/plugin", false)
+	c.Assert(err, gc.ErrorMatches, `insecure plugin endpoint "http://example.com/plugin" not valid`)
+	_, err = plugin.NewCaller("https://example.com/plugin", false)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *clientSuite) TestHTTPCall(c *gc.C) {
+	var req plugin.Request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Check(r.Method, gc.Equals, "POST")
+		err := json.NewDecoder(r.Body).Decode(&req)
+		c.Check(err, jc.ErrorIsNil)
+		fmt.Fprint(w, `{"result": {"ids": ["vol-0"]}}`)
+	}))
+	defer server.Close()
+
+	caller, err := plugin.NewCaller(server.URL, true)
+	c.Assert(err, jc.ErrorIsNil)
+	var result plugin.VolumeIdsResult
+	err = caller.Call(&plugin.Request{
+		Method:    plugin.MethodListVolumes,
+		ModelUUID: "model-uuid",
+	}, &result)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Ids, jc.DeepEquals, []string{"vol-0"})
+	c.Assert(req.Method, gc.Equals, "list-volumes")
+	c.Assert(req.ModelUUID, gc.Equals, "model-uuid")
+}
+
+func (s *clientSuite) TestHTTPCallError(c *gc.C) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"error": {"code": "not-supported", "message": "volumes not supported"}}`)
+	}))
+	defer server.Close()
+
+	caller, err := plugin.NewCaller(server.URL, true)
+	c.Assert(err, jc.ErrorIsNil)
+	err = caller.Call(&plugin.Request{Method: plugin.MethodListVolumes}, nil)
+	c.Assert(err, gc.ErrorMatches, "volumes not supported")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *clientSuite) TestHTTPCallStatus(c *gc.C) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "boom", http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	caller, err := plugin.NewCaller(server.URL, true)
+	c.Assert(err, jc.ErrorIsNil)
+	err = caller.Call(&plugin.Request{Method: plugin.MethodAllInstances}, nil)
+	c.Assert(err, gc.ErrorMatches, `calling plugin method "all-instances": 500 Internal Server Error: boom`)
+}
+
+func (s *clientSuite) TestExecCall(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("executable plugins are tested with a shell script")
+	}
+	path := filepath.Join(c.MkDir(), "plugin")
+	script := `#!/bin/sh
+cat > "$(dirname "$0")/request"
+echo '{"result": {"instances": [{"id": "'$1'", "status": "running"}]}}'
+`
+	err := ioutil.WriteFile(path, []byte(script), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+
+	caller, err := plugin.NewCaller("file://"+path, false)
+	c.Assert(err, jc.ErrorIsNil)
+	var result plugin.InstancesResult
+	err = caller.Call(&plugin.Request{Method: plugin.MethodAllInstances}, &result)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Instances, jc.DeepEquals, []plugin.Instance{{
+		Id:     "all-instances",
+		Status: "running",
+	}})
+
+	data, err := ioutil.ReadFile(filepath.Join(filepath.Dir(path), "request"))
+	c.Assert(err, jc.ErrorIsNil)
+	var req plugin.Request
+	err = json.Unmarshal(data, &req)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(req.Method, gc.Equals, "all-instances")
+}
+
+func (s *clientSuite) TestExecCallFailure(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("executable plugins are tested with a shell script")
+	}
+	path := filepath.Join(c.MkDir(), "plugin")
+	err := ioutil.WriteFile(path, []byte("#!/bin/sh\necho 'no cloud' >&2\nexit 1\n"), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+
+	caller, err := plugin.NewCaller(path, false)
+	c.Assert(err, jc.ErrorIsNil)
+	err = caller.Call(&plugin.Request{Method: plugin.MethodAllInstances}, nil)
+	c.Assert(err, gc.ErrorMatches, `calling plugin method "all-instances": no cloud`)
+}
+
+func (s *clientSuite) TestExecCallTimeout(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("executable plugins are tested with a shell script")
+	}
+	s.PatchValue(plugin.CallTimeout, 100*time.Millisecond)
+	path := filepath.Join(c.MkDir(), "plugin")
+	err := ioutil.WriteFile(path, []byte("#!/bin/sh\nexec sleep 60\n"), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+
+	caller, err := plugin.NewCaller(path, false)
+	c.Assert(err, jc.ErrorIsNil)
+	err = caller.Call(&plugin.Request{Method: plugin.MethodAllInstances}, nil)
+	c.Assert(err, gc.ErrorMatches, `calling plugin method "all-instances": timed out after 100ms`)
+}
@@ -0,0 +1,325 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package plugin defines the JSON protocol spoken between the
+// "external" provider and the plugins that manage the cloud resources
+// on its behalf, and a client for calling plugins.
+//
+// Each call is a single request/response exchange. The request is a
+// JSON-encoded Request, and the response a JSON-encoded Response. A
+// plugin is either:
+//
+//   - an HTTPS endpoint, to which the request is POSTed, and which
+//     must respond with status 200 and the response in the body
+//     (plain HTTP endpoints must be explicitly allowed, as requests
+//     carry the cloud credential); or
+//   - an executable, identified by a file: URL or an absolute path,
+//     which is run with the method name as its only argument, is given
+//     the request on stdin, and must write the response to stdout.
+//
+// Errors that are specific to a call are reported with a Response
+// whose Error field is set; any other failure, such as an HTTP status
+// other than 200 or an executable exiting non-zero, is reported as a
+// failure of the plugin itself. Calls that take longer than ten minutes
+// fail, and executable plugins are killed.
+//
+// Plugins are called by the Juju client when bootstrapping, and by the
+// controller thereafter, so executable plugins must be installed on
+// both the client and the controller machines.
+//
+// The methods, with their parameters and results, are:
+//
+//	start-instance      StartInstanceParams       StartInstanceResult
+//	stop-instances      InstanceIdsParams         (none)
+//	instances           InstanceIdsParams         InstancesResult
+//	all-instances       (none)                    InstancesResult
+//	open-ports          PortsParams               (none)
+//	close-ports         PortsParams               (none)
+//	ports               PortsParams               PortsResult
+//	create-volumes      CreateVolumesParams       VolumesResult
+//	list-volumes        (none)                    VolumeIdsResult
+//	describe-volumes    VolumeIdsParams           VolumesResult
+//	destroy-volumes     VolumeIdsParams           ErrorsResult
+//	attach-volumes      AttachVolumesParams       VolumeAttachmentsResult
+//	detach-volumes      AttachVolumesParams       ErrorsResult
+//	constraints         (none)                    ConstraintsResult
+//
+// A plugin that does not support a method must respond with an error
+// with the code CodeNotSupported.
+package plugin
+
+import "encoding/json"
+
+// Method names.
+const (
+	MethodStartInstance   = "start-instance"
+	MethodStopInstances   = "stop-instances"
+	MethodInstances       = "instances"
+	MethodAllInstances    = "all-instances"
+	MethodOpenPorts       = "open-ports"
+	MethodClosePorts      = "close-ports"
+	MethodPorts           = "ports"
+	MethodCreateVolumes   = "create-volumes"
+	MethodListVolumes     = "list-volumes"
+	MethodDescribeVolumes = "describe-volumes"
+	MethodDestroyVolumes  = "destroy-volumes"
+	MethodAttachVolumes   = "attach-volumes"
+	MethodDetachVolumes   = "detach-volumes"
+	MethodConstraints     = "constraints"
+)
+
+// Error codes. A plugin may report errors with other codes, or with
+// no code, which are treated as generic errors.
+const (
+	CodeNotFound     = "not-found"
+	CodeNotSupported = "not-supported"
+	CodeNotValid     = "not-valid"
+	CodeUnauthorized = "unauthorized"
+)
+
+// Request is a request sent to a plugin.
+type Request struct {
+	// Method is the name of the method being called.
+	Method string `json:"method"`
+
+	// Cloud describes the cloud that the model is running in.
+	Cloud Cloud `json:"cloud"`
+
+	// Credential holds the credential with which to access the cloud.
+	Credential Credential `json:"credential"`
+
+	// ModelUUID is the UUID of the model that the request is made for.
+	// Plugins should only report resources belonging to this model.
+	ModelUUID string `json:"model-uuid"`
+
+	// Params holds the method's parameters, if any.
+	Params interface{} `json:"params,omitempty"`
+}
+
+// Cloud describes a cloud region.
+type Cloud struct {
+	Name     string `json:"name"`
+	Region   string `json:"region,omitempty"`
+	Endpoint string `json:"endpoint"`
+}
+
+// Credential holds a cloud credential.
+type Credential struct {
+	AuthType   string            `json:"auth-type"`
+	Attributes map[string]string `json:"attributes,omitempty"`
+}
+
+// Response is the response to a request.
+type Response struct {
+	// Result holds the method's result, if any.
+	Result json.RawMessage `json:"result,omitempty"`
+
+	// Error, if non-nil, holds the error that caused the call to fail.
+	Error *Error `json:"error,omitempty"`
+}
+
+// Error is an error reported by a plugin.
+type Error struct {
+	Code    string `json:"code,omitempty"`
+	Message string `json:"message"`
+}
+
+// Error implements error.
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Hardware describes the hardware characteristics of an instance.
+type Hardware struct {
+	Arch             string `json:"arch,omitempty"`
+	Mem              uint64 `json:"mem,omitempty"`
+	RootDisk         uint64 `json:"root-disk,omitempty"`
+	CpuCores         uint64 `json:"cpu-cores,omitempty"`
+	CpuPower         uint64 `json:"cpu-power,omitempty"`
+	AvailabilityZone string `json:"availability-zone,omitempty"`
+}
+
+// Address is a network address of an instance. Scope is one of
+// "public", "local-cloud", "local-machine" or "link-local".
+type Address struct {
+	Value string `json:"value"`
+	Scope string `json:"scope,omitempty"`
+}
+
+// Instance describes an instance.
+type Instance struct {
+	Id string `json:"id"`
+
+	// Status is the instance's status, one of "pending", "allocating",
+	// "running" or "provisioning error". Message optionally describes
+	// the status in more detail.
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+
+	Addresses []Address `json:"addresses,omitempty"`
+
+	// Tags holds the tags that the instance was started with.
+	Tags map[string]string `json:"tags,omitempty"`
+}
+
+// StartInstanceParams holds the parameters for start-instance.
+type StartInstanceParams struct {
+	ControllerUUID string `json:"controller-uuid"`
+	MachineId      string `json:"machine-id"`
+	Series         string `json:"series"`
+
+	// Arch is the architecture that the instance must have; the
+	// agent binaries in the user-data are for this architecture.
+	Arch string `json:"arch"`
+
+	// Constraints holds the machine's constraints, in the format
+	// accepted by "juju add-machine --constraints".
+	Constraints string `json:"constraints,omitempty"`
+	Placement   string `json:"placement,omitempty"`
+
+	// UserData holds the cloud-init user-data to start the instance
+	// with.
+	UserData []byte `json:"user-data"`
+
+	// Tags holds the tags that the instance must be started with,
+	// and reported with.
+	Tags map[string]string `json:"tags,omitempty"`
+}
+
+// StartInstanceResult holds the result of start-instance.
+type StartInstanceResult struct {
+	Instance Instance  `json:"instance"`
+	Hardware *Hardware `json:"hardware,omitempty"`
+}
+
+// InstanceIdsParams holds the parameters for methods that operate
+// on instances.
+type InstanceIdsParams struct {
+	Ids []string `json:"ids"`
+}
+
+// InstancesResult holds the result of instances and all-instances.
+// For instances, any instances that do not exist are omitted.
+type InstancesResult struct {
+	Instances []Instance `json:"instances"`
+}
+
+// PortRange is a range of ports. Protocol is one of "tcp", "udp"
+// or "icmp".
+type PortRange struct {
+	FromPort int    `json:"from-port"`
+	ToPort   int    `json:"to-port"`
+	Protocol string `json:"protocol"`
+}
+
+// PortsParams holds the parameters for open-ports, close-ports and
+// ports. If InstanceId is empty, the ports are those open for all
+// of the model's instances.
+type PortsParams struct {
+	InstanceId string      `json:"instance-id,omitempty"`
+	Ports      []PortRange `json:"ports,omitempty"`
+}
+
+// PortsResult holds the result of ports.
+type PortsResult struct {
+	Ports []PortRange `json:"ports"`
+}
+
+// VolumeParams describes a volume to create.
+type VolumeParams struct {
+	// Name is the volume's Juju name, e.g. "0" or "1/2".
+	Name       string                 `json:"name"`
+	Size       uint64                 `json:"size"`
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+	Tags       map[string]string      `json:"tags,omitempty"`
+
+	// InstanceId, if non-empty, is the ID of the instance that the
+	// volume should be attached to once created.
+	InstanceId string `json:"instance-id,omitempty"`
+}
+
+// CreateVolumesParams holds the parameters for create-volumes.
+type CreateVolumesParams struct {
+	Volumes []VolumeParams `json:"volumes"`
+}
+
+// Volume describes a volume. Size is in MiB.
+type Volume struct {
+	Id         string `json:"id"`
+	HardwareId string `json:"hardware-id,omitempty"`
+	Size       uint64 `json:"size"`
+	Persistent bool   `json:"persistent"`
+}
+
+// VolumeAttachment describes how a volume is attached to an instance.
+type VolumeAttachment struct {
+	VolumeId   string `json:"volume-id"`
+	InstanceId string `json:"instance-id"`
+	DeviceName string `json:"device-name,omitempty"`
+	DeviceLink string `json:"device-link,omitempty"`
+	BusAddress string `json:"bus-address,omitempty"`
+	ReadOnly   bool   `json:"read-only,omitempty"`
+}
+
+// VolumeResult holds the result of creating or describing a volume.
+type VolumeResult struct {
+	Volume *Volume `json:"volume,omitempty"`
+
+	// Attachment, for create-volumes, describes the volume's
+	// attachment if it was requested.
+	Attachment *VolumeAttachment `json:"attachment,omitempty"`
+	Error      *Error            `json:"error,omitempty"`
+}
+
+// VolumesResult holds the result of create-volumes and
+// describe-volumes, with one entry for each volume in the request.
+type VolumesResult struct {
+	Results []VolumeResult `json:"results"`
+}
+
+// VolumeIdsParams holds the parameters for methods that operate
+// on volumes.
+type VolumeIdsParams struct {
+	Ids []string `json:"ids"`
+}
+
+// VolumeIdsResult holds the result of list-volumes.
+type VolumeIdsResult struct {
+	Ids []string `json:"ids"`
+}
+
+// AttachVolumesParams holds the parameters for attach-volumes and
+// detach-volumes.
+type AttachVolumesParams struct {
+	Attachments []VolumeAttachment `json:"attachments"`
+}
+
+// VolumeAttachmentResult holds the result of attaching a volume.
+type VolumeAttachmentResult struct {
+	Attachment *VolumeAttachment `json:"attachment,omitempty"`
+	Error      *Error            `json:"error,omitempty"`
+}
+
+// VolumeAttachmentsResult holds the result of attach-volumes, with
+// one entry for each attachment in the request.
+type VolumeAttachmentsResult struct {
+	Results []VolumeAttachmentResult `json:"results"`
+}
+
+// ErrorsResult holds the result of methods that report an error,
+// or nil, for each entity in the request.
+type ErrorsResult struct {
+	Errors []*Error `json:"errors"`
+}
+
+// ConstraintsResult holds the result of constraints, describing the
+// constraints that the plugin supports.
+type ConstraintsResult struct {
+	// Unsupported holds the names of the constraints that the
+	// plugin does not support.
+	Unsupported []string `json:"unsupported,omitempty"`
+
+	// Vocabulary maps constraint names to the values that the
+	// plugin accepts for them.
+	Vocabulary map[string][]interface{} `json:"vocabulary,omitempty"`
+}
@@ -0,0 +1,100 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package external
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/schema"
+	"gopkg.in/juju/environschema.v1"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/provider/external/plugin"
+)
+
+var logger = loggo.GetLogger("juju.provider.external")
+
+// environProvider is a provider that delegates the management of
+// instances, firewalls and volumes to a plugin. The cloud's endpoint
+// identifies the plugin; see the plugin package for the protocol.
+type environProvider struct {
+	environProviderCredentials
+}
+
+var providerInstance environProvider
+
+var _ environs.EnvironProvider = providerInstance
+
+// Open implements environs.EnvironProvider.
+func (environProvider) Open(args environs.OpenParams) (environs.Environ, error) {
+	ecfg, err := newValidConfig(args.Config, nil)
+	if err != nil {
+		return nil, errors.Annotate(err, "invalid config")
+	}
+	if err := validateCloudSpec(args.Cloud, ecfg); err != nil {
+		return nil, errors.Annotate(err, "validating cloud spec")
+	}
+	env, err := newEnviron(args.Cloud, ecfg)
+	return env, errors.Trace(err)
+}
+
+// PrepareConfig implements environs.EnvironProvider.
+func (p environProvider) PrepareConfig(args environs.PrepareConfigParams) (*config.Config, error) {
+	cfg, err := p.Validate(args.Config, nil)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ecfg, err := newValidConfig(cfg, nil)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := validateCloudSpec(args.Cloud, ecfg); err != nil {
+		return nil, errors.Annotate(err, "validating cloud spec")
+	}
+	return cfg, nil
+}
+
+// Validate implements environs.EnvironProvider.
+func (environProvider) Validate(cfg, old *config.Config) (*config.Config, error) {
+	ecfg, err := newValidConfig(cfg, old)
+	if err != nil {
+		return nil, errors.Annotate(err, "invalid config")
+	}
+	return cfg.Apply(ecfg.attrs)
+}
+
+// Schema returns the configuration schema for an environment.
+func (environProvider) Schema() environschema.Fields {
+	fields, err := config.Schema(configSchema)
+	if err != nil {
+		panic(err)
+	}
+	return fields
+}
+
+// ConfigSchema returns extra config attributes specific
+// to this provider only.
+func (environProvider) ConfigSchema() schema.Fields {
+	return configFields
+}
+
+// ConfigDefaults returns the default values for the
+// provider specific config attributes.
+func (environProvider) ConfigDefaults() schema.Defaults {
+	return configDefaults
+}
+
+func validateCloudSpec(spec environs.CloudSpec, ecfg *environConfig) error {
+	if err := spec.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	if spec.Endpoint == "" {
+		return errors.NotValidf("missing plugin endpoint")
+	}
+	if _, err := plugin.NewCaller(spec.Endpoint, ecfg.allowInsecureHTTP()); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package external_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs"
+)
+
+type providerSuite struct {
+	baseSuite
+}
+
+var _ = gc.Suite(&providerSuite{})
+
+func (s *providerSuite) TestOpenMissingEndpoint(c *gc.C) {
+	s.spec.Endpoint = ""
+	s.testOpenError(c, "validating cloud spec: missing plugin endpoint not valid")
+}
+
+func (s *providerSuite) TestOpenInvalidEndpoint(c *gc.C) {
+	s.spec.Endpoint = "relative/plugin"
+	s.testOpenError(c, `validating cloud spec: plugin endpoint "relative/plugin" not valid`)
+}
+
+func (s *providerSuite) TestOpenInsecureEndpoint(c *gc.C) {
+	var err error
+	s.config, err = s.config.Apply(map[string]interface{}{"allow-insecure-http": false})
+	c.Assert(err, jc.ErrorIsNil)
+	s.spec.Endpoint = "http://example.com/plugin"
+	s.testOpenError(c, `validating cloud spec: insecure plugin endpoint "http://example.com/plugin" not valid`)
+}
+
+func (s *providerSuite) TestOpenExecutableEndpoint(c *gc.C) {
+	s.spec.Endpoint = "/usr/local/bin/juju-cloud-plugin"
+	_, err := s.env.Provider().Open(environs.OpenParams{
+		Cloud:  s.spec,
+		Config: s.config,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *providerSuite) testOpenError(c *gc.C, expect string) {
+	_, err := s.env.Provider().Open(environs.OpenParams{
+		Cloud:  s.spec,
+		Config: s.config,
+	})
+	c.Assert(err, gc.ErrorMatches, expect)
+}
@@ -0,0 +1,252 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package external
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/provider/external/plugin"
+	"github.com/juju/juju/storage"
+)
+
+const (
+	storageProviderType = storage.ProviderType("external")
+)
+
+// StorageProviderTypes implements storage.ProviderRegistry.
+func (env *environ) StorageProviderTypes() []storage.ProviderType {
+	return []storage.ProviderType{storageProviderType}
+}
+
+// StorageProvider implements storage.ProviderRegistry.
+func (env *environ) StorageProvider(t storage.ProviderType) (storage.Provider, error) {
+	if t == storageProviderType {
+		return &storageProvider{env}, nil
+	}
+	return nil, errors.NotFoundf("storage provider %q", t)
+}
+
+// storageProvider provides volumes managed by the plugin. Storage pool
+// attributes are passed to the plugin, which is responsible for
+// validating them when volumes are created.
+type storageProvider struct {
+	env *environ
+}
+
+var _ storage.Provider = (*storageProvider)(nil)
+
+// ValidateConfig implements storage.Provider.
+func (p *storageProvider) ValidateConfig(cfg *storage.Config) error {
+	return nil
+}
+
+// Supports implements storage.Provider.
+func (p *storageProvider) Supports(k storage.StorageKind) bool {
+	return k == storage.StorageKindBlock
+}
+
+// Scope implements storage.Provider.
+func (p *storageProvider) Scope() storage.Scope {
+	return storage.ScopeEnviron
+}
+
+// Dynamic implements storage.Provider.
+func (p *storageProvider) Dynamic() bool {
+	return true
+}
+
+// DefaultPools implements storage.Provider.
+func (p *storageProvider) DefaultPools() []*storage.Config {
+	return nil
+}
+
+// FilesystemSource implements storage.Provider.
+func (p *storageProvider) FilesystemSource(providerConfig *storage.Config) (storage.FilesystemSource, error) {
+	return nil, errors.NotSupportedf("filesystems")
+}
+
+// VolumeSource implements storage.Provider.
+func (p *storageProvider) VolumeSource(providerConfig *storage.Config) (storage.VolumeSource, error) {
+	return &volumeSource{p.env}, nil
+}
+
+type volumeSource struct {
+	env *environ
+}
+
+var _ storage.VolumeSource = (*volumeSource)(nil)
+
+// CreateVolumes implements storage.VolumeSource.
+func (v *volumeSource) CreateVolumes(params []storage.VolumeParams) ([]storage.CreateVolumesResult, error) {
+	args := plugin.CreateVolumesParams{
+		Volumes: make([]plugin.VolumeParams, len(params)),
+	}
+	for i, p := range params {
+		args.Volumes[i] = plugin.VolumeParams{
+			Name:       p.Tag.Id(),
+			Size:       p.Size,
+			Attributes: p.Attributes,
+			Tags:       p.ResourceTags,
+		}
+		if p.Attachment != nil {
+			args.Volumes[i].InstanceId = string(p.Attachment.InstanceId)
+		}
+	}
+	var result plugin.VolumesResult
+	if err := v.env.call(plugin.MethodCreateVolumes, args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(result.Results) != len(params) {
+		return nil, errors.Errorf("expected %d results, got %d", len(params), len(result.Results))
+	}
+	results := make([]storage.CreateVolumesResult, len(params))
+	for i, r := range result.Results {
+		if r.Error != nil {
+			results[i].Error = plugin.ToError(r.Error)
+			continue
+		}
+		if r.Volume == nil {
+			results[i].Error = errors.New("plugin returned no volume")
+			continue
+		}
+		results[i].Volume = &storage.Volume{
+			Tag:        params[i].Tag,
+			VolumeInfo: volumeInfo(*r.Volume),
+		}
+		if r.Attachment != nil && params[i].Attachment != nil {
+			results[i].VolumeAttachment = &storage.VolumeAttachment{
+				Volume:               params[i].Tag,
+				Machine:              params[i].Attachment.Machine,
+				VolumeAttachmentInfo: volumeAttachmentInfo(*r.Attachment),
+			}
+		}
+	}
+	return results, nil
+}
+
+// ListVolumes implements storage.VolumeSource.
+func (v *volumeSource) ListVolumes() ([]string, error) {
+	var result plugin.VolumeIdsResult
+	if err := v.env.call(plugin.MethodListVolumes, nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Ids, nil
+}
+
+// DescribeVolumes implements storage.VolumeSource.
+func (v *volumeSource) DescribeVolumes(volIds []string) ([]storage.DescribeVolumesResult, error) {
+	var result plugin.VolumesResult
+	if err := v.env.call(plugin.MethodDescribeVolumes, plugin.VolumeIdsParams{Ids: volIds}, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(result.Results) != len(volIds) {
+		return nil, errors.Errorf("expected %d results, got %d", len(volIds), len(result.Results))
+	}
+	results := make([]storage.DescribeVolumesResult, len(volIds))
+	for i, r := range result.Results {
+		switch {
+		case r.Error != nil:
+			results[i].Error = plugin.ToError(r.Error)
+		case r.Volume == nil:
+			results[i].Error = errors.NotFoundf("volume %q", volIds[i])
+		default:
+			info := volumeInfo(*r.Volume)
+			results[i].VolumeInfo = &info
+		}
+	}
+	return results, nil
+}
+
+// DestroyVolumes implements storage.VolumeSource.
+func (v *volumeSource) DestroyVolumes(volIds []string) ([]error, error) {
+	var result plugin.ErrorsResult
+	if err := v.env.call(plugin.MethodDestroyVolumes, plugin.VolumeIdsParams{Ids: volIds}, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return toErrors(result, len(volIds))
+}
+
+// ValidateVolumeParams implements storage.VolumeSource.
+func (v *volumeSource) ValidateVolumeParams(params storage.VolumeParams) error {
+	return nil
+}
+
+// AttachVolumes implements storage.VolumeSource.
+func (v *volumeSource) AttachVolumes(params []storage.VolumeAttachmentParams) ([]storage.AttachVolumesResult, error) {
+	var result plugin.VolumeAttachmentsResult
+	if err := v.env.call(plugin.MethodAttachVolumes, attachVolumesParams(params), &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(result.Results) != len(params) {
+		return nil, errors.Errorf("expected %d results, got %d", len(params), len(result.Results))
+	}
+	results := make([]storage.AttachVolumesResult, len(params))
+	for i, r := range result.Results {
+		switch {
+		case r.Error != nil:
+			results[i].Error = plugin.ToError(r.Error)
+		case r.Attachment == nil:
+			results[i].Error = errors.New("plugin returned no attachment")
+		default:
+			results[i].VolumeAttachment = &storage.VolumeAttachment{
+				Volume:               params[i].Volume,
+				Machine:              params[i].Machine,
+				VolumeAttachmentInfo: volumeAttachmentInfo(*r.Attachment),
+			}
+		}
+	}
+	return results, nil
+}
+
+// DetachVolumes implements storage.VolumeSource.
+func (v *volumeSource) DetachVolumes(params []storage.VolumeAttachmentParams) ([]error, error) {
+	var result plugin.ErrorsResult
+	if err := v.env.call(plugin.MethodDetachVolumes, attachVolumesParams(params), &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return toErrors(result, len(params))
+}
+
+func attachVolumesParams(params []storage.VolumeAttachmentParams) plugin.AttachVolumesParams {
+	args := plugin.AttachVolumesParams{
+		Attachments: make([]plugin.VolumeAttachment, len(params)),
+	}
+	for i, p := range params {
+		args.Attachments[i] = plugin.VolumeAttachment{
+			VolumeId:   p.VolumeId,
+			InstanceId: string(p.InstanceId),
+			ReadOnly:   p.ReadOnly,
+		}
+	}
+	return args
+}
+
+func volumeInfo(v plugin.Volume) storage.VolumeInfo {
+	return storage.VolumeInfo{
+		VolumeId:   v.Id,
+		HardwareId: v.HardwareId,
+		Size:       v.Size,
+		Persistent: v.Persistent,
+	}
+}
+
+func volumeAttachmentInfo(a plugin.VolumeAttachment) storage.VolumeAttachmentInfo {
+	return storage.VolumeAttachmentInfo{
+		DeviceName: a.DeviceName,
+		DeviceLink: a.DeviceLink,
+		BusAddress: a.BusAddress,
+		ReadOnly:   a.ReadOnly,
+	}
+}
+
+func toErrors(result plugin.ErrorsResult, n int) ([]error, error) {
+	if len(result.Errors) != n {
+		return nil, errors.Errorf("expected %d results, got %d", n, len(result.Errors))
+	}
+	errs := make([]error, n)
+	for i, e := range result.Errors {
+		errs[i] = plugin.ToError(e)
+	}
+	return errs, nil
+}
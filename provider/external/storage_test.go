@@ -0,0 +1,147 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package external_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/provider/external/plugin"
+	"github.com/juju/juju/storage"
+)
+
+type storageSuite struct {
+	baseSuite
+	source storage.VolumeSource
+}
+
+var _ = gc.Suite(&storageSuite{})
+
+func (s *storageSuite) SetUpTest(c *gc.C) {
+	s.baseSuite.SetUpTest(c)
+	provider, err := s.env.StorageProvider("external")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(provider.Dynamic(), jc.IsTrue)
+	c.Assert(provider.Scope(), gc.Equals, storage.ScopeEnviron)
+	s.source, err = provider.VolumeSource(nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *storageSuite) TestCreateVolumes(c *gc.C) {
+	s.plugin.results[plugin.MethodCreateVolumes] = plugin.VolumesResult{
+		Results: []plugin.VolumeResult{{
+			Volume:     &plugin.Volume{Id: "vol-0", Size: 1024, Persistent: true},
+			Attachment: &plugin.VolumeAttachment{DeviceName: "vdb"},
+		}, {
+			Error: &plugin.Error{Code: plugin.CodeNotSupported, Message: "size too large"},
+		}},
+	}
+	results, err := s.source.CreateVolumes([]storage.VolumeParams{{
+		Tag:          names.NewVolumeTag("0"),
+		Size:         1024,
+		Attributes:   map[string]interface{}{"type": "ssd"},
+		ResourceTags: map[string]string{"juju-model-uuid": "x"},
+		Attachment: &storage.VolumeAttachmentParams{
+			AttachmentParams: storage.AttachmentParams{
+				Machine:    names.NewMachineTag("1"),
+				InstanceId: instance.Id("i-1"),
+			},
+			Volume: names.NewVolumeTag("0"),
+		},
+	}, {
+		Tag:  names.NewVolumeTag("1"),
+		Size: 1 << 30,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].Volume, jc.DeepEquals, &storage.Volume{
+		Tag: names.NewVolumeTag("0"),
+		VolumeInfo: storage.VolumeInfo{
+			VolumeId:   "vol-0",
+			Size:       1024,
+			Persistent: true,
+		},
+	})
+	c.Assert(results[0].VolumeAttachment, jc.DeepEquals, &storage.VolumeAttachment{
+		Volume:  names.NewVolumeTag("0"),
+		Machine: names.NewMachineTag("1"),
+		VolumeAttachmentInfo: storage.VolumeAttachmentInfo{
+			DeviceName: "vdb",
+		},
+	})
+	c.Assert(results[1].Error, jc.Satisfies, errors.IsNotSupported)
+
+	var params plugin.CreateVolumesParams
+	s.plugin.lastParams(c, &params)
+	c.Assert(params.Volumes, jc.DeepEquals, []plugin.VolumeParams{{
+		Name:       "0",
+		Size:       1024,
+		Attributes: map[string]interface{}{"type": "ssd"},
+		Tags:       map[string]string{"juju-model-uuid": "x"},
+		InstanceId: "i-1",
+	}, {
+		Name: "1",
+		Size: 1 << 30,
+	}})
+}
+
+func (s *storageSuite) TestCreateVolumesResultCount(c *gc.C) {
+	s.plugin.results[plugin.MethodCreateVolumes] = plugin.VolumesResult{}
+	_, err := s.source.CreateVolumes([]storage.VolumeParams{{
+		Tag: names.NewVolumeTag("0"),
+	}})
+	c.Assert(err, gc.ErrorMatches, "expected 1 results, got 0")
+}
+
+func (s *storageSuite) TestDestroyVolumes(c *gc.C) {
+	s.plugin.results[plugin.MethodDestroyVolumes] = plugin.ErrorsResult{
+		Errors: []*plugin.Error{nil, {Message: "volume in use"}},
+	}
+	errs, err := s.source.DestroyVolumes([]string{"vol-0", "vol-1"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs, gc.HasLen, 2)
+	c.Assert(errs[0], jc.ErrorIsNil)
+	c.Assert(errs[1], gc.ErrorMatches, "volume in use")
+}
+
+func (s *storageSuite) TestAttachVolumes(c *gc.C) {
+	s.plugin.results[plugin.MethodAttachVolumes] = plugin.VolumeAttachmentsResult{
+		Results: []plugin.VolumeAttachmentResult{{
+			Attachment: &plugin.VolumeAttachment{
+				VolumeId:   "vol-0",
+				InstanceId: "i-1",
+				DeviceLink: "/dev/disk/by-id/virtio-vol-0",
+			},
+		}},
+	}
+	results, err := s.source.AttachVolumes([]storage.VolumeAttachmentParams{{
+		AttachmentParams: storage.AttachmentParams{
+			Machine:    names.NewMachineTag("1"),
+			InstanceId: instance.Id("i-1"),
+		},
+		Volume:   names.NewVolumeTag("0"),
+		VolumeId: "vol-0",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.AttachVolumesResult{{
+		VolumeAttachment: &storage.VolumeAttachment{
+			Volume:  names.NewVolumeTag("0"),
+			Machine: names.NewMachineTag("1"),
+			VolumeAttachmentInfo: storage.VolumeAttachmentInfo{
+				DeviceLink: "/dev/disk/by-id/virtio-vol-0",
+			},
+		},
+	}})
+
+	var params plugin.AttachVolumesParams
+	s.plugin.lastParams(c, &params)
+	c.Assert(params.Attachments, jc.DeepEquals, []plugin.VolumeAttachment{{
+		VolumeId:   "vol-0",
+		InstanceId: "i-1",
+	}})
+}
@@ -0,0 +1,120 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package external_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/provider/external/plugin"
+	"github.com/juju/juju/testing"
+)
+
+// fakePlugin is an in-memory plugin that records the requests made of
+// it, and responds to them with the results or errors configured.
+type fakePlugin struct {
+	mu       sync.Mutex
+	requests []plugin.Request
+	params   []json.RawMessage
+	results  map[string]interface{}
+	errors   map[string]*plugin.Error
+}
+
+func newFakePlugin() *fakePlugin {
+	return &fakePlugin{
+		results: make(map[string]interface{}),
+		errors:  make(map[string]*plugin.Error),
+	}
+}
+
+func (p *fakePlugin) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		plugin.Request
+		Params json.RawMessage `json:"params"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.requests = append(p.requests, req.Request)
+	p.params = append(p.params, req.Params)
+
+	var resp struct {
+		Result interface{}   `json:"result,omitempty"`
+		Error  *plugin.Error `json:"error,omitempty"`
+	}
+	resp.Result = p.results[req.Method]
+	resp.Error = p.errors[req.Method]
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		panic(fmt.Sprintf("encoding response: %v", err))
+	}
+}
+
+// lastParams decodes the parameters of the last request into v,
+// and returns the request.
+func (p *fakePlugin) lastParams(c *gc.C, v interface{}) plugin.Request {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	c.Assert(p.requests, gc.Not(gc.HasLen), 0)
+	n := len(p.requests) - 1
+	if v != nil {
+		err := json.Unmarshal(p.params[n], v)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	return p.requests[n]
+}
+
+type baseSuite struct {
+	testing.BaseSuite
+
+	plugin *fakePlugin
+	spec   environs.CloudSpec
+	config *config.Config
+	env    environs.Environ
+}
+
+func (s *baseSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+
+	s.plugin = newFakePlugin()
+	server := httptest.NewServer(s.plugin)
+	s.AddCleanup(func(*gc.C) { server.Close() })
+
+	credential := cloud.NewCredential(cloud.AccessKeyAuthType, map[string]string{
+		"access-key": "key",
+		"secret-key": "secret",
+	})
+	s.spec = environs.CloudSpec{
+		Type:       "external",
+		Name:       "tiny",
+		Region:     "north",
+		Endpoint:   server.URL,
+		Credential: &credential,
+	}
+	var err error
+	s.config, err = config.New(config.UseDefaults, testing.FakeConfig().Merge(testing.Attrs{
+		"type":                "external",
+		"allow-insecure-http": true,
+	}))
+	c.Assert(err, jc.ErrorIsNil)
+
+	provider, err := environs.Provider("external")
+	c.Assert(err, jc.ErrorIsNil)
+	s.env, err = provider.Open(environs.OpenParams{
+		Cloud:  s.spec,
+		Config: s.config,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
@@ -9,6 +9,7 @@ import (
 	"sync"
 
 	"github.com/juju/errors"
+	"github.com/juju/schema"
 	"github.com/juju/utils"
 	"github.com/juju/utils/set"
 
@@ -39,10 +40,37 @@ type storageProvider struct {
 
 var _ storage.Provider = (*storageProvider)(nil)
 
+var storageConfigChecker = schema.FieldMap(
+	schema.Fields{
+		storage.ConfigEncrypted: schema.Bool(),
+		storage.ConfigKMSKeyId:  schema.String(),
+	},
+	schema.Defaults{
+		storage.ConfigEncrypted: false,
+		storage.ConfigKMSKeyId:  "",
+	},
+)
+
+// ValidateConfig implements storage.Provider. GCE persistent disks are
+// always encrypted at rest, so encrypted=true is accepted; customer
+// supplied keys are not supported.
 func (g *storageProvider) ValidateConfig(cfg *storage.Config) error {
+	out, err := storageConfigChecker.Coerce(cfg.Attrs(), nil)
+	if err != nil {
+		return errors.Annotate(err, "validating GCE storage config")
+	}
+	coerced := out.(map[string]interface{})
+	if coerced[storage.ConfigKMSKeyId].(string) != "" {
+		return errors.NotSupportedf("%s", storage.ConfigKMSKeyId)
+	}
 	return nil
 }
 
+// SupportsVolumeEncryption implements storage.VolumeEncryptionProvider.
+func (g *storageProvider) SupportsVolumeEncryption() bool {
+	return true
+}
+
 func (g *storageProvider) Supports(k storage.StorageKind) bool {
 	return k == storage.StorageKindBlock
 }
@@ -70,6 +98,9 @@ type volumeSource struct {
 	modelUUID string
 }
 
+var _ storage.VolumeResizer = (*volumeSource)(nil)
+var _ storage.VolumeSnapshotter = (*volumeSource)(nil)
+
 func (g *storageProvider) VolumeSource(cfg *storage.Config) (storage.VolumeSource, error) {
 	environConfig := g.env.Config()
 	source := &volumeSource{
@@ -205,6 +236,7 @@ func (v *volumeSource) createOneVolume(p storage.VolumeParams, instances instanc
 		Name:               volumeName,
 		PersistentDiskType: persistentType,
 		Description:        v.modelUUID,
+		SourceSnapshot:     p.SnapshotId,
 	}
 
 	gceDisks, err := v.gce.CreateDisks(zone, []google.DiskSpec{disk})
@@ -349,6 +381,92 @@ func (v *volumeSource) ValidateVolumeParams(params storage.VolumeParams) error {
 	return nil
 }
 
+// ResizeVolumes implements storage.VolumeResizer.
+func (v *volumeSource) ResizeVolumes(params []storage.ResizeVolumeParams) ([]storage.ResizeVolumesResult, error) {
+	results := make([]storage.ResizeVolumesResult, len(params))
+	for i, p := range params {
+		size, err := v.resizeOneVolume(p.VolumeId, p.Size)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "cannot resize volume %q", p.VolumeId)
+			continue
+		}
+		results[i].Size = size
+	}
+	return results, nil
+}
+
+func (v *volumeSource) resizeOneVolume(volName string, size uint64) (uint64, error) {
+	zone, _, err := parseVolumeId(volName)
+	if err != nil {
+		return 0, errors.Annotate(err, "invalid volume name")
+	}
+	disk, err := v.gce.Disk(zone, volName)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	// GCE disks are sized in whole gigabytes.
+	sizeGB := mibToGib(size)
+	if sizeGB*1024 < disk.Size {
+		return 0, errors.Errorf("cannot shrink volume from %dGB to %dGB", disk.Size/1024, sizeGB)
+	}
+	if sizeGB*1024 == disk.Size {
+		return disk.Size, nil
+	}
+	if err := v.gce.ResizeDisk(zone, volName, sizeGB); err != nil {
+		return 0, errors.Trace(err)
+	}
+	return sizeGB * 1024, nil
+}
+
+// CreateVolumeSnapshots implements storage.VolumeSnapshotter.
+func (v *volumeSource) CreateVolumeSnapshots(params []storage.VolumeSnapshotParams) ([]storage.CreateVolumeSnapshotsResult, error) {
+	results := make([]storage.CreateVolumeSnapshotsResult, len(params))
+	for i, p := range params {
+		info, err := v.createOneVolumeSnapshot(p.Id, p.VolumeId)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "cannot create snapshot of volume %q", p.VolumeId)
+			continue
+		}
+		results[i].VolumeSnapshot = &storage.VolumeSnapshot{
+			Id:                 p.Id,
+			Volume:             p.Volume,
+			VolumeSnapshotInfo: info,
+		}
+	}
+	return results, nil
+}
+
+func (v *volumeSource) createOneVolumeSnapshot(id, volName string) (storage.VolumeSnapshotInfo, error) {
+	zone, _, err := parseVolumeId(volName)
+	if err != nil {
+		return storage.VolumeSnapshotInfo{}, errors.Annotate(err, "invalid volume name")
+	}
+	disk, err := v.gce.Disk(zone, volName)
+	if err != nil {
+		return storage.VolumeSnapshotInfo{}, errors.Trace(err)
+	}
+	// Snapshots are global, so unlike volumes
+	// they need not be qualified by zone. The name
+	// identifies the Juju snapshot, so that a snapshot
+	// taken previously for the same request is reused,
+	// rather than taking another.
+	snapshotName := fmt.Sprintf("snap-%s-%s", v.modelUUID, id)
+	exists, err := v.gce.SnapshotExists(snapshotName)
+	if err != nil {
+		return storage.VolumeSnapshotInfo{}, errors.Annotate(err, "finding existing snapshot")
+	}
+	if exists {
+		logger.Debugf("found existing snapshot %q for snapshot request %s", snapshotName, id)
+	} else if err := v.gce.CreateSnapshot(zone, volName, snapshotName, v.modelUUID); err != nil {
+		return storage.VolumeSnapshotInfo{}, errors.Trace(err)
+	}
+	return storage.VolumeSnapshotInfo{
+		SnapshotId: snapshotName,
+		Size:       disk.Size,
+	}, nil
+}
+
+// AttachVolumes implements storage.VolumeSource.
 func (v *volumeSource) AttachVolumes(attachParams []storage.VolumeAttachmentParams) ([]storage.AttachVolumesResult, error) {
 	results := make([]storage.AttachVolumesResult, len(attachParams))
 	for i, attachment := range attachParams {
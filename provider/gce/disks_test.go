@@ -30,14 +30,30 @@ func (s *storageProviderSuite) SetUpTest(c *gc.C) {
 }
 
 func (s *storageProviderSuite) TestValidateConfig(c *gc.C) {
-	// ValidateConfig performs no validation at all yet, this test
-	// it is just here to make sure that the placeholder will
-	// accept a config.
 	cfg := &storage.Config{}
 	err := s.provider.ValidateConfig(cfg)
 	c.Check(err, jc.ErrorIsNil)
 }
 
+func (s *storageProviderSuite) TestValidateConfigEncrypted(c *gc.C) {
+	cfg, err := storage.NewConfig("secure", "gce", map[string]interface{}{
+		"encrypted": true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.provider.ValidateConfig(cfg)
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *storageProviderSuite) TestValidateConfigKMSKeyId(c *gc.C) {
+	cfg, err := storage.NewConfig("secure", "gce", map[string]interface{}{
+		"encrypted":  true,
+		"kms-key-id": "foo",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.provider.ValidateConfig(cfg)
+	c.Check(err, gc.ErrorMatches, "kms-key-id not supported")
+}
+
 func (s *storageProviderSuite) TestBlockStorageSupport(c *gc.C) {
 	supports := s.provider.Supports(storage.StorageKindBlock)
 	c.Check(supports, jc.IsTrue)
@@ -261,6 +277,85 @@ func (s *volumeSourceSuite) TestDescribeVolumes(c *gc.C) {
 	c.Assert(call[0].ID, gc.Equals, volName)
 }
 
+func (s *volumeSourceSuite) TestResizeVolumes(c *gc.C) {
+	s.FakeConn.GoogleDisk = s.BaseDisk
+	volName := "home-zone--c930380d-8337-4bf5-b07a-9dbb5ae771e4"
+	res, err := s.source.(storage.VolumeResizer).ResizeVolumes([]storage.ResizeVolumeParams{{
+		Tag:      names.NewVolumeTag("0"),
+		VolumeId: volName,
+		Size:     1500,
+	}})
+	c.Check(err, jc.ErrorIsNil)
+	c.Assert(res, jc.DeepEquals, []storage.ResizeVolumesResult{{Size: 2048}})
+
+	resizeCalled, call := s.FakeConn.WasCalled("ResizeDisk")
+	c.Assert(resizeCalled, jc.IsTrue)
+	c.Assert(call, gc.HasLen, 1)
+	c.Assert(call[0].ZoneName, gc.Equals, "home-zone")
+	c.Assert(call[0].VolumeName, gc.Equals, volName)
+	c.Assert(call[0].SizeGB, gc.Equals, uint64(2))
+}
+
+func (s *volumeSourceSuite) TestResizeVolumesShrink(c *gc.C) {
+	s.FakeConn.GoogleDisk = s.BaseDisk
+	volName := "home-zone--c930380d-8337-4bf5-b07a-9dbb5ae771e4"
+	s.BaseDisk.Size = 4096
+	res, err := s.source.(storage.VolumeResizer).ResizeVolumes([]storage.ResizeVolumeParams{{
+		Tag:      names.NewVolumeTag("0"),
+		VolumeId: volName,
+		Size:     1024,
+	}})
+	c.Check(err, jc.ErrorIsNil)
+	c.Assert(res, gc.HasLen, 1)
+	c.Assert(res[0].Error, gc.ErrorMatches, `cannot resize volume ".*": cannot shrink volume from 4GB to 1GB`)
+
+	resizeCalled, _ := s.FakeConn.WasCalled("ResizeDisk")
+	c.Assert(resizeCalled, jc.IsFalse)
+}
+
+func (s *volumeSourceSuite) TestCreateVolumeSnapshots(c *gc.C) {
+	s.FakeConn.GoogleDisk = s.BaseDisk
+	volName := "home-zone--c930380d-8337-4bf5-b07a-9dbb5ae771e4"
+	res, err := s.source.(storage.VolumeSnapshotter).CreateVolumeSnapshots([]storage.VolumeSnapshotParams{{
+		Id:       "0",
+		Volume:   names.NewVolumeTag("0"),
+		VolumeId: volName,
+	}})
+	c.Check(err, jc.ErrorIsNil)
+	c.Assert(res, gc.HasLen, 1)
+	c.Assert(res[0].Error, jc.ErrorIsNil)
+	c.Assert(res[0].VolumeSnapshot.Id, gc.Equals, "0")
+	c.Assert(res[0].VolumeSnapshot.Volume, gc.Equals, names.NewVolumeTag("0"))
+	c.Assert(res[0].VolumeSnapshot.Size, gc.Equals, s.BaseDisk.Size)
+
+	snapshotCalled, call := s.FakeConn.WasCalled("CreateSnapshot")
+	c.Assert(snapshotCalled, jc.IsTrue)
+	c.Assert(call, gc.HasLen, 1)
+	c.Assert(call[0].ZoneName, gc.Equals, "home-zone")
+	c.Assert(call[0].VolumeName, gc.Equals, volName)
+	c.Assert(call[0].SnapshotName, gc.Equals, res[0].VolumeSnapshot.SnapshotId)
+	c.Assert(call[0].SnapshotName, gc.Equals, "snap-"+s.Env.Config().UUID()+"-0")
+}
+
+func (s *volumeSourceSuite) TestCreateVolumeSnapshotsExisting(c *gc.C) {
+	s.FakeConn.GoogleDisk = s.BaseDisk
+	s.FakeConn.Snapshots = []string{"snap-" + s.Env.Config().UUID() + "-0"}
+	volName := "home-zone--c930380d-8337-4bf5-b07a-9dbb5ae771e4"
+	res, err := s.source.(storage.VolumeSnapshotter).CreateVolumeSnapshots([]storage.VolumeSnapshotParams{{
+		Id:       "0",
+		Volume:   names.NewVolumeTag("0"),
+		VolumeId: volName,
+	}})
+	c.Check(err, jc.ErrorIsNil)
+	c.Assert(res, gc.HasLen, 1)
+	c.Assert(res[0].Error, jc.ErrorIsNil)
+	c.Assert(res[0].VolumeSnapshot.SnapshotId, gc.Equals, "snap-"+s.Env.Config().UUID()+"-0")
+	c.Assert(res[0].VolumeSnapshot.Size, gc.Equals, s.BaseDisk.Size)
+
+	snapshotCalled, _ := s.FakeConn.WasCalled("CreateSnapshot")
+	c.Assert(snapshotCalled, jc.IsFalse)
+}
+
 func (s *volumeSourceSuite) TestAttachVolumes(c *gc.C) {
 	volName := "home-zone--c930380d-8337-4bf5-b07a-9dbb5ae771e4"
 	attachments := []storage.VolumeAttachmentParams{*s.attachmentParams}
@@ -32,6 +32,9 @@ type gceConnection interface {
 	Ports(fwname string) ([]network.PortRange, error)
 	OpenPorts(fwname string, ports ...network.PortRange) error
 	ClosePorts(fwname string, ports ...network.PortRange) error
+	IngressRules(fwname string) ([]network.IngressRule, error)
+	OpenIngressRules(fwname string, rules ...network.IngressRule) error
+	CloseIngressRules(fwname string, rules ...network.IngressRule) error
 
 	AvailabilityZones(region string) ([]google.AvailabilityZone, error)
 
@@ -53,6 +56,15 @@ type gceConnection interface {
 	// DetachDisk will detach <volumeName> disk from <instanceId> if possible
 	// and return error.
 	DetachDisk(zone, instanceId, volumeName string) error
+	// ResizeDisk will grow the disk identified by <name> in <zone>
+	// to <sizeGB> gigabytes.
+	ResizeDisk(zone, name string, sizeGB uint64) error
+	// CreateSnapshot will create a snapshot named <snapshotName> of the
+	// disk identified by <diskName> in <zone>.
+	CreateSnapshot(zone, diskName, snapshotName, description string) error
+	// SnapshotExists reports whether a snapshot named <snapshotName>
+	// exists.
+	SnapshotExists(snapshotName string) (bool, error)
 	// InstanceDisks returns a list of the disks attached to the passed instance.
 	InstanceDisks(zone, instanceId string) ([]*google.AttachedDisk, error)
 }
@@ -68,6 +80,11 @@ type environ struct {
 
 	// namespace is used to create the machine and device hostnames.
 	namespace instance.Namespace
+
+	// zoneNames caches the names of the availability zones, which
+	// constrain the zones constraint.
+	zoneNamesMutex sync.Mutex
+	zoneNames      []string
 }
 
 // Function entry points defined as variables so they can be overridden
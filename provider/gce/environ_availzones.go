@@ -93,7 +93,11 @@ func (env *environ) parseAvailabilityZones(args environs.StartInstanceParams) ([
 			return nil, errors.Trace(err)
 		}
 		// TODO(ericsnow) Fail if placement.Zone is not in the env's configured region?
-		return []string{placement.Zone.Name()}, nil
+		zoneName := placement.Zone.Name()
+		if err := common.ValidateZoneConstraint(zoneName, args.Constraints); err != nil {
+			return nil, errors.Trace(err)
+		}
+		return []string{zoneName}, nil
 	}
 
 	// If no availability zone is specified, then automatically spread across
@@ -112,6 +116,10 @@ func (env *environ) parseAvailabilityZones(args environs.StartInstanceParams) ([
 		return nil, errors.Trace(err)
 	}
 	logger.Infof("found %d zones: %v", len(zoneInstances), zoneInstances)
+	zoneInstances, err = common.FilterZoneAllocations(zoneInstances, args.Constraints)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 
 	var zoneNames []string
 	for _, z := range zoneInstances {
@@ -7,14 +7,21 @@ import (
 	"github.com/juju/errors"
 
 	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/provider/common"
 )
 
 // PrecheckInstance verifies that the provided series and constraints
 // are valid for use in creating an instance in this environment.
 func (env *environ) PrecheckInstance(series string, cons constraints.Value, placement string) error {
-	if _, err := env.parsePlacement(placement); err != nil {
+	p, err := env.parsePlacement(placement)
+	if err != nil {
 		return errors.Trace(err)
 	}
+	if p != nil {
+		if err := common.ValidateZoneConstraint(p.Zone.Name(), cons); err != nil {
+			return errors.Trace(err)
+		}
+	}
 
 	if cons.HasInstanceType() {
 		if !checkInstanceType(cons) {
@@ -28,6 +35,7 @@ func (env *environ) PrecheckInstance(series string, cons constraints.Value, plac
 var unsupportedConstraints = []string{
 	constraints.Tags,
 	constraints.VirtType,
+	constraints.PlacementGroup,
 }
 
 // instanceTypeConstraints defines the fields defined on each of the
@@ -67,9 +75,32 @@ func (env *environ) ConstraintsValidator() (constraints.Validator, error) {
 
 	validator.RegisterVocabulary(constraints.Container, []string{vtype})
 
+	zoneNames, err := env.availabilityZoneNames()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	validator.RegisterVocabulary(constraints.Zones, zoneNames)
+
 	return validator, nil
 }
 
+// availabilityZoneNames returns the names of the region's availability
+// zones. The names are cached, as the validator is obtained each time
+// constraints are set; the zones themselves are not, as their status
+// is used when placing instances.
+func (env *environ) availabilityZoneNames() ([]string, error) {
+	env.zoneNamesMutex.Lock()
+	defer env.zoneNamesMutex.Unlock()
+	if env.zoneNames == nil {
+		zoneNames, err := common.AvailabilityZoneNames(env)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		env.zoneNames = zoneNames
+	}
+	return env.zoneNames, nil
+}
+
 // SupportNetworks returns whether the environment has support to
 // specify networks for applications and machines.
 func (env *environ) SupportNetworks() bool {
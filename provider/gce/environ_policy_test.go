@@ -124,6 +124,18 @@ func (s *environPolSuite) TestPrecheckInstanceAvailZoneUnknown(c *gc.C) {
 	c.Check(err, jc.Satisfies, errors.IsNotFound)
 }
 
+func (s *environPolSuite) TestPrecheckInstanceAvailZoneConstraint(c *gc.C) {
+	s.FakeConn.Zones = []google.AvailabilityZone{
+		google.NewZone("a-zone", google.StatusUp, "", ""),
+	}
+
+	cons := constraints.MustParse("zones=b-zone")
+	placement := "zone=a-zone"
+	err := s.Env.PrecheckInstance(series.LatestLts(), cons, placement)
+
+	c.Check(err, gc.ErrorMatches, `placement zone "a-zone" does not satisfy constraint zones=\[b-zone\]`)
+}
+
 func (s *environPolSuite) TestConstraintsValidator(c *gc.C) {
 	validator, err := s.Env.ConstraintsValidator()
 	c.Assert(err, jc.ErrorIsNil)
@@ -175,6 +187,41 @@ func (s *environPolSuite) TestConstraintsValidatorVocabContainer(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, "invalid constraint value: container=lxd\nvalid values are:.*")
 }
 
+func (s *environPolSuite) TestConstraintsValidatorVocabZones(c *gc.C) {
+	s.FakeConn.Zones = []google.AvailabilityZone{
+		google.NewZone("a-zone", google.StatusUp, "", ""),
+		google.NewZone("b-zone", google.StatusUp, "", ""),
+	}
+	validator, err := s.Env.ConstraintsValidator()
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = validator.Validate(constraints.MustParse("zones=a-zone,b-zone"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = validator.Validate(constraints.MustParse("zones=a-zone,c-zone"))
+	c.Check(err, gc.ErrorMatches, "invalid constraint value: zones=c-zone\nvalid values are:.*")
+}
+
+func (s *environPolSuite) TestConstraintsValidatorZonesCached(c *gc.C) {
+	s.FakeConn.Zones = []google.AvailabilityZone{
+		google.NewZone("a-zone", google.StatusUp, "", ""),
+	}
+	_, err := s.Env.ConstraintsValidator()
+	c.Assert(err, jc.ErrorIsNil)
+	validator, err := s.Env.ConstraintsValidator()
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = validator.Validate(constraints.MustParse("zones=a-zone"))
+	c.Assert(err, jc.ErrorIsNil)
+	var calls int
+	for _, call := range s.FakeConn.Calls {
+		if call.FuncName == "AvailabilityZones" {
+			calls++
+		}
+	}
+	c.Assert(calls, gc.Equals, 1)
+}
+
 func (s *environPolSuite) TestConstraintsValidatorConflicts(c *gc.C) {
 	validator, err := s.Env.ConstraintsValidator()
 	c.Assert(err, jc.ErrorIsNil)
@@ -4,6 +4,8 @@
 package google
 
 import (
+	"net/http"
+
 	"github.com/juju/errors"
 	"golang.org/x/oauth2"
 	goauth2 "golang.org/x/oauth2/google"
@@ -18,9 +20,23 @@ var (
 )
 
 // newConnection opens a new low-level connection to the GCE API using
-// the Auth's data and returns it. This includes building the
-// OAuth-wrapping network transport.
-func newConnection(creds *Credentials) (*compute.Service, error) {
+// the Auth's data and returns it, along with the OAuth-wrapping HTTP
+// client used to make requests not covered by the compute API package.
+func newConnection(creds *Credentials) (*compute.Service, *http.Client, error) {
+	client, err := newHTTPClient(creds)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	service, err := compute.New(client)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	return service, client, nil
+}
+
+// newHTTPClient builds the OAuth-wrapping network transport from the
+// Auth's data.
+func newHTTPClient(creds *Credentials) (*http.Client, error) {
 	jsonKey := creds.JSONKey
 	if jsonKey == nil {
 		built, err := creds.buildJSONKey()
@@ -33,7 +49,5 @@ func newConnection(creds *Credentials) (*compute.Service, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	client := cfg.Client(oauth2.NoContext)
-	service, err := compute.New(client)
-	return service, errors.Trace(err)
+	return cfg.Client(oauth2.NoContext), nil
 }
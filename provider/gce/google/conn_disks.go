@@ -106,6 +106,44 @@ func (gce *Connection) DetachDisk(zone, instanceId, volumeName string) error {
 	return nil
 }
 
+// ResizeDisk implements storage section of gceConnection.
+func (gce *Connection) ResizeDisk(zone, name string, sizeGB uint64) error {
+	err := gce.raw.ResizeDisk(gce.projectID, zone, name, int64(sizeGB))
+	if err != nil {
+		return errors.Annotatef(err, "cannot resize disk %q in zone %q", name, zone)
+	}
+	return nil
+}
+
+// CreateSnapshot implements storage section of gceConnection.
+func (gce *Connection) CreateSnapshot(zone, diskName, snapshotName, description string) error {
+	snapshot := &compute.Snapshot{
+		Name:        snapshotName,
+		Description: description,
+	}
+	err := gce.raw.CreateSnapshot(gce.projectID, zone, diskName, snapshot)
+	if err != nil {
+		return errors.Annotatef(err, "cannot create snapshot of disk %q in zone %q", diskName, zone)
+	}
+	return nil
+}
+
+// SnapshotExists implements storage section of gceConnection.
+// A snapshot which failed to be created is reported as an error,
+// as its name cannot be reused until it is deleted.
+func (gce *Connection) SnapshotExists(snapshotName string) (bool, error) {
+	snapshot, err := gce.raw.GetSnapshot(gce.projectID, snapshotName)
+	if errors.IsNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, errors.Trace(err)
+	}
+	if snapshot.Status == "FAILED" {
+		return false, errors.Errorf("snapshot %q failed", snapshotName)
+	}
+	return true, nil
+}
+
 // sourceToVolumeName will return the disk Name part of a
 // source URL for a compute disk, compute is a bit inconsistent
 // on its handling of disk resources, when used in requests it will
@@ -4,6 +4,7 @@
 package google_test
 
 import (
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	"google.golang.org/api/compute/v1"
 	gc "gopkg.in/check.v1"
@@ -112,6 +113,58 @@ func (s *connSuite) TestConnectionDetachDisk(c *gc.C) {
 	c.Check(s.FakeConn.Calls[1].DeviceName, gc.Equals, "home-zone-0")
 }
 
+func (s *connSuite) TestConnectionResizeDisk(c *gc.C) {
+	err := s.Conn.ResizeDisk("home-zone", fakeVolName, 20)
+	c.Check(err, jc.ErrorIsNil)
+
+	c.Check(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "ResizeDisk")
+	c.Check(s.FakeConn.Calls[0].ProjectID, gc.Equals, "spam")
+	c.Check(s.FakeConn.Calls[0].ZoneName, gc.Equals, "home-zone")
+	c.Check(s.FakeConn.Calls[0].ID, gc.Equals, fakeVolName)
+	c.Check(s.FakeConn.Calls[0].SizeGb, gc.Equals, int64(20))
+}
+
+func (s *connSuite) TestConnectionCreateSnapshot(c *gc.C) {
+	err := s.Conn.CreateSnapshot("home-zone", fakeVolName, "snap-0", "a-model-uuid")
+	c.Check(err, jc.ErrorIsNil)
+
+	c.Check(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "CreateSnapshot")
+	c.Check(s.FakeConn.Calls[0].ProjectID, gc.Equals, "spam")
+	c.Check(s.FakeConn.Calls[0].ZoneName, gc.Equals, "home-zone")
+	c.Check(s.FakeConn.Calls[0].ID, gc.Equals, fakeVolName)
+	c.Check(s.FakeConn.Calls[0].Snapshot, jc.DeepEquals, &compute.Snapshot{
+		Name:        "snap-0",
+		Description: "a-model-uuid",
+	})
+}
+
+func (s *connSuite) TestConnectionSnapshotExists(c *gc.C) {
+	s.FakeConn.Snapshot = &compute.Snapshot{Name: "snap-0", Status: "READY"}
+	exists, err := s.Conn.SnapshotExists("snap-0")
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(exists, jc.IsTrue)
+
+	c.Check(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "GetSnapshot")
+	c.Check(s.FakeConn.Calls[0].ProjectID, gc.Equals, "spam")
+	c.Check(s.FakeConn.Calls[0].Name, gc.Equals, "snap-0")
+}
+
+func (s *connSuite) TestConnectionSnapshotExistsNotFound(c *gc.C) {
+	s.FakeConn.Err = errors.NotFoundf("snapshot")
+	exists, err := s.Conn.SnapshotExists("snap-0")
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(exists, jc.IsFalse)
+}
+
+func (s *connSuite) TestConnectionSnapshotExistsFailed(c *gc.C) {
+	s.FakeConn.Snapshot = &compute.Snapshot{Name: "snap-0", Status: "FAILED"}
+	_, err := s.Conn.SnapshotExists("snap-0")
+	c.Check(err, gc.ErrorMatches, `snapshot "snap-0" failed`)
+}
+
 func (s *connSuite) TestConnectionRemoveDisks(c *gc.C) {
 	err := s.Conn.RemoveDisk("home-zone", fakeVolName)
 	c.Check(err, jc.ErrorIsNil)
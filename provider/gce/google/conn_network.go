@@ -4,7 +4,12 @@
 package google
 
 import (
+	"crypto/sha256"
+	"fmt"
+	"strings"
+
 	"github.com/juju/errors"
+	"google.golang.org/api/compute/v1"
 
 	"github.com/juju/juju/network"
 )
@@ -20,7 +25,10 @@ func (gce Connection) Ports(fwname string) ([]network.PortRange, error) {
 	if err != nil {
 		return nil, errors.Annotate(err, "while getting ports from GCE")
 	}
+	return firewallPorts(firewall)
+}
 
+func firewallPorts(firewall *compute.Firewall) ([]network.PortRange, error) {
 	var ports []network.PortRange
 	for _, allowed := range firewall.Allowed {
 		for _, portRangeStr := range allowed.Ports {
@@ -43,6 +51,10 @@ func (gce Connection) Ports(fwname string) ([]network.PortRange, error) {
 // ports it already has open. The call blocks until the ports are
 // opened or the request fails.
 func (gce Connection) OpenPorts(fwname string, ports ...network.PortRange) error {
+	return gce.openPorts(fwname, fwname, []string{network.AllSourceCIDR}, ports...)
+}
+
+func (gce Connection) openPorts(fwname, target string, sourceCIDRs []string, ports ...network.PortRange) error {
 	// TODO(ericsnow) Short-circuit if ports is empty.
 
 	// Compose the full set of open ports.
@@ -59,7 +71,7 @@ func (gce Connection) OpenPorts(fwname string, ports ...network.PortRange) error
 	// Send the request, depending on the current ports.
 	if currentPortsSet.IsEmpty() {
 		// Create a new firewall.
-		firewall := firewallSpec(fwname, inputPortsSet)
+		firewall := sourceFirewallSpec(fwname, target, sourceCIDRs, inputPortsSet)
 		if err := gce.raw.AddFirewall(gce.projectID, firewall); err != nil {
 			return errors.Annotatef(err, "opening port(s) %+v", ports)
 		}
@@ -68,7 +80,7 @@ func (gce Connection) OpenPorts(fwname string, ports ...network.PortRange) error
 
 	// Update an existing firewall.
 	newPortsSet := currentPortsSet.Union(inputPortsSet)
-	firewall := firewallSpec(fwname, newPortsSet)
+	firewall := sourceFirewallSpec(fwname, target, sourceCIDRs, newPortsSet)
 	if err := gce.raw.UpdateFirewall(gce.projectID, fwname, firewall); err != nil {
 		return errors.Annotatef(err, "opening port(s) %+v", ports)
 	}
@@ -82,6 +94,10 @@ func (gce Connection) OpenPorts(fwname string, ports ...network.PortRange) error
 // match the provided port ranges. The call blocks until the ports are
 // closed or the request fails.
 func (gce Connection) ClosePorts(fwname string, ports ...network.PortRange) error {
+	return gce.closePorts(fwname, fwname, []string{network.AllSourceCIDR}, ports...)
+}
+
+func (gce Connection) closePorts(fwname, target string, sourceCIDRs []string, ports ...network.PortRange) error {
 	// Compose the full set of open ports.
 	currentPorts, err := gce.Ports(fwname)
 	if err != nil {
@@ -105,9 +121,114 @@ func (gce Connection) ClosePorts(fwname string, ports ...network.PortRange) erro
 	}
 
 	// Update an existing firewall.
-	firewall := firewallSpec(fwname, newPortsSet)
+	firewall := sourceFirewallSpec(fwname, target, sourceCIDRs, newPortsSet)
 	if err := gce.raw.UpdateFirewall(gce.projectID, fwname, firewall); err != nil {
 		return errors.Annotatef(err, "closing port(s) %+v", ports)
 	}
 	return nil
 }
+
+// A GCE firewall has a single set of source ranges, so ingress rules
+// which restrict the source CIDRs are kept in separate firewalls, one
+// for each set of source CIDRs, which target the same instances as the
+// named firewall.
+
+// sourceFirewallPrefix returns the prefix of the names of the
+// firewalls holding the source-restricted rules for fwname.
+func sourceFirewallPrefix(fwname string) string {
+	return fwname + "-cidr-"
+}
+
+// sourceFirewallName returns the name of the firewall holding the
+// rules for fwname which allow ingress from the given source CIDRs.
+func sourceFirewallName(fwname string, sourceCIDRs []string) string {
+	hash := sha256.Sum256([]byte(strings.Join(sourceCIDRs, ",")))
+	return fmt.Sprintf("%s%x", sourceFirewallPrefix(fwname), hash[:6])
+}
+
+// IngressRules returns the ingress rules for the instances targeted by
+// the named firewall, including those which are restricted to
+// particular source CIDRs.
+func (gce Connection) IngressRules(fwname string) ([]network.IngressRule, error) {
+	ports, err := gce.Ports(fwname)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	rules := network.IngressRulesFromPortRanges(ports)
+
+	firewalls, err := gce.raw.ListFirewalls(gce.projectID, sourceFirewallPrefix(fwname))
+	if err != nil {
+		return nil, errors.Annotate(err, "while getting ingress rules from GCE")
+	}
+	for _, firewall := range firewalls {
+		ports, err := firewallPorts(firewall)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, portRange := range ports {
+			rule, err := network.NewIngressRule(portRange, firewall.SourceRanges...)
+			if err != nil {
+				return nil, errors.Annotate(err, "bad source ranges from GCE")
+			}
+			rules = append(rules, rule)
+		}
+	}
+	network.SortIngressRules(rules)
+	return rules, nil
+}
+
+// OpenIngressRules sends requests to the GCE API to open the provided
+// ingress rules on the instances targeted by the named firewall. Rules
+// which allow ingress from anywhere are opened on the named firewall
+// itself.
+func (gce Connection) OpenIngressRules(fwname string, rules ...network.IngressRule) error {
+	for _, group := range groupRulesByFirewall(fwname, rules) {
+		err := gce.openPorts(group.name, fwname, group.sourceCIDRs, group.ports...)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// CloseIngressRules sends requests to the GCE API to close the
+// provided ingress rules on the instances targeted by the named
+// firewall.
+func (gce Connection) CloseIngressRules(fwname string, rules ...network.IngressRule) error {
+	for _, group := range groupRulesByFirewall(fwname, rules) {
+		err := gce.closePorts(group.name, fwname, group.sourceCIDRs, group.ports...)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+type firewallRules struct {
+	name        string
+	sourceCIDRs []string
+	ports       []network.PortRange
+}
+
+// groupRulesByFirewall groups the port ranges of the given rules by the
+// firewall in which they are kept.
+func groupRulesByFirewall(fwname string, rules []network.IngressRule) []*firewallRules {
+	var groups []*firewallRules
+	byName := make(map[string]*firewallRules)
+	for _, rule := range rules {
+		name := fwname
+		sourceCIDRs := []string{network.AllSourceCIDR}
+		if !rule.OpenToAll() {
+			name = sourceFirewallName(fwname, rule.SourceCIDRs)
+			sourceCIDRs = rule.SourceCIDRs
+		}
+		group, ok := byName[name]
+		if !ok {
+			group = &firewallRules{name: name, sourceCIDRs: sourceCIDRs}
+			byName[name] = group
+			groups = append(groups, group)
+		}
+		group.ports = append(group.ports, rule.PortRange)
+	}
+	return groups
+}
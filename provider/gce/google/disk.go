@@ -114,6 +114,9 @@ type DiskSpec struct {
 	// Description was picked because it is not mutable (actually no field is) for disks.
 	// There is a metadata API but it is not supported for disks for the moment.
 	Description string
+	// SourceSnapshot is the name of the snapshot from which the disk
+	// should be initialized, if any. (detached only)
+	SourceSnapshot string
 }
 
 // TooSmall checks the spec's size hint and indicates whether or not
@@ -175,12 +178,17 @@ func (ds *DiskSpec) newDetached() (*compute.Disk, error) {
 	if ds.PersistentDiskType == DiskLocalSSD {
 		return nil, errors.New("cannot create local ssd disks detached")
 	}
+	var sourceSnapshot string
+	if ds.SourceSnapshot != "" {
+		sourceSnapshot = "global/snapshots/" + ds.SourceSnapshot
+	}
 	return &compute.Disk{
-		Name:        ds.Name,
-		SizeGb:      int64(ds.SizeGB()),
-		SourceImage: ds.ImageURL,
-		Type:        string(ds.PersistentDiskType),
-		Description: ds.Description,
+		Name:           ds.Name,
+		SizeGb:         int64(ds.SizeGB()),
+		SourceImage:    ds.ImageURL,
+		SourceSnapshot: sourceSnapshot,
+		Type:           string(ds.PersistentDiskType),
+		Description:    ds.Description,
 	}, nil
 }
 
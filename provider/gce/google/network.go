@@ -58,14 +58,22 @@ func (ns *NetworkSpec) newInterface(name string) *compute.NetworkInterface {
 // firewallSpec expands a port range set in to compute.FirewallAllowed
 // and returns a compute.Firewall for the provided name.
 func firewallSpec(name string, ps network.PortSet) *compute.Firewall {
+	return sourceFirewallSpec(name, name, []string{network.AllSourceCIDR}, ps)
+}
+
+// sourceFirewallSpec expands a port range set in to
+// compute.FirewallAllowed and returns a compute.Firewall for the
+// provided name, which allows ingress from the source CIDRs to
+// instances tagged with the target.
+func sourceFirewallSpec(name, target string, sourceCIDRs []string, ps network.PortSet) *compute.Firewall {
 	firewall := compute.Firewall{
 		// Allowed is set below.
 		// Description is not set.
 		Name: name,
 		// Network: (defaults to global)
 		// SourceTags is not set.
-		TargetTags:   []string{name},
-		SourceRanges: []string{"0.0.0.0/0"},
+		TargetTags:   []string{target},
+		SourceRanges: sourceCIDRs,
 	}
 
 	for _, protocol := range ps.Protocols() {
@@ -4,6 +4,8 @@
 package google
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"path"
@@ -39,6 +41,11 @@ func convertRawAPIError(err error) error {
 
 type rawConn struct {
 	*compute.Service
+
+	// client is the authenticated HTTP client underlying Service,
+	// used for requests that the compute API package does not
+	// support.
+	client *http.Client
 }
 
 func (rc *rawConn) GetProject(projectID string) (*compute.Project, error) {
@@ -129,6 +136,30 @@ func (rc *rawConn) GetFirewall(projectID, name string) (*compute.Firewall, error
 	return firewallList.Items[0], nil
 }
 
+func (rc *rawConn) ListFirewalls(projectID, prefix string) ([]*compute.Firewall, error) {
+	call := rc.Firewalls.List(projectID)
+	call = call.Filter("name eq " + prefix + ".*")
+
+	var results []*compute.Firewall
+	for {
+		firewallList, err := call.Do()
+		if err != nil {
+			return nil, errors.Annotate(err, "while listing firewalls from GCE")
+		}
+
+		for _, firewall := range firewallList.Items {
+			if strings.HasPrefix(firewall.Name, prefix) {
+				results = append(results, firewall)
+			}
+		}
+		if firewallList.NextPageToken == "" {
+			break
+		}
+		call = call.PageToken(firewallList.NextPageToken)
+	}
+	return results, nil
+}
+
 func (rc *rawConn) AddFirewall(projectID string, firewall *compute.Firewall) error {
 	call := rc.Firewalls.Insert(projectID, firewall)
 	operation, err := call.Do()
@@ -267,6 +298,51 @@ func (rc *rawConn) DetachDisk(project, zone, instanceId, diskDeviceName string)
 	return nil
 }
 
+func (rc *rawConn) ResizeDisk(project, zone, id string, sizeGb int64) error {
+	// The version of the compute API package we use predates
+	// support for resizing disks, so we issue the request directly.
+	body, err := json.Marshal(map[string]int64{"sizeGb": sizeGb})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	url := rc.BasePath + path.Join(project, "zones", zone, "disks", id, "resize")
+	req, err := http.NewRequest("POST", url, bytes.NewReader(body))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := rc.client.Do(req)
+	if err != nil {
+		return errors.Annotatef(err, "cannot resize disk %q", id)
+	}
+	defer googleapi.CloseBody(resp)
+	if err := googleapi.CheckResponse(resp); err != nil {
+		return errors.Annotatef(err, "cannot resize disk %q", id)
+	}
+	var op compute.Operation
+	if err := json.NewDecoder(resp.Body).Decode(&op); err != nil {
+		return errors.Annotate(err, "decoding resize operation")
+	}
+	return errors.Trace(rc.waitOperation(project, &op, attemptsLong))
+}
+
+func (rc *rawConn) CreateSnapshot(project, zone, id string, snapshot *compute.Snapshot) error {
+	call := rc.Disks.CreateSnapshot(project, zone, id, snapshot)
+	op, err := call.Do()
+	if err != nil {
+		return errors.Annotatef(err, "cannot create snapshot of disk %q", id)
+	}
+	return errors.Trace(rc.waitOperation(project, op, attemptsLong))
+}
+
+func (rc *rawConn) GetSnapshot(project, name string) (*compute.Snapshot, error) {
+	snapshot, err := rc.Snapshots.Get(project, name).Do()
+	if err != nil {
+		return nil, errors.Annotatef(convertRawAPIError(err), "cannot get snapshot %q", name)
+	}
+	return snapshot, nil
+}
+
 func (rc *rawConn) InstanceDisks(project, zone, instanceId string) ([]*compute.AttachedDisk, error) {
 	instance, err := rc.GetInstance(project, zone, instanceId)
 	if err != nil {
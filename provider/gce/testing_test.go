@@ -464,11 +464,14 @@ type fakeConnCall struct {
 	InstanceSpec google.InstanceSpec
 	FirewallName string
 	PortRanges   []network.PortRange
+	Rules        []network.IngressRule
 	Region       string
 	Disks        []google.DiskSpec
 	VolumeName   string
 	InstanceId   string
 	Mode         string
+	SizeGB       uint64
+	SnapshotName string
 }
 
 type fakeConn struct {
@@ -477,12 +480,14 @@ type fakeConn struct {
 	Inst       *google.Instance
 	Insts      []google.Instance
 	PortRanges []network.PortRange
+	Rules      []network.IngressRule
 	Zones      []google.AvailabilityZone
 
 	GoogleDisks   []*google.Disk
 	GoogleDisk    *google.Disk
 	AttachedDisk  *google.AttachedDisk
 	AttachedDisks []*google.AttachedDisk
+	Snapshots     []string
 
 	Err        error
 	FailOnCall int
@@ -564,6 +569,32 @@ func (fc *fakeConn) ClosePorts(fwname string, ports ...network.PortRange) error
 	return fc.err()
 }
 
+func (fc *fakeConn) IngressRules(fwname string) ([]network.IngressRule, error) {
+	fc.Calls = append(fc.Calls, fakeConnCall{
+		FuncName:     "IngressRules",
+		FirewallName: fwname,
+	})
+	return fc.Rules, fc.err()
+}
+
+func (fc *fakeConn) OpenIngressRules(fwname string, rules ...network.IngressRule) error {
+	fc.Calls = append(fc.Calls, fakeConnCall{
+		FuncName:     "OpenIngressRules",
+		FirewallName: fwname,
+		Rules:        rules,
+	})
+	return fc.err()
+}
+
+func (fc *fakeConn) CloseIngressRules(fwname string, rules ...network.IngressRule) error {
+	fc.Calls = append(fc.Calls, fakeConnCall{
+		FuncName:     "CloseIngressRules",
+		FirewallName: fwname,
+		Rules:        rules,
+	})
+	return fc.err()
+}
+
 func (fc *fakeConn) AvailabilityZones(region string) ([]google.AvailabilityZone, error) {
 	fc.Calls = append(fc.Calls, fakeConnCall{
 		FuncName: "AvailabilityZones",
@@ -628,6 +659,39 @@ func (fc *fakeConn) DetachDisk(zone, instanceId, volumeName string) error {
 	return fc.err()
 }
 
+func (fc *fakeConn) ResizeDisk(zone, name string, sizeGB uint64) error {
+	fc.Calls = append(fc.Calls, fakeConnCall{
+		FuncName:   "ResizeDisk",
+		ZoneName:   zone,
+		VolumeName: name,
+		SizeGB:     sizeGB,
+	})
+	return fc.err()
+}
+
+func (fc *fakeConn) CreateSnapshot(zone, diskName, snapshotName, description string) error {
+	fc.Calls = append(fc.Calls, fakeConnCall{
+		FuncName:     "CreateSnapshot",
+		ZoneName:     zone,
+		VolumeName:   diskName,
+		SnapshotName: snapshotName,
+	})
+	return fc.err()
+}
+
+func (fc *fakeConn) SnapshotExists(snapshotName string) (bool, error) {
+	fc.Calls = append(fc.Calls, fakeConnCall{
+		FuncName:     "SnapshotExists",
+		SnapshotName: snapshotName,
+	})
+	for _, name := range fc.Snapshots {
+		if name == snapshotName {
+			return true, fc.err()
+		}
+	}
+	return false, fc.err()
+}
+
 func (fc *fakeConn) InstanceDisks(zone, instanceId string) ([]*google.AttachedDisk, error) {
 	fc.Calls = append(fc.Calls, fakeConnCall{
 		FuncName:   "InstanceDisks",
@@ -54,6 +54,8 @@ var unsupportedConstraints = []string{
 	constraints.CpuPower,
 	constraints.Tags,
 	constraints.VirtType,
+	constraints.Zones,
+	constraints.PlacementGroup,
 }
 
 // ConstraintsValidator is defined on the Environs interface.
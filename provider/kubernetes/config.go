@@ -0,0 +1,122 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package kubernetes
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/schema"
+	"gopkg.in/juju/environschema.v1"
+
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/provider/kubernetes/kubeclient"
+)
+
+const (
+	cfgNamespace   = "namespace"
+	cfgImage       = "image"
+	cfgServiceType = "service-type"
+)
+
+var configSchema = environschema.Fields{
+	cfgNamespace: {
+		Description: "The Kubernetes namespace in which to create the model's resources. Defaults to a name derived from the model UUID.",
+		Type:        environschema.Tstring,
+		Immutable:   true,
+	},
+	cfgImage: {
+		Description: "The container image used for machine pods. The image must run systemd and cloud-init with the NoCloud datasource. Defaults to ubuntu:<series>.",
+		Type:        environschema.Tstring,
+	},
+	cfgServiceType: {
+		Description: "The type of Kubernetes service used to expose applications.",
+		Type:        environschema.Tstring,
+		Values: []interface{}{
+			kubeclient.ServiceTypeLoadBalancer,
+			kubeclient.ServiceTypeNodePort,
+		},
+	},
+}
+
+var configFields = func() schema.Fields {
+	fs, _, err := configSchema.ValidationSchema()
+	if err != nil {
+		panic(err)
+	}
+	return fs
+}()
+
+var configDefaults = schema.Defaults{
+	cfgNamespace:   "",
+	cfgImage:       "",
+	cfgServiceType: kubeclient.ServiceTypeLoadBalancer,
+}
+
+var configImmutableFields = []string{
+	cfgNamespace,
+}
+
+type environConfig struct {
+	*config.Config
+	attrs map[string]interface{}
+}
+
+// newValidConfig builds a new environConfig from the provided Config,
+// filling in default values, if any. It returns an error if the
+// resulting configuration is not valid.
+func newValidConfig(cfg, old *config.Config) (*environConfig, error) {
+	if err := config.Validate(cfg, old); err != nil {
+		return nil, errors.Trace(err)
+	}
+	attrs, err := cfg.ValidateUnknownAttrs(configFields, configDefaults)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if old != nil {
+		oldEcfg, err := newValidConfig(old, nil)
+		if err != nil {
+			return nil, errors.Annotatef(err, "invalid base config")
+		}
+		for _, attr := range configImmutableFields {
+			oldv, newv := oldEcfg.attrs[attr], attrs[attr]
+			if oldv != newv {
+				return nil, errors.Errorf(
+					"%s: cannot change from %v to %v",
+					attr, oldv, newv,
+				)
+			}
+		}
+	}
+	return &environConfig{Config: cfg, attrs: attrs}, nil
+}
+
+// namespace returns the Kubernetes namespace in which the model's
+// resources are created.
+func (c *environConfig) namespace() (string, error) {
+	if ns := c.attrs[cfgNamespace].(string); ns != "" {
+		return ns, nil
+	}
+	ns, err := instance.NewNamespace(c.UUID())
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return strings.TrimSuffix(ns.Prefix(), "-"), nil
+}
+
+// image returns the container image to use for machine pods
+// running the given series.
+func (c *environConfig) image(series string) string {
+	if image := c.attrs[cfgImage].(string); image != "" {
+		return image
+	}
+	return "ubuntu:" + series
+}
+
+// serviceType returns the type of service used to expose
+// applications.
+func (c *environConfig) serviceType() string {
+	return c.attrs[cfgServiceType].(string)
+}
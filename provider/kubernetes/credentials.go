@@ -0,0 +1,47 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package kubernetes
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/cloud"
+)
+
+const (
+	credAttrUsername = "username"
+	credAttrPassword = "password"
+	credAttrToken    = "token"
+)
+
+type environProviderCredentials struct{}
+
+// CredentialSchemas is part of the environs.ProviderCredentials interface.
+func (environProviderCredentials) CredentialSchemas() map[cloud.AuthType]cloud.CredentialSchema {
+	return map[cloud.AuthType]cloud.CredentialSchema{
+		cloud.UserPassAuthType: {
+			{
+				credAttrUsername, cloud.CredentialAttr{Description: "The username to authenticate with."},
+			}, {
+				credAttrPassword, cloud.CredentialAttr{
+					Description: "The password to authenticate with.",
+					Hidden:      true,
+				},
+			},
+		},
+		cloud.OAuth2AuthType: {
+			{
+				credAttrToken, cloud.CredentialAttr{
+					Description: "The bearer token to authenticate with.",
+					Hidden:      true,
+				},
+			},
+		},
+	}
+}
+
+// DetectCredentials is part of the environs.ProviderCredentials interface.
+func (environProviderCredentials) DetectCredentials() (*cloud.CloudCredential, error) {
+	return nil, errors.NotFoundf("credentials")
+}
@@ -0,0 +1,275 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package kubernetes
+
+import (
+	"sync"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/arch"
+
+	"github.com/juju/juju/cloudconfig/instancecfg"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/provider/kubernetes/kubeclient"
+	coretools "github.com/juju/juju/tools"
+)
+
+var logger = loggo.GetLogger("juju.provider.kubernetes")
+
+// kubeClient is the subset of the Kubernetes API used by the provider.
+type kubeClient interface {
+	Namespaces(labels map[string]string) ([]kubeclient.Namespace, error)
+	CreateNamespace(name string, labels map[string]string) error
+	DeleteNamespace(name string) error
+
+	Pods(namespace string, labels map[string]string) ([]kubeclient.Pod, error)
+
+	StatefulSets(namespace string, labels map[string]string) ([]kubeclient.StatefulSet, error)
+	StatefulSet(namespace, name string) (*kubeclient.StatefulSet, error)
+	CreateStatefulSet(namespace string, set *kubeclient.StatefulSet) (*kubeclient.StatefulSet, error)
+	UpdateStatefulSet(namespace string, set *kubeclient.StatefulSet) (*kubeclient.StatefulSet, error)
+	DeleteStatefulSet(namespace, name string) error
+
+	Service(namespace, name string) (*kubeclient.Service, error)
+	CreateService(namespace string, svc *kubeclient.Service) (*kubeclient.Service, error)
+	UpdateService(namespace string, svc *kubeclient.Service) (*kubeclient.Service, error)
+	DeleteService(namespace, name string) error
+
+	PersistentVolumeClaims(namespace string, labels map[string]string) ([]kubeclient.PersistentVolumeClaim, error)
+	PersistentVolumeClaim(namespace, name string) (*kubeclient.PersistentVolumeClaim, error)
+	DeletePersistentVolumeClaim(namespace, name string) error
+
+	Secret(namespace, name string) (*kubeclient.Secret, error)
+	CreateSecret(namespace string, secret *kubeclient.Secret) (*kubeclient.Secret, error)
+	UpdateSecret(namespace string, secret *kubeclient.Secret) (*kubeclient.Secret, error)
+	DeleteSecret(namespace, name string) error
+}
+
+// Labels applied to the Kubernetes resources created by the provider.
+const (
+	labelModel      = "juju-model-uuid"
+	labelController = "juju-controller-uuid"
+	labelSet        = "juju-set"
+)
+
+type environ struct {
+	client    kubeClient
+	namespace string
+
+	// setLock serialises changes to stateful sets, so that machine
+	// ordinals are allocated consistently.
+	setLock sync.Mutex
+
+	lock sync.Mutex
+	ecfg *environConfig
+}
+
+var _ environs.Environ = (*environ)(nil)
+
+func newEnviron(spec environs.CloudSpec, cfg *config.Config) (*environ, error) {
+	ecfg, err := newValidConfig(cfg, nil)
+	if err != nil {
+		return nil, errors.Annotate(err, "invalid config")
+	}
+	namespace, err := ecfg.namespace()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	client, err := newClient(spec)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &environ{
+		client:    client,
+		namespace: namespace,
+		ecfg:      ecfg,
+	}, nil
+}
+
+// Provider implements environs.Environ.
+func (*environ) Provider() environs.EnvironProvider {
+	return providerInstance
+}
+
+// SetConfig implements environs.Environ.
+func (env *environ) SetConfig(cfg *config.Config) error {
+	env.lock.Lock()
+	defer env.lock.Unlock()
+	ecfg, err := newValidConfig(cfg, env.ecfg.Config)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	env.ecfg = ecfg
+	return nil
+}
+
+// Config implements environs.Environ.
+func (env *environ) Config() *config.Config {
+	return env.envConfig().Config
+}
+
+func (env *environ) envConfig() *environConfig {
+	env.lock.Lock()
+	defer env.lock.Unlock()
+	return env.ecfg
+}
+
+// modelLabels returns the labels that identify resources belonging
+// to the model.
+func (env *environ) modelLabels() map[string]string {
+	return map[string]string{labelModel: env.Config().UUID()}
+}
+
+// PrepareForBootstrap implements environs.Environ.
+func (env *environ) PrepareForBootstrap(ctx environs.BootstrapContext) error {
+	if env.Config().FirewallMode() == config.FwGlobal {
+		return errors.NotSupportedf("%q firewall mode", config.FwGlobal)
+	}
+	return nil
+}
+
+// Create implements environs.Environ.
+func (env *environ) Create(args environs.CreateParams) error {
+	return errors.Trace(env.ensureNamespace(args.ControllerUUID))
+}
+
+// ensureNamespace creates the model's namespace if it does not
+// already exist.
+func (env *environ) ensureNamespace(controllerUUID string) error {
+	labels := env.modelLabels()
+	labels[labelController] = controllerUUID
+	err := env.client.CreateNamespace(env.namespace, labels)
+	if errors.IsAlreadyExists(err) {
+		return nil
+	}
+	return errors.Annotatef(err, "creating namespace %q", env.namespace)
+}
+
+// Bootstrap implements environs.Environ.
+//
+// Machines in Kubernetes are not reachable over SSH, so the controller
+// is not bootstrapped with the common SSH-based implementation. Instead,
+// the complete bootstrap cloud-config is rendered into the controller
+// pod's user-data, and the controller's API server is exposed with a
+// service.
+func (env *environ) Bootstrap(ctx environs.BootstrapContext, args environs.BootstrapParams) (*environs.BootstrapResult, error) {
+	controllerUUID := args.ControllerConfig.ControllerUUID()
+	if err := env.ensureNamespace(controllerUUID); err != nil {
+		return nil, errors.Trace(err)
+	}
+	series := args.BootstrapSeries
+	if series == "" {
+		series = config.PreferredSeries(env.Config())
+	}
+	if _, err := args.AvailableTools.Match(coretools.Filter{
+		Arch:   arch.AMD64,
+		Series: series,
+	}); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	finalize := func(ctx environs.BootstrapContext, icfg *instancecfg.InstanceConfig, _ environs.BootstrapDialOpts) error {
+		cfg := env.Config()
+		icfg.Tags = instancecfg.InstanceTags(cfg.UUID(), controllerUUID, cfg, icfg.Jobs)
+		if err := instancecfg.FinishInstanceConfig(icfg, cfg); err != nil {
+			return errors.Trace(err)
+		}
+		inst, _, _, err := env.startInstance(controllerUUID, icfg, args.BootstrapConstraints, nil, func(id instance.Id) {
+			icfg.Bootstrap.BootstrapMachineInstanceId = id
+			icfg.Bootstrap.BootstrapMachineHardwareCharacteristics = hardwareCharacteristics(args.BootstrapConstraints)
+		})
+		if err != nil {
+			return errors.Annotate(err, "starting controller")
+		}
+		ctx.Infof(" - %s", inst.Id())
+
+		ports := []network.PortRange{{
+			FromPort: args.ControllerConfig.APIPort(),
+			ToPort:   args.ControllerConfig.APIPort(),
+			Protocol: "tcp",
+		}}
+		if err := env.openPorts(inst.setName, inst.id, ports); err != nil {
+			return errors.Annotate(err, "exposing controller")
+		}
+		return nil
+	}
+	return &environs.BootstrapResult{
+		Arch:     arch.AMD64,
+		Series:   series,
+		Finalize: finalize,
+	}, nil
+}
+
+// ControllerInstances implements environs.Environ.
+func (env *environ) ControllerInstances(controllerUUID string) ([]instance.Id, error) {
+	set, err := env.client.StatefulSet(env.namespace, controllerSetName)
+	if errors.IsNotFound(err) {
+		return nil, environs.ErrNotBootstrapped
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if set.Metadata.Labels[labelController] != controllerUUID {
+		return nil, environs.ErrNotBootstrapped
+	}
+	var ids []instance.Id
+	for _, ordinal := range liveOrdinals(set) {
+		ids = append(ids, instanceId(set.Metadata.Name, ordinal))
+	}
+	if len(ids) == 0 {
+		return nil, environs.ErrNoInstances
+	}
+	return ids, nil
+}
+
+// Destroy implements environs.Environ. All of the model's resources
+// are contained within its namespace, so destroying the namespace
+// destroys the model.
+func (env *environ) Destroy() error {
+	err := env.client.DeleteNamespace(env.namespace)
+	if err != nil && !errors.IsNotFound(err) {
+		return errors.Annotatef(err, "deleting namespace %q", env.namespace)
+	}
+	return nil
+}
+
+// DestroyController implements environs.Environ.
+func (env *environ) DestroyController(controllerUUID string) error {
+	if err := env.Destroy(); err != nil {
+		return errors.Trace(err)
+	}
+	namespaces, err := env.client.Namespaces(map[string]string{
+		labelController: controllerUUID,
+	})
+	if err != nil {
+		return errors.Annotate(err, "listing hosted model namespaces")
+	}
+	for _, ns := range namespaces {
+		logger.Debugf("deleting hosted model namespace %q", ns.Metadata.Name)
+		err := env.client.DeleteNamespace(ns.Metadata.Name)
+		if err != nil && !errors.IsNotFound(err) {
+			return errors.Annotatef(err, "deleting namespace %q", ns.Metadata.Name)
+		}
+	}
+	return nil
+}
+
+// OpenPorts implements environs.Firewaller. Kubernetes services select
+// pods, so only the "instance" firewall mode is supported.
+func (env *environ) OpenPorts(ports []network.PortRange) error {
+	return errors.NotSupportedf("%q firewall mode", config.FwGlobal)
+}
+
+// ClosePorts implements environs.Firewaller.
+func (env *environ) ClosePorts(ports []network.PortRange) error {
+	return errors.NotSupportedf("%q firewall mode", config.FwGlobal)
+}
+
+// Ports implements environs.Firewaller.
+func (env *environ) Ports() ([]network.PortRange, error) {
+	return nil, errors.NotSupportedf("%q firewall mode", config.FwGlobal)
+}
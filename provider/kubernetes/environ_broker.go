@@ -0,0 +1,606 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package kubernetes
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/arch"
+	"github.com/juju/utils/set"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/cloudconfig/instancecfg"
+	"github.com/juju/juju/cloudconfig/providerinit"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/provider/kubernetes/kubeclient"
+	"github.com/juju/juju/state/multiwatcher"
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/tools"
+)
+
+const (
+	// controllerSetName is the name of the stateful set that
+	// controller machines belong to.
+	controllerSetName = "juju-controller"
+
+	// machineSetName is the name of the stateful set that machines
+	// not hosting any units belong to.
+	machineSetName = "juju-machine"
+
+	// annotationStopped records the ordinals of a stateful set's
+	// pods whose machines have been stopped, but which could not
+	// yet be removed by scaling the stateful set down.
+	annotationStopped = "juju-stopped-ordinals"
+
+	// userDataVolumeName is the name of the pod volume containing
+	// the user-data for each of the stateful set's pods, keyed by
+	// pod name.
+	userDataVolumeName = "juju-userdata"
+	userDataMountPath  = "/var/lib/juju/seed"
+
+	// volumesMountPath is the directory in which the pod's persistent
+	// volume claims are mounted.
+	volumesMountPath = "/var/lib/juju/volumes"
+)
+
+// seedCommand is run as the machine container's command. It copies the
+// pod's user-data into place for cloud-init's NoCloud datasource, and
+// then starts the init system.
+var seedCommand = fmt.Sprintf(`set -e
+mkdir -p /var/lib/cloud/seed/nocloud
+cp %s/$(hostname) /var/lib/cloud/seed/nocloud/user-data
+echo "instance-id: $(hostname)" > /var/lib/cloud/seed/nocloud/meta-data
+exec /sbin/init`, userDataMountPath)
+
+func isController(icfg *instancecfg.InstanceConfig) bool {
+	return multiwatcher.AnyJobNeedsState(icfg.Jobs...)
+}
+
+// statefulSetName returns the name of the stateful set that the
+// machine with the given instance config should belong to. Each
+// application maps to a stateful set, and each of the application's
+// units to a pod within it.
+func statefulSetName(icfg *instancecfg.InstanceConfig) string {
+	if isController(icfg) {
+		return controllerSetName
+	}
+	if units := strings.Fields(icfg.Tags[tags.JujuUnitsDeployed]); len(units) > 0 {
+		if application, err := names.UnitApplication(units[0]); err == nil {
+			return application
+		}
+	}
+	return machineSetName
+}
+
+// instanceId returns the ID of the instance for the stateful set's pod
+// with the given ordinal. The instance ID is the pod's name.
+func instanceId(setName string, ordinal int) instance.Id {
+	return instance.Id(fmt.Sprintf("%s-%d", setName, ordinal))
+}
+
+// parseInstanceId returns the stateful set name and pod ordinal for
+// the given instance ID.
+func parseInstanceId(id instance.Id) (string, int, error) {
+	i := strings.LastIndex(string(id), "-")
+	if i < 0 {
+		return "", -1, errors.NotValidf("instance ID %q", id)
+	}
+	ordinal, err := strconv.Atoi(string(id[i+1:]))
+	if err != nil || ordinal < 0 {
+		return "", -1, errors.NotValidf("instance ID %q", id)
+	}
+	return string(id[:i]), ordinal, nil
+}
+
+func volumeClaimTemplateName(index int) string {
+	return fmt.Sprintf("juju-volume-%d", index)
+}
+
+// stoppedOrdinals returns the ordinals of the stateful set's pods
+// whose machines have been stopped.
+func stoppedOrdinals(statefulSet *kubeclient.StatefulSet) set.Ints {
+	stopped := set.NewInts()
+	for _, field := range strings.Split(statefulSet.Metadata.Annotations[annotationStopped], ",") {
+		if ordinal, err := strconv.Atoi(field); err == nil {
+			stopped.Add(ordinal)
+		}
+	}
+	return stopped
+}
+
+func setStoppedOrdinals(statefulSet *kubeclient.StatefulSet, stopped set.Ints) {
+	if stopped.IsEmpty() {
+		delete(statefulSet.Metadata.Annotations, annotationStopped)
+		return
+	}
+	fields := make([]string, 0, stopped.Size())
+	for _, ordinal := range stopped.SortedValues() {
+		fields = append(fields, strconv.Itoa(ordinal))
+	}
+	if statefulSet.Metadata.Annotations == nil {
+		statefulSet.Metadata.Annotations = make(map[string]string)
+	}
+	statefulSet.Metadata.Annotations[annotationStopped] = strings.Join(fields, ",")
+}
+
+// liveOrdinals returns the ordinals of the stateful set's pods whose
+// machines have not been stopped.
+func liveOrdinals(statefulSet *kubeclient.StatefulSet) []int {
+	stopped := stoppedOrdinals(statefulSet)
+	var ordinals []int
+	for i := 0; i < statefulSet.Spec.Replicas; i++ {
+		if !stopped.Contains(i) {
+			ordinals = append(ordinals, i)
+		}
+	}
+	return ordinals
+}
+
+// MaintainInstance implements environs.InstanceBroker.
+func (*environ) MaintainInstance(args environs.StartInstanceParams) error {
+	return nil
+}
+
+// StartInstance implements environs.InstanceBroker.
+func (env *environ) StartInstance(args environs.StartInstanceParams) (*environs.StartInstanceResult, error) {
+	if args.Placement != "" {
+		return nil, errors.NotSupportedf("placement directives")
+	}
+	hostTools, err := args.Tools.Match(tools.Filter{Arch: arch.AMD64})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := args.InstanceConfig.SetTools(hostTools); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := instancecfg.FinishInstanceConfig(args.InstanceConfig, env.Config()); err != nil {
+		return nil, errors.Trace(err)
+	}
+	inst, volumes, attachments, err := env.startInstance(
+		args.ControllerUUID, args.InstanceConfig, args.Constraints, args.Volumes, nil,
+	)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	logger.Infof("started instance %q", inst.Id())
+	return &environs.StartInstanceResult{
+		Instance:          inst,
+		Hardware:          hardwareCharacteristics(args.Constraints),
+		Volumes:           volumes,
+		VolumeAttachments: attachments,
+	}, nil
+}
+
+// startInstance adds a pod to the machine's stateful set, creating the
+// stateful set if necessary. If beforeRender is non-nil, it is called
+// with the new instance's ID before the instance's user-data is
+// rendered.
+func (env *environ) startInstance(
+	controllerUUID string,
+	icfg *instancecfg.InstanceConfig,
+	cons constraints.Value,
+	volumes []storage.VolumeParams,
+	beforeRender func(instance.Id),
+) (*environInstance, []storage.Volume, []storage.VolumeAttachment, error) {
+	env.setLock.Lock()
+	defer env.setLock.Unlock()
+
+	setName := statefulSetName(icfg)
+	set, err := env.client.StatefulSet(env.namespace, setName)
+	create := errors.IsNotFound(err)
+	if create {
+		set, err = env.newStatefulSet(controllerUUID, setName, icfg.Series, cons, volumes)
+	}
+	if err != nil {
+		return nil, nil, nil, errors.Trace(err)
+	}
+	if n := len(set.Spec.VolumeClaimTemplates); n != len(volumes) {
+		return nil, nil, nil, errors.Errorf(
+			"machine requires %d volumes, stateful set %q has %d",
+			len(volumes), setName, n,
+		)
+	}
+
+	ordinal := set.Spec.Replicas
+	id := instanceId(setName, ordinal)
+	for i := range volumes {
+		// Kubernetes does not delete persistent volume claims when a
+		// stateful set is scaled down, and will reuse any with the
+		// same name when it is scaled up again. Claims are deleted
+		// when their machines are stopped, but one may remain if
+		// that failed; replace it rather than giving a new machine
+		// the storage of an old one.
+		claimName := volumeClaimTemplateName(i) + "-" + string(id)
+		err := env.client.DeletePersistentVolumeClaim(env.namespace, claimName)
+		if err == nil {
+			logger.Infof("deleted persistent volume claim %q of a previous machine", claimName)
+		} else if !errors.IsNotFound(err) {
+			return nil, nil, nil, errors.Annotatef(
+				err, "deleting persistent volume claim %q of a previous machine", claimName,
+			)
+		}
+	}
+
+	if beforeRender != nil {
+		beforeRender(id)
+	}
+	userData, err := providerinit.ComposeUserData(icfg, nil, kubernetesRenderer{})
+	if err != nil {
+		return nil, nil, nil, errors.Annotate(err, "cannot make user data")
+	}
+	if err := env.setUserData(setName, string(id), userData); err != nil {
+		return nil, nil, nil, errors.Annotate(err, "storing user data")
+	}
+
+	set.Spec.Replicas = ordinal + 1
+	if create {
+		_, err = env.client.CreateStatefulSet(env.namespace, set)
+	} else {
+		_, err = env.client.UpdateStatefulSet(env.namespace, set)
+	}
+	if err != nil {
+		return nil, nil, nil, errors.Annotatef(err, "scaling stateful set %q", setName)
+	}
+
+	var resultVolumes []storage.Volume
+	var resultAttachments []storage.VolumeAttachment
+	for i, v := range volumes {
+		resultVolumes = append(resultVolumes, storage.Volume{
+			Tag: v.Tag,
+			VolumeInfo: storage.VolumeInfo{
+				VolumeId: volumeClaimTemplateName(i) + "-" + string(id),
+				Size:     v.Size,
+			},
+		})
+		if v.Attachment != nil {
+			resultAttachments = append(resultAttachments, storage.VolumeAttachment{
+				Volume:  v.Tag,
+				Machine: v.Attachment.Machine,
+			})
+		}
+	}
+	inst := &environInstance{env: env, id: id, setName: setName}
+	return inst, resultVolumes, resultAttachments, nil
+}
+
+// newStatefulSet returns a new stateful set with no replicas, for
+// machines with the given series, constraints and volumes.
+func (env *environ) newStatefulSet(
+	controllerUUID, setName, series string,
+	cons constraints.Value,
+	volumes []storage.VolumeParams,
+) (*kubeclient.StatefulSet, error) {
+	labels := env.modelLabels()
+	labels[labelSet] = setName
+	setLabels := env.modelLabels()
+	setLabels[labelSet] = setName
+	setLabels[labelController] = controllerUUID
+
+	privileged := true
+	container := kubeclient.Container{
+		Name:      "juju-machine",
+		Image:     env.envConfig().image(series),
+		Command:   []string{"/bin/sh", "-c", seedCommand},
+		Resources: resourceRequirements(cons),
+		VolumeMounts: []kubeclient.VolumeMount{{
+			Name:      userDataVolumeName,
+			MountPath: userDataMountPath,
+			ReadOnly:  true,
+		}},
+		// The container runs a full init system.
+		SecurityContext: &kubeclient.SecurityContext{Privileged: &privileged},
+	}
+
+	var claimTemplates []kubeclient.PersistentVolumeClaim
+	for i, v := range volumes {
+		if v.Provider != storageProviderType {
+			return nil, errors.NotSupportedf("storage provider %q", v.Provider)
+		}
+		name := volumeClaimTemplateName(i)
+		storageClass, _ := v.Attributes[storageClassAttr].(string)
+		claimTemplates = append(claimTemplates, kubeclient.PersistentVolumeClaim{
+			Metadata: kubeclient.ObjectMeta{
+				Name:   name,
+				Labels: env.modelLabels(),
+			},
+			Spec: kubeclient.PersistentVolumeClaimSpec{
+				AccessModes: []string{"ReadWriteOnce"},
+				Resources: kubeclient.ResourceRequirements{
+					Requests: kubeclient.ResourceList{
+						"storage": fmt.Sprintf("%dMi", v.Size),
+					},
+				},
+				StorageClassName: storageClass,
+			},
+		})
+		container.VolumeMounts = append(container.VolumeMounts, kubeclient.VolumeMount{
+			Name:      name,
+			MountPath: path.Join(volumesMountPath, name),
+		})
+	}
+
+	return &kubeclient.StatefulSet{
+		Metadata: kubeclient.ObjectMeta{
+			Name:   setName,
+			Labels: setLabels,
+		},
+		Spec: kubeclient.StatefulSetSpec{
+			Selector:    &kubeclient.LabelSelector{MatchLabels: labels},
+			ServiceName: setName,
+			Template: kubeclient.PodTemplateSpec{
+				Metadata: kubeclient.ObjectMeta{Labels: labels},
+				Spec: kubeclient.PodSpec{
+					Containers: []kubeclient.Container{container},
+					Volumes: []kubeclient.Volume{{
+						Name: userDataVolumeName,
+						Secret: &kubeclient.SecretVolumeSource{
+							SecretName: userDataSecretName(setName),
+						},
+					}},
+				},
+			},
+			VolumeClaimTemplates: claimTemplates,
+		},
+	}, nil
+}
+
+func userDataSecretName(setName string) string {
+	return setName + "-userdata"
+}
+
+// setUserData records the user-data for the named pod in the stateful
+// set's user-data secret, creating the secret if necessary.
+func (env *environ) setUserData(setName, podName string, userData []byte) error {
+	secretName := userDataSecretName(setName)
+	secret, err := env.client.Secret(env.namespace, secretName)
+	if errors.IsNotFound(err) {
+		_, err := env.client.CreateSecret(env.namespace, &kubeclient.Secret{
+			Metadata: kubeclient.ObjectMeta{
+				Name:   secretName,
+				Labels: env.modelLabels(),
+			},
+			Data: map[string][]byte{podName: userData},
+		})
+		return errors.Trace(err)
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if secret.Data == nil {
+		secret.Data = make(map[string][]byte)
+	}
+	secret.Data[podName] = userData
+	_, err = env.client.UpdateSecret(env.namespace, secret)
+	return errors.Trace(err)
+}
+
+// removeUserData removes the user-data for the named pods from the
+// stateful set's user-data secret.
+func (env *environ) removeUserData(setName string, podNames []string) error {
+	secret, err := env.client.Secret(env.namespace, userDataSecretName(setName))
+	if errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	for _, podName := range podNames {
+		delete(secret.Data, podName)
+	}
+	_, err = env.client.UpdateSecret(env.namespace, secret)
+	return errors.Trace(err)
+}
+
+// resourceRequirements returns the container resource requests that
+// satisfy the given constraints.
+func resourceRequirements(cons constraints.Value) kubeclient.ResourceRequirements {
+	requests := make(kubeclient.ResourceList)
+	if cons.HasCpuCores() {
+		requests["cpu"] = strconv.FormatUint(*cons.CpuCores, 10)
+	}
+	if cons.Mem != nil {
+		requests["memory"] = fmt.Sprintf("%dMi", *cons.Mem)
+	}
+	if len(requests) == 0 {
+		return kubeclient.ResourceRequirements{}
+	}
+	return kubeclient.ResourceRequirements{Requests: requests}
+}
+
+// hardwareCharacteristics returns the hardware characteristics of a
+// pod started with the given constraints. Pods are guaranteed the
+// resources that they request, and no more.
+func hardwareCharacteristics(cons constraints.Value) *instance.HardwareCharacteristics {
+	amd64 := arch.AMD64
+	return &instance.HardwareCharacteristics{
+		Arch:     &amd64,
+		CpuCores: cons.CpuCores,
+		Mem:      cons.Mem,
+	}
+}
+
+// StopInstances implements environs.InstanceBroker.
+//
+// A stateful set's pods can only be removed by scaling the set down,
+// which removes the pods with the highest ordinals. Stopping any other
+// pod's machine records the pod's ordinal as stopped, and the pod is
+// removed once the pods with higher ordinals have been. The persistent
+// volume claims of removed pods are deleted, and the ports opened by
+// stopped machines are closed.
+func (env *environ) StopInstances(ids ...instance.Id) error {
+	env.setLock.Lock()
+	defer env.setLock.Unlock()
+
+	bySet := make(map[string][]int)
+	for _, id := range ids {
+		setName, ordinal, err := parseInstanceId(id)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		bySet[setName] = append(bySet[setName], ordinal)
+	}
+	for setName, ordinals := range bySet {
+		if err := env.stopOrdinals(setName, ordinals); err != nil {
+			return errors.Annotatef(err, "stopping instances in stateful set %q", setName)
+		}
+	}
+	return nil
+}
+
+func (env *environ) stopOrdinals(setName string, ordinals []int) error {
+	set, err := env.client.StatefulSet(env.namespace, setName)
+	if errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	stopped := stoppedOrdinals(set)
+	podNames := make([]string, len(ordinals))
+	ids := make([]instance.Id, len(ordinals))
+	for i, ordinal := range ordinals {
+		stopped.Add(ordinal)
+		ids[i] = instanceId(setName, ordinal)
+		podNames[i] = string(ids[i])
+	}
+	if err := env.removeUserData(setName, podNames); err != nil {
+		return errors.Annotate(err, "removing user data")
+	}
+	replicas := set.Spec.Replicas
+	for set.Spec.Replicas > 0 && stopped.Contains(set.Spec.Replicas-1) {
+		stopped.Remove(set.Spec.Replicas - 1)
+		set.Spec.Replicas--
+	}
+	if set.Spec.Replicas == 0 {
+		if err := env.deleteStatefulSet(setName); err != nil {
+			return errors.Trace(err)
+		}
+	} else {
+		if err := env.removeInstancePorts(setName, ids); err != nil {
+			return errors.Trace(err)
+		}
+		setStoppedOrdinals(set, stopped)
+		if _, err := env.client.UpdateStatefulSet(env.namespace, set); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	for ordinal := set.Spec.Replicas; ordinal < replicas; ordinal++ {
+		if err := env.deleteClaims(set, instanceId(setName, ordinal)); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// deleteClaims deletes the persistent volume claims created from the
+// stateful set's volume claim templates for the pod with the given
+// instance ID.
+func (env *environ) deleteClaims(set *kubeclient.StatefulSet, id instance.Id) error {
+	for _, template := range set.Spec.VolumeClaimTemplates {
+		claimName := template.Metadata.Name + "-" + string(id)
+		err := env.client.DeletePersistentVolumeClaim(env.namespace, claimName)
+		if err != nil && !errors.IsNotFound(err) {
+			return errors.Annotatef(err, "deleting persistent volume claim %q", claimName)
+		}
+	}
+	return nil
+}
+
+// deleteStatefulSet deletes the named stateful set, along with its
+// user-data secret and service.
+func (env *environ) deleteStatefulSet(setName string) error {
+	if err := env.client.DeleteStatefulSet(env.namespace, setName); err != nil && !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	if err := env.client.DeleteSecret(env.namespace, userDataSecretName(setName)); err != nil && !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	if err := env.client.DeleteService(env.namespace, setName); err != nil && !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// AllInstances implements environs.InstanceBroker.
+func (env *environ) AllInstances() ([]instance.Instance, error) {
+	instances, err := env.allInstances()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ids := make([]string, 0, len(instances))
+	for id := range instances {
+		ids = append(ids, string(id))
+	}
+	sort.Strings(ids)
+	result := make([]instance.Instance, len(ids))
+	for i, id := range ids {
+		result[i] = instances[instance.Id(id)]
+	}
+	return result, nil
+}
+
+// Instances implements environs.Environ.
+func (env *environ) Instances(ids []instance.Id) ([]instance.Instance, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	instances, err := env.allInstances()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]instance.Instance, len(ids))
+	var found int
+	for i, id := range ids {
+		if inst, ok := instances[id]; ok {
+			result[i] = inst
+			found++
+		}
+	}
+	switch found {
+	case 0:
+		return nil, environs.ErrNoInstances
+	case len(ids):
+		return result, nil
+	}
+	return result, environs.ErrPartialInstances
+}
+
+// allInstances returns all of the model's instances, keyed by ID. An
+// instance exists for each live ordinal of each stateful set, whether
+// or not Kubernetes has created its pod yet.
+func (env *environ) allInstances() (map[instance.Id]*environInstance, error) {
+	sets, err := env.client.StatefulSets(env.namespace, env.modelLabels())
+	if errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Annotate(err, "listing stateful sets")
+	}
+	pods, err := env.client.Pods(env.namespace, env.modelLabels())
+	if err != nil {
+		return nil, errors.Annotate(err, "listing pods")
+	}
+	podsByName := make(map[string]*kubeclient.Pod)
+	for i := range pods {
+		podsByName[pods[i].Metadata.Name] = &pods[i]
+	}
+	instances := make(map[instance.Id]*environInstance)
+	for i := range sets {
+		set := &sets[i]
+		for _, ordinal := range liveOrdinals(set) {
+			id := instanceId(set.Metadata.Name, ordinal)
+			instances[id] = &environInstance{
+				env:     env,
+				id:      id,
+				setName: set.Metadata.Name,
+				pod:     podsByName[string(id)],
+			}
+		}
+	}
+	return instances, nil
+}
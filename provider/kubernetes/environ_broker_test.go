@@ -0,0 +1,208 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package kubernetes_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/storage"
+)
+
+type environBrokerSuite struct {
+	baseSuite
+}
+
+var _ = gc.Suite(&environBrokerSuite{})
+
+func (s *environBrokerSuite) TestStartInstance(c *gc.C) {
+	params := s.startInstanceParams(c, "1", "mysql/0")
+	params.Constraints = constraints.MustParse("cores=2 mem=4G")
+	result, err := s.env.StartInstance(params)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Instance.Id(), gc.Equals, instance.Id("mysql-0"))
+	c.Assert(*result.Hardware.CpuCores, gc.Equals, uint64(2))
+	c.Assert(*result.Hardware.Mem, gc.Equals, uint64(4096))
+
+	set, err := s.client.StatefulSet(testNamespace, "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(set.Spec.Replicas, gc.Equals, 1)
+	container := set.Spec.Template.Spec.Containers[0]
+	c.Assert(container.Image, gc.Equals, "ubuntu:xenial")
+	c.Assert(container.Resources.Requests, jc.DeepEquals, map[string]string{
+		"cpu":    "2",
+		"memory": "4096Mi",
+	})
+
+	secret, err := s.client.Secret(testNamespace, "mysql-userdata")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(secret.Data["mysql-0"], gc.Not(gc.HasLen), 0)
+
+	pod, err := s.client.Pod(testNamespace, "mysql-0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pod.Status.Phase, gc.Equals, "Running")
+}
+
+func (s *environBrokerSuite) TestStartInstanceSharesStatefulSet(c *gc.C) {
+	_, err := s.env.StartInstance(s.startInstanceParams(c, "1", "mysql/0"))
+	c.Assert(err, jc.ErrorIsNil)
+	result, err := s.env.StartInstance(s.startInstanceParams(c, "2", "mysql/1"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Instance.Id(), gc.Equals, instance.Id("mysql-1"))
+	result, err = s.env.StartInstance(s.startInstanceParams(c, "3"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Instance.Id(), gc.Equals, instance.Id("juju-machine-0"))
+
+	set, err := s.client.StatefulSet(testNamespace, "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(set.Spec.Replicas, gc.Equals, 2)
+
+	instances, err := s.env.AllInstances()
+	c.Assert(err, jc.ErrorIsNil)
+	ids := make([]instance.Id, len(instances))
+	for i, inst := range instances {
+		ids[i] = inst.Id()
+	}
+	c.Assert(ids, jc.DeepEquals, []instance.Id{"juju-machine-0", "mysql-0", "mysql-1"})
+}
+
+func (s *environBrokerSuite) TestStartInstancePlacement(c *gc.C) {
+	params := s.startInstanceParams(c, "1")
+	params.Placement = "node=foo"
+	_, err := s.env.StartInstance(params)
+	c.Assert(err, gc.ErrorMatches, "placement directives not supported")
+}
+
+func (s *environBrokerSuite) TestStartInstanceVolumes(c *gc.C) {
+	params := s.startInstanceParams(c, "1", "postgresql/0")
+	params.Volumes = []storage.VolumeParams{{
+		Tag:        names.NewVolumeTag("0"),
+		Size:       2048,
+		Provider:   "kubernetes",
+		Attributes: map[string]interface{}{"storage-class": "fast"},
+		Attachment: &storage.VolumeAttachmentParams{
+			AttachmentParams: storage.AttachmentParams{
+				Machine: names.NewMachineTag("1"),
+			},
+			Volume: names.NewVolumeTag("0"),
+		},
+	}}
+	result, err := s.env.StartInstance(params)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Volumes, jc.DeepEquals, []storage.Volume{{
+		Tag: names.NewVolumeTag("0"),
+		VolumeInfo: storage.VolumeInfo{
+			VolumeId: "juju-volume-0-postgresql-0",
+			Size:     2048,
+		},
+	}})
+	c.Assert(result.VolumeAttachments, jc.DeepEquals, []storage.VolumeAttachment{{
+		Volume:  names.NewVolumeTag("0"),
+		Machine: names.NewMachineTag("1"),
+	}})
+
+	claim, err := s.client.PersistentVolumeClaim(testNamespace, "juju-volume-0-postgresql-0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(claim.Spec.StorageClassName, gc.Equals, "fast")
+	c.Assert(claim.Spec.Resources.Requests["storage"], gc.Equals, "2048Mi")
+}
+
+func (s *environBrokerSuite) TestStopInstances(c *gc.C) {
+	for _, unit := range []string{"mysql/0", "mysql/1", "mysql/2"} {
+		_, err := s.env.StartInstance(s.startInstanceParams(c, "1", unit))
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	// Stopping a pod other than the last leaves the stateful set's
+	// size unchanged, but the instance is gone.
+	err := s.env.StopInstances("mysql-1")
+	c.Assert(err, jc.ErrorIsNil)
+	set, err := s.client.StatefulSet(testNamespace, "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(set.Spec.Replicas, gc.Equals, 3)
+	instances, err := s.env.Instances([]instance.Id{"mysql-0", "mysql-1", "mysql-2"})
+	c.Assert(err, gc.Equals, environs.ErrPartialInstances)
+	c.Assert(instances[1], gc.IsNil)
+
+	// Stopping the last pod scales the stateful set down past any
+	// previously stopped pods.
+	err = s.env.StopInstances("mysql-2")
+	c.Assert(err, jc.ErrorIsNil)
+	set, err = s.client.StatefulSet(testNamespace, "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(set.Spec.Replicas, gc.Equals, 1)
+	_, err = s.client.Pod(testNamespace, "mysql-1")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	// Stopping the remaining pod deletes the stateful set.
+	err = s.env.StopInstances("mysql-0")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.client.StatefulSet(testNamespace, "mysql")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	_, err = s.env.Instances([]instance.Id{"mysql-0"})
+	c.Assert(err, gc.Equals, environs.ErrNoInstances)
+}
+
+func (s *environBrokerSuite) TestStopInstancesDeletesClaims(c *gc.C) {
+	params := s.startInstanceParams(c, "1", "postgresql/0")
+	params.Volumes = []storage.VolumeParams{{
+		Tag:      names.NewVolumeTag("0"),
+		Size:     1024,
+		Provider: "kubernetes",
+	}}
+	for i := 0; i < 3; i++ {
+		_, err := s.env.StartInstance(params)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	// The claim of a stopped pod remains until the pod is removed.
+	err := s.env.StopInstances("postgresql-1")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.client.PersistentVolumeClaim(testNamespace, "juju-volume-0-postgresql-1")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.env.StopInstances("postgresql-2")
+	c.Assert(err, jc.ErrorIsNil)
+	for _, name := range []string{"juju-volume-0-postgresql-1", "juju-volume-0-postgresql-2"} {
+		_, err = s.client.PersistentVolumeClaim(testNamespace, name)
+		c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	}
+
+	err = s.env.StopInstances("postgresql-0")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.client.PersistentVolumeClaim(testNamespace, "juju-volume-0-postgresql-0")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *environBrokerSuite) TestStartInstanceReplacesOldClaim(c *gc.C) {
+	params := s.startInstanceParams(c, "1", "postgresql/0")
+	params.Volumes = []storage.VolumeParams{{
+		Tag:      names.NewVolumeTag("0"),
+		Size:     1024,
+		Provider: "kubernetes",
+	}}
+	_, err := s.env.StartInstance(params)
+	c.Assert(err, jc.ErrorIsNil)
+	claim, err := s.client.PersistentVolumeClaim(testNamespace, "juju-volume-0-postgresql-0")
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Scale the stateful set down without deleting the claim, as
+	// would happen if deleting it failed.
+	set, err := s.client.StatefulSet(testNamespace, "postgresql")
+	c.Assert(err, jc.ErrorIsNil)
+	set.Spec.Replicas = 0
+	_, err = s.client.UpdateStatefulSet(testNamespace, set)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.env.StartInstance(params)
+	c.Assert(err, jc.ErrorIsNil)
+	newClaim, err := s.client.PersistentVolumeClaim(testNamespace, "juju-volume-0-postgresql-0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(newClaim.Metadata.ResourceVersion, gc.Not(gc.Equals), claim.Metadata.ResourceVersion)
+}
@@ -0,0 +1,40 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package kubernetes
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/utils/arch"
+
+	"github.com/juju/juju/constraints"
+)
+
+// PrecheckInstance implements environs.Environ.
+func (env *environ) PrecheckInstance(series string, cons constraints.Value, placement string) error {
+	if placement != "" {
+		return errors.NotSupportedf("placement directives")
+	}
+	return nil
+}
+
+var unsupportedConstraints = []string{
+	constraints.Container,
+	constraints.CpuPower,
+	constraints.InstanceType,
+	constraints.RootDisk,
+	constraints.Spaces,
+	constraints.Tags,
+	constraints.VirtType,
+	constraints.Zones,
+	constraints.PlacementGroup,
+}
+
+// ConstraintsValidator implements environs.Environ. Pods request
+// the CPU cores and memory specified by the constraints.
+func (env *environ) ConstraintsValidator() (constraints.Validator, error) {
+	validator := constraints.NewValidator()
+	validator.RegisterUnsupported(unsupportedConstraints)
+	validator.RegisterVocabulary(constraints.Arch, []string{arch.AMD64})
+	return validator, nil
+}
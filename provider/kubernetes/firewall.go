@@ -0,0 +1,241 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package kubernetes
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/provider/kubernetes/kubeclient"
+)
+
+// annotationPorts records the port ranges opened on a service by each
+// of the stateful set's instances, as a JSON object mapping instance
+// IDs to port ranges. A Kubernetes service lists individual ports, so
+// the port ranges are recorded separately in order to report them back
+// as they were opened, and so that a port remains open on the shared
+// service while any instance has it open.
+const annotationPorts = "juju-port-ranges"
+
+// maxServicePorts is the maximum number of individual ports that may
+// be opened on a service. Each port in a port range becomes a separate
+// service port, so large ranges are refused rather than expanded.
+const maxServicePorts = 100
+
+// openPorts opens the given port ranges for the instance on the service
+// for the named stateful set, creating the service if necessary.
+func (env *environ) openPorts(setName string, id instance.Id, ports []network.PortRange) error {
+	svc, err := env.client.Service(env.namespace, setName)
+	create := errors.IsNotFound(err)
+	if create {
+		selector := env.modelLabels()
+		selector[labelSet] = setName
+		svc = &kubeclient.Service{
+			Metadata: kubeclient.ObjectMeta{
+				Name:   setName,
+				Labels: env.modelLabels(),
+			},
+			Spec: kubeclient.ServiceSpec{
+				Type:     env.envConfig().serviceType(),
+				Selector: selector,
+			},
+		}
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	byInstance, err := servicePortRanges(svc)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	existing := byInstance[id]
+	for _, p := range ports {
+		if !containsPortRange(existing, p) {
+			existing = append(existing, p)
+		}
+	}
+	byInstance[id] = existing
+	if err := setServicePortRanges(svc, byInstance); err != nil {
+		return errors.Annotatef(err, "opening ports on service %q", setName)
+	}
+	if create {
+		_, err = env.client.CreateService(env.namespace, svc)
+	} else {
+		_, err = env.client.UpdateService(env.namespace, svc)
+	}
+	return errors.Annotatef(err, "opening ports on service %q", setName)
+}
+
+// closePorts closes the given port ranges for the instance on the
+// service for the named stateful set. Ports remain open on the service
+// while other instances have them open, and the service is deleted
+// once no ports remain open.
+func (env *environ) closePorts(setName string, id instance.Id, ports []network.PortRange) error {
+	return errors.Annotatef(env.updatePorts(setName, func(byInstance map[instance.Id][]network.PortRange) {
+		var remaining []network.PortRange
+		for _, p := range byInstance[id] {
+			if !containsPortRange(ports, p) {
+				remaining = append(remaining, p)
+			}
+		}
+		byInstance[id] = remaining
+	}), "closing ports on service %q", setName)
+}
+
+// removeInstancePorts closes all of the port ranges opened by the
+// given instances on the service for the named stateful set.
+func (env *environ) removeInstancePorts(setName string, ids []instance.Id) error {
+	return errors.Annotatef(env.updatePorts(setName, func(byInstance map[instance.Id][]network.PortRange) {
+		for _, id := range ids {
+			delete(byInstance, id)
+		}
+	}), "closing ports on service %q", setName)
+}
+
+// updatePorts updates the port ranges opened by the stateful set's
+// instances on the service for the named stateful set, deleting the
+// service if no ports remain open.
+func (env *environ) updatePorts(setName string, update func(map[instance.Id][]network.PortRange)) error {
+	svc, err := env.client.Service(env.namespace, setName)
+	if errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	byInstance, err := servicePortRanges(svc)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	update(byInstance)
+	for id, ports := range byInstance {
+		if len(ports) == 0 {
+			delete(byInstance, id)
+		}
+	}
+	if len(byInstance) == 0 {
+		err := env.client.DeleteService(env.namespace, setName)
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return errors.Annotatef(err, "deleting service %q", setName)
+	}
+	if err := setServicePortRanges(svc, byInstance); err != nil {
+		return errors.Trace(err)
+	}
+	_, err = env.client.UpdateService(env.namespace, svc)
+	return errors.Trace(err)
+}
+
+// ports returns the port ranges opened by the instance on the service
+// for the named stateful set.
+func (env *environ) ports(setName string, id instance.Id) ([]network.PortRange, error) {
+	svc, err := env.client.Service(env.namespace, setName)
+	if errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	byInstance, err := servicePortRanges(svc)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ports := byInstance[id]
+	network.SortPortRanges(ports)
+	return ports, nil
+}
+
+func containsPortRange(ports []network.PortRange, p network.PortRange) bool {
+	for _, q := range ports {
+		if q == p {
+			return true
+		}
+	}
+	return false
+}
+
+// servicePortRanges returns the port ranges opened on the service,
+// keyed by the ID of the instance that opened them.
+func servicePortRanges(svc *kubeclient.Service) (map[instance.Id][]network.PortRange, error) {
+	byInstance := make(map[instance.Id][]network.PortRange)
+	value := svc.Metadata.Annotations[annotationPorts]
+	if value == "" {
+		return byInstance, nil
+	}
+	var values map[string]string
+	if err := json.Unmarshal([]byte(value), &values); err != nil {
+		return nil, errors.Annotatef(err, "parsing %s annotation", annotationPorts)
+	}
+	for id, value := range values {
+		ports, err := network.ParsePortRanges(value)
+		if err != nil {
+			return nil, errors.Annotatef(err, "parsing %s annotation", annotationPorts)
+		}
+		byInstance[instance.Id(id)] = ports
+	}
+	return byInstance, nil
+}
+
+// setServicePortRanges sets the service's ports to the union of the
+// port ranges opened by each instance. Kubernetes services support
+// only TCP and UDP ports. An error is returned if the port ranges
+// would open more than maxServicePorts ports.
+func setServicePortRanges(svc *kubeclient.Service, byInstance map[instance.Id][]network.PortRange) error {
+	values := make(map[string]string)
+	var all []network.PortRange
+	for id, ports := range byInstance {
+		network.SortPortRanges(ports)
+		rangeValues := make([]string, len(ports))
+		for i, p := range ports {
+			rangeValues[i] = p.String()
+			if !containsPortRange(all, p) {
+				all = append(all, p)
+			}
+		}
+		values[string(id)] = strings.Join(rangeValues, ",")
+	}
+	network.SortPortRanges(all)
+
+	var servicePorts []kubeclient.ServicePort
+	seen := set.NewStrings()
+	for _, p := range all {
+		protocol := strings.ToLower(p.Protocol)
+		if protocol != "tcp" && protocol != "udp" {
+			logger.Warningf("ignoring %s port range %s: protocol not supported", svc.Metadata.Name, p)
+			continue
+		}
+		if n := seen.Size() + p.ToPort - p.FromPort + 1; n > maxServicePorts {
+			return errors.Errorf(
+				"port range %s would open more than %d ports on the service", p, maxServicePorts,
+			)
+		}
+		for port := p.FromPort; port <= p.ToPort; port++ {
+			name := fmt.Sprintf("%s-%d", protocol, port)
+			if seen.Contains(name) {
+				continue
+			}
+			seen.Add(name)
+			servicePorts = append(servicePorts, kubeclient.ServicePort{
+				Name:     name,
+				Protocol: strings.ToUpper(protocol),
+				Port:     port,
+			})
+		}
+	}
+
+	value, err := json.Marshal(values)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if svc.Metadata.Annotations == nil {
+		svc.Metadata.Annotations = make(map[string]string)
+	}
+	svc.Metadata.Annotations[annotationPorts] = string(value)
+	svc.Spec.Ports = servicePorts
+	return nil
+}
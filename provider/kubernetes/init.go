@@ -0,0 +1,16 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package kubernetes
+
+import (
+	"github.com/juju/juju/environs"
+)
+
+const (
+	providerType = "kubernetes"
+)
+
+func init() {
+	environs.RegisterProvider(providerType, providerInstance)
+}
@@ -0,0 +1,103 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package kubernetes
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/provider/kubernetes/kubeclient"
+	"github.com/juju/juju/status"
+)
+
+// environInstance is a machine running as a pod in a stateful set.
+type environInstance struct {
+	env     *environ
+	id      instance.Id
+	setName string
+
+	// pod is the instance's pod, or nil if Kubernetes has not
+	// yet created it.
+	pod *kubeclient.Pod
+}
+
+var _ instance.Instance = (*environInstance)(nil)
+
+// Id implements instance.Instance.
+func (inst *environInstance) Id() instance.Id {
+	return inst.id
+}
+
+// Status implements instance.Instance.
+func (inst *environInstance) Status() instance.InstanceStatus {
+	if inst.pod == nil {
+		return instance.InstanceStatus{Status: status.StatusPending}
+	}
+	jujuStatus := status.StatusEmpty
+	switch inst.pod.Status.Phase {
+	case kubeclient.PodPending:
+		jujuStatus = status.StatusAllocating
+	case kubeclient.PodRunning:
+		jujuStatus = status.StatusRunning
+	case kubeclient.PodFailed:
+		jujuStatus = status.StatusProvisioningError
+	}
+	message := inst.pod.Status.Phase
+	if inst.pod.Status.Message != "" {
+		message += ": " + inst.pod.Status.Message
+	}
+	return instance.InstanceStatus{
+		Status:  jujuStatus,
+		Message: message,
+	}
+}
+
+// Addresses implements instance.Instance. The pod's own address is
+// reachable from within the cluster; the address of the stateful set's
+// service, if it has been exposed, is reachable from outside it.
+func (inst *environInstance) Addresses() ([]network.Address, error) {
+	var addresses []network.Address
+	if inst.pod != nil && inst.pod.Status.PodIP != "" {
+		addresses = append(addresses, network.NewScopedAddress(
+			inst.pod.Status.PodIP, network.ScopeCloudLocal,
+		))
+	}
+	svc, err := inst.env.client.Service(inst.env.namespace, inst.setName)
+	if errors.IsNotFound(err) {
+		return addresses, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, ingress := range svc.Status.LoadBalancer.Ingress {
+		if ingress.IP != "" {
+			addresses = append(addresses, network.NewScopedAddress(ingress.IP, network.ScopePublic))
+		}
+		if ingress.Hostname != "" {
+			addresses = append(addresses, network.NewScopedAddress(ingress.Hostname, network.ScopePublic))
+		}
+	}
+	return addresses, nil
+}
+
+// OpenPorts implements instance.Instance. Ports are opened on the
+// service for the instance's stateful set, and so are opened for all
+// units of the application; the ports opened by each instance are
+// recorded, and a port is closed on the service only once no instance
+// has it open.
+func (inst *environInstance) OpenPorts(machineId string, ports []network.PortRange) error {
+	return errors.Trace(inst.env.openPorts(inst.setName, inst.id, ports))
+}
+
+// ClosePorts implements instance.Instance.
+func (inst *environInstance) ClosePorts(machineId string, ports []network.PortRange) error {
+	return errors.Trace(inst.env.closePorts(inst.setName, inst.id, ports))
+}
+
+// Ports implements instance.Instance. Only the ports opened by this
+// instance are returned.
+func (inst *environInstance) Ports(machineId string) ([]network.PortRange, error) {
+	ports, err := inst.env.ports(inst.setName, inst.id)
+	return ports, errors.Trace(err)
+}
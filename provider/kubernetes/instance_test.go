@@ -0,0 +1,158 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package kubernetes_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/status"
+)
+
+type instanceSuite struct {
+	baseSuite
+	inst instance.Instance
+}
+
+var _ = gc.Suite(&instanceSuite{})
+
+func (s *instanceSuite) SetUpTest(c *gc.C) {
+	s.baseSuite.SetUpTest(c)
+	result, err := s.env.StartInstance(s.startInstanceParams(c, "1", "wordpress/0"))
+	c.Assert(err, jc.ErrorIsNil)
+	instances, err := s.env.Instances([]instance.Id{result.Instance.Id()})
+	c.Assert(err, jc.ErrorIsNil)
+	s.inst = instances[0]
+}
+
+func (s *instanceSuite) TestStatus(c *gc.C) {
+	c.Assert(s.inst.Status(), jc.DeepEquals, instance.InstanceStatus{
+		Status:  status.StatusRunning,
+		Message: "Running",
+	})
+
+	s.server.SetPodPhase(testNamespace, "wordpress-0", "Failed")
+	instances, err := s.env.Instances([]instance.Id{"wordpress-0"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(instances[0].Status().Status, gc.Equals, status.StatusProvisioningError)
+}
+
+func (s *instanceSuite) TestAddresses(c *gc.C) {
+	addrs, err := s.inst.Addresses()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(addrs, jc.DeepEquals, []network.Address{
+		network.NewScopedAddress("10.1.0.1", network.ScopeCloudLocal),
+	})
+
+	err = s.inst.OpenPorts("1", []network.PortRange{{80, 80, "tcp"}})
+	c.Assert(err, jc.ErrorIsNil)
+	addrs, err = s.inst.Addresses()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(addrs, jc.DeepEquals, []network.Address{
+		network.NewScopedAddress("10.1.0.1", network.ScopeCloudLocal),
+		network.NewScopedAddress("203.0.113.1", network.ScopePublic),
+	})
+}
+
+func (s *instanceSuite) TestOpenPorts(c *gc.C) {
+	err := s.inst.OpenPorts("1", []network.PortRange{
+		{80, 80, "tcp"},
+		{8000, 8002, "tcp"},
+		{53, 53, "udp"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	svc, err := s.client.Service(testNamespace, "wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(svc.Spec.Type, gc.Equals, "LoadBalancer")
+	c.Assert(svc.Spec.Selector["juju-set"], gc.Equals, "wordpress")
+	var portNames []string
+	for _, p := range svc.Spec.Ports {
+		portNames = append(portNames, p.Name)
+	}
+	c.Assert(portNames, jc.DeepEquals, []string{
+		"tcp-80", "tcp-8000", "tcp-8001", "tcp-8002", "udp-53",
+	})
+
+	ports, err := s.inst.Ports("1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ports, jc.DeepEquals, []network.PortRange{
+		{80, 80, "tcp"},
+		{8000, 8002, "tcp"},
+		{53, 53, "udp"},
+	})
+}
+
+func (s *instanceSuite) TestClosePorts(c *gc.C) {
+	err := s.inst.OpenPorts("1", []network.PortRange{
+		{80, 80, "tcp"},
+		{443, 443, "tcp"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.inst.ClosePorts("1", []network.PortRange{{80, 80, "tcp"}})
+	c.Assert(err, jc.ErrorIsNil)
+	ports, err := s.inst.Ports("1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ports, jc.DeepEquals, []network.PortRange{{443, 443, "tcp"}})
+
+	// Closing the last port deletes the service.
+	err = s.inst.ClosePorts("1", []network.PortRange{{443, 443, "tcp"}})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.client.Service(testNamespace, "wordpress")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	ports, err = s.inst.Ports("1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ports, gc.HasLen, 0)
+}
+
+func (s *instanceSuite) TestPortsSharedByInstances(c *gc.C) {
+	result, err := s.env.StartInstance(s.startInstanceParams(c, "2", "wordpress/1"))
+	c.Assert(err, jc.ErrorIsNil)
+	instances, err := s.env.Instances([]instance.Id{result.Instance.Id()})
+	c.Assert(err, jc.ErrorIsNil)
+	other := instances[0]
+
+	err = s.inst.OpenPorts("1", []network.PortRange{{80, 80, "tcp"}})
+	c.Assert(err, jc.ErrorIsNil)
+	err = other.OpenPorts("2", []network.PortRange{{80, 80, "tcp"}, {443, 443, "tcp"}})
+	c.Assert(err, jc.ErrorIsNil)
+
+	ports, err := s.inst.Ports("1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ports, jc.DeepEquals, []network.PortRange{{80, 80, "tcp"}})
+
+	// Closing a port opened by another instance leaves it open
+	// on the service.
+	err = s.inst.ClosePorts("1", []network.PortRange{{80, 80, "tcp"}})
+	c.Assert(err, jc.ErrorIsNil)
+	svc, err := s.client.Service(testNamespace, "wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	var portNames []string
+	for _, p := range svc.Spec.Ports {
+		portNames = append(portNames, p.Name)
+	}
+	c.Assert(portNames, jc.DeepEquals, []string{"tcp-80", "tcp-443"})
+
+	// Stopping the other instance closes its ports.
+	err = s.env.StopInstances(other.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.client.Service(testNamespace, "wordpress")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *instanceSuite) TestOpenPortsTooMany(c *gc.C) {
+	err := s.inst.OpenPorts("1", []network.PortRange{{1024, 65535, "tcp"}})
+	c.Assert(err, gc.ErrorMatches, `opening ports on service "wordpress": port range 1024-65535/tcp would open more than 100 ports on the service`)
+	_, err = s.client.Service(testNamespace, "wordpress")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *instanceSuite) TestGlobalPortsNotSupported(c *gc.C) {
+	err := s.env.OpenPorts([]network.PortRange{{80, 80, "tcp"}})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
@@ -0,0 +1,387 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package kubeclient provides a minimal client for the Kubernetes API,
+// covering the resources used by the Juju kubernetes provider.
+package kubeclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils"
+)
+
+var logger = loggo.GetLogger("juju.provider.kubernetes.kubeclient")
+
+const (
+	coreAPIPrefix = "/api/v1"
+	appsAPIPrefix = "/apis/apps/v1beta1"
+)
+
+// Config contains the parameters for connecting to a Kubernetes
+// API server.
+type Config struct {
+	// Endpoint is the URL of the API server, e.g.
+	// "https://10.0.0.1:6443".
+	Endpoint string
+
+	// Username and Password, if non-empty, are used for HTTP basic
+	// authentication.
+	Username string
+	Password string
+
+	// Token, if non-empty, is used as a bearer token.
+	Token string
+
+	// HTTPClient, if non-nil, is the HTTP client to use for making
+	// requests. If nil, a client that verifies TLS certificates
+	// is used.
+	HTTPClient *http.Client
+}
+
+// Validate checks that the client config is valid.
+func (cfg Config) Validate() error {
+	if cfg.Endpoint == "" {
+		return errors.NotValidf("empty Endpoint")
+	}
+	if _, err := url.Parse(cfg.Endpoint); err != nil {
+		return errors.NotValidf("Endpoint %q", cfg.Endpoint)
+	}
+	if cfg.Token != "" && (cfg.Username != "" || cfg.Password != "") {
+		return errors.NotValidf("specifying both Token and Username/Password")
+	}
+	return nil
+}
+
+// Client is a Kubernetes API client.
+type Client struct {
+	endpoint *url.URL
+	config   Config
+	http     *http.Client
+}
+
+// New returns a new Client with the given configuration.
+func New(cfg Config) (*Client, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Annotate(err, "validating client config")
+	}
+	endpoint, err := url.Parse(cfg.Endpoint)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	httpClient := cfg.HTTPClient
+	if httpClient == nil {
+		httpClient = utils.GetValidatingHTTPClient()
+	}
+	return &Client{endpoint, cfg, httpClient}, nil
+}
+
+// Namespaces returns the namespaces that match the given labels.
+func (c *Client) Namespaces(labels map[string]string) ([]Namespace, error) {
+	var out NamespaceList
+	if err := c.list(path.Join(coreAPIPrefix, "namespaces"), labels, &out); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return out.Items, nil
+}
+
+// CreateNamespace creates the namespace with the given name and labels.
+func (c *Client) CreateNamespace(name string, labels map[string]string) error {
+	in := &Namespace{
+		Kind:       "Namespace",
+		APIVersion: "v1",
+		Metadata:   ObjectMeta{Name: name, Labels: labels},
+	}
+	err := c.do("POST", path.Join(coreAPIPrefix, "namespaces"), nil, in, nil)
+	return errors.Trace(err)
+}
+
+// DeleteNamespace deletes the namespace with the given name, along
+// with all of the resources within it.
+func (c *Client) DeleteNamespace(name string) error {
+	err := c.do("DELETE", path.Join(coreAPIPrefix, "namespaces", name), nil, nil, nil)
+	return errors.Trace(err)
+}
+
+// Pods returns the pods in the namespace that match the given labels.
+func (c *Client) Pods(namespace string, labels map[string]string) ([]Pod, error) {
+	var out PodList
+	if err := c.list(corePath(namespace, "pods"), labels, &out); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return out.Items, nil
+}
+
+// Pod returns the pod with the given name.
+func (c *Client) Pod(namespace, name string) (*Pod, error) {
+	var out Pod
+	if err := c.do("GET", corePath(namespace, "pods", name), nil, nil, &out); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &out, nil
+}
+
+// StatefulSets returns the stateful sets in the namespace that match
+// the given labels.
+func (c *Client) StatefulSets(namespace string, labels map[string]string) ([]StatefulSet, error) {
+	var out StatefulSetList
+	if err := c.list(appsPath(namespace, "statefulsets"), labels, &out); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return out.Items, nil
+}
+
+// StatefulSet returns the stateful set with the given name.
+func (c *Client) StatefulSet(namespace, name string) (*StatefulSet, error) {
+	var out StatefulSet
+	if err := c.do("GET", appsPath(namespace, "statefulsets", name), nil, nil, &out); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &out, nil
+}
+
+// CreateStatefulSet creates the given stateful set, and returns the
+// stateful set as recorded by the API server.
+func (c *Client) CreateStatefulSet(namespace string, in *StatefulSet) (*StatefulSet, error) {
+	in.Kind, in.APIVersion = "StatefulSet", "apps/v1beta1"
+	var out StatefulSet
+	if err := c.do("POST", appsPath(namespace, "statefulsets"), nil, in, &out); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &out, nil
+}
+
+// UpdateStatefulSet replaces the given stateful set, and returns the
+// stateful set as recorded by the API server. The stateful set's
+// resource version must match that recorded by the API server.
+func (c *Client) UpdateStatefulSet(namespace string, in *StatefulSet) (*StatefulSet, error) {
+	in.Kind, in.APIVersion = "StatefulSet", "apps/v1beta1"
+	var out StatefulSet
+	if err := c.do("PUT", appsPath(namespace, "statefulsets", in.Metadata.Name), nil, in, &out); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &out, nil
+}
+
+// DeleteStatefulSet deletes the stateful set with the given name.
+func (c *Client) DeleteStatefulSet(namespace, name string) error {
+	err := c.do("DELETE", appsPath(namespace, "statefulsets", name), nil, nil, nil)
+	return errors.Trace(err)
+}
+
+// Services returns the services in the namespace that match the
+// given labels.
+func (c *Client) Services(namespace string, labels map[string]string) ([]Service, error) {
+	var out ServiceList
+	if err := c.list(corePath(namespace, "services"), labels, &out); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return out.Items, nil
+}
+
+// Service returns the service with the given name.
+func (c *Client) Service(namespace, name string) (*Service, error) {
+	var out Service
+	if err := c.do("GET", corePath(namespace, "services", name), nil, nil, &out); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &out, nil
+}
+
+// CreateService creates the given service, and returns the service
+// as recorded by the API server.
+func (c *Client) CreateService(namespace string, in *Service) (*Service, error) {
+	in.Kind, in.APIVersion = "Service", "v1"
+	var out Service
+	if err := c.do("POST", corePath(namespace, "services"), nil, in, &out); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &out, nil
+}
+
+// UpdateService replaces the given service, and returns the service
+// as recorded by the API server.
+func (c *Client) UpdateService(namespace string, in *Service) (*Service, error) {
+	in.Kind, in.APIVersion = "Service", "v1"
+	var out Service
+	if err := c.do("PUT", corePath(namespace, "services", in.Metadata.Name), nil, in, &out); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &out, nil
+}
+
+// DeleteService deletes the service with the given name.
+func (c *Client) DeleteService(namespace, name string) error {
+	err := c.do("DELETE", corePath(namespace, "services", name), nil, nil, nil)
+	return errors.Trace(err)
+}
+
+// PersistentVolumeClaims returns the persistent volume claims in
+// the namespace that match the given labels.
+func (c *Client) PersistentVolumeClaims(namespace string, labels map[string]string) ([]PersistentVolumeClaim, error) {
+	var out PersistentVolumeClaimList
+	if err := c.list(corePath(namespace, "persistentvolumeclaims"), labels, &out); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return out.Items, nil
+}
+
+// PersistentVolumeClaim returns the persistent volume claim with the
+// given name.
+func (c *Client) PersistentVolumeClaim(namespace, name string) (*PersistentVolumeClaim, error) {
+	var out PersistentVolumeClaim
+	if err := c.do("GET", corePath(namespace, "persistentvolumeclaims", name), nil, nil, &out); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &out, nil
+}
+
+// DeletePersistentVolumeClaim deletes the persistent volume claim
+// with the given name.
+func (c *Client) DeletePersistentVolumeClaim(namespace, name string) error {
+	err := c.do("DELETE", corePath(namespace, "persistentvolumeclaims", name), nil, nil, nil)
+	return errors.Trace(err)
+}
+
+// Secret returns the secret with the given name.
+func (c *Client) Secret(namespace, name string) (*Secret, error) {
+	var out Secret
+	if err := c.do("GET", corePath(namespace, "secrets", name), nil, nil, &out); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &out, nil
+}
+
+// CreateSecret creates the given secret, and returns the secret
+// as recorded by the API server.
+func (c *Client) CreateSecret(namespace string, in *Secret) (*Secret, error) {
+	in.Kind, in.APIVersion = "Secret", "v1"
+	var out Secret
+	if err := c.do("POST", corePath(namespace, "secrets"), nil, in, &out); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &out, nil
+}
+
+// UpdateSecret replaces the given secret, and returns the secret
+// as recorded by the API server.
+func (c *Client) UpdateSecret(namespace string, in *Secret) (*Secret, error) {
+	in.Kind, in.APIVersion = "Secret", "v1"
+	var out Secret
+	if err := c.do("PUT", corePath(namespace, "secrets", in.Metadata.Name), nil, in, &out); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &out, nil
+}
+
+// DeleteSecret deletes the secret with the given name.
+func (c *Client) DeleteSecret(namespace, name string) error {
+	err := c.do("DELETE", corePath(namespace, "secrets", name), nil, nil, nil)
+	return errors.Trace(err)
+}
+
+func corePath(namespace, resource string, name ...string) string {
+	return path.Join(append([]string{coreAPIPrefix, "namespaces", namespace, resource}, name...)...)
+}
+
+func appsPath(namespace, resource string, name ...string) string {
+	return path.Join(append([]string{appsAPIPrefix, "namespaces", namespace, resource}, name...)...)
+}
+
+// LabelSelector returns the label selector query string that matches
+// resources with all of the given labels.
+func LabelSelector(labels map[string]string) string {
+	selector := make([]string, 0, len(labels))
+	for k, v := range labels {
+		selector = append(selector, k+"="+v)
+	}
+	sort.Strings(selector)
+	return strings.Join(selector, ",")
+}
+
+func (c *Client) list(path string, labels map[string]string, out interface{}) error {
+	var query url.Values
+	if len(labels) > 0 {
+		query = url.Values{"labelSelector": {LabelSelector(labels)}}
+	}
+	return c.do("GET", path, query, nil, out)
+}
+
+func (c *Client) do(method, path string, query url.Values, in, out interface{}) error {
+	u := *c.endpoint
+	u.Path = strings.TrimSuffix(u.Path, "/") + path
+	u.RawQuery = query.Encode()
+
+	var body []byte
+	if in != nil {
+		var err error
+		if body, err = json.Marshal(in); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	req, err := http.NewRequest(method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.config.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.config.Token)
+	} else if c.config.Username != "" {
+		req.SetBasicAuth(c.config.Username, c.config.Password)
+	}
+
+	logger.Tracef("%s %s", method, u.String())
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return errors.Annotatef(err, "%s %s", method, path)
+	}
+	defer resp.Body.Close()
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Annotate(err, "reading response body")
+	}
+	if resp.StatusCode >= 300 {
+		return responseError(resp.StatusCode, path, respBody)
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return errors.Annotate(err, "decoding response body")
+	}
+	return nil
+}
+
+// responseError returns an error describing a failed API request. The
+// error will satisfy errors.IsNotFound or errors.IsAlreadyExists as
+// appropriate.
+func responseError(code int, path string, body []byte) error {
+	var status Status
+	message := strings.TrimSpace(string(body))
+	if err := json.Unmarshal(body, &status); err == nil && status.Message != "" {
+		message = status.Message
+	}
+	switch code {
+	case http.StatusNotFound:
+		return errors.NewNotFound(nil, message)
+	case http.StatusConflict:
+		if status.Reason == "AlreadyExists" {
+			return errors.NewAlreadyExists(nil, message)
+		}
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return errors.NewUnauthorized(nil, message)
+	}
+	return errors.Errorf("%s: %s (%d)", path, message, code)
+}
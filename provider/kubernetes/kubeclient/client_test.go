@@ -0,0 +1,118 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package kubeclient_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/provider/kubernetes/kubeclient"
+	"github.com/juju/juju/provider/kubernetes/kubetest"
+	"github.com/juju/juju/testing"
+)
+
+type clientSuite struct {
+	testing.BaseSuite
+	server *kubetest.Server
+	client *kubeclient.Client
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+func (s *clientSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.server = kubetest.NewServer()
+	s.AddCleanup(func(*gc.C) { s.server.Close() })
+	var err error
+	s.client, err = kubeclient.New(kubeclient.Config{
+		Endpoint: s.server.URL,
+		Token:    "token",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.client.CreateNamespace("ns", map[string]string{"app": "juju"})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *clientSuite) TestConfigValidate(c *gc.C) {
+	_, err := kubeclient.New(kubeclient.Config{})
+	c.Assert(err, gc.ErrorMatches, "validating client config: empty Endpoint not valid")
+	_, err = kubeclient.New(kubeclient.Config{
+		Endpoint: s.server.URL,
+		Token:    "token",
+		Username: "admin",
+	})
+	c.Assert(err, gc.ErrorMatches, "validating client config: specifying both Token and Username/Password not valid")
+}
+
+func (s *clientSuite) TestLabelSelector(c *gc.C) {
+	selector := kubeclient.LabelSelector(map[string]string{"b": "2", "a": "1"})
+	c.Assert(selector, gc.Equals, "a=1,b=2")
+}
+
+func (s *clientSuite) TestNamespaces(c *gc.C) {
+	err := s.client.CreateNamespace("other", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	namespaces, err := s.client.Namespaces(map[string]string{"app": "juju"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(namespaces, gc.HasLen, 1)
+	c.Assert(namespaces[0].Metadata.Name, gc.Equals, "ns")
+
+	err = s.client.CreateNamespace("ns", nil)
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+	err = s.client.DeleteNamespace("ns")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.client.DeleteNamespace("ns")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *clientSuite) TestStatefulSet(c *gc.C) {
+	labels := map[string]string{"set": "web"}
+	set, err := s.client.CreateStatefulSet("ns", &kubeclient.StatefulSet{
+		Metadata: kubeclient.ObjectMeta{Name: "web", Labels: labels},
+		Spec: kubeclient.StatefulSetSpec{
+			Replicas: 2,
+			Selector: &kubeclient.LabelSelector{MatchLabels: labels},
+			Template: kubeclient.PodTemplateSpec{
+				Metadata: kubeclient.ObjectMeta{Labels: labels},
+				Spec: kubeclient.PodSpec{
+					Containers: []kubeclient.Container{{Name: "web", Image: "nginx"}},
+				},
+			},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(set.Metadata.ResourceVersion, gc.Not(gc.Equals), "")
+
+	pods, err := s.client.Pods("ns", labels)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pods, gc.HasLen, 2)
+
+	set.Spec.Replicas = 1
+	_, err = s.client.UpdateStatefulSet("ns", set)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.client.Pod("ns", "web-1")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	// Updating a stale copy fails.
+	_, err = s.client.UpdateStatefulSet("ns", set)
+	c.Assert(err, gc.ErrorMatches, `.*statefulset "web" has been modified \(409\)`)
+	c.Assert(err, gc.Not(jc.Satisfies), errors.IsNotFound)
+
+	err = s.client.DeleteStatefulSet("ns", "web")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.client.StatefulSet("ns", "web")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *clientSuite) TestSecret(c *gc.C) {
+	_, err := s.client.CreateSecret("ns", &kubeclient.Secret{
+		Metadata: kubeclient.ObjectMeta{Name: "s"},
+		Data:     map[string][]byte{"key": []byte("value")},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	secret, err := s.client.Secret("ns", "s")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(secret.Data["key"]), gc.Equals, "value")
+}
@@ -0,0 +1,266 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package kubeclient
+
+// The types in this file are a minimal subset of the Kubernetes API
+// objects, containing only the fields that Juju makes use of. Unknown
+// fields returned by the API server are ignored.
+
+// ObjectMeta is metadata that all persisted resources must have.
+type ObjectMeta struct {
+	Name            string            `json:"name,omitempty"`
+	Namespace       string            `json:"namespace,omitempty"`
+	Labels          map[string]string `json:"labels,omitempty"`
+	Annotations     map[string]string `json:"annotations,omitempty"`
+	ResourceVersion string            `json:"resourceVersion,omitempty"`
+}
+
+// Namespace provides a scope for names.
+type Namespace struct {
+	Kind       string     `json:"kind,omitempty"`
+	APIVersion string     `json:"apiVersion,omitempty"`
+	Metadata   ObjectMeta `json:"metadata"`
+}
+
+// NamespaceList is a list of namespaces.
+type NamespaceList struct {
+	Items []Namespace `json:"items"`
+}
+
+// ResourceList maps resource names, such as "cpu" and "memory",
+// to quantities, such as "500m" and "1024Mi".
+type ResourceList map[string]string
+
+// ResourceRequirements describes the compute resource requirements
+// of a container.
+type ResourceRequirements struct {
+	Requests ResourceList `json:"requests,omitempty"`
+	Limits   ResourceList `json:"limits,omitempty"`
+}
+
+// ContainerPort represents a network port in a single container.
+type ContainerPort struct {
+	ContainerPort int    `json:"containerPort"`
+	Protocol      string `json:"protocol,omitempty"`
+}
+
+// VolumeMount describes a mounting of a volume within a container.
+type VolumeMount struct {
+	Name      string `json:"name"`
+	MountPath string `json:"mountPath"`
+	ReadOnly  bool   `json:"readOnly,omitempty"`
+}
+
+// SecurityContext holds security configuration that will be
+// applied to a container.
+type SecurityContext struct {
+	Privileged *bool `json:"privileged,omitempty"`
+}
+
+// Container is a single application container to run within a pod.
+type Container struct {
+	Name            string               `json:"name"`
+	Image           string               `json:"image"`
+	Command         []string             `json:"command,omitempty"`
+	Ports           []ContainerPort      `json:"ports,omitempty"`
+	Resources       ResourceRequirements `json:"resources,omitempty"`
+	VolumeMounts    []VolumeMount        `json:"volumeMounts,omitempty"`
+	SecurityContext *SecurityContext     `json:"securityContext,omitempty"`
+}
+
+// SecretVolumeSource adapts a Secret into a volume.
+type SecretVolumeSource struct {
+	SecretName string `json:"secretName"`
+}
+
+// Volume represents a named volume in a pod that may be accessed
+// by any container in the pod.
+type Volume struct {
+	Name   string              `json:"name"`
+	Secret *SecretVolumeSource `json:"secret,omitempty"`
+}
+
+// PodSpec is a description of a pod.
+type PodSpec struct {
+	Containers   []Container       `json:"containers"`
+	Volumes      []Volume          `json:"volumes,omitempty"`
+	Hostname     string            `json:"hostname,omitempty"`
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+}
+
+// Pod phases.
+const (
+	PodPending   = "Pending"
+	PodRunning   = "Running"
+	PodSucceeded = "Succeeded"
+	PodFailed    = "Failed"
+	PodUnknown   = "Unknown"
+)
+
+// PodStatus represents information about the status of a pod.
+type PodStatus struct {
+	Phase   string `json:"phase,omitempty"`
+	Message string `json:"message,omitempty"`
+	HostIP  string `json:"hostIP,omitempty"`
+	PodIP   string `json:"podIP,omitempty"`
+}
+
+// Pod is a collection of containers that run on a host.
+type Pod struct {
+	Kind       string     `json:"kind,omitempty"`
+	APIVersion string     `json:"apiVersion,omitempty"`
+	Metadata   ObjectMeta `json:"metadata"`
+	Spec       PodSpec    `json:"spec"`
+	Status     PodStatus  `json:"status,omitempty"`
+}
+
+// PodList is a list of pods.
+type PodList struct {
+	Items []Pod `json:"items"`
+}
+
+// PodTemplateSpec describes the pods that will be created from
+// a template.
+type PodTemplateSpec struct {
+	Metadata ObjectMeta `json:"metadata"`
+	Spec     PodSpec    `json:"spec"`
+}
+
+// LabelSelector is a label query over a set of resources.
+type LabelSelector struct {
+	MatchLabels map[string]string `json:"matchLabels,omitempty"`
+}
+
+// StatefulSetSpec is the specification of a stateful set.
+type StatefulSetSpec struct {
+	Replicas             int                     `json:"replicas"`
+	Selector             *LabelSelector          `json:"selector,omitempty"`
+	Template             PodTemplateSpec         `json:"template"`
+	VolumeClaimTemplates []PersistentVolumeClaim `json:"volumeClaimTemplates,omitempty"`
+	ServiceName          string                  `json:"serviceName"`
+}
+
+// StatefulSetStatus represents the current state of a stateful set.
+type StatefulSetStatus struct {
+	Replicas int `json:"replicas"`
+}
+
+// StatefulSet represents a set of pods with consistent identities.
+// Each pod is named "<set name>-<ordinal>", and retains its identity
+// and persistent volume claims across rescheduling.
+type StatefulSet struct {
+	Kind       string            `json:"kind,omitempty"`
+	APIVersion string            `json:"apiVersion,omitempty"`
+	Metadata   ObjectMeta        `json:"metadata"`
+	Spec       StatefulSetSpec   `json:"spec"`
+	Status     StatefulSetStatus `json:"status,omitempty"`
+}
+
+// StatefulSetList is a list of stateful sets.
+type StatefulSetList struct {
+	Items []StatefulSet `json:"items"`
+}
+
+// Service types.
+const (
+	ServiceTypeClusterIP    = "ClusterIP"
+	ServiceTypeNodePort     = "NodePort"
+	ServiceTypeLoadBalancer = "LoadBalancer"
+)
+
+// ServicePort contains information on a service's port.
+type ServicePort struct {
+	Name     string `json:"name,omitempty"`
+	Protocol string `json:"protocol,omitempty"`
+	Port     int    `json:"port"`
+}
+
+// ServiceSpec describes the attributes that a user creates on a service.
+type ServiceSpec struct {
+	Type                     string            `json:"type,omitempty"`
+	Selector                 map[string]string `json:"selector,omitempty"`
+	Ports                    []ServicePort     `json:"ports,omitempty"`
+	LoadBalancerSourceRanges []string          `json:"loadBalancerSourceRanges,omitempty"`
+}
+
+// LoadBalancerIngress represents the status of a load-balancer
+// ingress point.
+type LoadBalancerIngress struct {
+	IP       string `json:"ip,omitempty"`
+	Hostname string `json:"hostname,omitempty"`
+}
+
+// LoadBalancerStatus represents the status of a load-balancer.
+type LoadBalancerStatus struct {
+	Ingress []LoadBalancerIngress `json:"ingress,omitempty"`
+}
+
+// ServiceStatus represents the current status of a service.
+type ServiceStatus struct {
+	LoadBalancer LoadBalancerStatus `json:"loadBalancer,omitempty"`
+}
+
+// Service is a named abstraction of a software service, consisting
+// of the ports that the proxy listens on and the selector that
+// determines which pods will answer requests sent through the proxy.
+type Service struct {
+	Kind       string        `json:"kind,omitempty"`
+	APIVersion string        `json:"apiVersion,omitempty"`
+	Metadata   ObjectMeta    `json:"metadata"`
+	Spec       ServiceSpec   `json:"spec"`
+	Status     ServiceStatus `json:"status,omitempty"`
+}
+
+// ServiceList is a list of services.
+type ServiceList struct {
+	Items []Service `json:"items"`
+}
+
+// PersistentVolumeClaimSpec describes the common attributes of
+// storage devices.
+type PersistentVolumeClaimSpec struct {
+	AccessModes      []string             `json:"accessModes,omitempty"`
+	Resources        ResourceRequirements `json:"resources,omitempty"`
+	StorageClassName string               `json:"storageClassName,omitempty"`
+	VolumeName       string               `json:"volumeName,omitempty"`
+}
+
+// PersistentVolumeClaimStatus is the current status of a persistent
+// volume claim.
+type PersistentVolumeClaimStatus struct {
+	Phase    string       `json:"phase,omitempty"`
+	Capacity ResourceList `json:"capacity,omitempty"`
+}
+
+// PersistentVolumeClaim is a user's request for and claim to a
+// persistent volume.
+type PersistentVolumeClaim struct {
+	Kind       string                      `json:"kind,omitempty"`
+	APIVersion string                      `json:"apiVersion,omitempty"`
+	Metadata   ObjectMeta                  `json:"metadata"`
+	Spec       PersistentVolumeClaimSpec   `json:"spec"`
+	Status     PersistentVolumeClaimStatus `json:"status,omitempty"`
+}
+
+// PersistentVolumeClaimList is a list of persistent volume claims.
+type PersistentVolumeClaimList struct {
+	Items []PersistentVolumeClaim `json:"items"`
+}
+
+// Secret holds secret data of a certain type.
+type Secret struct {
+	Kind       string            `json:"kind,omitempty"`
+	APIVersion string            `json:"apiVersion,omitempty"`
+	Metadata   ObjectMeta        `json:"metadata"`
+	Data       map[string][]byte `json:"data,omitempty"`
+}
+
+// Status is the return value for calls that don't return other objects,
+// and for failed calls.
+type Status struct {
+	Status  string `json:"status,omitempty"`
+	Message string `json:"message,omitempty"`
+	Reason  string `json:"reason,omitempty"`
+	Code    int    `json:"code,omitempty"`
+}
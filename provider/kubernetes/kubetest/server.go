@@ -0,0 +1,576 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package kubetest provides a fake Kubernetes API server, for testing
+// the kubernetes provider and its client.
+//
+// The server keeps all resources in memory, and understands just
+// enough of the API to be useful: stateful sets are reconciled into
+// pods and persistent volume claims as soon as they are written, all
+// pods are immediately running, and load-balanced services are
+// immediately assigned an ingress address.
+package kubetest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/juju/juju/provider/kubernetes/kubeclient"
+)
+
+// Server is a fake Kubernetes API server.
+type Server struct {
+	*httptest.Server
+
+	mu              sync.Mutex
+	resourceVersion int
+	nextPodIP       int
+	nextIngressIP   int
+	namespaces      map[string]*kubeclient.Namespace
+	pods            map[string]*kubeclient.Pod
+	statefulSets    map[string]*kubeclient.StatefulSet
+	services        map[string]*kubeclient.Service
+	claims          map[string]*kubeclient.PersistentVolumeClaim
+	secrets         map[string]*kubeclient.Secret
+}
+
+// NewServer starts and returns a new fake Kubernetes API server.
+// The caller should call Close when finished, to shut it down.
+func NewServer() *Server {
+	s := &Server{
+		namespaces:   make(map[string]*kubeclient.Namespace),
+		pods:         make(map[string]*kubeclient.Pod),
+		statefulSets: make(map[string]*kubeclient.StatefulSet),
+		services:     make(map[string]*kubeclient.Service),
+		claims:       make(map[string]*kubeclient.PersistentVolumeClaim),
+		secrets:      make(map[string]*kubeclient.Secret),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// SetPodPhase sets the phase of the named pod, for testing instance
+// status reporting.
+func (s *Server) SetPodPhase(namespace, name, phase string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if pod, ok := s.pods[key(namespace, name)]; ok {
+		pod.Status.Phase = phase
+	}
+}
+
+// statusError is an error that is reported to the client as a
+// Kubernetes Status object.
+type statusError struct {
+	code   int
+	reason string
+	format string
+	args   []interface{}
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf(e.format, e.args...)
+}
+
+func notFound(resource, name string) error {
+	return &statusError{http.StatusNotFound, "NotFound", "%s %q not found", []interface{}{resource, name}}
+}
+
+func alreadyExists(resource, name string) error {
+	return &statusError{http.StatusConflict, "AlreadyExists", "%s %q already exists", []interface{}{resource, name}}
+}
+
+func conflict(resource, name string) error {
+	return &statusError{http.StatusConflict, "Conflict", "%s %q has been modified", []interface{}{resource, name}}
+}
+
+func badRequest(format string, args ...interface{}) error {
+	return &statusError{http.StatusBadRequest, "BadRequest", format, args}
+}
+
+func key(namespace, name string) string {
+	return namespace + "/" + name
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, req *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	body, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		writeError(w, badRequest("reading body: %v", err))
+		return
+	}
+	out, err := s.handle(req, body)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if req.Method == "POST" {
+		w.WriteHeader(http.StatusCreated)
+	}
+	if out == nil {
+		out = &kubeclient.Status{Status: "Success"}
+	}
+	json.NewEncoder(w).Encode(out)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	status := kubeclient.Status{
+		Status:  "Failure",
+		Message: err.Error(),
+		Code:    http.StatusInternalServerError,
+	}
+	if err, ok := err.(*statusError); ok {
+		status.Reason = err.reason
+		status.Code = err.code
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status.Code)
+	json.NewEncoder(w).Encode(status)
+}
+
+func (s *Server) handle(req *http.Request, body []byte) (interface{}, error) {
+	var parts []string
+	switch p := req.URL.Path; {
+	case strings.HasPrefix(p, "/api/v1/"):
+		parts = strings.Split(strings.TrimPrefix(p, "/api/v1/"), "/")
+	case strings.HasPrefix(p, "/apis/apps/v1beta1/"):
+		parts = strings.Split(strings.TrimPrefix(p, "/apis/apps/v1beta1/"), "/")
+		if len(parts) < 3 || parts[2] != "statefulsets" {
+			return nil, notFound("path", p)
+		}
+	default:
+		return nil, notFound("path", p)
+	}
+	if parts[0] != "namespaces" {
+		return nil, notFound("path", req.URL.Path)
+	}
+	if len(parts) <= 2 {
+		labels := parseSelector(req.URL.Query().Get("labelSelector"))
+		return s.handleNamespace(req.Method, parts[1:], labels, body)
+	}
+	namespace, resource, name := parts[1], parts[2], ""
+	if len(parts) > 3 {
+		name = parts[3]
+	}
+	if _, ok := s.namespaces[namespace]; !ok {
+		return nil, notFound("namespace", namespace)
+	}
+	labels := parseSelector(req.URL.Query().Get("labelSelector"))
+	switch resource {
+	case "pods":
+		return s.handlePods(req.Method, namespace, name, labels)
+	case "statefulsets":
+		return s.handleStatefulSets(req.Method, namespace, name, labels, body)
+	case "services":
+		return s.handleServices(req.Method, namespace, name, labels, body)
+	case "persistentvolumeclaims":
+		return s.handleClaims(req.Method, namespace, name, labels)
+	case "secrets":
+		return s.handleSecrets(req.Method, namespace, name, body)
+	}
+	return nil, notFound("resource", resource)
+}
+
+func (s *Server) handleNamespace(
+	method string, parts []string, labels map[string]string, body []byte,
+) (interface{}, error) {
+	switch {
+	case method == "GET" && len(parts) == 0:
+		out := kubeclient.NamespaceList{Items: []kubeclient.Namespace{}}
+		for _, k := range sortedKeys(s.namespaces) {
+			ns := s.namespaces[k]
+			if matchLabels(ns.Metadata.Labels, labels) {
+				out.Items = append(out.Items, *ns)
+			}
+		}
+		return &out, nil
+	case method == "POST" && len(parts) == 0:
+		var ns kubeclient.Namespace
+		if err := json.Unmarshal(body, &ns); err != nil {
+			return nil, badRequest("%v", err)
+		}
+		if _, ok := s.namespaces[ns.Metadata.Name]; ok {
+			return nil, alreadyExists("namespace", ns.Metadata.Name)
+		}
+		s.setResourceVersion(&ns.Metadata)
+		s.namespaces[ns.Metadata.Name] = &ns
+		return &ns, nil
+	case method == "GET" && len(parts) == 1:
+		ns, ok := s.namespaces[parts[0]]
+		if !ok {
+			return nil, notFound("namespace", parts[0])
+		}
+		return ns, nil
+	case method == "DELETE" && len(parts) == 1:
+		name := parts[0]
+		if _, ok := s.namespaces[name]; !ok {
+			return nil, notFound("namespace", name)
+		}
+		delete(s.namespaces, name)
+		prefix := key(name, "")
+		for k := range s.pods {
+			if strings.HasPrefix(k, prefix) {
+				delete(s.pods, k)
+			}
+		}
+		for k := range s.statefulSets {
+			if strings.HasPrefix(k, prefix) {
+				delete(s.statefulSets, k)
+			}
+		}
+		for k := range s.services {
+			if strings.HasPrefix(k, prefix) {
+				delete(s.services, k)
+			}
+		}
+		for k := range s.claims {
+			if strings.HasPrefix(k, prefix) {
+				delete(s.claims, k)
+			}
+		}
+		for k := range s.secrets {
+			if strings.HasPrefix(k, prefix) {
+				delete(s.secrets, k)
+			}
+		}
+		return nil, nil
+	}
+	return nil, badRequest("unsupported namespace request")
+}
+
+func (s *Server) handlePods(method, namespace, name string, labels map[string]string) (interface{}, error) {
+	switch {
+	case method == "GET" && name == "":
+		out := kubeclient.PodList{Items: []kubeclient.Pod{}}
+		for _, k := range sortedKeys(s.pods) {
+			pod := s.pods[k]
+			if pod.Metadata.Namespace == namespace && matchLabels(pod.Metadata.Labels, labels) {
+				out.Items = append(out.Items, *pod)
+			}
+		}
+		return &out, nil
+	case method == "GET":
+		pod, ok := s.pods[key(namespace, name)]
+		if !ok {
+			return nil, notFound("pod", name)
+		}
+		return pod, nil
+	}
+	return nil, badRequest("unsupported pod request")
+}
+
+func (s *Server) handleStatefulSets(
+	method, namespace, name string, labels map[string]string, body []byte,
+) (interface{}, error) {
+	switch method {
+	case "GET":
+		if name != "" {
+			set, ok := s.statefulSets[key(namespace, name)]
+			if !ok {
+				return nil, notFound("statefulset", name)
+			}
+			return set, nil
+		}
+		out := kubeclient.StatefulSetList{Items: []kubeclient.StatefulSet{}}
+		for _, k := range sortedKeys(s.statefulSets) {
+			set := s.statefulSets[k]
+			if set.Metadata.Namespace == namespace && matchLabels(set.Metadata.Labels, labels) {
+				out.Items = append(out.Items, *set)
+			}
+		}
+		return &out, nil
+	case "POST", "PUT":
+		var set kubeclient.StatefulSet
+		if err := json.Unmarshal(body, &set); err != nil {
+			return nil, badRequest("%v", err)
+		}
+		k := key(namespace, set.Metadata.Name)
+		existing, ok := s.statefulSets[k]
+		if method == "POST" && ok {
+			return nil, alreadyExists("statefulset", set.Metadata.Name)
+		}
+		if method == "PUT" {
+			if !ok {
+				return nil, notFound("statefulset", set.Metadata.Name)
+			}
+			if set.Metadata.ResourceVersion != existing.Metadata.ResourceVersion {
+				return nil, conflict("statefulset", set.Metadata.Name)
+			}
+		}
+		set.Metadata.Namespace = namespace
+		s.setResourceVersion(&set.Metadata)
+		s.statefulSets[k] = &set
+		s.reconcileStatefulSet(&set)
+		return &set, nil
+	case "DELETE":
+		k := key(namespace, name)
+		set, ok := s.statefulSets[k]
+		if !ok {
+			return nil, notFound("statefulset", name)
+		}
+		delete(s.statefulSets, k)
+		set.Spec.Replicas = 0
+		s.reconcileStatefulSet(set)
+		return nil, nil
+	}
+	return nil, badRequest("unsupported statefulset request")
+}
+
+// reconcileStatefulSet creates and deletes pods and persistent
+// volume claims so that they match the stateful set's spec. As with
+// Kubernetes, claims are not deleted when the pods are.
+func (s *Server) reconcileStatefulSet(set *kubeclient.StatefulSet) {
+	namespace := set.Metadata.Namespace
+	for k, pod := range s.pods {
+		if pod.Metadata.Namespace != namespace {
+			continue
+		}
+		ordinal, ok := podOrdinal(set.Metadata.Name, pod.Metadata.Name)
+		if ok && ordinal >= set.Spec.Replicas {
+			delete(s.pods, k)
+		}
+	}
+	for i := 0; i < set.Spec.Replicas; i++ {
+		podName := fmt.Sprintf("%s-%d", set.Metadata.Name, i)
+		if _, ok := s.pods[key(namespace, podName)]; ok {
+			continue
+		}
+		for _, template := range set.Spec.VolumeClaimTemplates {
+			claim := template
+			claim.Metadata.Name = template.Metadata.Name + "-" + podName
+			claim.Metadata.Namespace = namespace
+			claim.Status = kubeclient.PersistentVolumeClaimStatus{
+				Phase:    "Bound",
+				Capacity: claim.Spec.Resources.Requests,
+			}
+			if _, ok := s.claims[key(namespace, claim.Metadata.Name)]; !ok {
+				s.setResourceVersion(&claim.Metadata)
+				s.claims[key(namespace, claim.Metadata.Name)] = &claim
+			}
+		}
+		s.nextPodIP++
+		pod := &kubeclient.Pod{
+			Kind:       "Pod",
+			APIVersion: "v1",
+			Metadata:   set.Spec.Template.Metadata,
+			Spec:       set.Spec.Template.Spec,
+			Status: kubeclient.PodStatus{
+				Phase:  kubeclient.PodRunning,
+				HostIP: "10.0.0.1",
+				PodIP:  fmt.Sprintf("10.1.%d.%d", s.nextPodIP/256, s.nextPodIP%256),
+			},
+		}
+		pod.Metadata.Name = podName
+		pod.Metadata.Namespace = namespace
+		pod.Spec.Hostname = podName
+		s.setResourceVersion(&pod.Metadata)
+		s.pods[key(namespace, podName)] = pod
+	}
+	set.Status.Replicas = set.Spec.Replicas
+}
+
+func podOrdinal(setName, podName string) (int, bool) {
+	if !strings.HasPrefix(podName, setName+"-") {
+		return -1, false
+	}
+	ordinal, err := strconv.Atoi(podName[len(setName)+1:])
+	if err != nil {
+		return -1, false
+	}
+	return ordinal, true
+}
+
+func (s *Server) handleServices(
+	method, namespace, name string, labels map[string]string, body []byte,
+) (interface{}, error) {
+	switch method {
+	case "GET":
+		if name != "" {
+			svc, ok := s.services[key(namespace, name)]
+			if !ok {
+				return nil, notFound("service", name)
+			}
+			return svc, nil
+		}
+		out := kubeclient.ServiceList{Items: []kubeclient.Service{}}
+		for _, k := range sortedKeys(s.services) {
+			svc := s.services[k]
+			if svc.Metadata.Namespace == namespace && matchLabels(svc.Metadata.Labels, labels) {
+				out.Items = append(out.Items, *svc)
+			}
+		}
+		return &out, nil
+	case "POST", "PUT":
+		var svc kubeclient.Service
+		if err := json.Unmarshal(body, &svc); err != nil {
+			return nil, badRequest("%v", err)
+		}
+		k := key(namespace, svc.Metadata.Name)
+		existing, ok := s.services[k]
+		if method == "POST" && ok {
+			return nil, alreadyExists("service", svc.Metadata.Name)
+		}
+		if method == "PUT" {
+			if !ok {
+				return nil, notFound("service", svc.Metadata.Name)
+			}
+			if svc.Metadata.ResourceVersion != existing.Metadata.ResourceVersion {
+				return nil, conflict("service", svc.Metadata.Name)
+			}
+			svc.Status = existing.Status
+		}
+		if svc.Spec.Type == kubeclient.ServiceTypeLoadBalancer && len(svc.Status.LoadBalancer.Ingress) == 0 {
+			s.nextIngressIP++
+			svc.Status.LoadBalancer.Ingress = []kubeclient.LoadBalancerIngress{{
+				IP: fmt.Sprintf("203.0.113.%d", s.nextIngressIP),
+			}}
+		}
+		svc.Metadata.Namespace = namespace
+		s.setResourceVersion(&svc.Metadata)
+		s.services[k] = &svc
+		return &svc, nil
+	case "DELETE":
+		k := key(namespace, name)
+		if _, ok := s.services[k]; !ok {
+			return nil, notFound("service", name)
+		}
+		delete(s.services, k)
+		return nil, nil
+	}
+	return nil, badRequest("unsupported service request")
+}
+
+func (s *Server) handleClaims(method, namespace, name string, labels map[string]string) (interface{}, error) {
+	switch method {
+	case "GET":
+		if name != "" {
+			claim, ok := s.claims[key(namespace, name)]
+			if !ok {
+				return nil, notFound("persistentvolumeclaim", name)
+			}
+			return claim, nil
+		}
+		out := kubeclient.PersistentVolumeClaimList{Items: []kubeclient.PersistentVolumeClaim{}}
+		for _, k := range sortedKeys(s.claims) {
+			claim := s.claims[k]
+			if claim.Metadata.Namespace == namespace && matchLabels(claim.Metadata.Labels, labels) {
+				out.Items = append(out.Items, *claim)
+			}
+		}
+		return &out, nil
+	case "DELETE":
+		k := key(namespace, name)
+		if _, ok := s.claims[k]; !ok {
+			return nil, notFound("persistentvolumeclaim", name)
+		}
+		delete(s.claims, k)
+		return nil, nil
+	}
+	return nil, badRequest("unsupported persistentvolumeclaim request")
+}
+
+func (s *Server) handleSecrets(method, namespace, name string, body []byte) (interface{}, error) {
+	switch method {
+	case "GET":
+		secret, ok := s.secrets[key(namespace, name)]
+		if !ok {
+			return nil, notFound("secret", name)
+		}
+		return secret, nil
+	case "POST", "PUT":
+		var secret kubeclient.Secret
+		if err := json.Unmarshal(body, &secret); err != nil {
+			return nil, badRequest("%v", err)
+		}
+		k := key(namespace, secret.Metadata.Name)
+		existing, ok := s.secrets[k]
+		if method == "POST" && ok {
+			return nil, alreadyExists("secret", secret.Metadata.Name)
+		}
+		if method == "PUT" {
+			if !ok {
+				return nil, notFound("secret", secret.Metadata.Name)
+			}
+			if secret.Metadata.ResourceVersion != existing.Metadata.ResourceVersion {
+				return nil, conflict("secret", secret.Metadata.Name)
+			}
+		}
+		secret.Metadata.Namespace = namespace
+		s.setResourceVersion(&secret.Metadata)
+		s.secrets[k] = &secret
+		return &secret, nil
+	case "DELETE":
+		k := key(namespace, name)
+		if _, ok := s.secrets[k]; !ok {
+			return nil, notFound("secret", name)
+		}
+		delete(s.secrets, k)
+		return nil, nil
+	}
+	return nil, badRequest("unsupported secret request")
+}
+
+func (s *Server) setResourceVersion(meta *kubeclient.ObjectMeta) {
+	s.resourceVersion++
+	meta.ResourceVersion = strconv.Itoa(s.resourceVersion)
+}
+
+func parseSelector(selector string) map[string]string {
+	if selector == "" {
+		return nil
+	}
+	labels := make(map[string]string)
+	for _, term := range strings.Split(selector, ",") {
+		kv := strings.SplitN(term, "=", 2)
+		if len(kv) == 2 {
+			labels[kv[0]] = kv[1]
+		}
+	}
+	return labels
+}
+
+func matchLabels(labels, selector map[string]string) bool {
+	for k, v := range selector {
+		if labels[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+func sortedKeys(m interface{}) []string {
+	var keys []string
+	switch m := m.(type) {
+	case map[string]*kubeclient.Namespace:
+		for k := range m {
+			keys = append(keys, k)
+		}
+	case map[string]*kubeclient.Pod:
+		for k := range m {
+			keys = append(keys, k)
+		}
+	case map[string]*kubeclient.StatefulSet:
+		for k := range m {
+			keys = append(keys, k)
+		}
+	case map[string]*kubeclient.Service:
+		for k := range m {
+			keys = append(keys, k)
+		}
+	case map[string]*kubeclient.PersistentVolumeClaim:
+		for k := range m {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
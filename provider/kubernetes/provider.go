@@ -0,0 +1,103 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package kubernetes
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/schema"
+	"gopkg.in/juju/environschema.v1"
+
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/provider/kubernetes/kubeclient"
+)
+
+type environProvider struct {
+	environProviderCredentials
+}
+
+var providerInstance environProvider
+
+var _ environs.EnvironProvider = providerInstance
+
+// Open implements environs.EnvironProvider.
+func (environProvider) Open(args environs.OpenParams) (environs.Environ, error) {
+	if err := validateCloudSpec(args.Cloud); err != nil {
+		return nil, errors.Annotate(err, "validating cloud spec")
+	}
+	env, err := newEnviron(args.Cloud, args.Config)
+	return env, errors.Trace(err)
+}
+
+// PrepareConfig implements environs.EnvironProvider.
+func (p environProvider) PrepareConfig(args environs.PrepareConfigParams) (*config.Config, error) {
+	if err := validateCloudSpec(args.Cloud); err != nil {
+		return nil, errors.Annotate(err, "validating cloud spec")
+	}
+	return args.Config, nil
+}
+
+// Validate implements environs.EnvironProvider.
+func (environProvider) Validate(cfg, old *config.Config) (*config.Config, error) {
+	ecfg, err := newValidConfig(cfg, old)
+	if err != nil {
+		return nil, errors.Annotate(err, "invalid config")
+	}
+	return cfg.Apply(ecfg.attrs)
+}
+
+// Schema returns the configuration schema for an environment.
+func (environProvider) Schema() environschema.Fields {
+	fields, err := config.Schema(configSchema)
+	if err != nil {
+		panic(err)
+	}
+	return fields
+}
+
+// ConfigSchema returns extra config attributes specific
+// to this provider only.
+func (environProvider) ConfigSchema() schema.Fields {
+	return configFields
+}
+
+// ConfigDefaults returns the default values for the
+// provider specific config attributes.
+func (environProvider) ConfigDefaults() schema.Defaults {
+	return configDefaults
+}
+
+func validateCloudSpec(spec environs.CloudSpec) error {
+	if err := spec.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	if spec.Endpoint == "" {
+		return errors.NotValidf("missing endpoint")
+	}
+	if spec.Credential == nil {
+		return errors.NotValidf("missing credential")
+	}
+	switch authType := spec.Credential.AuthType(); authType {
+	case cloud.UserPassAuthType, cloud.OAuth2AuthType:
+	default:
+		return errors.NotSupportedf("%q auth-type", authType)
+	}
+	return nil
+}
+
+// newClient returns a Kubernetes API client for the given cloud spec.
+func newClient(spec environs.CloudSpec) (kubeClient, error) {
+	credAttrs := spec.Credential.Attributes()
+	client, err := kubeclient.New(kubeclient.Config{
+		Endpoint: spec.Endpoint,
+		Username: credAttrs[credAttrUsername],
+		Password: credAttrs[credAttrPassword],
+		Token:    credAttrs[credAttrToken],
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return client, nil
+}
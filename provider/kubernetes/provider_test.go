@@ -0,0 +1,103 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package kubernetes_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/testing"
+)
+
+type providerSuite struct {
+	baseSuite
+	provider environs.EnvironProvider
+}
+
+var _ = gc.Suite(&providerSuite{})
+
+func (s *providerSuite) SetUpTest(c *gc.C) {
+	s.baseSuite.SetUpTest(c)
+	var err error
+	s.provider, err = environs.Provider("kubernetes")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *providerSuite) TestOpen(c *gc.C) {
+	env, err := s.provider.Open(environs.OpenParams{
+		Cloud:  s.spec,
+		Config: s.config,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(env.Config().Name(), gc.Equals, "testenv")
+}
+
+func (s *providerSuite) TestOpenMissingEndpoint(c *gc.C) {
+	s.spec.Endpoint = ""
+	s.testOpenError(c, `validating cloud spec: missing endpoint not valid`)
+}
+
+func (s *providerSuite) TestOpenMissingCredential(c *gc.C) {
+	s.spec.Credential = nil
+	s.testOpenError(c, `validating cloud spec: missing credential not valid`)
+}
+
+func (s *providerSuite) TestOpenUnsupportedCredential(c *gc.C) {
+	credential := cloud.NewCredential(cloud.JSONFileAuthType, map[string]string{})
+	s.spec.Credential = &credential
+	s.testOpenError(c, `validating cloud spec: "jsonfile" auth-type not supported`)
+}
+
+func (s *providerSuite) testOpenError(c *gc.C, expect string) {
+	_, err := s.provider.Open(environs.OpenParams{
+		Cloud:  s.spec,
+		Config: s.config,
+	})
+	c.Assert(err, gc.ErrorMatches, expect)
+}
+
+func (s *providerSuite) TestValidateDefaults(c *gc.C) {
+	cfg, err := s.provider.Validate(s.config, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AllAttrs()["service-type"], gc.Equals, "LoadBalancer")
+}
+
+func (s *providerSuite) TestValidateServiceType(c *gc.C) {
+	cfg, err := s.config.Apply(testing.Attrs{"service-type": "ExternalName"})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.provider.Validate(cfg, nil)
+	c.Assert(err, gc.ErrorMatches, `invalid config: service-type: .*`)
+}
+
+func (s *providerSuite) TestValidateImmutableNamespace(c *gc.C) {
+	cfg, err := s.config.Apply(testing.Attrs{"namespace": "elsewhere"})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.provider.Validate(cfg, s.config)
+	c.Assert(err, gc.ErrorMatches, `invalid config: namespace: cannot change from juju-test to elsewhere`)
+}
+
+func (s *providerSuite) TestDefaultNamespace(c *gc.C) {
+	attrs := testing.FakeConfig().Merge(testing.Attrs{"type": "kubernetes"})
+	cfg, err := config.New(config.UseDefaults, attrs)
+	c.Assert(err, jc.ErrorIsNil)
+	env, err := s.provider.Open(environs.OpenParams{
+		Cloud:  s.spec,
+		Config: cfg,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = env.Create(environs.CreateParams{
+		ControllerUUID: testing.FakeControllerConfig().ControllerUUID(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	namespaces, err := s.client.Namespaces(map[string]string{
+		"juju-model-uuid": cfg.UUID(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(namespaces, gc.HasLen, 1)
+	c.Assert(namespaces[0].Metadata.Name, gc.Equals, "juju-"+cfg.UUID()[len(cfg.UUID())-6:])
+}
@@ -0,0 +1,208 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package kubernetes
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/schema"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/storage"
+)
+
+const (
+	storageProviderType = storage.ProviderType("kubernetes")
+
+	// storageClassAttr is the storage pool attribute naming the
+	// Kubernetes storage class from which volumes are claimed.
+	storageClassAttr = "storage-class"
+)
+
+// StorageProviderTypes implements storage.ProviderRegistry.
+func (env *environ) StorageProviderTypes() []storage.ProviderType {
+	return []storage.ProviderType{storageProviderType}
+}
+
+// StorageProvider implements storage.ProviderRegistry.
+func (env *environ) StorageProvider(t storage.ProviderType) (storage.Provider, error) {
+	if t == storageProviderType {
+		return &storageProvider{env}, nil
+	}
+	return nil, errors.NotFoundf("storage provider %q", t)
+}
+
+// storageProvider provides persistent volume claims. Claims are created
+// by Kubernetes from a stateful set's volume claim templates when its
+// pods are created, so volumes may only be created along with machines.
+// Claims are deleted when their machines are stopped, so volumes do not
+// outlive their machines.
+type storageProvider struct {
+	env *environ
+}
+
+var _ storage.Provider = (*storageProvider)(nil)
+
+var storageConfigChecker = schema.FieldMap(
+	schema.Fields{
+		storageClassAttr: schema.String(),
+	},
+	schema.Defaults{
+		storageClassAttr: schema.Omit,
+	},
+)
+
+// ValidateConfig implements storage.Provider.
+func (p *storageProvider) ValidateConfig(cfg *storage.Config) error {
+	_, err := storageConfigChecker.Coerce(cfg.Attrs(), nil)
+	return errors.Annotate(err, "validating kubernetes storage config")
+}
+
+// Supports implements storage.Provider.
+func (p *storageProvider) Supports(k storage.StorageKind) bool {
+	return k == storage.StorageKindBlock
+}
+
+// Scope implements storage.Provider.
+func (p *storageProvider) Scope() storage.Scope {
+	return storage.ScopeEnviron
+}
+
+// Dynamic implements storage.Provider.
+func (p *storageProvider) Dynamic() bool {
+	return false
+}
+
+// DefaultPools implements storage.Provider.
+func (p *storageProvider) DefaultPools() []*storage.Config {
+	return nil
+}
+
+// FilesystemSource implements storage.Provider.
+func (p *storageProvider) FilesystemSource(providerConfig *storage.Config) (storage.FilesystemSource, error) {
+	return nil, errors.NotSupportedf("filesystems")
+}
+
+// VolumeSource implements storage.Provider.
+func (p *storageProvider) VolumeSource(providerConfig *storage.Config) (storage.VolumeSource, error) {
+	return &volumeSource{p.env}, nil
+}
+
+type volumeSource struct {
+	env *environ
+}
+
+var _ storage.VolumeSource = (*volumeSource)(nil)
+
+// CreateVolumes implements storage.VolumeSource.
+func (v *volumeSource) CreateVolumes(params []storage.VolumeParams) ([]storage.CreateVolumesResult, error) {
+	results := make([]storage.CreateVolumesResult, len(params))
+	for i, p := range params {
+		results[i].Error = errors.NotSupportedf(
+			"creating volume %s after machine creation", names.ReadableString(p.Tag),
+		)
+	}
+	return results, nil
+}
+
+// ListVolumes implements storage.VolumeSource.
+func (v *volumeSource) ListVolumes() ([]string, error) {
+	claims, err := v.env.client.PersistentVolumeClaims(v.env.namespace, v.env.modelLabels())
+	if err != nil {
+		return nil, errors.Annotate(err, "listing persistent volume claims")
+	}
+	ids := make([]string, len(claims))
+	for i, claim := range claims {
+		ids[i] = claim.Metadata.Name
+	}
+	return ids, nil
+}
+
+// DescribeVolumes implements storage.VolumeSource.
+func (v *volumeSource) DescribeVolumes(volIds []string) ([]storage.DescribeVolumesResult, error) {
+	results := make([]storage.DescribeVolumesResult, len(volIds))
+	for i, volId := range volIds {
+		claim, err := v.env.client.PersistentVolumeClaim(v.env.namespace, volId)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "getting persistent volume claim %q", volId)
+			continue
+		}
+		quantity := claim.Status.Capacity["storage"]
+		if quantity == "" {
+			quantity = claim.Spec.Resources.Requests["storage"]
+		}
+		size, err := parseQuantityMiB(quantity)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "parsing size of persistent volume claim %q", volId)
+			continue
+		}
+		results[i].VolumeInfo = &storage.VolumeInfo{
+			VolumeId: volId,
+			Size:     size,
+		}
+	}
+	return results, nil
+}
+
+// DestroyVolumes implements storage.VolumeSource.
+func (v *volumeSource) DestroyVolumes(volIds []string) ([]error, error) {
+	results := make([]error, len(volIds))
+	for i, volId := range volIds {
+		err := v.env.client.DeletePersistentVolumeClaim(v.env.namespace, volId)
+		if err != nil && !errors.IsNotFound(err) {
+			results[i] = errors.Annotatef(err, "deleting persistent volume claim %q", volId)
+		}
+	}
+	return results, nil
+}
+
+// ValidateVolumeParams implements storage.VolumeSource.
+func (v *volumeSource) ValidateVolumeParams(params storage.VolumeParams) error {
+	return nil
+}
+
+// AttachVolumes implements storage.VolumeSource. Persistent volume
+// claims are attached to their pods by Kubernetes.
+func (v *volumeSource) AttachVolumes(params []storage.VolumeAttachmentParams) ([]storage.AttachVolumesResult, error) {
+	results := make([]storage.AttachVolumesResult, len(params))
+	for i, p := range params {
+		results[i].VolumeAttachment = &storage.VolumeAttachment{
+			Volume:  p.Volume,
+			Machine: p.Machine,
+		}
+	}
+	return results, nil
+}
+
+// DetachVolumes implements storage.VolumeSource. Persistent volume
+// claims remain attached to their pods until the pods are deleted.
+func (v *volumeSource) DetachVolumes(params []storage.VolumeAttachmentParams) ([]error, error) {
+	return make([]error, len(params)), nil
+}
+
+// quantitySuffixes maps the binary quantity suffixes used for storage
+// sizes to their size in MiB.
+var quantitySuffixes = map[string]uint64{
+	"Mi": 1,
+	"Gi": 1024,
+	"Ti": 1024 * 1024,
+}
+
+// parseQuantityMiB parses a Kubernetes storage quantity with a binary
+// suffix, such as "1024Mi" or "10Gi", returning the size in MiB.
+func parseQuantityMiB(quantity string) (uint64, error) {
+	for suffix, mib := range quantitySuffixes {
+		if !strings.HasSuffix(quantity, suffix) {
+			continue
+		}
+		n, err := strconv.ParseUint(strings.TrimSuffix(quantity, suffix), 10, 64)
+		if err != nil {
+			return 0, errors.NotValidf("quantity %q", quantity)
+		}
+		return n * mib, nil
+	}
+	return 0, errors.NotValidf("quantity %q", quantity)
+}
@@ -0,0 +1,97 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package kubernetes_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/storage"
+)
+
+type storageSuite struct {
+	baseSuite
+	provider storage.Provider
+	source   storage.VolumeSource
+}
+
+var _ = gc.Suite(&storageSuite{})
+
+func (s *storageSuite) SetUpTest(c *gc.C) {
+	s.baseSuite.SetUpTest(c)
+	var err error
+	s.provider, err = s.env.StorageProvider("kubernetes")
+	c.Assert(err, jc.ErrorIsNil)
+	s.source, err = s.provider.VolumeSource(nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	params := s.startInstanceParams(c, "1", "postgresql/0")
+	params.Volumes = []storage.VolumeParams{{
+		Tag:      names.NewVolumeTag("0"),
+		Size:     10240,
+		Provider: "kubernetes",
+	}}
+	_, err = s.env.StartInstance(params)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *storageSuite) TestProvider(c *gc.C) {
+	c.Assert(s.provider.Supports(storage.StorageKindBlock), jc.IsTrue)
+	c.Assert(s.provider.Supports(storage.StorageKindFilesystem), jc.IsFalse)
+	c.Assert(s.provider.Scope(), gc.Equals, storage.ScopeEnviron)
+	c.Assert(s.provider.Dynamic(), jc.IsFalse)
+}
+
+func (s *storageSuite) TestValidateConfig(c *gc.C) {
+	cfg, err := storage.NewConfig("fast", "kubernetes", map[string]interface{}{
+		"storage-class": "ssd",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.provider.ValidateConfig(cfg), jc.ErrorIsNil)
+
+	cfg, err = storage.NewConfig("bad", "kubernetes", map[string]interface{}{
+		"storage-class": 42,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.provider.ValidateConfig(cfg)
+	c.Assert(err, gc.ErrorMatches, `validating kubernetes storage config: storage-class: expected string, got int\(42\)`)
+}
+
+func (s *storageSuite) TestCreateVolumesNotSupported(c *gc.C) {
+	results, err := s.source.CreateVolumes([]storage.VolumeParams{{
+		Tag: names.NewVolumeTag("1"),
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *storageSuite) TestListVolumes(c *gc.C) {
+	ids, err := s.source.ListVolumes()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ids, jc.DeepEquals, []string{"juju-volume-0-postgresql-0"})
+}
+
+func (s *storageSuite) TestDescribeVolumes(c *gc.C) {
+	results, err := s.source.DescribeVolumes([]string{"juju-volume-0-postgresql-0", "missing"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].VolumeInfo, jc.DeepEquals, &storage.VolumeInfo{
+		VolumeId: "juju-volume-0-postgresql-0",
+		Size:     10240,
+	})
+	c.Assert(results[1].Error, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *storageSuite) TestDestroyVolumes(c *gc.C) {
+	results, err := s.source.DestroyVolumes([]string{"juju-volume-0-postgresql-0", "missing"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []error{nil, nil})
+	ids, err := s.source.ListVolumes()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ids, gc.HasLen, 0)
+}
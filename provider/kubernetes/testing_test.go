@@ -0,0 +1,125 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package kubernetes_test
+
+import (
+	"fmt"
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/arch"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/cloudconfig/instancecfg"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/imagemetadata"
+	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/provider/kubernetes/kubeclient"
+	"github.com/juju/juju/provider/kubernetes/kubetest"
+	"github.com/juju/juju/testing"
+	coretools "github.com/juju/juju/tools"
+)
+
+const testNamespace = "juju-test"
+
+type baseSuite struct {
+	testing.BaseSuite
+
+	server *kubetest.Server
+	client *kubeclient.Client
+	spec   environs.CloudSpec
+	config *config.Config
+	env    environs.Environ
+}
+
+func (s *baseSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+
+	s.server = kubetest.NewServer()
+	s.AddCleanup(func(*gc.C) { s.server.Close() })
+
+	var err error
+	s.client, err = kubeclient.New(kubeclient.Config{
+		Endpoint: s.server.URL,
+		Username: "admin",
+		Password: "secret",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	credential := cloud.NewCredential(cloud.UserPassAuthType, map[string]string{
+		"username": "admin",
+		"password": "secret",
+	})
+	s.spec = environs.CloudSpec{
+		Type:       "kubernetes",
+		Name:       "k8s",
+		Endpoint:   s.server.URL,
+		Credential: &credential,
+	}
+	s.config, err = config.New(config.UseDefaults, testing.FakeConfig().Merge(testing.Attrs{
+		"type":      "kubernetes",
+		"namespace": testNamespace,
+	}))
+	c.Assert(err, jc.ErrorIsNil)
+
+	provider, err := environs.Provider("kubernetes")
+	c.Assert(err, jc.ErrorIsNil)
+	s.env, err = provider.Open(environs.OpenParams{
+		Cloud:  s.spec,
+		Config: s.config,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.env.Create(environs.CreateParams{
+		ControllerUUID: testing.FakeControllerConfig().ControllerUUID(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+// startInstanceParams returns parameters for starting a machine with
+// the given ID, hosting the given units.
+func (s *baseSuite) startInstanceParams(c *gc.C, machineId string, units ...string) environs.StartInstanceParams {
+	machineTag := names.NewMachineTag(machineId)
+	apiInfo := &api.Info{
+		Addrs:    []string{"localhost:17070"},
+		CACert:   testing.CACert,
+		Password: "admin",
+		Tag:      machineTag,
+		ModelTag: testing.ModelTag,
+	}
+	icfg, err := instancecfg.NewInstanceConfig(
+		machineTag.Id(), "yanonce", imagemetadata.ReleasedStream,
+		"xenial", true, apiInfo,
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	icfg.Tags = map[string]string{
+		tags.JujuModel:      s.config.UUID(),
+		tags.JujuController: testing.FakeControllerConfig().ControllerUUID(),
+	}
+	if len(units) > 0 {
+		icfg.Tags[tags.JujuUnitsDeployed] = strings.Join(units, " ")
+	}
+	return environs.StartInstanceParams{
+		ControllerUUID: testing.FakeControllerConfig().ControllerUUID(),
+		InstanceConfig: icfg,
+		Tools:          makeToolsList("xenial"),
+	}
+}
+
+func makeToolsList(series string) coretools.List {
+	var toolsVersion version.Binary
+	toolsVersion.Number = version.MustParse("2.0.0")
+	toolsVersion.Arch = arch.AMD64
+	toolsVersion.Series = series
+	return coretools.List{{
+		Version: toolsVersion,
+		URL:     fmt.Sprintf("http://example.com/tools/juju-%s.tgz", toolsVersion),
+		SHA256:  "1234567890abcdef",
+		Size:    1024,
+	}}
+}
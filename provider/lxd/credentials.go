@@ -9,6 +9,12 @@ import (
 	"github.com/juju/juju/cloud"
 )
 
+const (
+	credAttrClientCert = "client-cert"
+	credAttrClientKey  = "client-key"
+	credAttrServerCert = "server-cert"
+)
+
 type environProviderCredentials struct{}
 
 // CredentialSchemas is part of the environs.ProviderCredentials interface.
@@ -16,7 +22,30 @@ func (environProviderCredentials) CredentialSchemas() map[cloud.AuthType]cloud.C
 	// TODO (anastasiamac 2016-04-14) When/If this value changes,
 	// verify that juju/juju/cloud/clouds.go#BuiltInClouds
 	// with lxd type are up to-date.
-	return map[cloud.AuthType]cloud.CredentialSchema{cloud.EmptyAuthType: {}}
+	return map[cloud.AuthType]cloud.CredentialSchema{
+		// The local LXD daemon is accessed over its unix socket,
+		// and requires no credentials.
+		cloud.EmptyAuthType: {},
+		// Remote LXD hosts are accessed over HTTPS, using a client
+		// certificate that the hosts have been configured to trust.
+		cloud.CertificateAuthType: {{
+			credAttrClientCert, cloud.CredentialAttr{
+				Description: "PEM-encoded client certificate trusted by the LXD hosts",
+				FileAttr:    "client-cert-path",
+			},
+		}, {
+			credAttrClientKey, cloud.CredentialAttr{
+				Description: "PEM-encoded private key for the client certificate",
+				Hidden:      true,
+				FileAttr:    "client-key-path",
+			},
+		}, {
+			credAttrServerCert, cloud.CredentialAttr{
+				Description: "PEM-encoded server certificate of each LXD host, in endpoint order",
+				FileAttr:    "server-cert-path",
+			},
+		}},
+	}
 }
 
 // DetectCredentials is part of the environs.ProviderCredentials interface.
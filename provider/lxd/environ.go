@@ -31,6 +31,16 @@ type environ struct {
 	raw  *rawProvider
 	base baseProvider
 
+	// hosts holds the LXD hosts in the cloud, each of which is an
+	// availability zone. For the local LXD daemon, raw is the host's
+	// raw provider; remote hosts are connected lazily, so raw then
+	// only provides the firewaller, which needs no connection.
+	hosts []*lxdHost
+
+	// remote records whether the hosts are remote, rather than the
+	// local LXD daemon.
+	remote bool
+
 	// namespace is used to create the machine and device hostnames.
 	namespace instance.Namespace
 
@@ -38,7 +48,7 @@ type environ struct {
 	ecfg *environConfig
 }
 
-type newRawProviderFunc func(environs.CloudSpec) (*rawProvider, error)
+type newRawProviderFunc func(environs.CloudSpec) ([]*lxdHost, error)
 
 func newEnviron(spec environs.CloudSpec, cfg *config.Config, newRawProvider newRawProviderFunc) (*environ, error) {
 	ecfg, err := newValidConfig(cfg)
@@ -51,7 +61,7 @@ func newEnviron(spec environs.CloudSpec, cfg *config.Config, newRawProvider newR
 		return nil, errors.Trace(err)
 	}
 
-	raw, err := newRawProvider(spec)
+	hosts, err := newRawProvider(spec)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -59,15 +69,24 @@ func newEnviron(spec environs.CloudSpec, cfg *config.Config, newRawProvider newR
 	env := &environ{
 		name:      ecfg.Name(),
 		uuid:      ecfg.UUID(),
-		raw:       raw,
+		hosts:     hosts,
+		remote:    spec.Endpoint != "",
 		namespace: namespace,
 		ecfg:      ecfg,
 	}
 	env.base = common.DefaultProvider{Env: env}
 
 	//TODO(wwitzel3) make sure we are also cleaning up profiles during destroy
-	if err := env.initProfile(); err != nil {
-		return nil, errors.Trace(err)
+	for _, host := range hosts {
+		host.init = env.initProfile
+	}
+	if env.remote {
+		env.raw = &rawProvider{Firewaller: common.NewFirewaller()}
+	} else {
+		env.raw, err = hosts[0].provider()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
 	}
 
 	return env, nil
@@ -78,8 +97,10 @@ var defaultProfileConfig = map[string]string{
 	"security.nesting": "true",
 }
 
-func (env *environ) initProfile() error {
-	hasProfile, err := env.raw.HasProfile(env.profileName())
+// initProfile creates the model's profile on the LXD host
+// with the given raw provider, if it does not already exist.
+func (env *environ) initProfile(raw *rawProvider) error {
+	hasProfile, err := raw.HasProfile(env.profileName())
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -88,7 +109,10 @@ func (env *environ) initProfile() error {
 		return nil
 	}
 
-	return env.raw.CreateProfile(env.profileName(), defaultProfileConfig)
+	if err := raw.CreateProfile(env.profileName(), defaultProfileConfig); err != nil {
+		return errors.Annotate(err, "creating profile")
+	}
+	return nil
 }
 
 func (env *environ) profileName() string {
@@ -178,7 +202,7 @@ func (env *environ) destroyHostedModelResources(controllerUUID string) error {
 		return errors.Annotate(err, "listing instances")
 	}
 	logger.Debugf("instances: %v", instances)
-	var names []string
+	names := make(map[string][]string)
 	for _, inst := range instances {
 		metadata := inst.raw.Metadata()
 		if metadata[tags.JujuModel] == env.uuid {
@@ -187,10 +211,40 @@ func (env *environ) destroyHostedModelResources(controllerUUID string) error {
 		if metadata[tags.JujuController] != controllerUUID {
 			continue
 		}
-		names = append(names, string(inst.Id()))
+		names[inst.zone] = append(names[inst.zone], string(inst.Id()))
 	}
-	if err := env.raw.RemoveInstances(prefix, names...); err != nil {
-		return errors.Annotate(err, "removing hosted model instances")
+	for _, host := range env.hosts {
+		if len(names[host.zone]) == 0 {
+			continue
+		}
+		raw, err := host.provider()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err := raw.RemoveInstances(prefix, names[host.zone]...); err != nil {
+			return errors.Annotate(err, "removing hosted model instances")
+		}
 	}
 	return nil
 }
+
+// zoneHost returns the LXD host that is exposed
+// as the specified availability zone.
+func (env *environ) zoneHost(zone string) (*lxdHost, error) {
+	for _, host := range env.hosts {
+		if host.zone == zone {
+			return host, nil
+		}
+	}
+	return nil, errors.NotFoundf("LXD host for availability zone %q", zone)
+}
+
+// hostProvider returns the raw provider for the LXD host
+// that is exposed as the specified availability zone.
+func (env *environ) hostProvider(zone string) (*rawProvider, error) {
+	host, err := env.zoneHost(zone)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return host.provider()
+}
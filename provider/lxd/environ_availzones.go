@@ -0,0 +1,114 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build go1.3
+
+package lxd
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/provider/common"
+)
+
+// lxdAvailabilityZone is an LXD host, exposed as an availability zone.
+type lxdAvailabilityZone struct {
+	name      string
+	available bool
+}
+
+// Name implements common.AvailabilityZone.
+func (z lxdAvailabilityZone) Name() string {
+	return z.name
+}
+
+// Available implements common.AvailabilityZone.
+func (z lxdAvailabilityZone) Available() bool {
+	return z.available
+}
+
+// AvailabilityZones returns all availability zones in the environment.
+// Each LXD host in the cloud is an availability zone, which is
+// unavailable if the host cannot be reached.
+func (env *environ) AvailabilityZones() ([]common.AvailabilityZone, error) {
+	zones := make([]common.AvailabilityZone, len(env.hosts))
+	for i, host := range env.hosts {
+		_, err := host.provider()
+		if err != nil {
+			logger.Warningf("LXD host %q is unavailable: %v", host.zone, err)
+		}
+		zones[i] = lxdAvailabilityZone{name: host.zone, available: err == nil}
+	}
+	return zones, nil
+}
+
+// InstanceAvailabilityZoneNames returns the names of the availability
+// zones for the specified instances. The error returned follows the same
+// rules as Environ.Instances.
+func (env *environ) InstanceAvailabilityZoneNames(ids []instance.Id) ([]string, error) {
+	instances, err := env.Instances(ids)
+	if err != nil && err != environs.ErrPartialInstances && err != environs.ErrNoInstances {
+		return nil, errors.Trace(err)
+	}
+	// We let the two environs errors pass on through. However, we do
+	// not use errors.Trace in that case since callers may not call
+	// errors.Cause.
+
+	results := make([]string, len(ids))
+	for i, inst := range instances {
+		if eInst, ok := inst.(*environInstance); ok && eInst != nil {
+			results[i] = eInst.zone
+		}
+	}
+	return results, err
+}
+
+// DistributeInstances implements the state.InstanceDistributor policy.
+func (env *environ) DistributeInstances(candidates, distributionGroup []instance.Id) ([]instance.Id, error) {
+	return common.DistributeInstances(env, candidates, distributionGroup)
+}
+
+var availabilityZoneAllocations = common.AvailabilityZoneAllocations
+
+// startInstanceHost returns the LXD host that the instance described
+// by the given parameters should be started on. If a zone placement
+// directive was provided then that zone's host is returned. Otherwise
+// the host is chosen so that the environment's instances are spread
+// evenly across the hosts.
+func (env *environ) startInstanceHost(args environs.StartInstanceParams) (*lxdHost, error) {
+	placement, err := env.parsePlacement(args.Placement)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	zone := placement.zone
+	if zone != "" {
+		if err := common.ValidateZoneConstraint(zone, args.Constraints); err != nil {
+			return nil, errors.Trace(err)
+		}
+	} else if len(env.hosts) == 1 {
+		return env.hosts[0], nil
+	} else {
+		var group []instance.Id
+		if args.DistributionGroup != nil {
+			group, err = args.DistributionGroup()
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		zoneInstances, err := availabilityZoneAllocations(env, group)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		zoneInstances, err = common.FilterZoneAllocations(zoneInstances, args.Constraints)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if len(zoneInstances) == 0 {
+			return nil, errors.New("failed to determine availability zones")
+		}
+		zone = zoneInstances[0].ZoneName
+	}
+	return env.zoneHost(zone)
+}
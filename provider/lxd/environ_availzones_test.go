@@ -0,0 +1,160 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build go1.3
+
+package lxd_test
+
+import (
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/arch"
+	"github.com/juju/utils/series"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/provider/lxd"
+	"github.com/juju/juju/tools/lxdclient"
+)
+
+type environAvailzonesSuite struct {
+	lxd.BaseSuite
+
+	OtherStub   *gitjujutesting.Stub
+	OtherClient *lxd.StubClient
+}
+
+var _ = gc.Suite(&environAvailzonesSuite{})
+
+func (s *environAvailzonesSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+
+	s.OtherStub = &gitjujutesting.Stub{}
+	s.OtherClient = &lxd.StubClient{Stub: s.OtherStub}
+	lxd.AddEnvHost(s.Env, "other", s.OtherClient)
+
+	// Patch the host's arch, so the broker will filter tools.
+	s.PatchValue(&arch.HostArch, func() string { return arch.ARM64 })
+}
+
+func (s *environAvailzonesSuite) TestAvailabilityZones(c *gc.C) {
+	zones, err := s.Env.AvailabilityZones()
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(zones, gc.HasLen, 2)
+	c.Check(zones[0].Name(), gc.Equals, "localhost")
+	c.Check(zones[0].Available(), jc.IsTrue)
+	c.Check(zones[1].Name(), gc.Equals, "other")
+	c.Check(zones[1].Available(), jc.IsTrue)
+	s.CheckNoAPI(c)
+}
+
+func (s *environAvailzonesSuite) TestAvailabilityZonesUnreachable(c *gc.C) {
+	lxd.AddUnreachableEnvHost(s.Env, "down", errors.New("no route to host"))
+
+	zones, err := s.Env.AvailabilityZones()
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(zones, gc.HasLen, 3)
+	c.Check(zones[0].Available(), jc.IsTrue)
+	c.Check(zones[1].Available(), jc.IsTrue)
+	c.Check(zones[2].Name(), gc.Equals, "down")
+	c.Check(zones[2].Available(), jc.IsFalse)
+}
+
+func (s *environAvailzonesSuite) TestStartInstanceSkipsUnreachable(c *gc.C) {
+	lxd.AddUnreachableEnvHost(s.Env, "down", errors.New("no route to host"))
+	// The unreachable host has no known instances, but
+	// the other host should still be chosen.
+	s.Client.Insts = []lxdclient.Instance{*s.NewRawInstance(c, "spam")}
+	s.OtherClient.Inst = s.RawInstance
+
+	_, err := s.Env.StartInstance(s.StartInstArgs)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.Stub.CheckCallNames(c, "Instances", "Instances")
+	s.OtherStub.CheckCallNames(c, "Instances", "Instances", "EnsureImageExists", "AddCert", "ServerStatus", "AddInstance")
+}
+
+func (s *environAvailzonesSuite) TestStartInstancePlacementUnreachable(c *gc.C) {
+	lxd.AddUnreachableEnvHost(s.Env, "down", errors.New("no route to host"))
+	s.StartInstArgs.Placement = "zone=down"
+
+	_, err := s.Env.StartInstance(s.StartInstArgs)
+	c.Assert(err, gc.ErrorMatches, `availability zone "down" is unavailable: connecting to LXD host "down": no route to host`)
+}
+
+func (s *environAvailzonesSuite) TestInstanceAvailabilityZoneNames(c *gc.C) {
+	s.Client.Insts = []lxdclient.Instance{*s.NewRawInstance(c, "spam")}
+	s.OtherClient.Insts = []lxdclient.Instance{*s.NewRawInstance(c, "eggs")}
+
+	zones, err := s.Env.InstanceAvailabilityZoneNames([]instance.Id{"eggs", "spam"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(zones, jc.DeepEquals, []string{"other", "localhost"})
+}
+
+func (s *environAvailzonesSuite) TestStartInstancePlacement(c *gc.C) {
+	s.OtherClient.Inst = s.RawInstance
+	s.StartInstArgs.Placement = "zone=other"
+
+	_, err := s.Env.StartInstance(s.StartInstArgs)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.Stub.CheckNoCalls(c)
+	s.OtherStub.CheckCallNames(c, "EnsureImageExists", "AddCert", "ServerStatus", "AddInstance")
+}
+
+func (s *environAvailzonesSuite) TestStartInstanceDistributes(c *gc.C) {
+	// The local host is running an instance,
+	// so the other host should be chosen.
+	s.Client.Insts = []lxdclient.Instance{*s.NewRawInstance(c, "spam")}
+	s.OtherClient.Inst = s.RawInstance
+
+	_, err := s.Env.StartInstance(s.StartInstArgs)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Instances are listed once to determine the distribution
+	// group, and again to determine their availability zones.
+	s.Stub.CheckCallNames(c, "Instances", "Instances")
+	s.OtherStub.CheckCallNames(c, "Instances", "Instances", "EnsureImageExists", "AddCert", "ServerStatus", "AddInstance")
+}
+
+func (s *environAvailzonesSuite) TestStartInstanceZonesConstraint(c *gc.C) {
+	// The local host is running an instance, but
+	// it is the only host satisfying the constraint.
+	s.Client.Insts = []lxdclient.Instance{*s.NewRawInstance(c, "spam")}
+	s.Client.Inst = s.RawInstance
+	s.StartInstArgs.Constraints = constraints.MustParse("zones=localhost")
+
+	_, err := s.Env.StartInstance(s.StartInstArgs)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.Stub.CheckCallNames(c, "Instances", "Instances", "EnsureImageExists", "AddCert", "ServerStatus", "AddInstance")
+	s.OtherStub.CheckCallNames(c, "Instances", "Instances")
+}
+
+func (s *environAvailzonesSuite) TestPrecheckInstanceUnknownZone(c *gc.C) {
+	err := s.Env.PrecheckInstance(series.LatestLts(), constraints.Value{}, "zone=unknown")
+	c.Assert(err, gc.ErrorMatches, `invalid availability zone "unknown"`)
+}
+
+func (s *environAvailzonesSuite) TestPrecheckInstanceZonesConstraint(c *gc.C) {
+	cons := constraints.MustParse("zones=localhost")
+	err := s.Env.PrecheckInstance(series.LatestLts(), cons, "zone=other")
+	c.Assert(err, gc.ErrorMatches, `placement zone "other" does not satisfy constraint zones=\[localhost\]`)
+}
+
+func (s *environAvailzonesSuite) TestConstraintsValidatorZones(c *gc.C) {
+	validator, err := s.Env.ConstraintsValidator()
+	c.Assert(err, jc.ErrorIsNil)
+
+	unsupported, err := validator.Validate(constraints.MustParse("zones=localhost,other"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(unsupported, gc.HasLen, 0)
+
+	_, err = validator.Validate(constraints.MustParse("zones=unknown"))
+	c.Assert(err, gc.ErrorMatches, "invalid constraint value: zones=unknown\nvalid values are:.*")
+}
@@ -41,21 +41,31 @@ func (env *environ) StartInstance(args environs.StartInstanceParams) (*environs.
 	series := args.Tools.OneSeries()
 	logger.Debugf("StartInstance: %q, %s", args.InstanceConfig.MachineId, series)
 
-	if err := env.finishInstanceConfig(args); err != nil {
+	host, err := env.startInstanceHost(args)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	hostRaw, err := host.provider()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	if err := env.finishInstanceConfig(args, hostRaw); err != nil {
 		return nil, errors.Trace(err)
 	}
 
 	// TODO(ericsnow) Handle constraints?
 
-	raw, err := env.newRawInstance(args)
+	raw, err := env.newRawInstance(args, hostRaw)
 	if err != nil {
 		if args.StatusCallback != nil {
 			args.StatusCallback(status.StatusProvisioningError, err.Error(), nil)
 		}
 		return nil, errors.Trace(err)
 	}
-	logger.Infof("started instance %q", raw.Name)
+	logger.Infof("started instance %q on LXD host %q", raw.Name, host.zone)
 	inst := newInstance(raw, env)
+	inst.zone = host.zone
 
 	// Build the result.
 	hwc := env.getHardwareCharacteristics(args, inst)
@@ -66,11 +76,12 @@ func (env *environ) StartInstance(args environs.StartInstanceParams) (*environs.
 	return &result, nil
 }
 
-func (env *environ) finishInstanceConfig(args environs.StartInstanceParams) error {
-	// TODO(natefinch): This is only correct so long as the lxd is running on
-	// the local machine.  If/when we support a remote lxd environment, we'll
-	// need to change this to match the arch of the remote machine.
-	tools, err := args.Tools.Match(tools.Filter{Arch: arch.HostArch()})
+func (env *environ) finishInstanceConfig(args environs.StartInstanceParams, host *rawProvider) error {
+	hostArch, err := env.hostArch(host)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	tools, err := args.Tools.Match(tools.Filter{Arch: hostArch})
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -85,6 +96,19 @@ func (env *environ) finishInstanceConfig(args environs.StartInstanceParams) erro
 	return nil
 }
 
+// hostArch returns the architecture of the given LXD host. The local
+// LXD daemon runs on this machine, and so has the same architecture.
+func (env *environ) hostArch(raw *rawProvider) (string, error) {
+	if !env.remote {
+		return arch.HostArch(), nil
+	}
+	serverState, err := raw.ServerStatus()
+	if err != nil {
+		return "", errors.Annotate(err, "getting server status")
+	}
+	return arch.NormaliseArch(serverState.Environment.KernelArchitecture), nil
+}
+
 func (env *environ) getImageSources() ([]lxdclient.Remote, error) {
 	metadataSources, err := environs.ImageMetadataSources(env)
 	if err != nil {
@@ -125,9 +149,9 @@ func (env *environ) getImageSources() ([]lxdclient.Remote, error) {
 }
 
 // newRawInstance is where the new physical instance is actually
-// provisioned, relative to the provided args and spec. Info for that
-// low-level instance is returned.
-func (env *environ) newRawInstance(args environs.StartInstanceParams) (*lxdclient.Instance, error) {
+// provisioned on the given host, relative to the provided args and
+// spec. Info for that low-level instance is returned.
+func (env *environ) newRawInstance(args environs.StartInstanceParams, host *rawProvider) (*lxdclient.Instance, error) {
 	hostname, err := env.namespace.Hostname(args.InstanceConfig.MachineId)
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -172,7 +196,7 @@ func (env *environ) newRawInstance(args environs.StartInstanceParams) (*lxdclien
 	imageCallback := func(copyProgress string) {
 		statusCallback(status.StatusAllocating, copyProgress)
 	}
-	if err := env.raw.EnsureImageExists(series, imageSources, imageCallback); err != nil {
+	if err := host.EnsureImageExists(series, imageSources, imageCallback); err != nil {
 		return nil, errors.Trace(err)
 	}
 	cleanupCallback() // Clean out any long line of completed download status
@@ -181,10 +205,11 @@ func (env *environ) newRawInstance(args environs.StartInstanceParams) (*lxdclien
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	if args.InstanceConfig.Controller != nil {
+	if args.InstanceConfig.Controller != nil && !env.remote {
 		// For controller machines, generate a certificate pair and write
 		// them to the instance's disk in a well-defined location, along
-		// with the server's certificate.
+		// with the server's certificate. Controllers in remote clouds
+		// use the cloud credential instead.
 		certPEM, keyPEM, err := lxdshared.GenerateMemCert()
 		if err != nil {
 			return nil, errors.Trace(err)
@@ -194,10 +219,10 @@ func (env *environ) newRawInstance(args environs.StartInstanceParams) (*lxdclien
 		// TODO(axw) 2016-08-24 #1616346
 		// We need to remove this cert when removing
 		// the machine and/or destroying the controller.
-		if err := env.raw.AddCert(cert); err != nil {
+		if err := host.AddCert(cert); err != nil {
 			return nil, errors.Annotatef(err, "adding certificate %q", cert.Name)
 		}
-		serverState, err := env.raw.ServerStatus()
+		serverState, err := host.ServerStatus()
 		if err != nil {
 			return nil, errors.Annotate(err, "getting server status")
 		}
@@ -241,7 +266,7 @@ func (env *environ) newRawInstance(args environs.StartInstanceParams) (*lxdclien
 	logger.Infof("starting instance %q (image %q)...", instSpec.Name, instSpec.Image)
 
 	statusCallback(status.StatusAllocating, "starting instance")
-	inst, err := env.raw.AddInstance(instSpec)
+	inst, err := host.AddInstance(instSpec)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -333,7 +358,17 @@ func (env *environ) StopInstances(instances ...instance.Id) error {
 		ids = append(ids, string(id))
 	}
 
+	// Instances are removed from each of the hosts, since
+	// the hosts ignore the IDs of instances they do not have.
 	prefix := env.namespace.Prefix()
-	err := env.raw.RemoveInstances(prefix, ids...)
-	return errors.Trace(err)
+	for _, host := range env.hosts {
+		raw, err := host.provider()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err := raw.RemoveInstances(prefix, ids...); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
 }
@@ -6,6 +6,8 @@
 package lxd
 
 import (
+	"strings"
+
 	"github.com/juju/errors"
 
 	"github.com/juju/juju/environs"
@@ -73,53 +75,99 @@ func (env *environ) allInstances() ([]*environInstance, error) {
 	return env.prefixedInstances(prefix)
 }
 
-// prefixedInstances returns instances with the specified prefix.
+// prefixedInstances returns instances with the specified prefix,
+// from all of the LXD hosts. Hosts that cannot be reached are
+// unavailable zones, and are skipped.
 func (env *environ) prefixedInstances(prefix string) ([]*environInstance, error) {
-	instances, err := env.raw.Instances(prefix, lxdclient.AliveStatuses...)
-	err = errors.Trace(err)
-
-	// Turn lxdclient.Instance values into *environInstance values,
-	// whether or not we got an error.
 	var results []*environInstance
-	for _, base := range instances {
-		// If we don't make a copy then the same pointer is used for the
-		// base of all resulting instances.
-		copied := base
-		inst := newInstance(&copied, env)
-		results = append(results, inst)
+	var resultErr error
+	for _, host := range env.hosts {
+		raw, err := host.provider()
+		if err != nil {
+			logger.Warningf("skipping instances on unavailable LXD host: %v", err)
+			continue
+		}
+		instances, err := raw.Instances(prefix, lxdclient.AliveStatuses...)
+		if err != nil && resultErr == nil {
+			resultErr = errors.Trace(err)
+		}
+
+		// Turn lxdclient.Instance values into *environInstance values,
+		// whether or not we got an error.
+		for _, base := range instances {
+			// If we don't make a copy then the same pointer is used for the
+			// base of all resulting instances.
+			copied := base
+			inst := newInstance(&copied, env)
+			inst.zone = host.zone
+			results = append(results, inst)
+		}
 	}
-	return results, err
+	return results, resultErr
 }
 
 // ControllerInstances returns the IDs of the instances corresponding
 // to juju controllers.
 func (env *environ) ControllerInstances(controllerUUID string) ([]instance.Id, error) {
-	instances, err := env.raw.Instances("juju-", lxdclient.AliveStatuses...)
-	if err != nil {
-		return nil, errors.Trace(err)
-	}
-
 	var results []instance.Id
-	for _, inst := range instances {
-		if inst.Metadata()[tags.JujuController] != controllerUUID {
+	var unavailableErr error
+	for _, host := range env.hosts {
+		raw, err := host.provider()
+		if err != nil {
+			logger.Warningf("skipping instances on unavailable LXD host: %v", err)
+			unavailableErr = errors.Trace(err)
 			continue
 		}
-		if inst.Metadata()[tags.JujuIsController] == "true" {
-			results = append(results, instance.Id(inst.Name))
+		instances, err := raw.Instances("juju-", lxdclient.AliveStatuses...)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+
+		for _, inst := range instances {
+			if inst.Metadata()[tags.JujuController] != controllerUUID {
+				continue
+			}
+			if inst.Metadata()[tags.JujuIsController] == "true" {
+				results = append(results, instance.Id(inst.Name))
+			}
 		}
 	}
 	if len(results) == 0 {
+		if unavailableErr != nil {
+			// The controllers may be on an unavailable host.
+			return nil, unavailableErr
+		}
 		return nil, environs.ErrNotBootstrapped
 	}
 	return results, nil
 }
 
-type instPlacement struct{}
+type instPlacement struct {
+	// zone is the availability zone of the LXD host
+	// to start the instance on, if specified.
+	zone string
+}
 
 func (env *environ) parsePlacement(placement string) (*instPlacement, error) {
 	if placement == "" {
 		return &instPlacement{}, nil
 	}
 
+	pos := strings.IndexRune(placement, '=')
+	if pos == -1 {
+		return nil, errors.Errorf("unknown placement directive: %v", placement)
+	}
+
+	switch key, value := placement[:pos], placement[pos+1:]; key {
+	case "zone":
+		host, err := env.zoneHost(value)
+		if err != nil {
+			return nil, errors.Errorf("invalid availability zone %q", value)
+		}
+		if _, err := host.provider(); err != nil {
+			return nil, errors.Annotatef(err, "availability zone %q is unavailable", value)
+		}
+		return &instPlacement{zone: value}, nil
+	}
 	return nil, errors.Errorf("unknown placement directive: %v", placement)
 }
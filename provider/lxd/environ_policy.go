@@ -7,15 +7,20 @@ package lxd
 
 import (
 	"github.com/juju/errors"
-	"github.com/juju/utils/arch"
+	"github.com/juju/utils/set"
 
 	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/provider/common"
 )
 
 // PrecheckInstance verifies that the provided series and constraints
 // are valid for use in creating an instance in this environment.
 func (env *environ) PrecheckInstance(series string, cons constraints.Value, placement string) error {
-	if _, err := env.parsePlacement(placement); err != nil {
+	instPlacement, err := env.parsePlacement(placement)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := common.ValidateZoneConstraint(instPlacement.zone, cons); err != nil {
 		return errors.Trace(err)
 	}
 
@@ -33,6 +38,7 @@ var unsupportedConstraints = []string{
 	constraints.InstanceType,
 	constraints.Tags,
 	constraints.VirtType,
+	constraints.PlacementGroup,
 }
 
 // ConstraintsValidator returns a Validator value which is used to
@@ -46,14 +52,41 @@ func (env *environ) ConstraintsValidator() (constraints.Validator, error) {
 
 	// Register unsupported constraints.
 
-	validator.RegisterUnsupported(unsupportedConstraints)
+	// Zones are only useful when there are
+	// multiple LXD hosts to choose between.
+	multipleHosts := len(env.hosts) > 1
+	unsupported := append([]string{}, unsupportedConstraints...)
+	if !multipleHosts {
+		unsupported = append(unsupported, constraints.Zones)
+	}
+	validator.RegisterUnsupported(unsupported)
 
 	// Register the constraints vocab.
 
-	// TODO(natefinch): This is only correct so long as the lxd is running on
-	// the local machine.  If/when we support a remote lxd environment, we'll
-	// need to change this to match the arch of the remote machine.
-	validator.RegisterVocabulary(constraints.Arch, []string{arch.HostArch()})
+	arches := set.NewStrings()
+	for _, host := range env.hosts {
+		raw, err := host.provider()
+		if err != nil {
+			// An unreachable host is an unavailable zone,
+			// so its architecture need not be allowed.
+			logger.Warningf("LXD host %q is unavailable: %v", host.zone, err)
+			continue
+		}
+		hostArch, err := env.hostArch(raw)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		arches.Add(hostArch)
+	}
+	validator.RegisterVocabulary(constraints.Arch, arches.SortedValues())
+
+	if multipleHosts {
+		zoneNames, err := common.AvailabilityZoneNames(env)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		validator.RegisterVocabulary(constraints.Zones, zoneNames)
+	}
 
 	// TODO(ericsnow) Get this working...
 	//validator.RegisterVocabulary(constraints.Container, supportedContainerTypes)
@@ -6,20 +6,26 @@
 package lxd
 
 import (
+	"encoding/pem"
 	"io/ioutil"
+	"net"
+	"net/url"
 	"os"
 	"path"
 	"strings"
+	"sync"
 
 	"github.com/juju/errors"
 	"github.com/juju/utils"
 	"github.com/juju/utils/series"
+	"github.com/juju/utils/set"
 	lxdshared "github.com/lxc/lxd/shared"
 
 	"github.com/juju/juju/environs"
 	jujupaths "github.com/juju/juju/juju/paths"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/provider/common"
+	"github.com/juju/juju/provider/lxd/lxdnames"
 	"github.com/juju/juju/tools/lxdclient"
 )
 
@@ -63,13 +69,94 @@ type lxdImages interface {
 	EnsureImageExists(series string, sources []lxdclient.Remote, copyProgressHandler func(string)) error
 }
 
+// lxdHost is an LXD host in the cloud. Each host is exposed as an
+// availability zone. Remote hosts are connected to when they are first
+// used, so that an unreachable host does not prevent the others from
+// being used.
+type lxdHost struct {
+	zone string
+
+	// connect returns a raw provider connected to the host.
+	connect func() (*rawProvider, error)
+
+	// init is called with the host's raw provider when it is
+	// first connected, to prepare the host for use by the model.
+	init func(*rawProvider) error
+
+	mu  sync.Mutex
+	raw *rawProvider
+}
+
+// provider returns the host's raw provider, connecting to the
+// host if it has not already been connected. A failed connection
+// is retried the next time the provider is requested.
+func (h *lxdHost) provider() (*rawProvider, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.raw != nil {
+		return h.raw, nil
+	}
+	raw, err := h.connect()
+	if err != nil {
+		return nil, errors.Annotatef(err, "connecting to LXD host %q", h.zone)
+	}
+	if h.init != nil {
+		if err := h.init(raw); err != nil {
+			return nil, errors.Annotatef(err, "preparing LXD host %q", h.zone)
+		}
+	}
+	h.raw = raw
+	return raw, nil
+}
+
+// newRawProviders returns a raw provider for each of the LXD hosts
+// in the cloud. The local LXD daemon is the only host of a cloud
+// without an endpoint, and is connected to immediately; remote
+// hosts are not connected to until they are used.
+func newRawProviders(spec environs.CloudSpec) ([]*lxdHost, error) {
+	if spec.Endpoint == "" {
+		raw, err := newRawProvider(spec)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		host := &lxdHost{zone: lxdnames.DefaultRegion}
+		host.connect = func() (*rawProvider, error) {
+			return raw, nil
+		}
+		return []*lxdHost{host}, nil
+	}
+
+	remotes, err := getRemoteHostConfigs(spec)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	hosts := make([]*lxdHost, len(remotes))
+	for i, remote := range remotes {
+		remote := remote
+		hosts[i] = &lxdHost{
+			zone: remote.Name,
+			connect: func() (*rawProvider, error) {
+				client, err := lxdclient.Connect(lxdclient.Config{Remote: remote})
+				if err != nil {
+					return nil, errors.Trace(err)
+				}
+				return newRawProviderFromClient(client), nil
+			},
+		}
+	}
+	return hosts, nil
+}
+
 func newRawProvider(spec environs.CloudSpec) (*rawProvider, error) {
 	client, err := newClient(spec, ioutil.ReadFile, utils.RunCommand)
 	if err != nil {
 		return nil, errors.Annotate(err, "creating LXD client")
 	}
+	return newRawProviderFromClient(client), nil
+}
 
-	raw := &rawProvider{
+func newRawProviderFromClient(client *lxdclient.Client) *rawProvider {
+	return &rawProvider{
 		lxdCerts:     client,
 		lxdConfig:    client,
 		lxdInstances: client,
@@ -77,7 +164,6 @@ func newRawProvider(spec environs.CloudSpec) (*rawProvider, error) {
 		lxdImages:    client,
 		Firewaller:   common.NewFirewaller(),
 	}
-	return raw, nil
 }
 
 type readFileFunc func(string) ([]byte, error)
@@ -150,6 +236,106 @@ func getRemoteConfig(readFile readFileFunc, runCommand runCommandFunc) (*lxdclie
 	}, nil
 }
 
+// getRemoteHostConfigs returns a lxdclient.Remote for each of the LXD
+// hosts at the cloud's endpoint. The hosts are named for their
+// availability zones, and are accessed using the client certificate
+// from the cloud credential. The credential's server certificate is
+// either shared by all of the hosts, or holds one certificate for
+// each host in the same order as the endpoint.
+func getRemoteHostConfigs(spec environs.CloudSpec) ([]lxdclient.Remote, error) {
+	addrs, err := parseEndpoint(spec.Endpoint)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if spec.Credential == nil {
+		return nil, errors.NotValidf("endpoint %q without credential", spec.Endpoint)
+	}
+	attrs := spec.Credential.Attributes()
+	cert := lxdclient.NewCert([]byte(attrs[credAttrClientCert]), []byte(attrs[credAttrClientKey]))
+	serverCerts := splitPEMCertificates(attrs[credAttrServerCert])
+	if len(serverCerts) != 1 && len(serverCerts) != len(addrs) {
+		return nil, errors.NotValidf(
+			"%d server certificates for %d LXD hosts",
+			len(serverCerts), len(addrs),
+		)
+	}
+
+	remotes := make([]lxdclient.Remote, len(addrs))
+	zones := make(set.Strings)
+	for i, addr := range addrs {
+		zone, _, err := net.SplitHostPort(addr)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if zones.Contains(zone) {
+			return nil, errors.NotValidf("duplicate LXD host %q", zone)
+		}
+		zones.Add(zone)
+		serverCert := serverCerts[0]
+		if len(serverCerts) > 1 {
+			serverCert = serverCerts[i]
+		}
+		remotes[i] = lxdclient.Remote{
+			Name:          zone,
+			Host:          addr,
+			Protocol:      lxdclient.LXDProtocol,
+			Cert:          &cert,
+			ServerPEMCert: serverCert,
+		}
+	}
+	return remotes, nil
+}
+
+// parseEndpoint parses the endpoint of a remote LXD cloud, which is a
+// comma-separated list of LXD host addresses, each optionally prefixed
+// with "https://". The addresses are returned in the form host:port,
+// using the default LXD port if none is specified.
+func parseEndpoint(endpoint string) ([]string, error) {
+	var addrs []string
+	for _, addr := range strings.Split(endpoint, ",") {
+		addr = strings.TrimSpace(addr)
+		if addr == "" {
+			continue
+		}
+		if strings.Contains(addr, "://") {
+			u, err := url.Parse(addr)
+			if err != nil {
+				return nil, errors.NotValidf("LXD host %q", addr)
+			}
+			if u.Scheme != "https" {
+				return nil, errors.NotValidf("LXD host %q with scheme %q", addr, u.Scheme)
+			}
+			addr = u.Host
+		}
+		if _, _, err := net.SplitHostPort(addr); err != nil {
+			// There is no port, so use the default. Any brackets
+			// around an IPv6 address are added back when joining.
+			addr = net.JoinHostPort(strings.Trim(addr, "[]"), lxdshared.DefaultPort)
+		}
+		addrs = append(addrs, addr)
+	}
+	if len(addrs) == 0 {
+		return nil, errors.NotValidf("endpoint %q", endpoint)
+	}
+	return addrs, nil
+}
+
+// splitPEMCertificates returns each of the PEM-encoded
+// certificates in the given data.
+func splitPEMCertificates(data string) []string {
+	var certs []string
+	rest := []byte(data)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		certs = append(certs, string(pem.EncodeToMemory(block)))
+	}
+	return certs
+}
+
 func getDefaultGateway(runCommand runCommandFunc) (string, error) {
 	out, err := runCommand("ip", "route", "list", "match", "0/0")
 	if err != nil {
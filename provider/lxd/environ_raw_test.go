@@ -13,6 +13,8 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/environs"
 	"github.com/juju/juju/tools/lxdclient"
 )
 
@@ -95,3 +97,79 @@ func (s *environRawSuite) TestGetRemoteConfigIPRouteCommandError(c *gc.C) {
 	_, err := getRemoteConfig(s.readFile, s.runCommand)
 	c.Assert(err, gc.ErrorMatches, `getting gateway address: buh bow`)
 }
+
+const (
+	serverCert0 = "-----BEGIN CERTIFICATE-----\nc2VydmVyLTA=\n-----END CERTIFICATE-----\n"
+	serverCert1 = "-----BEGIN CERTIFICATE-----\nc2VydmVyLTE=\n-----END CERTIFICATE-----\n"
+)
+
+func remoteCloudSpec(endpoint, serverCert string) environs.CloudSpec {
+	cred := cloud.NewCredential(cloud.CertificateAuthType, map[string]string{
+		credAttrClientCert: "client-cert-data",
+		credAttrClientKey:  "client-key-data",
+		credAttrServerCert: serverCert,
+	})
+	return environs.CloudSpec{
+		Type:       "lxd",
+		Name:       "lab",
+		Endpoint:   endpoint,
+		Credential: &cred,
+	}
+}
+
+func (s *environRawSuite) TestGetRemoteHostConfigs(c *gc.C) {
+	spec := remoteCloudSpec("https://10.0.0.2:8443,node1", serverCert0+serverCert1)
+	remotes, err := getRemoteHostConfigs(spec)
+	c.Assert(err, jc.ErrorIsNil)
+	cert := &lxdclient.Cert{
+		CertPEM: []byte("client-cert-data"),
+		KeyPEM:  []byte("client-key-data"),
+	}
+	c.Assert(remotes, jc.DeepEquals, []lxdclient.Remote{{
+		Name:          "10.0.0.2",
+		Host:          "10.0.0.2:8443",
+		Protocol:      "lxd",
+		Cert:          cert,
+		ServerPEMCert: serverCert0,
+	}, {
+		Name:          "node1",
+		Host:          "node1:8443",
+		Protocol:      "lxd",
+		Cert:          cert,
+		ServerPEMCert: serverCert1,
+	}})
+}
+
+func (s *environRawSuite) TestGetRemoteHostConfigsSharedServerCert(c *gc.C) {
+	spec := remoteCloudSpec("node0, node1", serverCert0)
+	remotes, err := getRemoteHostConfigs(spec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(remotes, gc.HasLen, 2)
+	c.Check(remotes[0].ServerPEMCert, gc.Equals, serverCert0)
+	c.Check(remotes[1].ServerPEMCert, gc.Equals, serverCert0)
+}
+
+func (s *environRawSuite) TestGetRemoteHostConfigsServerCertMismatch(c *gc.C) {
+	spec := remoteCloudSpec("node0,node1,node2", serverCert0+serverCert1)
+	_, err := getRemoteHostConfigs(spec)
+	c.Assert(err, gc.ErrorMatches, "2 server certificates for 3 LXD hosts not valid")
+}
+
+func (s *environRawSuite) TestGetRemoteHostConfigsDuplicateHost(c *gc.C) {
+	spec := remoteCloudSpec("node0:8443,node0:8444", serverCert0)
+	_, err := getRemoteHostConfigs(spec)
+	c.Assert(err, gc.ErrorMatches, `duplicate LXD host "node0" not valid`)
+}
+
+func (s *environRawSuite) TestParseEndpoint(c *gc.C) {
+	addrs, err := parseEndpoint("https://10.0.0.2:8443, 10.0.0.3,,https://[fd00::4]")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(addrs, jc.DeepEquals, []string{"10.0.0.2:8443", "10.0.0.3:8443", "[fd00::4]:8443"})
+}
+
+func (s *environRawSuite) TestParseEndpointInvalid(c *gc.C) {
+	_, err := parseEndpoint("http://10.0.0.2")
+	c.Assert(err, gc.ErrorMatches, `LXD host "http://10.0.0.2" with scheme "http" not valid`)
+	_, err = parseEndpoint(" , ")
+	c.Assert(err, gc.ErrorMatches, `endpoint " , " not valid`)
+}
@@ -39,3 +39,28 @@ func ExposeEnvClient(env *environ) lxdInstances {
 func GetImageSources(env *environ) ([]lxdclient.Remote, error) {
 	return env.getImageSources()
 }
+
+// AddEnvHost adds an LXD host, exposed as the given
+// availability zone, to the environ.
+func AddEnvHost(env *environ, zone string, client *StubClient) {
+	env.hosts = append(env.hosts, &lxdHost{
+		zone: zone,
+		raw: &rawProvider{
+			lxdCerts:     client,
+			lxdConfig:    client,
+			lxdInstances: client,
+			lxdImages:    client,
+		},
+	})
+}
+
+// AddUnreachableEnvHost adds an LXD host, exposed as the given
+// availability zone, that fails to connect with the given error.
+func AddUnreachableEnvHost(env *environ, zone string, err error) {
+	env.hosts = append(env.hosts, &lxdHost{
+		zone: zone,
+		connect: func() (*rawProvider, error) {
+			return nil, err
+		},
+	})
+}
@@ -17,6 +17,10 @@ import (
 type environInstance struct {
 	raw *lxdclient.Instance
 	env *environ
+
+	// zone is the availability zone of the LXD host
+	// running the instance.
+	zone string
 }
 
 var _ instance.Instance = (*environInstance)(nil)
@@ -56,7 +60,11 @@ func (inst *environInstance) Status() instance.InstanceStatus {
 
 // Addresses implements instance.Instance.
 func (inst *environInstance) Addresses() ([]network.Address, error) {
-	return inst.env.raw.Addresses(inst.raw.Name)
+	host, err := inst.env.hostProvider(inst.zone)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return host.Addresses(inst.raw.Name)
 }
 
 // firewall stuff
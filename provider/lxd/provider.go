@@ -28,7 +28,7 @@ func (environProvider) Open(args environs.OpenParams) (environs.Environ, error)
 		return nil, errors.Annotate(err, "validating cloud spec")
 	}
 	// TODO(ericsnow) verify prerequisites (see provider/local/prereq.go)?
-	env, err := newEnviron(args.Cloud, args.Config, newRawProvider)
+	env, err := newEnviron(args.Cloud, args.Config, newRawProviders)
 	return env, errors.Trace(err)
 }
 
@@ -69,13 +69,34 @@ func validateCloudSpec(spec environs.CloudSpec) error {
 	if err := spec.Validate(); err != nil {
 		return errors.Trace(err)
 	}
-	if spec.Endpoint != "" {
-		return errors.NotValidf("non-empty endpoint %q", spec.Endpoint)
-	}
+	authType := cloud.EmptyAuthType
 	if spec.Credential != nil {
-		if authType := spec.Credential.AuthType(); authType != cloud.EmptyAuthType {
-			return errors.NotSupportedf("%q auth-type", authType)
+		authType = spec.Credential.AuthType()
+	}
+	switch authType {
+	case cloud.EmptyAuthType:
+		// The local LXD daemon is accessed without
+		// credentials, and so has no endpoint.
+		if spec.Endpoint != "" {
+			return errors.NotValidf("endpoint %q without %q credential", spec.Endpoint, cloud.CertificateAuthType)
+		}
+	case cloud.CertificateAuthType:
+		// Remote LXD hosts are accessed using the
+		// certificate credential, at the endpoint.
+		if spec.Endpoint == "" {
+			return errors.NotValidf("%q credential without endpoint", authType)
+		}
+		if _, err := parseEndpoint(spec.Endpoint); err != nil {
+			return errors.Trace(err)
+		}
+		attrs := spec.Credential.Attributes()
+		for _, attr := range []string{credAttrClientCert, credAttrClientKey, credAttrServerCert} {
+			if attrs[attr] == "" {
+				return errors.NotValidf("credential with empty %q", attr)
+			}
 		}
+	default:
+		return errors.NotSupportedf("%q auth-type", authType)
 	}
 	return nil
 }
@@ -117,7 +117,7 @@ func (s *ProviderFunctionalSuite) TestPrepareConfig(c *gc.C) {
 }
 
 func (s *ProviderFunctionalSuite) TestPrepareConfigUnsupportedAuthType(c *gc.C) {
-	cred := cloud.NewCredential(cloud.CertificateAuthType, nil)
+	cred := cloud.NewCredential(cloud.UserPassAuthType, nil)
 	_, err := s.provider.PrepareConfig(environs.PrepareConfigParams{
 		Cloud: environs.CloudSpec{
 			Type:       "lxd",
@@ -125,10 +125,10 @@ func (s *ProviderFunctionalSuite) TestPrepareConfigUnsupportedAuthType(c *gc.C)
 			Credential: &cred,
 		},
 	})
-	c.Assert(err, gc.ErrorMatches, `validating cloud spec: "certificate" auth-type not supported`)
+	c.Assert(err, gc.ErrorMatches, `validating cloud spec: "userpass" auth-type not supported`)
 }
 
-func (s *ProviderFunctionalSuite) TestPrepareConfigNonEmptyEndpoint(c *gc.C) {
+func (s *ProviderFunctionalSuite) TestPrepareConfigEndpointWithoutCertificate(c *gc.C) {
 	_, err := s.provider.PrepareConfig(environs.PrepareConfigParams{
 		Cloud: environs.CloudSpec{
 			Type:     "lxd",
@@ -136,5 +136,56 @@ func (s *ProviderFunctionalSuite) TestPrepareConfigNonEmptyEndpoint(c *gc.C) {
 			Endpoint: "1.2.3.4",
 		},
 	})
-	c.Assert(err, gc.ErrorMatches, `validating cloud spec: non-empty endpoint "1.2.3.4" not valid`)
+	c.Assert(err, gc.ErrorMatches, `validating cloud spec: endpoint "1.2.3.4" without "certificate" credential not valid`)
+}
+
+func (s *ProviderFunctionalSuite) TestPrepareConfigCertificateWithoutEndpoint(c *gc.C) {
+	cred := remoteCredential()
+	_, err := s.provider.PrepareConfig(environs.PrepareConfigParams{
+		Cloud: environs.CloudSpec{
+			Type:       "lxd",
+			Name:       "remotehost",
+			Credential: &cred,
+		},
+	})
+	c.Assert(err, gc.ErrorMatches, `validating cloud spec: "certificate" credential without endpoint not valid`)
+}
+
+func (s *ProviderFunctionalSuite) TestPrepareConfigCertificateMissingAttribute(c *gc.C) {
+	cred := cloud.NewCredential(cloud.CertificateAuthType, map[string]string{
+		"client-cert": "client-cert-data",
+		"client-key":  "client-key-data",
+	})
+	_, err := s.provider.PrepareConfig(environs.PrepareConfigParams{
+		Cloud: environs.CloudSpec{
+			Type:       "lxd",
+			Name:       "remotehost",
+			Endpoint:   "https://10.0.0.2:8443",
+			Credential: &cred,
+		},
+	})
+	c.Assert(err, gc.ErrorMatches, `validating cloud spec: credential with empty "server-cert" not valid`)
+}
+
+func (s *ProviderFunctionalSuite) TestPrepareConfigRemote(c *gc.C) {
+	cred := remoteCredential()
+	cfg, err := s.provider.PrepareConfig(environs.PrepareConfigParams{
+		Cloud: environs.CloudSpec{
+			Type:       "lxd",
+			Name:       "remotehost",
+			Endpoint:   "https://10.0.0.2:8443, https://10.0.0.3:8443",
+			Credential: &cred,
+		},
+		Config: s.Config,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg, gc.NotNil)
+}
+
+func remoteCredential() cloud.Credential {
+	return cloud.NewCredential(cloud.CertificateAuthType, map[string]string{
+		"client-cert": "client-cert-data",
+		"client-key":  "client-key-data",
+		"server-cert": "server-cert-data",
+	})
 }
@@ -23,6 +23,7 @@ import (
 	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
+	"github.com/juju/juju/provider/lxd/lxdnames"
 	"github.com/juju/juju/testing"
 	coretools "github.com/juju/juju/tools"
 	"github.com/juju/juju/tools/lxdclient"
@@ -272,7 +273,9 @@ func (s *BaseSuiteUnpatched) NewRawInstance(c *gc.C, name string) *lxdclient.Ins
 
 func (s *BaseSuiteUnpatched) NewInstance(c *gc.C, name string) *environInstance {
 	raw := s.NewRawInstance(c, name)
-	return newInstance(raw, s.Env)
+	inst := newInstance(raw, s.Env)
+	inst.zone = lxdnames.DefaultRegion
+	return inst
 }
 
 func (s *BaseSuiteUnpatched) IsRunningLocally(c *gc.C) bool {
@@ -314,6 +317,7 @@ func (s *BaseSuite) SetUpTest(c *gc.C) {
 		lxdImages:    s.Client,
 		Firewaller:   s.Firewaller,
 	}
+	s.Env.hosts = []*lxdHost{{zone: lxdnames.DefaultRegion, raw: s.Env.raw}}
 	s.Env.base = s.Common
 }
 
@@ -14,12 +14,14 @@ import (
 
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/network"
+	"github.com/juju/juju/provider/common"
 )
 
 var unsupportedConstraints = []string{
 	constraints.CpuPower,
 	constraints.InstanceType,
 	constraints.VirtType,
+	constraints.PlacementGroup,
 }
 
 // ConstraintsValidator is defined on the Environs interface.
@@ -31,6 +33,15 @@ func (environ *maasEnviron) ConstraintsValidator() (constraints.Validator, error
 		return nil, err
 	}
 	validator.RegisterVocabulary(constraints.Arch, supportedArches)
+	zoneNames, err := common.AvailabilityZoneNames(environ)
+	if errors.IsNotImplemented(err) {
+		// Old MAAS servers do not support zones.
+		validator.RegisterUnsupported([]string{constraints.Zones})
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	} else {
+		validator.RegisterVocabulary(constraints.Zones, zoneNames)
+	}
 	return validator, nil
 }
 
@@ -560,8 +560,11 @@ func (env *maasEnviron) PrecheckInstance(series string, cons constraints.Value,
 	if placement == "" {
 		return nil
 	}
-	_, err := env.parsePlacement(placement)
-	return err
+	p, err := env.parsePlacement(placement)
+	if err != nil {
+		return err
+	}
+	return common.ValidateZoneConstraint(p.zoneName, cons)
 }
 
 const (
@@ -840,9 +843,15 @@ func (environ *maasEnviron) StartInstance(args environs.StartInstanceParams) (
 		}
 		switch {
 		case placement.zoneName != "":
+			if err := common.ValidateZoneConstraint(placement.zoneName, args.Constraints); err != nil {
+				return nil, errors.Trace(err)
+			}
 			availabilityZones = append(availabilityZones, placement.zoneName)
 		default:
 			nodeName = placement.nodeName
+			if args.Constraints.HasZones() {
+				availabilityZones = append(availabilityZones, *args.Constraints.Zones...)
+			}
 		}
 	}
 
@@ -867,6 +876,10 @@ func (environ *maasEnviron) StartInstance(args environs.StartInstanceParams) (
 		} else if err != nil {
 			return nil, errors.Annotate(err, "cannot get availability zone allocations")
 		} else if len(zoneInstances) > 0 {
+			zoneInstances, err = common.FilterZoneAllocations(zoneInstances, args.Constraints)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
 			for _, z := range zoneInstances {
 				availabilityZones = append(availabilityZones, z.ZoneName)
 			}
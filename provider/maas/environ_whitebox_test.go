@@ -502,6 +502,29 @@ func (suite *environSuite) TestConstraintsValidatorVocab(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "invalid constraint value: arch=ppc64el\nvalid values are: \\[amd64 armhf\\]")
 }
 
+func (suite *environSuite) TestConstraintsValidatorZones(c *gc.C) {
+	suite.testMAASObject.TestServer.AddBootImage("uuid-0", `{"architecture": "amd64", "release": "trusty"}`)
+	suite.testMAASObject.TestServer.AddZone("zone1", "the grass is greener in zone1")
+	env := suite.makeEnviron()
+	validator, err := env.ConstraintsValidator()
+	c.Assert(err, jc.ErrorIsNil)
+	unsupported, err := validator.Validate(constraints.MustParse("zones=zone1"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unsupported, gc.HasLen, 0)
+	_, err = validator.Validate(constraints.MustParse("zones=zone2"))
+	c.Assert(err, gc.ErrorMatches, "invalid constraint value: zones=zone2\nvalid values are: \\[zone1\\]")
+}
+
+func (suite *environSuite) TestConstraintsValidatorZonesUnsupported(c *gc.C) {
+	suite.testMAASObject.TestServer.AddBootImage("uuid-0", `{"architecture": "amd64", "release": "trusty"}`)
+	env := suite.makeEnviron()
+	validator, err := env.ConstraintsValidator()
+	c.Assert(err, jc.ErrorIsNil)
+	unsupported, err := validator.Validate(constraints.MustParse("zones=zone1"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unsupported, jc.SameContents, []string{"zones"})
+}
+
 func (suite *environSuite) TestSupportsNetworking(c *gc.C) {
 	env := suite.makeEnviron()
 	_, supported := environs.SupportsNetworking(env)
@@ -725,6 +748,17 @@ func (s *environSuite) TestPrecheckInstanceAvailZoneUnknown(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `invalid availability zone "zone2"`)
 }
 
+func (s *environSuite) TestPrecheckInstanceAvailZoneConstraint(c *gc.C) {
+	s.testMAASObject.TestServer.AddZone("zone1", "the grass is greener in zone1")
+	s.testMAASObject.TestServer.AddZone("zone2", "the grass is browner in zone2")
+	env := s.makeEnviron()
+	cons := constraints.MustParse("zones=zone2")
+	err := env.PrecheckInstance(series.LatestLts(), cons, "zone=zone1")
+	c.Assert(err, gc.ErrorMatches, `placement zone "zone1" does not satisfy constraint zones=\[zone2\]`)
+	err = env.PrecheckInstance(series.LatestLts(), cons, "zone=zone2")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *environSuite) TestPrecheckInstanceAvailZonesUnsupported(c *gc.C) {
 	env := s.makeEnviron()
 	placement := "zone=test-unknown"
@@ -762,6 +796,36 @@ func (s *environSuite) TestStartInstanceAvailZoneUnknown(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `invalid availability zone "test-unknown"`)
 }
 
+func (s *environSuite) TestStartInstanceZonesConstraint(c *gc.C) {
+	s.newNode(c, "thenode1", "host1", map[string]interface{}{"zone": "test-available"})
+	s.addSubnet(c, 1, 1, "thenode1")
+	s.testMAASObject.TestServer.AddZone("test-available", "description")
+	s.testMAASObject.TestServer.AddZone("test-other", "description")
+	env := s.bootstrap(c)
+	params := environs.StartInstanceParams{
+		ControllerUUID: s.controllerUUID,
+		Constraints:    constraints.MustParse("zones=test-available"),
+	}
+	result, err := testing.StartInstanceWithParams(env, "1", params)
+	c.Assert(err, jc.ErrorIsNil)
+	zone, err := result.Instance.(maasInstance).zone()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(zone, gc.Equals, "test-available")
+}
+
+func (s *environSuite) TestStartInstanceAvailZoneConstraintMismatch(c *gc.C) {
+	s.testMAASObject.TestServer.AddZone("test-available", "description")
+	s.testMAASObject.TestServer.AddZone("test-other", "description")
+	env := s.bootstrap(c)
+	params := environs.StartInstanceParams{
+		ControllerUUID: s.controllerUUID,
+		Placement:      "zone=test-available",
+		Constraints:    constraints.MustParse("zones=test-other"),
+	}
+	_, err := testing.StartInstanceWithParams(env, "1", params)
+	c.Assert(err, gc.ErrorMatches, `placement zone "test-available" does not satisfy constraint zones=\[test-other\]`)
+}
+
 func (s *environSuite) testStartInstanceAvailZone(c *gc.C, zone string) (instance.Instance, error) {
 	env := s.bootstrap(c)
 	params := environs.StartInstanceParams{ControllerUUID: s.controllerUUID, Placement: "zone=" + zone}
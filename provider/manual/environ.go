@@ -283,6 +283,8 @@ var unsupportedConstraints = []string{
 	constraints.InstanceType,
 	constraints.Tags,
 	constraints.VirtType,
+	constraints.Zones,
+	constraints.PlacementGroup,
 }
 
 // ConstraintsValidator is defined on the Environs interface.
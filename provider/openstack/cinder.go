@@ -4,12 +4,20 @@
 package openstack
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
 	"math"
+	"net/http"
 	"net/url"
+	"path"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/juju/errors"
+	"github.com/juju/schema"
 	"github.com/juju/utils"
 	"gopkg.in/goose.v1/cinder"
 	"gopkg.in/goose.v1/identity"
@@ -22,6 +30,12 @@ import (
 
 const (
 	CinderProviderType = storage.ProviderType("cinder")
+
+	// CinderVolumeType is the pool attribute specifying the Cinder
+	// volume type to create volumes with. Encryption is a property
+	// of the volume type, so encrypted pools must specify one.
+	CinderVolumeType = "volume-type"
+
 	// autoAssignedMountPoint specifies the value to pass in when
 	// you'd like Cinder to automatically assign a mount point.
 	autoAssignedMountPoint = ""
@@ -55,7 +69,7 @@ func (env *Environ) cinderProvider() (*cinderProvider, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	return &cinderProvider{storageAdapter, envName, modelUUID}, nil
+	return &cinderProvider{storageAdapter, envName, modelUUID, env}, nil
 }
 
 var newOpenstackStorage = func(env *Environ) (OpenstackStorage, error) {
@@ -72,9 +86,14 @@ var newOpenstackStorage = func(env *Environ) (OpenstackStorage, error) {
 		return nil, errors.Annotate(err, "getting volume endpoint")
 	}
 
+	httpClient := utils.GetHTTPClient(utils.VerifySSLHostnames)
+	if !env.Config().SSLHostnameVerification() {
+		httpClient = utils.GetNonValidatingHTTPClient()
+	}
 	return &openstackStorageAdapter{
 		cinderClient{cinder.Basic(endpointUrl, authClient.TenantId(), authClient.Token)},
 		novaClient{envNovaClient},
+		cinderVolumeActions{endpointUrl, authClient.Token, httpClient},
 	}, nil
 }
 
@@ -82,6 +101,14 @@ type cinderProvider struct {
 	storageAdapter OpenstackStorage
 	envName        string
 	modelUUID      string
+	zoneNamer      instanceZoneNamer
+}
+
+// instanceZoneNamer is the interface used by the Cinder volume source
+// to determine the availability zones of the instances that volumes
+// are to be attached to, so the volumes can be created alongside them.
+type instanceZoneNamer interface {
+	InstanceAvailabilityZoneNames(ids []instance.Id) ([]string, error)
 }
 
 var _ storage.Provider = (*cinderProvider)(nil)
@@ -100,6 +127,7 @@ func (p *cinderProvider) VolumeSource(providerConfig *storage.Config) (storage.V
 		storageAdapter: p.storageAdapter,
 		envName:        p.envName,
 		modelUUID:      p.modelUUID,
+		zoneNamer:      p.zoneNamer,
 	}
 	return source, nil
 }
@@ -109,6 +137,11 @@ func (p *cinderProvider) FilesystemSource(providerConfig *storage.Config) (stora
 	return nil, errors.NotSupportedf("filesystems")
 }
 
+// SupportsVolumeEncryption implements storage.VolumeEncryptionProvider.
+func (p *cinderProvider) SupportsVolumeEncryption() bool {
+	return true
+}
+
 // Supports implements storage.Provider.
 func (p *cinderProvider) Supports(kind storage.StorageKind) bool {
 	switch kind {
@@ -123,11 +156,52 @@ func (s *cinderProvider) Scope() storage.Scope {
 	return storage.ScopeEnviron
 }
 
+var cinderConfigFields = schema.Fields{
+	CinderVolumeType:        schema.String(),
+	storage.ConfigEncrypted: schema.Bool(),
+	storage.ConfigKMSKeyId:  schema.String(),
+}
+
+var cinderConfigChecker = schema.FieldMap(
+	cinderConfigFields,
+	schema.Defaults{
+		CinderVolumeType:        "",
+		storage.ConfigEncrypted: false,
+		storage.ConfigKMSKeyId:  "",
+	},
+)
+
+type cinderConfig struct {
+	volumeType string
+}
+
+func newCinderConfig(attrs map[string]interface{}) (*cinderConfig, error) {
+	out, err := cinderConfigChecker.Coerce(attrs, nil)
+	if err != nil {
+		return nil, errors.Annotate(err, "validating Cinder storage config")
+	}
+	coerced := out.(map[string]interface{})
+	cinderConfig := &cinderConfig{
+		volumeType: coerced[CinderVolumeType].(string),
+	}
+	if coerced[storage.ConfigKMSKeyId].(string) != "" {
+		return nil, errors.NotSupportedf(
+			"%s (encryption keys are managed by the Cinder volume type)",
+			storage.ConfigKMSKeyId,
+		)
+	}
+	if coerced[storage.ConfigEncrypted].(bool) && cinderConfig.volumeType == "" {
+		return nil, errors.Errorf("encrypted volumes require an encrypted %s", CinderVolumeType)
+	}
+	return cinderConfig, nil
+}
+
 // ValidateConfig implements storage.Provider.
 func (p *cinderProvider) ValidateConfig(cfg *storage.Config) error {
 	// TODO(axw) 2015-05-01 #1450737
 	// Reject attempts to create non-persistent volumes.
-	return nil
+	_, err := newCinderConfig(cfg.Attrs())
+	return errors.Trace(err)
 }
 
 // Dynamic implements storage.Provider.
@@ -144,9 +218,12 @@ type cinderVolumeSource struct {
 	storageAdapter OpenstackStorage
 	envName        string // non unique, informational only
 	modelUUID      string
+	zoneNamer      instanceZoneNamer
 }
 
 var _ storage.VolumeSource = (*cinderVolumeSource)(nil)
+var _ storage.VolumeResizer = (*cinderVolumeSource)(nil)
+var _ storage.VolumeSnapshotter = (*cinderVolumeSource)(nil)
 
 // CreateVolumes implements storage.VolumeSource.
 func (s *cinderVolumeSource) CreateVolumes(args []storage.VolumeParams) ([]storage.CreateVolumesResult, error) {
@@ -163,6 +240,10 @@ func (s *cinderVolumeSource) CreateVolumes(args []storage.VolumeParams) ([]stora
 }
 
 func (s *cinderVolumeSource) createVolume(arg storage.VolumeParams) (*storage.Volume, error) {
+	cinderConfig, err := newCinderConfig(arg.Attributes)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	var metadata interface{}
 	if len(arg.ResourceTags) > 0 {
 		metadata = arg.ResourceTags
@@ -170,11 +251,12 @@ func (s *cinderVolumeSource) createVolume(arg storage.VolumeParams) (*storage.Vo
 	cinderVolume, err := s.storageAdapter.CreateVolume(cinder.CreateVolumeVolumeParams{
 		// The Cinder documentation incorrectly states the
 		// size parameter is in GB. It is actually GiB.
-		Size: int(math.Ceil(float64(arg.Size / 1024))),
-		Name: resourceName(arg.Tag, s.envName),
-		// TODO(axw) use the AZ of the initially attached machine.
-		AvailabilityZone: "",
+		Size:             int(math.Ceil(float64(arg.Size / 1024))),
+		Name:             resourceName(arg.Tag, s.envName),
+		VolumeType:       cinderConfig.volumeType,
+		AvailabilityZone: s.attachmentZone(arg),
 		Metadata:         metadata,
+		SnapshotId:       arg.SnapshotId,
 	})
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -197,6 +279,27 @@ func (s *cinderVolumeSource) createVolume(arg storage.VolumeParams) (*storage.Vo
 	return &storage.Volume{arg.Tag, cinderToJujuVolumeInfo(cinderVolume)}, nil
 }
 
+// attachmentZone returns the availability zone of the instance that the
+// volume will initially be attached to, so the volume is created in the
+// same zone. If the zone cannot be determined, the empty string is
+// returned and Cinder will choose the zone.
+func (s *cinderVolumeSource) attachmentZone(arg storage.VolumeParams) string {
+	if s.zoneNamer == nil || arg.Attachment == nil || arg.Attachment.InstanceId == "" {
+		return ""
+	}
+	zones, err := s.zoneNamer.InstanceAvailabilityZoneNames(
+		[]instance.Id{arg.Attachment.InstanceId},
+	)
+	if err != nil {
+		logger.Warningf(
+			"cannot determine availability zone of instance %q: %v",
+			arg.Attachment.InstanceId, err,
+		)
+		return ""
+	}
+	return zones[0]
+}
+
 // ListVolumes is specified on the storage.VolumeSource interface.
 func (s *cinderVolumeSource) ListVolumes() ([]string, error) {
 	volumes, err := listVolumes(s.storageAdapter, func(v *cinder.Volume) bool {
@@ -333,6 +436,119 @@ func (s *cinderVolumeSource) ValidateVolumeParams(params storage.VolumeParams) e
 	return nil
 }
 
+// ResizeVolumes implements storage.VolumeResizer.
+func (s *cinderVolumeSource) ResizeVolumes(args []storage.ResizeVolumeParams) ([]storage.ResizeVolumesResult, error) {
+	results := make([]storage.ResizeVolumesResult, len(args))
+	for i, arg := range args {
+		size, err := s.resizeVolume(arg)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "resizing volume %s", arg.Tag.Id())
+			continue
+		}
+		results[i].Size = size
+	}
+	return results, nil
+}
+
+func (s *cinderVolumeSource) resizeVolume(arg storage.ResizeVolumeParams) (uint64, error) {
+	// The Cinder API has a minimum volume size of 1GB, and
+	// sizes are expressed in whole gigabytes.
+	newSize := int(math.Ceil(float64(arg.Size) / 1024))
+	volume, err := s.storageAdapter.GetVolume(arg.VolumeId)
+	if err != nil {
+		return 0, errors.Annotate(err, "getting volume")
+	}
+	if newSize < volume.Size {
+		return 0, errors.Errorf(
+			"cannot shrink volume from %dGB to %dGB",
+			volume.Size, newSize,
+		)
+	}
+	if newSize == volume.Size {
+		return uint64(volume.Size * 1024), nil
+	}
+	if err := s.storageAdapter.ExtendVolume(arg.VolumeId, newSize); err != nil {
+		return 0, errors.Trace(err)
+	}
+	volume, err = waitVolume(s.storageAdapter, arg.VolumeId, func(v *cinder.Volume) (bool, error) {
+		switch v.Status {
+		case volumeStatusAvailable, volumeStatusInUse:
+			return v.Size >= newSize, nil
+		case volumeStatusError, "error_extending":
+			return false, errors.New("volume entered error state while extending")
+		}
+		return false, nil
+	})
+	if err != nil {
+		return 0, errors.Annotate(err, "waiting for volume to be extended")
+	}
+	return uint64(volume.Size * 1024), nil
+}
+
+// CreateVolumeSnapshots implements storage.VolumeSnapshotter.
+func (s *cinderVolumeSource) CreateVolumeSnapshots(args []storage.VolumeSnapshotParams) ([]storage.CreateVolumeSnapshotsResult, error) {
+	results := make([]storage.CreateVolumeSnapshotsResult, len(args))
+	for i, arg := range args {
+		snapshot, err := s.createVolumeSnapshot(arg)
+		if err != nil {
+			results[i].Error = errors.Annotatef(
+				err, "creating snapshot %s of volume %s", arg.Id, arg.Volume.Id(),
+			)
+			continue
+		}
+		results[i].VolumeSnapshot = snapshot
+	}
+	return results, nil
+}
+
+func (s *cinderVolumeSource) createVolumeSnapshot(arg storage.VolumeSnapshotParams) (*storage.VolumeSnapshot, error) {
+	// The snapshot name identifies the Juju snapshot; a snapshot
+	// taken previously for the same request is reused, rather
+	// than taking another.
+	name := fmt.Sprintf("%s-snapshot-%s", resourceName(arg.Volume, s.envName), arg.Id)
+	snapshot, err := s.findVolumeSnapshot(arg.VolumeId, name)
+	if errors.IsNotFound(err) {
+		snapshot, err = s.storageAdapter.CreateSnapshot(cinder.CreateSnapshotSnapshotParams{
+			Name:     name,
+			VolumeId: arg.VolumeId,
+			// Volumes are snapshotted while attached, so
+			// the snapshot must be forced.
+			Force: true,
+		})
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	} else if err != nil {
+		return nil, errors.Annotate(err, "finding existing snapshot")
+	} else {
+		logger.Debugf("found existing snapshot %q for snapshot request %s", snapshot.ID, arg.Id)
+	}
+	return &storage.VolumeSnapshot{
+		Id:     arg.Id,
+		Volume: arg.Volume,
+		VolumeSnapshotInfo: storage.VolumeSnapshotInfo{
+			SnapshotId: snapshot.ID,
+			Size:       uint64(snapshot.Size * 1024),
+		},
+	}, nil
+}
+
+// findVolumeSnapshot returns the snapshot of the specified volume
+// with the given name, which is not in an error state.
+func (s *cinderVolumeSource) findVolumeSnapshot(volumeId, name string) (*cinder.Snapshot, error) {
+	snapshots, err := s.storageAdapter.GetSnapshotsDetail()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, snapshot := range snapshots {
+		if snapshot.Name == name && snapshot.VolumeID == volumeId && snapshot.Status != "error" {
+			snapshot := snapshot
+			return &snapshot, nil
+		}
+	}
+	return nil, errors.NotFoundf("snapshot %q of volume %q", name, volumeId)
+}
+
 // AttachVolumes implements storage.VolumeSource.
 func (s *cinderVolumeSource) AttachVolumes(args []storage.VolumeAttachmentParams) ([]storage.AttachVolumesResult, error) {
 	results := make([]storage.AttachVolumesResult, len(args))
@@ -463,6 +679,9 @@ type OpenstackStorage interface {
 	GetVolumesDetail() ([]cinder.Volume, error)
 	DeleteVolume(volumeId string) error
 	CreateVolume(cinder.CreateVolumeVolumeParams) (*cinder.Volume, error)
+	ExtendVolume(volumeId string, newSize int) error
+	CreateSnapshot(cinder.CreateSnapshotSnapshotParams) (*cinder.Snapshot, error)
+	GetSnapshotsDetail() ([]cinder.Snapshot, error)
 	AttachVolume(serverId, volumeId, mountPoint string) (*nova.VolumeAttachment, error)
 	DetachVolume(serverId, attachmentId string) error
 	ListVolumeAttachments(serverId string) ([]nova.VolumeAttachment, error)
@@ -489,6 +708,7 @@ func getVolumeEndpointURL(client endpointResolver, region string) (*url.URL, err
 type openstackStorageAdapter struct {
 	cinderClient
 	novaClient
+	volumeActions cinderVolumeActions
 }
 
 type cinderClient struct {
@@ -525,3 +745,74 @@ func (ga *openstackStorageAdapter) GetVolume(volumeId string) (*cinder.Volume, e
 	}
 	return &resp.Volume, nil
 }
+
+// CreateSnapshot is part of the OpenstackStorage interface.
+func (ga *openstackStorageAdapter) CreateSnapshot(args cinder.CreateSnapshotSnapshotParams) (*cinder.Snapshot, error) {
+	resp, err := ga.cinderClient.CreateSnapshot(args)
+	if err != nil {
+		return nil, err
+	}
+	return &resp.Snapshot, nil
+}
+
+// GetSnapshotsDetail is part of the OpenstackStorage interface.
+func (ga *openstackStorageAdapter) GetSnapshotsDetail() ([]cinder.Snapshot, error) {
+	resp, err := ga.cinderClient.GetSnapshotsDetail()
+	if err != nil {
+		return nil, err
+	}
+	return resp.Snapshots, nil
+}
+
+// ExtendVolume is part of the OpenstackStorage interface.
+func (ga *openstackStorageAdapter) ExtendVolume(volumeId string, newSize int) error {
+	return ga.volumeActions.extend(volumeId, newSize)
+}
+
+// cinderVolumeActions issues requests to the Cinder volume actions
+// API, which is not supported by the goose cinder client.
+type cinderVolumeActions struct {
+	endpoint *url.URL
+	token    func() string
+	client   *http.Client
+}
+
+// extend grows the volume with the specified ID to newSize GiB.
+func (a cinderVolumeActions) extend(volumeId string, newSize int) error {
+	var body struct {
+		Extend struct {
+			NewSize int `json:"new_size"`
+		} `json:"os-extend"`
+	}
+	body.Extend.NewSize = newSize
+	return a.do(volumeId, body)
+}
+
+func (a cinderVolumeActions) do(volumeId string, action interface{}) error {
+	data, err := json.Marshal(action)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	actionURL := *a.endpoint
+	actionURL.Path = path.Join(actionURL.Path, "volumes", volumeId, "action")
+	req, err := http.NewRequest("POST", actionURL.String(), bytes.NewReader(data))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("X-Auth-Token", a.token())
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusAccepted {
+		message, _ := ioutil.ReadAll(resp.Body)
+		return errors.Errorf(
+			"volume action failed: %s: %s",
+			resp.Status, strings.TrimSpace(string(message)),
+		)
+	}
+	return nil
+}
@@ -77,7 +77,27 @@ var (
 func NewCinderVolumeSource(s OpenstackStorage) storage.VolumeSource {
 	const envName = "testenv"
 	modelUUID := testing.ModelTag.Id()
-	return &cinderVolumeSource{s, envName, modelUUID}
+	return &cinderVolumeSource{s, envName, modelUUID, nil}
+}
+
+// NewCinderVolumeSourceWithZones returns a Cinder volume source that
+// looks up attached instances' availability zones with the given function.
+func NewCinderVolumeSourceWithZones(
+	s OpenstackStorage,
+	zoneNames func([]instance.Id) ([]string, error),
+) storage.VolumeSource {
+	modelUUID := testing.ModelTag.Id()
+	return &cinderVolumeSource{s, "testenv", modelUUID, instanceZoneNamerFunc(zoneNames)}
+}
+
+type instanceZoneNamerFunc func([]instance.Id) ([]string, error)
+
+func (f instanceZoneNamerFunc) InstanceAvailabilityZoneNames(ids []instance.Id) ([]string, error) {
+	return f(ids)
+}
+
+func NewCinderProvider(s OpenstackStorage) storage.Provider {
+	return &cinderProvider{s, "testenv", testing.ModelTag.Id(), nil}
 }
 
 // Include images for arches currently supported.  i386 is no longer
@@ -485,6 +505,7 @@ func ResolveNetwork(e environs.Environ, networkName string) (string, error) {
 }
 
 var PortsToRuleInfo = portsToRuleInfo
+var RulesToRuleInfo = rulesToRuleInfo
 var RuleMatchesPortRange = ruleMatchesPortRange
 
 var MakeServiceURL = &makeServiceURL
@@ -12,6 +12,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/retry"
 	"github.com/juju/utils/clock"
+	"github.com/juju/utils/set"
 	gooseerrors "gopkg.in/goose.v1/errors"
 	"gopkg.in/goose.v1/nova"
 
@@ -65,6 +66,34 @@ type Firewaller interface {
 	InstancePorts(inst instance.Instance, machineId string) ([]network.PortRange, error)
 }
 
+// IngressRuleFirewaller is implemented by Firewallers which can
+// restrict ingress to opened ports to particular source CIDRs.
+type IngressRuleFirewaller interface {
+	// OpenIngressRules opens the given ingress rules for the whole
+	// environment.
+	OpenIngressRules(rules []network.IngressRule) error
+
+	// CloseIngressRules closes the given ingress rules for the whole
+	// environment.
+	CloseIngressRules(rules []network.IngressRule) error
+
+	// IngressRules returns the ingress rules opened for the whole
+	// environment.
+	IngressRules() ([]network.IngressRule, error)
+
+	// OpenInstanceIngressRules opens the given ingress rules for the
+	// specified instance.
+	OpenInstanceIngressRules(inst instance.Instance, machineId string, rules []network.IngressRule) error
+
+	// CloseInstanceIngressRules closes the given ingress rules for
+	// the specified instance.
+	CloseInstanceIngressRules(inst instance.Instance, machineId string, rules []network.IngressRule) error
+
+	// InstanceIngressRules returns the ingress rules opened for the
+	// specified instance.
+	InstanceIngressRules(inst instance.Instance, machineId string) ([]network.IngressRule, error)
+}
+
 type firewallerFactory struct {
 }
 
@@ -383,6 +412,78 @@ func (c *defaultFirewaller) InstancePorts(inst instance.Instance, machineId stri
 	return portRanges, nil
 }
 
+// OpenIngressRules implements IngressRuleFirewaller interface.
+func (c *defaultFirewaller) OpenIngressRules(rules []network.IngressRule) error {
+	if c.environ.Config().FirewallMode() != config.FwGlobal {
+		return fmt.Errorf("invalid firewall mode %q for opening ports on model",
+			c.environ.Config().FirewallMode())
+	}
+	if err := c.openRulesInGroup(c.globalGroupRegexp(), rules); err != nil {
+		return err
+	}
+	logger.Infof("opened ports in global group: %v", rules)
+	return nil
+}
+
+// CloseIngressRules implements IngressRuleFirewaller interface.
+func (c *defaultFirewaller) CloseIngressRules(rules []network.IngressRule) error {
+	if c.environ.Config().FirewallMode() != config.FwGlobal {
+		return fmt.Errorf("invalid firewall mode %q for closing ports on model",
+			c.environ.Config().FirewallMode())
+	}
+	if err := c.closeRulesInGroup(c.globalGroupRegexp(), rules); err != nil {
+		return err
+	}
+	logger.Infof("closed ports in global group: %v", rules)
+	return nil
+}
+
+// IngressRules implements IngressRuleFirewaller interface.
+func (c *defaultFirewaller) IngressRules() ([]network.IngressRule, error) {
+	if c.environ.Config().FirewallMode() != config.FwGlobal {
+		return nil, fmt.Errorf("invalid firewall mode %q for retrieving ports from model",
+			c.environ.Config().FirewallMode())
+	}
+	return c.rulesInGroup(c.globalGroupRegexp())
+}
+
+// OpenInstanceIngressRules implements IngressRuleFirewaller interface.
+func (c *defaultFirewaller) OpenInstanceIngressRules(inst instance.Instance, machineId string, rules []network.IngressRule) error {
+	if c.environ.Config().FirewallMode() != config.FwInstance {
+		return fmt.Errorf("invalid firewall mode %q for opening ports on instance",
+			c.environ.Config().FirewallMode())
+	}
+	nameRegexp := c.machineGroupRegexp(machineId)
+	if err := c.openRulesInGroup(nameRegexp, rules); err != nil {
+		return err
+	}
+	logger.Infof("opened ports in security group %s-%s: %v", c.environ.Config().UUID(), machineId, rules)
+	return nil
+}
+
+// CloseInstanceIngressRules implements IngressRuleFirewaller interface.
+func (c *defaultFirewaller) CloseInstanceIngressRules(inst instance.Instance, machineId string, rules []network.IngressRule) error {
+	if c.environ.Config().FirewallMode() != config.FwInstance {
+		return fmt.Errorf("invalid firewall mode %q for closing ports on instance",
+			c.environ.Config().FirewallMode())
+	}
+	nameRegexp := c.machineGroupRegexp(machineId)
+	if err := c.closeRulesInGroup(nameRegexp, rules); err != nil {
+		return err
+	}
+	logger.Infof("closed ports in security group %s-%s: %v", c.environ.Config().UUID(), machineId, rules)
+	return nil
+}
+
+// InstanceIngressRules implements IngressRuleFirewaller interface.
+func (c *defaultFirewaller) InstanceIngressRules(inst instance.Instance, machineId string) ([]network.IngressRule, error) {
+	if c.environ.Config().FirewallMode() != config.FwInstance {
+		return nil, fmt.Errorf("invalid firewall mode %q for retrieving ports from instance",
+			c.environ.Config().FirewallMode())
+	}
+	return c.rulesInGroup(c.machineGroupRegexp(machineId))
+}
+
 func (c *defaultFirewaller) matchingGroup(nameRegExp string) (nova.SecurityGroup, error) {
 	re, err := regexp.Compile(nameRegExp)
 	if err != nil {
@@ -414,14 +515,20 @@ func (c *defaultFirewaller) openPortsInGroup(nameRegExp string, portRanges []net
 	rules := portsToRuleInfo(group.Id, portRanges)
 	for _, rule := range rules {
 		_, err := novaclient.CreateSecurityGroupRule(rule)
-		if err != nil {
-			// TODO: if err is not rule already exists, raise?
-			logger.Debugf("error creating security group rule: %v", err.Error())
+		if err != nil && !isDuplicateRuleError(err) {
+			return errors.Annotate(err, "creating security group rule")
 		}
 	}
 	return nil
 }
 
+// isDuplicateRuleError reports whether the given error, returned when
+// creating a security group rule, means that the rule already exists.
+// Nova reports this as a bad request rather than a conflict.
+func isDuplicateRuleError(err error) bool {
+	return gooseerrors.IsDuplicateValue(err) || strings.Contains(err.Error(), "already exists")
+}
+
 // ruleMatchesPortRange checks if supplied nova security group rule matches the port range
 func ruleMatchesPortRange(rule nova.SecurityGroupRule, portRange network.PortRange) bool {
 	if rule.IPProtocol == nil || rule.FromPort == nil || rule.ToPort == nil {
@@ -473,6 +580,89 @@ func (c *defaultFirewaller) portsInGroup(nameRegexp string) (portRanges []networ
 	return portRanges, nil
 }
 
+func (c *defaultFirewaller) openRulesInGroup(nameRegExp string, rules []network.IngressRule) error {
+	group, err := c.matchingGroup(nameRegExp)
+	if err != nil {
+		return err
+	}
+	novaclient := c.environ.nova()
+	for _, ruleInfo := range rulesToRuleInfo(group.Id, rules) {
+		_, err := novaclient.CreateSecurityGroupRule(ruleInfo)
+		if err != nil && !isDuplicateRuleError(err) {
+			return errors.Annotate(err, "creating security group rule")
+		}
+	}
+	return nil
+}
+
+// ruleCIDR returns the source CIDR of the supplied nova security
+// group rule, or "" if the rule's source is another group.
+func ruleCIDR(rule nova.SecurityGroupRule) string {
+	return rule.IPRange["cidr"]
+}
+
+func (c *defaultFirewaller) closeRulesInGroup(nameRegExp string, rules []network.IngressRule) error {
+	if len(rules) == 0 {
+		return nil
+	}
+	group, err := c.matchingGroup(nameRegExp)
+	if err != nil {
+		return err
+	}
+	novaclient := c.environ.nova()
+	for _, rule := range rules {
+		cidrs := set.NewStrings(rule.SourceCIDRs...)
+		if cidrs.IsEmpty() {
+			cidrs.Add(network.AllSourceCIDR)
+		}
+		for _, p := range group.Rules {
+			if !ruleMatchesPortRange(p, rule.PortRange) || !cidrs.Contains(ruleCIDR(p)) {
+				continue
+			}
+			if err := novaclient.DeleteSecurityGroupRule(p.Id); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// rulesInGroup returns the ingress rules of the matching group, with
+// the nova rules for each port range combined into a single rule.
+func (c *defaultFirewaller) rulesInGroup(nameRegexp string) ([]network.IngressRule, error) {
+	group, err := c.matchingGroup(nameRegexp)
+	if err != nil {
+		return nil, err
+	}
+	var portRanges []network.PortRange
+	sourceCIDRs := make(map[network.PortRange][]string)
+	for _, p := range group.Rules {
+		cidr := ruleCIDR(p)
+		if p.IPProtocol == nil || p.FromPort == nil || p.ToPort == nil || cidr == "" {
+			continue
+		}
+		portRange := network.PortRange{
+			Protocol: *p.IPProtocol,
+			FromPort: *p.FromPort,
+			ToPort:   *p.ToPort,
+		}
+		if _, ok := sourceCIDRs[portRange]; !ok {
+			portRanges = append(portRanges, portRange)
+		}
+		sourceCIDRs[portRange] = append(sourceCIDRs[portRange], cidr)
+	}
+	rules := make([]network.IngressRule, 0, len(portRanges))
+	for _, portRange := range portRanges {
+		rule, err := network.NewIngressRule(portRange, sourceCIDRs[portRange]...)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		rules = append(rules, rule)
+	}
+	network.SortIngressRules(rules)
+	return rules, nil
+}
+
 func (c *defaultFirewaller) globalGroupName(controllerUUID string) string {
 	return fmt.Sprintf("%s-global", c.jujuGroupName(controllerUUID))
 }
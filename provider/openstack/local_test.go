@@ -703,6 +703,36 @@ func (s *localServerSuite) TestInstanceStatus(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *localServerSuite) TestOpenIngressRulesError(c *gc.C) {
+	inst, _ := testing.AssertStartInstance(c, s.env, s.ControllerUUID, "100")
+	defer func() {
+		err := s.env.StopInstances(inst.Id())
+		c.Assert(err, jc.ErrorIsNil)
+	}()
+	fwInst := inst.(instance.IngressRuleFirewaller)
+	rules := []network.IngressRule{
+		network.MustNewIngressRule(network.PortRange{80, 80, "tcp"}, "10.0.0.0/8"),
+	}
+
+	// Opening a rule which already exists is not an error.
+	err := fwInst.OpenIngressRules("100", rules)
+	c.Assert(err, jc.ErrorIsNil)
+	err = fwInst.OpenIngressRules("100", rules)
+	c.Assert(err, jc.ErrorIsNil)
+
+	cleanup := s.srv.Nova.RegisterControlPoint(
+		"addSecurityGroupRule",
+		func(sc hook.ServiceControl, args ...interface{}) error {
+			return fmt.Errorf("failed on purpose")
+		},
+	)
+	defer cleanup()
+	err = fwInst.OpenIngressRules("100", []network.IngressRule{
+		network.MustNewIngressRule(network.PortRange{443, 443, "tcp"}, "10.0.0.0/8"),
+	})
+	c.Assert(err, gc.ErrorMatches, "(.|\n)*failed on purpose(.|\n)*")
+}
+
 func (s *localServerSuite) TestAllInstancesFloatingIP(c *gc.C) {
 	env := s.openEnviron(c, coretesting.Attrs{"use-floating-ip": true})
 
@@ -1131,6 +1161,32 @@ func (t *localServerSuite) TestPrecheckInstanceAvailZonesUnsupported(c *gc.C) {
 	c.Assert(err, jc.Satisfies, jujuerrors.IsNotImplemented)
 }
 
+func (t *localServerSuite) TestPrecheckInstanceAvailZoneConstraint(c *gc.C) {
+	placement := "zone=test-available"
+	cons := constraints.MustParse("zones=test-unavailable")
+	err := t.env.PrecheckInstance(series.LatestLts(), cons, placement)
+	c.Assert(err, gc.ErrorMatches, `placement zone "test-available" does not satisfy constraint zones=\[test-unavailable\]`)
+}
+
+func (t *localServerSuite) TestConstraintsValidatorAvailZonesUnsupported(c *gc.C) {
+	t.srv.Nova.SetAvailabilityZones() // no availability zone support
+	validator, err := t.env.ConstraintsValidator()
+	c.Assert(err, jc.ErrorIsNil)
+	unsupported, err := validator.Validate(constraints.MustParse("zones=test-available"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unsupported, jc.SameContents, []string{"zones"})
+
+	// The cloud is not asked again.
+	t.srv.Nova.SetAvailabilityZones(nova.AvailabilityZone{
+		Name: "test-available",
+		State: nova.AvailabilityZoneState{
+			Available: true,
+		},
+	})
+	_, err = t.env.(common.ZonedEnviron).AvailabilityZones()
+	c.Assert(err, jc.Satisfies, jujuerrors.IsNotImplemented)
+}
+
 func (s *localServerSuite) TestValidateImageMetadata(c *gc.C) {
 	env := s.Open(c, s.env.Config())
 	params, err := env.(simplestreams.MetadataValidator).MetadataLookupParams("some-region")
@@ -163,10 +163,15 @@ type Environ struct {
 	keystoneToolsDataSourceMutex sync.Mutex
 	keystoneToolsDataSource      simplestreams.DataSource
 
-	availabilityZonesMutex sync.Mutex
-	availabilityZones      []common.AvailabilityZone
-	firewaller             Firewaller
-	configurator           ProviderConfigurator
+	// availabilityZones caches the result of AvailabilityZones;
+	// availabilityZonesNotImplemented records that the cloud does
+	// not support availability zones, so that it is not asked again.
+	availabilityZonesMutex          sync.Mutex
+	availabilityZones               []common.AvailabilityZone
+	availabilityZonesNotImplemented bool
+
+	firewaller   Firewaller
+	configurator ProviderConfigurator
 }
 
 var _ environs.Environ = (*Environ)(nil)
@@ -337,6 +342,33 @@ func (inst *openstackInstance) Ports(machineId string) ([]network.PortRange, err
 	return inst.e.firewaller.InstancePorts(inst, machineId)
 }
 
+// OpenIngressRules is part of the instance.IngressRuleFirewaller interface.
+func (inst *openstackInstance) OpenIngressRules(machineId string, rules []network.IngressRule) error {
+	fw, err := inst.e.ingressRuleFirewaller()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return fw.OpenInstanceIngressRules(inst, machineId, rules)
+}
+
+// CloseIngressRules is part of the instance.IngressRuleFirewaller interface.
+func (inst *openstackInstance) CloseIngressRules(machineId string, rules []network.IngressRule) error {
+	fw, err := inst.e.ingressRuleFirewaller()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return fw.CloseInstanceIngressRules(inst, machineId, rules)
+}
+
+// IngressRules is part of the instance.IngressRuleFirewaller interface.
+func (inst *openstackInstance) IngressRules(machineId string) ([]network.IngressRule, error) {
+	fw, err := inst.e.ingressRuleFirewaller()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return fw.InstanceIngressRules(inst, machineId)
+}
+
 func (e *Environ) ecfg() *environConfig {
 	e.ecfgMutex.Lock()
 	ecfg := e.ecfgUnlocked
@@ -362,7 +394,18 @@ func (e *Environ) ConstraintsValidator() (constraints.Validator, error) {
 	validator.RegisterConflicts(
 		[]string{constraints.InstanceType},
 		[]string{constraints.Mem, constraints.RootDisk, constraints.CpuCores})
-	validator.RegisterUnsupported(unsupportedConstraints)
+	unsupported := unsupportedConstraints
+	zoneNames, err := common.AvailabilityZoneNames(e)
+	if errors.IsNotImplemented(err) {
+		// Availability zones are an extension, so we may get a
+		// not implemented error; zones cannot be constrained then.
+		unsupported = append(unsupported[:len(unsupported):len(unsupported)], constraints.Zones)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	} else {
+		validator.RegisterVocabulary(constraints.Zones, zoneNames)
+	}
+	validator.RegisterUnsupported(unsupported)
 	novaClient := e.nova()
 	flavors, err := novaClient.ListFlavorsDetail()
 	if err != nil {
@@ -374,6 +417,10 @@ func (e *Environ) ConstraintsValidator() (constraints.Validator, error) {
 	}
 	validator.RegisterVocabulary(constraints.InstanceType, instTypeNames)
 	validator.RegisterVocabulary(constraints.VirtType, []string{"kvm", "lxd"})
+	validator.RegisterVocabulary(constraints.PlacementGroup, []string{
+		constraints.PlacementGroupSpread,
+		constraints.PlacementGroupCluster,
+	})
 	return validator, nil
 }
 
@@ -395,9 +442,13 @@ func (z *openstackAvailabilityZone) Available() bool {
 func (e *Environ) AvailabilityZones() ([]common.AvailabilityZone, error) {
 	e.availabilityZonesMutex.Lock()
 	defer e.availabilityZonesMutex.Unlock()
+	if e.availabilityZonesNotImplemented {
+		return nil, errors.NotImplementedf("availability zones")
+	}
 	if e.availabilityZones == nil {
 		zones, err := novaListAvailabilityZones(e.nova())
 		if gooseerrors.IsNotImplemented(err) {
+			e.availabilityZonesNotImplemented = true
 			return nil, errors.NotImplementedf("availability zones")
 		}
 		if err != nil {
@@ -459,7 +510,11 @@ func (e *Environ) parsePlacement(placement string) (*openstackPlacement, error)
 // PrecheckInstance is defined on the state.Prechecker interface.
 func (e *Environ) PrecheckInstance(series string, cons constraints.Value, placement string) error {
 	if placement != "" {
-		if _, err := e.parsePlacement(placement); err != nil {
+		p, err := e.parsePlacement(placement)
+		if err != nil {
+			return err
+		}
+		if err := common.ValidateZoneConstraint(p.availabilityZone.Name, cons); err != nil {
 			return err
 		}
 	}
@@ -817,6 +872,9 @@ func (e *Environ) StartInstance(args environs.StartInstanceParams) (*environs.St
 		if !placement.availabilityZone.State.Available {
 			return nil, errors.Errorf("availability zone %q is unavailable", placement.availabilityZone.Name)
 		}
+		if err := common.ValidateZoneConstraint(placement.availabilityZone.Name, args.Constraints); err != nil {
+			return nil, errors.Trace(err)
+		}
 		availabilityZones = append(availabilityZones, placement.availabilityZone.Name)
 	}
 
@@ -839,6 +897,10 @@ func (e *Environ) StartInstance(args environs.StartInstanceParams) (*environs.St
 		} else if err != nil {
 			return nil, err
 		} else {
+			zoneInstances, err = common.FilterZoneAllocations(zoneInstances, args.Constraints)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
 			for _, zone := range zoneInstances {
 				availabilityZones = append(availabilityZones, zone.ZoneName)
 			}
@@ -925,13 +987,26 @@ func (e *Environ) StartInstance(args environs.StartInstanceParams) (*environs.St
 		e.Config().UUID(),
 	)
 
+	// If the application's machines are constrained to a placement
+	// group, start the server in the application's server group.
+	runServer := e.nova().RunServer
+	if groupName, strategy := common.InstancePlacementGroup(e.Config().UUID(), args); groupName != "" {
+		groupId, err := ensureServerGroup(e, groupName, strategy)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		args.InstanceConfig.Tags[tags.JujuPlacementGroup] = groupName
+		runServer = func(opts nova.RunServerOpts) (*nova.Entity, error) {
+			return e.serverGroups().runServer(opts, groupId)
+		}
+	}
+
 	tryStartNovaInstance := func(
 		attempts utils.AttemptStrategy,
-		client *nova.Client,
 		instanceOpts nova.RunServerOpts,
 	) (server *nova.Entity, err error) {
 		for a := attempts.Start(); a.Next(); {
-			server, err = client.RunServer(instanceOpts)
+			server, err = runServer(instanceOpts)
 			if err == nil || gooseerrors.IsNotFound(err) == false {
 				break
 			}
@@ -941,14 +1016,13 @@ func (e *Environ) StartInstance(args environs.StartInstanceParams) (*environs.St
 
 	tryStartNovaInstanceAcrossAvailZones := func(
 		attempts utils.AttemptStrategy,
-		client *nova.Client,
 		instanceOpts nova.RunServerOpts,
 		availabilityZones []string,
 	) (server *nova.Entity, err error) {
 		for _, zone := range availabilityZones {
 			instanceOpts.AvailabilityZone = zone
 			e.configurator.ModifyRunServerOptions(&instanceOpts)
-			server, err = tryStartNovaInstance(attempts, client, instanceOpts)
+			server, err = tryStartNovaInstance(attempts, instanceOpts)
 			if err == nil || isNoValidHostsError(err) == false {
 				break
 			}
@@ -972,7 +1046,7 @@ func (e *Environ) StartInstance(args environs.StartInstanceParams) (*environs.St
 		Networks:           networks,
 		Metadata:           args.InstanceConfig.Tags,
 	}
-	server, err := tryStartNovaInstanceAcrossAvailZones(shortAttempt, e.nova(), opts, availabilityZones)
+	server, err := tryStartNovaInstanceAcrossAvailZones(shortAttempt, opts, availabilityZones)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -1024,10 +1098,17 @@ func (e *Environ) StopInstances(ids ...instance.Id) error {
 	if err != nil {
 		return err
 	}
+	serverGroupNames, err := e.instanceServerGroups(ids)
+	if err != nil {
+		logger.Warningf("cannot determine server groups to delete: %v", err)
+	}
 	logger.Debugf("terminating instances %v", ids)
 	if err := e.terminateInstances(ids); err != nil {
 		return err
 	}
+	if err := e.deleteServerGroups(serverGroupNames, ids); err != nil {
+		logger.Errorf("provider failure: %v", err)
+	}
 	if securityGroupNames != nil {
 		return e.deleteSecurityGroups(securityGroupNames)
 	}
@@ -1205,6 +1286,12 @@ func (e *Environ) Destroy() error {
 	if err != nil {
 		return errors.Trace(err)
 	}
+	if err := e.deleteModelServerGroups(); err != nil {
+		// Server groups are an extension, which may not be
+		// supported, so a failure to delete them does not
+		// prevent destruction.
+		logger.Warningf("cannot delete server groups: %v", err)
+	}
 	// Delete all security groups remaining in the model.
 	return e.firewaller.DeleteAllModelGroups()
 }
@@ -1289,6 +1376,29 @@ func (e *Environ) machinesFilter() *nova.Filter {
 }
 
 // portsToRuleInfo maps port ranges to nova rules
+// rulesToRuleInfo returns the nova rules for the given ingress rules;
+// nova rules have a single source CIDR, so there is one for each of
+// each rule's source CIDRs.
+func rulesToRuleInfo(groupId string, rules []network.IngressRule) []nova.RuleInfo {
+	var ruleInfos []nova.RuleInfo
+	for _, rule := range rules {
+		sourceCIDRs := rule.SourceCIDRs
+		if len(sourceCIDRs) == 0 {
+			sourceCIDRs = []string{network.AllSourceCIDR}
+		}
+		for _, cidr := range sourceCIDRs {
+			ruleInfos = append(ruleInfos, nova.RuleInfo{
+				ParentGroupId: groupId,
+				FromPort:      rule.FromPort,
+				ToPort:        rule.ToPort,
+				IPProtocol:    rule.Protocol,
+				Cidr:          cidr,
+			})
+		}
+	}
+	return ruleInfos
+}
+
 func portsToRuleInfo(groupId string, ports []network.PortRange) []nova.RuleInfo {
 	rules := make([]nova.RuleInfo, len(ports))
 	for i, portRange := range ports {
@@ -1315,6 +1425,41 @@ func (e *Environ) Ports() ([]network.PortRange, error) {
 	return e.firewaller.Ports()
 }
 
+func (e *Environ) ingressRuleFirewaller() (IngressRuleFirewaller, error) {
+	fw, ok := e.firewaller.(IngressRuleFirewaller)
+	if !ok {
+		return nil, errors.NotSupportedf("ingress rules")
+	}
+	return fw, nil
+}
+
+// OpenIngressRules is part of the environs.IngressRuleFirewaller interface.
+func (e *Environ) OpenIngressRules(rules []network.IngressRule) error {
+	fw, err := e.ingressRuleFirewaller()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return fw.OpenIngressRules(rules)
+}
+
+// CloseIngressRules is part of the environs.IngressRuleFirewaller interface.
+func (e *Environ) CloseIngressRules(rules []network.IngressRule) error {
+	fw, err := e.ingressRuleFirewaller()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return fw.CloseIngressRules(rules)
+}
+
+// IngressRules is part of the environs.IngressRuleFirewaller interface.
+func (e *Environ) IngressRules() ([]network.IngressRule, error) {
+	fw, err := e.ingressRuleFirewaller()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return fw.IngressRules()
+}
+
 func (e *Environ) Provider() environs.EnvironProvider {
 	return providerInstance
 }
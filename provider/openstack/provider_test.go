@@ -281,6 +281,33 @@ func (*localTests) TestPortsToRuleInfo(c *gc.C) {
 	}
 }
 
+func (*localTests) TestRulesToRuleInfo(c *gc.C) {
+	groupId := "groupid"
+	rules := []network.IngressRule{
+		network.MustNewIngressRule(network.PortRange{80, 80, "tcp"}, "10.0.0.0/8", "192.168.0.0/16"),
+		network.MustNewIngressRule(network.PortRange{53, 53, "udp"}),
+	}
+	c.Check(RulesToRuleInfo(groupId, rules), gc.DeepEquals, []nova.RuleInfo{{
+		IPProtocol:    "tcp",
+		FromPort:      80,
+		ToPort:        80,
+		Cidr:          "10.0.0.0/8",
+		ParentGroupId: groupId,
+	}, {
+		IPProtocol:    "tcp",
+		FromPort:      80,
+		ToPort:        80,
+		Cidr:          "192.168.0.0/16",
+		ParentGroupId: groupId,
+	}, {
+		IPProtocol:    "udp",
+		FromPort:      53,
+		ToPort:        53,
+		Cidr:          "0.0.0.0/0",
+		ParentGroupId: groupId,
+	}})
+}
+
 func (*localTests) TestRuleMatchesPortRange(c *gc.C) {
 	proto_tcp := "tcp"
 	proto_udp := "udp"
@@ -0,0 +1,246 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package openstack
+
+import (
+	"encoding/base64"
+	"net/http"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/goose.v1/client"
+	goosehttp "gopkg.in/goose.v1/http"
+	"gopkg.in/goose.v1/nova"
+
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/provider/common"
+)
+
+// serverGroupPolicies maps the strategies of the placement-group
+// constraint to Nova server group policies.
+var serverGroupPolicies = map[string]string{
+	constraints.PlacementGroupSpread:  "anti-affinity",
+	constraints.PlacementGroupCluster: "affinity",
+}
+
+// serverGroup holds the details of a Nova server group.
+type serverGroup struct {
+	Id       string   `json:"id"`
+	Name     string   `json:"name"`
+	Policies []string `json:"policies"`
+	Members  []string `json:"members"`
+}
+
+// serverGroupClient issues requests to the Nova server groups API,
+// and runs servers with scheduler hints, neither of which is supported
+// by the goose nova client.
+type serverGroupClient struct {
+	client client.Client
+}
+
+// list returns all of the tenant's server groups.
+func (c serverGroupClient) list() ([]serverGroup, error) {
+	var resp struct {
+		ServerGroups []serverGroup `json:"server_groups"`
+	}
+	requestData := goosehttp.RequestData{
+		RespValue:      &resp,
+		ExpectedStatus: []int{http.StatusOK},
+	}
+	if err := c.client.SendRequest("GET", "compute", "os-server-groups", &requestData); err != nil {
+		return nil, errors.Annotate(err, "listing server groups")
+	}
+	return resp.ServerGroups, nil
+}
+
+// create creates a server group with the given name and policy.
+func (c serverGroupClient) create(name, policy string) (*serverGroup, error) {
+	var req struct {
+		ServerGroup struct {
+			Name     string   `json:"name"`
+			Policies []string `json:"policies"`
+		} `json:"server_group"`
+	}
+	req.ServerGroup.Name = name
+	req.ServerGroup.Policies = []string{policy}
+	var resp struct {
+		ServerGroup serverGroup `json:"server_group"`
+	}
+	requestData := goosehttp.RequestData{
+		ReqValue:       req,
+		RespValue:      &resp,
+		ExpectedStatus: []int{http.StatusOK},
+	}
+	if err := c.client.SendRequest("POST", "compute", "os-server-groups", &requestData); err != nil {
+		return nil, errors.Annotatef(err, "creating server group %q", name)
+	}
+	return &resp.ServerGroup, nil
+}
+
+// delete deletes the server group with the given ID.
+func (c serverGroupClient) delete(id string) error {
+	requestData := goosehttp.RequestData{
+		ExpectedStatus: []int{http.StatusNoContent},
+	}
+	if err := c.client.SendRequest("DELETE", "compute", "os-server-groups/"+id, &requestData); err != nil {
+		return errors.Annotatef(err, "deleting server group %q", id)
+	}
+	return nil
+}
+
+// runServer runs a server with the given options in the server group
+// with the given ID. Errors are returned as reported by goose, so that
+// they can be inspected in the same way as those from nova.RunServer.
+func (c serverGroupClient) runServer(opts nova.RunServerOpts, groupId string) (*nova.Entity, error) {
+	type runServerOpts struct {
+		nova.RunServerOpts
+		// UserData overrides the embedded field, as Nova
+		// requires the user data to be base64-encoded.
+		UserData string `json:"user_data,omitempty"`
+	}
+	var req struct {
+		Server         runServerOpts `json:"server"`
+		SchedulerHints struct {
+			Group string `json:"group"`
+		} `json:"os:scheduler_hints"`
+	}
+	req.Server.RunServerOpts = opts
+	if opts.UserData != nil {
+		req.Server.UserData = base64.StdEncoding.EncodeToString(opts.UserData)
+	}
+	req.SchedulerHints.Group = groupId
+	var resp struct {
+		Server nova.Entity `json:"server"`
+	}
+	requestData := goosehttp.RequestData{
+		ReqValue:       req,
+		RespValue:      &resp,
+		ExpectedStatus: []int{http.StatusAccepted},
+	}
+	if err := c.client.SendRequest("POST", "compute", "servers", &requestData); err != nil {
+		return nil, err
+	}
+	return &resp.Server, nil
+}
+
+func (e *Environ) serverGroups() serverGroupClient {
+	e.ecfgMutex.Lock()
+	defer e.ecfgMutex.Unlock()
+	return serverGroupClient{e.client}
+}
+
+var ensureServerGroup = _ensureServerGroup
+
+// ensureServerGroup creates the server group with the given name and
+// placement-group constraint strategy, if it does not already exist,
+// and returns its ID.
+func _ensureServerGroup(e *Environ, name, strategy string) (string, error) {
+	policy, ok := serverGroupPolicies[strategy]
+	if !ok {
+		return "", errors.NotValidf("placement group strategy %q", strategy)
+	}
+	groupClient := e.serverGroups()
+	groups, err := groupClient.list()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	for _, group := range groups {
+		if group.Name != name {
+			continue
+		}
+		if len(group.Policies) != 1 || group.Policies[0] != policy {
+			return "", errors.Errorf(
+				"server group %q has policies %v, not %q",
+				name, group.Policies, policy,
+			)
+		}
+		return group.Id, nil
+	}
+	logger.Debugf("creating server group %q with policy %q", name, policy)
+	group, err := groupClient.create(name, policy)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return group.Id, nil
+}
+
+// instanceServerGroups returns the names of the server groups that the
+// specified instances were started in.
+func (e *Environ) instanceServerGroups(ids []instance.Id) ([]string, error) {
+	servers, err := e.listServers(ids)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var groupNames []string
+	for _, server := range servers {
+		if name := server.Metadata[tags.JujuPlacementGroup]; name != "" {
+			groupNames = append(groupNames, name)
+		}
+	}
+	return groupNames, nil
+}
+
+// deleteServerGroups deletes the server groups with the given names,
+// if no servers other than the specified terminated instances remain
+// in them. A server group is created for an application when its first
+// machine is started, and deleted along with its last machine.
+func (e *Environ) deleteServerGroups(groupNames []string, terminated []instance.Id) error {
+	if len(groupNames) == 0 {
+		return nil
+	}
+	groupClient := e.serverGroups()
+	groups, err := groupClient.list()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	wanted := make(map[string]bool)
+	for _, name := range groupNames {
+		wanted[name] = true
+	}
+	isTerminated := make(map[string]bool)
+	for _, id := range terminated {
+		isTerminated[string(id)] = true
+	}
+	for _, group := range groups {
+		if !wanted[group.Name] {
+			continue
+		}
+		var inUse bool
+		for _, member := range group.Members {
+			if !isTerminated[member] {
+				inUse = true
+				break
+			}
+		}
+		if inUse {
+			continue
+		}
+		if err := groupClient.delete(group.Id); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// deleteModelServerGroups deletes all server groups created for the
+// model's applications.
+func (e *Environ) deleteModelServerGroups() error {
+	groupClient := e.serverGroups()
+	groups, err := groupClient.list()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	prefix := common.PlacementGroupPrefix(e.Config().UUID())
+	for _, group := range groups {
+		if !strings.HasPrefix(group.Name, prefix) {
+			continue
+		}
+		if err := groupClient.delete(group.Id); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package openstack
+
+import (
+	"encoding/json"
+
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/goose.v1/client"
+	goosehttp "gopkg.in/goose.v1/http"
+
+	"github.com/juju/juju/instance"
+)
+
+type serverGroupSuite struct {
+	gitjujutesting.IsolationSuite
+}
+
+var _ = gc.Suite(&serverGroupSuite{})
+
+type serverGroupRequest struct {
+	method  string
+	apiCall string
+	req     interface{}
+}
+
+// fakeServerGroupClient records requests sent to the Nova server
+// groups API, and responds with the given server groups.
+type fakeServerGroupClient struct {
+	client.AuthenticatingClient
+	groups   []serverGroup
+	requests []serverGroupRequest
+}
+
+func (c *fakeServerGroupClient) SendRequest(method, svcType, apiCall string, requestData *goosehttp.RequestData) error {
+	c.requests = append(c.requests, serverGroupRequest{method, apiCall, requestData.ReqValue})
+	var resp interface{}
+	switch method {
+	case "GET":
+		resp = map[string]interface{}{"server_groups": c.groups}
+	case "POST":
+		resp = map[string]interface{}{"server_group": serverGroup{Id: "new-group-id"}}
+	default:
+		return nil
+	}
+	data, err := json.Marshal(resp)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, requestData.RespValue)
+}
+
+func (s *serverGroupSuite) environ(groups ...serverGroup) (*Environ, *fakeServerGroupClient) {
+	fakeClient := &fakeServerGroupClient{groups: groups}
+	return &Environ{client: fakeClient}, fakeClient
+}
+
+func (s *serverGroupSuite) TestEnsureServerGroupExists(c *gc.C) {
+	env, fakeClient := s.environ(serverGroup{
+		Id:       "group-id",
+		Name:     "juju-group",
+		Policies: []string{"anti-affinity"},
+	})
+	id, err := ensureServerGroup(env, "juju-group", "spread")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(id, gc.Equals, "group-id")
+	c.Assert(fakeClient.requests, gc.HasLen, 1)
+}
+
+func (s *serverGroupSuite) TestEnsureServerGroupPolicyMismatch(c *gc.C) {
+	env, _ := s.environ(serverGroup{
+		Id:       "group-id",
+		Name:     "juju-group",
+		Policies: []string{"anti-affinity"},
+	})
+	_, err := ensureServerGroup(env, "juju-group", "cluster")
+	c.Assert(err, gc.ErrorMatches, `server group "juju-group" has policies \[anti-affinity\], not "affinity"`)
+}
+
+func (s *serverGroupSuite) TestEnsureServerGroupCreates(c *gc.C) {
+	env, fakeClient := s.environ()
+	id, err := ensureServerGroup(env, "juju-group", "cluster")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(id, gc.Equals, "new-group-id")
+	c.Assert(fakeClient.requests, gc.HasLen, 2)
+	c.Check(fakeClient.requests[1].method, gc.Equals, "POST")
+	c.Check(fakeClient.requests[1].apiCall, gc.Equals, "os-server-groups")
+	data, err := json.Marshal(fakeClient.requests[1].req)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, `{"server_group":{"name":"juju-group","policies":["affinity"]}}`)
+}
+
+func (s *serverGroupSuite) TestEnsureServerGroupInvalidStrategy(c *gc.C) {
+	env, fakeClient := s.environ()
+	_, err := ensureServerGroup(env, "juju-group", "partition")
+	c.Assert(err, gc.ErrorMatches, `placement group strategy "partition" not valid`)
+	c.Assert(fakeClient.requests, gc.HasLen, 0)
+}
+
+func (s *serverGroupSuite) TestDeleteServerGroups(c *gc.C) {
+	env, fakeClient := s.environ(serverGroup{
+		Id:      "empty-id",
+		Name:    "juju-empty",
+		Members: []string{"inst-0"},
+	}, serverGroup{
+		Id:      "used-id",
+		Name:    "juju-used",
+		Members: []string{"inst-0", "inst-1"},
+	}, serverGroup{
+		Id:   "other-id",
+		Name: "juju-other",
+	})
+	err := env.deleteServerGroups([]string{"juju-empty", "juju-used"}, []instance.Id{"inst-0"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(fakeClient.requests, gc.HasLen, 2)
+	c.Check(fakeClient.requests[1].method, gc.Equals, "DELETE")
+	c.Check(fakeClient.requests[1].apiCall, gc.Equals, "os-server-groups/empty-id")
+}
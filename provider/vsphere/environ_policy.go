@@ -23,6 +23,8 @@ func (env *environ) PrecheckInstance(series string, cons constraints.Value, plac
 var unsupportedConstraints = []string{
 	constraints.Tags,
 	constraints.VirtType,
+	constraints.Zones,
+	constraints.PlacementGroup,
 }
 
 // ConstraintsValidator returns a Validator value which is used to
@@ -22,6 +22,10 @@ type Call struct {
 	Response interface{}
 	Error    error
 	Done     chan *Call
+
+	// traceContext holds the span context propagated
+	// to the server, if the call is being traced.
+	traceContext map[string]string
 }
 
 // RequestError represents an error returned from an RPC request.
@@ -63,9 +67,10 @@ func (conn *Conn) send(call *Call) {
 
 	// Encode and send the request.
 	hdr := &Header{
-		RequestId: reqId,
-		Request:   call.Request,
-		Version:   1,
+		RequestId:    reqId,
+		Request:      call.Request,
+		Version:      1,
+		TraceContext: call.traceContext,
 	}
 	params := call.Params
 	if params == nil {
@@ -138,13 +143,16 @@ func (call *Call) done() {
 // The params value may be nil if no parameters are provided; the response value
 // may be nil to indicate that any result should be discarded.
 func (conn *Conn) Call(req Request, params, response interface{}) error {
+	span, traceContext := conn.startClientSpan(req)
 	call := &Call{
-		Request:  req,
-		Params:   params,
-		Response: response,
-		Done:     make(chan *Call, 1),
+		Request:      req,
+		Params:       params,
+		Response:     response,
+		Done:         make(chan *Call, 1),
+		traceContext: traceContext,
 	}
 	conn.send(call)
 	result := <-call.Done
+	finishSpan(span, result.Error)
 	return errors.Trace(result.Error)
 }
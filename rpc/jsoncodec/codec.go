@@ -72,6 +72,8 @@ type inMsgV1 struct {
 	Error     string          `json:"error"`
 	ErrorCode string          `json:"error-code"`
 	Response  json.RawMessage `json:"response"`
+
+	TraceContext map[string]string `json:"trace-context"`
 }
 
 // outMsg holds an outgoing message.
@@ -97,6 +99,8 @@ type outMsgV1 struct {
 	Error     string      `json:"error,omitempty"`
 	ErrorCode string      `json:"error-code,omitempty"`
 	Response  interface{} `json:"response,omitempty"`
+
+	TraceContext map[string]string `json:"trace-context,omitempty"`
 }
 
 func (c *Codec) Close() error {
@@ -139,6 +143,7 @@ func (c *Codec) ReadHeader(hdr *rpc.Header) error {
 	}
 	hdr.Error = c.msg.Error
 	hdr.ErrorCode = c.msg.ErrorCode
+	hdr.TraceContext = c.msg.TraceContext
 	hdr.Version = version
 	return nil
 }
@@ -265,13 +270,14 @@ func newOutMsgV0(hdr *rpc.Header, body interface{}) outMsgV0 {
 // reflect, but no.
 func newOutMsgV1(hdr *rpc.Header, body interface{}) outMsgV1 {
 	result := outMsgV1{
-		RequestId: hdr.RequestId,
-		Type:      hdr.Request.Type,
-		Version:   hdr.Request.Version,
-		Id:        hdr.Request.Id,
-		Request:   hdr.Request.Action,
-		Error:     hdr.Error,
-		ErrorCode: hdr.ErrorCode,
+		RequestId:    hdr.RequestId,
+		Type:         hdr.Request.Type,
+		Version:      hdr.Request.Version,
+		Id:           hdr.Request.Id,
+		Request:      hdr.Request.Action,
+		Error:        hdr.Error,
+		ErrorCode:    hdr.ErrorCode,
+		TraceContext: hdr.TraceContext,
 	}
 	if hdr.IsRequest() {
 		result.Params = body
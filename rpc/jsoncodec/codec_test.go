@@ -115,6 +115,21 @@ func (*suite) TestRead(c *gc.C) {
 			Version: 1,
 		},
 		expectBody: &value{X: "param"},
+	}, {
+		msg: `{"request-id": 5, "type": "foo", "request": "frob", "params": {"X": "param"}, "trace-context": {"X-B3-TraceId": "00000000000004d2", "X-B3-SpanId": "000000000000162e"}}`,
+		expectHdr: rpc.Header{
+			RequestId: 5,
+			Request: rpc.Request{
+				Type:   "foo",
+				Action: "frob",
+			},
+			TraceContext: map[string]string{
+				"X-B3-TraceId": "00000000000004d2",
+				"X-B3-SpanId":  "000000000000162e",
+			},
+			Version: 1,
+		},
+		expectBody: &value{X: "param"},
 	}} {
 		c.Logf("test %d", i)
 		codec := jsoncodec.New(&testConn{
@@ -236,6 +251,21 @@ func (*suite) TestWrite(c *gc.C) {
 		},
 		body:   &value{X: "param"},
 		expect: `{"request-id": 4, "type": "foo", "version": 2, "request": "frob", "params": {"X": "param"}}`,
+	}, {
+		hdr: &rpc.Header{
+			RequestId: 5,
+			Request: rpc.Request{
+				Type:   "foo",
+				Action: "frob",
+			},
+			TraceContext: map[string]string{
+				"X-B3-TraceId": "00000000000004d2",
+				"X-B3-SpanId":  "000000000000162e",
+			},
+			Version: 1,
+		},
+		body:   &value{X: "param"},
+		expect: `{"request-id": 5, "type": "foo", "request": "frob", "params": {"X": "param"}, "trace-context": {"X-B3-TraceId": "00000000000004d2", "X-B3-SpanId": "000000000000162e"}}`,
 	}} {
 		c.Logf("test %d", i)
 		var conn testConn
@@ -525,13 +525,13 @@ func (root *Root) assertServerNotified(c *gc.C, p testCallParams, requestId uint
 		c.Assert(serverReply.body, gc.Equals, stringVal{p.request().Action + " ret"})
 	}
 	if p.retErr && p.testErr {
-		c.Assert(serverReply.hdr, gc.Equals, rpc.Header{
+		c.Assert(serverReply.hdr, gc.DeepEquals, rpc.Header{
 			RequestId: requestId,
 			Error:     p.errorMessage(),
 			Version:   1,
 		})
 	} else {
-		c.Assert(serverReply.hdr, gc.Equals, rpc.Header{
+		c.Assert(serverReply.hdr, gc.DeepEquals, rpc.Header{
 			RequestId: requestId,
 			Version:   1,
 		})
@@ -1109,6 +1109,8 @@ func newRPCClientServer(
 		if custroot, ok := root.(*CustomRoot); ok {
 			rpcConn.ServeRoot(custroot, tfErr)
 			custroot.root.conn = rpcConn
+		} else if tracedRoot, ok := root.(*tracedRoot); ok {
+			rpcConn.ServeRoot(tracedRoot, tfErr)
 		} else {
 			rpcConn.Serve(root, tfErr)
 		}
@@ -0,0 +1,47 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package rpcreflect
+
+import (
+	"reflect"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+	"github.com/opentracing/opentracing-go/log"
+)
+
+// NewTracingMethodCaller returns a MethodCaller that records each
+// dispatch to the underlying caller as a span, named after the given
+// facade method, that is a child of parent.
+func NewTracingMethodCaller(caller MethodCaller, parent opentracing.Span, name string) MethodCaller {
+	return tracingMethodCaller{
+		MethodCaller: caller,
+		parent:       parent,
+		name:         name,
+	}
+}
+
+type tracingMethodCaller struct {
+	MethodCaller
+	parent opentracing.Span
+	name   string
+}
+
+// Call is part of the MethodCaller interface.
+func (caller tracingMethodCaller) Call(objId string, arg reflect.Value) (reflect.Value, error) {
+	span := caller.parent.Tracer().StartSpan(
+		"dispatch "+caller.name,
+		opentracing.ChildOf(caller.parent.Context()),
+	)
+	defer span.Finish()
+	if objId != "" {
+		span.SetTag("rpc.id", objId)
+	}
+	rv, err := caller.MethodCaller.Call(objId, arg)
+	if err != nil {
+		ext.Error.Set(span, true)
+		span.LogFields(log.Error(err))
+	}
+	return rv, err
+}
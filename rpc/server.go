@@ -10,6 +10,7 @@ import (
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"github.com/opentracing/opentracing-go"
 
 	"github.com/juju/juju/rpc/rpcreflect"
 )
@@ -64,6 +65,10 @@ type Header struct {
 
 	// Version defines the wire format of the request and response structure.
 	Version int
+
+	// TraceContext holds the propagated tracing span context, if any.
+	// It is only sent in version 1 messages.
+	TraceContext map[string]string
 }
 
 // Request represents an RPC to be performed, absent its parameters.
@@ -148,6 +153,10 @@ type Conn struct {
 	inputLoopError error
 
 	observerFactory ObserverFactory
+
+	// traceParent, if set, is the span under which client
+	// calls are traced.
+	traceParent opentracing.Span
 }
 
 // NewConn creates a new connection that uses the given codec for
@@ -310,6 +319,17 @@ type Root interface {
 	Killer
 }
 
+// TracingRoot is implemented by a Root that can link the work done by
+// the methods it finds, such as database transactions, to the span
+// recording the request.
+type TracingRoot interface {
+	Root
+
+	// FindTracedMethod is like FindMethod, but the returned method
+	// traces its work as children of the given span context.
+	FindTracedMethod(rootName string, version int, methodName string, parent opentracing.SpanContext) (rpcreflect.MethodCaller, error)
+}
+
 // Killer represents a type that can be asked to abort any outstanding
 // requests.  The Kill method should return immediately.
 type Killer interface {
@@ -373,9 +393,11 @@ func (conn *Conn) readBody(resp interface{}, isRequest bool) error {
 
 func (conn *Conn) handleRequest(hdr *Header) error {
 	observer := conn.observerFactory.RPCObserver()
-	req, err := conn.bindRequest(hdr)
+	span := startServerSpan(hdr)
+	req, err := conn.bindRequest(hdr, span)
 	if err != nil {
 		observer.ServerRequest(hdr, nil)
+		finishSpan(span, err)
 		if err := conn.readBody(nil, true); err != nil {
 			return err
 		}
@@ -392,6 +414,7 @@ func (conn *Conn) handleRequest(hdr *Header) error {
 	}
 	if err := conn.readBody(argp, true); err != nil {
 		observer.ServerRequest(hdr, nil)
+		finishSpan(span, err)
 
 		// If we get EOF, we know the connection is a
 		// goner, so don't try to respond.
@@ -417,11 +440,12 @@ func (conn *Conn) handleRequest(hdr *Header) error {
 	closing := conn.closing
 	if !closing {
 		conn.srvPending.Add(1)
-		go conn.runRequest(req, arg, hdr.Version, observer)
+		go conn.runRequest(req, arg, hdr.Version, observer, span)
 	}
 	conn.mutex.Unlock()
 	if closing {
 		// We're closing down - no new requests may be initiated.
+		finishSpan(span, ErrShutdown)
 		return conn.writeErrorResponse(hdr, req.transformErrors(ErrShutdown), observer)
 	}
 	return nil
@@ -431,8 +455,9 @@ func (conn *Conn) writeErrorResponse(reqHdr *Header, err error, observer Observe
 	conn.sending.Lock()
 	defer conn.sending.Unlock()
 	hdr := &Header{
-		RequestId: reqHdr.RequestId,
-		Version:   reqHdr.Version,
+		RequestId:    reqHdr.RequestId,
+		Version:      reqHdr.Version,
+		TraceContext: reqHdr.TraceContext,
 	}
 	if err, ok := err.(ErrorCoder); ok {
 		hdr.ErrorCode = err.ErrorCode()
@@ -455,8 +480,10 @@ type boundRequest struct {
 
 // bindRequest searches for methods implementing the
 // request held in the given header and returns
-// a boundRequest that can call those methods.
-func (conn *Conn) bindRequest(hdr *Header) (boundRequest, error) {
+// a boundRequest that can call those methods. If the request continues
+// a trace propagated by the caller, and the root supports it, the
+// methods trace their work under the given span.
+func (conn *Conn) bindRequest(hdr *Header, span opentracing.Span) (boundRequest, error) {
 	conn.mutex.Lock()
 	root := conn.root
 	transformErrors := conn.transformErrors
@@ -465,8 +492,15 @@ func (conn *Conn) bindRequest(hdr *Header) (boundRequest, error) {
 	if root == nil {
 		return boundRequest{}, errors.New("no service")
 	}
-	caller, err := root.FindMethod(
-		hdr.Request.Type, hdr.Request.Version, hdr.Request.Action)
+	var caller rpcreflect.MethodCaller
+	var err error
+	if tracingRoot, ok := root.(TracingRoot); ok && len(hdr.TraceContext) > 0 {
+		caller, err = tracingRoot.FindTracedMethod(
+			hdr.Request.Type, hdr.Request.Version, hdr.Request.Action, span.Context())
+	} else {
+		caller, err = root.FindMethod(
+			hdr.Request.Type, hdr.Request.Version, hdr.Request.Action)
+	}
 	if err != nil {
 		if _, ok := err.(*rpcreflect.CallNotImplementedError); ok {
 			err = &serverError{
@@ -484,16 +518,20 @@ func (conn *Conn) bindRequest(hdr *Header) (boundRequest, error) {
 	}, nil
 }
 
-// runRequest runs the given request and sends the reply.
-func (conn *Conn) runRequest(req boundRequest, arg reflect.Value, version int, observer Observer) {
+// runRequest runs the given request and sends the reply, recording
+// the call's outcome on the request's span.
+func (conn *Conn) runRequest(req boundRequest, arg reflect.Value, version int, observer Observer, span opentracing.Span) {
 	defer conn.srvPending.Done()
-	rv, err := req.Call(req.hdr.Request.Id, arg)
+	caller := rpcreflect.NewTracingMethodCaller(req.MethodCaller, span, spanName(req.hdr.Request))
+	rv, err := caller.Call(req.hdr.Request.Id, arg)
+	finishSpan(span, err)
 	if err != nil {
 		err = conn.writeErrorResponse(&req.hdr, req.transformErrors(err), observer)
 	} else {
 		hdr := &Header{
-			RequestId: req.hdr.RequestId,
-			Version:   version,
+			RequestId:    req.hdr.RequestId,
+			Version:      version,
+			TraceContext: req.hdr.TraceContext,
 		}
 		var rvi interface{}
 		if rv.IsValid() {
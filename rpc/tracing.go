@@ -0,0 +1,98 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package rpc
+
+import (
+	"fmt"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+	"github.com/opentracing/opentracing-go/log"
+)
+
+// TraceCalls causes every subsequent client call made on the
+// connection to be recorded as a child span of parent, and the
+// span context to be propagated to the server in the request header.
+// Passing nil stops calls from being traced.
+func (conn *Conn) TraceCalls(parent opentracing.Span) {
+	conn.mutex.Lock()
+	defer conn.mutex.Unlock()
+	conn.traceParent = parent
+}
+
+// spanName returns the name of the spans recording the given request.
+func spanName(req Request) string {
+	return fmt.Sprintf("%s.%s", req.Type, req.Action)
+}
+
+func requestTags(req Request) opentracing.Tags {
+	tags := opentracing.Tags{
+		"rpc.facade":  req.Type,
+		"rpc.version": req.Version,
+	}
+	if req.Id != "" {
+		tags["rpc.id"] = req.Id
+	}
+	return tags
+}
+
+// startClientSpan starts a span covering a client call, if the
+// connection is tracing calls. It returns a nil span and context
+// otherwise.
+func (conn *Conn) startClientSpan(req Request) (opentracing.Span, map[string]string) {
+	conn.mutex.Lock()
+	parent := conn.traceParent
+	conn.mutex.Unlock()
+	if parent == nil {
+		return nil, nil
+	}
+	tracer := parent.Tracer()
+	span := tracer.StartSpan(
+		spanName(req),
+		opentracing.ChildOf(parent.Context()),
+		ext.SpanKindRPCClient,
+		requestTags(req),
+	)
+	traceContext := make(map[string]string)
+	if err := tracer.Inject(span.Context(), opentracing.TextMap, opentracing.TextMapCarrier(traceContext)); err != nil {
+		logger.Debugf("cannot propagate trace context: %v", err)
+		traceContext = nil
+	}
+	return span, traceContext
+}
+
+// startServerSpan starts a span covering the handling of the request
+// in hdr using the global tracer, continuing any trace propagated by
+// the caller.
+func startServerSpan(hdr *Header) opentracing.Span {
+	tracer := opentracing.GlobalTracer()
+	opts := []opentracing.StartSpanOption{
+		ext.SpanKindRPCServer,
+		requestTags(hdr.Request),
+	}
+	if len(hdr.TraceContext) > 0 {
+		parent, err := tracer.Extract(opentracing.TextMap, opentracing.TextMapCarrier(hdr.TraceContext))
+		switch err {
+		case nil:
+			opts = append(opts, opentracing.ChildOf(parent))
+		case opentracing.ErrSpanContextNotFound:
+		default:
+			logger.Debugf("ignoring trace context %v: %v", hdr.TraceContext, err)
+		}
+	}
+	return tracer.StartSpan(spanName(hdr.Request), opts...)
+}
+
+// finishSpan records the outcome of a call on the span and finishes
+// it. It does nothing if span is nil.
+func finishSpan(span opentracing.Span, err error) {
+	if span == nil {
+		return
+	}
+	if err != nil {
+		ext.Error.Set(span, true)
+		span.LogFields(log.Error(err))
+	}
+	span.Finish()
+}
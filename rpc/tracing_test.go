@@ -0,0 +1,175 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package rpc_test
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/clock"
+	"github.com/opentracing/opentracing-go"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/rpc"
+	"github.com/juju/juju/rpc/rpcreflect"
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/tracing"
+)
+
+type tracingSuite struct {
+	testing.BaseSuite
+
+	recorder *spanRecorder
+	tracer   *tracing.Tracer
+}
+
+var _ = gc.Suite(&tracingSuite{})
+
+func (s *tracingSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.recorder = &spanRecorder{}
+	tracer, err := tracing.NewTracer(tracing.Config{
+		Recorder: s.recorder,
+		Clock:    clock.WallClock,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.tracer = tracer
+	opentracing.SetGlobalTracer(tracer)
+	s.AddCleanup(func(*gc.C) {
+		opentracing.SetGlobalTracer(opentracing.NoopTracer{})
+	})
+}
+
+func (s *tracingSuite) TestCallPropagatesTrace(c *gc.C) {
+	root := SimpleRoot()
+	client, srvDone, serverNotifier := newRPCClientServer(c, root, nil, false)
+	defer closeClient(c, client, srvDone)
+
+	parent := s.tracer.StartSpan("juju status")
+	client.TraceCalls(parent)
+	err := client.Call(rpc.Request{"SimpleMethods", 0, "a99", "Call0r0"}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	parent.Finish()
+
+	parentCtx := parent.Context().(tracing.SpanContext)
+	c.Assert(serverNotifier.serverRequests, gc.HasLen, 1)
+	traceContext := serverNotifier.serverRequests[0].hdr.TraceContext
+	c.Assert(tracing.TraceIDFromHeaders(traceContext), gc.Equals, parentCtx.TraceIDString())
+
+	spans := s.recorder.byName()
+	c.Assert(spans, gc.HasLen, 4)
+	clientSpan := spans["SimpleMethods.Call0r0 client"]
+	serverSpan := spans["SimpleMethods.Call0r0 server"]
+	dispatchSpan := spans["dispatch SimpleMethods.Call0r0"]
+	for _, span := range []tracing.FinishedSpan{clientSpan, serverSpan, dispatchSpan} {
+		c.Check(span.Context.TraceID, gc.Equals, parentCtx.TraceID)
+	}
+	c.Check(clientSpan.ParentSpanID, gc.Equals, parentCtx.SpanID)
+	c.Check(serverSpan.ParentSpanID, gc.Equals, clientSpan.Context.SpanID)
+	c.Check(dispatchSpan.ParentSpanID, gc.Equals, serverSpan.Context.SpanID)
+	c.Check(serverSpan.Tags["rpc.id"], gc.Equals, "a99")
+}
+
+func (s *tracingSuite) TestCallErrorTagged(c *gc.C) {
+	root := &Root{
+		errorInst: &ErrorMethods{&codedError{"message", "code"}},
+	}
+	client, srvDone, _ := newRPCClientServer(c, root, nil, false)
+	defer closeClient(c, client, srvDone)
+
+	err := client.Call(rpc.Request{"ErrorMethods", 0, "", "Call"}, nil, nil)
+	c.Assert(err, gc.ErrorMatches, `message \(code\)`)
+
+	spans := s.recorder.byName()
+	c.Assert(spans, gc.HasLen, 2)
+	c.Check(spans["ErrorMethods.Call server"].Tags["error"], jc.IsTrue)
+	c.Check(spans["dispatch ErrorMethods.Call"].Tags["error"], jc.IsTrue)
+}
+
+func (s *tracingSuite) TestUntracedCall(c *gc.C) {
+	root := SimpleRoot()
+	client, srvDone, serverNotifier := newRPCClientServer(c, root, nil, false)
+	defer closeClient(c, client, srvDone)
+
+	err := client.Call(rpc.Request{"SimpleMethods", 0, "a99", "Call0r0"}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(serverNotifier.serverRequests, gc.HasLen, 1)
+	c.Assert(serverNotifier.serverRequests[0].hdr.TraceContext, gc.IsNil)
+	// Without a propagated context, the server starts a new trace.
+	spans := s.recorder.byName()
+	c.Assert(spans, gc.HasLen, 2)
+	c.Check(spans["SimpleMethods.Call0r0 server"].ParentSpanID, gc.Equals, uint64(0))
+}
+
+func (s *tracingSuite) TestTracedRootLinksMethods(c *gc.C) {
+	root := &tracedRoot{Root: rpcreflect.ValueOf(reflect.ValueOf(SimpleRoot()))}
+	client, srvDone, _ := newRPCClientServer(c, root, nil, false)
+	defer closeClient(c, client, srvDone)
+
+	err := client.Call(rpc.Request{"SimpleMethods", 0, "a99", "Call0r0"}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(root.parents(), gc.HasLen, 0)
+
+	parent := s.tracer.StartSpan("juju status")
+	client.TraceCalls(parent)
+	err = client.Call(rpc.Request{"SimpleMethods", 0, "a99", "Call0r0"}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	parents := root.parents()
+	c.Assert(parents, gc.HasLen, 1)
+	serverSpan := s.recorder.byName()["SimpleMethods.Call0r0 server"]
+	c.Check(parents[0], jc.DeepEquals, serverSpan.Context)
+}
+
+// tracedRoot is an rpc.TracingRoot that records the span contexts
+// passed to it.
+type tracedRoot struct {
+	rpc.Root
+
+	mu     sync.Mutex
+	traced []opentracing.SpanContext
+}
+
+func (r *tracedRoot) FindTracedMethod(rootName string, version int, methodName string, parent opentracing.SpanContext) (rpcreflect.MethodCaller, error) {
+	r.mu.Lock()
+	r.traced = append(r.traced, parent)
+	r.mu.Unlock()
+	return r.Root.FindMethod(rootName, version, methodName)
+}
+
+func (r *tracedRoot) parents() []opentracing.SpanContext {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.traced
+}
+
+type spanRecorder struct {
+	mu    sync.Mutex
+	spans []tracing.FinishedSpan
+}
+
+func (r *spanRecorder) RecordSpan(span tracing.FinishedSpan) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.spans = append(r.spans, span)
+}
+
+// byName returns the recorded spans keyed by operation name, with
+// client and server RPC spans distinguished by a suffix.
+func (r *spanRecorder) byName() map[string]tracing.FinishedSpan {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	result := make(map[string]tracing.FinishedSpan)
+	for _, span := range r.spans {
+		name := span.OperationName
+		if kind, ok := span.Tags["span.kind"]; ok {
+			name += " " + fmt.Sprint(kind)
+		}
+		result[name] = span
+	}
+	return result
+}
@@ -307,6 +307,7 @@ func allCollections() collectionSchema {
 			}},
 		},
 		volumeAttachmentsC: {},
+		volumeSnapshotsC:   {},
 
 		// -----
 
@@ -372,6 +373,15 @@ func allCollections() collectionSchema {
 			}},
 		},
 
+		// This collection holds a bounded history of the hooks and
+		// actions run by each unit.
+		unitHookRunsC: {
+			rawAccess: true,
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "unit", "started"},
+			}},
+		},
+
 		// This collection holds information about cloud image metadata.
 		cloudimagemetadataC: {
 			global: true,
@@ -387,6 +397,11 @@ func allCollections() collectionSchema {
 		auditingC: {
 			global:    true,
 			rawAccess: true,
+			indexes: []mgo.Index{{
+				Key: []string{"time"},
+			}, {
+				Key: []string{"model-uuid", "time"},
+			}},
 		},
 	}
 }
@@ -462,6 +477,7 @@ const (
 	toolsmetadataC           = "toolsmetadata"
 	txnLogC                  = "txns.log"
 	txnsC                    = "txns"
+	unitHookRunsC            = "unithookruns"
 	unitsC                   = "units"
 	upgradeInfoC             = "upgradeInfo"
 	userLastLoginC           = "userLastLogin"
@@ -469,5 +485,6 @@ const (
 	usersC                   = "users"
 	volumeAttachmentsC       = "volumeattachments"
 	volumesC                 = "volumes"
+	volumeSnapshotsC         = "volumesnapshots"
 	// "resources" (see resource/persistence/mongo.go)
 )
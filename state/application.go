@@ -6,6 +6,7 @@ package state
 import (
 	stderrors "errors"
 	"fmt"
+	"net"
 	"sort"
 	"strconv"
 	"strings"
@@ -35,22 +36,23 @@ type Application struct {
 // serviceDoc represents the internal state of an application in MongoDB.
 // Note the correspondence with ApplicationInfo in apiserver.
 type applicationDoc struct {
-	DocID                string     `bson:"_id"`
-	Name                 string     `bson:"name"`
-	ModelUUID            string     `bson:"model-uuid"`
-	Series               string     `bson:"series"`
-	Subordinate          bool       `bson:"subordinate"`
-	CharmURL             *charm.URL `bson:"charmurl"`
-	Channel              string     `bson:"cs-channel"`
-	CharmModifiedVersion int        `bson:"charmmodifiedversion"`
-	ForceCharm           bool       `bson:"forcecharm"`
-	Life                 Life       `bson:"life"`
-	UnitCount            int        `bson:"unitcount"`
-	RelationCount        int        `bson:"relationcount"`
-	Exposed              bool       `bson:"exposed"`
-	MinUnits             int        `bson:"minunits"`
-	TxnRevno             int64      `bson:"txn-revno"`
-	MetricCredentials    []byte     `bson:"metric-credentials"`
+	DocID                string                     `bson:"_id"`
+	Name                 string                     `bson:"name"`
+	ModelUUID            string                     `bson:"model-uuid"`
+	Series               string                     `bson:"series"`
+	Subordinate          bool                       `bson:"subordinate"`
+	CharmURL             *charm.URL                 `bson:"charmurl"`
+	Channel              string                     `bson:"cs-channel"`
+	CharmModifiedVersion int                        `bson:"charmmodifiedversion"`
+	ForceCharm           bool                       `bson:"forcecharm"`
+	Life                 Life                       `bson:"life"`
+	UnitCount            int                        `bson:"unitcount"`
+	RelationCount        int                        `bson:"relationcount"`
+	Exposed              bool                       `bson:"exposed"`
+	ExposedEndpoints     map[string]ExposedEndpoint `bson:"exposed-endpoints,omitempty"`
+	MinUnits             int                        `bson:"minunits"`
+	TxnRevno             int64                      `bson:"txn-revno"`
+	MetricCredentials    []byte                     `bson:"metric-credentials"`
 }
 
 func newApplication(st *State, doc *applicationDoc) *Application {
@@ -295,16 +297,108 @@ func (s *Application) ClearExposed() error {
 }
 
 func (s *Application) setExposed(exposed bool) (err error) {
+	update := bson.D{{"$set", bson.D{{"exposed", exposed}}}}
+	if !exposed {
+		update = append(update, bson.DocElem{"$unset", bson.D{{"exposed-endpoints", nil}}})
+	}
 	ops := []txn.Op{{
 		C:      applicationsC,
 		Id:     s.doc.DocID,
 		Assert: isAliveDoc,
-		Update: bson.D{{"$set", bson.D{{"exposed", exposed}}}},
+		Update: update,
 	}}
 	if err := s.st.runTransaction(ops); err != nil {
 		return fmt.Errorf("cannot set exposed flag for application %q to %v: %v", s, exposed, onAbort(err, errNotAlive))
 	}
 	s.doc.Exposed = exposed
+	if !exposed {
+		s.doc.ExposedEndpoints = nil
+	}
+	return nil
+}
+
+// AllEndpoints is the endpoint name used in expose settings which
+// apply to all of an application's endpoints.
+const AllEndpoints = ""
+
+// ExposedEndpoint holds the expose settings for an endpoint of an
+// exposed application.
+type ExposedEndpoint struct {
+	// ExposeToCIDRs holds the CIDRs from which the endpoint may be
+	// accessed. If empty, it may be accessed from anywhere.
+	ExposeToCIDRs []string `bson:"to-cidrs,omitempty"`
+}
+
+// ExposedEndpoints returns the expose settings of the application's
+// endpoints, keyed by endpoint name; settings under AllEndpoints
+// apply to all of the endpoints. An exposed application without
+// expose settings may be accessed from anywhere.
+func (s *Application) ExposedEndpoints() map[string]ExposedEndpoint {
+	if len(s.doc.ExposedEndpoints) == 0 {
+		return nil
+	}
+	endpoints := make(map[string]ExposedEndpoint, len(s.doc.ExposedEndpoints))
+	for name, ep := range s.doc.ExposedEndpoints {
+		endpoints[name] = ExposedEndpoint{
+			ExposeToCIDRs: append([]string(nil), ep.ExposeToCIDRs...),
+		}
+	}
+	return endpoints
+}
+
+// MergeExposeSettings marks the application as exposed, and replaces
+// the expose settings of the given endpoints, leaving the settings of
+// other endpoints unchanged.
+func (s *Application) MergeExposeSettings(endpoints map[string]ExposedEndpoint) error {
+	for name, ep := range endpoints {
+		if name != AllEndpoints {
+			if _, err := s.Endpoint(name); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		for _, cidr := range ep.ExposeToCIDRs {
+			if _, _, err := net.ParseCIDR(cidr); err != nil {
+				return errors.NotValidf("CIDR %q", cidr)
+			}
+		}
+	}
+	var merged map[string]ExposedEndpoint
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := s.Refresh(); errors.IsNotFound(err) {
+				return nil, errNotAlive
+			} else if err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if s.doc.Life != Alive {
+			return nil, errNotAlive
+		}
+		// Merge with the settings last read, and assert that they
+		// have not changed since, so that concurrent merges do not
+		// overwrite each other.
+		merged = s.ExposedEndpoints()
+		if merged == nil {
+			merged = make(map[string]ExposedEndpoint)
+		}
+		for name, ep := range endpoints {
+			merged[name] = ep
+		}
+		return []txn.Op{{
+			C:      applicationsC,
+			Id:     s.doc.DocID,
+			Assert: bson.D{{"life", Alive}, {"txn-revno", s.doc.TxnRevno}},
+			Update: bson.D{{"$set", bson.D{
+				{"exposed", true},
+				{"exposed-endpoints", merged},
+			}}},
+		}}, nil
+	}
+	if err := s.st.run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot set expose settings for application %q", s)
+	}
+	s.doc.Exposed = true
+	s.doc.ExposedEndpoints = merged
 	return nil
 }
 
@@ -1393,6 +1393,84 @@ func (s *ServiceSuite) TestServiceExposed(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, notAliveErr)
 }
 
+func (s *ServiceSuite) TestMergeExposeSettings(c *gc.C) {
+	err := s.mysql.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		"server": {ExposeToCIDRs: []string{"10.0.0.0/8"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		state.AllEndpoints: {ExposeToCIDRs: []string{"192.168.0.0/16"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsExposed(), jc.IsTrue)
+
+	expected := map[string]state.ExposedEndpoint{
+		"server":           {ExposeToCIDRs: []string{"10.0.0.0/8"}},
+		state.AllEndpoints: {ExposeToCIDRs: []string{"192.168.0.0/16"}},
+	}
+	c.Assert(s.mysql.ExposedEndpoints(), jc.DeepEquals, expected)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.ExposedEndpoints(), jc.DeepEquals, expected)
+
+	// Unexposing the application clears its expose settings.
+	err = s.mysql.ClearExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.ExposedEndpoints(), gc.IsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.ExposedEndpoints(), gc.IsNil)
+}
+
+func (s *ServiceSuite) TestMergeExposeSettingsConcurrent(c *gc.C) {
+	defer state.SetBeforeHooks(c, s.State, func() {
+		mysql, err := s.State.Application("mysql")
+		c.Assert(err, jc.ErrorIsNil)
+		err = mysql.MergeExposeSettings(map[string]state.ExposedEndpoint{
+			"server": {ExposeToCIDRs: []string{"10.0.0.0/8"}},
+		})
+		c.Assert(err, jc.ErrorIsNil)
+	}).Check()
+
+	err := s.mysql.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		state.AllEndpoints: {ExposeToCIDRs: []string{"192.168.0.0/16"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The concurrent change is merged rather than overwritten.
+	expected := map[string]state.ExposedEndpoint{
+		"server":           {ExposeToCIDRs: []string{"10.0.0.0/8"}},
+		state.AllEndpoints: {ExposeToCIDRs: []string{"192.168.0.0/16"}},
+	}
+	c.Assert(s.mysql.ExposedEndpoints(), jc.DeepEquals, expected)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.ExposedEndpoints(), jc.DeepEquals, expected)
+}
+
+func (s *ServiceSuite) TestMergeExposeSettingsDying(c *gc.C) {
+	_, err := s.mysql.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.mysql.MergeExposeSettings(nil)
+	c.Assert(err, gc.ErrorMatches, `cannot set expose settings for application "mysql": not found or not alive`)
+}
+
+func (s *ServiceSuite) TestMergeExposeSettingsInvalid(c *gc.C) {
+	err := s.mysql.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		"website": {},
+	})
+	c.Assert(err, gc.ErrorMatches, `application "mysql" has no "website" relation`)
+
+	err = s.mysql.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		"server": {ExposeToCIDRs: []string{"10.0.0.0"}},
+	})
+	c.Assert(err, gc.ErrorMatches, `CIDR "10.0.0.0" not valid`)
+	c.Assert(s.mysql.IsExposed(), jc.IsFalse)
+}
+
 func (s *ServiceSuite) TestAddUnit(c *gc.C) {
 	// Check that principal units can be added on their own.
 	unitZero, err := s.mysql.AddUnit()
@@ -0,0 +1,107 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/audit"
+)
+
+type AuditSuite struct {
+	ConnSuite
+	start time.Time
+}
+
+var _ = gc.Suite(&AuditSuite{})
+
+func (s *AuditSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+
+	s.start = time.Date(2016, 10, 1, 12, 0, 0, 0, time.UTC)
+	put := s.State.PutAuditEntryFn()
+	for i, entry := range []struct {
+		user      string
+		operation string
+	}{
+		{"user-bob", "Client:v1 - FullStatus"},
+		{"user-bob", "Application:v1 - Deploy"},
+		{"user-mary", "Client:v1 - FullStatus"},
+	} {
+		err := put(audit.AuditEntry{
+			JujuServerVersion: version.MustParse("2.0.0"),
+			ModelUUID:         s.State.ModelUUID(),
+			Timestamp:         s.start.Add(time.Duration(i) * time.Minute),
+			RemoteAddress:     "10.0.0.1",
+			OriginType:        "API request",
+			OriginName:        entry.user,
+			Operation:         entry.operation,
+		})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+}
+
+func (s *AuditSuite) operations(c *gc.C, filter audit.Filter) []string {
+	entries, err := s.State.AuditEntries(filter)
+	c.Assert(err, jc.ErrorIsNil)
+	operations := make([]string, len(entries))
+	for i, entry := range entries {
+		operations[i] = entry.OriginName + " " + entry.Operation
+	}
+	return operations
+}
+
+func (s *AuditSuite) TestAuditEntriesAll(c *gc.C) {
+	c.Check(s.operations(c, audit.Filter{}), jc.DeepEquals, []string{
+		"user-mary Client:v1 - FullStatus",
+		"user-bob Application:v1 - Deploy",
+		"user-bob Client:v1 - FullStatus",
+	})
+}
+
+func (s *AuditSuite) TestAuditEntriesFiltered(c *gc.C) {
+	c.Check(s.operations(c, audit.Filter{OriginName: "user-bob"}), jc.DeepEquals, []string{
+		"user-bob Application:v1 - Deploy",
+		"user-bob Client:v1 - FullStatus",
+	})
+	c.Check(s.operations(c, audit.Filter{Operation: "Client"}), jc.DeepEquals, []string{
+		"user-mary Client:v1 - FullStatus",
+		"user-bob Client:v1 - FullStatus",
+	})
+	c.Check(s.operations(c, audit.Filter{Since: s.start.Add(time.Minute)}), jc.DeepEquals, []string{
+		"user-mary Client:v1 - FullStatus",
+		"user-bob Application:v1 - Deploy",
+	})
+	c.Check(s.operations(c, audit.Filter{Limit: 1}), jc.DeepEquals, []string{
+		"user-mary Client:v1 - FullStatus",
+	})
+	c.Check(s.operations(c, audit.Filter{ModelUUID: "00000000-0000-4000-8000-000000000000"}), gc.HasLen, 0)
+}
+
+func (s *AuditSuite) TestPruneAuditEntries(c *gc.C) {
+	err := s.State.PruneAuditEntries(s.start.Add(time.Minute))
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.operations(c, audit.Filter{}), jc.DeepEquals, []string{
+		"user-mary Client:v1 - FullStatus",
+		"user-bob Application:v1 - Deploy",
+	})
+}
+
+func (s *AuditSuite) TestAuditEntriesFrom(c *gc.C) {
+	entries, err := s.State.AuditEntriesFrom(s.start.Add(time.Minute), 0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, gc.HasLen, 2)
+	c.Check(entries[0].Operation, gc.Equals, "Application:v1 - Deploy")
+	c.Check(entries[1].OriginName, gc.Equals, "user-mary")
+
+	entries, err = s.State.AuditEntriesFrom(time.Time{}, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, gc.HasLen, 1)
+	c.Check(entries[0].Timestamp.Equal(s.start), jc.IsTrue)
+}
@@ -4,11 +4,14 @@
 package audit
 
 import (
-	"github.com/juju/errors"
+	"regexp"
+	"time"
 
-	"github.com/juju/juju/audit"
+	"github.com/juju/errors"
 	"github.com/juju/version"
+	"gopkg.in/mgo.v2/bson"
 
+	"github.com/juju/juju/audit"
 	"github.com/juju/juju/mongo/utils"
 )
 
@@ -27,6 +30,10 @@ type auditEntryDoc struct {
 	// unmarshaled via time.Time::UnmarshalText.
 	Timestamp string `bson:"timestamp"`
 
+	// Time is when the audit entry was written, in nanoseconds since
+	// the epoch. It is used to query and prune the collection.
+	Time int64 `bson:"time"`
+
 	// RemoteAddress is the IP of the machine from which the
 	// audit-event was triggered.
 	RemoteAddress string `bson:"remote-address"`
@@ -76,6 +83,7 @@ func auditEntryDocFromAuditEntry(auditEntry audit.AuditEntry) (auditEntryDoc, er
 		JujuServerVersion: auditEntry.JujuServerVersion,
 		ModelUUID:         auditEntry.ModelUUID,
 		Timestamp:         string(timeAsBlob),
+		Time:              auditEntry.Timestamp.UnixNano(),
 		RemoteAddress:     auditEntry.RemoteAddress,
 		OriginType:        auditEntry.OriginType,
 		OriginName:        auditEntry.OriginName,
@@ -83,3 +91,72 @@ func auditEntryDocFromAuditEntry(auditEntry audit.AuditEntry) (auditEntryDoc, er
 		Data:              utils.EscapeKeys(auditEntry.Data),
 	}, nil
 }
+
+// GetAuditEntriesFn creates a closure which when passed a Filter will
+// return the matching entries from the audit collection. findDocs must
+// unmarshal the docs matching the query, up to the limit (if non-zero),
+// into result; the entries are returned in the same order.
+func GetAuditEntriesFn(
+	collectionName string,
+	findDocs func(collectionName string, query bson.D, limit int, result interface{}) error,
+) func(audit.Filter) ([]audit.AuditEntry, error) {
+	return func(filter audit.Filter) ([]audit.AuditEntry, error) {
+		var docs []auditEntryDoc
+		if err := findDocs(collectionName, QueryForFilter(filter), filter.Limit, &docs); err != nil {
+			return nil, errors.Trace(err)
+		}
+		entries := make([]audit.AuditEntry, len(docs))
+		for i, doc := range docs {
+			entry, err := auditEntryFromAuditEntryDoc(doc)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			entries[i] = entry
+		}
+		return entries, nil
+	}
+}
+
+// QueryForFilter returns the query that selects the docs in the audit
+// collection that match the filter. Limit is not considered.
+func QueryForFilter(filter audit.Filter) bson.D {
+	query := bson.D{}
+	if filter.OriginName != "" {
+		query = append(query, bson.DocElem{"origin-name", filter.OriginName})
+	}
+	if filter.ModelUUID != "" {
+		query = append(query, bson.DocElem{"model-uuid", filter.ModelUUID})
+	}
+	if filter.Operation != "" {
+		query = append(query, bson.DocElem{"operation", bson.RegEx{
+			Pattern: "^" + regexp.QuoteMeta(filter.Operation),
+		}})
+	}
+	if !filter.Since.IsZero() {
+		query = append(query, bson.DocElem{"time", bson.D{{"$gte", filter.Since.UnixNano()}}})
+	}
+	return query
+}
+
+// QueryForPrune returns the query that selects the docs in the audit
+// collection that were written before the given time.
+func QueryForPrune(before time.Time) bson.D {
+	return bson.D{{"time", bson.D{{"$lt", before.UnixNano()}}}}
+}
+
+func auditEntryFromAuditEntryDoc(doc auditEntryDoc) (audit.AuditEntry, error) {
+	var timestamp time.Time
+	if err := timestamp.UnmarshalText([]byte(doc.Timestamp)); err != nil {
+		return audit.AuditEntry{}, errors.Trace(err)
+	}
+	return audit.AuditEntry{
+		JujuServerVersion: doc.JujuServerVersion,
+		ModelUUID:         doc.ModelUUID,
+		Timestamp:         timestamp.UTC(),
+		RemoteAddress:     doc.RemoteAddress,
+		OriginType:        doc.OriginType,
+		OriginName:        doc.OriginName,
+		Operation:         doc.Operation,
+		Data:              utils.UnescapeKeys(doc.Data),
+	}, nil
+}
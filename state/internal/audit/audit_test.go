@@ -59,6 +59,7 @@ func (*AuditSuite) TestPutAuditEntry_PersistAuditEntry(c *gc.C) {
 			"juju-server-version": requested.JujuServerVersion,
 			"model-uuid":          requested.ModelUUID,
 			"timestamp":           string(requestedTimeBlob),
+			"time":                requested.Timestamp.UnixNano(),
 			"remote-address":      "8.8.8.8",
 			"origin-type":         requested.OriginType,
 			"origin-name":         requested.OriginName,
@@ -112,3 +113,96 @@ func (*AuditSuite) TestPutAuditEntry_ValidateAuditEntry(c *gc.C) {
 	err := putAuditEntry(auditEntry)
 	c.Check(err, gc.ErrorMatches, validationErr.Error())
 }
+
+func (*AuditSuite) TestGetAuditEntries(c *gc.C) {
+	requested := audit.AuditEntry{
+		JujuServerVersion: version.MustParse("1.0.0"),
+		ModelUUID:         utils.MustNewUUID().String(),
+		Timestamp:         time.Now().UTC(),
+		RemoteAddress:     "8.8.8.8",
+		OriginType:        "API request",
+		OriginName:        "user-bob",
+		Operation:         "Client:v1 - FullStatus",
+		Data: map[string]interface{}{
+			"$a.b": "c",
+		},
+	}
+	var docs []interface{}
+	putAuditEntry := stateaudit.PutAuditEntryFn("audit.log", func(_ string, inserted ...interface{}) error {
+		docs = append(docs, inserted...)
+		return nil
+	})
+	err := putAuditEntry(requested)
+	c.Assert(err, jc.ErrorIsNil)
+
+	filter := audit.Filter{
+		OriginName: "user-bob",
+		Limit:      10,
+	}
+	findDocs := func(collectionName string, query bson.D, limit int, result interface{}) error {
+		c.Check(collectionName, gc.Equals, "audit.log")
+		c.Check(query, jc.DeepEquals, stateaudit.QueryForFilter(filter))
+		c.Check(limit, gc.Equals, 10)
+		return unmarshalDocs(docs, result)
+	}
+	getAuditEntries := stateaudit.GetAuditEntriesFn("audit.log", findDocs)
+	entries, err := getAuditEntries(filter)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, gc.HasLen, 1)
+	c.Check(entries[0].Timestamp.Equal(requested.Timestamp), jc.IsTrue)
+	entries[0].Timestamp = requested.Timestamp
+	c.Check(entries[0], jc.DeepEquals, requested)
+}
+
+func (*AuditSuite) TestGetAuditEntries_PropagatesReadError(c *gc.C) {
+	findDocs := func(string, bson.D, int, interface{}) error {
+		return errors.New("my error")
+	}
+	getAuditEntries := stateaudit.GetAuditEntriesFn("audit.log", findDocs)
+	_, err := getAuditEntries(audit.Filter{})
+	c.Check(err, gc.ErrorMatches, "my error")
+}
+
+func (*AuditSuite) TestQueryForFilter_Zero(c *gc.C) {
+	c.Check(stateaudit.QueryForFilter(audit.Filter{}), gc.HasLen, 0)
+}
+
+func (*AuditSuite) TestQueryForFilter_Full(c *gc.C) {
+	since := time.Unix(12345, 0)
+	query := stateaudit.QueryForFilter(audit.Filter{
+		OriginName: "user-bob",
+		ModelUUID:  "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		Operation:  "Client:v1 - Full.Status",
+		Since:      since,
+		Limit:      5,
+	})
+	c.Check(query, jc.DeepEquals, bson.D{
+		{"origin-name", "user-bob"},
+		{"model-uuid", "deadbeef-0bad-400d-8000-4b1d0d06f00d"},
+		{"operation", bson.RegEx{Pattern: `^Client:v1 - Full\.Status`}},
+		{"time", bson.D{{"$gte", since.UnixNano()}}},
+	})
+}
+
+func (*AuditSuite) TestQueryForPrune(c *gc.C) {
+	before := time.Unix(12345, 0)
+	c.Check(stateaudit.QueryForPrune(before), jc.DeepEquals, bson.D{
+		{"time", bson.D{{"$lt", before.UnixNano()}}},
+	})
+}
+
+// unmarshalDocs round-trips the docs through BSON into result, as
+// reading them from the database would.
+func unmarshalDocs(docs []interface{}, result interface{}) error {
+	data, err := bson.Marshal(bson.M{"docs": docs})
+	if err != nil {
+		return err
+	}
+	var wrapper struct {
+		Docs bson.Raw `bson:"docs"`
+	}
+	if err := bson.Unmarshal(data, &wrapper); err != nil {
+		return err
+	}
+	return wrapper.Docs.Unmarshal(result)
+}
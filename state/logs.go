@@ -650,11 +650,26 @@ func logDocToRecord(doc *logDoc) (*LogRecord, error) {
 	return rec, nil
 }
 
+// ModelLogLimits holds the limits on the log records of a single
+// model. Zero values mean that the limits passed to PruneLogs apply.
+type ModelLogLimits struct {
+	// MinLogTime is the time before which the model's log records
+	// are removed.
+	MinLogTime time.Time
+
+	// MaxLogsMB is the maximum size of the model's log records.
+	MaxLogsMB int
+}
+
 // PruneLogs removes old log documents in order to control the size of
-// logs collection. All logs older than minLogTime are
-// removed. Further removal is also performed if the logs collection
-// size is greater than maxLogsMB.
-func PruneLogs(st MongoSessioner, minLogTime time.Time, maxLogsMB int) error {
+// logs collection. All logs older than minLogTime, or than the
+// MinLogTime in the model's entry in modelLimits, are removed. Next,
+// the oldest logs of any model over its own MaxLogsMB are removed.
+// Further removal is also performed if the logs collection size is
+// greater than maxLogsMB, starting with the models using the greatest
+// share of their allowance so that one noisy model does not cause the
+// logs of other models to be removed.
+func PruneLogs(st MongoSessioner, minLogTime time.Time, maxLogsMB int, modelLimits map[string]ModelLogLimits) error {
 	session, logsColl := initLogsSession(st)
 	defer session.Close()
 
@@ -668,9 +683,13 @@ func PruneLogs(st MongoSessioner, minLogTime time.Time, maxLogsMB int) error {
 	// Remove old log entries (per model UUID to take advantage
 	// of indexes on the logs collection).
 	for _, modelUUID := range modelUUIDs {
+		modelMinLogTime := minLogTime
+		if t := modelLimits[modelUUID].MinLogTime; !t.IsZero() {
+			modelMinLogTime = t
+		}
 		removeInfo, err := logsColl.RemoveAll(bson.M{
 			"e": modelUUID,
-			"t": bson.M{"$lt": minLogTime.UnixNano()},
+			"t": bson.M{"$lt": modelMinLogTime.UnixNano()},
 		})
 		if err != nil {
 			return errors.Annotate(err, "failed to prune logs by time")
@@ -678,7 +697,32 @@ func PruneLogs(st MongoSessioner, minLogTime time.Time, maxLogsMB int) error {
 		pruneCounts[modelUUID] = removeInfo.Removed
 	}
 
+	// Remove the oldest log entries of any model over its own size
+	// limit.
+	sizes, err := getLogSizes(logsColl, modelUUIDs)
+	if err != nil {
+		return errors.Annotate(err, "failed to retrieve log sizes")
+	}
+	for _, modelUUID := range modelUUIDs {
+		maxBytes := int64(modelLimits[modelUUID].MaxLogsMB) * humanize.MiByte
+		size := sizes[modelUUID]
+		if maxBytes <= 0 || size.bytes <= maxBytes {
+			continue
+		}
+		// Remove the proportion of the model's records by which
+		// it is over its limit.
+		toRemove := size.count - int(int64(size.count)*maxBytes/size.bytes)
+		removed, err := removeOldestLogs(logsColl, modelUUID, toRemove)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		pruneCounts[modelUUID] += removed
+	}
+
 	// Do further pruning if the logs collection is over the maximum size.
+	// Models with too few records for pruning them to be worthwhile are
+	// skipped, so that others can still be pruned.
+	skip := make(map[string]bool)
 	for {
 		collMB, err := getCollectionMB(logsColl)
 		if err != nil {
@@ -688,40 +732,27 @@ func PruneLogs(st MongoSessioner, minLogTime time.Time, maxLogsMB int) error {
 			break
 		}
 
-		modelUUID, count, err := findEnvWithMostLogs(logsColl, modelUUIDs)
+		sizes, err := getLogSizes(logsColl, modelUUIDs)
 		if err != nil {
 			return errors.Annotate(err, "log count query failed")
 		}
+		modelUUID := findModelToPrune(sizes, modelLimits, maxLogsMB, skip)
+		if modelUUID == "" {
+			break // No model is worth pruning
+		}
+		count := sizes[modelUUID].count
 		if count < 5000 {
-			break // Pruning is not worthwhile
+			skip[modelUUID] = true
+			continue
 		}
 
 		// Remove the oldest 1% of log records for the model.
 		toRemove := int(float64(count) * 0.01)
-
-		// Find the threshold timestammp to start removing from.
-		// NOTE: this assumes that there are no more logs being added
-		// for the time range being pruned (which should be true for
-		// any realistic minimum log collection size).
-		tsQuery := logsColl.Find(bson.M{"e": modelUUID}).Sort("e", "t")
-		tsQuery = tsQuery.Skip(toRemove)
-		tsQuery = tsQuery.Select(bson.M{"t": 1})
-		var doc bson.M
-		err = tsQuery.One(&doc)
-		if err != nil {
-			return errors.Annotate(err, "log pruning timestamp query failed")
-		}
-		thresholdTs := doc["t"]
-
-		// Remove old records.
-		removeInfo, err := logsColl.RemoveAll(bson.M{
-			"e": modelUUID,
-			"t": bson.M{"$lt": thresholdTs},
-		})
+		removed, err := removeOldestLogs(logsColl, modelUUID, toRemove)
 		if err != nil {
-			return errors.Annotate(err, "log pruning failed")
+			return errors.Trace(err)
 		}
-		pruneCounts[modelUUID] += removeInfo.Removed
+		pruneCounts[modelUUID] += removed
 	}
 
 	for modelUUID, count := range pruneCounts {
@@ -732,6 +763,130 @@ func PruneLogs(st MongoSessioner, minLogTime time.Time, maxLogsMB int) error {
 	return nil
 }
 
+// removeOldestLogs removes the oldest toRemove log records of the
+// given model, returning the number of records actually removed.
+func removeOldestLogs(logsColl *mgo.Collection, modelUUID string, toRemove int) (int, error) {
+	// Find the threshold timestammp to start removing from.
+	// NOTE: this assumes that there are no more logs being added
+	// for the time range being pruned (which should be true for
+	// any realistic minimum log collection size).
+	tsQuery := logsColl.Find(bson.M{"e": modelUUID}).Sort("e", "t")
+	tsQuery = tsQuery.Skip(toRemove)
+	tsQuery = tsQuery.Select(bson.M{"t": 1})
+	query := bson.M{"e": modelUUID}
+	var doc bson.M
+	switch err := tsQuery.One(&doc); err {
+	case nil:
+		query["t"] = bson.M{"$lt": doc["t"]}
+	case mgo.ErrNotFound:
+		// The model has no more than toRemove records, so they
+		// are all removed.
+	default:
+		return 0, errors.Annotate(err, "log pruning timestamp query failed")
+	}
+
+	// Remove old records.
+	removeInfo, err := logsColl.RemoveAll(query)
+	if err != nil {
+		return 0, errors.Annotate(err, "log pruning failed")
+	}
+	return removeInfo.Removed, nil
+}
+
+// logSize holds the number of log records of a model and their
+// approximate size.
+type logSize struct {
+	count int
+	bytes int64
+}
+
+// getLogSizes returns the number and approximate size of the log
+// records of each of the given models. We assume that log records
+// are of a similar size across models, so each model is apportioned
+// the share of the collection size matching its share of the records.
+func getLogSizes(logsColl *mgo.Collection, modelUUIDs []string) (map[string]logSize, error) {
+	collBytes, err := getCollectionBytes(logsColl)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	sizes := make(map[string]logSize)
+	var total int
+	for _, modelUUID := range modelUUIDs {
+		count, err := getLogCountForEnv(logsColl, modelUUID)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		sizes[modelUUID] = logSize{count: count}
+		total += count
+	}
+	if total == 0 {
+		return sizes, nil
+	}
+	for modelUUID, size := range sizes {
+		size.bytes = collBytes * int64(size.count) / int64(total)
+		sizes[modelUUID] = size
+	}
+	return sizes, nil
+}
+
+// findModelToPrune returns the UUID of the model, other than those in
+// skip, using the greatest share of its allowance: its own MaxLogsMB if
+// set, or otherwise an equal share of maxLogsMB. It returns the empty
+// string if every model is skipped.
+func findModelToPrune(sizes map[string]logSize, modelLimits map[string]ModelLogLimits, maxLogsMB int, skip map[string]bool) string {
+	fairShareBytes := float64(maxLogsMB) * humanize.MiByte / float64(len(sizes))
+	var maxModelUUID string
+	var maxUsage float64
+	for modelUUID, size := range sizes {
+		if skip[modelUUID] {
+			continue
+		}
+		allowance := fairShareBytes
+		if mb := modelLimits[modelUUID].MaxLogsMB; mb > 0 {
+			allowance = float64(mb) * humanize.MiByte
+		}
+		usage := float64(size.bytes)
+		if allowance > 0 {
+			usage /= allowance
+		}
+		if maxModelUUID == "" || usage > maxUsage {
+			maxModelUUID = modelUUID
+			maxUsage = usage
+		}
+	}
+	return maxModelUUID
+}
+
+// ModelLogsSize returns the approximate size, in bytes, of the log
+// records stored for the given model.
+func ModelLogsSize(st MongoSessioner, modelUUID string) (int64, error) {
+	session, logsColl := initLogsSession(st)
+	defer session.Close()
+
+	collBytes, err := getCollectionBytes(logsColl)
+	if err != nil {
+		return 0, errors.Annotate(err, "failed to retrieve log collection size")
+	}
+	total, err := logsColl.Count()
+	if err != nil {
+		return 0, errors.Annotate(err, "failed to get log count")
+	}
+	if total == 0 {
+		return 0, nil
+	}
+	count, err := getLogCountForEnv(logsColl, modelUUID)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	return collBytes * int64(count) / int64(total), nil
+}
+
+// ModelLogsSize returns the approximate size, in bytes, of the log
+// records stored for the State's model.
+func (st *State) ModelLogsSize() (int64, error) {
+	return ModelLogsSize(st, st.ModelUUID())
+}
+
 // initLogsSession creates a new session suitable for logging updates,
 // returning the session and a logs mgo.Collection connected to that
 // session.
@@ -761,6 +916,25 @@ func getCollectionMB(coll *mgo.Collection) (int, error) {
 	return result["size"].(int), nil
 }
 
+// getCollectionBytes returns the size of a MongoDB collection (in
+// bytes), excluding space used by indexes.
+func getCollectionBytes(coll *mgo.Collection) (int64, error) {
+	var result bson.M
+	err := coll.Database.Run(bson.D{{"collStats", coll.Name}}, &result)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	switch size := result["size"].(type) {
+	case int:
+		return int64(size), nil
+	case int64:
+		return size, nil
+	case float64:
+		return int64(size), nil
+	}
+	return 0, errors.Errorf("unexpected collection size %v", result["size"])
+}
+
 // getEnvsInLogs returns the unique model UUIDs that exist in
 // the logs collection. This uses the one of the indexes on the
 // collection and should be fast.
@@ -773,24 +947,6 @@ func getEnvsInLogs(coll *mgo.Collection) ([]string, error) {
 	return modelUUIDs, nil
 }
 
-// findEnvWithMostLogs returns the modelUUID and log count for the
-// model with the most logs in the logs collection.
-func findEnvWithMostLogs(logsColl *mgo.Collection, modelUUIDs []string) (string, int, error) {
-	var maxModelUUID string
-	var maxCount int
-	for _, modelUUID := range modelUUIDs {
-		count, err := getLogCountForEnv(logsColl, modelUUID)
-		if err != nil {
-			return "", -1, errors.Trace(err)
-		}
-		if count > maxCount {
-			maxModelUUID = modelUUID
-			maxCount = count
-		}
-	}
-	return maxModelUUID, maxCount, nil
-}
-
 // getLogCountForEnv returns the number of log records stored for a
 // given model.
 func getLogCountForEnv(coll *mgo.Collection, modelUUID string) (int, error) {
@@ -214,7 +214,7 @@ func (s *LogsSuite) TestPruneLogsByTime(c *gc.C) {
 	log(maxLogTime.Add(-(2 * time.Second)), "prune")
 
 	noPruneMB := 100
-	err := state.PruneLogs(s.State, maxLogTime, noPruneMB)
+	err := state.PruneLogs(s.State, maxLogTime, noPruneMB, nil)
 	c.Assert(err, jc.ErrorIsNil)
 
 	// After pruning there should just be 3 "keep" messages left.
@@ -248,7 +248,7 @@ func (s *LogsSuite) TestPruneLogsBySize(c *gc.C) {
 
 	// Prune logs collection back to 1 MiB.
 	tsNoPrune := time.Now().Add(-3 * 24 * time.Hour)
-	err := state.PruneLogs(s.State, tsNoPrune, 1)
+	err := state.PruneLogs(s.State, tsNoPrune, 1, nil)
 	c.Assert(err, jc.ErrorIsNil)
 
 	// Logs for first env should not be touched.
@@ -273,6 +273,117 @@ func (s *LogsSuite) TestPruneLogsBySize(c *gc.C) {
 	assertLatestTs(s2)
 }
 
+func (s *LogsSuite) TestPruneLogsByModelTime(c *gc.C) {
+	now := time.Now().Truncate(time.Millisecond)
+	s0 := s.State
+	s.generateLogs(c, s0, now, 10)
+	s1 := s.Factory.MakeModel(c, nil)
+	defer s1.Close()
+	s.generateLogs(c, s1, now, 10)
+
+	// Only the first model's logs are pruned, and only those older
+	// than its own limit.
+	tsNoPrune := now.Add(-3 * 24 * time.Hour)
+	err := state.PruneLogs(s.State, tsNoPrune, 100, map[string]state.ModelLogLimits{
+		s0.ModelUUID(): {MinLogTime: now.Add(-4 * time.Second)},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.countLogs(c, s0), gc.Equals, 5)
+	c.Assert(s.countLogs(c, s1), gc.Equals, 10)
+}
+
+func (s *LogsSuite) TestPruneLogsByModelSize(c *gc.C) {
+	now := time.Now().Truncate(time.Millisecond)
+	s0 := s.State
+	startingLogsS0 := 10
+	s.generateLogs(c, s0, now, startingLogsS0)
+	s1 := s.Factory.MakeModel(c, nil)
+	defer s1.Close()
+	startingLogsS1 := 20000
+	s.generateLogs(c, s1, now, startingLogsS1)
+
+	// Prune the second model's logs back to 1 MiB, leaving the
+	// logs collection well under its limit.
+	tsNoPrune := now.Add(-3 * 24 * time.Hour)
+	err := state.PruneLogs(s.State, tsNoPrune, 1000, map[string]state.ModelLogLimits{
+		s1.ModelUUID(): {MaxLogsMB: 1},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.countLogs(c, s0), gc.Equals, startingLogsS0)
+	c.Assert(s.countLogs(c, s1), jc.LessThan, startingLogsS1)
+
+	// The latest log records are still there.
+	var doc bson.M
+	err = s.logsColl.Find(bson.M{"e": s1.ModelUUID()}).Sort("-t").One(&doc)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(doc["t"], gc.Equals, now.UnixNano())
+}
+
+func (s *LogsSuite) TestPruneLogsBySizePrioritised(c *gc.C) {
+	now := time.Now().Truncate(time.Millisecond)
+	s1 := s.Factory.MakeModel(c, nil)
+	defer s1.Close()
+	startingLogsS1 := 6000
+	s.generateLogs(c, s1, now, startingLogsS1)
+	s2 := s.Factory.MakeModel(c, nil)
+	defer s2.Close()
+	startingLogsS2 := 20000
+	s.generateLogs(c, s2, now, startingLogsS2)
+
+	// The first model is allowed plenty of space, so the logs
+	// collection is pruned back to 1 MiB at the expense of the
+	// second model.
+	tsNoPrune := now.Add(-3 * 24 * time.Hour)
+	err := state.PruneLogs(s.State, tsNoPrune, 1, map[string]state.ModelLogLimits{
+		s1.ModelUUID(): {MaxLogsMB: 1000},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.countLogs(c, s1), gc.Equals, startingLogsS1)
+	c.Assert(s.countLogs(c, s2), jc.LessThan, startingLogsS2)
+}
+
+func (s *LogsSuite) TestPruneLogsBySizeSkipsSmallModels(c *gc.C) {
+	now := time.Now().Truncate(time.Millisecond)
+	s1 := s.Factory.MakeModel(c, nil)
+	defer s1.Close()
+	startingLogsS1 := 4000
+	s.generateLogs(c, s1, now, startingLogsS1)
+	s2 := s.Factory.MakeModel(c, nil)
+	defer s2.Close()
+	startingLogsS2 := 20000
+	s.generateLogs(c, s2, now, startingLogsS2)
+
+	// The first model uses the greatest share of its allowance, but
+	// has too few logs for pruning them to be worthwhile, so the
+	// second model is pruned instead.
+	tsNoPrune := now.Add(-3 * 24 * time.Hour)
+	err := state.PruneLogs(s.State, tsNoPrune, 1, map[string]state.ModelLogLimits{
+		s2.ModelUUID(): {MaxLogsMB: 1000},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.countLogs(c, s1), gc.Equals, startingLogsS1)
+	c.Assert(s.countLogs(c, s2), jc.LessThan, startingLogsS2)
+}
+
+func (s *LogsSuite) TestModelLogsSize(c *gc.C) {
+	size, err := state.ModelLogsSize(s.State, s.State.ModelUUID())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(size, gc.Equals, int64(0))
+
+	now := time.Now()
+	s.generateLogs(c, s.State, now, 10)
+	s1 := s.Factory.MakeModel(c, nil)
+	defer s1.Close()
+	s.generateLogs(c, s1, now, 1000)
+
+	size0, err := state.ModelLogsSize(s.State, s.State.ModelUUID())
+	c.Assert(err, jc.ErrorIsNil)
+	size1, err := state.ModelLogsSize(s.State, s1.ModelUUID())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(size0 > 0, jc.IsTrue)
+	c.Assert(size1 > size0, jc.IsTrue)
+}
+
 func (s *LogsSuite) generateLogs(c *gc.C, st *state.State, endTime time.Time, count int) {
 	dbLogger := state.NewDbLogger(st, names.NewMachineTag("0"), jujuversion.Current)
 	defer dbLogger.Close()
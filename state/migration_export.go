@@ -393,6 +393,7 @@ func (e *exporter) openedPortsArgsForMachine(machineId string, portsData []ports
 					FromPort: p.FromPort,
 					ToPort:   p.ToPort,
 					Protocol: p.Protocol,
+					Endpoint: p.Endpoint,
 				})
 			}
 			result = append(result, args)
@@ -592,6 +593,14 @@ func (e *exporter) addApplication(ctx addApplicationContext) error {
 		LeadershipSettings:   leadershipSettingsDoc.Settings,
 		MetricsCredentials:   application.doc.MetricCredentials,
 	}
+	if len(application.doc.ExposedEndpoints) > 0 {
+		args.ExposedEndpoints = make(map[string]description.ExposedEndpointArgs)
+		for name, ep := range application.doc.ExposedEndpoints {
+			args.ExposedEndpoints[name] = description.ExposedEndpointArgs{
+				ExposeToCIDRs: ep.ExposeToCIDRs,
+			}
+		}
+	}
 	globalKey := application.globalKey()
 	if constraints, found := ctx.storageConstraints[globalKey]; found {
 		args.StorageConstraints = e.storageConstraints(constraints)
@@ -1180,6 +1189,9 @@ func (e *exporter) constraintsArgs(globalKey string) (description.ConstraintsArg
 		Spaces:       optionalStringSlice("spaces"),
 		Tags:         optionalStringSlice("tags"),
 		VirtType:     optionalString("virttype"),
+		Zones:        optionalStringSlice("zones"),
+
+		PlacementGroup: optionalString("placementgroup"),
 	}
 	if optionalErr != nil {
 		return description.ConstraintsArgs{}, errors.Trace(optionalErr)
@@ -1202,6 +1214,9 @@ func (e *exporter) storage() error {
 	if err := e.volumes(); err != nil {
 		return errors.Trace(err)
 	}
+	if err := e.volumeSnapshots(); err != nil {
+		return errors.Trace(err)
+	}
 	if err := e.filesystems(); err != nil {
 		return errors.Trace(err)
 	}
@@ -1214,6 +1229,42 @@ func (e *exporter) storage() error {
 	return nil
 }
 
+func (e *exporter) volumeSnapshots() error {
+	coll, closer := e.st.getCollection(volumeSnapshotsC)
+	defer closer()
+
+	var doc volumeSnapshotDoc
+	iter := coll.Find(nil).Sort("_id").Iter()
+	defer iter.Close()
+	for iter.Next(&doc) {
+		args := description.VolumeSnapshotArgs{
+			ID:      doc.Id,
+			Volume:  names.NewVolumeTag(doc.Volume),
+			Pool:    doc.Pool,
+			Created: time.Unix(0, doc.Created),
+		}
+		if doc.StorageId != "" {
+			args.Storage = names.NewStorageTag(doc.StorageId)
+		}
+		if doc.Info != nil {
+			args.Provisioned = true
+			args.SnapshotID = doc.Info.SnapshotId
+			args.Size = doc.Info.Size
+		}
+		globalKey := volumeSnapshotGlobalKey(doc.Id)
+		statusArgs, err := e.statusArgs(globalKey)
+		if err != nil {
+			return errors.Annotatef(err, "status for volume snapshot %s", doc.Id)
+		}
+		exSnapshot := e.model.AddVolumeSnapshot(args)
+		exSnapshot.SetStatus(statusArgs)
+	}
+	if err := iter.Err(); err != nil {
+		return errors.Annotate(err, "failed to read volume snapshots")
+	}
+	return nil
+}
+
 func (e *exporter) volumes() error {
 	coll, closer := e.st.getCollection(volumesC)
 	defer closer()
@@ -1253,6 +1304,7 @@ func (e *exporter) addVolume(vol *volume, volAttachments []volumeAttachmentDoc)
 		}
 	}
 	logger.Debugf("addVolume: %#v", vol.doc)
+	args.RequestedSize = vol.doc.RequestedSize
 	if info, err := vol.Info(); err == nil {
 		logger.Debugf("  info %#v", info)
 		args.Provisioned = true
@@ -1266,6 +1318,7 @@ func (e *exporter) addVolume(vol *volume, volAttachments []volumeAttachmentDoc)
 		logger.Debugf("  params %#v", params)
 		args.Size = params.Size
 		args.Pool = params.Pool
+		args.SnapshotID = params.SnapshotId
 	}
 
 	globalKey := vol.globalKey()
@@ -229,6 +229,10 @@ func (s *MigrationExportSuite) TestMachinesWithVirtConstraint(c *gc.C) {
 	s.assertMachinesMigrated(c, constraints.MustParse("arch=amd64 mem=8G virt-type=kvm"))
 }
 
+func (s *MigrationExportSuite) TestMachinesWithPlacementGroupConstraint(c *gc.C) {
+	s.assertMachinesMigrated(c, constraints.MustParse("arch=amd64 mem=8G placement-group=spread"))
+}
+
 func (s *MigrationExportSuite) assertMachinesMigrated(c *gc.C, cons constraints.Value) {
 	// Add a machine with an LXC container.
 	machine1 := s.Factory.MakeMachine(c, &factory.MachineParams{
@@ -256,6 +260,9 @@ func (s *MigrationExportSuite) assertMachinesMigrated(c *gc.C, cons constraints.
 	if cons.HasVirtType() {
 		c.Assert(constraints.VirtType(), gc.Equals, *cons.VirtType)
 	}
+	if cons.HasPlacementGroup() {
+		c.Assert(constraints.PlacementGroup(), gc.Equals, *cons.PlacementGroup)
+	}
 
 	tools, err := machine1.AgentTools()
 	c.Assert(err, jc.ErrorIsNil)
@@ -339,6 +346,10 @@ func (s *MigrationExportSuite) assertMigrateApplications(c *gc.C, cons constrain
 	c.Assert(err, jc.ErrorIsNil)
 	err = application.SetMetricCredentials([]byte("sekrit"))
 	c.Assert(err, jc.ErrorIsNil)
+	err = application.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		state.AllEndpoints: {ExposeToCIDRs: []string{"10.0.0.0/8"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
 	err = s.State.SetAnnotations(application, testAnnotations)
 	c.Assert(err, jc.ErrorIsNil)
 	s.primeStatusHistory(c, application, status.StatusActive, addedHistoryCount)
@@ -362,6 +373,10 @@ func (s *MigrationExportSuite) assertMigrateApplications(c *gc.C, cons constrain
 		"leader": "true",
 	})
 	c.Assert(exported.MetricsCredentials(), jc.DeepEquals, []byte("sekrit"))
+	c.Assert(exported.Exposed(), jc.IsTrue)
+	exposedEndpoints := exported.ExposedEndpoints()
+	c.Assert(exposedEndpoints, gc.HasLen, 1)
+	c.Assert(exposedEndpoints[""].ExposeToCIDRs(), jc.DeepEquals, []string{"10.0.0.0/8"})
 
 	constraints := exported.Constraints()
 	c.Assert(constraints, gc.NotNil)
@@ -467,6 +482,8 @@ func (s *MigrationExportSuite) TestUnitsOpenPorts(c *gc.C) {
 	unit := s.Factory.MakeUnit(c, nil)
 	err := unit.OpenPorts("tcp", 1234, 2345)
 	c.Assert(err, jc.ErrorIsNil)
+	err = unit.OpenEndpointPorts("server", "tcp", 3306, 3306)
+	c.Assert(err, jc.ErrorIsNil)
 
 	model, err := s.State.Export()
 	c.Assert(err, jc.ErrorIsNil)
@@ -480,8 +497,11 @@ func (s *MigrationExportSuite) TestUnitsOpenPorts(c *gc.C) {
 	port := ports[0]
 	c.Assert(port.SubnetID(), gc.Equals, "")
 	opened := port.OpenPorts()
-	c.Assert(opened, gc.HasLen, 1)
+	c.Assert(opened, gc.HasLen, 2)
 	c.Assert(opened[0].UnitName(), gc.Equals, unit.Name())
+	c.Assert(opened[0].Endpoint(), gc.Equals, "")
+	c.Assert(opened[1].UnitName(), gc.Equals, unit.Name())
+	c.Assert(opened[1].Endpoint(), gc.Equals, "server")
 }
 
 func (s *MigrationExportSuite) TestRelations(c *gc.C) {
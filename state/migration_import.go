@@ -387,6 +387,7 @@ func (i *importer) machinePortsOps(m description.Machine) []txn.Op {
 				FromPort: opened.FromPort(),
 				ToPort:   opened.ToPort(),
 				Protocol: opened.Protocol(),
+				Endpoint: opened.Endpoint(),
 			})
 		}
 		result = append(result, txn.Op{
@@ -799,6 +800,16 @@ func (i *importer) makeApplicationDoc(s description.Application) (*applicationDo
 		return nil, errors.Trace(err)
 	}
 
+	var exposedEndpoints map[string]ExposedEndpoint
+	if endpoints := s.ExposedEndpoints(); len(endpoints) > 0 {
+		exposedEndpoints = make(map[string]ExposedEndpoint)
+		for name, ep := range endpoints {
+			exposedEndpoints[name] = ExposedEndpoint{
+				ExposeToCIDRs: ep.ExposeToCIDRs(),
+			}
+		}
+	}
+
 	return &applicationDoc{
 		Name:                 s.Name(),
 		Series:               s.Series(),
@@ -811,6 +822,7 @@ func (i *importer) makeApplicationDoc(s description.Application) (*applicationDo
 		UnitCount:            len(s.Units()),
 		RelationCount:        i.relationCount(s.Name()),
 		Exposed:              s.Exposed(),
+		ExposedEndpoints:     exposedEndpoints,
 		MinUnits:             s.MinUnits(),
 		MetricCredentials:    s.MetricsCredentials(),
 	}, nil
@@ -1273,6 +1285,12 @@ func (i *importer) constraints(cons description.Constraints) constraints.Value {
 	if virt := cons.VirtType(); virt != "" {
 		result.VirtType = &virt
 	}
+	if zones := cons.Zones(); len(zones) > 0 {
+		result.Zones = &zones
+	}
+	if group := cons.PlacementGroup(); group != "" {
+		result.PlacementGroup = &group
+	}
 	return result
 }
 
@@ -1283,6 +1301,9 @@ func (i *importer) storage() error {
 	if err := i.volumes(); err != nil {
 		return errors.Annotate(err, "volumes")
 	}
+	if err := i.volumeSnapshots(); err != nil {
+		return errors.Annotate(err, "volume snapshots")
+	}
 	if err := i.filesystems(); err != nil {
 		return errors.Annotate(err, "filesystems")
 	}
@@ -1352,6 +1373,39 @@ func (i *importer) volumes() error {
 	return nil
 }
 
+func (i *importer) volumeSnapshots() error {
+	i.logger.Debugf("importing volume snapshots")
+	var ops []txn.Op
+	for _, snapshot := range i.model.VolumeSnapshots() {
+		doc := volumeSnapshotDoc{
+			Id:        snapshot.ID(),
+			Volume:    snapshot.Volume().Id(),
+			StorageId: snapshot.Storage().Id(),
+			Pool:      snapshot.Pool(),
+			Created:   snapshot.Created().UnixNano(),
+		}
+		if snapshot.Provisioned() {
+			doc.Info = &VolumeSnapshotInfo{
+				SnapshotId: snapshot.SnapshotID(),
+				Size:       snapshot.Size(),
+			}
+		}
+		ops = append(ops, txn.Op{
+			C:      volumeSnapshotsC,
+			Id:     doc.Id,
+			Assert: txn.DocMissing,
+			Insert: &doc,
+		}, createStatusOp(i.st, volumeSnapshotGlobalKey(doc.Id), i.makeStatusDoc(snapshot.Status())))
+	}
+	if len(ops) > 0 {
+		if err := i.st.runTransaction(ops); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	i.logger.Debugf("importing volume snapshots succeeded")
+	return nil
+}
+
 func (i *importer) addVolume(volume description.Volume) error {
 
 	attachments := volume.Attachments()
@@ -1376,8 +1430,9 @@ func (i *importer) addVolume(volume description.Volume) error {
 		}
 	} else {
 		params = &VolumeParams{
-			Size: volume.Size(),
-			Pool: volume.Pool(),
+			Size:       volume.Size(),
+			Pool:       volume.Pool(),
+			SnapshotId: volume.SnapshotID(),
 		}
 	}
 	doc := volumeDoc{
@@ -1388,6 +1443,7 @@ func (i *importer) addVolume(volume description.Volume) error {
 		Params:          params,
 		Info:            info,
 		AttachmentCount: len(attachments),
+		RequestedSize:   volume.RequestedSize(),
 	}
 	status := i.makeStatusDoc(volume.Status())
 	ops := i.st.newVolumeOps(doc, status)
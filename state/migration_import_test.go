@@ -325,6 +325,10 @@ func (s *MigrationImportSuite) TestApplications(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	// Expose the application.
 	c.Assert(application.SetExposed(), jc.ErrorIsNil)
+	err = application.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		state.AllEndpoints: {ExposeToCIDRs: []string{"10.0.0.0/8"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
 	err = s.State.SetAnnotations(application, testAnnotations)
 	c.Assert(err, jc.ErrorIsNil)
 	s.primeStatusHistory(c, application, status.StatusActive, 5)
@@ -345,6 +349,7 @@ func (s *MigrationImportSuite) TestApplications(c *gc.C) {
 	c.Assert(imported.ApplicationTag(), gc.Equals, exported.ApplicationTag())
 	c.Assert(imported.Series(), gc.Equals, exported.Series())
 	c.Assert(imported.IsExposed(), gc.Equals, exported.IsExposed())
+	c.Assert(imported.ExposedEndpoints(), jc.DeepEquals, exported.ExposedEndpoints())
 	c.Assert(imported.MetricCredentials(), jc.DeepEquals, exported.MetricCredentials())
 
 	exportedConfig, err := exported.ConfigSettings()
@@ -394,6 +399,14 @@ func (s *MigrationImportSuite) TestUnitsWithVirtConstraint(c *gc.C) {
 	s.assertUnitsMigrated(c, constraints.MustParse("arch=amd64 mem=8G virt-type=kvm"))
 }
 
+func (s *MigrationImportSuite) TestUnitsWithZonesConstraint(c *gc.C) {
+	s.assertUnitsMigrated(c, constraints.MustParse("arch=amd64 mem=8G zones=az1,az2"))
+}
+
+func (s *MigrationImportSuite) TestUnitsWithPlacementGroupConstraint(c *gc.C) {
+	s.assertUnitsMigrated(c, constraints.MustParse("arch=amd64 mem=8G placement-group=cluster"))
+}
+
 func (s *MigrationImportSuite) assertUnitsMigrated(c *gc.C, cons constraints.Value) {
 	exported, pwd := s.Factory.MakeUnitReturningPassword(c, &factory.UnitParams{
 		Constraints: cons,
@@ -492,6 +505,8 @@ func (s *MigrationImportSuite) TestUnitsOpenPorts(c *gc.C) {
 	unit := s.Factory.MakeUnit(c, nil)
 	err := unit.OpenPorts("tcp", 1234, 2345)
 	c.Assert(err, jc.ErrorIsNil)
+	err = unit.OpenEndpointPorts("server", "tcp", 3306, 3306)
+	c.Assert(err, jc.ErrorIsNil)
 
 	_, newSt := s.importModel(c)
 
@@ -502,11 +517,25 @@ func (s *MigrationImportSuite) TestUnitsOpenPorts(c *gc.C) {
 
 	ports, err := imported.OpenedPorts()
 	c.Assert(err, jc.ErrorIsNil)
-	c.Assert(ports, gc.HasLen, 1)
-	c.Assert(ports[0], gc.Equals, network.PortRange{
+	c.Assert(ports, jc.DeepEquals, []network.PortRange{{
 		FromPort: 1234,
 		ToPort:   2345,
 		Protocol: "tcp",
+	}, {
+		FromPort: 3306,
+		ToPort:   3306,
+		Protocol: "tcp",
+	}})
+
+	machineId, err := imported.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	machine, err := newSt.Machine(machineId)
+	c.Assert(err, jc.ErrorIsNil)
+	machinePorts, err := machine.OpenedPorts("")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machinePorts.PortRangeEndpoints(), jc.DeepEquals, map[network.PortRange]string{
+		{1234, 2345, "tcp"}: "",
+		{3306, 3306, "tcp"}: "server",
 	})
 }
 
@@ -789,6 +818,56 @@ func (s *MigrationImportSuite) TestVolumes(c *gc.C) {
 	c.Check(attParams.ReadOnly, jc.IsTrue)
 }
 
+func (s *MigrationImportSuite) TestVolumeSnapshots(c *gc.C) {
+	s.Factory.MakeMachine(c, &factory.MachineParams{
+		Volumes: []state.MachineVolumeParams{{
+			Volume: state.VolumeParams{Pool: "environscoped", Size: 1024},
+		}},
+	})
+	volTag := names.NewVolumeTag("0")
+	err := s.State.SetVolumeInfo(volTag, state.VolumeInfo{
+		Size:     1024,
+		Pool:     "environscoped",
+		VolumeId: "vol-0",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	taken, err := s.State.CreateVolumeSnapshot(volTag)
+	c.Assert(err, jc.ErrorIsNil)
+	snapshotInfo := state.VolumeSnapshotInfo{SnapshotId: "snap-0", Size: 1024}
+	err = s.State.SetVolumeSnapshotInfo(taken.Id(), snapshotInfo)
+	c.Assert(err, jc.ErrorIsNil)
+	pending, err := s.State.CreateVolumeSnapshot(volTag)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, newSt := s.importModel(c)
+
+	snapshot, err := newSt.VolumeSnapshot(taken.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(snapshot.Volume(), gc.Equals, volTag)
+	c.Check(snapshot.Pool(), gc.Equals, "environscoped")
+	c.Check(snapshot.Created(), gc.Equals, taken.Created())
+	info, err := snapshot.Info()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(info, jc.DeepEquals, snapshotInfo)
+	statusInfo, err := snapshot.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(statusInfo.Status, gc.Equals, status.StatusAvailable)
+
+	snapshot, err = newSt.VolumeSnapshot(pending.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = snapshot.Info()
+	c.Check(err, jc.Satisfies, errors.IsNotProvisioned)
+	statusInfo, err = snapshot.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(statusInfo.Status, gc.Equals, status.StatusPending)
+
+	// The sequence is migrated, so new snapshots get new IDs.
+	next, err := newSt.CreateVolumeSnapshot(volTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(next.Id(), gc.Equals, "2")
+}
+
 func (s *MigrationImportSuite) TestFilesystems(c *gc.C) {
 	machine := s.Factory.MakeMachine(c, &factory.MachineParams{
 		Filesystems: []state.MachineFilesystemParams{{
@@ -66,6 +66,7 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		storageInstancesC,
 		volumesC,
 		volumeAttachmentsC,
+		volumeSnapshotsC,
 	)
 
 	ignoredCollections := set.NewStrings(
@@ -96,6 +97,8 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		usermodelnameC,
 		// Metrics aren't migrated.
 		metricsC,
+		// Hook run history is diagnostic, and isn't migrated.
+		unitHookRunsC,
 		// Backup and restore information is not migrated.
 		restoreInfoC,
 		// reference counts are implementation details that should be
@@ -339,6 +342,7 @@ func (s *MigrationSuite) TestApplicationDocFields(c *gc.C) {
 		"CharmModifiedVersion",
 		"ForceCharm",
 		"Exposed",
+		"ExposedEndpoints",
 		"MinUnits",
 		"MetricCredentials",
 	)
@@ -505,6 +509,8 @@ func (s *MigrationSuite) TestConstraintsDocFields(c *gc.C) {
 		"Tags",
 		"Spaces",
 		"VirtType",
+		"Zones",
+		"PlacementGroup",
 	)
 	s.AssertExportedFields(c, constraintsDoc{}, fields)
 }
@@ -664,13 +670,32 @@ func (s *MigrationSuite) TestVolumeDocFields(c *gc.C) {
 		"Binding",
 		"Info",
 		"Params",
+		"RequestedSize",
 	)
 	s.AssertExportedFields(c, volumeDoc{}, migrated.Union(ignored))
 	// The info and params fields ar structs.
 	s.AssertExportedFields(c, VolumeInfo{}, set.NewStrings(
 		"HardwareId", "Size", "Pool", "VolumeId", "Persistent"))
 	s.AssertExportedFields(c, VolumeParams{}, set.NewStrings(
-		"Size", "Pool"))
+		"Size", "Pool", "SnapshotId"))
+}
+
+func (s *MigrationSuite) TestVolumeSnapshotDocFields(c *gc.C) {
+	ignored := set.NewStrings(
+		"ModelUUID",
+		"DocID",
+	)
+	migrated := set.NewStrings(
+		"Id",
+		"Volume",
+		"StorageId",
+		"Pool",
+		"Created",
+		"Info",
+	)
+	s.AssertExportedFields(c, volumeSnapshotDoc{}, migrated.Union(ignored))
+	s.AssertExportedFields(c, VolumeSnapshotInfo{}, set.NewStrings(
+		"SnapshotId", "Size"))
 }
 
 func (s *MigrationSuite) TestVolumeAttachmentDocFields(c *gc.C) {
@@ -6,6 +6,10 @@ package state
 import (
 	"github.com/juju/errors"
 	"github.com/juju/schema"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
 
 	"github.com/juju/juju/controller"
 	"github.com/juju/juju/environs"
@@ -281,6 +285,57 @@ func (st *State) UpdateModelConfig(updateAttrs map[string]interface{}, removeAtt
 	return modelSettings.write(ops)
 }
 
+// SetLoggingOverride sets the logging config override for the agents
+// of the machine, application or unit with the given tag, or removes
+// it if loggingConfig is empty. Only that entity's entry in the
+// model's logging-overrides is changed, and the change is not applied
+// on top of a concurrent update, so no other entity's override is
+// lost. Removing an override that does not exist returns a NotFound
+// error.
+func (st *State) SetLoggingOverride(tag names.Tag, loggingConfig string) error {
+	buildTxn := func(int) ([]txn.Op, error) {
+		modelSettings, err := readSettings(st, settingsC, modelGlobalKey)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		current, _ := modelSettings.Get(config.LoggingOverridesKey)
+		currentValue, _ := current.(string)
+		overrides, err := config.ParseLoggingOverrides(currentValue)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		old, ok := overrides[tag.String()]
+		switch {
+		case loggingConfig == "" && !ok:
+			return nil, errors.NotFoundf("logging override for %s", names.ReadableString(tag))
+		case loggingConfig == old:
+			return nil, jujutxn.ErrNoOperations
+		case loggingConfig == "":
+			delete(overrides, tag.String())
+		default:
+			overrides[tag.String()] = loggingConfig
+		}
+		value := config.FormatLoggingOverrides(overrides)
+
+		oldConfig, err := config.New(config.NoDefaults, modelSettings.Map())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		updateAttrs := attrValues{config.LoggingOverridesKey: value}
+		if _, err := st.buildAndValidateModelConfig(updateAttrs, nil, oldConfig); err != nil {
+			return nil, errors.Trace(err)
+		}
+
+		modelSettings.Set(config.LoggingOverridesKey, value)
+		_, ops := modelSettings.settingsUpdateOps()
+		// Assert that the settings are unchanged since they were
+		// read; if not, the transaction is retried.
+		ops[0].Assert = bson.D{{"version", modelSettings.version}}
+		return ops, nil
+	}
+	return st.run(buildTxn)
+}
+
 type modelConfigSourceFunc func() (attrValues, error)
 
 type modelConfigSource struct {
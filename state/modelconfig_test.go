@@ -248,6 +248,59 @@ func (s *ModelConfigSuite) TestUpdateModelConfigPreferredOverRemove(c *gc.C) {
 	c.Assert(ok, jc.IsFalse)
 }
 
+func (s *ModelConfigSuite) TestSetLoggingOverride(c *gc.C) {
+	err := s.State.SetLoggingOverride(names.NewUnitTag("mysql/0"), "juju=TRACE")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetLoggingOverride(names.NewMachineTag("0"), "<root>=DEBUG")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetLoggingOverride(names.NewUnitTag("mysql/0"), "juju=INFO")
+	c.Assert(err, jc.ErrorIsNil)
+
+	cfg, err := s.State.ModelConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.LoggingOverrides(), jc.DeepEquals, map[string]string{
+		"machine-0":    "<root>=DEBUG",
+		"unit-mysql-0": "juju=INFO",
+	})
+}
+
+func (s *ModelConfigSuite) TestSetLoggingOverrideRemove(c *gc.C) {
+	err := s.State.SetLoggingOverride(names.NewUnitTag("mysql/0"), "juju=TRACE")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetLoggingOverride(names.NewUnitTag("mysql/0"), "")
+	c.Assert(err, jc.ErrorIsNil)
+
+	cfg, err := s.State.ModelConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.LoggingOverrides(), gc.HasLen, 0)
+
+	err = s.State.SetLoggingOverride(names.NewUnitTag("mysql/0"), "")
+	c.Assert(err, gc.ErrorMatches, "logging override for unit mysql/0 not found")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ModelConfigSuite) TestSetLoggingOverrideInvalid(c *gc.C) {
+	err := s.State.SetLoggingOverride(names.NewUserTag("bob"), "juju=TRACE")
+	c.Assert(err, gc.ErrorMatches, `.*logging override entity "user-bob" not valid`)
+}
+
+func (s *ModelConfigSuite) TestSetLoggingOverrideConcurrentChange(c *gc.C) {
+	defer state.SetBeforeHooks(c, s.State, func() {
+		err := s.State.SetLoggingOverride(names.NewMachineTag("0"), "<root>=DEBUG")
+		c.Assert(err, jc.ErrorIsNil)
+	}).Check()
+
+	err := s.State.SetLoggingOverride(names.NewUnitTag("mysql/0"), "juju=TRACE")
+	c.Assert(err, jc.ErrorIsNil)
+
+	cfg, err := s.State.ModelConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.LoggingOverrides(), jc.DeepEquals, map[string]string{
+		"machine-0":    "<root>=DEBUG",
+		"unit-mysql-0": "juju=TRACE",
+	})
+}
+
 type ModelConfigSourceSuite struct {
 	ConnSuite
 }
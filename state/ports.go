@@ -37,6 +37,10 @@ type PortRange struct {
 	FromPort int
 	ToPort   int
 	Protocol string
+	// Endpoint holds the name of the unit's endpoint for which the
+	// range was opened. It is empty if the range was opened for all
+	// of the unit's endpoints.
+	Endpoint string `bson:",omitempty"`
 }
 
 // NewPortRange create a new port range and validate it.
@@ -107,7 +111,16 @@ func (a PortRange) SanitizeBounds() PortRange {
 	return b
 }
 
-// CheckConflicts determines if the two port ranges conflict.
+// samePorts reports whether the two port ranges cover the same ports
+// for the same unit, whichever endpoints they were opened for.
+func (prA PortRange) samePorts(prB PortRange) bool {
+	prA.Endpoint, prB.Endpoint = "", ""
+	return prA == prB
+}
+
+// CheckConflicts determines if the two port ranges conflict. A unit
+// may open a port range for a single endpoint, or for all endpoints,
+// so the same range opened for different endpoints conflicts.
 func (prA PortRange) CheckConflicts(prB PortRange) error {
 	if err := prA.Validate(); err != nil {
 		return err
@@ -133,6 +146,9 @@ func (prA PortRange) CheckConflicts(prB PortRange) error {
 
 // Strings returns the port range as a string.
 func (p PortRange) String() string {
+	if p.Endpoint != "" {
+		return fmt.Sprintf("%d-%d/%s (%q endpoint %q)", p.FromPort, p.ToPort, strings.ToLower(p.Protocol), p.UnitName, p.Endpoint)
+	}
 	return fmt.Sprintf("%d-%d/%s (%q)", p.FromPort, p.ToPort, strings.ToLower(p.Protocol), p.UnitName)
 }
 
@@ -267,7 +283,7 @@ func (p *Ports) verifySubnetAliveWhenSet() error {
 }
 
 // ClosePorts removes the specified port range from the list of ports
-// maintained by this document.
+// maintained by this document, whichever endpoint it was opened for.
 func (p *Ports) ClosePorts(portRange PortRange) (err error) {
 	defer errors.DeferredAnnotatef(&err, "cannot close ports %s", portRange)
 
@@ -293,7 +309,7 @@ func (p *Ports) ClosePorts(portRange PortRange) (err error) {
 
 		found := false
 		for _, existingPortsDef := range ports.doc.Ports {
-			if existingPortsDef == portRange {
+			if existingPortsDef.samePorts(portRange) {
 				found = true
 				continue
 			}
@@ -362,6 +378,22 @@ func (p *Ports) AllPortRanges() map[network.PortRange]string {
 	return result
 }
 
+// PortRangeEndpoints returns a map with network.PortRange as keys and
+// the names of the endpoints for which the ranges were opened as
+// values. Ranges opened for all endpoints have an empty endpoint name.
+func (p *Ports) PortRangeEndpoints() map[network.PortRange]string {
+	result := make(map[network.PortRange]string)
+	for _, portRange := range p.doc.Ports {
+		rawRange := network.PortRange{
+			FromPort: portRange.FromPort,
+			ToPort:   portRange.ToPort,
+			Protocol: portRange.Protocol,
+		}
+		result[rawRange] = portRange.Endpoint
+	}
+	return result
+}
+
 // Remove removes the ports document from state.
 func (p *Ports) Remove() error {
 	ports := &Ports{st: p.st, doc: p.doc}
@@ -505,17 +537,10 @@ func removePortsForUnitOps(st *State, unit *Unit) ([]txn.Op, error) {
 	}
 	var ops []txn.Op
 	for _, ports := range allPorts {
-		allRanges := ports.AllPortRanges()
 		var keepPorts []PortRange
-		for portRange, unitName := range allRanges {
-			if unitName != unit.Name() {
-				unitRange := PortRange{
-					UnitName: unitName,
-					FromPort: portRange.FromPort,
-					ToPort:   portRange.ToPort,
-					Protocol: portRange.Protocol,
-				}
-				keepPorts = append(keepPorts, unitRange)
+		for _, portRange := range ports.doc.Ports {
+			if portRange.UnitName != unit.Name() {
+				keepPorts = append(keepPorts, portRange)
 			}
 		}
 		if len(keepPorts) > 0 {
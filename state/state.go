@@ -23,6 +23,7 @@ import (
 	"github.com/juju/utils/series"
 	"github.com/juju/utils/set"
 	"github.com/juju/version"
+	"github.com/opentracing/opentracing-go"
 	"gopkg.in/juju/charm.v6-unstable"
 	csparams "gopkg.in/juju/charmrepo.v2-unstable/csclient/params"
 	"gopkg.in/juju/names.v2"
@@ -109,6 +110,12 @@ type State struct {
 
 	// TODO(anastasiamac 2015-07-16) As state gets broken up, remove this.
 	CloudImageMetadataStorage cloudimagemetadata.Storage
+
+	// traceParent, if set, is the span context under which the
+	// state's transactions are traced, and untraced is the State
+	// of which this one is a view. See TracedBy.
+	traceParent opentracing.SpanContext
+	untraced    *State
 }
 
 // StateServingInfo holds information needed by a controller.
@@ -501,6 +508,9 @@ func (st *State) MongoSession() *mgo.Session {
 }
 
 func (st *State) Watch() *Multiwatcher {
+	if st.untraced != nil {
+		return st.untraced.Watch()
+	}
 	st.mu.Lock()
 	if st.allManager == nil {
 		st.allManager = newStoreManager(newAllWatcherStateBacking(st))
@@ -510,6 +520,9 @@ func (st *State) Watch() *Multiwatcher {
 }
 
 func (st *State) WatchAllModels() *Multiwatcher {
+	if st.untraced != nil {
+		return st.untraced.WatchAllModels()
+	}
 	st.mu.Lock()
 	if st.allModelManager == nil {
 		st.allModelWatcherBacking = NewAllModelWatcherStateBacking(st)
@@ -2003,6 +2016,46 @@ func (st *State) PutAuditEntryFn() func(audit.AuditEntry) error {
 	return stateaudit.PutAuditEntryFn(auditingC, insert)
 }
 
+// AuditEntries returns the audit entries that match the filter, most
+// recent first.
+func (st *State) AuditEntries(filter audit.Filter) ([]audit.AuditEntry, error) {
+	entries, err := st.findAuditEntries(filter, "-time")
+	return entries, errors.Trace(err)
+}
+
+// AuditEntriesFrom returns up to limit audit entries that were written
+// at or after the given time, oldest first. Entries written at the
+// same time are returned in the order in which they were recorded.
+func (st *State) AuditEntriesFrom(from time.Time, limit int) ([]audit.AuditEntry, error) {
+	filter := audit.Filter{Since: from, Limit: limit}
+	entries, err := st.findAuditEntries(filter, "time", "_id")
+	return entries, errors.Trace(err)
+}
+
+func (st *State) findAuditEntries(filter audit.Filter, sort ...string) ([]audit.AuditEntry, error) {
+	find := func(collectionName string, query bson.D, limit int, result interface{}) error {
+		collection, closeCollection := st.getCollection(collectionName)
+		defer closeCollection()
+
+		q := collection.Find(query).Sort(sort...)
+		if limit > 0 {
+			q = q.Limit(limit)
+		}
+		return errors.Trace(q.All(result))
+	}
+	return stateaudit.GetAuditEntriesFn(auditingC, find)(filter)
+}
+
+// PruneAuditEntries removes the audit entries that were written
+// before the given time.
+func (st *State) PruneAuditEntries(before time.Time) error {
+	collection, closeCollection := st.getCollection(auditingC)
+	defer closeCollection()
+
+	_, err := collection.Writeable().RemoveAll(stateaudit.QueryForPrune(before))
+	return errors.Trace(err)
+}
+
 var tagPrefix = map[byte]string{
 	'm': names.MachineTagKind + "-",
 	'a': names.ApplicationTagKind + "-",
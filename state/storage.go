@@ -769,6 +769,11 @@ type StorageConstraints struct {
 
 	// Count is the required number of storage instances.
 	Count uint64 `bson:"count"`
+
+	// snapshotId, if non-empty, is the provider ID of the volume
+	// snapshot from which volumes are to be created. It is only
+	// used when adding storage to a unit, and is never persisted.
+	snapshotId string
 }
 
 func createStorageConstraintsOp(key string, cons map[string]StorageConstraints) txn.Op {
@@ -1072,23 +1077,82 @@ func defaultStoragePool(cfg *config.Config, kind storage.StorageKind, cons Stora
 func (st *State) AddStorageForUnit(
 	tag names.UnitTag, name string, cons StorageConstraints,
 ) error {
-	u, err := st.Unit(tag.Id())
+	u, ch, err := st.unitAndCharm(tag)
 	if err != nil {
 		return errors.Trace(err)
 	}
+	return st.addStorageForUnit(ch, u, name, cons)
+}
 
-	s, err := u.Application()
+// AddStorageForUnitFromSnapshot adds block storage instances to the given
+// unit as AddStorageForUnit does, creating each storage instance's volume
+// from the specified volume snapshot. If no pool is specified, the pool
+// that the snapshot was taken from is used; the size defaults to, and may
+// not be less than, the size of the snapshotted volume.
+//
+// The unit must be assigned to a machine, so that its volumes may be
+// created immediately.
+func (st *State) AddStorageForUnitFromSnapshot(
+	tag names.UnitTag, name string, cons StorageConstraints, snapshotId string,
+) error {
+	snapshot, err := st.VolumeSnapshot(snapshotId)
 	if err != nil {
-		return errors.Annotatef(err, "getting service for unit %v", u.Tag().Id())
+		return errors.Trace(err)
 	}
-	ch, _, err := s.Charm()
+	info, err := snapshot.Info()
 	if err != nil {
-		return errors.Annotatef(err, "getting charm for unit %q", u.Tag().Id())
+		return errors.Trace(err)
+	}
+	if cons.Pool == "" {
+		cons.Pool = snapshot.Pool()
+	} else if cons.Pool != snapshot.Pool() {
+		return errors.NotValidf(
+			"pool %q for snapshot %q taken from pool %q",
+			cons.Pool, snapshotId, snapshot.Pool(),
+		)
 	}
+	if cons.Size == 0 {
+		cons.Size = info.Size
+	} else if cons.Size < info.Size {
+		return errors.NotValidf(
+			"size %dMiB for snapshot %q of %dMiB volume",
+			cons.Size, snapshotId, info.Size,
+		)
+	}
+	cons.snapshotId = info.SnapshotId
 
+	u, ch, err := st.unitAndCharm(tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := u.AssignedMachineId(); err != nil {
+		return errors.Trace(err)
+	}
+	if charmStorage, ok := ch.Meta().Storage[name]; ok && charmStorage.Type != charm.StorageBlock {
+		return errors.NotSupportedf("creating %s storage from a volume snapshot", charmStorage.Type)
+	}
 	return st.addStorageForUnit(ch, u, name, cons)
 }
 
+// unitAndCharm returns the unit with the specified tag, and the charm
+// of its application.
+func (st *State) unitAndCharm(tag names.UnitTag) (*Unit, *Charm, error) {
+	u, err := st.Unit(tag.Id())
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+
+	s, err := u.Application()
+	if err != nil {
+		return nil, nil, errors.Annotatef(err, "getting service for unit %v", u.Tag().Id())
+	}
+	ch, _, err := s.Charm()
+	if err != nil {
+		return nil, nil, errors.Annotatef(err, "getting charm for unit %q", u.Tag().Id())
+	}
+	return u, ch, nil
+}
+
 // addStorage adds storage instances to given unit as specified.
 func (st *State) addStorageForUnit(
 	ch *Charm, u *Unit,
@@ -0,0 +1,36 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/opentracing/opentracing-go"
+)
+
+// TracedBy returns a view of the state whose transactions are traced
+// as children of the given span context, linking them to the operation,
+// such as an API request, that ran them. Transactions run through a
+// State that is not such a view are not traced.
+//
+// The view shares the state's session, workers and watchers, and is
+// only usable while the state is open. Closing the view does nothing.
+func (st *State) TracedBy(parent opentracing.SpanContext) *State {
+	if st.untraced != nil {
+		st = st.untraced
+	}
+	return &State{
+		modelTag:                  st.modelTag,
+		controllerModelTag:        st.controllerModelTag,
+		mongoInfo:                 st.mongoInfo,
+		session:                   st.session,
+		database:                  st.database,
+		policy:                    st.policy,
+		newPolicy:                 st.newPolicy,
+		cloudName:                 st.cloudName,
+		leaseClientId:             st.leaseClientId,
+		workers:                   st.workers,
+		CloudImageMetadataStorage: st.CloudImageMetadataStorage,
+		traceParent:               parent,
+		untraced:                  st,
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/clock"
+	"github.com/opentracing/opentracing-go"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/tracing"
+)
+
+type txnTracingSuite struct {
+	testing.BaseSuite
+
+	spans  []tracing.FinishedSpan
+	tracer *tracing.Tracer
+}
+
+var _ = gc.Suite(&txnTracingSuite{})
+
+func (s *txnTracingSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.spans = nil
+	tracer, err := tracing.NewTracer(tracing.Config{
+		Recorder: s,
+		Clock:    clock.WallClock,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.tracer = tracer
+	opentracing.SetGlobalTracer(tracer)
+	s.AddCleanup(func(*gc.C) {
+		opentracing.SetGlobalTracer(opentracing.NoopTracer{})
+	})
+}
+
+// RecordSpan is part of the tracing.SpanRecorder interface.
+func (s *txnTracingSuite) RecordSpan(span tracing.FinishedSpan) {
+	s.spans = append(s.spans, span)
+}
+
+func (s *txnTracingSuite) TestUntracedTransaction(c *gc.C) {
+	st := &State{}
+	span := st.startTxnSpan(1)
+	c.Assert(span, gc.IsNil)
+	finishTxn(span, time.Now(), nil)
+	c.Assert(s.spans, gc.HasLen, 0)
+}
+
+func (s *txnTracingSuite) TestTracedTransaction(c *gc.C) {
+	parent := s.tracer.StartSpan("request")
+	st := (&State{}).TracedBy(parent.Context())
+
+	finishTxn(st.startTxnSpan(2), time.Now(), nil)
+	c.Assert(s.spans, gc.HasLen, 1)
+	c.Check(s.spans[0].OperationName, gc.Equals, "state transaction")
+	c.Check(s.spans[0].ParentSpanID, gc.Equals, parent.Context().(tracing.SpanContext).SpanID)
+	c.Check(s.spans[0].Tags["txn.ops"], gc.Equals, 2)
+}
+
+func (s *txnTracingSuite) TestTracedByView(c *gc.C) {
+	st := &State{}
+	first := s.tracer.StartSpan("first").Context()
+	second := s.tracer.StartSpan("second").Context()
+
+	view := st.TracedBy(first).TracedBy(second)
+	c.Check(view.untraced, gc.Equals, st)
+	c.Check(view.traceParent, jc.DeepEquals, second)
+	c.Check(view.Close(), jc.ErrorIsNil)
+}
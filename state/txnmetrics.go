@@ -0,0 +1,37 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// txnDurations records the duration of the transactions run by all
+// States in this process, by outcome.
+var txnDurations = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: "juju",
+	Subsystem: "state",
+	Name:      "txn_duration_seconds",
+	Help:      "Duration of Mongo transactions, including retries, in seconds.",
+	Buckets:   prometheus.ExponentialBuckets(0.001, 2, 16),
+}, []string{"result"})
+
+// TxnMetrics returns a prometheus.Collector that exposes the durations
+// of the transactions run by all States in this process. It is not
+// registered with any Prometheus registry.
+func TxnMetrics() prometheus.Collector {
+	return txnDurations
+}
+
+// observeTxnDuration records the duration of a transaction that
+// started at the given time and finished with the given error.
+func observeTxnDuration(start time.Time, err error) {
+	result := "success"
+	if err != nil {
+		result = "error"
+	}
+	txnDurations.WithLabelValues(result).Observe(time.Since(start).Seconds())
+}
@@ -0,0 +1,40 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"errors"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	dto "github.com/prometheus/client_model/go"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/testing"
+)
+
+type txnMetricsSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&txnMetricsSuite{})
+
+func (s *txnMetricsSuite) TestObserveTxnDuration(c *gc.C) {
+	successes := txnSampleCount(c, "success")
+	errs := txnSampleCount(c, "error")
+
+	observeTxnDuration(time.Now().Add(-time.Second), nil)
+	observeTxnDuration(time.Now(), errors.New("boom"))
+	observeTxnDuration(time.Now(), errors.New("boom"))
+
+	c.Assert(txnSampleCount(c, "success"), gc.Equals, successes+1)
+	c.Assert(txnSampleCount(c, "error"), gc.Equals, errs+2)
+}
+
+func txnSampleCount(c *gc.C, result string) uint64 {
+	var out dto.Metric
+	err := txnDurations.WithLabelValues(result).Write(&out)
+	c.Assert(err, jc.ErrorIsNil)
+	return out.GetHistogram().GetSampleCount()
+}
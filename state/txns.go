@@ -4,8 +4,13 @@
 package state
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	jujutxn "github.com/juju/txn"
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+	"github.com/opentracing/opentracing-go/log"
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
 )
@@ -24,9 +29,12 @@ func (st *State) readTxnRevno(collectionName string, id interface{}) (int64, err
 
 // runTransaction is a convenience method delegating to the state's Database.
 func (st *State) runTransaction(ops []txn.Op) error {
+	span, start := st.startTxnSpan(len(ops)), time.Now()
 	runner, closer := st.database.TransactionRunner()
 	defer closer()
-	return runner.RunTransaction(ops)
+	err := runner.RunTransaction(ops)
+	finishTxn(span, start, err)
+	return err
 }
 
 // runRawTransaction is a convenience method that will run a single
@@ -38,14 +46,70 @@ func (st *State) runRawTransaction(ops []txn.Op) error {
 	if multiRunner, ok := runner.(*multiModelRunner); ok {
 		runner = multiRunner.rawRunner
 	}
-	return runner.RunTransaction(ops)
+	span, start := st.startTxnSpan(len(ops)), time.Now()
+	err := runner.RunTransaction(ops)
+	finishTxn(span, start, err)
+	return err
 }
 
 // run is a convenience method delegating to the state's Database.
 func (st *State) run(transactions jujutxn.TransactionSource) error {
+	span, start := st.startTxnSpan(-1), time.Now()
 	runner, closer := st.database.TransactionRunner()
 	defer closer()
-	return runner.Run(transactions)
+	var attempts, ops int
+	err := runner.Run(func(attempt int) ([]txn.Op, error) {
+		result, err := transactions(attempt)
+		attempts, ops = attempt+1, len(result)
+		return result, err
+	})
+	if span != nil {
+		span.SetTag("txn.attempts", attempts)
+		span.SetTag("txn.ops", ops)
+	}
+	finishTxn(span, start, err)
+	return err
+}
+
+// startTxnSpan starts a span, using the global tracer, covering a
+// transaction run against the state's model. If ops is non-negative
+// it is recorded as the number of operations in the transaction.
+//
+// Only transactions run through a view returned by TracedBy are
+// traced, as children of the view's span context; for others, which
+// would otherwise each start a trace of their own, it returns nil.
+func (st *State) startTxnSpan(ops int) opentracing.Span {
+	if st.traceParent == nil {
+		return nil
+	}
+	tags := opentracing.Tags{
+		string(ext.DBType): "mongo",
+		"model-uuid":       st.ModelUUID(),
+	}
+	if ops >= 0 {
+		tags["txn.ops"] = ops
+	}
+	return opentracing.GlobalTracer().StartSpan(
+		"state transaction",
+		opentracing.ChildOf(st.traceParent),
+		ext.SpanKindRPCClient,
+		tags,
+	)
+}
+
+// finishTxn records the outcome of a transaction run on the span, if
+// any, and finishes it. It also records the duration of the
+// transaction, which started at the given time.
+func finishTxn(span opentracing.Span, start time.Time, err error) {
+	observeTxnDuration(start, err)
+	if span == nil {
+		return
+	}
+	if err != nil {
+		ext.Error.Set(span, true)
+		span.LogFields(log.Error(err))
+	}
+	span.Finish()
 }
 
 // ResumeTransactions resumes all pending transactions.
@@ -339,6 +339,9 @@ func (u *Unit) Destroy() (err error) {
 		if historyErr := unit.eraseHistory(); historyErr != nil {
 			logger.Errorf("cannot delete history for unit %q: %v", unit.globalKey(), err)
 		}
+		if hookRunsErr := unit.eraseHookRuns(); hookRunsErr != nil {
+			logger.Errorf("cannot delete hook runs for unit %q: %v", unit.Name(), hookRunsErr)
+		}
 		if err = unit.Refresh(); errors.IsNotFound(err) {
 			return nil
 		}
@@ -889,12 +892,41 @@ func (u *Unit) SetStatus(unitStatus status.StatusInfo) error {
 // opening the requested range conflicts with another already opened range on
 // the same subnet and and the unit's assigned machine.
 func (u *Unit) OpenPortsOnSubnet(subnetID, protocol string, fromPort, toPort int) (err error) {
+	return u.openPorts(subnetID, "", protocol, fromPort, toPort)
+}
+
+// OpenEndpointPorts opens the given port range and protocol for the
+// named endpoint of the unit. When the unit's application is exposed,
+// the range may be reached from the CIDRs in the expose settings of
+// that endpoint, and from those that apply to all endpoints. Returns
+// an error if the range conflicts with another range already opened
+// on the unit's assigned machine, including the same range opened for
+// another of the unit's endpoints.
+func (u *Unit) OpenEndpointPorts(endpoint, protocol string, fromPort, toPort int) error {
+	if endpoint == "" {
+		return errors.NotValidf("empty endpoint name")
+	}
+	return u.openPorts("", endpoint, protocol, fromPort, toPort)
+}
+
+func (u *Unit) openPorts(subnetID, endpoint, protocol string, fromPort, toPort int) (err error) {
 	ports, err := NewPortRange(u.Name(), fromPort, toPort, protocol)
 	if err != nil {
 		return errors.Annotatef(err, "invalid port range %v-%v/%v", fromPort, toPort, protocol)
 	}
+	ports.Endpoint = endpoint
 	defer errors.DeferredAnnotatef(&err, "cannot open ports %v for unit %q on subnet %q", ports, u, subnetID)
 
+	if endpoint != "" {
+		application, err := u.Application()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if _, err := application.Endpoint(endpoint); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
 	machineID, err := u.AssignedMachineId()
 	if err != nil {
 		return errors.Annotatef(err, "unit %q has no assigned machine", u)
@@ -1770,10 +1802,11 @@ func machineStorageParamsForStorageInstance(
 			// to create a volume.
 			cons := allCons[storage.StorageName()]
 			volumeParams := VolumeParams{
-				storage: storage.StorageTag(),
-				binding: storage.StorageTag(),
-				Pool:    cons.Pool,
-				Size:    cons.Size,
+				storage:    storage.StorageTag(),
+				binding:    storage.StorageTag(),
+				Pool:       cons.Pool,
+				Size:       cons.Size,
+				SnapshotId: cons.snapshotId,
 			}
 			volumes = append(volumes, MachineVolumeParams{
 				volumeParams, volumeAttachmentParams,
@@ -1154,6 +1154,45 @@ func (s *UnitSuite) TestOpenClosePortWhenDying(c *gc.C) {
 	})
 }
 
+func (s *UnitSuite) TestOpenCloseEndpointPorts(c *gc.C) {
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.AssignToMachine(machine)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.unit.OpenEndpointPorts("url", "tcp", 80, 81)
+	c.Assert(err, jc.ErrorIsNil)
+	ports, err := machine.OpenedPorts("")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ports.PortRangeEndpoints(), jc.DeepEquals, map[network.PortRange]string{
+		{80, 81, "tcp"}: "url",
+	})
+
+	// The same range cannot be opened for another endpoint, or for
+	// all endpoints.
+	err = s.unit.OpenEndpointPorts("db", "tcp", 80, 81)
+	c.Assert(err, gc.ErrorMatches, `cannot open ports 80-81/tcp \("wordpress/0" endpoint "db"\) .*: port ranges .* conflict`)
+	err = s.unit.OpenPorts("tcp", 80, 81)
+	c.Assert(err, gc.ErrorMatches, `cannot open ports 80-81/tcp \("wordpress/0"\) .*: port ranges .* conflict`)
+
+	// Closing the range closes it whichever endpoint it was opened for.
+	err = s.unit.ClosePorts("tcp", 80, 81)
+	c.Assert(err, jc.ErrorIsNil)
+	open, err := s.unit.OpenedPorts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(open, gc.HasLen, 0)
+}
+
+func (s *UnitSuite) TestOpenEndpointPortsUnknownEndpoint(c *gc.C) {
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.AssignToMachine(machine)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.unit.OpenEndpointPorts("foo", "tcp", 80, 80)
+	c.Assert(err, gc.ErrorMatches, `cannot open ports .*: application "wordpress" has no "foo" relation`)
+}
+
 func (s *UnitSuite) TestRemoveLastUnitOnMachineRemovesAllPorts(c *gc.C) {
 	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
 	c.Assert(err, jc.ErrorIsNil)
@@ -0,0 +1,128 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// MaxUnitHookRuns is the number of hook and action executions that
+// are retained for each unit; older records are discarded as new
+// ones are added.
+const MaxUnitHookRuns = 100
+
+// HookRun describes a single execution of a hook or action by a unit.
+type HookRun struct {
+	// Kind is either "hook" or "action".
+	Kind string
+
+	// Name is the name of the hook or action that was run.
+	Name string
+
+	// Started and Finished record when execution began and ended.
+	Started  time.Time
+	Finished time.Time
+
+	// ExitCode holds the exit code of the hook or action process,
+	// or -1 if it is not known.
+	ExitCode int
+
+	// Error holds a description of the failure, if any.
+	Error string
+}
+
+// unitHookRunDoc represents a HookRun in mongodb.
+type unitHookRunDoc struct {
+	ModelUUID string `bson:"model-uuid"`
+	Unit      string `bson:"unit"`
+	Kind      string `bson:"kind"`
+	Name      string `bson:"name"`
+	Started   int64  `bson:"started"`
+	Finished  int64  `bson:"finished"`
+	ExitCode  int    `bson:"exitcode"`
+	Error     string `bson:"error,omitempty"`
+}
+
+// RecordHookRun records the execution of a hook or action by the unit,
+// discarding the oldest records so that no more than MaxUnitHookRuns
+// are retained.
+func (u *Unit) RecordHookRun(run HookRun) error {
+	if run.Name == "" {
+		return errors.NotValidf("empty hook run name")
+	}
+	switch run.Kind {
+	case "hook", "action":
+	default:
+		return errors.NotValidf("hook run kind %q", run.Kind)
+	}
+	runs, closer := u.st.getCollection(unitHookRunsC)
+	defer closer()
+	runsW := runs.Writeable()
+
+	doc := &unitHookRunDoc{
+		Unit:     u.Name(),
+		Kind:     run.Kind,
+		Name:     run.Name,
+		Started:  run.Started.UnixNano(),
+		Finished: run.Finished.UnixNano(),
+		ExitCode: run.ExitCode,
+		Error:    run.Error,
+	}
+	if err := runsW.Insert(doc); err != nil {
+		return errors.Annotatef(err, "cannot record hook run for unit %q", u.Name())
+	}
+
+	var oldest unitHookRunDoc
+	err := runs.Find(bson.D{{"unit", u.Name()}}).Sort("-started").Skip(MaxUnitHookRuns).One(&oldest)
+	if err == mgo.ErrNotFound {
+		return nil
+	} else if err != nil {
+		return errors.Annotate(err, "cannot find hook runs to discard")
+	}
+	_, err = runsW.RemoveAll(bson.D{
+		{"unit", u.Name()},
+		{"started", bson.M{"$lte": oldest.Started}},
+	})
+	if err != nil {
+		return errors.Annotate(err, "cannot discard old hook runs")
+	}
+	return nil
+}
+
+// HookRuns returns the hook and action executions recorded for
+// the unit, most recent first.
+func (u *Unit) HookRuns() ([]HookRun, error) {
+	runs, closer := u.st.getCollection(unitHookRunsC)
+	defer closer()
+
+	var docs []unitHookRunDoc
+	err := runs.Find(bson.D{{"unit", u.Name()}}).Sort("-started").All(&docs)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get hook runs for unit %q", u.Name())
+	}
+	results := make([]HookRun, len(docs))
+	for i, doc := range docs {
+		results[i] = HookRun{
+			Kind:     doc.Kind,
+			Name:     doc.Name,
+			Started:  time.Unix(0, doc.Started).UTC(),
+			Finished: time.Unix(0, doc.Finished).UTC(),
+			ExitCode: doc.ExitCode,
+			Error:    doc.Error,
+		}
+	}
+	return results, nil
+}
+
+// eraseHookRuns removes all hook runs recorded for the unit.
+func (u *Unit) eraseHookRuns() error {
+	runs, closer := u.st.getCollection(unitHookRunsC)
+	defer closer()
+	_, err := runs.Writeable().RemoveAll(bson.D{{"unit", u.Name()}})
+	return errors.Trace(err)
+}
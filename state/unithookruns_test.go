@@ -0,0 +1,105 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"fmt"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type UnitHookRunsSuite struct {
+	ConnSuite
+	factory *factory.Factory
+	unit    *state.Unit
+}
+
+var _ = gc.Suite(&UnitHookRunsSuite{})
+
+func (s *UnitHookRunsSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.factory = factory.NewFactory(s.State)
+	s.unit = s.factory.MakeUnit(c, nil)
+}
+
+func hookRun(name string, started time.Time) state.HookRun {
+	return state.HookRun{
+		Kind:     "hook",
+		Name:     name,
+		Started:  started,
+		Finished: started.Add(time.Second),
+	}
+}
+
+func (s *UnitHookRunsSuite) TestHookRunsEmpty(c *gc.C) {
+	runs, err := s.unit.HookRuns()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(runs, gc.HasLen, 0)
+}
+
+func (s *UnitHookRunsSuite) TestRecordHookRun(c *gc.C) {
+	t0 := time.Date(2016, 10, 1, 12, 0, 0, 0, time.UTC)
+	err := s.unit.RecordHookRun(hookRun("install", t0))
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.RecordHookRun(state.HookRun{
+		Kind:     "action",
+		Name:     "backup",
+		Started:  t0.Add(time.Minute),
+		Finished: t0.Add(2 * time.Minute),
+		ExitCode: 1,
+		Error:    "exit status 1",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	runs, err := s.unit.HookRuns()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(runs, jc.DeepEquals, []state.HookRun{{
+		Kind:     "action",
+		Name:     "backup",
+		Started:  t0.Add(time.Minute),
+		Finished: t0.Add(2 * time.Minute),
+		ExitCode: 1,
+		Error:    "exit status 1",
+	}, hookRun("install", t0)})
+}
+
+func (s *UnitHookRunsSuite) TestRecordHookRunInvalid(c *gc.C) {
+	err := s.unit.RecordHookRun(state.HookRun{Kind: "hook"})
+	c.Assert(err, gc.ErrorMatches, "empty hook run name not valid")
+	err = s.unit.RecordHookRun(state.HookRun{Kind: "cron", Name: "foo"})
+	c.Assert(err, gc.ErrorMatches, `hook run kind "cron" not valid`)
+}
+
+func (s *UnitHookRunsSuite) TestRecordHookRunBounded(c *gc.C) {
+	t0 := time.Date(2016, 10, 1, 12, 0, 0, 0, time.UTC)
+	extra := 5
+	for i := 0; i < state.MaxUnitHookRuns+extra; i++ {
+		started := t0.Add(time.Duration(i) * time.Minute)
+		err := s.unit.RecordHookRun(hookRun(fmt.Sprintf("hook-%d", i), started))
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	runs, err := s.unit.HookRuns()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(runs, gc.HasLen, state.MaxUnitHookRuns)
+	c.Assert(runs[0].Name, gc.Equals, fmt.Sprintf("hook-%d", state.MaxUnitHookRuns+extra-1))
+	c.Assert(runs[len(runs)-1].Name, gc.Equals, fmt.Sprintf("hook-%d", extra))
+}
+
+func (s *UnitHookRunsSuite) TestHookRunsPerUnit(c *gc.C) {
+	application, err := s.unit.Application()
+	c.Assert(err, jc.ErrorIsNil)
+	other := s.factory.MakeUnit(c, &factory.UnitParams{Application: application})
+	t0 := time.Date(2016, 10, 1, 12, 0, 0, 0, time.UTC)
+	err = s.unit.RecordHookRun(hookRun("install", t0))
+	c.Assert(err, jc.ErrorIsNil)
+
+	runs, err := other.HookRuns()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(runs, gc.HasLen, 0)
+}
@@ -250,3 +250,31 @@ func addDefaultBindingsToServices(st *State) error {
 func AddDefaultEndpointBindingsToServices(st *State) error {
 	return runForAllEnvStates(st, addDefaultBindingsToServices)
 }
+
+// AddAuditEntryTimes sets the time of each audit entry that was
+// written before entries recorded it, from the entry's timestamp, so
+// that the entry can be queried and pruned by time. Entries whose
+// timestamp cannot be parsed are left unchanged.
+func AddAuditEntryTimes(st *State) error {
+	coll, closer := st.getRawCollection(auditingC)
+	defer closer()
+
+	var doc struct {
+		Id        interface{} `bson:"_id"`
+		Timestamp string      `bson:"timestamp"`
+	}
+	iter := coll.Find(bson.D{{"time", bson.D{{"$exists", false}}}}).Select(bson.D{{"timestamp", 1}}).Iter()
+	for iter.Next(&doc) {
+		var timestamp time.Time
+		if err := timestamp.UnmarshalText([]byte(doc.Timestamp)); err != nil {
+			upgradesLogger.Warningf("cannot parse timestamp of audit entry %v: %v", doc.Id, err)
+			continue
+		}
+		err := coll.UpdateId(doc.Id, bson.D{{"$set", bson.D{{"time", timestamp.UnixNano()}}}})
+		if err != nil {
+			iter.Close()
+			return errors.Annotatef(err, "setting time of audit entry %v", doc.Id)
+		}
+	}
+	return errors.Trace(iter.Close())
+}
@@ -585,3 +585,33 @@ func (s *upgradesSuite) TestAddDefaultEndpointBindingsToServices(c *gc.C) {
 func (s *upgradesSuite) TestAddDefaultEndpointBindingsToServicesIdempotent(c *gc.C) {
 	s.testAddDefaultEndpointBindingsToServices(c, true)
 }
+
+func (s *upgradesSuite) TestAddAuditEntryTimes(c *gc.C) {
+	coll, closer := s.state.getRawCollection(auditingC)
+	defer closer()
+
+	timestamp := time.Date(2016, 10, 18, 12, 30, 0, 0, time.UTC)
+	timestampText, err := timestamp.MarshalText()
+	c.Assert(err, jc.ErrorIsNil)
+	err = coll.Insert(
+		bson.M{"_id": "legacy", "timestamp": string(timestampText)},
+		bson.M{"_id": "current", "timestamp": string(timestampText), "time": int64(42)},
+		bson.M{"_id": "bad", "timestamp": "not a time"},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = AddAuditEntryTimes(s.state)
+	c.Assert(err, jc.ErrorIsNil)
+	// Running the step again is harmless.
+	err = AddAuditEntryTimes(s.state)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var doc bson.M
+	s.FindId(c, coll, "legacy", &doc)
+	c.Check(doc["time"], gc.Equals, timestamp.UnixNano())
+	s.FindId(c, coll, "current", &doc)
+	c.Check(doc["time"], gc.Equals, int64(42))
+	doc = nil
+	s.FindId(c, coll, "bad", &doc)
+	c.Check(doc["time"], gc.IsNil)
+}
@@ -46,6 +46,11 @@ type Volume interface {
 	// if it has not already been provisioned. Params returns true if the
 	// returned parameters are usable for provisioning, otherwise false.
 	Params() (VolumeParams, bool)
+
+	// RequestedSize returns the size, in MiB, that the volume has been
+	// requested to grow to. RequestedSize returns true if a resize is
+	// pending, otherwise false.
+	RequestedSize() (uint64, bool)
 }
 
 // VolumeAttachment describes an attachment of a volume to a machine.
@@ -91,6 +96,11 @@ type volumeDoc struct {
 	Binding         string        `bson:"binding,omitempty"`
 	Info            *VolumeInfo   `bson:"info,omitempty"`
 	Params          *VolumeParams `bson:"params,omitempty"`
+
+	// RequestedSize is the size, in MiB, that the volume has been
+	// requested to grow to. It is unset once the volume's info
+	// records a size at least as large.
+	RequestedSize uint64 `bson:"requestedsize,omitempty"`
 }
 
 // volumeAttachmentDoc records information about a volume attachment.
@@ -117,6 +127,10 @@ type VolumeParams struct {
 
 	Pool string `bson:"pool"`
 	Size uint64 `bson:"size"`
+
+	// SnapshotId, if non-empty, is the provider ID of the volume
+	// snapshot from which the volume is to be created.
+	SnapshotId string `bson:"snapshotid,omitempty"`
 }
 
 // VolumeInfo describes information about a volume.
@@ -236,6 +250,11 @@ func (v *volume) Params() (VolumeParams, bool) {
 	return *v.doc.Params, true
 }
 
+// RequestedSize is required to implement Volume.
+func (v *volume) RequestedSize() (uint64, bool) {
+	return v.doc.RequestedSize, v.doc.RequestedSize > 0
+}
+
 // Status is required to implement StatusGetter.
 func (v *volume) Status() (status.StatusInfo, error) {
 	return v.st.VolumeStatus(v.VolumeTag())
@@ -980,11 +999,110 @@ func (st *State) SetVolumeInfo(tag names.VolumeTag, info VolumeInfo) (err error)
 			}
 		}
 		ops = append(ops, setVolumeInfoOps(tag, info, unsetParams)...)
+		if size, ok := v.RequestedSize(); ok && info.Size >= size {
+			// The requested resize has been carried out.
+			ops = append(ops, txn.Op{
+				C:      volumesC,
+				Id:     tag.Id(),
+				Assert: bson.D{{"requestedsize", size}},
+				Update: bson.D{{"$unset", bson.D{{"requestedsize", nil}}}},
+			})
+		}
 		return ops, nil
 	}
 	return st.run(buildTxn)
 }
 
+// ResizeStorageInstance requests that the volume underlying the specified
+// storage instance be grown to the specified size, in MiB. The volume is
+// resized asynchronously by the storage provisioner; the requested size
+// is recorded until the volume's info reflects the new size.
+//
+// Filesystem storage may only be resized if the filesystem is backed by
+// a volume, in which case the volume is grown, and the filesystem on it
+// is grown by the machine's storage provisioner.
+func (st *State) ResizeStorageInstance(tag names.StorageTag, size uint64) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot resize storage %s", tag.Id())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		v, err := st.storageInstanceBackingVolume(tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return resizeVolumeOps(v, size)
+	}
+	return st.run(buildTxn)
+}
+
+// ResizeVolume requests that the specified volume be grown to the
+// specified size, in MiB. See ResizeStorageInstance.
+func (st *State) ResizeVolume(tag names.VolumeTag, size uint64) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot resize volume %s", tag.Id())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		v, err := st.volumeByTag(tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return resizeVolumeOps(v, size)
+	}
+	return st.run(buildTxn)
+}
+
+func resizeVolumeOps(v *volume, size uint64) ([]txn.Op, error) {
+	if v.Life() != Alive {
+		return nil, errors.Errorf("volume %s is not alive", v.Tag().Id())
+	}
+	info, err := v.Info()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if size <= info.Size {
+		return nil, errors.Errorf(
+			"new size %dMiB must be larger than current size %dMiB",
+			size, info.Size,
+		)
+	}
+	assert := bson.D{{"info.size", info.Size}}
+	if requested, ok := v.RequestedSize(); ok {
+		if requested == size {
+			return nil, jujutxn.ErrNoOperations
+		}
+		assert = append(assert, bson.DocElem{"requestedsize", requested})
+	} else {
+		assert = append(assert, bson.DocElem{"requestedsize", bson.D{{"$exists", false}}})
+	}
+	return []txn.Op{{
+		C:      volumesC,
+		Id:     v.doc.Name,
+		Assert: append(assert, isAliveDoc...),
+		Update: bson.D{{"$set", bson.D{{"requestedsize", size}}}},
+	}}, nil
+}
+
+// storageInstanceBackingVolume returns the volume that backs the specified
+// storage instance: either the storage instance's own volume, or the
+// volume backing its filesystem.
+func (st *State) storageInstanceBackingVolume(tag names.StorageTag) (*volume, error) {
+	v, err := st.storageInstanceVolume(tag)
+	if err == nil {
+		return v, nil
+	} else if !errors.IsNotFound(err) {
+		return nil, errors.Trace(err)
+	}
+	f, err := st.storageInstanceFilesystem(tag)
+	if errors.IsNotFound(err) {
+		return nil, errors.NotFoundf("volume or filesystem for storage %s", tag.Id())
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	volumeTag, err := f.Volume()
+	if errors.Cause(err) == ErrNoBackingVolume {
+		return nil, errors.NotSupportedf("filesystem without a backing volume")
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return st.volumeByTag(volumeTag)
+}
+
 func validateVolumeInfoChange(newInfo, oldInfo VolumeInfo) error {
 	if newInfo.Pool != oldInfo.Pool {
 		return errors.Errorf(
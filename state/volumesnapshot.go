@@ -0,0 +1,328 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/status"
+)
+
+// VolumeSnapshot describes a point-in-time snapshot of a volume in the
+// model, from which new volumes may be provisioned.
+type VolumeSnapshot interface {
+	// Id returns the unique ID of the snapshot.
+	Id() string
+
+	// Volume returns the tag of the volume that the snapshot is of.
+	Volume() names.VolumeTag
+
+	// StorageInstance returns the tag of the storage instance that the
+	// snapshotted volume was assigned to, if any. If the volume was
+	// not assigned to a storage instance, an error satisfying
+	// errors.IsNotAssigned will be returned.
+	StorageInstance() (names.StorageTag, error)
+
+	// Pool returns the name of the storage pool that the snapshotted
+	// volume was provisioned from. Volumes created from the snapshot
+	// must be provisioned from the same pool.
+	Pool() string
+
+	// Created returns the time at which the snapshot was requested.
+	Created() time.Time
+
+	// Info returns the snapshot's VolumeSnapshotInfo, or a NotProvisioned
+	// error if the snapshot has not yet been taken.
+	Info() (VolumeSnapshotInfo, error)
+
+	// Status returns the status of the snapshot.
+	Status() (status.StatusInfo, error)
+
+	// SetStatus sets the status of the snapshot.
+	SetStatus(status.StatusInfo) error
+}
+
+// VolumeSnapshotInfo describes information about a volume snapshot.
+type VolumeSnapshotInfo struct {
+	SnapshotId string `bson:"snapshotid"`
+	Size       uint64 `bson:"size"`
+}
+
+type volumeSnapshot struct {
+	st  *State
+	doc volumeSnapshotDoc
+}
+
+// volumeSnapshotDoc records information about a volume snapshot in
+// the model.
+type volumeSnapshotDoc struct {
+	DocID     string              `bson:"_id"`
+	Id        string              `bson:"id"`
+	ModelUUID string              `bson:"model-uuid"`
+	Volume    string              `bson:"volumeid"`
+	StorageId string              `bson:"storageid,omitempty"`
+	Pool      string              `bson:"pool"`
+	Created   int64               `bson:"created"`
+	Info      *VolumeSnapshotInfo `bson:"info,omitempty"`
+}
+
+// Id is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Id() string {
+	return s.doc.Id
+}
+
+// Volume is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Volume() names.VolumeTag {
+	return names.NewVolumeTag(s.doc.Volume)
+}
+
+// StorageInstance is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) StorageInstance() (names.StorageTag, error) {
+	if s.doc.StorageId == "" {
+		msg := fmt.Sprintf("volume snapshot %q is not of any storage instance", s.doc.Id)
+		return names.StorageTag{}, errors.NewNotAssigned(nil, msg)
+	}
+	return names.NewStorageTag(s.doc.StorageId), nil
+}
+
+// Pool is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Pool() string {
+	return s.doc.Pool
+}
+
+// Created is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Created() time.Time {
+	return time.Unix(0, s.doc.Created).UTC()
+}
+
+// Info is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Info() (VolumeSnapshotInfo, error) {
+	if s.doc.Info == nil {
+		return VolumeSnapshotInfo{}, errors.NotProvisionedf("volume snapshot %q", s.doc.Id)
+	}
+	return *s.doc.Info, nil
+}
+
+// Status is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Status() (status.StatusInfo, error) {
+	return s.st.VolumeSnapshotStatus(s.doc.Id)
+}
+
+// SetStatus is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) SetStatus(snapshotStatus status.StatusInfo) error {
+	return s.st.SetVolumeSnapshotStatus(s.doc.Id, snapshotStatus.Status, snapshotStatus.Message, snapshotStatus.Data, snapshotStatus.Since)
+}
+
+// volumeSnapshotGlobalKey returns the global database key for the
+// volume snapshot with the specified ID.
+func volumeSnapshotGlobalKey(id string) string {
+	return "vs#" + id
+}
+
+// VolumeSnapshotStatus returns the status of the specified volume
+// snapshot.
+func (st *State) VolumeSnapshotStatus(id string) (status.StatusInfo, error) {
+	return getStatus(st, volumeSnapshotGlobalKey(id), "volume snapshot")
+}
+
+// SetVolumeSnapshotStatus sets the status of the specified volume
+// snapshot. Snapshots that have not yet been taken may be set to
+// pending or error; snapshots that have been taken are available.
+func (st *State) SetVolumeSnapshotStatus(id string, snapshotStatus status.Status, info string, data map[string]interface{}, updated *time.Time) error {
+	switch snapshotStatus {
+	case status.StatusPending, status.StatusError:
+		if snapshotStatus == status.StatusError && info == "" {
+			return errors.Errorf("cannot set status %q without info", snapshotStatus)
+		}
+		s, err := st.volumeSnapshot(id)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if _, err := s.Info(); err == nil {
+			return errors.Errorf("cannot set status %q for taken volume snapshot", snapshotStatus)
+		}
+	case status.StatusAvailable:
+	default:
+		return errors.Errorf("cannot set invalid status %q", snapshotStatus)
+	}
+	return setStatus(st, setStatusParams{
+		badge:     "volume snapshot",
+		globalKey: volumeSnapshotGlobalKey(id),
+		status:    snapshotStatus,
+		message:   info,
+		rawData:   data,
+		updated:   updated,
+	})
+}
+
+// VolumeSnapshot returns the VolumeSnapshot with the specified ID.
+func (st *State) VolumeSnapshot(id string) (VolumeSnapshot, error) {
+	s, err := st.volumeSnapshot(id)
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (st *State) volumeSnapshot(id string) (*volumeSnapshot, error) {
+	coll, closer := st.getCollection(volumeSnapshotsC)
+	defer closer()
+
+	var doc volumeSnapshotDoc
+	err := coll.FindId(id).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("volume snapshot %q", id)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot get volume snapshot %q", id)
+	}
+	return &volumeSnapshot{st, doc}, nil
+}
+
+// AllVolumeSnapshots returns all volume snapshots in the model.
+func (st *State) AllVolumeSnapshots() ([]VolumeSnapshot, error) {
+	coll, closer := st.getCollection(volumeSnapshotsC)
+	defer closer()
+
+	var docs []volumeSnapshotDoc
+	if err := coll.Find(nil).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get volume snapshots")
+	}
+	snapshots := make([]VolumeSnapshot, len(docs))
+	for i, doc := range docs {
+		snapshots[i] = &volumeSnapshot{st, doc}
+	}
+	return snapshots, nil
+}
+
+// newVolumeSnapshotId returns a unique volume snapshot ID.
+func newVolumeSnapshotId(st *State) (string, error) {
+	seq, err := st.sequence("volumesnapshot")
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return fmt.Sprint(seq), nil
+}
+
+// CreateStorageSnapshot requests a snapshot of the volume backing the
+// specified storage instance, returning the pending snapshot. The
+// snapshot is taken by the model's storage provisioner, which records
+// the snapshot's info once it has been taken.
+//
+// Only model-scoped volumes may be snapshotted, as snapshots must
+// outlive the machines that volumes are attached to.
+func (st *State) CreateStorageSnapshot(tag names.StorageTag) (_ VolumeSnapshot, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot create snapshot of storage %s", tag.Id())
+	return st.createVolumeSnapshot(func() (*volume, error) {
+		return st.storageInstanceBackingVolume(tag)
+	})
+}
+
+// CreateVolumeSnapshot requests a snapshot of the specified volume. See
+// CreateStorageSnapshot.
+func (st *State) CreateVolumeSnapshot(tag names.VolumeTag) (_ VolumeSnapshot, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot create snapshot of volume %s", tag.Id())
+	return st.createVolumeSnapshot(func() (*volume, error) {
+		return st.volumeByTag(tag)
+	})
+}
+
+func (st *State) createVolumeSnapshot(getVolume func() (*volume, error)) (VolumeSnapshot, error) {
+	id, err := newVolumeSnapshotId(st)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot generate volume snapshot ID")
+	}
+	var doc volumeSnapshotDoc
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		v, err := getVolume()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if v.Life() != Alive {
+			return nil, errors.Errorf("volume %s is not alive", v.Tag().Id())
+		}
+		if strings.Contains(v.doc.Name, "/") {
+			return nil, errors.NotSupportedf("snapshots of machine-scoped volume %s", v.Tag().Id())
+		}
+		info, err := v.Info()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		now := GetClock().Now()
+		doc = volumeSnapshotDoc{
+			Id:        id,
+			Volume:    v.doc.Name,
+			StorageId: v.doc.StorageId,
+			Pool:      info.Pool,
+			Created:   now.UnixNano(),
+		}
+		return []txn.Op{{
+			C:      volumesC,
+			Id:     v.doc.Name,
+			Assert: isAliveDoc,
+		}, {
+			C:      volumeSnapshotsC,
+			Id:     id,
+			Assert: txn.DocMissing,
+			Insert: &doc,
+		}, createStatusOp(st, volumeSnapshotGlobalKey(id), statusDoc{
+			Status:  status.StatusPending,
+			Updated: now.UnixNano(),
+		})}, nil
+	}
+	if err := st.run(buildTxn); err != nil {
+		return nil, err
+	}
+	return &volumeSnapshot{st, doc}, nil
+}
+
+// SetVolumeSnapshotInfo records the info of a volume snapshot that has
+// been taken by the storage provider, and marks the snapshot available.
+// Once set, the info may not be changed.
+func (st *State) SetVolumeSnapshotInfo(id string, info VolumeSnapshotInfo) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set info for volume snapshot %q", id)
+	if info.SnapshotId == "" {
+		return errors.New("snapshot ID not set")
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		s, err := st.volumeSnapshot(id)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if oldInfo, err := s.Info(); err == nil {
+			if oldInfo == info {
+				return nil, jujutxn.ErrNoOperations
+			}
+			return nil, errors.Errorf(
+				"cannot change snapshot ID from %q to %q",
+				oldInfo.SnapshotId, info.SnapshotId,
+			)
+		}
+		return []txn.Op{{
+			C:      volumeSnapshotsC,
+			Id:     id,
+			Assert: bson.D{{"info", bson.D{{"$exists", false}}}},
+			Update: bson.D{{"$set", bson.D{{"info", &info}}}},
+		}}, nil
+	}
+	if err := st.run(buildTxn); err != nil {
+		return err
+	}
+	now := GetClock().Now()
+	return st.SetVolumeSnapshotStatus(id, status.StatusAvailable, "", nil, &now)
+}
+
+// WatchVolumeSnapshots returns a StringsWatcher that notifies of the
+// creation of, and changes to, volume snapshots in the model.
+func (st *State) WatchVolumeSnapshots() StringsWatcher {
+	return newcollectionWatcher(st, colWCfg{col: volumeSnapshotsC})
+}
@@ -0,0 +1,218 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/testing"
+	"github.com/juju/juju/status"
+)
+
+type VolumeSnapshotSuite struct {
+	StorageStateSuiteBase
+}
+
+var _ = gc.Suite(&VolumeSnapshotSuite{})
+
+func (s *VolumeSnapshotSuite) setupProvisionedStorage(c *gc.C, pool string) (*state.Unit, names.StorageTag) {
+	_, u, storageTag := s.setupSingleStorage(c, "block", pool)
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	volumeTag := s.storageInstanceVolume(c, storageTag).VolumeTag()
+	err = s.State.SetVolumeInfo(volumeTag, state.VolumeInfo{Size: 1024, VolumeId: "vol-ume"})
+	c.Assert(err, jc.ErrorIsNil)
+	return u, storageTag
+}
+
+func (s *VolumeSnapshotSuite) TestCreateStorageSnapshot(c *gc.C) {
+	_, storageTag := s.setupProvisionedStorage(c, "environscoped")
+
+	snapshot, err := s.State.CreateStorageSnapshot(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshot.Id(), gc.Equals, "0")
+	c.Assert(snapshot.Volume(), gc.Equals, names.NewVolumeTag("0"))
+	c.Assert(snapshot.Pool(), gc.Equals, "environscoped")
+	snapshotStorageTag, err := snapshot.StorageInstance()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshotStorageTag, gc.Equals, storageTag)
+	_, err = snapshot.Info()
+	c.Assert(err, jc.Satisfies, errors.IsNotProvisioned)
+
+	err = s.State.SetVolumeSnapshotInfo("0", state.VolumeSnapshotInfo{
+		SnapshotId: "snap-0", Size: 1024,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	snapshot, err = s.State.VolumeSnapshot("0")
+	c.Assert(err, jc.ErrorIsNil)
+	info, err := snapshot.Info()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info, jc.DeepEquals, state.VolumeSnapshotInfo{SnapshotId: "snap-0", Size: 1024})
+
+	snapshots, err := s.State.AllVolumeSnapshots()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshots, gc.HasLen, 1)
+	c.Assert(snapshots[0].Id(), gc.Equals, "0")
+}
+
+func (s *VolumeSnapshotSuite) TestCreateVolumeSnapshot(c *gc.C) {
+	_, storageTag := s.setupProvisionedStorage(c, "environscoped")
+	volumeTag := s.storageInstanceVolume(c, storageTag).VolumeTag()
+
+	snapshot, err := s.State.CreateVolumeSnapshot(volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshot.Id(), gc.Equals, "0")
+	c.Assert(snapshot.Volume(), gc.Equals, volumeTag)
+}
+
+func (s *VolumeSnapshotSuite) TestCreateStorageSnapshotMachineScoped(c *gc.C) {
+	_, storageTag := s.setupProvisionedStorage(c, "loop-pool")
+	_, err := s.State.CreateStorageSnapshot(storageTag)
+	c.Assert(err, gc.ErrorMatches, `cannot create snapshot of storage data/0: snapshots of machine-scoped volume 0/0 not supported`)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *VolumeSnapshotSuite) TestCreateStorageSnapshotUnprovisioned(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorage(c, "block", "environscoped")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.CreateStorageSnapshot(storageTag)
+	c.Assert(err, gc.ErrorMatches, `cannot create snapshot of storage data/0: volume "0" not provisioned`)
+	c.Assert(err, jc.Satisfies, errors.IsNotProvisioned)
+}
+
+func (s *VolumeSnapshotSuite) TestVolumeSnapshotNotFound(c *gc.C) {
+	_, err := s.State.VolumeSnapshot("42")
+	c.Assert(err, gc.ErrorMatches, `volume snapshot "42" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *VolumeSnapshotSuite) TestSetVolumeSnapshotInfoImmutable(c *gc.C) {
+	_, storageTag := s.setupProvisionedStorage(c, "environscoped")
+	_, err := s.State.CreateStorageSnapshot(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+
+	info := state.VolumeSnapshotInfo{SnapshotId: "snap-0", Size: 1024}
+	err = s.State.SetVolumeSnapshotInfo("0", info)
+	c.Assert(err, jc.ErrorIsNil)
+	// Setting the same info again is a no-op.
+	err = s.State.SetVolumeSnapshotInfo("0", info)
+	c.Assert(err, jc.ErrorIsNil)
+
+	info.SnapshotId = "snap-1"
+	err = s.State.SetVolumeSnapshotInfo("0", info)
+	c.Assert(err, gc.ErrorMatches, `cannot set info for volume snapshot "0": cannot change snapshot ID from "snap-0" to "snap-1"`)
+}
+
+func (s *VolumeSnapshotSuite) TestVolumeSnapshotStatus(c *gc.C) {
+	_, storageTag := s.setupProvisionedStorage(c, "environscoped")
+	snapshot, err := s.State.CreateStorageSnapshot(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	statusInfo, err := snapshot.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(statusInfo.Status, gc.Equals, status.StatusPending)
+
+	now := time.Now()
+	err = snapshot.SetStatus(status.StatusInfo{Status: status.StatusError, Since: &now})
+	c.Assert(err, gc.ErrorMatches, `cannot set status "error" without info`)
+	err = snapshot.SetStatus(status.StatusInfo{
+		Status: status.StatusError, Message: "snapshot quota exceeded", Since: &now,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	statusInfo, err = snapshot.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(statusInfo.Status, gc.Equals, status.StatusError)
+	c.Assert(statusInfo.Message, gc.Equals, "snapshot quota exceeded")
+
+	// Recording the snapshot's info makes it available.
+	err = s.State.SetVolumeSnapshotInfo("0", state.VolumeSnapshotInfo{SnapshotId: "snap-0"})
+	c.Assert(err, jc.ErrorIsNil)
+	statusInfo, err = snapshot.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(statusInfo.Status, gc.Equals, status.StatusAvailable)
+
+	err = snapshot.SetStatus(status.StatusInfo{Status: status.StatusPending, Since: &now})
+	c.Assert(err, gc.ErrorMatches, `cannot set status "pending" for taken volume snapshot`)
+}
+
+func (s *VolumeSnapshotSuite) TestWatchVolumeSnapshots(c *gc.C) {
+	_, storageTag := s.setupProvisionedStorage(c, "environscoped")
+
+	w := s.State.WatchVolumeSnapshots()
+	defer testing.AssertStop(c, w)
+	wc := testing.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChangeInSingleEvent() // initial
+	wc.AssertNoChange()
+
+	_, err := s.State.CreateStorageSnapshot(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChangeInSingleEvent("0")
+	wc.AssertNoChange()
+
+	err = s.State.SetVolumeSnapshotInfo("0", state.VolumeSnapshotInfo{SnapshotId: "snap-0"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChangeInSingleEvent("0")
+	wc.AssertNoChange()
+}
+
+func (s *VolumeSnapshotSuite) TestAddStorageForUnitFromSnapshot(c *gc.C) {
+	u, storageTag := s.setupProvisionedStorage(c, "environscoped")
+	_, err := s.State.CreateStorageSnapshot(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetVolumeSnapshotInfo("0", state.VolumeSnapshotInfo{
+		SnapshotId: "snap-0", Size: 2048,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.AddStorageForUnitFromSnapshot(
+		u.UnitTag(), "allecto", state.StorageConstraints{Count: 1}, "0",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	volumes, err := s.State.AllVolumes()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(volumes, gc.HasLen, 2)
+	var restored []state.VolumeParams
+	for _, v := range volumes {
+		if params, ok := v.Params(); ok {
+			restored = append(restored, params)
+		}
+	}
+	c.Assert(restored, gc.HasLen, 1)
+	c.Assert(restored[0].Pool, gc.Equals, "environscoped")
+	c.Assert(restored[0].Size, gc.Equals, uint64(2048))
+	c.Assert(restored[0].SnapshotId, gc.Equals, "snap-0")
+}
+
+func (s *VolumeSnapshotSuite) TestAddStorageForUnitFromSnapshotInvalid(c *gc.C) {
+	u, storageTag := s.setupProvisionedStorage(c, "environscoped")
+	_, err := s.State.CreateStorageSnapshot(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.AddStorageForUnitFromSnapshot(
+		u.UnitTag(), "allecto", state.StorageConstraints{Count: 1}, "0",
+	)
+	c.Assert(err, gc.ErrorMatches, `volume snapshot "0" not provisioned`)
+
+	err = s.State.SetVolumeSnapshotInfo("0", state.VolumeSnapshotInfo{
+		SnapshotId: "snap-0", Size: 2048,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.AddStorageForUnitFromSnapshot(
+		u.UnitTag(), "allecto", state.StorageConstraints{Pool: "loop-pool", Count: 1}, "0",
+	)
+	c.Assert(err, gc.ErrorMatches, `pool "loop-pool" for snapshot "0" taken from pool "environscoped" not valid`)
+
+	err = s.State.AddStorageForUnitFromSnapshot(
+		u.UnitTag(), "allecto", state.StorageConstraints{Size: 1024, Count: 1}, "0",
+	)
+	c.Assert(err, gc.ErrorMatches, `size 1024MiB for snapshot "0" of 2048MiB volume not valid`)
+}
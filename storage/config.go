@@ -19,6 +19,16 @@ const (
 	// should not be relied upon until a storage source is
 	// constructed.
 	ConfigStorageDir = "storage-dir"
+
+	// ConfigEncrypted is the name of the attribute requesting that
+	// volumes be encrypted, for storage providers that support it.
+	ConfigEncrypted = "encrypted"
+
+	// ConfigKMSKeyId is the name of the attribute identifying the
+	// key management service key with which volumes are encrypted,
+	// for storage providers that support it. It may only be
+	// specified along with encrypted=true.
+	ConfigKMSKeyId = "kms-key-id"
 )
 
 // Config defines the configuration for a storage source.
@@ -123,6 +123,35 @@ type VolumeSource interface {
 	DetachVolumes(params []VolumeAttachmentParams) ([]error, error)
 }
 
+// VolumeEncryptionProvider is an interface that may be implemented by
+// a Provider whose volumes may be encrypted. The ConfigEncrypted and
+// ConfigKMSKeyId attributes are rejected in the storage pools of other
+// providers.
+type VolumeEncryptionProvider interface {
+	// SupportsVolumeEncryption reports whether the provider's volumes
+	// may be encrypted.
+	SupportsVolumeEncryption() bool
+}
+
+// VolumeResizer is an interface that may be implemented by a VolumeSource
+// that is capable of growing volumes in place.
+type VolumeResizer interface {
+	// ResizeVolumes grows the volumes with the specified parameters.
+	// Volumes may only be grown; requests to shrink a volume must be
+	// rejected.
+	ResizeVolumes(params []ResizeVolumeParams) ([]ResizeVolumesResult, error)
+}
+
+// VolumeSnapshotter is an interface that may be implemented by a
+// VolumeSource that is capable of taking snapshots of volumes. A
+// VolumeSource that implements VolumeSnapshotter must also honour
+// VolumeParams.SnapshotId when creating volumes.
+type VolumeSnapshotter interface {
+	// CreateVolumeSnapshots takes snapshots of the volumes with the
+	// specified parameters.
+	CreateVolumeSnapshots(params []VolumeSnapshotParams) ([]CreateVolumeSnapshotsResult, error)
+}
+
 // FilesystemSource provides an interface for creating, destroying and
 // describing filesystems in the environment. A FilesystemSource is
 // configured in a particular way, and corresponds to a storage "pool".
@@ -156,6 +185,14 @@ type FilesystemSource interface {
 	DetachFilesystems(params []FilesystemAttachmentParams) ([]error, error)
 }
 
+// FilesystemResizer is an interface that may be implemented by a
+// FilesystemSource that is capable of growing filesystems in place.
+type FilesystemResizer interface {
+	// ResizeFilesystems grows the filesystems with the specified
+	// parameters to fill the space available to them.
+	ResizeFilesystems(params []ResizeFilesystemParams) ([]ResizeFilesystemsResult, error)
+}
+
 // VolumeParams is a fully specified set of parameters for volume creation,
 // derived from one or more of user-specified storage constraints, a
 // storage pool definition, and charm storage metadata.
@@ -190,6 +227,12 @@ type VolumeParams struct {
 	// once the instance is created there are still unprovisioned volumes,
 	// the dynamic storage provisioner will take care of creating them.
 	Attachment *VolumeAttachmentParams
+
+	// SnapshotId is the provider-supplied ID of the volume snapshot
+	// from which the volume should be created, or empty if the volume
+	// should be created empty. SnapshotId will only be set for volume
+	// sources that implement VolumeSnapshotter.
+	SnapshotId string
 }
 
 // VolumeAttachmentParams is a set of parameters for volume attachment or
@@ -254,6 +297,70 @@ type FilesystemParams struct {
 	ResourceTags map[string]string
 }
 
+// ResizeVolumeParams is a set of parameters for resizing a volume.
+type ResizeVolumeParams struct {
+	// Tag is the unique tag assigned by Juju for the volume.
+	Tag names.VolumeTag
+
+	// VolumeId is the unique provider-supplied ID for the volume.
+	VolumeId string
+
+	// Size is the requested size of the volume in MiB.
+	Size uint64
+
+	// Provider is the name of the storage provider that manages the
+	// volume.
+	Provider ProviderType
+
+	// Attributes is the set of provider-specific attributes that the
+	// volume was created with.
+	Attributes map[string]interface{}
+
+	// Attachment identifies the machine that the volume is attached
+	// to, or nil if the volume is not attached to any machine. Some
+	// providers, such as Azure, require the attached machine in
+	// order to resize the volume.
+	Attachment *VolumeAttachmentParams
+}
+
+// ResizeFilesystemParams is a set of parameters for resizing a filesystem.
+type ResizeFilesystemParams struct {
+	// Tag is the unique tag assigned by Juju for the filesystem.
+	Tag names.FilesystemTag
+
+	// FilesystemId is the unique provider-supplied ID for the
+	// filesystem.
+	FilesystemId string
+
+	// Size is the requested size of the filesystem in MiB.
+	Size uint64
+}
+
+// VolumeSnapshotParams is a set of parameters for taking a snapshot of
+// a volume.
+type VolumeSnapshotParams struct {
+	// Id is the unique ID assigned by Juju for the requested snapshot.
+	Id string
+
+	// Volume is the unique tag assigned by Juju for the volume.
+	Volume names.VolumeTag
+
+	// VolumeId is the unique provider-supplied ID for the volume.
+	VolumeId string
+
+	// Provider is the name of the storage provider that manages the
+	// volume.
+	Provider ProviderType
+
+	// Attributes is the set of provider-specific attributes that the
+	// volume was created with.
+	Attributes map[string]interface{}
+
+	// ResourceTags is a set of tags to set on the created snapshot, if
+	// the storage provider supports tags.
+	ResourceTags map[string]string
+}
+
 // FilesystemAttachmentParams is a set of parameters for filesystem attachment
 // or detachment.
 type FilesystemAttachmentParams struct {
@@ -295,6 +402,32 @@ type AttachVolumesResult struct {
 	Error            error
 }
 
+// ResizeVolumesResult contains the result of a VolumeResizer.ResizeVolumes
+// call for one volume. Size should only be used if Error is nil.
+type ResizeVolumesResult struct {
+	// Size is the size of the volume after resizing, in MiB. This
+	// may be larger than the requested size.
+	Size  uint64
+	Error error
+}
+
+// CreateVolumeSnapshotsResult contains the result of a
+// VolumeSnapshotter.CreateVolumeSnapshots call for one volume.
+// VolumeSnapshot should only be used if Error is nil.
+type CreateVolumeSnapshotsResult struct {
+	VolumeSnapshot *VolumeSnapshot
+	Error          error
+}
+
+// ResizeFilesystemsResult contains the result of a
+// FilesystemResizer.ResizeFilesystems call for one filesystem. Size
+// should only be used if Error is nil.
+type ResizeFilesystemsResult struct {
+	// Size is the size of the filesystem after resizing, in MiB.
+	Size  uint64
+	Error error
+}
+
 // CreateFilesystemsResult contains the result of a FilesystemSource.CreateFilesystems call
 // for one filesystem. Filesystem should only be used if Error is nil.
 type CreateFilesystemsResult struct {
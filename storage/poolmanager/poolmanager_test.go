@@ -130,6 +130,32 @@ func (s *poolSuite) TestCreateInvalidConfig(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "validating storage provider config: no good")
 }
 
+func (s *poolSuite) TestCreateEncrypted(c *gc.C) {
+	s.registry.Providers["encrypting"] = &dummystorage.StorageProvider{IsEncrypting: true}
+	attrs := map[string]interface{}{"encrypted": "true", "kms-key-id": "key"}
+	created, err := s.poolManager.Create("testpool", "encrypting", attrs)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(created.Attrs(), jc.DeepEquals, attrs)
+}
+
+func (s *poolSuite) TestCreateEncryptedNotSupported(c *gc.C) {
+	attrs := map[string]interface{}{"encrypted": "true", "kms-key-id": "key"}
+	_, err := s.poolManager.Create("testpool", "loop", attrs)
+	c.Assert(err, gc.ErrorMatches, "validating storage provider config: encrypted=true for this storage provider not supported")
+
+	// Explicitly not encrypting is fine.
+	_, err = s.poolManager.Create("testpool", "loop", map[string]interface{}{"encrypted": false})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *poolSuite) TestCreateKMSKeyIdWithoutEncrypted(c *gc.C) {
+	_, err := s.poolManager.Create("testpool", "loop", map[string]interface{}{"kms-key-id": "key"})
+	c.Assert(err, gc.ErrorMatches, "validating storage provider config: kms-key-id without encrypted=true not valid")
+
+	_, err = s.poolManager.Create("testpool", "loop", map[string]interface{}{"encrypted": "yes please"})
+	c.Assert(err, gc.ErrorMatches, "validating storage provider config: validating encryption config: encrypted: .*")
+}
+
 func (s *poolSuite) TestDelete(c *gc.C) {
 	s.createSettings(c)
 	err := s.poolManager.Delete("testpool")
@@ -5,6 +5,7 @@ package provider
 
 import (
 	"github.com/juju/errors"
+	"github.com/juju/schema"
 
 	"github.com/juju/juju/storage"
 )
@@ -28,5 +29,47 @@ func CommonStorageProviders() storage.ProviderRegistry {
 // ValidateConfig performs storage provider config validation, including
 // any common validation.
 func ValidateConfig(p storage.Provider, cfg *storage.Config) error {
+	if err := validateEncryptionConfig(p, cfg); err != nil {
+		return errors.Trace(err)
+	}
 	return p.ValidateConfig(cfg)
 }
+
+var encryptionConfigChecker = schema.FieldMap(
+	schema.Fields{
+		storage.ConfigEncrypted: schema.Bool(),
+		storage.ConfigKMSKeyId:  schema.String(),
+	},
+	schema.Defaults{
+		storage.ConfigEncrypted: false,
+		storage.ConfigKMSKeyId:  "",
+	},
+)
+
+// validateEncryptionConfig checks that a KMS key is only specified for
+// encrypted volumes, and that encryption is only requested of providers
+// which can encrypt volumes.
+func validateEncryptionConfig(p storage.Provider, cfg *storage.Config) error {
+	attrs := make(map[string]interface{})
+	for _, name := range []string{storage.ConfigEncrypted, storage.ConfigKMSKeyId} {
+		if value, ok := cfg.Attrs()[name]; ok {
+			attrs[name] = value
+		}
+	}
+	out, err := encryptionConfigChecker.Coerce(attrs, nil)
+	if err != nil {
+		return errors.Annotate(err, "validating encryption config")
+	}
+	coerced := out.(map[string]interface{})
+	encrypted := coerced[storage.ConfigEncrypted].(bool)
+	if coerced[storage.ConfigKMSKeyId].(string) != "" && !encrypted {
+		return errors.NotValidf("%s without %s=true", storage.ConfigKMSKeyId, storage.ConfigEncrypted)
+	}
+	if !encrypted {
+		return nil
+	}
+	if ep, ok := p.(storage.VolumeEncryptionProvider); !ok || !ep.SupportsVolumeEncryption() {
+		return errors.NotSupportedf("%s=true for this storage provider", storage.ConfigEncrypted)
+	}
+	return nil
+}
@@ -43,6 +43,10 @@ type StorageProvider struct {
 	// SupportsFunc will be called by Supports, if non-nil; otherwise,
 	// Supports returns true.
 	SupportsFunc func(kind storage.StorageKind) bool
+
+	// IsEncrypting defines whether or not the provider reports that
+	// its volumes may be encrypted.
+	IsEncrypting bool
 }
 
 // VolumeSource is defined on storage.Provider.
@@ -72,6 +76,12 @@ func (p *StorageProvider) ValidateConfig(providerConfig *storage.Config) error {
 	return nil
 }
 
+// SupportsVolumeEncryption is defined on storage.VolumeEncryptionProvider.
+func (p *StorageProvider) SupportsVolumeEncryption() bool {
+	p.MethodCall(p, "SupportsVolumeEncryption")
+	return p.IsEncrypting
+}
+
 // Supports is defined on storage.Provider.
 func (p *StorageProvider) Supports(kind storage.StorageKind) bool {
 	p.MethodCall(p, "Supports", kind)
@@ -6,6 +6,7 @@ package provider
 import (
 	"path"
 	"path/filepath"
+	"strings"
 	"unicode"
 
 	"github.com/juju/errors"
@@ -31,6 +32,8 @@ type managedFilesystemSource struct {
 	filesystems        map[names.FilesystemTag]storage.Filesystem
 }
 
+var _ storage.FilesystemResizer = (*managedFilesystemSource)(nil)
+
 // NewManagedFilesystemSource returns a storage.FilesystemSource that manages
 // filesystems on block devices on the host machine.
 //
@@ -167,6 +170,48 @@ func (s *managedFilesystemSource) DetachFilesystems(args []storage.FilesystemAtt
 	return results, nil
 }
 
+// ResizeFilesystems is defined on storage.FilesystemResizer.
+func (s *managedFilesystemSource) ResizeFilesystems(args []storage.ResizeFilesystemParams) ([]storage.ResizeFilesystemsResult, error) {
+	results := make([]storage.ResizeFilesystemsResult, len(args))
+	for i, arg := range args {
+		size, err := s.resizeFilesystem(arg)
+		if err != nil {
+			results[i].Error = err
+			continue
+		}
+		results[i].Size = size
+	}
+	return results, nil
+}
+
+func (s *managedFilesystemSource) resizeFilesystem(arg storage.ResizeFilesystemParams) (uint64, error) {
+	filesystem, ok := s.filesystems[arg.Tag]
+	if !ok {
+		return 0, errors.Errorf("filesystem %v is not yet provisioned", arg.Tag.Id())
+	}
+	blockDevice, err := s.backingVolumeBlockDevice(filesystem.Volume)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	if blockDevice.Size < arg.Size {
+		return 0, errors.Errorf(
+			"backing-volume %s is %dMiB, smaller than the requested %dMiB",
+			filesystem.Volume.Id(), blockDevice.Size, arg.Size,
+		)
+	}
+	devicePath := devicePath(blockDevice)
+	if isDiskDevice(devicePath) {
+		if err := growPartition(s.run, devicePath); err != nil {
+			return 0, errors.Trace(err)
+		}
+		devicePath = partitionDevicePath(devicePath)
+	}
+	if err := growFilesystem(s.run, devicePath); err != nil {
+		return 0, errors.Trace(err)
+	}
+	return blockDevice.Size, nil
+}
+
 func destroyPartitions(run runCommandFunc, devicePath string) error {
 	logger.Debugf("destroying partitions on %q", devicePath)
 	if _, err := run("sgdisk", "--zap-all", devicePath); err != nil {
@@ -196,6 +241,57 @@ func createFilesystem(run runCommandFunc, devicePath string) error {
 	return nil
 }
 
+// growPartition grows the single partition (1) on the disk with the
+// specified device path to fill the disk.
+func growPartition(run runCommandFunc, devicePath string) error {
+	logger.Debugf("growing partition on %q", devicePath)
+	if _, err := run("growpart", devicePath, "1"); err != nil {
+		// growpart exits with status 1 if the partition
+		// cannot be grown because it already fills the disk.
+		if !strings.Contains(err.Error(), "NOCHANGE") {
+			return errors.Annotate(err, "growpart failed")
+		}
+	}
+	return nil
+}
+
+// growFilesystem grows the filesystem on the specified device to fill
+// the device. The filesystem may be mounted; xfs filesystems must be.
+func growFilesystem(run runCommandFunc, devicePath string) error {
+	logger.Debugf("attempting to grow filesystem on %q", devicePath)
+	fsType, err := run("blkid", "-o", "value", "-s", "TYPE", devicePath)
+	if err != nil {
+		return errors.Annotate(err, "blkid failed")
+	}
+	var resizecmd string
+	var args []string
+	switch fsType = strings.TrimSpace(fsType); fsType {
+	case "ext2", "ext3", "ext4":
+		resizecmd = "resize2fs"
+		args = []string{devicePath}
+	case "xfs":
+		// xfs_growfs operates on the mount point rather
+		// than the device.
+		mountPoint, err := run("findmnt", "-n", "-o", "TARGET", "--source", devicePath)
+		if err != nil {
+			return errors.Annotate(err, "findmnt failed")
+		}
+		mountPoint = strings.TrimSpace(mountPoint)
+		if mountPoint == "" {
+			return errors.Errorf("xfs filesystem on %q is not mounted", devicePath)
+		}
+		resizecmd = "xfs_growfs"
+		args = []string{mountPoint}
+	default:
+		return errors.NotSupportedf("growing %q filesystem on %q", fsType, devicePath)
+	}
+	if _, err := run(resizecmd, args...); err != nil {
+		return errors.Annotatef(err, "%s failed", resizecmd)
+	}
+	logger.Infof("grew %s filesystem on %q", fsType, devicePath)
+	return nil
+}
+
 func mountFilesystem(run runCommandFunc, dirFuncs dirFuncs, devicePath, mountPoint string, readOnly bool) error {
 	logger.Debugf("attempting to mount filesystem on %q at %q", devicePath, mountPoint)
 	if err := dirFuncs.mkDirAll(mountPoint, 0755); err != nil {
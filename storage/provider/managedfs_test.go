@@ -112,6 +112,80 @@ func (s *managedfsSuite) TestCreateFilesystemsNoBlockDevice(c *gc.C) {
 	c.Assert(results[0].Error, gc.ErrorMatches, "backing-volume 0 is not yet attached")
 }
 
+func (s *managedfsSuite) TestResizeFilesystems(c *gc.C) {
+	source := s.initSource(c)
+	// The partition on sda is grown before the filesystem.
+	s.commands.expect("growpart", "/dev/sda", "1")
+	s.commands.expect("blkid", "-o", "value", "-s", "TYPE", "/dev/sda1").respond("ext4\n", nil)
+	s.commands.expect("resize2fs", "/dev/sda1")
+	s.commands.expect("blkid", "-o", "value", "-s", "TYPE", "/dev/xvdf1").respond("xfs\n", nil)
+	s.commands.expect("findmnt", "-n", "-o", "TARGET", "--source", "/dev/xvdf1").respond("/srv/data\n", nil)
+	s.commands.expect("xfs_growfs", "/srv/data")
+
+	s.blockDevices[names.NewVolumeTag("0")] = storage.BlockDevice{
+		DeviceName: "sda",
+		Size:       4,
+	}
+	s.blockDevices[names.NewVolumeTag("1")] = storage.BlockDevice{
+		DeviceName: "xvdf1",
+		Size:       6,
+	}
+	s.filesystems[names.NewFilesystemTag("0/0")] = storage.Filesystem{
+		Tag:    names.NewFilesystemTag("0/0"),
+		Volume: names.NewVolumeTag("0"),
+	}
+	s.filesystems[names.NewFilesystemTag("0/1")] = storage.Filesystem{
+		Tag:    names.NewFilesystemTag("0/1"),
+		Volume: names.NewVolumeTag("1"),
+	}
+	results, err := source.(storage.FilesystemResizer).ResizeFilesystems([]storage.ResizeFilesystemParams{{
+		Tag:  names.NewFilesystemTag("0/0"),
+		Size: 4,
+	}, {
+		Tag:  names.NewFilesystemTag("0/1"),
+		Size: 6,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.ResizeFilesystemsResult{{Size: 4}, {Size: 6}})
+}
+
+func (s *managedfsSuite) TestResizeFilesystemsUnsupportedType(c *gc.C) {
+	source := s.initSource(c)
+	s.commands.expect("blkid", "-o", "value", "-s", "TYPE", "/dev/xvdf1").respond("btrfs\n", nil)
+	s.blockDevices[names.NewVolumeTag("0")] = storage.BlockDevice{
+		DeviceName: "xvdf1",
+		Size:       4,
+	}
+	s.filesystems[names.NewFilesystemTag("0/0")] = storage.Filesystem{
+		Tag:    names.NewFilesystemTag("0/0"),
+		Volume: names.NewVolumeTag("0"),
+	}
+	results, err := source.(storage.FilesystemResizer).ResizeFilesystems([]storage.ResizeFilesystemParams{{
+		Tag:  names.NewFilesystemTag("0/0"),
+		Size: 4,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results[0].Error, gc.ErrorMatches, `growing "btrfs" filesystem on "/dev/xvdf1" not supported`)
+}
+
+func (s *managedfsSuite) TestResizeFilesystemsVolumeTooSmall(c *gc.C) {
+	source := s.initSource(c)
+	s.blockDevices[names.NewVolumeTag("0")] = storage.BlockDevice{
+		DeviceName: "sda",
+		Size:       2,
+	}
+	s.filesystems[names.NewFilesystemTag("0/0")] = storage.Filesystem{
+		Tag:    names.NewFilesystemTag("0/0"),
+		Volume: names.NewVolumeTag("0"),
+	}
+	results, err := source.(storage.FilesystemResizer).ResizeFilesystems([]storage.ResizeFilesystemParams{{
+		Tag:  names.NewFilesystemTag("0/0"),
+		Size: 4,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results[0].Error, gc.ErrorMatches, "backing-volume 0 is 2MiB, smaller than the requested 4MiB")
+}
+
 func (s *managedfsSuite) TestAttachFilesystems(c *gc.C) {
 	s.testAttachFilesystems(c, false, false)
 }
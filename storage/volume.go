@@ -73,3 +73,27 @@ type VolumeAttachmentInfo struct {
 	// ReadOnly signifies whether the volume is read only or writable.
 	ReadOnly bool
 }
+
+// VolumeSnapshot identifies and describes a point-in-time snapshot of
+// a volume, from which new volumes may be created.
+type VolumeSnapshot struct {
+	// Id is the unique ID assigned by Juju to the snapshot.
+	Id string
+
+	// Volume is the unique tag assigned by Juju for the volume
+	// that the snapshot was taken of.
+	Volume names.VolumeTag
+
+	VolumeSnapshotInfo
+}
+
+// VolumeSnapshotInfo describes a volume snapshot.
+type VolumeSnapshotInfo struct {
+	// SnapshotId is a unique provider-supplied ID for the snapshot.
+	SnapshotId string
+
+	// Size is the size of the volume that the snapshot was taken
+	// of, in MiB. Volumes created from the snapshot must be at
+	// least this large.
+	Size uint64
+}
@@ -0,0 +1,136 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package tracing
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+	"gopkg.in/tomb.v1"
+)
+
+var logger = loggo.GetLogger("juju.tracing")
+
+const (
+	// DefaultFlushInterval is how often buffered spans are exported.
+	DefaultFlushInterval = 5 * time.Second
+
+	// DefaultMaxBuffered is the number of finished spans held while
+	// waiting to be exported; further spans are dropped.
+	DefaultMaxBuffered = 1000
+)
+
+// Exporter sends finished spans to a trace collector.
+type Exporter interface {
+	Export([]FinishedSpan) error
+}
+
+// CollectorConfig holds the configuration for a Collector.
+type CollectorConfig struct {
+	Exporter      Exporter
+	Clock         clock.Clock
+	FlushInterval time.Duration
+	MaxBuffered   int
+}
+
+// Validate returns an error if the config cannot be used to
+// start a Collector.
+func (config CollectorConfig) Validate() error {
+	if config.Exporter == nil {
+		return errors.NotValidf("nil Exporter")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.FlushInterval <= 0 {
+		return errors.NotValidf("non-positive FlushInterval")
+	}
+	if config.MaxBuffered <= 0 {
+		return errors.NotValidf("non-positive MaxBuffered")
+	}
+	return nil
+}
+
+// Collector is a SpanRecorder that buffers finished spans and
+// periodically hands them to an Exporter. Recording never blocks on
+// the exporter: if the buffer is full, spans are dropped.
+type Collector struct {
+	tomb   tomb.Tomb
+	config CollectorConfig
+
+	mu      sync.Mutex
+	spans   []FinishedSpan
+	dropped int
+}
+
+// NewCollector starts and returns a new Collector. It must be killed
+// when no longer needed, at which point any buffered spans are
+// exported.
+func NewCollector(config CollectorConfig) (*Collector, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	c := &Collector{config: config}
+	go func() {
+		defer c.tomb.Done()
+		c.tomb.Kill(c.loop())
+	}()
+	return c, nil
+}
+
+// RecordSpan is part of the SpanRecorder interface.
+func (c *Collector) RecordSpan(span FinishedSpan) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.spans) >= c.config.MaxBuffered {
+		c.dropped++
+		return
+	}
+	c.spans = append(c.spans, span)
+}
+
+// Kill is part of the worker.Worker interface.
+func (c *Collector) Kill() {
+	c.tomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (c *Collector) Wait() error {
+	return c.tomb.Wait()
+}
+
+func (c *Collector) loop() error {
+	for {
+		select {
+		case <-c.tomb.Dying():
+			c.flush()
+			return tomb.ErrDying
+		case <-c.config.Clock.After(c.config.FlushInterval):
+			c.flush()
+		}
+	}
+}
+
+// flush exports the buffered spans. Export failures are logged
+// rather than retried, since tracing is best-effort and must not
+// take down the agent.
+func (c *Collector) flush() {
+	c.mu.Lock()
+	spans, dropped := c.spans, c.dropped
+	c.spans, c.dropped = nil, 0
+	c.mu.Unlock()
+
+	if dropped > 0 {
+		logger.Warningf("dropped %d spans: buffer full", dropped)
+	}
+	if len(spans) == 0 {
+		return
+	}
+	if err := c.config.Exporter.Export(spans); err != nil {
+		logger.Warningf("cannot export %d spans: %v", len(spans), err)
+	}
+}
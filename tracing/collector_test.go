@@ -0,0 +1,162 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package tracing_test
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/tracing"
+)
+
+type CollectorSuite struct {
+	testing.IsolationSuite
+
+	clock    *coretesting.Clock
+	exporter *fakeExporter
+	config   tracing.CollectorConfig
+}
+
+var _ = gc.Suite(&CollectorSuite{})
+
+func (s *CollectorSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = coretesting.NewClock(t0)
+	s.exporter = &fakeExporter{exported: make(chan []tracing.FinishedSpan, 10)}
+	s.config = tracing.CollectorConfig{
+		Exporter:      s.exporter,
+		Clock:         s.clock,
+		FlushInterval: time.Second,
+		MaxBuffered:   2,
+	}
+}
+
+func (s *CollectorSuite) startCollector(c *gc.C) *tracing.Collector {
+	collector, err := tracing.NewCollector(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) {
+		collector.Kill()
+		collector.Wait()
+	})
+	return collector
+}
+
+func (s *CollectorSuite) waitFlushPending(c *gc.C) {
+	select {
+	case <-s.clock.Alarms():
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for collector to wait")
+	}
+}
+
+func (s *CollectorSuite) waitExported(c *gc.C) []tracing.FinishedSpan {
+	select {
+	case spans := <-s.exporter.exported:
+		return spans
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for spans to be exported")
+	}
+	panic("unreachable")
+}
+
+func (s *CollectorSuite) TestValidate(c *gc.C) {
+	for i, test := range []struct {
+		mutate func(*tracing.CollectorConfig)
+		err    string
+	}{{
+		func(cfg *tracing.CollectorConfig) { cfg.Exporter = nil }, "nil Exporter not valid",
+	}, {
+		func(cfg *tracing.CollectorConfig) { cfg.Clock = nil }, "nil Clock not valid",
+	}, {
+		func(cfg *tracing.CollectorConfig) { cfg.FlushInterval = 0 }, "non-positive FlushInterval not valid",
+	}, {
+		func(cfg *tracing.CollectorConfig) { cfg.MaxBuffered = 0 }, "non-positive MaxBuffered not valid",
+	}} {
+		c.Logf("test %d", i)
+		config := s.config
+		test.mutate(&config)
+		_, err := tracing.NewCollector(config)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *CollectorSuite) TestFlushesPeriodically(c *gc.C) {
+	collector := s.startCollector(c)
+	s.waitFlushPending(c)
+	collector.RecordSpan(tracing.FinishedSpan{OperationName: "one"})
+	collector.RecordSpan(tracing.FinishedSpan{OperationName: "two"})
+	s.clock.Advance(time.Second)
+
+	spans := s.waitExported(c)
+	c.Assert(spans, gc.HasLen, 2)
+	c.Check(spans[0].OperationName, gc.Equals, "one")
+	c.Check(spans[1].OperationName, gc.Equals, "two")
+}
+
+func (s *CollectorSuite) TestDropsWhenFull(c *gc.C) {
+	collector := s.startCollector(c)
+	s.waitFlushPending(c)
+	for _, name := range []string{"one", "two", "three"} {
+		collector.RecordSpan(tracing.FinishedSpan{OperationName: name})
+	}
+	s.clock.Advance(time.Second)
+
+	spans := s.waitExported(c)
+	c.Assert(spans, gc.HasLen, 2)
+	c.Check(spans[1].OperationName, gc.Equals, "two")
+}
+
+func (s *CollectorSuite) TestFlushesOnKill(c *gc.C) {
+	collector := s.startCollector(c)
+	collector.RecordSpan(tracing.FinishedSpan{OperationName: "one"})
+	collector.Kill()
+	c.Assert(collector.Wait(), jc.ErrorIsNil)
+
+	spans := s.waitExported(c)
+	c.Assert(spans, gc.HasLen, 1)
+}
+
+func (s *CollectorSuite) TestExportErrorNotFatal(c *gc.C) {
+	s.exporter.setError(errors.New("collector down"))
+	collector := s.startCollector(c)
+	s.waitFlushPending(c)
+	collector.RecordSpan(tracing.FinishedSpan{OperationName: "one"})
+	s.clock.Advance(time.Second)
+	s.waitExported(c)
+
+	// The collector carries on, and the failed spans are not retried.
+	s.waitFlushPending(c)
+	s.exporter.setError(nil)
+	collector.RecordSpan(tracing.FinishedSpan{OperationName: "two"})
+	s.clock.Advance(time.Second)
+	spans := s.waitExported(c)
+	c.Assert(spans, gc.HasLen, 1)
+	c.Check(spans[0].OperationName, gc.Equals, "two")
+}
+
+type fakeExporter struct {
+	mu       sync.Mutex
+	err      error
+	exported chan []tracing.FinishedSpan
+}
+
+func (e *fakeExporter) setError(err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.err = err
+}
+
+func (e *fakeExporter) Export(spans []tracing.FinishedSpan) error {
+	e.exported <- spans
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.err
+}
@@ -0,0 +1,12 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package tracing provides an OpenTracing-compatible tracer for
+// following a single operation from the juju client, through the API
+// server and its facades, down to the transactions run against mongo.
+//
+// Span contexts are propagated between processes using the B3 header
+// convention (X-B3-TraceId, X-B3-SpanId, X-B3-Sampled), and finished
+// spans are exported in Zipkin's v2 JSON format to a collector
+// endpoint, by default one listening locally on port 9411.
+package tracing
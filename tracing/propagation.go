@@ -0,0 +1,91 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package tracing
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/opentracing/opentracing-go"
+)
+
+// The B3 header names used to propagate span contexts. Keys are
+// matched case-insensitively on extraction, so the same names work
+// for both HTTP headers and plain text maps.
+const (
+	TraceIDHeader = "X-B3-TraceId"
+	SpanIDHeader  = "X-B3-SpanId"
+	SampledHeader = "X-B3-Sampled"
+
+	// BaggageHeaderPrefix prefixes the key of each baggage item.
+	BaggageHeaderPrefix = "Ot-Baggage-"
+)
+
+func injectB3(ctx SpanContext, writer opentracing.TextMapWriter) {
+	writer.Set(TraceIDHeader, ctx.TraceIDString())
+	writer.Set(SpanIDHeader, ctx.SpanIDString())
+	if ctx.Sampled {
+		writer.Set(SampledHeader, "1")
+	} else {
+		writer.Set(SampledHeader, "0")
+	}
+	for k, v := range ctx.Baggage {
+		writer.Set(BaggageHeaderPrefix+k, v)
+	}
+}
+
+func extractB3(reader opentracing.TextMapReader) (opentracing.SpanContext, error) {
+	var ctx SpanContext
+	var found bool
+	// Unless told otherwise, a propagated trace is recorded.
+	ctx.Sampled = true
+	lowerBaggagePrefix := strings.ToLower(BaggageHeaderPrefix)
+	err := reader.ForeachKey(func(key, value string) error {
+		var err error
+		switch lowerKey := strings.ToLower(key); lowerKey {
+		case strings.ToLower(TraceIDHeader):
+			ctx.TraceID, err = parseID(value)
+			found = true
+		case strings.ToLower(SpanIDHeader):
+			ctx.SpanID, err = parseID(value)
+		case strings.ToLower(SampledHeader):
+			switch value {
+			case "1", "true":
+				ctx.Sampled = true
+			case "0", "false":
+				ctx.Sampled = false
+			default:
+				err = opentracing.ErrSpanContextCorrupted
+			}
+		default:
+			if strings.HasPrefix(lowerKey, lowerBaggagePrefix) {
+				ctx = ctx.withBaggageItem(lowerKey[len(lowerBaggagePrefix):], value)
+			}
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, opentracing.ErrSpanContextNotFound
+	}
+	if ctx.SpanID == 0 {
+		return nil, opentracing.ErrSpanContextCorrupted
+	}
+	return ctx, nil
+}
+
+// parseID parses a hex span or trace ID. 128-bit trace IDs are
+// accepted, but only their low 64 bits are kept.
+func parseID(value string) (uint64, error) {
+	if n := len(value); n > 16 && n <= 32 {
+		value = value[n-16:]
+	}
+	id, err := strconv.ParseUint(value, 16, 64)
+	if err != nil || id == 0 {
+		return 0, opentracing.ErrSpanContextCorrupted
+	}
+	return id, nil
+}
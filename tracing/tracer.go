@@ -0,0 +1,331 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package tracing
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/log"
+)
+
+// SpanRecorder receives spans once they have finished.
+type SpanRecorder interface {
+	// RecordSpan is called once for every finished, sampled span.
+	// It must not block.
+	RecordSpan(FinishedSpan)
+}
+
+// FinishedSpan holds the details of a span that has finished.
+type FinishedSpan struct {
+	Context       SpanContext
+	ParentSpanID  uint64
+	OperationName string
+	Start         time.Time
+	Duration      time.Duration
+	Tags          map[string]interface{}
+	Logs          []opentracing.LogRecord
+}
+
+// SpanContext is the opentracing.SpanContext used by Tracer. It
+// identifies a span within a trace, and is what gets propagated
+// across process boundaries.
+type SpanContext struct {
+	TraceID uint64
+	SpanID  uint64
+	Sampled bool
+	Baggage map[string]string
+}
+
+// ForeachBaggageItem is part of the opentracing.SpanContext interface.
+func (c SpanContext) ForeachBaggageItem(handler func(k, v string) bool) {
+	for k, v := range c.Baggage {
+		if !handler(k, v) {
+			break
+		}
+	}
+}
+
+// TraceIDString returns the trace ID in the hex form used in headers
+// and log messages.
+func (c SpanContext) TraceIDString() string {
+	return formatID(c.TraceID)
+}
+
+// SpanIDString returns the span ID in the hex form used in headers.
+func (c SpanContext) SpanIDString() string {
+	return formatID(c.SpanID)
+}
+
+func (c SpanContext) withBaggageItem(key, value string) SpanContext {
+	baggage := make(map[string]string, len(c.Baggage)+1)
+	for k, v := range c.Baggage {
+		baggage[k] = v
+	}
+	baggage[key] = value
+	c.Baggage = baggage
+	return c
+}
+
+func formatID(id uint64) string {
+	return fmt.Sprintf("%016x", id)
+}
+
+// Config holds the configuration for a Tracer.
+type Config struct {
+	// Recorder receives every sampled span when it finishes.
+	Recorder SpanRecorder
+
+	// Clock is used to time spans.
+	Clock clock.Clock
+}
+
+// Validate returns an error if the config cannot be used to
+// create a Tracer.
+func (config Config) Validate() error {
+	if config.Recorder == nil {
+		return errors.NotValidf("nil Recorder")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	return nil
+}
+
+// Tracer is an opentracing.Tracer that hands finished spans to a
+// SpanRecorder.
+type Tracer struct {
+	config Config
+
+	mu   sync.Mutex
+	rand *rand.Rand
+}
+
+// NewTracer returns a new Tracer with the given configuration.
+func NewTracer(config Config) (*Tracer, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &Tracer{
+		config: config,
+		rand:   rand.New(rand.NewSource(time.Now().UnixNano())),
+	}, nil
+}
+
+// newID returns a new, non-zero, random span or trace ID.
+func (t *Tracer) newID() uint64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for {
+		if id := uint64(t.rand.Int63()); id != 0 {
+			return id
+		}
+	}
+}
+
+// StartSpan is part of the opentracing.Tracer interface.
+func (t *Tracer) StartSpan(operationName string, opts ...opentracing.StartSpanOption) opentracing.Span {
+	var options opentracing.StartSpanOptions
+	for _, opt := range opts {
+		opt.Apply(&options)
+	}
+	start := options.StartTime
+	if start.IsZero() {
+		start = t.config.Clock.Now()
+	}
+
+	s := &span{
+		tracer: t,
+		data: FinishedSpan{
+			OperationName: operationName,
+			Start:         start,
+		},
+	}
+	for _, ref := range options.References {
+		parent, ok := ref.ReferencedContext.(SpanContext)
+		if !ok {
+			// Contexts from other tracers cannot be continued.
+			continue
+		}
+		s.data.Context = parent
+		s.data.ParentSpanID = parent.SpanID
+		break
+	}
+	if s.data.Context.TraceID == 0 {
+		s.data.Context = SpanContext{
+			TraceID: t.newID(),
+			Sampled: true,
+		}
+	}
+	s.data.Context.SpanID = t.newID()
+	if len(options.Tags) > 0 {
+		s.data.Tags = make(map[string]interface{}, len(options.Tags))
+		for k, v := range options.Tags {
+			s.data.Tags[k] = v
+		}
+	}
+	return s
+}
+
+// Inject is part of the opentracing.Tracer interface.
+func (t *Tracer) Inject(sc opentracing.SpanContext, format interface{}, carrier interface{}) error {
+	ctx, ok := sc.(SpanContext)
+	if !ok {
+		return opentracing.ErrInvalidSpanContext
+	}
+	switch format {
+	case opentracing.TextMap, opentracing.HTTPHeaders:
+		writer, ok := carrier.(opentracing.TextMapWriter)
+		if !ok {
+			return opentracing.ErrInvalidCarrier
+		}
+		injectB3(ctx, writer)
+		return nil
+	}
+	return opentracing.ErrUnsupportedFormat
+}
+
+// Extract is part of the opentracing.Tracer interface.
+func (t *Tracer) Extract(format interface{}, carrier interface{}) (opentracing.SpanContext, error) {
+	switch format {
+	case opentracing.TextMap, opentracing.HTTPHeaders:
+		reader, ok := carrier.(opentracing.TextMapReader)
+		if !ok {
+			return nil, opentracing.ErrInvalidCarrier
+		}
+		return extractB3(reader)
+	}
+	return nil, opentracing.ErrUnsupportedFormat
+}
+
+// span is the opentracing.Span returned by Tracer.
+type span struct {
+	tracer *Tracer
+
+	mu       sync.Mutex
+	data     FinishedSpan
+	finished bool
+}
+
+// Finish is part of the opentracing.Span interface.
+func (s *span) Finish() {
+	s.FinishWithOptions(opentracing.FinishOptions{})
+}
+
+// FinishWithOptions is part of the opentracing.Span interface.
+func (s *span) FinishWithOptions(opts opentracing.FinishOptions) {
+	finish := opts.FinishTime
+	if finish.IsZero() {
+		finish = s.tracer.config.Clock.Now()
+	}
+
+	s.mu.Lock()
+	if s.finished {
+		s.mu.Unlock()
+		return
+	}
+	s.finished = true
+	for _, ld := range opts.BulkLogData {
+		s.data.Logs = append(s.data.Logs, ld.ToLogRecord())
+	}
+	s.data.Logs = append(s.data.Logs, opts.LogRecords...)
+	s.data.Duration = finish.Sub(s.data.Start)
+	finished := s.data
+	s.mu.Unlock()
+
+	if finished.Context.Sampled {
+		s.tracer.config.Recorder.RecordSpan(finished)
+	}
+}
+
+// Context is part of the opentracing.Span interface.
+func (s *span) Context() opentracing.SpanContext {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.data.Context
+}
+
+// SetOperationName is part of the opentracing.Span interface.
+func (s *span) SetOperationName(operationName string) opentracing.Span {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data.OperationName = operationName
+	return s
+}
+
+// SetTag is part of the opentracing.Span interface.
+func (s *span) SetTag(key string, value interface{}) opentracing.Span {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.data.Tags == nil {
+		s.data.Tags = make(map[string]interface{})
+	}
+	s.data.Tags[key] = value
+	return s
+}
+
+// LogFields is part of the opentracing.Span interface.
+func (s *span) LogFields(fields ...log.Field) {
+	record := opentracing.LogRecord{
+		Timestamp: s.tracer.config.Clock.Now(),
+		Fields:    fields,
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data.Logs = append(s.data.Logs, record)
+}
+
+// LogKV is part of the opentracing.Span interface.
+func (s *span) LogKV(alternatingKeyValues ...interface{}) {
+	fields, err := log.InterleavedKVToFields(alternatingKeyValues...)
+	if err != nil {
+		fields = []log.Field{log.Error(err), log.String("function", "LogKV")}
+	}
+	s.LogFields(fields...)
+}
+
+// SetBaggageItem is part of the opentracing.Span interface.
+func (s *span) SetBaggageItem(key, value string) opentracing.Span {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data.Context = s.data.Context.withBaggageItem(key, value)
+	return s
+}
+
+// BaggageItem is part of the opentracing.Span interface.
+func (s *span) BaggageItem(key string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.data.Context.Baggage[key]
+}
+
+// Tracer is part of the opentracing.Span interface.
+func (s *span) Tracer() opentracing.Tracer {
+	return s.tracer
+}
+
+// LogEvent is part of the opentracing.Span interface.
+func (s *span) LogEvent(event string) {
+	s.Log(opentracing.LogData{Event: event})
+}
+
+// LogEventWithPayload is part of the opentracing.Span interface.
+func (s *span) LogEventWithPayload(event string, payload interface{}) {
+	s.Log(opentracing.LogData{Event: event, Payload: payload})
+}
+
+// Log is part of the opentracing.Span interface.
+func (s *span) Log(ld opentracing.LogData) {
+	if ld.Timestamp.IsZero() {
+		ld.Timestamp = s.tracer.config.Clock.Now()
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data.Logs = append(s.data.Logs, ld.ToLogRecord())
+}
@@ -0,0 +1,199 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package tracing_test
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/log"
+	gc "gopkg.in/check.v1"
+
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/tracing"
+)
+
+var t0 = time.Date(2016, 10, 1, 12, 0, 0, 0, time.UTC)
+
+type TracerSuite struct {
+	testing.IsolationSuite
+
+	clock    *coretesting.Clock
+	recorder *spanRecorder
+	tracer   *tracing.Tracer
+}
+
+var _ = gc.Suite(&TracerSuite{})
+
+func (s *TracerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = coretesting.NewClock(t0)
+	s.recorder = &spanRecorder{}
+	tracer, err := tracing.NewTracer(tracing.Config{
+		Recorder: s.recorder,
+		Clock:    s.clock,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.tracer = tracer
+}
+
+func (s *TracerSuite) TestValidate(c *gc.C) {
+	_, err := tracing.NewTracer(tracing.Config{Clock: s.clock})
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, "nil Recorder not valid")
+
+	_, err = tracing.NewTracer(tracing.Config{Recorder: s.recorder})
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, "nil Clock not valid")
+}
+
+func (s *TracerSuite) TestRootSpan(c *gc.C) {
+	span := s.tracer.StartSpan("deploy", opentracing.Tag{Key: "application", Value: "mysql"})
+	s.clock.Advance(time.Second)
+	span.SetTag("units", 3)
+	span.Finish()
+
+	c.Assert(s.recorder.spans, gc.HasLen, 1)
+	finished := s.recorder.spans[0]
+	c.Check(finished.OperationName, gc.Equals, "deploy")
+	c.Check(finished.Context.TraceID, gc.Not(gc.Equals), uint64(0))
+	c.Check(finished.Context.SpanID, gc.Not(gc.Equals), uint64(0))
+	c.Check(finished.Context.Sampled, jc.IsTrue)
+	c.Check(finished.ParentSpanID, gc.Equals, uint64(0))
+	c.Check(finished.Start, gc.Equals, t0)
+	c.Check(finished.Duration, gc.Equals, time.Second)
+	c.Check(finished.Tags, jc.DeepEquals, map[string]interface{}{
+		"application": "mysql",
+		"units":       3,
+	})
+}
+
+func (s *TracerSuite) TestChildSpan(c *gc.C) {
+	parent := s.tracer.StartSpan("parent")
+	parent.SetBaggageItem("user", "bob")
+	child := s.tracer.StartSpan("child", opentracing.ChildOf(parent.Context()))
+	child.Finish()
+	parent.Finish()
+
+	c.Assert(s.recorder.spans, gc.HasLen, 2)
+	childSpan, parentSpan := s.recorder.spans[0], s.recorder.spans[1]
+	c.Check(childSpan.Context.TraceID, gc.Equals, parentSpan.Context.TraceID)
+	c.Check(childSpan.Context.SpanID, gc.Not(gc.Equals), parentSpan.Context.SpanID)
+	c.Check(childSpan.ParentSpanID, gc.Equals, parentSpan.Context.SpanID)
+	c.Check(child.BaggageItem("user"), gc.Equals, "bob")
+}
+
+func (s *TracerSuite) TestFinishTwice(c *gc.C) {
+	span := s.tracer.StartSpan("op")
+	span.Finish()
+	span.Finish()
+	c.Assert(s.recorder.spans, gc.HasLen, 1)
+}
+
+func (s *TracerSuite) TestUnsampledNotRecorded(c *gc.C) {
+	parent, err := s.tracer.Extract(opentracing.TextMap, opentracing.TextMapCarrier{
+		"X-B3-TraceId": "00000000000004d2",
+		"X-B3-SpanId":  "000000000000162e",
+		"X-B3-Sampled": "0",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.tracer.StartSpan("op", opentracing.ChildOf(parent)).Finish()
+	c.Assert(s.recorder.spans, gc.HasLen, 0)
+}
+
+func (s *TracerSuite) TestLogFields(c *gc.C) {
+	span := s.tracer.StartSpan("op")
+	span.LogFields(log.String("event", "retry"), log.Int("attempt", 2))
+	span.Finish()
+
+	c.Assert(s.recorder.spans, gc.HasLen, 1)
+	logs := s.recorder.spans[0].Logs
+	c.Assert(logs, gc.HasLen, 1)
+	c.Check(logs[0].Timestamp, gc.Equals, t0)
+	c.Check(logs[0].Fields, gc.HasLen, 2)
+}
+
+func (s *TracerSuite) TestInjectExtract(c *gc.C) {
+	span := s.tracer.StartSpan("op")
+	span.SetBaggageItem("user", "bob")
+	carrier := opentracing.TextMapCarrier{}
+	err := s.tracer.Inject(span.Context(), opentracing.TextMap, carrier)
+	c.Assert(err, jc.ErrorIsNil)
+
+	ctx := span.Context().(tracing.SpanContext)
+	c.Check(carrier, jc.DeepEquals, opentracing.TextMapCarrier{
+		"X-B3-TraceId":    ctx.TraceIDString(),
+		"X-B3-SpanId":     ctx.SpanIDString(),
+		"X-B3-Sampled":    "1",
+		"Ot-Baggage-user": "bob",
+	})
+
+	extracted, err := s.tracer.Extract(opentracing.TextMap, carrier)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(extracted, jc.DeepEquals, ctx)
+}
+
+func (s *TracerSuite) TestExtractHTTPHeaders(c *gc.C) {
+	header := http.Header{}
+	header.Set("X-B3-TraceId", "463ac35c9f6413ad48485a3953bb6124")
+	header.Set("X-B3-SpanId", "a2fb4a1d1a96d312")
+	ctx, err := s.tracer.Extract(opentracing.HTTPHeaders, opentracing.HTTPHeadersCarrier(header))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(ctx, jc.DeepEquals, tracing.SpanContext{
+		TraceID: 0x48485a3953bb6124,
+		SpanID:  0xa2fb4a1d1a96d312,
+		Sampled: true,
+	})
+}
+
+func (s *TracerSuite) TestExtractErrors(c *gc.C) {
+	for i, test := range []struct {
+		carrier opentracing.TextMapCarrier
+		err     error
+	}{{
+		carrier: opentracing.TextMapCarrier{},
+		err:     opentracing.ErrSpanContextNotFound,
+	}, {
+		carrier: opentracing.TextMapCarrier{"X-B3-TraceId": "00000000000004d2"},
+		err:     opentracing.ErrSpanContextCorrupted,
+	}, {
+		carrier: opentracing.TextMapCarrier{"X-B3-TraceId": "xyz", "X-B3-SpanId": "000000000000162e"},
+		err:     opentracing.ErrSpanContextCorrupted,
+	}, {
+		carrier: opentracing.TextMapCarrier{"X-B3-TraceId": "00000000000004d2", "X-B3-SpanId": "000000000000162e", "X-B3-Sampled": "maybe"},
+		err:     opentracing.ErrSpanContextCorrupted,
+	}} {
+		c.Logf("test %d", i)
+		_, err := s.tracer.Extract(opentracing.TextMap, test.carrier)
+		c.Check(err, gc.Equals, test.err)
+	}
+}
+
+func (s *TracerSuite) TestUnsupportedFormat(c *gc.C) {
+	span := s.tracer.StartSpan("op")
+	err := s.tracer.Inject(span.Context(), opentracing.Binary, nil)
+	c.Check(err, gc.Equals, opentracing.ErrUnsupportedFormat)
+	_, err = s.tracer.Extract(opentracing.Binary, nil)
+	c.Check(err, gc.Equals, opentracing.ErrUnsupportedFormat)
+}
+
+func (s *TracerSuite) TestTraceIDFromHeaders(c *gc.C) {
+	c.Check(tracing.TraceIDFromHeaders(map[string]string{
+		"X-B3-TraceId": "00000000000004d2",
+		"X-B3-SpanId":  "000000000000162e",
+	}), gc.Equals, "00000000000004d2")
+	c.Check(tracing.TraceIDFromHeaders(nil), gc.Equals, "")
+}
+
+type spanRecorder struct {
+	spans []tracing.FinishedSpan
+}
+
+func (r *spanRecorder) RecordSpan(span tracing.FinishedSpan) {
+	r.spans = append(r.spans, span)
+}
@@ -0,0 +1,52 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package tracing
+
+import (
+	"sync"
+
+	"github.com/opentracing/opentracing-go"
+)
+
+var (
+	rootMu   sync.Mutex
+	rootSpan opentracing.Span
+)
+
+// SetRootSpan sets the span under which API calls made by this process
+// are traced. It is intended for short-lived processes, such as the
+// juju client, where a whole run is a single traced operation. Passing
+// nil stops calls from being traced.
+func SetRootSpan(span opentracing.Span) {
+	rootMu.Lock()
+	defer rootMu.Unlock()
+	rootSpan = span
+}
+
+// RootSpan returns the span set by SetRootSpan, or nil if there
+// is none.
+func RootSpan() opentracing.Span {
+	rootMu.Lock()
+	defer rootMu.Unlock()
+	return rootSpan
+}
+
+// TraceID returns the trace ID of the given span context, or the
+// empty string if it was not created by a Tracer.
+func TraceID(ctx opentracing.SpanContext) string {
+	if ctx, ok := ctx.(SpanContext); ok {
+		return ctx.TraceIDString()
+	}
+	return ""
+}
+
+// TraceIDFromHeaders returns the trace ID propagated in the given
+// header map, or the empty string if there is none.
+func TraceIDFromHeaders(headers map[string]string) string {
+	ctx, err := extractB3(opentracing.TextMapCarrier(headers))
+	if err != nil {
+		return ""
+	}
+	return TraceID(ctx)
+}
@@ -0,0 +1,154 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package tracing
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+)
+
+// DefaultCollectorURL is the Zipkin v2 span endpoint of a collector
+// running on the local machine.
+const DefaultCollectorURL = "http://localhost:9411/api/v2/spans"
+
+// defaultTimeout is the time allowed for a single batch of spans to
+// be sent and acknowledged.
+const defaultTimeout = 10 * time.Second
+
+// Doer exposes the underlying functionality needed by ZipkinExporter.
+type Doer interface {
+	// Do sends the HTTP request and returns the response.
+	Do(*http.Request) (*http.Response, error)
+}
+
+// ZipkinExporter sends finished spans to a Zipkin collector using
+// its v2 JSON API.
+type ZipkinExporter struct {
+	// URL is the collector endpoint to which spans are POSTed.
+	URL string
+
+	// ServiceName identifies the process recording the spans.
+	ServiceName string
+
+	// Doer is used to send the HTTP requests.
+	Doer Doer
+}
+
+// NewZipkinExporter returns a ZipkinExporter that posts spans recorded
+// by the named service to the given collector URL. If the URL is
+// empty, DefaultCollectorURL is used.
+func NewZipkinExporter(url, serviceName string) *ZipkinExporter {
+	if url == "" {
+		url = DefaultCollectorURL
+	}
+	return &ZipkinExporter{
+		URL:         url,
+		ServiceName: serviceName,
+		Doer:        &http.Client{Timeout: defaultTimeout},
+	}
+}
+
+// Export is part of the Exporter interface.
+func (e *ZipkinExporter) Export(spans []FinishedSpan) error {
+	if len(spans) == 0 {
+		return nil
+	}
+	docs := make([]zipkinSpan, len(spans))
+	for i, span := range spans {
+		docs[i] = e.zipkinSpan(span)
+	}
+	body, err := json.Marshal(docs)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	req, err := http.NewRequest("POST", e.URL, bytes.NewReader(body))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := e.Doer.Do(req)
+	if err != nil {
+		return errors.Annotate(err, "sending spans")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return errors.Errorf("sending spans: %s", resp.Status)
+	}
+	return nil
+}
+
+// zipkinSpan is the Zipkin v2 JSON representation of a span.
+// Timestamps and durations are in microseconds.
+type zipkinSpan struct {
+	TraceID       string             `json:"traceId"`
+	ID            string             `json:"id"`
+	ParentID      string             `json:"parentId,omitempty"`
+	Name          string             `json:"name"`
+	Kind          string             `json:"kind,omitempty"`
+	Timestamp     int64              `json:"timestamp"`
+	Duration      int64              `json:"duration"`
+	LocalEndpoint zipkinEndpoint     `json:"localEndpoint"`
+	Tags          map[string]string  `json:"tags,omitempty"`
+	Annotations   []zipkinAnnotation `json:"annotations,omitempty"`
+}
+
+type zipkinEndpoint struct {
+	ServiceName string `json:"serviceName"`
+}
+
+type zipkinAnnotation struct {
+	Timestamp int64  `json:"timestamp"`
+	Value     string `json:"value"`
+}
+
+func (e *ZipkinExporter) zipkinSpan(span FinishedSpan) zipkinSpan {
+	doc := zipkinSpan{
+		TraceID:       span.Context.TraceIDString(),
+		ID:            span.Context.SpanIDString(),
+		Name:          span.OperationName,
+		Timestamp:     span.Start.UnixNano() / int64(time.Microsecond),
+		Duration:      int64(span.Duration / time.Microsecond),
+		LocalEndpoint: zipkinEndpoint{ServiceName: e.ServiceName},
+	}
+	if span.ParentSpanID != 0 {
+		doc.ParentID = formatID(span.ParentSpanID)
+	}
+	for k, v := range span.Tags {
+		if k == string(ext.SpanKind) {
+			doc.Kind = strings.ToUpper(fmt.Sprint(v))
+			continue
+		}
+		if doc.Tags == nil {
+			doc.Tags = make(map[string]string)
+		}
+		doc.Tags[k] = fmt.Sprint(v)
+	}
+	for _, record := range span.Logs {
+		doc.Annotations = append(doc.Annotations, zipkinAnnotation{
+			Timestamp: record.Timestamp.UnixNano() / int64(time.Microsecond),
+			Value:     annotationValue(record),
+		})
+	}
+	return doc
+}
+
+// annotationValue renders the fields of a log record as a single,
+// stable, space-separated list of key:value pairs.
+func annotationValue(record opentracing.LogRecord) string {
+	values := make([]string, len(record.Fields))
+	for i, field := range record.Fields {
+		values[i] = field.String()
+	}
+	sort.Strings(values)
+	return strings.Join(values, " ")
+}
@@ -0,0 +1,109 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package tracing_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/log"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/tracing"
+)
+
+type ZipkinSuite struct {
+	testing.IsolationSuite
+
+	server   *httptest.Server
+	requests []*http.Request
+	bodies   [][]byte
+	status   int
+}
+
+var _ = gc.Suite(&ZipkinSuite{})
+
+func (s *ZipkinSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+
+	s.requests = nil
+	s.bodies = nil
+	s.status = http.StatusAccepted
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := ioutil.ReadAll(req.Body)
+		s.requests = append(s.requests, req)
+		s.bodies = append(s.bodies, body)
+		w.WriteHeader(s.status)
+	}))
+	s.AddCleanup(func(*gc.C) { s.server.Close() })
+}
+
+func (s *ZipkinSuite) TestNewZipkinExporterDefaultURL(c *gc.C) {
+	exporter := tracing.NewZipkinExporter("", "jujud")
+	c.Check(exporter.URL, gc.Equals, tracing.DefaultCollectorURL)
+	c.Check(exporter.ServiceName, gc.Equals, "jujud")
+}
+
+func (s *ZipkinSuite) TestExport(c *gc.C) {
+	exporter := tracing.NewZipkinExporter(s.server.URL+"/api/v2/spans", "jujud")
+	err := exporter.Export([]tracing.FinishedSpan{{
+		Context: tracing.SpanContext{
+			TraceID: 0x4d2,
+			SpanID:  0x162e,
+			Sampled: true,
+		},
+		ParentSpanID:  0x1,
+		OperationName: "Client.FullStatus",
+		Start:         t0,
+		Duration:      1500 * time.Microsecond,
+		Tags: map[string]interface{}{
+			"span.kind":   "server",
+			"rpc.version": 1,
+		},
+		Logs: []opentracing.LogRecord{{
+			Timestamp: t0.Add(time.Millisecond),
+			Fields:    []log.Field{log.String("event", "retry")},
+		}},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.requests, gc.HasLen, 1)
+	c.Check(s.requests[0].Method, gc.Equals, "POST")
+	c.Check(s.requests[0].URL.Path, gc.Equals, "/api/v2/spans")
+	c.Check(s.requests[0].Header.Get("Content-Type"), gc.Equals, "application/json")
+	c.Check(string(s.bodies[0]), jc.JSONEquals, []map[string]interface{}{{
+		"traceId":       "00000000000004d2",
+		"id":            "000000000000162e",
+		"parentId":      "0000000000000001",
+		"name":          "Client.FullStatus",
+		"kind":          "SERVER",
+		"timestamp":     t0.UnixNano() / 1000,
+		"duration":      1500,
+		"localEndpoint": map[string]interface{}{"serviceName": "jujud"},
+		"tags":          map[string]interface{}{"rpc.version": "1"},
+		"annotations": []map[string]interface{}{{
+			"timestamp": t0.UnixNano()/1000 + 1000,
+			"value":     "event:retry",
+		}},
+	}})
+}
+
+func (s *ZipkinSuite) TestExportNothing(c *gc.C) {
+	exporter := tracing.NewZipkinExporter(s.server.URL, "jujud")
+	err := exporter.Export(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.requests, gc.HasLen, 0)
+}
+
+func (s *ZipkinSuite) TestExportRejected(c *gc.C) {
+	s.status = http.StatusBadRequest
+	exporter := tracing.NewZipkinExporter(s.server.URL, "jujud")
+	err := exporter.Export([]tracing.FinishedSpan{{OperationName: "op"}})
+	c.Assert(err, gc.ErrorMatches, "sending spans: 400 Bad Request")
+}
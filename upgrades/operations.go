@@ -18,11 +18,14 @@ import (
 // (below).
 var stateUpgradeOperations = func() []Operation {
 	steps := []Operation{
-		// Replace when we have upgrades to do
 		upgradeToVersion{
 			version.MustParse("1.26-placeholder1"),
 			[]Step{},
 		},
+		upgradeToVersion{
+			version.MustParse("2.0.0"),
+			stateStepsFor20(),
+		},
 	}
 	return steps
 }
@@ -670,6 +670,7 @@ func (s *upgradeSuite) TestStateUpgradeOperationsVersions(c *gc.C) {
 	versions := extractUpgradeVersions(c, (*upgrades.StateUpgradeOperations)())
 	c.Assert(versions, gc.DeepEquals, []string{
 		"1.26-placeholder1",
+		"2.0.0",
 	})
 }
 
@@ -685,7 +686,9 @@ func extractUpgradeVersions(c *gc.C, ops []upgrades.Operation) []string {
 	for _, utv := range ops {
 		vers := utv.TargetVersion()
 		// Upgrade steps should only be targeted at final versions (not alpha/beta).
-		c.Check(vers.Tag, gc.Equals, "placeholder")
+		if vers.Tag != "placeholder" {
+			c.Check(vers.Tag, gc.Equals, "")
+		}
 		versions = append(versions, vers.String())
 	}
 	return versions
@@ -0,0 +1,256 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package auditforwarder provides a worker that forwards the audit
+// entries recorded by the controller to a remote sink, such as a
+// syslog server.
+package auditforwarder
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+
+	"github.com/juju/juju/audit"
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/worker"
+)
+
+var logger = loggo.GetLogger("juju.worker.auditforwarder")
+
+// DefaultPollInterval is the time the worker waits between checks for
+// new audit entries, and before retrying after a failure to forward.
+const DefaultPollInterval = 10 * time.Second
+
+// DefaultBatchSize is the maximum number of audit entries read and
+// sent at once.
+const DefaultBatchSize = 500
+
+// DefaultSettleDelay is how old an audit entry must be before it is
+// forwarded.
+const DefaultSettleDelay = time.Minute
+
+// Backend provides the audit entries to forward.
+type Backend interface {
+	// AuditEntriesFrom returns up to limit audit entries that were
+	// written at or after the given time, oldest first.
+	AuditEntriesFrom(from time.Time, limit int) ([]audit.AuditEntry, error)
+}
+
+// Cursor durably records how far forwarding has progressed, as the
+// timestamp (in nanoseconds) of the last forwarded entry and the
+// number of forwarded entries written at that exact time. It is
+// satisfied by *state.LastSentLogTracker.
+type Cursor interface {
+	Get() (int64, int64, error)
+	Set(count, timestamp int64) error
+}
+
+// Sink is a connection to the remote sink for audit entries.
+type Sink interface {
+	SendAudit(origin logfwd.Origin, entries []audit.AuditEntry) error
+	Close() error
+}
+
+// Config holds the configuration and dependencies for a worker.
+type Config struct {
+	// Backend supplies the audit entries to forward.
+	Backend Backend
+
+	// Cursor records the last entry forwarded.
+	Cursor Cursor
+
+	// Origin identifies the agent forwarding the entries.
+	Origin logfwd.Origin
+
+	// OpenSink connects to the remote sink.
+	OpenSink func() (Sink, error)
+
+	// Clock is used to wait between polls.
+	Clock clock.Clock
+
+	// PollInterval is the time between polls for new entries.
+	PollInterval time.Duration
+
+	// BatchSize is the maximum number of entries sent at once.
+	BatchSize int
+
+	// SettleDelay is how old an entry must be before it is forwarded.
+	// Entries are timestamped before they are written, and controllers
+	// write them concurrently, so an entry may become visible after
+	// newer ones have been read. Holding back recent entries ensures
+	// that none is skipped, provided that the delay exceeds both the
+	// clock skew between controllers and the time taken to write an
+	// entry.
+	SettleDelay time.Duration
+}
+
+// Validate returns an error if the config cannot be used to start
+// a worker.
+func (config Config) Validate() error {
+	if config.Backend == nil {
+		return errors.NotValidf("nil Backend")
+	}
+	if config.Cursor == nil {
+		return errors.NotValidf("nil Cursor")
+	}
+	if config.OpenSink == nil {
+		return errors.NotValidf("nil OpenSink")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.PollInterval <= 0 {
+		return errors.NotValidf("non-positive PollInterval")
+	}
+	if config.BatchSize <= 0 {
+		return errors.NotValidf("non-positive BatchSize")
+	}
+	if config.SettleDelay <= 0 {
+		return errors.NotValidf("non-positive SettleDelay")
+	}
+	return nil
+}
+
+// New returns a worker that forwards audit entries, oldest first, to
+// the configured sink. The cursor is only advanced once entries have
+// been sent, so if the sink is unavailable no entries are lost: they
+// are sent once it can be reached again.
+func New(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	f := &forwarder{config: config}
+	return worker.NewSimpleWorker(f.loop), nil
+}
+
+type forwarder struct {
+	config Config
+	sink   Sink
+}
+
+func (f *forwarder) loop(stopCh <-chan struct{}) error {
+	defer f.closeSink()
+	for {
+		if err := f.forward(stopCh); err != nil {
+			return errors.Trace(err)
+		}
+		select {
+		case <-stopCh:
+			return nil
+		case <-f.config.Clock.After(f.config.PollInterval):
+		}
+	}
+}
+
+// forward sends batches of entries until none remain, or the sink
+// fails. Failures of the sink are logged and retried at the next
+// poll; other errors are returned.
+func (f *forwarder) forward(stopCh <-chan struct{}) error {
+	for {
+		select {
+		case <-stopCh:
+			return nil
+		default:
+		}
+
+		count, timestamp, err := f.config.Cursor.Get()
+		if errors.Cause(err) == state.ErrNeverForwarded {
+			count, timestamp = 0, 0
+		} else if err != nil {
+			return errors.Annotate(err, "reading audit cursor")
+		}
+
+		entries, err := f.config.Backend.AuditEntriesFrom(
+			time.Unix(0, timestamp), int(count)+f.config.BatchSize,
+		)
+		if err != nil {
+			return errors.Annotate(err, "reading audit entries")
+		}
+		entries = skipSent(entries, count, timestamp)
+		more := len(entries) == f.config.BatchSize
+		settled := settledBefore(entries, f.config.Clock.Now().Add(-f.config.SettleDelay))
+		if len(settled) < len(entries) {
+			entries, more = settled, false
+		}
+		if len(entries) == 0 {
+			return nil
+		}
+
+		if err := f.send(entries); err != nil {
+			logger.Warningf("cannot forward audit entries: %v", err)
+			f.closeSink()
+			return nil
+		}
+
+		count, timestamp = advance(entries, count, timestamp)
+		if err := f.config.Cursor.Set(count, timestamp); err != nil {
+			return errors.Annotate(err, "recording audit cursor")
+		}
+		if !more {
+			return nil
+		}
+	}
+}
+
+func (f *forwarder) send(entries []audit.AuditEntry) error {
+	if f.sink == nil {
+		sink, err := f.config.OpenSink()
+		if err != nil {
+			return errors.Annotate(err, "opening sink")
+		}
+		f.sink = sink
+	}
+	return errors.Trace(f.sink.SendAudit(f.config.Origin, entries))
+}
+
+func (f *forwarder) closeSink() {
+	if f.sink == nil {
+		return
+	}
+	if err := f.sink.Close(); err != nil {
+		logger.Debugf("closing audit sink: %v", err)
+	}
+	f.sink = nil
+}
+
+// skipSent drops the leading entries that were written at the cursor's
+// timestamp and have already been forwarded.
+func skipSent(entries []audit.AuditEntry, count, timestamp int64) []audit.AuditEntry {
+	var skip int64
+	for _, entry := range entries {
+		if skip == count || entry.Timestamp.UnixNano() != timestamp {
+			break
+		}
+		skip++
+	}
+	return entries[skip:]
+}
+
+// settledBefore returns the leading entries that were written no later
+// than the given time.
+func settledBefore(entries []audit.AuditEntry, cutoff time.Time) []audit.AuditEntry {
+	for i, entry := range entries {
+		if entry.Timestamp.After(cutoff) {
+			return entries[:i]
+		}
+	}
+	return entries
+}
+
+// advance returns the cursor that follows the forwarding of entries
+// from the given cursor.
+func advance(entries []audit.AuditEntry, count, timestamp int64) (int64, int64) {
+	last := entries[len(entries)-1].Timestamp.UnixNano()
+	var sameTime int64
+	for i := len(entries) - 1; i >= 0 && entries[i].Timestamp.UnixNano() == last; i-- {
+		sameTime++
+	}
+	if last == timestamp {
+		sameTime += count
+	}
+	return sameTime, last
+}
@@ -0,0 +1,358 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditforwarder_test
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/audit"
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/auditforwarder"
+)
+
+type WorkerSuite struct {
+	testing.IsolationSuite
+	backend *fakeBackend
+	cursor  *fakeCursor
+	sink    *fakeSink
+	clock   *coretesting.Clock
+	config  auditforwarder.Config
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+var t0 = time.Date(2016, 10, 1, 12, 0, 0, 0, time.UTC)
+
+// now is the time at which the tests start, by which the entries
+// written in the first seconds after t0 have settled.
+var now = t0.Add(time.Hour)
+
+func entryAt(t time.Time, operation string) audit.AuditEntry {
+	return audit.AuditEntry{
+		Timestamp: t,
+		Operation: operation,
+	}
+}
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.backend = &fakeBackend{}
+	s.cursor = &fakeCursor{}
+	s.sink = &fakeSink{}
+	s.clock = coretesting.NewClock(now)
+	s.config = auditforwarder.Config{
+		Backend: s.backend,
+		Cursor:  s.cursor,
+		Origin:  logfwd.Origin{Hostname: "machine-0.controller"},
+		OpenSink: func() (auditforwarder.Sink, error) {
+			s.sink.opened++
+			return s.sink, s.sink.openErr
+		},
+		Clock:        s.clock,
+		PollInterval: time.Minute,
+		BatchSize:    2,
+		SettleDelay:  time.Minute,
+	}
+}
+
+func (s *WorkerSuite) startWorker(c *gc.C) worker.Worker {
+	w, err := auditforwarder.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) {
+		w.Kill()
+		w.Wait()
+	})
+	return w
+}
+
+// waitPoll waits until the worker has finished forwarding and is
+// waiting for the next poll.
+func (s *WorkerSuite) waitPoll(c *gc.C) {
+	select {
+	case <-s.clock.Alarms():
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for worker to poll")
+	}
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	for i, test := range []struct {
+		mutate func(*auditforwarder.Config)
+		err    string
+	}{{
+		func(cfg *auditforwarder.Config) { cfg.Backend = nil }, "nil Backend not valid",
+	}, {
+		func(cfg *auditforwarder.Config) { cfg.Cursor = nil }, "nil Cursor not valid",
+	}, {
+		func(cfg *auditforwarder.Config) { cfg.OpenSink = nil }, "nil OpenSink not valid",
+	}, {
+		func(cfg *auditforwarder.Config) { cfg.Clock = nil }, "nil Clock not valid",
+	}, {
+		func(cfg *auditforwarder.Config) { cfg.PollInterval = 0 }, "non-positive PollInterval not valid",
+	}, {
+		func(cfg *auditforwarder.Config) { cfg.BatchSize = 0 }, "non-positive BatchSize not valid",
+	}, {
+		func(cfg *auditforwarder.Config) { cfg.SettleDelay = 0 }, "non-positive SettleDelay not valid",
+	}} {
+		c.Logf("test %d", i)
+		config := s.config
+		test.mutate(&config)
+		_, err := auditforwarder.New(config)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *WorkerSuite) TestForwardsAllInBatches(c *gc.C) {
+	s.backend.entries = []audit.AuditEntry{
+		entryAt(t0, "a"),
+		entryAt(t0.Add(time.Second), "b"),
+		entryAt(t0.Add(2*time.Second), "c"),
+	}
+	s.startWorker(c)
+	s.waitPoll(c)
+
+	c.Check(s.sink.sentOperations(), jc.DeepEquals, [][]string{{"a", "b"}, {"c"}})
+	c.Check(s.sink.origin, jc.DeepEquals, s.config.Origin)
+	c.Check(s.cursor.get(), jc.DeepEquals, [2]int64{1, t0.Add(2 * time.Second).UnixNano()})
+	c.Check(s.sink.opened, gc.Equals, 1)
+}
+
+func (s *WorkerSuite) TestEntriesAtSameTime(c *gc.C) {
+	s.backend.entries = []audit.AuditEntry{
+		entryAt(t0, "a"),
+		entryAt(t0, "b"),
+		entryAt(t0, "c"),
+	}
+	s.startWorker(c)
+	s.waitPoll(c)
+
+	c.Check(s.sink.sentOperations(), jc.DeepEquals, [][]string{{"a", "b"}, {"c"}})
+	c.Check(s.cursor.get(), jc.DeepEquals, [2]int64{3, t0.UnixNano()})
+}
+
+func (s *WorkerSuite) TestResumesFromCursor(c *gc.C) {
+	s.backend.entries = []audit.AuditEntry{
+		entryAt(t0, "a"),
+		entryAt(t0, "b"),
+		entryAt(t0.Add(time.Second), "c"),
+	}
+	s.cursor.set(1, t0.UnixNano())
+	s.startWorker(c)
+	s.waitPoll(c)
+
+	c.Check(s.sink.sentOperations(), jc.DeepEquals, [][]string{{"b", "c"}})
+	c.Check(s.cursor.get(), jc.DeepEquals, [2]int64{1, t0.Add(time.Second).UnixNano()})
+}
+
+func (s *WorkerSuite) TestForwardsNewEntriesOnPoll(c *gc.C) {
+	s.backend.entries = []audit.AuditEntry{entryAt(t0, "a")}
+	s.startWorker(c)
+	s.waitPoll(c)
+
+	s.backend.add(entryAt(t0.Add(time.Second), "b"))
+	s.clock.Advance(time.Minute)
+	s.waitPoll(c)
+
+	c.Check(s.sink.sentOperations(), jc.DeepEquals, [][]string{{"a"}, {"b"}})
+}
+
+func (s *WorkerSuite) TestWaitsForEntriesToSettle(c *gc.C) {
+	s.backend.entries = []audit.AuditEntry{
+		entryAt(now.Add(-2*time.Minute), "a"),
+		entryAt(now.Add(-30*time.Second), "b"),
+		entryAt(now.Add(-10*time.Second), "c"),
+	}
+	s.startWorker(c)
+	s.waitPoll(c)
+
+	c.Check(s.sink.sentOperations(), jc.DeepEquals, [][]string{{"a"}})
+	c.Check(s.cursor.get(), jc.DeepEquals, [2]int64{1, now.Add(-2 * time.Minute).UnixNano()})
+
+	// An entry written before the unsettled ones, but only now
+	// visible, is not skipped.
+	s.backend.set(
+		entryAt(now.Add(-2*time.Minute), "a"),
+		entryAt(now.Add(-40*time.Second), "late"),
+		entryAt(now.Add(-30*time.Second), "b"),
+		entryAt(now.Add(-10*time.Second), "c"),
+	)
+	s.clock.Advance(time.Minute)
+	s.waitPoll(c)
+
+	c.Check(s.sink.sentOperations(), jc.DeepEquals, [][]string{{"a"}, {"late", "b"}, {"c"}})
+}
+
+func (s *WorkerSuite) TestSinkFailureRetried(c *gc.C) {
+	s.backend.entries = []audit.AuditEntry{entryAt(t0, "a")}
+	s.sink.sendErrs = []error{errors.New("connection refused")}
+	s.startWorker(c)
+	s.waitPoll(c)
+
+	c.Check(s.sink.sentOperations(), gc.HasLen, 0)
+	c.Check(s.sink.closed, gc.Equals, 1)
+	_, _, err := s.cursor.Get()
+	c.Check(errors.Cause(err), gc.Equals, state.ErrNeverForwarded)
+
+	s.clock.Advance(time.Minute)
+	s.waitPoll(c)
+
+	c.Check(s.sink.sentOperations(), jc.DeepEquals, [][]string{{"a"}})
+	c.Check(s.sink.opened, gc.Equals, 2)
+	c.Check(s.cursor.get(), jc.DeepEquals, [2]int64{1, t0.UnixNano()})
+}
+
+func (s *WorkerSuite) TestOpenFailureRetried(c *gc.C) {
+	s.backend.entries = []audit.AuditEntry{entryAt(t0, "a")}
+	s.sink.openErr = errors.New("no route to host")
+	s.startWorker(c)
+	s.waitPoll(c)
+	c.Check(s.sink.sentOperations(), gc.HasLen, 0)
+
+	s.sink.openErr = nil
+	s.clock.Advance(time.Minute)
+	s.waitPoll(c)
+	c.Check(s.sink.sentOperations(), jc.DeepEquals, [][]string{{"a"}})
+}
+
+func (s *WorkerSuite) TestBackendError(c *gc.C) {
+	s.backend.err = errors.New("boom")
+	w := s.startWorker(c)
+
+	err := w.Wait()
+	c.Assert(err, gc.ErrorMatches, "reading audit entries: boom")
+}
+
+func (s *WorkerSuite) TestClosesSinkOnStop(c *gc.C) {
+	s.backend.entries = []audit.AuditEntry{entryAt(t0, "a")}
+	w := s.startWorker(c)
+	s.waitPoll(c)
+
+	w.Kill()
+	c.Assert(w.Wait(), jc.ErrorIsNil)
+	c.Check(s.sink.closed, gc.Equals, 1)
+}
+
+type fakeBackend struct {
+	mu      sync.Mutex
+	entries []audit.AuditEntry
+	err     error
+}
+
+func (b *fakeBackend) add(entry audit.AuditEntry) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.entries = append(b.entries, entry)
+}
+
+func (b *fakeBackend) set(entries ...audit.AuditEntry) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.entries = entries
+}
+
+func (b *fakeBackend) AuditEntriesFrom(from time.Time, limit int) ([]audit.AuditEntry, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.err != nil {
+		return nil, b.err
+	}
+	var result []audit.AuditEntry
+	for _, entry := range b.entries {
+		if entry.Timestamp.Before(from) {
+			continue
+		}
+		if len(result) == limit {
+			break
+		}
+		result = append(result, entry)
+	}
+	return result, nil
+}
+
+type fakeCursor struct {
+	mu        sync.Mutex
+	valid     bool
+	count     int64
+	timestamp int64
+}
+
+func (f *fakeCursor) Get() (int64, int64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if !f.valid {
+		return 0, 0, errors.Trace(state.ErrNeverForwarded)
+	}
+	return f.count, f.timestamp, nil
+}
+
+func (f *fakeCursor) Set(count, timestamp int64) error {
+	f.set(count, timestamp)
+	return nil
+}
+
+func (f *fakeCursor) set(count, timestamp int64) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.valid, f.count, f.timestamp = true, count, timestamp
+}
+
+func (f *fakeCursor) get() [2]int64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return [2]int64{f.count, f.timestamp}
+}
+
+type fakeSink struct {
+	mu       sync.Mutex
+	origin   logfwd.Origin
+	sent     [][]audit.AuditEntry
+	sendErrs []error
+	openErr  error
+	opened   int
+	closed   int
+}
+
+func (f *fakeSink) SendAudit(origin logfwd.Origin, entries []audit.AuditEntry) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if len(f.sendErrs) > 0 {
+		err := f.sendErrs[0]
+		f.sendErrs = f.sendErrs[1:]
+		return err
+	}
+	f.origin = origin
+	f.sent = append(f.sent, entries)
+	return nil
+}
+
+func (f *fakeSink) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.closed++
+	return nil
+}
+
+func (f *fakeSink) sentOperations() [][]string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var result [][]string
+	for _, batch := range f.sent {
+		var operations []string
+		for _, entry := range batch {
+			operations = append(operations, entry.Operation)
+		}
+		result = append(result, operations)
+	}
+	return result
+}
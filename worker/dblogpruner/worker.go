@@ -13,16 +13,24 @@ import (
 	"github.com/juju/juju/worker"
 )
 
-// LogPruneParams specifies how logs should be pruned.
+// LogPruneParams specifies how logs should be pruned. MaxLogAge and
+// MaxCollectionMB apply to the logs of all models, except that a
+// model's max-logs-age config overrides MaxLogAge for its logs and
+// its max-logs-size config further limits the size of its logs.
 type LogPruneParams struct {
 	MaxLogAge       time.Duration
 	MaxCollectionMB int
 	PruneInterval   time.Duration
+
+	// MaxAuditAge is the age beyond which audit entries are removed.
+	// If it is zero then audit entries are not pruned.
+	MaxAuditAge time.Duration
 }
 
 const DefaultMaxLogAge = 3 * 24 * time.Hour // 3 days
 const DefaultMaxCollectionMB = 4 * 1024     // 4 GB
 const DefaultPruneInterval = 5 * time.Minute
+const DefaultMaxAuditAge = 30 * 24 * time.Hour // 30 days
 
 // NewLogPruneParams returns a LogPruneParams initialised with default
 // values.
@@ -31,6 +39,7 @@ func NewLogPruneParams() *LogPruneParams {
 		MaxLogAge:       DefaultMaxLogAge,
 		MaxCollectionMB: DefaultMaxCollectionMB,
 		PruneInterval:   DefaultPruneInterval,
+		MaxAuditAge:     DefaultMaxAuditAge,
 	}
 }
 
@@ -58,11 +67,48 @@ func (w *pruneWorker) loop(stopCh <-chan struct{}) error {
 			return tomb.ErrDying
 		case <-time.After(p.PruneInterval):
 			// TODO(fwereade): 2016-03-17 lp:1558657
-			minLogTime := time.Now().Add(-p.MaxLogAge)
-			err := state.PruneLogs(w.st, minLogTime, p.MaxCollectionMB)
+			now := time.Now()
+			minLogTime := now.Add(-p.MaxLogAge)
+			modelLimits, err := w.modelLogLimits(now)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			err = state.PruneLogs(w.st, minLogTime, p.MaxCollectionMB, modelLimits)
 			if err != nil {
 				return errors.Trace(err)
 			}
+			if p.MaxAuditAge > 0 {
+				minAuditTime := time.Now().Add(-p.MaxAuditAge)
+				if err := w.st.PruneAuditEntries(minAuditTime); err != nil {
+					return errors.Trace(err)
+				}
+			}
+		}
+	}
+}
+
+// modelLogLimits returns the log limits configured for each model,
+// keyed by model UUID.
+func (w *pruneWorker) modelLogLimits(now time.Time) (map[string]state.ModelLogLimits, error) {
+	models, err := w.st.AllModels()
+	if err != nil {
+		return nil, errors.Annotate(err, "getting models")
+	}
+	modelLimits := make(map[string]state.ModelLogLimits)
+	for _, model := range models {
+		cfg, err := model.Config()
+		if errors.IsNotFound(err) {
+			// The model has been removed.
+			continue
+		} else if err != nil {
+			return nil, errors.Annotatef(err, "getting config for model %q", model.Name())
+		}
+		var limits state.ModelLogLimits
+		if age := cfg.MaxLogsAge(); age > 0 {
+			limits.MinLogTime = now.Add(-age)
 		}
+		limits.MaxLogsMB = cfg.MaxLogsSizeMB()
+		modelLimits[model.UUID()] = limits
 	}
+	return modelLimits, nil
 }
@@ -14,6 +14,7 @@ import (
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
 
+	"github.com/juju/juju/audit"
 	"github.com/juju/juju/state"
 	statetesting "github.com/juju/juju/state/testing"
 	"github.com/juju/juju/testing"
@@ -107,6 +108,69 @@ func (s *suite) TestPrunesLogsBySize(c *gc.C) {
 	c.Fatal("pruning didn't happen as expected")
 }
 
+func (s *suite) TestPrunesOldLogsByModelConfig(c *gc.C) {
+	err := s.State.UpdateModelConfig(map[string]interface{}{
+		"max-logs-age": "1h",
+	}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	now := time.Now()
+	s.addLogs(c, now, "keep", 5)
+	s.addLogs(c, now.Add(-2*time.Hour), "prune", 5)
+	s.StartWorker(c, 999*time.Hour, int(1e9))
+
+	for attempt := testing.LongAttempt.Start(); attempt.Next(); {
+		pruneRemaining, err := s.logsColl.Find(bson.M{"x": "prune"}).Count()
+		c.Assert(err, jc.ErrorIsNil)
+		if pruneRemaining == 0 {
+			keepCount, err := s.logsColl.Find(bson.M{"x": "keep"}).Count()
+			c.Assert(err, jc.ErrorIsNil)
+			c.Assert(keepCount, gc.Equals, 5)
+			return
+		}
+	}
+	c.Fatal("pruning didn't happen as expected")
+}
+
+func (s *suite) TestPrunesOldAuditEntries(c *gc.C) {
+	now := time.Now().UTC()
+	put := s.State.PutAuditEntryFn()
+	for _, age := range []time.Duration{0, 48 * time.Hour} {
+		err := put(audit.AuditEntry{
+			JujuServerVersion: version.Current,
+			ModelUUID:         s.State.ModelUUID(),
+			Timestamp:         now.Add(-age),
+			RemoteAddress:     "10.0.0.1",
+			OriginType:        "API request",
+			OriginName:        "user-bob",
+			Operation:         "Client:v1 - FullStatus",
+		})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	params := &dblogpruner.LogPruneParams{
+		MaxLogAge:       999 * time.Hour,
+		MaxCollectionMB: int(1e9),
+		PruneInterval:   time.Millisecond,
+		MaxAuditAge:     24 * time.Hour,
+	}
+	s.pruner = dblogpruner.New(s.State, params)
+	s.AddCleanup(func(*gc.C) {
+		s.pruner.Kill()
+		c.Assert(s.pruner.Wait(), jc.ErrorIsNil)
+	})
+
+	for attempt := testing.LongAttempt.Start(); attempt.Next(); {
+		entries, err := s.State.AuditEntries(audit.Filter{})
+		c.Assert(err, jc.ErrorIsNil)
+		if len(entries) == 1 {
+			c.Assert(entries[0].Timestamp.Equal(now), jc.IsTrue)
+			return
+		}
+	}
+	c.Fatal("pruning didn't happen as expected")
+}
+
 func (s *suite) addLogs(c *gc.C, t0 time.Time, text string, count int) {
 	dbLogger := state.NewDbLogger(s.State, names.NewMachineTag("0"), version.Current)
 	defer dbLogger.Close()
@@ -41,6 +41,22 @@ type EngineConfig struct {
 	// a worker that was deliberately stopped because its dependencies
 	// changed. It must not be negative.
 	BounceDelay time.Duration
+
+	// Metrics, if not nil, is informed whenever a worker is started
+	// or stopped.
+	Metrics EngineMetrics
+}
+
+// EngineMetrics records the lifecycle events of an engine's workers.
+type EngineMetrics interface {
+
+	// WorkerStarted is called when the named manifold's worker has
+	// been started.
+	WorkerStarted(name string)
+
+	// WorkerStopped is called when the named manifold's worker has
+	// stopped, or failed to start, with the given error.
+	WorkerStopped(name string, err error)
 }
 
 // Validate returns an error if any field is invalid.
@@ -80,6 +96,7 @@ func NewEngine(config EngineConfig) (*Engine, error) {
 		started: make(chan startedTicket),
 		stopped: make(chan stoppedTicket),
 		report:  make(chan reportTicket),
+		bounce:  make(chan bounceTicket),
 	}
 	go func() {
 		defer engine.tomb.Done()
@@ -116,12 +133,13 @@ type Engine struct {
 	// current holds the active worker information for each installed manifold.
 	current map[string]workerInfo
 
-	// install, started, report and stopped each communicate requests and changes into
-	// the loop goroutine.
+	// install, started, report, stopped and bounce each communicate requests
+	// and changes into the loop goroutine.
 	install chan installTicket
 	started chan startedTicket
 	stopped chan stoppedTicket
 	report  chan reportTicket
+	bounce  chan bounceTicket
 }
 
 // loop serializes manifold install operations and worker start/stop notifications.
@@ -147,6 +165,9 @@ func (engine *Engine) loop() error {
 		case ticket := <-engine.install:
 			// This is safe so long as the Install method reads the result.
 			ticket.result <- engine.gotInstall(ticket.name, ticket.manifold)
+		case ticket := <-engine.bounce:
+			// This is safe so long as the Bounce method reads the result.
+			ticket.result <- engine.gotBounce(ticket.name)
 		case ticket := <-engine.started:
 			engine.gotStarted(ticket.name, ticket.worker, ticket.resourceLog)
 		case ticket := <-engine.stopped:
@@ -233,6 +254,7 @@ func (engine *Engine) manifoldsReport() map[string]interface{} {
 			KeyState:       info.state(),
 			KeyInputs:      engine.manifolds[name].Inputs,
 			KeyResourceLog: resourceLogReport(info.resourceLog),
+			KeyStartCount:  info.startCount,
 		}
 		if info.err != nil {
 			report[KeyError] = info.err.Error()
@@ -278,6 +300,37 @@ func (engine *Engine) gotInstall(name string, manifold Manifold) error {
 	return nil
 }
 
+// Bounce stops the named manifold's worker, if it is running, and starts
+// it again; or starts it, if it is not running. It is part of the Bouncer
+// interface.
+func (engine *Engine) Bounce(name string) error {
+	result := make(chan error)
+	select {
+	case <-engine.tomb.Dying():
+		return errors.New("engine is shutting down")
+	case engine.bounce <- bounceTicket{name, result}:
+		// This is safe so long as the loop sends a result.
+		return <-result
+	}
+}
+
+// gotBounce handles the params originally supplied to Bounce. It must only be
+// called from the loop goroutine.
+func (engine *Engine) gotBounce(name string) error {
+	if _, found := engine.manifolds[name]; !found {
+		return errors.NotFoundf("%q manifold", name)
+	}
+	logger.Infof("bouncing %q manifold worker", name)
+	if engine.current[name].stopped() {
+		engine.requestStart(name, 0)
+	} else {
+		// The worker will be restarted when it stops, because
+		// we asked it to.
+		engine.requestStop(name)
+	}
+	return nil
+}
+
 // uninstall removes the named manifold from the engine's records.
 func (engine *Engine) uninstall(name string) {
 	// Note that we *don't* want to remove dependents[name] -- all those other
@@ -476,9 +529,13 @@ func (engine *Engine) gotStarted(name string, worker worker.Worker, resourceLog
 	default:
 		// It's fine to use this worker; update info and copy back.
 		logger.Debugf("%q manifold worker started", name)
+		if engine.config.Metrics != nil {
+			engine.config.Metrics.WorkerStarted(name)
+		}
 		engine.current[name] = workerInfo{
 			worker:      worker,
 			resourceLog: resourceLog,
+			startCount:  info.startCount + 1,
 		}
 
 		// Any manifold that declares this one as an input needs to be restarted.
@@ -493,6 +550,9 @@ func (engine *Engine) gotStopped(name string, err error, resourceLog []resourceA
 	if filter := engine.manifolds[name].Filter; filter != nil {
 		err = filter(err)
 	}
+	if engine.config.Metrics != nil {
+		engine.config.Metrics.WorkerStopped(name, err)
+	}
 
 	// Copy current info and check for reasons to stop the engine.
 	info := engine.current[name]
@@ -507,6 +567,7 @@ func (engine *Engine) gotStopped(name string, err error, resourceLog []resourceA
 	engine.current[name] = workerInfo{
 		err:         err,
 		resourceLog: resourceLog,
+		startCount:  info.startCount,
 	}
 	if engine.isDying() {
 		logger.Tracef("permanently stopped %q manifold worker (shutting down)", name)
@@ -616,6 +677,7 @@ type workerInfo struct {
 	worker      worker.Worker
 	err         error
 	resourceLog []resourceAccess
+	startCount  int
 }
 
 // stopped returns true unless the worker is either assigned or starting.
@@ -666,6 +728,13 @@ type stoppedTicket struct {
 	resourceLog []resourceAccess
 }
 
+// bounceTicket is used by engine to induce a restart of the worker for a named
+// manifold and pass on any errors encountered in the process.
+type bounceTicket struct {
+	name   string
+	result chan<- error
+}
+
 // reportTicket is used by the engine to notify the loop that a status report
 // should be generated.
 type reportTicket struct {
@@ -710,6 +710,91 @@ func (s *EngineSuite) TestConfigValidate(c *gc.C) {
 	}
 }
 
+func (s *EngineSuite) TestMetrics(c *gc.C) {
+	metrics := newMetricsRecorder()
+	s.fix.metrics = metrics
+	s.fix.run(c, func(engine *dependency.Engine) {
+
+		// Install a worker; check its start is recorded.
+		mh1 := newManifoldHarness()
+		err := engine.Install("some-task", mh1.Manifold())
+		c.Assert(err, jc.ErrorIsNil)
+		mh1.AssertOneStart(c)
+		metrics.AssertEvent(c, "started some-task")
+
+		// Make it fail; check the stop and the restart are recorded.
+		mh1.InjectError(c, errors.New("arg"))
+		mh1.AssertOneStart(c)
+		metrics.AssertEvent(c, "stopped some-task: arg")
+		metrics.AssertEvent(c, "started some-task")
+	})
+}
+
+func (s *EngineSuite) TestBounceRunningWorker(c *gc.C) {
+	s.fix.run(c, func(engine *dependency.Engine) {
+
+		// Install a worker with a dependent; check both start.
+		mh1 := newManifoldHarness()
+		err := engine.Install("some-task", mh1.Manifold())
+		c.Assert(err, jc.ErrorIsNil)
+		mh1.AssertOneStart(c)
+		mh2 := newManifoldHarness("some-task")
+		err = engine.Install("other-task", mh2.Manifold())
+		c.Assert(err, jc.ErrorIsNil)
+		mh2.AssertOneStart(c)
+
+		// Bounce the first; check both restart.
+		err = engine.Bounce("some-task")
+		c.Assert(err, jc.ErrorIsNil)
+		mh1.AssertOneStart(c)
+		mh2.AssertStart(c)
+	})
+}
+
+func (s *EngineSuite) TestBounceStoppedWorker(c *gc.C) {
+	s.fix.run(c, func(engine *dependency.Engine) {
+
+		// Install a worker, and stop it with ErrMissing so that the
+		// engine won't restart it.
+		mh1 := newManifoldHarness()
+		err := engine.Install("some-task", mh1.Manifold())
+		c.Assert(err, jc.ErrorIsNil)
+		mh1.AssertOneStart(c)
+		mh1.InjectError(c, dependency.ErrMissing)
+		mh1.AssertNoStart(c)
+
+		// Bounce it; check it starts again.
+		err = engine.Bounce("some-task")
+		c.Assert(err, jc.ErrorIsNil)
+		mh1.AssertOneStart(c)
+	})
+}
+
+func (s *EngineSuite) TestBounceUnknownManifold(c *gc.C) {
+	s.fix.run(c, func(engine *dependency.Engine) {
+		err := engine.Bounce("some-task")
+		c.Assert(err, gc.ErrorMatches, `"some-task" manifold not found`)
+		c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	})
+}
+
+func (s *EngineSuite) TestReportStartCount(c *gc.C) {
+	s.fix.run(c, func(engine *dependency.Engine) {
+		mh1 := newManifoldHarness()
+		err := engine.Install("some-task", mh1.Manifold())
+		c.Assert(err, jc.ErrorIsNil)
+		mh1.AssertOneStart(c)
+
+		err = engine.Bounce("some-task")
+		c.Assert(err, jc.ErrorIsNil)
+		mh1.AssertOneStart(c)
+
+		manifolds := engine.Report()[dependency.KeyManifolds].(map[string]interface{})
+		report := manifolds["some-task"].(map[string]interface{})
+		c.Check(report[dependency.KeyStartCount], gc.Equals, 2)
+	})
+}
+
 func (s *EngineSuite) TestValidateEmptyManifolds(c *gc.C) {
 	err := dependency.Validate(dependency.Manifolds{})
 	c.Check(err, jc.ErrorIsNil)
@@ -57,6 +57,10 @@ const (
 	// error encountered.
 	KeyResourceLog = "resource-log"
 
+	// KeyStartCount holds the number of times the manifold's worker has
+	// been started.
+	KeyStartCount = "start-count"
+
 	// KeyName holds the name of some resource.
 	KeyName = "name"
 
@@ -94,6 +94,7 @@ func (s *ReportSuite) TestReportStopping(c *gc.C) {
 					"state":        "stopping",
 					"inputs":       ([]string)(nil),
 					"resource-log": []map[string]interface{}{},
+					"start-count":  1,
 					"report": map[string]interface{}{
 						"key1": "hello there",
 					},
@@ -123,6 +124,7 @@ func (s *ReportSuite) TestReportInputs(c *gc.C) {
 					"state":        "started",
 					"inputs":       ([]string)(nil),
 					"resource-log": []map[string]interface{}{},
+					"start-count":  1,
 					"report": map[string]interface{}{
 						"key1": "hello there",
 					},
@@ -134,6 +136,7 @@ func (s *ReportSuite) TestReportInputs(c *gc.C) {
 						"name": "task",
 						"type": "<nil>",
 					}},
+					"start-count": 1,
 					"report": map[string]interface{}{
 						"key1": "hello there",
 					},
@@ -165,6 +168,7 @@ func (s *ReportSuite) TestReportError(c *gc.C) {
 						"type":  "<nil>",
 						"error": `"missing" not running: dependency not available`,
 					}},
+					"start-count": 0,
 				},
 			},
 		})
@@ -56,7 +56,7 @@ func (s *SelfSuite) TestOutputBadOutput(c *gc.C) {
 		manifold := dependency.SelfManifold(engine)
 		var unknown interface{}
 		err := manifold.Output(engine, &unknown)
-		c.Check(err, gc.ErrorMatches, "out should be a \\*Installer or a \\*Reporter; is .*")
+		c.Check(err, gc.ErrorMatches, "out should be a \\*Installer, a \\*Reporter or a \\*Bouncer; is .*")
 		c.Check(unknown, gc.IsNil)
 	})
 }
@@ -81,6 +81,16 @@ func (s *SelfSuite) TestOutputInstaller(c *gc.C) {
 	})
 }
 
+func (s *SelfSuite) TestOutputBouncer(c *gc.C) {
+	s.fix.run(c, func(engine *dependency.Engine) {
+		manifold := dependency.SelfManifold(engine)
+		var bouncer dependency.Bouncer
+		err := manifold.Output(engine, &bouncer)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(bouncer, gc.Equals, engine)
+	})
+}
+
 func (s *SelfSuite) TestActuallyWorks(c *gc.C) {
 	s.fix.run(c, func(engine *dependency.Engine) {
 
@@ -14,6 +14,11 @@ type Installer interface {
 	Install(name string, manifold Manifold) error
 }
 
+// Bouncer exposes an Engine's Bounce method.
+type Bouncer interface {
+	Bounce(name string) error
+}
+
 // Install is a convenience function for installing multiple manifolds into an
 // Installer at once. It returns the first error it encounters (and installs no
 // more manifolds).
@@ -94,8 +99,10 @@ func SelfManifold(engine *Engine) Manifold {
 				*outPtr = engine
 			case *Reporter:
 				*outPtr = engine
+			case *Bouncer:
+				*outPtr = engine
 			default:
-				return errors.Errorf("out should be a *Installer or a *Reporter; is %#v", out)
+				return errors.Errorf("out should be a *Installer, a *Reporter or a *Bouncer; is %#v", out)
 			}
 			return nil
 		},
@@ -4,6 +4,7 @@
 package dependency_test
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/juju/errors"
@@ -21,6 +22,7 @@ type engineFixture struct {
 	isFatal    dependency.IsFatalFunc
 	worstError dependency.WorstErrorFunc
 	filter     dependency.FilterFunc
+	metrics    dependency.EngineMetrics
 	dirty      bool
 }
 
@@ -42,7 +44,8 @@ func (fix *engineFixture) run(c *gc.C, test func(*dependency.Engine)) {
 	config := dependency.EngineConfig{
 		IsFatal:     fix.isFatalFunc(),
 		WorstError:  fix.worstErrorFunc(),
-		Filter:      fix.filter, // can be nil anyway
+		Filter:      fix.filter,  // can be nil anyway
+		Metrics:     fix.metrics, // can be nil anyway
 		ErrorDelay:  coretesting.ShortWait / 2,
 		BounceDelay: coretesting.ShortWait / 10,
 	}
@@ -244,3 +247,30 @@ func alwaysFatal(_ error) bool {
 func firstError(err, _ error) error {
 	return err
 }
+
+// metricsRecorder is a dependency.EngineMetrics that reports the
+// worker lifecycle events it receives on a channel.
+type metricsRecorder struct {
+	events chan string
+}
+
+func newMetricsRecorder() *metricsRecorder {
+	return &metricsRecorder{events: make(chan string, 1000)}
+}
+
+func (r *metricsRecorder) WorkerStarted(name string) {
+	r.events <- "started " + name
+}
+
+func (r *metricsRecorder) WorkerStopped(name string, err error) {
+	r.events <- fmt.Sprintf("stopped %s: %v", name, err)
+}
+
+func (r *metricsRecorder) AssertEvent(c *gc.C, expect string) {
+	select {
+	case event := <-r.events:
+		c.Assert(event, gc.Equals, expect)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("never recorded %q", expect)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/juju/errors"
+	"github.com/juju/utils/set"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/firewaller"
@@ -39,7 +40,7 @@ type Firewaller struct {
 	applicationids  map[names.ApplicationTag]*serviceData
 	exposedChange   chan *exposedChange
 	globalMode      bool
-	globalPortRef   map[network.PortRange]int
+	globalRuleRef   map[string]int
 	machinePorts    map[names.MachineTag]machineRanges
 }
 
@@ -89,7 +90,7 @@ func (fw *Firewaller) setUp() error {
 	case config.FwInstance:
 	case config.FwGlobal:
 		fw.globalMode = true
-		fw.globalPortRef = make(map[network.PortRange]int)
+		fw.globalRuleRef = make(map[string]int)
 	case config.FwNone:
 		logger.Infof("stopping firewaller (not required)")
 		fw.Kill()
@@ -182,6 +183,7 @@ func (fw *Firewaller) loop() error {
 			}
 		case change := <-fw.exposedChange:
 			change.serviced.exposed = change.exposed
+			change.serviced.exposedEndpoints = change.exposedEndpoints
 			unitds := []*unitData{}
 			for _, unitd := range change.serviced.unitds {
 				unitds = append(unitds, unitd)
@@ -200,8 +202,8 @@ func (fw *Firewaller) startMachine(tag names.MachineTag) error {
 		fw:           fw,
 		tag:          tag,
 		unitds:       make(map[names.UnitTag]*unitData),
-		openedPorts:  make([]network.PortRange, 0),
-		definedPorts: make(map[network.PortRange]names.UnitTag),
+		openedRules:  make([]network.IngressRule, 0),
+		definedPorts: make(map[network.PortRange]firewaller.UnitEndpoint),
 	}
 	m, err := machined.machine()
 	if params.IsCodeNotFound(err) {
@@ -309,20 +311,21 @@ func (fw *Firewaller) startUnit(unit *firewaller.Unit, machineTag names.MachineT
 // startService creates a new data value for tracking details of the
 // service and starts watching the service for exposure changes.
 func (fw *Firewaller) startService(service *firewaller.Application) error {
-	exposed, err := service.IsExposed()
+	exposed, endpoints, err := service.ExposeInfo()
 	if err != nil {
 		return err
 	}
 	serviced := &serviceData{
-		fw:          fw,
-		application: service,
-		exposed:     exposed,
-		unitds:      make(map[names.UnitTag]*unitData),
+		fw:               fw,
+		application:      service,
+		exposed:          exposed,
+		exposedEndpoints: endpoints,
+		unitds:           make(map[names.UnitTag]*unitData),
 	}
 	err = catacomb.Invoke(catacomb.Plan{
 		Site: &serviced.catacomb,
 		Work: func() error {
-			return serviced.watchLoop(exposed)
+			return serviced.watchLoop(exposed, endpoints)
 		},
 	})
 	if err != nil {
@@ -336,53 +339,60 @@ func (fw *Firewaller) startService(service *firewaller.Application) error {
 }
 
 // reconcileGlobal compares the initially started watcher for machines,
-// units and services with the opened and closed ports globally and
-// opens and closes the appropriate ports for the whole environment.
+// units and services with the opened and closed ingress rules globally
+// and opens and closes the appropriate rules for the whole environment.
 func (fw *Firewaller) reconcileGlobal() error {
-	initialPortRanges, err := fw.environ.Ports()
+	initialRules, err := fw.globalIngressRules()
 	if err != nil {
 		return err
 	}
-	collector := make(map[network.PortRange]bool)
+	collector := make(map[string]network.IngressRule)
 	for _, machined := range fw.machineds {
-		for portRange, unitTag := range machined.definedPorts {
-			unitd, known := machined.unitds[unitTag]
+		for portRange, unitEndpoint := range machined.definedPorts {
+			unitd, known := machined.unitds[unitEndpoint.Unit]
 			if !known {
-				delete(machined.unitds, unitTag)
+				delete(machined.unitds, unitEndpoint.Unit)
 				continue
 			}
 			if unitd.serviced.exposed {
-				collector[portRange] = true
+				rules, err := unitd.serviced.ingressRules(portRange, unitEndpoint.Endpoint)
+				if err != nil {
+					return errors.Trace(err)
+				}
+				for _, rule := range rules {
+					collector[rule.String()] = rule
+				}
 			}
 		}
 	}
-	wantedPorts := []network.PortRange{}
-	for port := range collector {
-		wantedPorts = append(wantedPorts, port)
+	wantedRules := []network.IngressRule{}
+	for _, rule := range collector {
+		wantedRules = append(wantedRules, rule)
 	}
-	// Check which ports to open or to close.
-	toOpen := diffRanges(wantedPorts, initialPortRanges)
-	toClose := diffRanges(initialPortRanges, wantedPorts)
-	if len(toOpen) > 0 {
-		logger.Infof("opening global ports %v", toOpen)
-		if err := fw.environ.OpenPorts(toOpen); err != nil {
+	// Check which rules to open or to close. Rules are closed first,
+	// so that closing a rule does not undo the opening of another
+	// which shares a port range and source CIDR with it.
+	toClose, toOpen := reconcileRules(initialRules, wantedRules)
+	if len(toClose) > 0 {
+		network.SortIngressRules(toClose)
+		logger.Infof("closing global ingress rules %v", toClose)
+		if err := fw.closeGlobalRules(toClose); err != nil {
 			return err
 		}
-		network.SortPortRanges(toOpen)
 	}
-	if len(toClose) > 0 {
-		logger.Infof("closing global ports %v", toClose)
-		if err := fw.environ.ClosePorts(toClose); err != nil {
+	if len(toOpen) > 0 {
+		network.SortIngressRules(toOpen)
+		logger.Infof("opening global ingress rules %v", toOpen)
+		if err := fw.openGlobalRules(toOpen); err != nil {
 			return err
 		}
-		network.SortPortRanges(toClose)
 	}
 	return nil
 }
 
 // reconcileInstances compares the initially started watcher for machines,
-// units and services with the opened and closed ports of the instances and
-// opens and closes the appropriate ports for each instance.
+// units and services with the opened and closed ingress rules of the
+// instances and opens and closes the appropriate rules for each instance.
 func (fw *Firewaller) reconcileInstances() error {
 	for _, machined := range fw.machineds {
 		m, err := machined.machine()
@@ -411,31 +421,32 @@ func (fw *Firewaller) reconcileInstances() error {
 			return err
 		}
 		machineId := machined.tag.Id()
-		initialPortRanges, err := instances[0].Ports(machineId)
+		initialRules, err := instanceIngressRules(instances[0], machineId)
 		if err != nil {
 			return err
 		}
 
-		// Check which ports to open or to close.
-		toOpen := diffRanges(machined.openedPorts, initialPortRanges)
-		toClose := diffRanges(initialPortRanges, machined.openedPorts)
-		if len(toOpen) > 0 {
-			logger.Infof("opening instance port ranges %v for %q",
-				toOpen, machined.tag)
-			if err := instances[0].OpenPorts(machineId, toOpen); err != nil {
+		// Check which rules to open or to close. Rules are closed
+		// first, so that closing a rule does not undo the opening of
+		// another which shares a port range and source CIDR with it.
+		toClose, toOpen := reconcileRules(initialRules, machined.openedRules)
+		if len(toClose) > 0 {
+			network.SortIngressRules(toClose)
+			logger.Infof("closing instance ingress rules %v for %q",
+				toClose, machined.tag)
+			if err := closeInstanceRules(instances[0], machineId, toClose); err != nil {
 				// TODO(mue) Add local retry logic.
 				return err
 			}
-			network.SortPortRanges(toOpen)
 		}
-		if len(toClose) > 0 {
-			logger.Infof("closing instance port ranges %v for %q",
-				toClose, machined.tag)
-			if err := instances[0].ClosePorts(machineId, toClose); err != nil {
+		if len(toOpen) > 0 {
+			network.SortIngressRules(toOpen)
+			logger.Infof("opening instance ingress rules %v for %q",
+				toOpen, machined.tag)
+			if err := openInstanceRules(instances[0], machineId, toOpen); err != nil {
 				// TODO(mue) Add local retry logic.
 				return err
 			}
-			network.SortPortRanges(toClose)
 		}
 	}
 	return nil
@@ -499,13 +510,14 @@ func (fw *Firewaller) openedPortsChanged(machineTag names.MachineTag, subnetTag
 		return err
 	}
 
-	ports, err := m.OpenedPorts(subnetTag)
+	ports, err := m.OpenedPortRanges(subnetTag)
 	if err != nil {
 		return err
 	}
 
-	newPortRanges := make(map[network.PortRange]names.UnitTag)
-	for portRange, unitTag := range ports {
+	newPortRanges := make(map[network.PortRange]firewaller.UnitEndpoint)
+	for portRange, unitEndpoint := range ports {
+		unitTag := unitEndpoint.Unit
 		unitd, ok := machined.unitds[unitTag]
 		if !ok {
 			// It is common to receive port change notification before
@@ -514,7 +526,10 @@ func (fw *Firewaller) openedPortsChanged(machineTag names.MachineTag, subnetTag
 			logger.Errorf("failed to lookup %q, skipping port change", unitTag)
 			return nil
 		}
-		newPortRanges[portRange] = unitd.tag
+		newPortRanges[portRange] = firewaller.UnitEndpoint{
+			Unit:     unitd.tag,
+			Endpoint: unitEndpoint.Endpoint,
+		}
 	}
 
 	if !portMapsEqual(machined.definedPorts, newPortRanges) {
@@ -524,7 +539,7 @@ func (fw *Firewaller) openedPortsChanged(machineTag names.MachineTag, subnetTag
 	return nil
 }
 
-func portMapsEqual(a, b map[network.PortRange]names.UnitTag) bool {
+func portMapsEqual(a, b map[network.PortRange]firewaller.UnitEndpoint) bool {
 	if len(a) != len(b) {
 		return false
 	}
@@ -554,70 +569,78 @@ func (fw *Firewaller) flushUnits(unitds []*unitData) error {
 	return nil
 }
 
-// flushMachine opens and closes ports for the passed machine.
+// flushMachine opens and closes ingress rules for the passed machine.
 func (fw *Firewaller) flushMachine(machined *machineData) error {
-	// Gather ports to open and close.
-	want := []network.PortRange{}
-	for portRange, unitTag := range machined.definedPorts {
-		unitd, known := machined.unitds[unitTag]
+	// Gather rules to open and close.
+	want := []network.IngressRule{}
+	for portRange, unitEndpoint := range machined.definedPorts {
+		unitd, known := machined.unitds[unitEndpoint.Unit]
 		if !known {
-			delete(machined.unitds, unitTag)
+			delete(machined.unitds, unitEndpoint.Unit)
 			continue
 		}
 		if unitd.serviced.exposed {
-			want = append(want, portRange)
+			rules, err := unitd.serviced.ingressRules(portRange, unitEndpoint.Endpoint)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			want = append(want, rules...)
 		}
 	}
-	toOpen := diffRanges(want, machined.openedPorts)
-	toClose := diffRanges(machined.openedPorts, want)
-	machined.openedPorts = want
+	toOpen := diffRules(want, machined.openedRules)
+	toClose := diffRules(machined.openedRules, want)
+	machined.openedRules = want
 	if fw.globalMode {
-		return fw.flushGlobalPorts(toOpen, toClose)
+		return fw.flushGlobalRules(toOpen, toClose)
 	}
-	return fw.flushInstancePorts(machined, toOpen, toClose)
+	return fw.flushInstanceRules(machined, toOpen, toClose)
 }
 
-// flushGlobalPorts opens and closes global ports in the environment.
-// It keeps a reference count for ports so that only 0-to-1 and 1-to-0 events
+// flushGlobalRules opens and closes global ingress rules in the
+// environment. The rules each have a single source CIDR, and it keeps a
+// reference count for them so that only 0-to-1 and 1-to-0 events
 // modify the environment.
-func (fw *Firewaller) flushGlobalPorts(rawOpen, rawClose []network.PortRange) error {
-	// Filter which ports are really to open or close.
-	var toOpen, toClose []network.PortRange
-	for _, portRange := range rawOpen {
-		if fw.globalPortRef[portRange] == 0 {
-			toOpen = append(toOpen, portRange)
+func (fw *Firewaller) flushGlobalRules(rawOpen, rawClose []network.IngressRule) error {
+	// Filter which rules are really to open or close.
+	var toOpen, toClose []network.IngressRule
+	for _, rule := range rawOpen {
+		key := rule.String()
+		if fw.globalRuleRef[key] == 0 {
+			toOpen = append(toOpen, rule)
 		}
-		fw.globalPortRef[portRange]++
-	}
-	for _, portRange := range rawClose {
-		fw.globalPortRef[portRange]--
-		if fw.globalPortRef[portRange] == 0 {
-			toClose = append(toClose, portRange)
-			delete(fw.globalPortRef, portRange)
+		fw.globalRuleRef[key]++
+	}
+	for _, rule := range rawClose {
+		key := rule.String()
+		fw.globalRuleRef[key]--
+		if fw.globalRuleRef[key] == 0 {
+			toClose = append(toClose, rule)
+			delete(fw.globalRuleRef, key)
 		}
 	}
-	// Open and close the ports.
-	if len(toOpen) > 0 {
-		if err := fw.environ.OpenPorts(toOpen); err != nil {
+	// Close and open the rules.
+	if len(toClose) > 0 {
+		if err := fw.closeGlobalRules(toClose); err != nil {
 			// TODO(mue) Add local retry logic.
 			return err
 		}
-		network.SortPortRanges(toOpen)
-		logger.Infof("opened port ranges %v in environment", toOpen)
+		network.SortIngressRules(toClose)
+		logger.Infof("closed ingress rules %v in environment", toClose)
 	}
-	if len(toClose) > 0 {
-		if err := fw.environ.ClosePorts(toClose); err != nil {
+	if len(toOpen) > 0 {
+		if err := fw.openGlobalRules(toOpen); err != nil {
 			// TODO(mue) Add local retry logic.
 			return err
 		}
-		network.SortPortRanges(toClose)
-		logger.Infof("closed port ranges %v in environment", toClose)
+		network.SortIngressRules(toOpen)
+		logger.Infof("opened ingress rules %v in environment", toOpen)
 	}
 	return nil
 }
 
-// flushInstancePorts opens and closes ports global on the machine.
-func (fw *Firewaller) flushInstancePorts(machined *machineData, toOpen, toClose []network.PortRange) error {
+// flushInstanceRules opens and closes ingress rules on the machine's
+// instance.
+func (fw *Firewaller) flushInstanceRules(machined *machineData, toOpen, toClose []network.IngressRule) error {
 	// If there's nothing to do, do nothing.
 	// This is important because when a machine is first created,
 	// it will have no instance id but also no open ports -
@@ -641,26 +664,134 @@ func (fw *Firewaller) flushInstancePorts(machined *machineData, toOpen, toClose
 	if err != nil {
 		return err
 	}
-	// Open and close the ports.
-	if len(toOpen) > 0 {
-		if err := instances[0].OpenPorts(machineId, toOpen); err != nil {
+	// Close and open the rules.
+	if len(toClose) > 0 {
+		if err := closeInstanceRules(instances[0], machineId, toClose); err != nil {
 			// TODO(mue) Add local retry logic.
 			return err
 		}
-		network.SortPortRanges(toOpen)
-		logger.Infof("opened port ranges %v on %q", toOpen, machined.tag)
+		network.SortIngressRules(toClose)
+		logger.Infof("closed ingress rules %v on %q", toClose, machined.tag)
 	}
-	if len(toClose) > 0 {
-		if err := instances[0].ClosePorts(machineId, toClose); err != nil {
+	if len(toOpen) > 0 {
+		if err := openInstanceRules(instances[0], machineId, toOpen); err != nil {
 			// TODO(mue) Add local retry logic.
 			return err
 		}
-		network.SortPortRanges(toClose)
-		logger.Infof("closed port ranges %v on %q", toClose, machined.tag)
+		network.SortIngressRules(toOpen)
+		logger.Infof("opened ingress rules %v on %q", toOpen, machined.tag)
+	}
+	return nil
+}
+
+// globalIngressRules returns the ingress rules opened for the whole
+// environment. If the environment cannot restrict ingress to source
+// CIDRs, its open ports are reported as open to all.
+func (fw *Firewaller) globalIngressRules() ([]network.IngressRule, error) {
+	if rf, ok := fw.environ.(environs.IngressRuleFirewaller); ok {
+		rules, err := rf.IngressRules()
+		if !errors.IsNotSupported(err) {
+			return rules, err
+		}
+	}
+	ports, err := fw.environ.Ports()
+	if err != nil {
+		return nil, err
+	}
+	return network.IngressRulesFromPortRanges(ports), nil
+}
+
+// openGlobalRules opens the given ingress rules for the whole
+// environment.
+func (fw *Firewaller) openGlobalRules(rules []network.IngressRule) error {
+	if rf, ok := fw.environ.(environs.IngressRuleFirewaller); ok {
+		err := rf.OpenIngressRules(rules)
+		if !errors.IsNotSupported(err) {
+			return err
+		}
+	}
+	if ports := openToAllPorts(rules); len(ports) > 0 {
+		return fw.environ.OpenPorts(ports)
+	}
+	return nil
+}
+
+// closeGlobalRules closes the given ingress rules for the whole
+// environment.
+func (fw *Firewaller) closeGlobalRules(rules []network.IngressRule) error {
+	if rf, ok := fw.environ.(environs.IngressRuleFirewaller); ok {
+		err := rf.CloseIngressRules(rules)
+		if !errors.IsNotSupported(err) {
+			return err
+		}
+	}
+	if ports := openToAllPorts(rules); len(ports) > 0 {
+		return fw.environ.ClosePorts(ports)
+	}
+	return nil
+}
+
+// instanceIngressRules returns the ingress rules opened on the given
+// instance. If the instance cannot restrict ingress to source CIDRs,
+// its open ports are reported as open to all.
+func instanceIngressRules(inst instance.Instance, machineId string) ([]network.IngressRule, error) {
+	if rf, ok := inst.(instance.IngressRuleFirewaller); ok {
+		rules, err := rf.IngressRules(machineId)
+		if !errors.IsNotSupported(err) {
+			return rules, err
+		}
+	}
+	ports, err := inst.Ports(machineId)
+	if err != nil {
+		return nil, err
+	}
+	return network.IngressRulesFromPortRanges(ports), nil
+}
+
+// openInstanceRules opens the given ingress rules on the instance.
+func openInstanceRules(inst instance.Instance, machineId string, rules []network.IngressRule) error {
+	if rf, ok := inst.(instance.IngressRuleFirewaller); ok {
+		err := rf.OpenIngressRules(machineId, rules)
+		if !errors.IsNotSupported(err) {
+			return err
+		}
+	}
+	if ports := openToAllPorts(rules); len(ports) > 0 {
+		return inst.OpenPorts(machineId, ports)
+	}
+	return nil
+}
+
+// closeInstanceRules closes the given ingress rules on the instance.
+func closeInstanceRules(inst instance.Instance, machineId string, rules []network.IngressRule) error {
+	if rf, ok := inst.(instance.IngressRuleFirewaller); ok {
+		err := rf.CloseIngressRules(machineId, rules)
+		if !errors.IsNotSupported(err) {
+			return err
+		}
+	}
+	if ports := openToAllPorts(rules); len(ports) > 0 {
+		return inst.ClosePorts(machineId, ports)
 	}
 	return nil
 }
 
+// openToAllPorts returns the port ranges of those rules which allow
+// ingress from anywhere. It is used with providers that cannot
+// restrict ingress to source CIDRs; rather than widening access, the
+// other rules are left unapplied.
+func openToAllPorts(rules []network.IngressRule) []network.PortRange {
+	var ports []network.PortRange
+	for _, rule := range rules {
+		if !rule.OpenToAll() {
+			logger.Errorf("cannot apply ingress rule %v: provider does not support source CIDRs", rule)
+			continue
+		}
+		ports = append(ports, rule.PortRange)
+	}
+	return ports
+}
+
 // machineLifeChanged starts watching new machines when the firewaller
 // is starting, or when new machines come to life, and stops watching
 // machines that are dying.
@@ -751,13 +882,16 @@ type unitsChange struct {
 
 // machineData holds machine details and watches units added or removed.
 type machineData struct {
-	catacomb    catacomb.Catacomb
-	fw          *Firewaller
-	tag         names.MachineTag
-	unitds      map[names.UnitTag]*unitData
-	openedPorts []network.PortRange
-	// ports defined by units on this machine
-	definedPorts map[network.PortRange]names.UnitTag
+	catacomb catacomb.Catacomb
+	fw       *Firewaller
+	tag      names.MachineTag
+	unitds   map[names.UnitTag]*unitData
+	// openedRules holds the ingress rules opened for the machine,
+	// each with a single source CIDR.
+	openedRules []network.IngressRule
+	// ports defined by units on this machine, with the endpoints
+	// they were opened for
+	definedPorts map[network.PortRange]firewaller.UnitEndpoint
 }
 
 func (md *machineData) machine() (*firewaller.Machine, error) {
@@ -805,10 +939,12 @@ type unitData struct {
 	machined *machineData
 }
 
-// exposedChange contains the changed exposed flag for one specific service.
+// exposedChange contains the changed exposed flag and expose settings
+// for one specific service.
 type exposedChange struct {
-	serviced *serviceData
-	exposed  bool
+	serviced         *serviceData
+	exposed          bool
+	exposedEndpoints map[string]params.ExposedEndpoint
 }
 
 // serviceData holds service details and watches exposure changes.
@@ -817,11 +953,30 @@ type serviceData struct {
 	fw          *Firewaller
 	application *firewaller.Application
 	exposed     bool
-	unitds      map[names.UnitTag]*unitData
+	// exposedEndpoints holds the service's expose settings, keyed by
+	// endpoint name; "" holds the settings for all endpoints.
+	exposedEndpoints map[string]params.ExposedEndpoint
+	unitds           map[names.UnitTag]*unitData
+}
+
+// ingressRules returns the ingress rules for the given port range
+// opened by one of the service's units for the given endpoint, one
+// for each source CIDR.
+func (sd *serviceData) ingressRules(portRange network.PortRange, endpoint string) ([]network.IngressRule, error) {
+	sourceCIDRs, ok := exposeSourceCIDRs(sd.exposedEndpoints, endpoint)
+	if !ok {
+		return nil, nil
+	}
+	rule, err := network.NewIngressRule(portRange, sourceCIDRs...)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return splitRule(rule), nil
 }
 
-// watchLoop watches the service's exposed flag for changes.
-func (sd *serviceData) watchLoop(exposed bool) error {
+// watchLoop watches the service's exposed flag and expose settings
+// for changes.
+func (sd *serviceData) watchLoop(exposed bool, endpoints map[string]params.ExposedEndpoint) error {
 	serviceWatcher, err := sd.application.Watch()
 	if err != nil {
 		return errors.Trace(err)
@@ -843,17 +998,18 @@ func (sd *serviceData) watchLoop(exposed bool) error {
 				}
 				return nil
 			}
-			change, err := sd.application.IsExposed()
+			change, changeEndpoints, err := sd.application.ExposeInfo()
 			if err != nil {
 				return errors.Trace(err)
 			}
-			if change == exposed {
+			if change == exposed && exposedEndpointsEqual(changeEndpoints, endpoints) {
 				continue
 			}
 
 			exposed = change
+			endpoints = changeEndpoints
 			select {
-			case sd.fw.exposedChange <- &exposedChange{sd, change}:
+			case sd.fw.exposedChange <- &exposedChange{sd, change, changeEndpoints}:
 			case <-sd.catacomb.Dying():
 				return sd.catacomb.ErrDying()
 			}
@@ -871,12 +1027,12 @@ func (sd *serviceData) Wait() error {
 	return sd.catacomb.Wait()
 }
 
-// diffRanges returns all the port rangess that exist in A but not B.
-func diffRanges(A, B []network.PortRange) (missing []network.PortRange) {
+// diffRules returns all the ingress rules that exist in A but not B.
+func diffRules(A, B []network.IngressRule) (missing []network.IngressRule) {
 next:
 	for _, a := range A {
 		for _, b := range B {
-			if a == b {
+			if a.String() == b.String() {
 				continue next
 			}
 		}
@@ -885,6 +1041,127 @@ next:
 	return
 }
 
+// splitRule returns the given ingress rule as rules with a single
+// source CIDR each. Rules are opened, closed and reference counted per
+// port range and source CIDR, so that narrowing a rule's source CIDRs
+// closes only those CIDRs which were removed, and rules which share a
+// port range and source CIDR do not close each other.
+func splitRule(rule network.IngressRule) []network.IngressRule {
+	if len(rule.SourceCIDRs) == 0 {
+		return network.IngressRulesFromPortRanges([]network.PortRange{rule.PortRange})
+	}
+	rules := make([]network.IngressRule, len(rule.SourceCIDRs))
+	for i, cidr := range rule.SourceCIDRs {
+		rules[i] = network.IngressRule{
+			PortRange:   rule.PortRange,
+			SourceCIDRs: []string{cidr},
+		}
+	}
+	return rules
+}
+
+// reconcileRules returns the rules to close and then open so that the
+// current rules, as reported by the provider, match the wanted rules,
+// which each have a single source CIDR. A current rule is closed if
+// any of its source CIDRs is no longer wanted, and the wanted CIDRs
+// it covered are opened again.
+func reconcileRules(current, wanted []network.IngressRule) (toClose, toOpen []network.IngressRule) {
+	wantedKeys := make(map[string]bool)
+	for _, rule := range wanted {
+		wantedKeys[rule.String()] = true
+	}
+	keptKeys := make(map[string]bool)
+	for _, rule := range current {
+		split := splitRule(rule)
+		keep := true
+		for _, r := range split {
+			if !wantedKeys[r.String()] {
+				keep = false
+				break
+			}
+		}
+		if !keep {
+			toClose = append(toClose, rule)
+			continue
+		}
+		for _, r := range split {
+			keptKeys[r.String()] = true
+		}
+	}
+	for _, rule := range wanted {
+		if !keptKeys[rule.String()] {
+			toOpen = append(toOpen, rule)
+		}
+	}
+	return toClose, toOpen
+}
+
+// exposeSourceCIDRs returns the sorted source CIDRs from which a port
+// range opened for the given endpoint may be reached, according to the
+// given expose settings; "" is both the endpoint of ranges opened for
+// all endpoints and the key of the settings for all endpoints. A range
+// opened for one endpoint may be reached from the CIDRs of that
+// endpoint and of all endpoints, and a range opened for all endpoints
+// from the CIDRs of any endpoint. It returns nil, meaning that ingress
+// is allowed from anywhere, if there are no settings or an applicable
+// setting does not restrict its source CIDRs, and false if no setting
+// applies, in which case the range is not opened at all.
+func exposeSourceCIDRs(endpoints map[string]params.ExposedEndpoint, endpoint string) ([]string, bool) {
+	if len(endpoints) == 0 {
+		return nil, true
+	}
+	var applicable []params.ExposedEndpoint
+	for name, ep := range endpoints {
+		if endpoint == "" || name == "" || name == endpoint {
+			applicable = append(applicable, ep)
+		}
+	}
+	if len(applicable) == 0 {
+		return nil, false
+	}
+	cidrs := set.NewStrings()
+	for _, ep := range applicable {
+		if len(ep.ExposeToCIDRs) == 0 {
+			return nil, true
+		}
+		cidrs = cidrs.Union(set.NewStrings(ep.ExposeToCIDRs...))
+	}
+	if cidrs.Contains(network.AllSourceCIDR) {
+		return nil, true
+	}
+	return cidrs.SortedValues(), true
+}
+
+func exposedEndpointsEqual(a, b map[string]params.ExposedEndpoint) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for name, epA := range a {
+		epB, ok := b[name]
+		if !ok {
+			return false
+		}
+		cidrsA := set.NewStrings(epA.ExposeToCIDRs...).SortedValues()
+		cidrsB := set.NewStrings(epB.ExposeToCIDRs...).SortedValues()
+		if !stringsEqual(cidrsA, cidrsB) {
+			return false
+		}
+	}
+	return true
+}
+
+func stringsEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
 // parsePortsKey parses a ports document global key coming from the ports
 // watcher (e.g. "42:0.1.2.0/24") and returns the machine and subnet tags from
 // its components (in the last example "machine-42" and "subnet-0.1.2.0/24").
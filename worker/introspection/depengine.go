@@ -0,0 +1,100 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package introspection
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/juju/errors"
+	goyaml "gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/worker/dependency"
+)
+
+const (
+	// DepEnginePath is the path at which the worker reports the state
+	// of the agent's dependency engine.
+	DepEnginePath = "/depengine"
+
+	// DepEngineBouncePath is the path to which a manifold's name is
+	// POSTed, as the "name" query parameter, to bounce its worker.
+	DepEngineBouncePath = "/depengine/bounce"
+)
+
+// Engine exposes the parts of a dependency.Engine that the worker uses
+// to report on, and bounce, the engine's workers.
+type Engine interface {
+	dependency.Reporter
+	dependency.Bouncer
+}
+
+// depEngineReporter serves the report of a dependency engine, as YAML
+// or, if the "format" query parameter is "json", as JSON.
+type depEngineReporter struct {
+	engine Engine
+}
+
+// ServeHTTP is part of the http.Handler interface.
+func (h depEngineReporter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		http.Error(w, fmt.Sprintf("unsupported method: %q", r.Method), http.StatusMethodNotAllowed)
+		return
+	}
+	report := h.engine.Report()
+	var data []byte
+	var err error
+	switch format := r.URL.Query().Get("format"); format {
+	case "", "yaml":
+		data, err = goyaml.Marshal(report)
+	case "json":
+		data, err = json.MarshalIndent(report, "", "  ")
+		data = append(data, '\n')
+	default:
+		http.Error(w, fmt.Sprintf("unknown format %q", format), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		http.Error(w, fmt.Sprintf("cannot marshal report: %v", err), http.StatusInternalServerError)
+		return
+	}
+	w.Write(data)
+}
+
+// depEngineBouncer bounces the worker of the manifold named by the
+// "name" query parameter, if the request is authorized.
+type depEngineBouncer struct {
+	engine Engine
+
+	// authorize reports whether the request may bounce a worker.
+	// The introspection socket is reachable by any local user, so
+	// only root and the agent's user are authorized.
+	authorize func(*http.Request) bool
+}
+
+// ServeHTTP is part of the http.Handler interface.
+func (h depEngineBouncer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		http.Error(w, fmt.Sprintf("unsupported method: %q", r.Method), http.StatusMethodNotAllowed)
+		return
+	}
+	if !h.authorize(r) {
+		http.Error(w, "permission denied", http.StatusForbidden)
+		return
+	}
+	name := r.URL.Query().Get("name")
+	if name == "" {
+		http.Error(w, "missing manifold name", http.StatusBadRequest)
+		return
+	}
+	if err := h.engine.Bounce(name); errors.IsNotFound(err) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	} else if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	fmt.Fprintf(w, "bounced %q manifold\n", name)
+}
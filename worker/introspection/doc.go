@@ -9,4 +9,12 @@
 //   - prints out all the goroutines in the agent
 // * `/debug/pprof/heap?debug=1`
 //   - prints out the heap profile
+// * `/introspection/metrics`
+//   - prints out the agent's Prometheus metrics
+// * `/depengine`
+//   - prints out the state of the agent's dependency engine, as YAML,
+//     or as JSON if requested with `?format=json`
+// * `/depengine/bounce?name=<manifold>` (POST)
+//   - restarts the named manifold's worker; only root and the
+//     agent's user may do this
 package introspection
@@ -0,0 +1,8 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package introspection
+
+// AgentUID is the uid that, with root, is trusted to make
+// requests that change the agent's state.
+var AgentUID = &agentUID
@@ -4,6 +4,7 @@
 package introspection
 
 import (
+	"net/http"
 	"runtime"
 
 	"github.com/juju/errors"
@@ -21,6 +22,14 @@ var logger = loggo.GetLogger("juju.worker.introspection")
 type ManifoldConfig struct {
 	AgentName  string
 	WorkerFunc func(Config) (worker.Worker, error)
+
+	// Handlers holds additional handlers to be served by the
+	// worker, keyed by path.
+	Handlers map[string]http.Handler
+
+	// Engine, if not nil, is reported on by the worker, and may have
+	// its workers bounced through it.
+	Engine Engine
 }
 
 // Manifold returns a Manifold which encapsulates the introspection worker.
@@ -43,6 +52,8 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 			socketName := "jujud-" + a.CurrentConfig().Tag().String()
 			w, err := config.WorkerFunc(Config{
 				SocketName: socketName,
+				Handlers:   config.Handlers,
+				Engine:     config.Engine,
 			})
 			if err != nil {
 				return nil, errors.Trace(err)
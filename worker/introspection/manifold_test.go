@@ -4,6 +4,7 @@
 package introspection_test
 
 import (
+	"net/http"
 	"runtime"
 
 	"github.com/juju/errors"
@@ -23,6 +24,7 @@ import (
 type ManifoldSuite struct {
 	testing.IsolationSuite
 	manifold dependency.Manifold
+	engine   *fakeEngine
 	startErr error
 }
 
@@ -31,8 +33,11 @@ var _ = gc.Suite(&ManifoldSuite{})
 func (s *ManifoldSuite) SetUpTest(c *gc.C) {
 	s.IsolationSuite.SetUpTest(c)
 	s.startErr = nil
+	s.engine = &fakeEngine{}
 	s.manifold = introspection.Manifold(introspection.ManifoldConfig{
 		AgentName: "agent-name",
+		Handlers:  map[string]http.Handler{"/foo": http.NotFoundHandler()},
+		Engine:    s.engine,
 		WorkerFunc: func(cfg introspection.Config) (worker.Worker, error) {
 			if s.startErr != nil {
 				return nil, s.startErr
@@ -103,6 +108,9 @@ func (s *ManifoldSuite) TestStartSuccess(c *gc.C) {
 	dummy, ok := worker.(*dummyWorker)
 	c.Assert(ok, jc.IsTrue)
 	c.Assert(dummy.config.SocketName, gc.Equals, "jujud-machine-42")
+	c.Assert(dummy.config.Handlers, gc.HasLen, 1)
+	c.Assert(dummy.config.Handlers["/foo"], gc.NotNil)
+	c.Assert(dummy.config.Engine, gc.Equals, s.engine)
 }
 
 type dummyAgent struct {
@@ -0,0 +1,108 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package introspection
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"sync"
+)
+
+// agentUID is the uid of the agent process. Peers running as this
+// user, or as root, may make requests that change the agent's state.
+var agentUID = os.Getuid()
+
+// peerListener is a net.Listener that records the uid of the process
+// at the other end of each accepted connection, so that handlers can
+// authorize requests by the user making them.
+type peerListener struct {
+	*net.UnixListener
+
+	mu     sync.Mutex
+	nextID int
+	uids   map[string]int
+}
+
+func newPeerListener(l *net.UnixListener) *peerListener {
+	return &peerListener{
+		UnixListener: l,
+		uids:         make(map[string]int),
+	}
+}
+
+// Accept is part of the net.Listener interface. The returned
+// connection's remote address identifies the connection to
+// the listener's peerUID method.
+func (l *peerListener) Accept() (net.Conn, error) {
+	conn, err := l.AcceptUnix()
+	if err != nil {
+		return nil, err
+	}
+	uid, err := peerUID(conn)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.nextID++
+	addr := peerAddr(fmt.Sprintf("@peer-%d", l.nextID))
+	if err != nil {
+		logger.Warningf("cannot get credentials of introspection peer: %v", err)
+	} else {
+		l.uids[addr.String()] = uid
+	}
+	return &peerConn{Conn: conn, addr: addr}, nil
+}
+
+// peerUID returns the uid of the peer of the connection with the
+// given remote address, and whether it is known.
+func (l *peerListener) peerUID(remoteAddr string) (int, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	uid, ok := l.uids[remoteAddr]
+	return uid, ok
+}
+
+// connStateChanged forgets the peers of connections that are no
+// longer served. It is intended for use as an http.Server's
+// ConnState hook.
+func (l *peerListener) connStateChanged(conn net.Conn, state http.ConnState) {
+	if state != http.StateClosed && state != http.StateHijacked {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.uids, conn.RemoteAddr().String())
+}
+
+// trustedPeer reports whether the request was made by root or by the
+// agent's user, as identified by the peer credentials of its connection.
+func (l *peerListener) trustedPeer(r *http.Request) bool {
+	uid, ok := l.peerUID(r.RemoteAddr)
+	return ok && (uid == 0 || uid == agentUID)
+}
+
+// peerAddr is the remote address of a peerConn.
+type peerAddr string
+
+// Network is part of the net.Addr interface.
+func (a peerAddr) Network() string {
+	return "unix"
+}
+
+// String is part of the net.Addr interface.
+func (a peerAddr) String() string {
+	return string(a)
+}
+
+// peerConn is a connection accepted by a peerListener.
+type peerConn struct {
+	net.Conn
+	addr peerAddr
+}
+
+// RemoteAddr is part of the net.Conn interface.
+func (c *peerConn) RemoteAddr() net.Addr {
+	return c.addr
+}
@@ -0,0 +1,26 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package introspection
+
+import (
+	"net"
+	"syscall"
+
+	"github.com/juju/errors"
+)
+
+// peerUID returns the uid of the process at the other end of the
+// connection, using the SO_PEERCRED socket option.
+func peerUID(conn *net.UnixConn) (int, error) {
+	f, err := conn.File()
+	if err != nil {
+		return -1, errors.Trace(err)
+	}
+	defer f.Close()
+	cred, err := syscall.GetsockoptUcred(int(f.Fd()), syscall.SOL_SOCKET, syscall.SO_PEERCRED)
+	if err != nil {
+		return -1, errors.Annotate(err, "getting peer credentials")
+	}
+	return int(cred.Uid), nil
+}
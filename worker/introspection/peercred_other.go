@@ -0,0 +1,18 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build !linux
+
+package introspection
+
+import (
+	"net"
+
+	"github.com/juju/errors"
+)
+
+// peerUID is not supported on this OS; the introspection
+// worker only runs on linux.
+func peerUID(conn *net.UnixConn) (int, error) {
+	return -1, errors.NotSupportedf("peer credentials")
+}
@@ -7,6 +7,7 @@ import (
 	"net"
 	"net/http"
 	"runtime"
+	"strings"
 
 	"github.com/juju/errors"
 	"gopkg.in/tomb.v1"
@@ -15,9 +16,22 @@ import (
 	"github.com/juju/juju/worker/introspection/pprof"
 )
 
+// MetricsPath is the path at which agents serve their Prometheus
+// metrics over the introspection socket.
+const MetricsPath = "/introspection/metrics"
+
 // Config describes the arguments required to create the introspection worker.
 type Config struct {
 	SocketName string
+
+	// Handlers holds additional handlers to serve, keyed by
+	// the path at which they are served.
+	Handlers map[string]http.Handler
+
+	// Engine, if not nil, is the dependency engine that is reported at
+	// DepEnginePath, and whose workers may be bounced at
+	// DepEngineBouncePath.
+	Engine Engine
 }
 
 // Validate checks the config values to assert they are valid to create the worker.
@@ -25,13 +39,23 @@ func (c *Config) Validate() error {
 	if c.SocketName == "" {
 		return errors.NotValidf("empty SocketName")
 	}
+	for path, handler := range c.Handlers {
+		if !strings.HasPrefix(path, "/") {
+			return errors.NotValidf("handler path %q", path)
+		}
+		if handler == nil {
+			return errors.NotValidf("nil handler for %q", path)
+		}
+	}
 	return nil
 }
 
 // socketListener is a worker and constructed with NewWorker.
 type socketListener struct {
 	tomb     tomb.Tomb
-	listener *net.UnixListener
+	listener *peerListener
+	handlers map[string]http.Handler
+	engine   Engine
 }
 
 // NewWorker starts an http server listening on an abstract domain socket
@@ -57,7 +81,9 @@ func NewWorker(config Config) (worker.Worker, error) {
 	logger.Debugf("introspection worker listening on %q", path)
 
 	w := &socketListener{
-		listener: l,
+		listener: newPeerListener(l),
+		handlers: config.Handlers,
+		engine:   config.Engine,
 	}
 	go w.serve()
 	go w.run()
@@ -70,9 +96,20 @@ func (w *socketListener) serve() {
 	mux.Handle("/debug/pprof/cmdline", http.HandlerFunc(pprof.Cmdline))
 	mux.Handle("/debug/pprof/profile", http.HandlerFunc(pprof.Profile))
 	mux.Handle("/debug/pprof/symbol", http.HandlerFunc(pprof.Symbol))
+	if w.engine != nil {
+		mux.Handle(DepEnginePath, depEngineReporter{w.engine})
+		mux.Handle(DepEngineBouncePath, depEngineBouncer{
+			engine:    w.engine,
+			authorize: w.listener.trustedPeer,
+		})
+	}
+	for path, handler := range w.handlers {
+		mux.Handle(path, handler)
+	}
 
 	srv := http.Server{
-		Handler: mux,
+		Handler:   mux,
+		ConnState: w.listener.connStateChanged,
 	}
 
 	logger.Debugf("stats worker now servering")
@@ -9,10 +9,13 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net"
+	"net/http"
 	"os"
 	"regexp"
 	"runtime"
+	"sync"
 
+	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -34,6 +37,22 @@ func (s *suite) TestConfigValidation(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "empty SocketName not valid")
 }
 
+func (s *suite) TestConfigValidationHandlers(c *gc.C) {
+	w, err := introspection.NewWorker(introspection.Config{
+		SocketName: "introspection-test",
+		Handlers:   map[string]http.Handler{"foo": http.NotFoundHandler()},
+	})
+	c.Check(w, gc.IsNil)
+	c.Assert(err, gc.ErrorMatches, `handler path "foo" not valid`)
+
+	w, err = introspection.NewWorker(introspection.Config{
+		SocketName: "introspection-test",
+		Handlers:   map[string]http.Handler{"/foo": nil},
+	})
+	c.Check(w, gc.IsNil)
+	c.Assert(err, gc.ErrorMatches, `nil handler for "/foo" not valid`)
+}
+
 func (s *suite) TestStartStop(c *gc.C) {
 	if runtime.GOOS != "linux" {
 		c.Skip("introspection worker not supported on non-linux")
@@ -51,6 +70,7 @@ type introspectionSuite struct {
 
 	name   string
 	worker worker.Worker
+	engine *fakeEngine
 }
 
 var _ = gc.Suite(&introspectionSuite{})
@@ -63,8 +83,15 @@ func (s *introspectionSuite) SetUpTest(c *gc.C) {
 	s.IsolationSuite.SetUpTest(c)
 
 	s.name = fmt.Sprintf("introspection-test-%d", os.Getpid())
+	s.engine = &fakeEngine{}
 	w, err := introspection.NewWorker(introspection.Config{
+		Engine:     s.engine,
 		SocketName: s.name,
+		Handlers: map[string]http.Handler{
+			"/extra": http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprintln(w, "extra handler")
+			}),
+		},
 	})
 	c.Assert(err, jc.ErrorIsNil)
 	s.worker = w
@@ -74,12 +101,16 @@ func (s *introspectionSuite) SetUpTest(c *gc.C) {
 }
 
 func (s *introspectionSuite) call(c *gc.C, url string) []byte {
+	return s.request(c, "GET", url)
+}
+
+func (s *introspectionSuite) request(c *gc.C, method, url string) []byte {
 	path := "@" + s.name
 	conn, err := net.Dial("unix", path)
 	c.Assert(err, jc.ErrorIsNil)
 	defer conn.Close()
 
-	_, err = fmt.Fprintf(conn, "GET %s HTTP/1.0\r\n\r\n", url)
+	_, err = fmt.Fprintf(conn, "%s %s HTTP/1.0\r\n\r\n", method, url)
 	c.Assert(err, jc.ErrorIsNil)
 
 	buf, err := ioutil.ReadAll(conn)
@@ -99,6 +130,102 @@ func (s *introspectionSuite) TestGoroutineProfile(c *gc.C) {
 	matches(c, buf, `^goroutine profile: total \d+`)
 }
 
+func (s *introspectionSuite) TestHandlers(c *gc.C) {
+	buf := s.call(c, "/extra")
+	matches(c, buf, "^extra handler$")
+}
+
+func (s *introspectionSuite) TestDepEngineReport(c *gc.C) {
+	buf := s.call(c, "/depengine")
+	matches(c, buf, "^HTTP/1.0 200 OK")
+	matches(c, buf, "^state: started$")
+	matches(c, buf, "^    start-count: 3$")
+}
+
+func (s *introspectionSuite) TestDepEngineReportJSON(c *gc.C) {
+	buf := s.call(c, "/depengine?format=json")
+	matches(c, buf, "^HTTP/1.0 200 OK")
+	matches(c, buf, `^  "state": "started",?$`)
+	matches(c, buf, `^      "start-count": 3,?$`)
+}
+
+func (s *introspectionSuite) TestDepEngineReportUnknownFormat(c *gc.C) {
+	buf := s.call(c, "/depengine?format=xml")
+	matches(c, buf, "^HTTP/1.0 400 Bad Request")
+	matches(c, buf, `^unknown format "xml"$`)
+}
+
+func (s *introspectionSuite) TestDepEngineBounce(c *gc.C) {
+	buf := s.request(c, "POST", "/depengine/bounce?name=some-task")
+	matches(c, buf, "^HTTP/1.0 200 OK")
+	matches(c, buf, `^bounced "some-task" manifold$`)
+	c.Assert(s.engine.Bounced(), jc.DeepEquals, []string{"some-task"})
+}
+
+func (s *introspectionSuite) TestDepEngineBounceNotFound(c *gc.C) {
+	buf := s.request(c, "POST", "/depengine/bounce?name=unknown")
+	matches(c, buf, "^HTTP/1.0 404 Not Found")
+	matches(c, buf, `^"unknown" manifold not found$`)
+}
+
+func (s *introspectionSuite) TestDepEngineBounceRequiresPOST(c *gc.C) {
+	buf := s.call(c, "/depengine/bounce?name=some-task")
+	matches(c, buf, "^HTTP/1.0 405 Method Not Allowed")
+	c.Assert(s.engine.Bounced(), gc.HasLen, 0)
+}
+
+func (s *introspectionSuite) TestDepEngineBounceUnauthorized(c *gc.C) {
+	if os.Getuid() == 0 {
+		c.Skip("root is always authorized")
+	}
+	s.PatchValue(introspection.AgentUID, os.Getuid()+1)
+	buf := s.request(c, "POST", "/depengine/bounce?name=some-task")
+	matches(c, buf, "^HTTP/1.0 403 Forbidden")
+	matches(c, buf, "^permission denied$")
+	c.Assert(s.engine.Bounced(), gc.HasLen, 0)
+}
+
+func (s *introspectionSuite) TestDepEngineBounceRequiresName(c *gc.C) {
+	buf := s.request(c, "POST", "/depengine/bounce")
+	matches(c, buf, "^HTTP/1.0 400 Bad Request")
+	c.Assert(s.engine.Bounced(), gc.HasLen, 0)
+}
+
+// fakeEngine is an introspection.Engine with a single manifold,
+// named "some-task".
+type fakeEngine struct {
+	mu      sync.Mutex
+	bounced []string
+}
+
+func (*fakeEngine) Report() map[string]interface{} {
+	return map[string]interface{}{
+		"state": "started",
+		"manifolds": map[string]interface{}{
+			"some-task": map[string]interface{}{
+				"state":       "started",
+				"start-count": 3,
+			},
+		},
+	}
+}
+
+func (e *fakeEngine) Bounce(name string) error {
+	if name != "some-task" {
+		return errors.NotFoundf("%q manifold", name)
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.bounced = append(e.bounced, name)
+	return nil
+}
+
+func (e *fakeEngine) Bounced() []string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.bounced
+}
+
 // matches fails if regex is not found in the contents of b.
 // b is expected to be the response from the pprof http server, and will
 // contain some HTTP preamble that should be ignored.
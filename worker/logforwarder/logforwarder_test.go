@@ -14,6 +14,7 @@ import (
 
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/logfwd"
 	"github.com/juju/juju/logfwd/syslog"
 	coretesting "github.com/juju/juju/testing"
@@ -92,6 +93,7 @@ func (s *LogForwarderSuite) checkClose(c *gc.C, lf worker.Worker, expected error
 type mockLogForwardConfig struct {
 	enabled bool
 	host    string
+	filter  logfwd.Filter
 	changes chan struct{}
 }
 
@@ -131,13 +133,18 @@ func (c *mockLogForwardConfig) WatchForLogForwardConfigChanges() (watcher.Notify
 	}, nil
 }
 
-func (c *mockLogForwardConfig) LogForwardConfig() (*syslog.RawConfig, bool, error) {
-	return &syslog.RawConfig{
-		Enabled:    c.enabled,
-		Host:       c.host,
-		CACert:     coretesting.CACert,
-		ClientCert: coretesting.ServerCert,
-		ClientKey:  coretesting.ServerKey,
+func (c *mockLogForwardConfig) LogForwardConfig() (*config.LogFwdConfig, bool, error) {
+	return &config.LogFwdConfig{
+		Enabled: c.enabled,
+		Sink:    config.LogFwdSinkSyslog,
+		Syslog: syslog.RawConfig{
+			Enabled:    c.enabled,
+			Host:       c.host,
+			CACert:     coretesting.CACert,
+			ClientCert: coretesting.ServerCert,
+			ClientKey:  coretesting.ServerKey,
+		},
+		Filter: c.filter,
 	}, true, nil
 }
 
@@ -155,8 +162,8 @@ func (s *LogForwarderSuite) newLogForwarderArgsWithAPI(c *gc.C, configAPI logfor
 		LogForwardConfig: configAPI,
 		AllModels:        true,
 		ControllerUUID:   "feebdaed-2f18-4fd2-967d-db9663db7bea",
-		OpenSink: func(cfg *syslog.RawConfig) (*logforwarder.LogSink, error) {
-			sender.host = cfg.Host
+		OpenSink: func(cfg *config.LogFwdConfig) (*logforwarder.LogSink, error) {
+			sender.host = cfg.Syslog.Host
 			sink := &logforwarder.LogSink{
 				sender,
 			}
@@ -212,6 +219,38 @@ func (s *LogForwarderSuite) TestConfigChange(c *gc.C) {
 	s.stub.ResetCalls()
 }
 
+func (s *LogForwarderSuite) TestFilter(c *gc.C) {
+	rec2 := s.rec
+	rec2.ID = 11
+	rec2.Level = loggo.WARNING
+	s.stream.setRecords(c, []logfwd.Record{
+		s.rec,
+		rec2,
+	})
+
+	api := &mockLogForwardConfig{
+		enabled: true,
+		host:    "10.0.0.1",
+		filter: logfwd.Filter{
+			MinLevel: loggo.WARNING,
+		},
+	}
+	lf, err := logforwarder.NewLogForwarder(s.newLogForwarderArgsWithAPI(c, api, s.stream, s.sender))
+	c.Assert(err, jc.ErrorIsNil)
+	defer s.checkClose(c, lf, nil)
+
+	// The first record is below the minimum level, so only the
+	// second is sent.
+	s.stream.waitBeforeNext(c)
+	s.stream.waitAfterNext(c)
+	s.stream.waitBeforeNext(c)
+	s.stream.waitAfterNext(c)
+	s.sender.waitAfterSend(c)
+	s.stub.CheckCallNames(c, "Next", "Next", "Send")
+	s.stub.CheckCall(c, 2, "Send", []logfwd.Record{rec2})
+	s.stub.ResetCalls()
+}
+
 func (s *LogForwarderSuite) TestNotEnabled(c *gc.C) {
 	lf, err := logforwarder.NewLogForwarder(s.newLogForwarderArgs(c, nil, s.sender))
 	c.Assert(err, jc.ErrorIsNil)
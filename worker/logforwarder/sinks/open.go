@@ -0,0 +1,29 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sinks
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/worker/logforwarder"
+)
+
+// Open returns a sink used to receive log messages to be forwarded
+// to the sink selected in the given config. It is a
+// logforwarder.LogSinkFn.
+func Open(cfg *config.LogFwdConfig) (*logforwarder.LogSink, error) {
+	switch cfg.Sink {
+	case config.LogFwdSinkSyslog:
+		sink, err := OpenSyslog(&cfg.Syslog)
+		return sink, errors.Trace(err)
+	case config.LogFwdSinkHTTP:
+		sink, err := OpenHTTP(&cfg.HTTP)
+		return sink, errors.Trace(err)
+	case config.LogFwdSinkGELF:
+		sink, err := OpenGELF(&cfg.GELF)
+		return sink, errors.Trace(err)
+	}
+	return nil, errors.NotSupportedf("log forwarding sink %q", cfg.Sink)
+}
@@ -0,0 +1,80 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sinks_test
+
+import (
+	"net"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/logfwd/gelf"
+	"github.com/juju/juju/logfwd/httpjson"
+	"github.com/juju/juju/worker/logforwarder/sinks"
+)
+
+type OpenSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&OpenSuite{})
+
+func (s *OpenSuite) TestOpenHTTP(c *gc.C) {
+	sink, err := sinks.Open(&config.LogFwdConfig{
+		Enabled: true,
+		Sink:    config.LogFwdSinkHTTP,
+		HTTP: httpjson.RawConfig{
+			Enabled: true,
+			URL:     "http://logs.example.com/",
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	client, ok := sink.SendCloser.(*httpjson.Client)
+	c.Assert(ok, jc.IsTrue)
+	c.Check(client.URL, gc.Equals, "http://logs.example.com/")
+}
+
+func (s *OpenSuite) TestOpenGELF(c *gc.C) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	c.Assert(err, jc.ErrorIsNil)
+	defer conn.Close()
+
+	sink, err := sinks.Open(&config.LogFwdConfig{
+		Enabled: true,
+		Sink:    config.LogFwdSinkGELF,
+		GELF: gelf.RawConfig{
+			Enabled: true,
+			Address: conn.LocalAddr().String(),
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer sink.Close()
+
+	client, ok := sink.SendCloser.(*gelf.Client)
+	c.Assert(ok, jc.IsTrue)
+	c.Check(client.Protocol, gc.Equals, gelf.ProtocolUDP)
+}
+
+func (s *OpenSuite) TestOpenNotEnabled(c *gc.C) {
+	_, err := sinks.Open(&config.LogFwdConfig{
+		Sink: config.LogFwdSinkHTTP,
+		HTTP: httpjson.RawConfig{
+			URL: "http://logs.example.com/",
+		},
+	})
+	c.Check(err, gc.ErrorMatches, "log forwarding not enabled")
+}
+
+func (s *OpenSuite) TestOpenUnknownSink(c *gc.C) {
+	_, err := sinks.Open(&config.LogFwdConfig{
+		Enabled: true,
+		Sink:    "carrier-pigeon",
+	})
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+	c.Check(err, gc.ErrorMatches, `log forwarding sink "carrier-pigeon" not supported`)
+}
@@ -9,8 +9,8 @@ import (
 
 	"github.com/juju/juju/api/base"
 	logfwdapi "github.com/juju/juju/api/logfwd"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/logfwd"
-	"github.com/juju/juju/logfwd/syslog"
 )
 
 // TrackingSinkArgs holds the args to OpenTrackingSender.
@@ -19,7 +19,7 @@ type TrackingSinkArgs struct {
 	AllModels bool
 
 	// Config is the logging config that will be used.
-	Config *syslog.RawConfig
+	Config *config.LogFwdConfig
 
 	// Caller is the API caller that will be used.
 	Caller base.APICaller
@@ -42,12 +42,38 @@ func OpenTrackingSink(args TrackingSinkArgs) (*LogSink, error) {
 
 	return &LogSink{
 		&trackingSender{
-			SendCloser: sink,
-			tracker:    newLastSentTracker(args.Name, args.Caller),
+			SendCloser: &filteringSender{
+				SendCloser: sink,
+				filter:     args.Config.Filter,
+			},
+			tracker: newLastSentTracker(args.Name, args.Caller),
 		},
 	}, nil
 }
 
+// filteringSender passes on to the wrapped sink only those records
+// that match the configured filter. Records that do not match are
+// dropped, but are still tracked as sent by the enclosing
+// trackingSender so that they are not streamed again.
+type filteringSender struct {
+	SendCloser
+	filter logfwd.Filter
+}
+
+// Send implements Sender.
+func (s *filteringSender) Send(records []logfwd.Record) error {
+	var matched []logfwd.Record
+	for _, rec := range records {
+		if s.filter.Match(rec) {
+			matched = append(matched, rec)
+		}
+	}
+	if len(matched) == 0 {
+		return nil
+	}
+	return errors.Trace(s.SendCloser.Send(matched))
+}
+
 type trackingSender struct {
 	SendCloser
 	tracker   *lastSentTracker
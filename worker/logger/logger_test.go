@@ -103,3 +103,20 @@ func (s *LoggerSuite) TestInitialState(c *gc.C) {
 
 	s.waitLoggingInfo(c, expected)
 }
+
+func (s *LoggerSuite) TestAppliesOverride(c *gc.C) {
+	loggingWorker, _ := s.makeLogger(c)
+	defer worker.Stop(loggingWorker)
+
+	err := s.State.UpdateModelConfig(map[string]interface{}{
+		"logging-config":    "<root>=WARNING;unit=DEBUG",
+		"logging-overrides": s.machine.Tag().String() + ":juju.worker=TRACE",
+	}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitLoggingInfo(c, "<root>=WARNING;juju.worker=TRACE;unit=DEBUG")
+
+	// Removing the override restores the model's logging config.
+	err = s.State.UpdateModelConfig(nil, []string{"logging-overrides"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitLoggingInfo(c, "<root>=WARNING;unit=DEBUG")
+}
@@ -13,9 +13,10 @@ import (
 
 // machineBlockDevicesChanged is called when the block devices of the scoped
 // machine have been seen to have changed. This triggers a refresh of all
-// block devices for attached volumes backing pending filesystems.
+// block devices for attached volumes backing pending or provisioned
+// filesystems, and grows any filesystems whose block devices have grown.
 func machineBlockDevicesChanged(ctx *context) error {
-	if len(ctx.incompleteFilesystemParams) == 0 {
+	if len(ctx.incompleteFilesystemParams)+len(ctx.filesystems) == 0 {
 		return nil
 	}
 	volumeTags := make([]names.VolumeTag, 0, len(ctx.incompleteFilesystemParams))
@@ -37,10 +38,21 @@ func machineBlockDevicesChanged(ctx *context) error {
 		}
 		volumeTags = append(volumeTags, params.Volume)
 	}
+	// Provisioned filesystems' block devices are refreshed so that we
+	// can observe changes in size, i.e. when the volume is resized.
+	for _, filesystem := range ctx.filesystems {
+		if filesystem.Volume == (names.VolumeTag{}) {
+			continue
+		}
+		volumeTags = append(volumeTags, filesystem.Volume)
+	}
 	if len(volumeTags) == 0 {
 		return nil
 	}
-	return refreshVolumeBlockDevices(ctx, volumeTags)
+	if err := refreshVolumeBlockDevices(ctx, volumeTags); err != nil {
+		return errors.Trace(err)
+	}
+	return growFilesystems(ctx)
 }
 
 // processPendingVolumeBlockDevices is called before waiting for any events,
@@ -57,7 +69,10 @@ func processPendingVolumeBlockDevices(ctx *context) error {
 	}
 	// Clear out the pending set, so we don't force-refresh again.
 	ctx.pendingVolumeBlockDevices = set.NewTags()
-	return refreshVolumeBlockDevices(ctx, volumeTags)
+	if err := refreshVolumeBlockDevices(ctx, volumeTags); err != nil {
+		return errors.Trace(err)
+	}
+	return growFilesystems(ctx)
 }
 
 // refreshVolumeBlockDevices refreshes the block devices for the specified
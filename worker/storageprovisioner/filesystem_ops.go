@@ -114,6 +114,75 @@ func createFilesystems(ctx *context, ops map[names.FilesystemTag]*createFilesyst
 	return nil
 }
 
+// growFilesystems grows volume-backed filesystems to fill their backing
+// volumes, if the volumes' block devices are larger than the filesystems.
+// This is the case after a backing volume has been resized.
+func growFilesystems(ctx *context) error {
+	var resizeParams []storage.ResizeFilesystemParams
+	for _, filesystem := range ctx.filesystems {
+		if filesystem.Volume == (names.VolumeTag{}) {
+			continue
+		}
+		blockDevice, ok := ctx.volumeBlockDevices[filesystem.Volume]
+		if !ok || blockDevice.Size <= filesystem.Size {
+			continue
+		}
+		resizeParams = append(resizeParams, storage.ResizeFilesystemParams{
+			Tag:          filesystem.Tag,
+			FilesystemId: filesystem.FilesystemId,
+			Size:         blockDevice.Size,
+		})
+	}
+	if len(resizeParams) == 0 {
+		return nil
+	}
+	filesystemResizer, ok := ctx.managedFilesystemSource.(storage.FilesystemResizer)
+	if !ok {
+		logger.Warningf("managed filesystem source does not support resizing")
+		return nil
+	}
+	logger.Debugf("growing filesystems: %v", resizeParams)
+	results, err := filesystemResizer.ResizeFilesystems(resizeParams)
+	if err != nil {
+		return errors.Annotate(err, "growing filesystems")
+	}
+	var filesystems []storage.Filesystem
+	for i, result := range results {
+		p := resizeParams[i]
+		if result.Error != nil {
+			// The filesystem will be grown the next
+			// time the block devices are refreshed.
+			logger.Errorf(
+				"failed to grow %s: %v",
+				names.ReadableString(p.Tag), result.Error,
+			)
+			continue
+		}
+		filesystem := ctx.filesystems[p.Tag]
+		filesystem.Size = result.Size
+		filesystems = append(filesystems, filesystem)
+	}
+	if len(filesystems) == 0 {
+		return nil
+	}
+	errorResults, err := ctx.config.Filesystems.SetFilesystemInfo(filesystemsFromStorage(filesystems))
+	if err != nil {
+		return errors.Annotate(err, "publishing filesystems to state")
+	}
+	for i, result := range errorResults {
+		if result.Error != nil {
+			logger.Errorf(
+				"publishing filesystem %s to state: %v",
+				filesystems[i].Tag.Id(),
+				result.Error,
+			)
+			continue
+		}
+		updateFilesystem(ctx, filesystems[i])
+	}
+	return nil
+}
+
 // attachFilesystems creates filesystem attachments with the specified parameters.
 func attachFilesystems(ctx *context, ops map[params.MachineStorageId]*attachFilesystemOp) error {
 	filesystemAttachmentParams := make([]storage.FilesystemAttachmentParams, 0, len(ops))
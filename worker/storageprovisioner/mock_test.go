@@ -90,6 +90,8 @@ func (w *mockAttachmentsWatcher) Changes() watcher.MachineStorageIdsChannel {
 
 type mockVolumeAccessor struct {
 	volumesWatcher         *mockStringsWatcher
+	volumeResizesWatcher   *mockStringsWatcher
+	volumeSnapshotsWatcher *mockStringsWatcher
 	attachmentsWatcher     *mockAttachmentsWatcher
 	blockDevicesWatcher    *mockNotifyWatcher
 	provisionedMachines    map[string]instance.Id
@@ -99,6 +101,13 @@ type mockVolumeAccessor struct {
 
 	setVolumeInfo           func([]params.Volume) ([]params.ErrorResult, error)
 	setVolumeAttachmentInfo func([]params.VolumeAttachment) ([]params.ErrorResult, error)
+	setVolumeSnapshotInfo   func([]params.VolumeSnapshot) ([]params.ErrorResult, error)
+	setVolumeSnapshotStatus func([]params.VolumeSnapshotStatus) ([]params.ErrorResult, error)
+	volumeResizeParams      func([]names.VolumeTag) ([]params.VolumeResizeParamsResult, error)
+	volumeSnapshotParams    func([]string) ([]params.VolumeSnapshotParamsResult, error)
+
+	watchVolumeResizesErr   error
+	watchVolumeSnapshotsErr error
 }
 
 func (m *mockVolumeAccessor) provisionVolume(tag names.VolumeTag) params.Volume {
@@ -116,6 +125,20 @@ func (w *mockVolumeAccessor) WatchVolumes() (watcher.StringsWatcher, error) {
 	return w.volumesWatcher, nil
 }
 
+func (w *mockVolumeAccessor) WatchVolumeResizes() (watcher.StringsWatcher, error) {
+	if w.watchVolumeResizesErr != nil {
+		return nil, w.watchVolumeResizesErr
+	}
+	return w.volumeResizesWatcher, nil
+}
+
+func (w *mockVolumeAccessor) WatchVolumeSnapshots() (watcher.StringsWatcher, error) {
+	if w.watchVolumeSnapshotsErr != nil {
+		return nil, w.watchVolumeSnapshotsErr
+	}
+	return w.volumeSnapshotsWatcher, nil
+}
+
 func (w *mockVolumeAccessor) WatchVolumeAttachments() (watcher.MachineStorageIdsWatcher, error) {
 	return w.attachmentsWatcher, nil
 }
@@ -211,6 +234,28 @@ func (v *mockVolumeAccessor) VolumeAttachmentParams(ids []params.MachineStorageI
 	return result, nil
 }
 
+func (v *mockVolumeAccessor) VolumeResizeParams(volumes []names.VolumeTag) ([]params.VolumeResizeParamsResult, error) {
+	if v.volumeResizeParams != nil {
+		return v.volumeResizeParams(volumes)
+	}
+	result := make([]params.VolumeResizeParamsResult, len(volumes))
+	for i, tag := range volumes {
+		result[i].Error = common.ServerError(errors.NotFoundf("resize request for volume %q", tag.Id()))
+	}
+	return result, nil
+}
+
+func (v *mockVolumeAccessor) VolumeSnapshotParams(ids []string) ([]params.VolumeSnapshotParamsResult, error) {
+	if v.volumeSnapshotParams != nil {
+		return v.volumeSnapshotParams(ids)
+	}
+	result := make([]params.VolumeSnapshotParamsResult, len(ids))
+	for i, id := range ids {
+		result[i].Error = common.ServerError(errors.NotFoundf("pending volume snapshot %q", id))
+	}
+	return result, nil
+}
+
 func (v *mockVolumeAccessor) SetVolumeInfo(volumes []params.Volume) ([]params.ErrorResult, error) {
 	if v.setVolumeInfo != nil {
 		return v.setVolumeInfo(volumes)
@@ -225,9 +270,25 @@ func (v *mockVolumeAccessor) SetVolumeAttachmentInfo(volumeAttachments []params.
 	return make([]params.ErrorResult, len(volumeAttachments)), nil
 }
 
+func (v *mockVolumeAccessor) SetVolumeSnapshotInfo(snapshots []params.VolumeSnapshot) ([]params.ErrorResult, error) {
+	if v.setVolumeSnapshotInfo != nil {
+		return v.setVolumeSnapshotInfo(snapshots)
+	}
+	return make([]params.ErrorResult, len(snapshots)), nil
+}
+
+func (v *mockVolumeAccessor) SetVolumeSnapshotStatus(statuses []params.VolumeSnapshotStatus) ([]params.ErrorResult, error) {
+	if v.setVolumeSnapshotStatus != nil {
+		return v.setVolumeSnapshotStatus(statuses)
+	}
+	return make([]params.ErrorResult, len(statuses)), nil
+}
+
 func newMockVolumeAccessor() *mockVolumeAccessor {
 	return &mockVolumeAccessor{
 		volumesWatcher:         newMockStringsWatcher(),
+		volumeResizesWatcher:   newMockStringsWatcher(),
+		volumeSnapshotsWatcher: newMockStringsWatcher(),
 		attachmentsWatcher:     newMockAttachmentsWatcher(),
 		blockDevicesWatcher:    newMockNotifyWatcher(),
 		provisionedMachines:    make(map[string]instance.Id),
@@ -431,6 +492,8 @@ type dummyProvider struct {
 	detachFilesystemsFunc        func([]storage.FilesystemAttachmentParams) ([]error, error)
 	destroyVolumesFunc           func([]string) ([]error, error)
 	destroyFilesystemsFunc       func([]string) ([]error, error)
+	resizeVolumesFunc            func([]storage.ResizeVolumeParams) ([]storage.ResizeVolumesResult, error)
+	createVolumeSnapshotsFunc    func([]storage.VolumeSnapshotParams) ([]storage.CreateVolumeSnapshotsResult, error)
 	validateVolumeParamsFunc     func(storage.VolumeParams) error
 	validateFilesystemParamsFunc func(storage.FilesystemParams) error
 }
@@ -540,6 +603,36 @@ func (s *dummyVolumeSource) DetachVolumes(params []storage.VolumeAttachmentParam
 	return make([]error, len(params)), nil
 }
 
+// ResizeVolumes resizes volumes.
+func (s *dummyVolumeSource) ResizeVolumes(params []storage.ResizeVolumeParams) ([]storage.ResizeVolumesResult, error) {
+	if s.provider.resizeVolumesFunc != nil {
+		return s.provider.resizeVolumesFunc(params)
+	}
+	results := make([]storage.ResizeVolumesResult, len(params))
+	for i, p := range params {
+		results[i].Size = p.Size
+	}
+	return results, nil
+}
+
+// CreateVolumeSnapshots creates volume snapshots.
+func (s *dummyVolumeSource) CreateVolumeSnapshots(params []storage.VolumeSnapshotParams) ([]storage.CreateVolumeSnapshotsResult, error) {
+	if s.provider.createVolumeSnapshotsFunc != nil {
+		return s.provider.createVolumeSnapshotsFunc(params)
+	}
+	results := make([]storage.CreateVolumeSnapshotsResult, len(params))
+	for i, p := range params {
+		results[i].VolumeSnapshot = &storage.VolumeSnapshot{
+			Id:     p.Id,
+			Volume: p.Volume,
+			VolumeSnapshotInfo: storage.VolumeSnapshotInfo{
+				SnapshotId: "snap-" + p.Id,
+			},
+		}
+	}
+	return results, nil
+}
+
 func (s *dummyFilesystemSource) ValidateFilesystemParams(params storage.FilesystemParams) error {
 	if s.provider != nil && s.provider.validateFilesystemParamsFunc != nil {
 		return s.provider.validateFilesystemParamsFunc(params)
@@ -674,6 +767,24 @@ func (s *mockManagedFilesystemSource) AttachFilesystems(args []storage.Filesyste
 	return results, nil
 }
 
+func (s *mockManagedFilesystemSource) ResizeFilesystems(args []storage.ResizeFilesystemParams) ([]storage.ResizeFilesystemsResult, error) {
+	results := make([]storage.ResizeFilesystemsResult, len(args))
+	for i, arg := range args {
+		filesystem, ok := s.filesystems[arg.Tag]
+		if !ok {
+			results[i].Error = errors.Errorf("filesystem %v has not been created", arg.Tag.Id())
+			continue
+		}
+		blockDevice, ok := s.blockDevices[filesystem.Volume]
+		if !ok {
+			results[i].Error = errors.Errorf("filesystem %v's backing-volume is not attached", arg.Tag.Id())
+			continue
+		}
+		results[i].Size = blockDevice.Size
+	}
+	return results, nil
+}
+
 func (s *mockManagedFilesystemSource) DetachFilesystems(params []storage.FilesystemAttachmentParams) ([]error, error) {
 	return nil, errors.NotImplementedf("DetachFilesystems")
 }
@@ -1055,6 +1055,297 @@ func (s *storageProvisionerSuite) TestAttachVolumeBackedFilesystem(c *gc.C) {
 	}})
 }
 
+func (s *storageProvisionerSuite) TestGrowVolumeBackedFilesystem(c *gc.C) {
+	filesystemInfoSet := make(chan interface{})
+	filesystemAccessor := newMockFilesystemAccessor()
+	filesystemAccessor.provisionedFilesystems["filesystem-0-0"] = params.Filesystem{
+		FilesystemTag: "filesystem-0-0",
+		VolumeTag:     "volume-0-0",
+		Info: params.FilesystemInfo{
+			FilesystemId: "xvdf1",
+			Size:         1024,
+		},
+	}
+	filesystemAccessor.setFilesystemInfo = func(filesystems []params.Filesystem) ([]params.ErrorResult, error) {
+		filesystemInfoSet <- filesystems
+		return nil, nil
+	}
+
+	args := &workerArgs{
+		scope:       names.NewMachineTag("0"),
+		filesystems: filesystemAccessor,
+		registry:    s.registry,
+	}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	// The backing volume has been resized, so its block
+	// device is now larger than the filesystem.
+	args.volumes.blockDevices[params.MachineStorageId{
+		MachineTag:    "machine-0",
+		AttachmentTag: "volume-0-0",
+	}] = storage.BlockDevice{
+		DeviceName: "xvdf1",
+		Size:       2048,
+	}
+	filesystemAccessor.filesystemsWatcher.changes <- []string{"0/0"}
+
+	filesystemInfo := waitChannel(
+		c, filesystemInfoSet,
+		"waiting for filesystem info to be set",
+	).([]params.Filesystem)
+	c.Assert(filesystemInfo, jc.DeepEquals, []params.Filesystem{{
+		FilesystemTag: "filesystem-0-0",
+		VolumeTag:     "volume-0-0",
+		Info: params.FilesystemInfo{
+			FilesystemId: "xvdf1",
+			Size:         2048,
+		},
+	}})
+}
+
+func (s *storageProvisionerSuite) TestResizeVolumes(c *gc.C) {
+	volumeInfoSet := make(chan interface{})
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.provisionVolume(names.NewVolumeTag("1"))
+	volumeAccessor.volumeResizeParams = func(tags []names.VolumeTag) ([]params.VolumeResizeParamsResult, error) {
+		c.Assert(tags, jc.DeepEquals, []names.VolumeTag{names.NewVolumeTag("1")})
+		return []params.VolumeResizeParamsResult{{
+			Result: params.VolumeResizeParams{
+				VolumeTag: "volume-1",
+				VolumeId:  "vol-1",
+				Size:      2048,
+				Provider:  "dummy",
+			},
+		}}, nil
+	}
+	volumeAccessor.setVolumeInfo = func(volumes []params.Volume) ([]params.ErrorResult, error) {
+		volumeInfoSet <- volumes
+		return nil, nil
+	}
+
+	resizedChan := make(chan interface{}, 1)
+	s.provider.resizeVolumesFunc = func(args []storage.ResizeVolumeParams) ([]storage.ResizeVolumesResult, error) {
+		resizedChan <- args
+		return []storage.ResizeVolumesResult{{Size: 3072}}, nil
+	}
+
+	args := &workerArgs{volumes: volumeAccessor, registry: s.registry}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	volumeAccessor.volumesWatcher.changes <- []string{"1"}
+	volumeAccessor.volumeResizesWatcher.changes <- []string{"1"}
+
+	resized := waitChannel(c, resizedChan, "waiting for volume to be resized")
+	c.Assert(resized, jc.DeepEquals, []storage.ResizeVolumeParams{{
+		Tag:      names.NewVolumeTag("1"),
+		VolumeId: "vol-1",
+		Size:     2048,
+		Provider: "dummy",
+	}})
+
+	// The size reported by the provider is recorded,
+	// which may be larger than the requested size.
+	volumeInfo := waitChannel(c, volumeInfoSet, "waiting for volume info to be set")
+	c.Assert(volumeInfo, jc.DeepEquals, []params.Volume{{
+		VolumeTag: "volume-1",
+		Info: params.VolumeInfo{
+			VolumeId: "vol-1",
+			Size:     3072,
+		},
+	}})
+}
+
+func (s *storageProvisionerSuite) TestResizeVolumesNotSupported(c *gc.C) {
+	volumeInfoSet := make(chan interface{})
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.watchVolumeResizesErr = errors.NotSupportedf("WatchVolumeResizes")
+	volumeAccessor.provisionedMachines["machine-1"] = instance.Id("already-provisioned-1")
+	volumeAccessor.setVolumeInfo = func(volumes []params.Volume) ([]params.ErrorResult, error) {
+		defer close(volumeInfoSet)
+		return nil, nil
+	}
+
+	args := &workerArgs{volumes: volumeAccessor, registry: s.registry}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	// Volumes are still provisioned when the controller
+	// does not support resizing them.
+	volumeAccessor.volumesWatcher.changes <- []string{"1"}
+	waitChannel(c, volumeInfoSet, "waiting for volume info to be set")
+}
+
+func (s *storageProvisionerSuite) TestResizeVolumesRemoved(c *gc.C) {
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.provisionVolume(names.NewVolumeTag("1"))
+	volumeAccessor.volumeResizeParams = func(tags []names.VolumeTag) ([]params.VolumeResizeParamsResult, error) {
+		c.Assert(tags, jc.DeepEquals, []names.VolumeTag{
+			names.NewVolumeTag("2"), names.NewVolumeTag("3"), names.NewVolumeTag("1"),
+		})
+		return []params.VolumeResizeParamsResult{
+			{Error: &params.Error{Code: params.CodeNotFound}},
+			{Error: &params.Error{Code: params.CodeUnauthorized}},
+			{Result: params.VolumeResizeParams{
+				VolumeTag: "volume-1",
+				VolumeId:  "vol-1",
+				Size:      2048,
+				Provider:  "dummy",
+			}},
+		}, nil
+	}
+
+	resizedChan := make(chan interface{}, 1)
+	s.provider.resizeVolumesFunc = func(args []storage.ResizeVolumeParams) ([]storage.ResizeVolumesResult, error) {
+		resizedChan <- args
+		return []storage.ResizeVolumesResult{{Size: 2048}}, nil
+	}
+
+	args := &workerArgs{volumes: volumeAccessor, registry: s.registry}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	volumeAccessor.volumesWatcher.changes <- []string{"1"}
+	volumeAccessor.volumeResizesWatcher.changes <- []string{"2", "3", "1"}
+
+	// The removed volumes are skipped without stopping the worker.
+	resized := waitChannel(c, resizedChan, "waiting for volume to be resized")
+	c.Assert(resized, jc.DeepEquals, []storage.ResizeVolumeParams{{
+		Tag:      names.NewVolumeTag("1"),
+		VolumeId: "vol-1",
+		Size:     2048,
+		Provider: "dummy",
+	}})
+}
+
+func (s *storageProvisionerSuite) TestCreateVolumeSnapshots(c *gc.C) {
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.volumeSnapshotParams = func(ids []string) ([]params.VolumeSnapshotParamsResult, error) {
+		c.Assert(ids, jc.DeepEquals, []string{"0"})
+		return []params.VolumeSnapshotParamsResult{{
+			Result: params.VolumeSnapshotParams{
+				Id:        "0",
+				VolumeTag: "volume-1",
+				VolumeId:  "vol-1",
+				Provider:  "dummy",
+			},
+		}}, nil
+	}
+	snapshotInfoSet := make(chan interface{})
+	volumeAccessor.setVolumeSnapshotInfo = func(snapshots []params.VolumeSnapshot) ([]params.ErrorResult, error) {
+		snapshotInfoSet <- snapshots
+		return nil, nil
+	}
+
+	snapshotsChan := make(chan interface{}, 1)
+	s.provider.createVolumeSnapshotsFunc = func(args []storage.VolumeSnapshotParams) ([]storage.CreateVolumeSnapshotsResult, error) {
+		snapshotsChan <- args
+		return []storage.CreateVolumeSnapshotsResult{{
+			VolumeSnapshot: &storage.VolumeSnapshot{
+				Id:     "0",
+				Volume: names.NewVolumeTag("1"),
+				VolumeSnapshotInfo: storage.VolumeSnapshotInfo{
+					SnapshotId: "snap-1",
+					Size:       1024,
+				},
+			},
+		}}, nil
+	}
+
+	args := &workerArgs{volumes: volumeAccessor, registry: s.registry}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	volumeAccessor.volumeSnapshotsWatcher.changes <- []string{"0"}
+
+	snapshots := waitChannel(c, snapshotsChan, "waiting for volume snapshot to be created")
+	c.Assert(snapshots, jc.DeepEquals, []storage.VolumeSnapshotParams{{
+		Id:       "0",
+		Volume:   names.NewVolumeTag("1"),
+		VolumeId: "vol-1",
+		Provider: "dummy",
+	}})
+
+	snapshotInfo := waitChannel(c, snapshotInfoSet, "waiting for volume snapshot info to be set")
+	c.Assert(snapshotInfo, jc.DeepEquals, []params.VolumeSnapshot{{
+		Id:        "0",
+		VolumeTag: "volume-1",
+		Info: params.VolumeSnapshotInfo{
+			SnapshotId: "snap-1",
+			Size:       1024,
+		},
+	}})
+}
+
+func (s *storageProvisionerSuite) TestCreateVolumeSnapshotsError(c *gc.C) {
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.volumeSnapshotParams = func(ids []string) ([]params.VolumeSnapshotParamsResult, error) {
+		return []params.VolumeSnapshotParamsResult{{
+			Result: params.VolumeSnapshotParams{
+				Id:        "0",
+				VolumeTag: "volume-1",
+				VolumeId:  "vol-1",
+				Provider:  "dummy",
+			},
+		}}, nil
+	}
+	statusSet := make(chan interface{}, 1)
+	volumeAccessor.setVolumeSnapshotStatus = func(statuses []params.VolumeSnapshotStatus) ([]params.ErrorResult, error) {
+		statusSet <- statuses
+		return make([]params.ErrorResult, len(statuses)), nil
+	}
+	volumeAccessor.setVolumeSnapshotInfo = func(snapshots []params.VolumeSnapshot) ([]params.ErrorResult, error) {
+		c.Fatalf("unexpected call to SetVolumeSnapshotInfo")
+		return nil, nil
+	}
+	s.provider.createVolumeSnapshotsFunc = func(args []storage.VolumeSnapshotParams) ([]storage.CreateVolumeSnapshotsResult, error) {
+		return []storage.CreateVolumeSnapshotsResult{{
+			Error: errors.New("snapshot quota exceeded"),
+		}}, nil
+	}
+
+	args := &workerArgs{volumes: volumeAccessor, registry: s.registry}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	volumeAccessor.volumeSnapshotsWatcher.changes <- []string{"0"}
+
+	statuses := waitChannel(c, statusSet, "waiting for volume snapshot status to be set")
+	c.Assert(statuses, jc.DeepEquals, []params.VolumeSnapshotStatus{{
+		Id:     "0",
+		Status: "error",
+		Info:   "snapshot quota exceeded",
+	}})
+}
+
+func (s *storageProvisionerSuite) TestCreateVolumeSnapshotsNotSupported(c *gc.C) {
+	volumeInfoSet := make(chan interface{})
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.watchVolumeSnapshotsErr = errors.NotSupportedf("WatchVolumeSnapshots")
+	volumeAccessor.provisionedMachines["machine-1"] = instance.Id("already-provisioned-1")
+	volumeAccessor.setVolumeInfo = func(volumes []params.Volume) ([]params.ErrorResult, error) {
+		defer close(volumeInfoSet)
+		return nil, nil
+	}
+
+	args := &workerArgs{volumes: volumeAccessor, registry: s.registry}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	// Volumes are still provisioned when the controller
+	// does not support snapshotting them.
+	volumeAccessor.volumesWatcher.changes <- []string{"1"}
+	waitChannel(c, volumeInfoSet, "waiting for volume info to be set")
+}
+
 func (s *storageProvisionerSuite) TestResourceTags(c *gc.C) {
 	volumeInfoSet := make(chan interface{})
 	volumeAccessor := newMockVolumeAccessor()
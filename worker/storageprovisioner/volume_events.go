@@ -95,11 +95,83 @@ func volumeAttachmentsChanged(ctx *context, watcherIds []watcher.MachineStorageI
 	return nil
 }
 
+// volumeResizesChanged is called when volumes with the provided IDs
+// have been seen to have changed, and may have pending resize requests.
+func volumeResizesChanged(ctx *context, changes []string) error {
+	tags := make([]names.VolumeTag, len(changes))
+	for i, change := range changes {
+		tags[i] = names.NewVolumeTag(change)
+	}
+	results, err := ctx.config.Volumes.VolumeResizeParams(tags)
+	if err != nil {
+		return errors.Annotate(err, "getting volume resize parameters")
+	}
+	var ops []scheduleOp
+	for i, result := range results {
+		if result.Error != nil {
+			if params.IsCodeNotFound(result.Error) || params.IsCodeUnauthorized(result.Error) {
+				// There is no pending resize request for the
+				// volume, or the volume has been removed, which
+				// is reported as a permission error; nothing to do.
+				continue
+			}
+			return errors.Annotatef(
+				result.Error, "getting resize parameters for volume %q", tags[i].Id(),
+			)
+		}
+		args, err := resizeVolumeParamsFromParams(result.Result)
+		if err != nil {
+			return errors.Annotate(err, "getting volume resize parameters")
+		}
+		op := &resizeVolumeOp{args: args}
+		// Replace any previously scheduled resize of the
+		// volume, as the requested size may have changed.
+		ctx.schedule.Remove(op.key())
+		ops = append(ops, op)
+	}
+	scheduleOperations(ctx, ops...)
+	return nil
+}
+
+// volumeSnapshotsChanged is called when volume snapshots with the
+// provided IDs have been seen to have changed, and may be pending.
+func volumeSnapshotsChanged(ctx *context, changes []string) error {
+	results, err := ctx.config.Volumes.VolumeSnapshotParams(changes)
+	if err != nil {
+		return errors.Annotate(err, "getting volume snapshot parameters")
+	}
+	var ops []scheduleOp
+	for i, result := range results {
+		if result.Error != nil {
+			if params.IsCodeNotFound(result.Error) {
+				// The snapshot has already been taken,
+				// or has been removed; nothing to do.
+				continue
+			}
+			return errors.Annotatef(
+				result.Error, "getting parameters for volume snapshot %q", changes[i],
+			)
+		}
+		args, err := volumeSnapshotParamsFromParams(result.Result)
+		if err != nil {
+			return errors.Annotate(err, "getting volume snapshot parameters")
+		}
+		op := &createVolumeSnapshotOp{args: args}
+		// Replace any previously scheduled creation of the snapshot,
+		// as the schedule does not permit duplicate keys.
+		ctx.schedule.Remove(op.key())
+		ops = append(ops, op)
+	}
+	scheduleOperations(ctx, ops...)
+	return nil
+}
+
 // processDyingVolumes processes the VolumeResults for Dying volumes,
 // removing them from provisioning-pending as necessary.
 func processDyingVolumes(ctx *context, tags []names.Tag) error {
 	for _, tag := range tags {
 		removePendingVolume(ctx, tag.(names.VolumeTag))
+		ctx.schedule.Remove(volumeResizeKey{tag.(names.VolumeTag)})
 	}
 	return nil
 }
@@ -398,6 +470,21 @@ func volumesFromStorage(in []storage.Volume) []params.Volume {
 	return out
 }
 
+func volumeSnapshotsFromStorage(in []storage.VolumeSnapshot) []params.VolumeSnapshot {
+	out := make([]params.VolumeSnapshot, len(in))
+	for i, s := range in {
+		out[i] = params.VolumeSnapshot{
+			Id:        s.Id,
+			VolumeTag: s.Volume.String(),
+			Info: params.VolumeSnapshotInfo{
+				SnapshotId: s.SnapshotId,
+				Size:       s.Size,
+			},
+		}
+	}
+	return out
+}
+
 func volumeAttachmentsFromStorage(in []storage.VolumeAttachment) []params.VolumeAttachment {
 	out := make([]params.VolumeAttachment, len(in))
 	for i, v := range in {
@@ -475,6 +562,7 @@ func volumeParamsFromParams(in params.VolumeParams) (storage.VolumeParams, error
 		in.Attributes,
 		in.Tags,
 		attachment,
+		in.SnapshotId,
 	}, nil
 }
 
@@ -498,3 +586,43 @@ func volumeAttachmentParamsFromParams(in params.VolumeAttachmentParams) (storage
 		VolumeId: in.VolumeId,
 	}, nil
 }
+
+func resizeVolumeParamsFromParams(in params.VolumeResizeParams) (storage.ResizeVolumeParams, error) {
+	volumeTag, err := names.ParseVolumeTag(in.VolumeTag)
+	if err != nil {
+		return storage.ResizeVolumeParams{}, errors.Trace(err)
+	}
+	var attachment *storage.VolumeAttachmentParams
+	if in.Attachment != nil {
+		attachmentParams, err := volumeAttachmentParamsFromParams(*in.Attachment)
+		if err != nil {
+			return storage.ResizeVolumeParams{}, errors.Annotate(
+				err, "parsing attachment parameters",
+			)
+		}
+		attachment = &attachmentParams
+	}
+	return storage.ResizeVolumeParams{
+		Tag:        volumeTag,
+		VolumeId:   in.VolumeId,
+		Size:       in.Size,
+		Provider:   storage.ProviderType(in.Provider),
+		Attributes: in.Attributes,
+		Attachment: attachment,
+	}, nil
+}
+
+func volumeSnapshotParamsFromParams(in params.VolumeSnapshotParams) (storage.VolumeSnapshotParams, error) {
+	volumeTag, err := names.ParseVolumeTag(in.VolumeTag)
+	if err != nil {
+		return storage.VolumeSnapshotParams{}, errors.Trace(err)
+	}
+	return storage.VolumeSnapshotParams{
+		Id:           in.Id,
+		Volume:       volumeTag,
+		VolumeId:     in.VolumeId,
+		Provider:     storage.ProviderType(in.Provider),
+		Attributes:   in.Attributes,
+		ResourceTags: in.Tags,
+	}, nil
+}
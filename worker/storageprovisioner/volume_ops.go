@@ -322,6 +322,80 @@ func detachVolumes(ctx *context, ops map[params.MachineStorageId]*detachVolumeOp
 	return nil
 }
 
+// resizeVolumes resizes volumes with the specified parameters.
+func resizeVolumes(ctx *context, ops map[volumeResizeKey]*resizeVolumeOp) error {
+	var reschedule []scheduleOp
+	resizeParams := make([]storage.ResizeVolumeParams, 0, len(ops))
+	for _, op := range ops {
+		if _, ok := ctx.volumes[op.args.Tag]; !ok {
+			// We haven't yet seen the volume in this session,
+			// so we would not be able to record the new size.
+			reschedule = append(reschedule, op)
+			continue
+		}
+		resizeParams = append(resizeParams, op.args)
+	}
+	paramsBySource, volumeSources, err := resizeVolumeParamsBySource(
+		ctx.config.StorageDir, resizeParams, ctx.config.Registry,
+	)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var volumes []storage.Volume
+	for sourceName, resizeParams := range paramsBySource {
+		logger.Debugf("resizing volumes from %q: %v", sourceName, resizeParams)
+		volumeResizer, ok := volumeSources[sourceName].(storage.VolumeResizer)
+		if !ok {
+			// The resize request remains pending, so that it
+			// will be picked up if the provider is upgraded.
+			logger.Warningf(
+				"cannot resize volumes %v: %v", resizeParams,
+				errors.NotSupportedf("resizing volumes from source %q", sourceName),
+			)
+			continue
+		}
+		results, err := volumeResizer.ResizeVolumes(resizeParams)
+		if err != nil {
+			return errors.Annotatef(err, "resizing volumes from source %q", sourceName)
+		}
+		for i, result := range results {
+			p := resizeParams[i]
+			if result.Error != nil {
+				reschedule = append(reschedule, ops[volumeResizeKey{p.Tag}])
+				logger.Errorf(
+					"failed to resize %s: %v",
+					names.ReadableString(p.Tag), result.Error,
+				)
+				continue
+			}
+			volume := ctx.volumes[p.Tag]
+			volume.Size = result.Size
+			volumes = append(volumes, volume)
+		}
+	}
+	scheduleOperations(ctx, reschedule...)
+	if len(volumes) == 0 {
+		return nil
+	}
+	errorResults, err := ctx.config.Volumes.SetVolumeInfo(volumesFromStorage(volumes))
+	if err != nil {
+		return errors.Annotate(err, "publishing volumes to state")
+	}
+	for i, result := range errorResults {
+		if result.Error != nil {
+			logger.Errorf(
+				"publishing volume %s to state: %v",
+				volumes[i].Tag.Id(),
+				result.Error,
+			)
+		}
+	}
+	for _, v := range volumes {
+		updateVolume(ctx, v)
+	}
+	return nil
+}
+
 // volumeParamsBySource separates the volume parameters by volume source.
 func volumeParamsBySource(
 	baseStorageDir string,
@@ -407,6 +481,164 @@ func volumeAttachmentParamsBySource(
 	return paramsBySource, volumeSources, nil
 }
 
+// createVolumeSnapshots takes volume snapshots with the specified
+// parameters.
+func createVolumeSnapshots(ctx *context, ops map[volumeSnapshotKey]*createVolumeSnapshotOp) error {
+	snapshotParams := make([]storage.VolumeSnapshotParams, 0, len(ops))
+	for _, op := range ops {
+		snapshotParams = append(snapshotParams, op.args)
+	}
+	paramsBySource, volumeSources, err := volumeSnapshotParamsBySource(
+		ctx.config.StorageDir, snapshotParams, ctx.config.Registry,
+	)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var reschedule []scheduleOp
+	var snapshots []storage.VolumeSnapshot
+	var statuses []params.VolumeSnapshotStatus
+	for sourceName, snapshotParams := range paramsBySource {
+		logger.Debugf("creating volume snapshots from %q: %v", sourceName, snapshotParams)
+		volumeSnapshotter, ok := volumeSources[sourceName].(storage.VolumeSnapshotter)
+		if !ok {
+			// The snapshot request remains pending, so that it
+			// will be picked up if the provider is upgraded.
+			err := errors.NotSupportedf("creating volume snapshots from source %q", sourceName)
+			logger.Warningf("cannot create volume snapshots %v: %v", snapshotParams, err)
+			for _, p := range snapshotParams {
+				statuses = append(statuses, params.VolumeSnapshotStatus{
+					Id:     p.Id,
+					Status: status.StatusError.String(),
+					Info:   err.Error(),
+				})
+			}
+			continue
+		}
+		// Providers find snapshots that were previously taken for
+		// the same Juju snapshot ID, so rescheduled requests do not
+		// take duplicate snapshots.
+		results, err := volumeSnapshotter.CreateVolumeSnapshots(snapshotParams)
+		if err != nil {
+			return errors.Annotatef(err, "creating volume snapshots from source %q", sourceName)
+		}
+		for i, result := range results {
+			p := snapshotParams[i]
+			if result.Error != nil {
+				reschedule = append(reschedule, ops[volumeSnapshotKey{p.Id}])
+				logger.Errorf(
+					"failed to create snapshot %q of %s: %v",
+					p.Id, names.ReadableString(p.Volume), result.Error,
+				)
+				statuses = append(statuses, params.VolumeSnapshotStatus{
+					Id:     p.Id,
+					Status: status.StatusError.String(),
+					Info:   result.Error.Error(),
+				})
+				continue
+			}
+			snapshots = append(snapshots, *result.VolumeSnapshot)
+		}
+	}
+	scheduleOperations(ctx, reschedule...)
+	if len(statuses) > 0 {
+		if err := setVolumeSnapshotStatus(ctx, statuses); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if len(snapshots) == 0 {
+		return nil
+	}
+	errorResults, err := ctx.config.Volumes.SetVolumeSnapshotInfo(
+		volumeSnapshotsFromStorage(snapshots),
+	)
+	if err != nil {
+		return errors.Annotate(err, "publishing volume snapshots to state")
+	}
+	for i, result := range errorResults {
+		if result.Error != nil {
+			logger.Errorf(
+				"publishing volume snapshot %q to state: %v",
+				snapshots[i].Id, result.Error,
+			)
+		}
+	}
+	return nil
+}
+
+// setVolumeSnapshotStatus sets the status of the specified volume
+// snapshots, logging any failures to do so.
+func setVolumeSnapshotStatus(ctx *context, statuses []params.VolumeSnapshotStatus) error {
+	errorResults, err := ctx.config.Volumes.SetVolumeSnapshotStatus(statuses)
+	if err != nil {
+		return errors.Annotate(err, "setting volume snapshot status")
+	}
+	for i, result := range errorResults {
+		if result.Error != nil {
+			logger.Errorf(
+				"setting status of volume snapshot %q: %v",
+				statuses[i].Id, result.Error,
+			)
+		}
+	}
+	return nil
+}
+
+// volumeSnapshotParamsBySource separates the volume snapshot parameters
+// by volume source. Non-dynamic volume sources are recorded as nil.
+func volumeSnapshotParamsBySource(
+	baseStorageDir string,
+	params []storage.VolumeSnapshotParams,
+	registry storage.ProviderRegistry,
+) (map[string][]storage.VolumeSnapshotParams, map[string]storage.VolumeSource, error) {
+	volumeSources := make(map[string]storage.VolumeSource)
+	paramsBySource := make(map[string][]storage.VolumeSnapshotParams)
+	for _, params := range params {
+		sourceName := string(params.Provider)
+		paramsBySource[sourceName] = append(paramsBySource[sourceName], params)
+		if _, ok := volumeSources[sourceName]; ok {
+			continue
+		}
+		volumeSource, err := volumeSource(
+			baseStorageDir, sourceName, params.Provider, registry,
+		)
+		if errors.Cause(err) == errNonDynamic {
+			volumeSource = nil
+		} else if err != nil {
+			return nil, nil, errors.Annotate(err, "getting volume source")
+		}
+		volumeSources[sourceName] = volumeSource
+	}
+	return paramsBySource, volumeSources, nil
+}
+
+// resizeVolumeParamsBySource separates the volume resize parameters by
+// volume source. Non-dynamic volume sources are recorded as nil.
+func resizeVolumeParamsBySource(
+	baseStorageDir string,
+	params []storage.ResizeVolumeParams,
+	registry storage.ProviderRegistry,
+) (map[string][]storage.ResizeVolumeParams, map[string]storage.VolumeSource, error) {
+	volumeSources := make(map[string]storage.VolumeSource)
+	paramsBySource := make(map[string][]storage.ResizeVolumeParams)
+	for _, params := range params {
+		sourceName := string(params.Provider)
+		paramsBySource[sourceName] = append(paramsBySource[sourceName], params)
+		if _, ok := volumeSources[sourceName]; ok {
+			continue
+		}
+		volumeSource, err := volumeSource(
+			baseStorageDir, sourceName, params.Provider, registry,
+		)
+		if errors.Cause(err) == errNonDynamic {
+			volumeSource = nil
+		} else if err != nil {
+			return nil, nil, errors.Annotate(err, "getting volume source")
+		}
+		volumeSources[sourceName] = volumeSource
+	}
+	return paramsBySource, volumeSources, nil
+}
+
 func setVolumeAttachmentInfo(ctx *context, volumeAttachments []storage.VolumeAttachment) error {
 	if len(volumeAttachments) == 0 {
 		return nil
@@ -481,3 +713,32 @@ func (op *detachVolumeOp) key() interface{} {
 		AttachmentTag: op.args.Volume.String(),
 	}
 }
+
+// volumeResizeKey is the schedule key for resizeVolumeOps; it is
+// distinct from the volume tag, which keys the other volume ops.
+type volumeResizeKey struct {
+	tag names.VolumeTag
+}
+
+type resizeVolumeOp struct {
+	exponentialBackoff
+	args storage.ResizeVolumeParams
+}
+
+func (op *resizeVolumeOp) key() interface{} {
+	return volumeResizeKey{op.args.Tag}
+}
+
+// volumeSnapshotKey is the schedule key for createVolumeSnapshotOps.
+type volumeSnapshotKey struct {
+	id string
+}
+
+type createVolumeSnapshotOp struct {
+	exponentialBackoff
+	args storage.VolumeSnapshotParams
+}
+
+func (op *createVolumeSnapshotOp) key() interface{} {
+	return volumeSnapshotKey{op.args.Id}
+}
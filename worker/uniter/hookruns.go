@@ -0,0 +1,99 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"net/http"
+	"os/exec"
+	"sync"
+	"syscall"
+
+	"github.com/juju/errors"
+	goyaml "gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/worker/uniter/operation"
+)
+
+// maxHookRuns is the number of hook and action runs retained by HookRuns.
+const maxHookRuns = 100
+
+// HookRuns holds a bounded history of the hooks and actions run by
+// the uniter since the agent started. It implements http.Handler,
+// so that the history can be served by the introspection worker.
+type HookRuns struct {
+	mu   sync.Mutex
+	runs []params.HookRun
+}
+
+// NewHookRuns returns a new, empty, HookRuns.
+func NewHookRuns() *HookRuns {
+	return &HookRuns{}
+}
+
+// Add records a hook or action run, discarding the oldest
+// record if the history is full.
+func (h *HookRuns) Add(run params.HookRun) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.runs = append(h.runs, run)
+	if len(h.runs) > maxHookRuns {
+		h.runs = h.runs[len(h.runs)-maxHookRuns:]
+	}
+}
+
+// Runs returns the recorded hook and action runs, most recent first.
+func (h *HookRuns) Runs() []params.HookRun {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	runs := make([]params.HookRun, len(h.runs))
+	for i, run := range h.runs {
+		runs[len(runs)-1-i] = run
+	}
+	return runs
+}
+
+// ServeHTTP is part of the http.Handler interface.
+func (h *HookRuns) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	data, err := goyaml.Marshal(h.Runs())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Write(data)
+}
+
+// hookRunParams converts an operation.HookRun into the form in
+// which it is reported.
+func hookRunParams(run operation.HookRun) params.HookRun {
+	result := params.HookRun{
+		Kind:     params.HookRunKindHook,
+		Name:     run.Name,
+		Started:  run.Started,
+		Finished: run.Finished,
+	}
+	if run.Kind == operation.RunAction {
+		result.Kind = params.HookRunKindAction
+	}
+	if run.Err != nil {
+		result.ExitCode = exitCode(run.Err)
+		result.Error = run.Err.Error()
+	}
+	return result
+}
+
+// exitCode returns the exit code of the process whose failure caused
+// err, or -1 if it cannot be determined.
+func exitCode(err error) int {
+	exitErr, ok := errors.Cause(err).(*exec.ExitError)
+	if !ok {
+		return -1
+	}
+	status, ok := exitErr.Sys().(syscall.WaitStatus)
+	if !ok {
+		return -1
+	}
+	return status.ExitStatus()
+}
@@ -0,0 +1,117 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os/exec"
+	"runtime"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	goyaml "gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/worker/uniter"
+	"github.com/juju/juju/worker/uniter/operation"
+)
+
+type HookRunsSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&HookRunsSuite{})
+
+func (s *HookRunsSuite) TestRunsMostRecentFirst(c *gc.C) {
+	hookRuns := uniter.NewHookRuns()
+	hookRuns.Add(params.HookRun{Kind: params.HookRunKindHook, Name: "install"})
+	hookRuns.Add(params.HookRun{Kind: params.HookRunKindAction, Name: "backup"})
+	c.Assert(hookRuns.Runs(), jc.DeepEquals, []params.HookRun{
+		{Kind: params.HookRunKindAction, Name: "backup"},
+		{Kind: params.HookRunKindHook, Name: "install"},
+	})
+}
+
+func (s *HookRunsSuite) TestRunsBounded(c *gc.C) {
+	hookRuns := uniter.NewHookRuns()
+	for i := 0; i < 150; i++ {
+		hookRuns.Add(params.HookRun{Kind: params.HookRunKindHook, Name: fmt.Sprintf("hook-%d", i)})
+	}
+	runs := hookRuns.Runs()
+	c.Assert(runs, gc.HasLen, 100)
+	c.Assert(runs[0].Name, gc.Equals, "hook-149")
+	c.Assert(runs[99].Name, gc.Equals, "hook-50")
+}
+
+func (s *HookRunsSuite) TestServeHTTP(c *gc.C) {
+	hookRuns := uniter.NewHookRuns()
+	started := time.Date(2016, 10, 1, 12, 0, 0, 0, time.UTC)
+	hookRuns.Add(params.HookRun{
+		Kind:     params.HookRunKindHook,
+		Name:     "install",
+		Started:  started,
+		Finished: started.Add(time.Second),
+		ExitCode: 1,
+		Error:    "exit status 1",
+	})
+	recorder := httptest.NewRecorder()
+	hookRuns.ServeHTTP(recorder, &http.Request{Method: "GET"})
+	c.Assert(recorder.Code, gc.Equals, http.StatusOK)
+
+	var runs []map[string]interface{}
+	err := goyaml.Unmarshal(recorder.Body.Bytes(), &runs)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(runs, gc.HasLen, 1)
+	c.Assert(runs[0]["kind"], gc.Equals, "hook")
+	c.Assert(runs[0]["name"], gc.Equals, "install")
+	c.Assert(runs[0]["exit-code"], gc.Equals, 1)
+	c.Assert(runs[0]["error"], gc.Equals, "exit status 1")
+}
+
+func (s *HookRunsSuite) TestHookRunParams(c *gc.C) {
+	started := time.Date(2016, 10, 1, 12, 0, 0, 0, time.UTC)
+	run := operation.HookRun{
+		Kind:     operation.RunAction,
+		Name:     "backup",
+		Started:  started,
+		Finished: started.Add(time.Minute),
+	}
+	c.Assert(uniter.HookRunParams(run), jc.DeepEquals, params.HookRun{
+		Kind:     params.HookRunKindAction,
+		Name:     "backup",
+		Started:  started,
+		Finished: started.Add(time.Minute),
+	})
+
+	run.Kind = operation.RunHook
+	run.Err = errors.New("boom")
+	c.Assert(uniter.HookRunParams(run), jc.DeepEquals, params.HookRun{
+		Kind:     params.HookRunKindHook,
+		Name:     "backup",
+		Started:  started,
+		Finished: started.Add(time.Minute),
+		ExitCode: -1,
+		Error:    "boom",
+	})
+}
+
+func (s *HookRunsSuite) TestHookRunParamsExitCode(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("test uses a shell")
+	}
+	err := exec.Command("/bin/sh", "-c", "exit 3").Run()
+	c.Assert(err, gc.FitsTypeOf, &exec.ExitError{})
+	result := uniter.HookRunParams(operation.HookRun{
+		Kind: operation.RunHook,
+		Name: "install",
+		Err:  errors.Trace(err),
+	})
+	c.Assert(result.ExitCode, gc.Equals, 3)
+	c.Assert(result.Error, gc.Equals, "exit status 3")
+}
@@ -29,6 +29,10 @@ type ManifoldConfig struct {
 	LeadershipTrackerName string
 	CharmDirName          string
 	HookRetryStrategyName string
+
+	// HookRuns, if not nil, records the hooks and actions run by
+	// the uniter.
+	HookRuns *HookRuns
 }
 
 // Manifold returns a dependency manifold that runs a uniter worker,
@@ -99,6 +103,7 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 				HookRetryStrategy:    hookRetryStrategy,
 				NewOperationExecutor: operation.NewExecutor,
 				Clock:                manifoldConfig.Clock,
+				HookRuns:             manifoldConfig.HookRuns,
 			})
 			if err != nil {
 				return nil, errors.Trace(err)
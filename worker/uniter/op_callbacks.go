@@ -15,6 +15,7 @@ import (
 	"github.com/juju/juju/status"
 	"github.com/juju/juju/worker/uniter/charm"
 	"github.com/juju/juju/worker/uniter/hook"
+	"github.com/juju/juju/worker/uniter/operation"
 	"github.com/juju/juju/worker/uniter/runner"
 )
 
@@ -34,7 +35,7 @@ func (opc *operationCallbacks) PrepareHook(hi hook.Info) (string, error) {
 		if err != nil {
 			return "", err
 		}
-	case hi.Kind.IsStorage():
+	case hook.IsStorage(hi.Kind):
 		if err := opc.u.storage.ValidateHook(hi); err != nil {
 			return "", err
 		}
@@ -60,7 +61,7 @@ func (opc *operationCallbacks) CommitHook(hi hook.Info) error {
 	switch {
 	case hi.Kind.IsRelation():
 		return opc.u.relations.CommitHook(hi)
-	case hi.Kind.IsStorage():
+	case hook.IsStorage(hi.Kind):
 		return opc.u.storage.CommitHook(hi)
 	}
 	return nil
@@ -91,6 +92,39 @@ func (opc *operationCallbacks) NotifyHookFailed(hook string, ctx runner.Context)
 	}
 }
 
+// RecordHookRun is part of the operation.Callbacks interface.
+func (opc *operationCallbacks) RecordHookRun(run operation.HookRun) error {
+	record := hookRunParams(run)
+	if opc.u.hookRuns != nil {
+		opc.u.hookRuns.Add(record)
+	}
+	err := opc.u.unit.RecordHookRun(record)
+	if errors.IsNotSupported(err) {
+		// Older controllers do not keep a hook run history.
+		logger.Debugf("not recording %s run: %v", run.Name, err)
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if run.Kind != operation.RunHook {
+		return nil
+	}
+	// Each hook run adds a single entry, carrying its duration, to
+	// the status history: the error status reported for a failed
+	// hook, or the idle status set as soon as a hook succeeds. The
+	// idle status set once there is nothing left to do is then
+	// unchanged, and is not recorded again.
+	duration := run.Finished.Sub(run.Started).String()
+	if run.Err != nil {
+		opc.u.failedHookDuration = duration
+		return nil
+	}
+	opc.u.failedHookDuration = ""
+	return setAgentStatus(opc.u, status.StatusIdle, "", map[string]interface{}{
+		"hook":     run.Name,
+		"duration": duration,
+	})
+}
+
 // FailAction is part of the operation.Callbacks interface.
 func (opc *operationCallbacks) FailAction(actionId, message string) error {
 	if !names.IsValidAction(actionId) {
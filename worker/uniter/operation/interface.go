@@ -4,6 +4,8 @@
 package operation
 
 import (
+	"time"
+
 	"github.com/juju/loggo"
 	utilexec "github.com/juju/utils/exec"
 	corecharm "gopkg.in/juju/charm.v6-unstable"
@@ -116,6 +118,22 @@ type CommandArgs struct {
 	ForceRemoteUnit bool
 }
 
+// HookRun describes a single execution of a hook or action.
+type HookRun struct {
+	// Kind is RunHook or RunAction.
+	Kind Kind
+
+	// Name is the name of the hook or action.
+	Name string
+
+	// Started and Finished record when execution began and ended.
+	Started  time.Time
+	Finished time.Time
+
+	// Err holds the error with which the hook or action failed, if any.
+	Err error
+}
+
 // CommandResponseFunc is for marshalling command responses back to the source
 // of the original request.
 type CommandResponseFunc func(*utilexec.ExecResponse, error)
@@ -138,6 +156,11 @@ type Callbacks interface {
 	NotifyHookCompleted(string, runner.Context)
 	NotifyHookFailed(string, runner.Context)
 
+	// RecordHookRun records the execution of a hook or action, and
+	// whether it failed. It's only used by RunHook and RunAction
+	// operations.
+	RecordHookRun(run HookRun) error
+
 	// The following methods exist primarily to allow us to test operation code
 	// without using a live api connection.
 
@@ -5,6 +5,7 @@ package operation
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/errors"
 
@@ -72,7 +73,9 @@ func (ra *runAction) Execute(state State) (*State, error) {
 		return nil, err
 	}
 
+	started := time.Now()
 	err := ra.runner.RunAction(ra.name)
+	ra.recordRun(started, err)
 	if err != nil {
 		// This indicates an actual error -- an action merely failing should
 		// be handled inside the Runner, and returned as nil.
@@ -86,6 +89,28 @@ func (ra *runAction) Execute(state State) (*State, error) {
 	}.apply(state), nil
 }
 
+// recordRun records the execution of the action. An action that failed
+// inside the runner is recorded with the failure message it reported.
+// Failure to record is logged, but does not affect the outcome of the
+// operation.
+func (ra *runAction) recordRun(started time.Time, runErr error) {
+	run := HookRun{
+		Kind:     RunAction,
+		Name:     ra.name,
+		Started:  started,
+		Finished: time.Now(),
+		Err:      runErr,
+	}
+	if runErr == nil {
+		if actionData, err := ra.runner.Context().ActionData(); err == nil && actionData.Failed {
+			run.Err = errors.New(actionData.ResultsMessage)
+		}
+	}
+	if err := ra.callbacks.RecordHookRun(run); err != nil {
+		logger.Errorf("cannot record %q action run: %v", ra.name, err)
+	}
+}
+
 // Commit preserves the recorded hook, and returns a neutral state.
 // Commit is part of the Operation interface.
 func (ra *runAction) Commit(state State) (*State, error) {
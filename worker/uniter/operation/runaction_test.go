@@ -227,6 +227,47 @@ func (s *RunActionSuite) TestExecuteSuccess(c *gc.C) {
 	}
 }
 
+func (s *RunActionSuite) TestExecuteRecordsActionRun(c *gc.C) {
+	runnerFactory := NewRunActionRunnerFactory(nil)
+	callbacks := &RunActionCallbacks{MockRecordHookRun: &MockRecordHookRun{}}
+	factory := operation.NewFactory(operation.FactoryParams{
+		RunnerFactory: runnerFactory,
+		Callbacks:     callbacks,
+	})
+	op, err := factory.NewAction(someActionId)
+	c.Assert(err, jc.ErrorIsNil)
+	midState, err := op.Prepare(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = op.Execute(*midState)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(callbacks.MockRecordHookRun.gotKind, gc.Equals, operation.RunAction)
+	c.Assert(*callbacks.MockRecordHookRun.gotName, gc.Equals, "some-action-name")
+	c.Assert(callbacks.MockRecordHookRun.gotDuration >= 0, jc.IsTrue)
+	c.Assert(callbacks.MockRecordHookRun.gotErr, gc.IsNil)
+}
+
+func (s *RunActionSuite) TestExecuteRecordsFailedActionRun(c *gc.C) {
+	runnerFactory := NewRunActionRunnerFactory(nil)
+	actionData, err := runnerFactory.MockNewActionRunner.runner.Context().ActionData()
+	c.Assert(err, jc.ErrorIsNil)
+	actionData.Failed = true
+	actionData.ResultsMessage = "exit status 2"
+	callbacks := &RunActionCallbacks{MockRecordHookRun: &MockRecordHookRun{}}
+	factory := operation.NewFactory(operation.FactoryParams{
+		RunnerFactory: runnerFactory,
+		Callbacks:     callbacks,
+	})
+	op, err := factory.NewAction(someActionId)
+	c.Assert(err, jc.ErrorIsNil)
+	midState, err := op.Prepare(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = op.Execute(*midState)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(callbacks.MockRecordHookRun.gotErr, gc.ErrorMatches, "exit status 2")
+}
+
 func (s *RunActionSuite) TestCommit(c *gc.C) {
 	var stateChangeTests = []struct {
 		description string
@@ -5,6 +5,7 @@ package operation
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/errors"
 	"gopkg.in/juju/charm.v6-unstable/hooks"
@@ -38,7 +39,7 @@ func (rh *runHook) String() string {
 		} else {
 			suffix = fmt.Sprintf(" (%d; %s)", rh.info.RelationId, rh.info.RemoteUnit)
 		}
-	case rh.info.Kind.IsStorage():
+	case hook.IsStorage(rh.info.Kind):
 		suffix = fmt.Sprintf(" (%s)", rh.info.StorageId)
 	}
 	return fmt.Sprintf("run %s%s hook", rh.info.Kind, suffix)
@@ -91,7 +92,9 @@ func (rh *runHook) Execute(state State) (*State, error) {
 	ranHook := true
 	step := Done
 
+	started := time.Now()
 	err := rh.runner.RunHook(rh.name)
+	duration := time.Since(started)
 	cause := errors.Cause(err)
 	switch {
 	case context.IsMissingHookError(cause):
@@ -104,13 +107,25 @@ func (rh *runHook) Execute(state State) (*State, error) {
 		err = ErrNeedsReboot
 	case err == nil:
 	default:
-		logger.Errorf("hook %q failed: %v", rh.name, err)
+		logger.Errorf("hook %q failed after %v: %v", rh.name, duration, err)
+		rh.recordRun(started, duration, err)
 		rh.callbacks.NotifyHookFailed(rh.name, rh.runner.Context())
-		return nil, ErrHookFailed
+		if !runner.IsHookTimedOutError(err) {
+			return nil, ErrHookFailed
+		}
+		// Record the timeout, so that it can be reported
+		// for as long as the unit remains in error.
+		return stateChange{
+			Kind:         RunHook,
+			Step:         Pending,
+			Hook:         &rh.info,
+			HookTimedOut: true,
+		}.apply(state), ErrHookFailed
 	}
 
 	if ranHook {
-		logger.Infof("ran %q hook", rh.name)
+		logger.Infof("ran %q hook in %v", rh.name, duration)
+		rh.recordRun(started, duration, nil)
 		rh.callbacks.NotifyHookCompleted(rh.name, rh.runner.Context())
 	} else {
 		logger.Infof("skipped %q hook (missing)", rh.name)
@@ -129,6 +144,21 @@ func (rh *runHook) Execute(state State) (*State, error) {
 	}.apply(state), err
 }
 
+// recordRun records the execution of the hook. Failure to record
+// is logged, but does not affect the outcome of the operation.
+func (rh *runHook) recordRun(started time.Time, duration time.Duration, runErr error) {
+	err := rh.callbacks.RecordHookRun(HookRun{
+		Kind:     RunHook,
+		Name:     rh.name,
+		Started:  started,
+		Finished: started.Add(duration),
+		Err:      runErr,
+	})
+	if err != nil {
+		logger.Errorf("cannot record %q hook run: %v", rh.name, err)
+	}
+}
+
 func (rh *runHook) beforeHook() error {
 	var err error
 	switch rh.info.Kind {
@@ -90,6 +90,10 @@ type State struct {
 	// RunAction, it holds the running action.
 	ActionId *string `yaml:"action-id,omitempty"`
 
+	// HookTimedOut indicates that the hook described by Hook was killed
+	// because it did not complete within its timeout.
+	HookTimedOut bool `yaml:"hook-timed-out,omitempty"`
+
 	// Charm describes the charm being deployed by an Install or Upgrade
 	// operation, and is otherwise blank.
 	CharmURL *charm.URL `yaml:"charm,omitempty"`
@@ -165,6 +169,7 @@ type stateChange struct {
 	ActionId        *string
 	CharmURL        *charm.URL
 	HasRunStatusSet bool
+	HookTimedOut    bool
 }
 
 func (change stateChange) apply(state State) *State {
@@ -173,6 +178,7 @@ func (change stateChange) apply(state State) *State {
 	state.Hook = change.Hook
 	state.ActionId = change.ActionId
 	state.CharmURL = change.CharmURL
+	state.HookTimedOut = change.HookTimedOut
 	state.StatusSet = state.StatusSet || change.HasRunStatusSet
 	return &state
 }
@@ -4,6 +4,8 @@
 package operation_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	utilexec "github.com/juju/utils/exec"
@@ -115,7 +117,15 @@ func (mock *MockFailAction) Call(actionId, message string) error {
 type RunActionCallbacks struct {
 	operation.Callbacks
 	*MockFailAction
-	executingMessage string
+	MockRecordHookRun *MockRecordHookRun
+	executingMessage  string
+}
+
+func (cb *RunActionCallbacks) RecordHookRun(run operation.HookRun) error {
+	if cb.MockRecordHookRun == nil {
+		return nil
+	}
+	return cb.MockRecordHookRun.Call(run)
 }
 
 func (cb *RunActionCallbacks) FailAction(actionId, message string) error {
@@ -173,10 +183,31 @@ func (mock *MockNotify) Call(hookName string, ctx runner.Context) {
 	mock.gotContext = &ctx
 }
 
+type MockRecordHookRun struct {
+	gotKind     operation.Kind
+	gotName     *string
+	gotDuration time.Duration
+	gotErr      error
+	err         error
+}
+
+func (mock *MockRecordHookRun) Call(run operation.HookRun) error {
+	mock.gotKind = run.Kind
+	mock.gotName = &run.Name
+	mock.gotDuration = run.Finished.Sub(run.Started)
+	mock.gotErr = run.Err
+	return mock.err
+}
+
 type ExecuteHookCallbacks struct {
 	*PrepareHookCallbacks
 	MockNotifyHookCompleted *MockNotify
 	MockNotifyHookFailed    *MockNotify
+	MockRecordHookRun       *MockRecordHookRun
+}
+
+func (cb *ExecuteHookCallbacks) RecordHookRun(run operation.HookRun) error {
+	return cb.MockRecordHookRun.Call(run)
 }
 
 func (cb *ExecuteHookCallbacks) NotifyHookCompleted(hookName string, ctx runner.Context) {
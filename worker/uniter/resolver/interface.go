@@ -51,6 +51,38 @@ type Resolver interface {
 	) (operation.Operation, error)
 }
 
+// StepDecision describes what the resolver loop should do with an
+// operation that has been presented to a Stepper.
+type StepDecision string
+
+const (
+	// StepRun causes the operation to be run.
+	StepRun StepDecision = "run"
+
+	// StepSkip causes the operation to be dropped; the loop will
+	// not run any more operations until the remote state changes.
+	StepSkip StepDecision = "skip"
+
+	// StepRetry causes a failed operation to be run again.
+	StepRetry StepDecision = "retry"
+)
+
+// Stepper instances are consulted by the resolver loop before each
+// operation is run, so that a user may interactively step through
+// the operations chosen by the Resolver.
+type Stepper interface {
+	// Step is called with the operation about to be run and the
+	// remote state snapshot that caused it to be chosen. If running
+	// the operation fails, Step is called again with the error; only
+	// StepRetry will cause the operation to be run again, any other
+	// decision causes the error to be returned from the loop as usual.
+	Step(
+		op operation.Operation,
+		remoteState remotestate.Snapshot,
+		runErr error,
+	) (StepDecision, error)
+}
+
 // LocalState is a cache of the state of the local unit, as needed by the
 // Uniter. It is generally compared to the remote state of the expected state of
 // the unit as stored in the controller.
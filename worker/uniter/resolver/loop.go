@@ -25,6 +25,10 @@ type LoopConfig struct {
 	Abort         <-chan struct{}
 	OnIdle        func() error
 	CharmDirGuard fortress.Guard
+
+	// Stepper, if non-nil, is consulted before each operation
+	// is run, and again if the operation fails.
+	Stepper Stepper
 }
 
 // Loop repeatedly waits for remote state changes, feeding the local and
@@ -46,6 +50,9 @@ type LoopConfig struct {
 //    state has changed again
 //  - if the resolver, onIdle, or executor return some other
 //    error, the loop will exit immediately
+//  - if a stepper is configured and it decides to skip an
+//    operation, no operations will be executed until the
+//    remote state has changed again
 func Loop(cfg LoopConfig, localState *LocalState) error {
 	rf := &resolverOpFactory{Factory: cfg.Factory, LocalState: localState}
 
@@ -61,10 +68,17 @@ func Loop(cfg LoopConfig, localState *LocalState) error {
 
 		op, err := cfg.Resolver.NextOp(*rf.LocalState, rf.RemoteState, rf)
 		for err == nil {
-			logger.Tracef("running op: %v", op)
-			if err := cfg.Executor.Run(op); err != nil {
+			var skipped bool
+			skipped, err = runOp(cfg, op, rf.RemoteState)
+			if err != nil {
 				return errors.Trace(err)
 			}
+			if skipped {
+				// Don't ask the resolver again until something
+				// has changed, or it will just pick the same op.
+				err = ErrWaiting
+				break
+			}
 			// Refresh snapshot, in case remote state
 			// changed between operations.
 			rf.RemoteState = cfg.Watcher.Snapshot()
@@ -101,6 +115,34 @@ func Loop(cfg LoopConfig, localState *LocalState) error {
 	}
 }
 
+// runOp runs the supplied operation with the configured executor,
+// consulting the configured stepper (if any) before running it and
+// again if it fails. It reports whether the operation was skipped.
+func runOp(cfg LoopConfig, op operation.Operation, remoteState remotestate.Snapshot) (bool, error) {
+	if cfg.Stepper == nil {
+		logger.Tracef("running op: %v", op)
+		return false, cfg.Executor.Run(op)
+	}
+	var runErr error
+	for {
+		decision, err := cfg.Stepper.Step(op, remoteState, runErr)
+		if err != nil {
+			return false, errors.Annotatef(err, "stepping through %v", op)
+		}
+		switch {
+		case runErr != nil && decision != StepRetry:
+			return false, runErr
+		case runErr == nil && decision == StepSkip:
+			logger.Infof("skipping op: %v", op)
+			return true, nil
+		}
+		logger.Tracef("running op: %v", op)
+		if runErr = cfg.Executor.Run(op); runErr == nil {
+			return false, nil
+		}
+	}
+}
+
 // updateCharmDir sets charm directory availability for sharing among
 // concurrent workers according to local operation state.
 func updateCharmDir(opState operation.State, guard fortress.Guard, abort fortress.Abort) error {
@@ -28,6 +28,7 @@ type LoopSuite struct {
 	charmURL  *charm.URL
 	abort     chan struct{}
 	onIdle    func() error
+	stepper   resolver.Stepper
 }
 
 var _ = gc.Suite(&LoopSuite{})
@@ -58,6 +59,7 @@ func (s *LoopSuite) loop() (resolver.LocalState, error) {
 		Abort:         s.abort,
 		OnIdle:        s.onIdle,
 		CharmDirGuard: &mockCharmDirGuard{},
+		Stepper:       s.stepper,
 	}, &localState)
 	return localState, err
 }
@@ -204,6 +206,94 @@ func (s *LoopSuite) TestNextOpFails(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "NextOp fails")
 }
 
+func (s *LoopSuite) TestStepperRun(c *gc.C) {
+	theOp := &mockOp{}
+	s.resolver = onceResolver(theOp, s.abort)
+	stepper := &mockStepper{decisions: []resolver.StepDecision{resolver.StepRun}}
+	s.stepper = stepper
+
+	_, err := s.loop()
+	c.Assert(err, gc.Equals, resolver.ErrLoopAborted)
+	stepper.CheckCallNames(c, "Step")
+	stepper.CheckCall(c, 0, "Step", theOp, remotestate.Snapshot{}, nil)
+	s.executor.CheckCallNames(c, "State", "State", "Run", "State")
+}
+
+func (s *LoopSuite) TestStepperSkip(c *gc.C) {
+	var resolverCalls int
+	s.resolver = resolver.ResolverFunc(func(
+		_ resolver.LocalState,
+		_ remotestate.Snapshot,
+		_ operation.Factory,
+	) (operation.Operation, error) {
+		resolverCalls++
+		close(s.abort)
+		return &mockOp{}, nil
+	})
+	s.stepper = &mockStepper{decisions: []resolver.StepDecision{resolver.StepSkip}}
+
+	_, err := s.loop()
+	c.Assert(err, gc.Equals, resolver.ErrLoopAborted)
+	c.Assert(resolverCalls, gc.Equals, 1)
+	s.executor.CheckCallNames(c, "State", "State")
+}
+
+func (s *LoopSuite) TestStepperRetry(c *gc.C) {
+	theOp := &mockOp{}
+	s.resolver = onceResolver(theOp, s.abort)
+	s.executor.SetErrors(errors.New("Run fails"))
+	stepper := &mockStepper{decisions: []resolver.StepDecision{
+		resolver.StepRun, resolver.StepRetry,
+	}}
+	s.stepper = stepper
+
+	_, err := s.loop()
+	c.Assert(err, gc.Equals, resolver.ErrLoopAborted)
+	stepper.CheckCallNames(c, "Step", "Step")
+	c.Assert(stepper.Calls()[1].Args[2], gc.ErrorMatches, "Run fails")
+	s.executor.CheckCallNames(c, "State", "State", "Run", "Run", "State")
+}
+
+func (s *LoopSuite) TestStepperNoRetry(c *gc.C) {
+	s.resolver = onceResolver(&mockOp{}, s.abort)
+	s.executor.SetErrors(errors.New("Run fails"))
+	s.stepper = &mockStepper{decisions: []resolver.StepDecision{
+		resolver.StepRun, resolver.StepRun,
+	}}
+
+	_, err := s.loop()
+	c.Assert(err, gc.ErrorMatches, "Run fails")
+}
+
+func (s *LoopSuite) TestStepperFails(c *gc.C) {
+	s.resolver = onceResolver(&mockOp{}, s.abort)
+	stepper := &mockStepper{}
+	stepper.SetErrors(errors.New("Step fails"))
+	s.stepper = stepper
+
+	_, err := s.loop()
+	c.Assert(err, gc.ErrorMatches, "stepping through .*: Step fails")
+	s.executor.CheckCallNames(c, "State", "State")
+}
+
+// onceResolver returns a resolver that returns the supplied
+// operation on the first call, and then closes abort.
+func onceResolver(op operation.Operation, abort chan struct{}) resolver.Resolver {
+	var called bool
+	return resolver.ResolverFunc(func(
+		_ resolver.LocalState,
+		_ remotestate.Snapshot,
+		_ operation.Factory,
+	) (operation.Operation, error) {
+		if !called {
+			called = true
+			return op, nil
+		}
+		close(abort)
+		return nil, resolver.ErrNoOperation
+	})
+}
+
 func waitChannel(c *gc.C, ch <-chan interface{}, activity string) interface{} {
 	select {
 	case v := <-ch:
@@ -11,6 +11,7 @@ import (
 	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/operation"
 	"github.com/juju/juju/worker/uniter/remotestate"
+	"github.com/juju/juju/worker/uniter/resolver"
 )
 
 type mockRemoteStateWatcher struct {
@@ -79,6 +80,21 @@ func (e *mockOpExecutor) Run(op operation.Operation) error {
 	return e.NextErr()
 }
 
+type mockStepper struct {
+	testing.Stub
+	decisions []resolver.StepDecision
+}
+
+func (s *mockStepper) Step(op operation.Operation, remoteState remotestate.Snapshot, runErr error) (resolver.StepDecision, error) {
+	s.MethodCall(s, "Step", op, remoteState, runErr)
+	if err := s.NextErr(); err != nil {
+		return "", err
+	}
+	decision := s.decisions[0]
+	s.decisions = s.decisions[1:]
+	return decision, nil
+}
+
 type mockOp struct {
 	operation.Operation
 	commit func(operation.State) (*operation.State, error)
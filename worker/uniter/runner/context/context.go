@@ -159,6 +159,10 @@ type HookContext struct {
 	// proxySettings are the current proxy settings that the uniter knows about.
 	proxySettings proxy.Settings
 
+	// hookTimeout is the model's limit on how long a hook may run;
+	// zero means that there is no limit.
+	hookTimeout time.Duration
+
 	// meterStatus is the status of the unit's metering.
 	meterStatus *meterStatus
 
@@ -384,6 +388,12 @@ func (ctx *HookContext) ResetExecutionSetUnitStatus() {
 	ctx.hasRunStatusSet = false
 }
 
+// HookTimeout returns the model's limit on how long a hook may run,
+// or zero if there is no limit.
+func (ctx *HookContext) HookTimeout() time.Duration {
+	return ctx.hookTimeout
+}
+
 func (ctx *HookContext) PublicAddress() (string, error) {
 	if ctx.publicAddress == "" {
 		return "", errors.NotFoundf("public address")
@@ -435,7 +445,18 @@ func (ctx *HookContext) AddUnitStorage(cons map[string]params.StorageConstraints
 
 func (ctx *HookContext) OpenPorts(protocol string, fromPort, toPort int) error {
 	return tryOpenPorts(
-		protocol, fromPort, toPort,
+		"", protocol, fromPort, toPort,
+		ctx.unit.Tag(),
+		ctx.machinePorts, ctx.pendingPorts,
+	)
+}
+
+func (ctx *HookContext) OpenEndpointPorts(endpoint, protocol string, fromPort, toPort int) error {
+	if endpoint == "" {
+		return errors.NotValidf("empty endpoint")
+	}
+	return tryOpenPorts(
+		endpoint, protocol, fromPort, toPort,
 		ctx.unit.Tag(),
 		ctx.machinePorts, ctx.pendingPorts,
 	)
@@ -676,7 +697,15 @@ func (ctx *HookContext) Flush(process string, ctxErr error) (err error) {
 		if writeChanges {
 			var e error
 			var op string
-			if rangeInfo.ShouldOpen {
+			if rangeInfo.ShouldOpen && rangeInfo.Endpoint != "" {
+				e = ctx.unit.OpenEndpointPorts(
+					rangeInfo.Endpoint,
+					rangeKey.Ports.Protocol,
+					rangeKey.Ports.FromPort,
+					rangeKey.Ports.ToPort,
+				)
+				op = "open"
+			} else if rangeInfo.ShouldOpen {
 				e = ctx.unit.OpenPorts(
 					rangeKey.Ports.Protocol,
 					rangeKey.Ports.FromPort,
@@ -19,6 +19,10 @@ import (
 type PortRangeInfo struct {
 	ShouldOpen  bool
 	RelationTag names.RelationTag
+
+	// Endpoint is the endpoint a range pending to be opened is
+	// opened for; empty means all endpoints.
+	Endpoint string
 }
 
 // PortRange contains a port range and a relation id. Used as key to
@@ -42,7 +46,7 @@ func validatePortRange(protocol string, fromPort, toPort int) (network.PortRange
 }
 
 func tryOpenPorts(
-	protocol string,
+	endpoint, protocol string,
 	fromPort, toPort int,
 	unitTag names.UnitTag,
 	machinePorts map[network.PortRange]params.RelationUnit,
@@ -65,10 +69,12 @@ func tryOpenPorts(
 
 	rangeInfo, isKnown := pendingPorts[rangeKey]
 	if isKnown {
-		if !rangeInfo.ShouldOpen {
+		if !rangeInfo.ShouldOpen || rangeInfo.Endpoint != endpoint {
 			// If the same range is already pending to be closed, just
-			// mark is pending to be opened.
+			// mark is pending to be opened. The last endpoint given
+			// for a pending range wins.
 			rangeInfo.ShouldOpen = true
+			rangeInfo.Endpoint = endpoint
 			pendingPorts[rangeKey] = rangeInfo
 		}
 		return nil
@@ -109,6 +115,7 @@ func tryOpenPorts(
 
 	rangeInfo = pendingPorts[rangeKey]
 	rangeInfo.ShouldOpen = true
+	rangeInfo.Endpoint = endpoint
 	pendingPorts[rangeKey] = rangeInfo
 	return nil
 }
@@ -133,8 +133,20 @@ func makePendingPorts(
 	return result
 }
 
+func makeEndpointPendingPorts(
+	endpoint, proto string, fromPort, toPort int,
+) map[context.PortRange]context.PortRangeInfo {
+	result := makePendingPorts(proto, fromPort, toPort, true)
+	for key, info := range result {
+		info.Endpoint = endpoint
+		result[key] = info
+	}
+	return result
+}
+
 type portsTest struct {
 	about         string
+	endpoint      string
 	proto         string
 	ports         []int
 	machinePorts  map[network.PortRange]params.RelationUnit
@@ -168,6 +180,15 @@ func (s *PortsSuite) TestTryOpenPorts(c *gc.C) {
 	}, {
 		about:         "open a new range (no machine ports yet)",
 		expectPending: makePendingPorts("tcp", 10, 20, true),
+	}, {
+		about:         "open a new range for an endpoint",
+		endpoint:      "db",
+		expectPending: makeEndpointPendingPorts("db", "tcp", 10, 20),
+	}, {
+		about:         "open a range pending to be opened for another endpoint",
+		endpoint:      "db",
+		pendingPorts:  makePendingPorts("tcp", 10, 20, true),
+		expectPending: makeEndpointPendingPorts("db", "tcp", 10, 20),
 	}, {
 		about:         "open an existing range (ignored)",
 		machinePorts:  makeMachinePorts("u/0", "tcp", 10, 20),
@@ -202,6 +223,7 @@ func (s *PortsSuite) TestTryOpenPorts(c *gc.C) {
 
 		test = test.withDefaults("tcp", 10, 20)
 		err := context.TryOpenPorts(
+			test.endpoint,
 			test.proto,
 			test.ports[0],
 			test.ports[1],
@@ -0,0 +1,176 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package debug
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	goyaml "gopkg.in/yaml.v2"
+)
+
+// ErrStepAborted is returned by StepSession.Prompt when the supplied
+// abort channel is signalled before the client makes a decision.
+var ErrStepAborted = errors.New("step prompt aborted")
+
+// StepRequest describes an operation that the uniter is about
+// to run, and which is presented to a "juju debug-hooks --step"
+// client for a decision.
+type StepRequest struct {
+	// Operation is a description of the operation to be run.
+	Operation string `yaml:"operation"`
+
+	// RemoteState is a rendering of the remote state snapshot
+	// that caused the operation to be chosen.
+	RemoteState string `yaml:"remote-state,omitempty"`
+
+	// Error holds the error from the last attempt to run the
+	// operation, if it failed.
+	Error string `yaml:"error,omitempty"`
+}
+
+// Valid step decisions, as written by the client script.
+const (
+	StepRun   = "run"
+	StepSkip  = "skip"
+	StepRetry = "retry"
+)
+
+// stepPollInterval is the interval at which the server checks
+// for a response from the client. This is a var so it can be
+// replaced for testing.
+var stepPollInterval = time.Second
+
+// StepDir returns the directory through which the uniter and a
+// "juju debug-hooks --step" client exchange requests and decisions.
+// The directory exists for exactly as long as the client is connected.
+func (c *HooksContext) StepDir() string {
+	return c.ClientFileLock() + "-step"
+}
+
+func (c *HooksContext) stepRequestFile() string {
+	return filepath.Join(c.StepDir(), "request")
+}
+
+func (c *HooksContext) stepResponseFile() string {
+	return filepath.Join(c.StepDir(), "response")
+}
+
+// StepSession represents a "juju debug-hooks --step" session.
+type StepSession struct {
+	*HooksContext
+}
+
+// FindStepSession attempts to find a stepping session for the unit
+// specified in the context. It returns nil, without error, if there
+// is no client connected.
+func (c *HooksContext) FindStepSession() (*StepSession, error) {
+	info, err := os.Stat(c.StepDir())
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !info.IsDir() {
+		return nil, errors.Errorf("%q is not a directory", c.StepDir())
+	}
+	return &StepSession{c}, nil
+}
+
+// Prompt presents the request to the client, and waits for it to
+// respond with one of StepRun, StepSkip or StepRetry. If the client
+// goes away while the server is waiting, the operation is run as if
+// there had been no session.
+func (s *StepSession) Prompt(req StepRequest, abort <-chan struct{}) (string, error) {
+	data, err := goyaml.Marshal(req)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	responseFile := s.stepResponseFile()
+	if err := os.Remove(responseFile); err != nil && !os.IsNotExist(err) {
+		return "", errors.Trace(err)
+	}
+	requestFile := s.stepRequestFile()
+	if err := ioutil.WriteFile(requestFile, data, 0600); err != nil {
+		return "", errors.Trace(err)
+	}
+	defer os.Remove(requestFile)
+	for {
+		response, err := ioutil.ReadFile(responseFile)
+		switch {
+		case err == nil:
+			os.Remove(responseFile)
+			return parseStepResponse(response)
+		case !os.IsNotExist(err):
+			return "", errors.Trace(err)
+		}
+		if _, err := os.Stat(s.StepDir()); os.IsNotExist(err) {
+			return StepRun, nil
+		}
+		select {
+		case <-abort:
+			return "", ErrStepAborted
+		case <-time.After(stepPollInterval):
+		}
+	}
+}
+
+func parseStepResponse(response []byte) (string, error) {
+	decision := strings.TrimSpace(string(response))
+	switch decision {
+	case StepRun, StepSkip, StepRetry:
+		return decision, nil
+	}
+	return "", errors.NotValidf("step decision %q", decision)
+}
+
+// StepClientScript returns a bash script suitable for executing on
+// the unit system to step through the operations run by the uniter.
+func StepClientScript(c *HooksContext) string {
+	s := strings.Replace(debugStepClientScript, "{step_dir}", c.StepDir(), -1)
+	s = strings.Replace(s, "{exit_flock}", c.ClientExitFileLock(), -1)
+	return s
+}
+
+const debugStepClientScript = `#!/bin/bash
+(
+# Lock the juju-<unit>-debug-hooks-exit lockfile, so that only
+# one client can step through operations at a time.
+flock -n 9 || {
+	echo "Found an existing debug session for this unit" >&2
+	exit 1
+}
+mkdir -p {step_dir}
+trap 'rm -rf {step_dir}' EXIT
+
+echo "Waiting for the next operation; press CTRL+c to stop stepping."
+while true; do
+	if [ ! -f {step_dir}/request ]; then
+		sleep 1
+		continue
+	fi
+	echo
+	cat {step_dir}/request
+	decision=""
+	while true; do
+		read -p "run, skip or retry? [run] " decision < /dev/tty || exit 0
+		case "${decision:-run}" in
+		run|skip|retry)
+			break
+			;;
+		esac
+	done
+	echo "${decision:-run}" > {step_dir}/response.tmp
+	mv {step_dir}/response.tmp {step_dir}/response
+	while [ -f {step_dir}/response ]; do
+		sleep 1
+	done
+done
+) 9>{exit_flock}
+exit $?
+`
@@ -0,0 +1,139 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package debug
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	goyaml "gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/testing"
+)
+
+type DebugStepSuite struct {
+	testing.BaseSuite
+	ctx *HooksContext
+}
+
+var _ = gc.Suite(&DebugStepSuite{})
+
+func (s *DebugStepSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.ctx = NewHooksContext("foo/8")
+	s.ctx.FlockDir = c.MkDir()
+	s.PatchValue(&stepPollInterval, time.Millisecond)
+}
+
+func (s *DebugStepSuite) startSession(c *gc.C) *StepSession {
+	err := os.Mkdir(s.ctx.StepDir(), 0700)
+	c.Assert(err, jc.ErrorIsNil)
+	session, err := s.ctx.FindStepSession()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(session, gc.NotNil)
+	return session
+}
+
+// respond waits for a request to be written, and answers it
+// with the supplied decision, returning the request seen.
+func (s *DebugStepSuite) respond(c *gc.C, decision string) <-chan StepRequest {
+	ch := make(chan StepRequest, 1)
+	go func() {
+		for {
+			data, err := ioutil.ReadFile(s.ctx.stepRequestFile())
+			if err == nil {
+				var req StepRequest
+				c.Check(goyaml.Unmarshal(data, &req), jc.ErrorIsNil)
+				tmpFile := s.ctx.stepResponseFile() + ".tmp"
+				c.Check(ioutil.WriteFile(tmpFile, []byte(decision+"\n"), 0600), jc.ErrorIsNil)
+				c.Check(os.Rename(tmpFile, s.ctx.stepResponseFile()), jc.ErrorIsNil)
+				ch <- req
+				return
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}()
+	return ch
+}
+
+func (s *DebugStepSuite) TestFindStepSessionNoClient(c *gc.C) {
+	session, err := s.ctx.FindStepSession()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(session, gc.IsNil)
+}
+
+func (s *DebugStepSuite) TestFindStepSessionNotDir(c *gc.C) {
+	err := ioutil.WriteFile(s.ctx.StepDir(), nil, 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.ctx.FindStepSession()
+	c.Assert(err, gc.ErrorMatches, `".*-step" is not a directory`)
+}
+
+func (s *DebugStepSuite) TestPrompt(c *gc.C) {
+	session := s.startSession(c)
+	for _, decision := range []string{StepRun, StepSkip, StepRetry} {
+		seen := s.respond(c, decision)
+		req := StepRequest{
+			Operation:   "run install hook",
+			RemoteState: "life: alive\n",
+			Error:       "boom",
+		}
+		result, err := session.Prompt(req, nil)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(result, gc.Equals, decision)
+		c.Assert(<-seen, jc.DeepEquals, req)
+
+		// Both request and response are cleaned up.
+		_, err = os.Stat(s.ctx.stepRequestFile())
+		c.Assert(err, jc.Satisfies, os.IsNotExist)
+		_, err = os.Stat(s.ctx.stepResponseFile())
+		c.Assert(err, jc.Satisfies, os.IsNotExist)
+	}
+}
+
+func (s *DebugStepSuite) TestPromptInvalidResponse(c *gc.C) {
+	session := s.startSession(c)
+	s.respond(c, "explode")
+	_, err := session.Prompt(StepRequest{Operation: "foo"}, nil)
+	c.Assert(err, gc.ErrorMatches, `step decision "explode" not valid`)
+}
+
+func (s *DebugStepSuite) TestPromptClientGone(c *gc.C) {
+	// If the client goes away while we're waiting,
+	// the operation is run.
+	session := s.startSession(c)
+	go func() {
+		for {
+			if _, err := os.Stat(s.ctx.stepRequestFile()); err == nil {
+				c.Check(os.RemoveAll(s.ctx.StepDir()), jc.ErrorIsNil)
+				return
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}()
+	result, err := session.Prompt(StepRequest{Operation: "foo"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.Equals, StepRun)
+}
+
+func (s *DebugStepSuite) TestPromptAborted(c *gc.C) {
+	session := s.startSession(c)
+	abort := make(chan struct{})
+	close(abort)
+	_, err := session.Prompt(StepRequest{Operation: "foo"}, abort)
+	c.Assert(err, gc.Equals, ErrStepAborted)
+}
+
+func (s *DebugStepSuite) TestStepClientScript(c *gc.C) {
+	result := StepClientScript(s.ctx)
+	c.Assert(result, gc.Not(gc.Matches), "(.|\n)*{step_dir}(.|\n)*")
+	c.Assert(result, gc.Not(gc.Matches), "(.|\n)*{exit_flock}(.|\n)*")
+	c.Assert(result, gc.Matches, fmt.Sprintf("(.|\n)*mkdir -p %s\n(.|\n)*", regexp.QuoteMeta(s.ctx.StepDir())))
+	c.Assert(result, gc.Matches, fmt.Sprintf("(.|\n)*\\) 9>%s(.|\n)*", regexp.QuoteMeta(s.ctx.ClientExitFileLock())))
+}
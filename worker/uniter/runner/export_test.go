@@ -17,3 +17,9 @@ var (
 func RunnerPaths(rnr Runner) context.Paths {
 	return rnr.(*runner).paths
 }
+
+// NewRunnerSharingHookTimeouts returns a runner for ctx that shares
+// the charm hook-timeouts already read by rnr.
+func NewRunnerSharingHookTimeouts(ctx Context, paths context.Paths, rnr Runner) Runner {
+	return newRunner(ctx, paths, rnr.(*runner).hookTimeouts)
+}
@@ -45,6 +45,7 @@ func NewFactory(
 		state:          state,
 		paths:          paths,
 		contextFactory: contextFactory,
+		hookTimeouts:   newCharmHookTimeouts(),
 	}
 
 	return f, nil
@@ -57,7 +58,8 @@ type factory struct {
 	state *uniter.State
 
 	// Fields that shouldn't change in a factory's lifetime.
-	paths context.Paths
+	paths        context.Paths
+	hookTimeouts *charmHookTimeouts
 }
 
 // NewCommandRunner exists to satisfy the Factory interface.
@@ -66,7 +68,7 @@ func (f *factory) NewCommandRunner(commandInfo context.CommandInfo) (Runner, err
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	runner := NewRunner(ctx, f.paths)
+	runner := newRunner(ctx, f.paths, f.hookTimeouts)
 	return runner, nil
 }
 
@@ -80,7 +82,7 @@ func (f *factory) NewHookRunner(hookInfo hook.Info) (Runner, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	runner := NewRunner(ctx, f.paths)
+	runner := newRunner(ctx, f.paths, f.hookTimeouts)
 	return runner, nil
 }
 
@@ -123,7 +125,7 @@ func (f *factory) NewActionRunner(actionId string) (Runner, error) {
 
 	actionData := context.NewActionData(name, &tag, params)
 	ctx, err := f.contextFactory.ActionContext(actionData)
-	runner := NewRunner(ctx, f.paths)
+	runner := newRunner(ctx, f.paths, f.hookTimeouts)
 	return runner, nil
 }
 
@@ -149,6 +149,11 @@ type ContextNetworking interface {
 	// executing unit's service is exposed.
 	OpenPorts(protocol string, fromPort, toPort int) error
 
+	// OpenEndpointPorts marks the supplied port range for opening
+	// when the executing unit's service is exposed for the given
+	// endpoint.
+	OpenEndpointPorts(endpoint, protocol string, fromPort, toPort int) error
+
 	// ClosePorts ensures the supplied port range is closed even when
 	// the executing unit's service is exposed (unless it is opened
 	// separately by a co- located unit).
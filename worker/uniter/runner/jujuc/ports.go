@@ -111,6 +111,10 @@ type portCommand struct {
 	FromPort   int
 	ToPort     int
 	formatFlag string // deprecated
+
+	// hasEndpoint is true for commands that accept --endpoint.
+	hasEndpoint bool
+	Endpoint    string
 }
 
 func (c *portCommand) Info() *cmd.Info {
@@ -119,6 +123,9 @@ func (c *portCommand) Info() *cmd.Info {
 
 func (c *portCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.StringVar(&c.formatFlag, "format", "", "deprecated format flag")
+	if c.hasEndpoint {
+		f.StringVar(&c.Endpoint, "endpoint", "", "open the port or range for this endpoint only")
+	}
 }
 
 func (c *portCommand) Init(args []string) error {
@@ -148,13 +155,23 @@ var openPortInfo = &cmd.Info{
 	Name:    "open-port",
 	Args:    portFormat,
 	Purpose: "register a port or range to open",
-	Doc:     "The port range will only be open while the service is exposed.",
+	Doc: `
+The port range will only be open while the service is exposed.
+
+With --endpoint, the port range is opened for the given endpoint only,
+and is reachable from the CIDRs the application is exposed to for that
+endpoint. Otherwise it is opened for all endpoints. close-port closes
+the range whichever endpoint it was opened for.`,
 }
 
 func NewOpenPortCommand(ctx Context) (cmd.Command, error) {
 	return &portCommand{
-		info: openPortInfo,
+		info:        openPortInfo,
+		hasEndpoint: true,
 		action: func(c *portCommand) error {
+			if c.Endpoint != "" {
+				return ctx.OpenEndpointPorts(c.Endpoint, c.Protocol, c.FromPort, c.ToPort)
+			}
 			return ctx.OpenPorts(c.Protocol, c.FromPort, c.ToPort)
 		},
 	}, nil
@@ -82,6 +82,25 @@ func (s *PortsSuite) TestOpenClose(c *gc.C) {
 	}
 }
 
+func (s *PortsSuite) TestOpenEndpointPorts(c *gc.C) {
+	hctx := s.GetHookContext(c, -1, "")
+	com, err := jujuc.NewCommand(hctx, cmdString("open-port"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := testing.Context(c)
+	code := cmd.Main(com, ctx, []string{"--endpoint", "db", "80"})
+	c.Assert(code, gc.Equals, 0)
+	s.Stub.CheckCall(c, 0, "OpenEndpointPorts", "db", "tcp", 80, 80)
+	hctx.info.CheckPorts(c, makeRanges("80/tcp"))
+}
+
+func (s *PortsSuite) TestClosePortHasNoEndpoint(c *gc.C) {
+	hctx := s.GetHookContext(c, -1, "")
+	com, err := jujuc.NewCommand(hctx, cmdString("close-port"))
+	c.Assert(err, jc.ErrorIsNil)
+	err = testing.InitCommand(com, []string{"--endpoint", "db", "80"})
+	c.Assert(err, gc.ErrorMatches, "flag provided but not defined: --endpoint")
+}
+
 var badPortsTests = []struct {
 	args []string
 	err  string
@@ -126,6 +145,11 @@ register a port or range to open
 
 Details:
 The port range will only be open while the service is exposed.
+
+With --endpoint, the port range is opened for the given endpoint only,
+and is reachable from the CIDRs the application is exposed to for that
+endpoint. Otherwise it is opened for all endpoints. close-port closes
+the range whichever endpoint it was opened for.
 `[1:])
 
 	close, err := jujuc.NewCommand(hctx, cmdString("close-port"))
@@ -56,6 +56,11 @@ func (*RestrictedContext) OpenPorts(protocol string, fromPort, toPort int) error
 	return ErrRestrictedContext
 }
 
+// OpenEndpointPorts implements jujuc.Context.
+func (*RestrictedContext) OpenEndpointPorts(endpoint, protocol string, fromPort, toPort int) error {
+	return ErrRestrictedContext
+}
+
 // ClosePorts implements jujuc.Context.
 func (*RestrictedContext) ClosePorts(protocol string, fromPort, toPort int) error {
 	return ErrRestrictedContext
@@ -0,0 +1,20 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build windows
+
+package runner
+
+import (
+	"os"
+	"os/exec"
+)
+
+// setProcessGroup does nothing on windows.
+func setProcessGroup(cmd *exec.Cmd) {}
+
+// killProcessGroup kills the supplied process; windows has
+// no equivalent of a process group that we can signal.
+func killProcessGroup(proc *os.Process) error {
+	return proc.Kill()
+}
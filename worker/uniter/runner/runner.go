@@ -6,9 +6,12 @@ package runner
 import (
 	"encoding/base64"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"os/exec"
+	"path"
 	"path/filepath"
+	"sync"
 	"time"
 	"unicode/utf8"
 
@@ -17,8 +20,10 @@ import (
 	"github.com/juju/loggo"
 	"github.com/juju/utils/clock"
 	utilexec "github.com/juju/utils/exec"
+	goyaml "gopkg.in/yaml.v2"
 
 	"github.com/juju/juju/core/actions"
+	"github.com/juju/juju/worker/uniter/charm"
 	"github.com/juju/juju/worker/uniter/runner/context"
 	"github.com/juju/juju/worker/uniter/runner/debug"
 	"github.com/juju/juju/worker/uniter/runner/jujuc"
@@ -27,13 +32,22 @@ import (
 
 var logger = loggo.GetLogger("juju.worker.uniter.runner")
 
+// dispatchName is the name of the optional executable, in the root of
+// the charm directory, which is invoked in place of every hook and
+// action. The hook or action being run is passed to it, relative to
+// the charm directory, in JUJU_DISPATCH_PATH.
+const dispatchName = "dispatch"
+
 // Runner is responsible for invoking commands in a context.
 type Runner interface {
 
 	// Context returns the context against which the runner executes.
 	Context() Context
 
-	// RunHook executes the hook with the supplied name.
+	// RunHook executes the hook with the supplied name. If the hook
+	// does not complete within the timeout configured for the charm
+	// or model, it is killed and an error satisfying IsHookTimedOutError
+	// is returned.
 	RunHook(name string) error
 
 	// RunAction executes the action with the supplied name.
@@ -52,6 +66,7 @@ type Context interface {
 	SetProcess(process context.HookProcess)
 	HasExecutionSetUnitStatus() bool
 	ResetExecutionSetUnitStatus()
+	HookTimeout() time.Duration
 
 	Prepare() error
 	Flush(badge string, failure error) error
@@ -59,13 +74,22 @@ type Context interface {
 
 // NewRunner returns a Runner backed by the supplied context and paths.
 func NewRunner(context Context, paths context.Paths) Runner {
-	return &runner{context, paths}
+	return newRunner(context, paths, newCharmHookTimeouts())
+}
+
+func newRunner(context Context, paths context.Paths, hookTimeouts *charmHookTimeouts) Runner {
+	return &runner{
+		context:      context,
+		paths:        paths,
+		hookTimeouts: hookTimeouts,
+	}
 }
 
 // runner implements Runner.
 type runner struct {
-	context Context
-	paths   context.Paths
+	context      Context
+	paths        context.Paths
+	hookTimeouts *charmHookTimeouts
 }
 
 func (runner *runner) Context() Context {
@@ -195,15 +219,25 @@ func (runner *runner) RunAction(actionName string) error {
 	if actionName == actions.JujuRunActionName {
 		return runner.runJujuRunAction()
 	}
-	return runner.runCharmHookWithLocation(actionName, "actions")
+	return runner.runCharmHookWithLocation(actionName, "actions", 0)
 }
 
 // RunHook exists to satisfy the Runner interface.
 func (runner *runner) RunHook(hookName string) error {
-	return runner.runCharmHookWithLocation(hookName, "hooks")
+	return runner.runCharmHookWithLocation(hookName, "hooks", runner.hookTimeout())
 }
 
-func (runner *runner) runCharmHookWithLocation(hookName, charmLocation string) error {
+// hookTimeout returns the time after which a hook should be killed. A
+// timeout declared in the charm's metadata takes precedence over the
+// model's hook-timeout setting; zero means that there is no limit.
+func (runner *runner) hookTimeout() time.Duration {
+	if timeout := runner.hookTimeouts.get(runner.paths.GetCharmDir()); timeout != 0 {
+		return timeout
+	}
+	return runner.context.HookTimeout()
+}
+
+func (runner *runner) runCharmHookWithLocation(hookName, charmLocation string, timeout time.Duration) error {
 	srv, err := runner.startJujucServer()
 	if err != nil {
 		return err
@@ -220,20 +254,25 @@ func (runner *runner) runCharmHookWithLocation(hookName, charmLocation string) e
 		// because that already has handling for windows environment requirements.
 		env = mergeWindowsEnvironment(env, os.Environ())
 	}
+	env = append(env, "JUJU_DISPATCH_PATH="+path.Join(charmLocation, hookName))
 
 	debugctx := debug.NewHooksContext(runner.context.UnitName())
 	if session, _ := debugctx.FindSession(); session != nil && session.MatchHook(hookName) {
 		logger.Infof("executing %s via debug-hooks", hookName)
 		err = session.RunHook(hookName, runner.paths.GetCharmDir(), env)
 	} else {
-		err = runner.runCharmHook(hookName, env, charmLocation)
+		err = runner.runCharmHook(hookName, env, charmLocation, timeout)
 	}
 	return runner.context.Flush(hookName, err)
 }
 
-func (runner *runner) runCharmHook(hookName string, env []string, charmLocation string) error {
+func (runner *runner) runCharmHook(hookName string, env []string, charmLocation string, timeout time.Duration) error {
 	charmDir := runner.paths.GetCharmDir()
-	hook, err := searchHook(charmDir, filepath.Join(charmLocation, hookName))
+	hook, err := searchHook(charmDir, dispatchName)
+	if context.IsMissingHookError(err) {
+		// No dispatch script, so fall back to the hook itself.
+		hook, err = searchHook(charmDir, filepath.Join(charmLocation, hookName))
+	}
 	if err != nil {
 		return err
 	}
@@ -241,6 +280,7 @@ func (runner *runner) runCharmHook(hookName string, env []string, charmLocation
 	ps := exec.Command(hookCmd[0], hookCmd[1:]...)
 	ps.Env = env
 	ps.Dir = charmDir
+	setProcessGroup(ps)
 	outReader, outWriter, err := os.Pipe()
 	if err != nil {
 		return errors.Errorf("cannot make logging pipe: %v", err)
@@ -258,13 +298,117 @@ func (runner *runner) runCharmHook(hookName string, env []string, charmLocation
 	if err == nil {
 		// Record the *os.Process of the hook
 		runner.context.SetProcess(hookProcess{ps.Process})
+		// The timer records whether it killed the hook under the
+		// same lock that is taken once the hook has exited, so a
+		// hook that finishes just as the timer fires is never
+		// reported as having timed out.
+		var mu sync.Mutex
+		var exited, timedOut bool
+		var timer *time.Timer
+		if timeout > 0 {
+			timer = time.AfterFunc(timeout, func() {
+				mu.Lock()
+				defer mu.Unlock()
+				if exited {
+					return
+				}
+				timedOut = true
+				logger.Warningf("%s hook timed out after %v, killing it", hookName, timeout)
+				if err := killProcessGroup(ps.Process); err != nil {
+					logger.Errorf("cannot kill %s: %v", hookName, err)
+				}
+			})
+		}
 		// Block until execution finishes
 		err = ps.Wait()
+		mu.Lock()
+		exited = true
+		killed := timedOut
+		mu.Unlock()
+		if timer != nil {
+			timer.Stop()
+		}
+		if killed {
+			err = NewHookTimedOutError(hookName, timeout)
+		}
 	}
 	hookLogger.stop()
 	return errors.Trace(err)
 }
 
+// charmMetadataTimeout holds the parts of a charm's metadata.yaml
+// that control how its hooks are run.
+type charmMetadataTimeout struct {
+	HookTimeout string `yaml:"hook-timeout"`
+}
+
+// charmHookTimeouts records the hook-timeout declared by each charm, so
+// that a charm's metadata is read once when it is first run rather than
+// for every hook.
+type charmHookTimeouts struct {
+	mu       sync.Mutex
+	timeouts map[string]time.Duration
+}
+
+func newCharmHookTimeouts() *charmHookTimeouts {
+	return &charmHookTimeouts{
+		timeouts: make(map[string]time.Duration),
+	}
+}
+
+// get returns the hook-timeout declared by the charm deployed in
+// charmDir, or zero if none is declared. A timeout that cannot be read
+// is logged and treated as undeclared.
+func (t *charmHookTimeouts) get(charmDir string) time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	// A charm directory without a recorded URL is not cached, as
+	// there is nothing to tell when a different charm replaces it.
+	var key string
+	curl, err := charm.ReadCharmURL(filepath.Join(charmDir, charm.CharmURLPath))
+	if err == nil {
+		key = curl.String()
+		if timeout, ok := t.timeouts[key]; ok {
+			return timeout
+		}
+	}
+	timeout, err := charmHookTimeout(charmDir)
+	if err != nil {
+		logger.Warningf("using the model's hook-timeout: %v", err)
+		timeout = 0
+	}
+	if key != "" {
+		t.timeouts[key] = timeout
+	}
+	return timeout
+}
+
+// charmHookTimeout returns the hook-timeout declared in the metadata of
+// the charm in charmDir, or zero if none is declared.
+func charmHookTimeout(charmDir string) (time.Duration, error) {
+	data, err := ioutil.ReadFile(filepath.Join(charmDir, "metadata.yaml"))
+	if os.IsNotExist(err) {
+		return 0, nil
+	} else if err != nil {
+		return 0, errors.Annotate(err, "cannot read charm metadata")
+	}
+	var meta charmMetadataTimeout
+	if err := goyaml.Unmarshal(data, &meta); err != nil {
+		return 0, errors.Annotate(err, "cannot parse charm metadata")
+	}
+	if meta.HookTimeout == "" {
+		return 0, nil
+	}
+	timeout, err := time.ParseDuration(meta.HookTimeout)
+	if err != nil {
+		return 0, errors.Annotate(err, "invalid hook-timeout in charm metadata")
+	}
+	if timeout < 0 {
+		return 0, errors.NotValidf("negative hook-timeout %q in charm metadata", meta.HookTimeout)
+	}
+	return timeout, nil
+}
+
 func (runner *runner) startJujucServer() (*jujuc.Server, error) {
 	// Prepare server.
 	getCmd := func(ctxId, cmdName string) (cmd.Command, error) {
@@ -6,6 +6,7 @@ package runner_test
 import (
 	"fmt"
 	"io/ioutil"
+	"os"
 	"path/filepath"
 	"runtime"
 	"strings"
@@ -17,8 +18,11 @@ import (
 	"github.com/juju/utils/exec"
 	"github.com/juju/utils/proxy"
 	gc "gopkg.in/check.v1"
+	corecharm "gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/charm.v6-unstable/hooks"
 
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/uniter/charm"
 	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/runner"
 	"github.com/juju/juju/worker/uniter/runner/context"
@@ -155,6 +159,7 @@ type MockContext struct {
 	flushBadge      string
 	flushFailure    error
 	flushResult     error
+	hookTimeout     time.Duration
 }
 
 func (ctx *MockContext) UnitName() string {
@@ -165,6 +170,10 @@ func (ctx *MockContext) HookVars(paths context.Paths) ([]string, error) {
 	return []string{"VAR=value"}, nil
 }
 
+func (ctx *MockContext) HookTimeout() time.Duration {
+	return ctx.hookTimeout
+}
+
 func (ctx *MockContext) ActionData() (*context.ActionData, error) {
 	if ctx.actionData == nil {
 		return nil, errors.New("blam")
@@ -359,3 +368,192 @@ func (s *RunMockContextSuite) TestRunCommandsFlushFailure(c *gc.C) {
 	c.Assert(ctx.flushFailure, gc.IsNil) // exit code in _ result, as tested elsewhere
 	s.assertRecordedPid(c, ctx.expectPid)
 }
+
+func (s *RunMockContextSuite) makeDispatch(c *gc.C, code int) {
+	if runtime.GOOS == "windows" {
+		c.Skip("dispatch is only tested on linux")
+	}
+	script := fmt.Sprintf(`#!/bin/bash
+%s
+echo $JUJU_DISPATCH_PATH > dispatch-path
+exit %d
+`, echoPidScript, code)
+	path := filepath.Join(s.paths.GetCharmDir(), "dispatch")
+	err := ioutil.WriteFile(path, []byte(script), 0700)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *RunMockContextSuite) assertDispatchPath(c *gc.C, expect string) {
+	path := filepath.Join(s.paths.GetCharmDir(), "dispatch-path")
+	content, err := ioutil.ReadFile(path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(strings.TrimRight(string(content), "\n"), gc.Equals, expect)
+}
+
+func (s *RunMockContextSuite) TestRunHookDispatch(c *gc.C) {
+	s.makeDispatch(c, 0)
+	ctx := &MockContext{}
+	err := runner.NewRunner(ctx, s.paths).RunHook("something-happened")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.flushBadge, gc.Equals, "something-happened")
+	c.Assert(ctx.flushFailure, gc.IsNil)
+	s.assertRecordedPid(c, ctx.expectPid)
+	s.assertDispatchPath(c, "hooks/something-happened")
+}
+
+func (s *RunMockContextSuite) TestRunHookDispatchPreferred(c *gc.C) {
+	s.makeDispatch(c, 0)
+	makeCharm(c, hookSpec{
+		dir:  "hooks",
+		name: hookName,
+		perm: 0700,
+		code: 123,
+	}, s.paths.GetCharmDir())
+	ctx := &MockContext{}
+	err := runner.NewRunner(ctx, s.paths).RunHook("something-happened")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.flushFailure, gc.IsNil)
+	s.assertDispatchPath(c, "hooks/something-happened")
+}
+
+func (s *RunMockContextSuite) TestRunHookDispatchFailure(c *gc.C) {
+	s.makeDispatch(c, 123)
+	ctx := &MockContext{}
+	err := runner.NewRunner(ctx, s.paths).RunHook("something-happened")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.flushFailure, gc.ErrorMatches, "exit status 123")
+}
+
+func (s *RunMockContextSuite) TestRunActionDispatch(c *gc.C) {
+	s.makeDispatch(c, 0)
+	ctx := &MockContext{
+		actionData: &context.ActionData{},
+	}
+	err := runner.NewRunner(ctx, s.paths).RunAction("something-happened")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.flushBadge, gc.Equals, "something-happened")
+	c.Assert(ctx.flushFailure, gc.IsNil)
+	s.assertRecordedPid(c, ctx.expectPid)
+	s.assertDispatchPath(c, "actions/something-happened")
+}
+
+func (s *RunMockContextSuite) makeSleepingHook(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("hook timeouts are only tested on linux")
+	}
+	dir := filepath.Join(s.paths.GetCharmDir(), "hooks")
+	err := os.Mkdir(dir, 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	script := fmt.Sprintf("#!/bin/bash\n%s\n(sleep 100; touch orphan) &\nsleep 100\n", echoPidScript)
+	err = ioutil.WriteFile(filepath.Join(dir, hookName), []byte(script), 0700)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *RunMockContextSuite) TestRunHookTimeout(c *gc.C) {
+	s.makeSleepingHook(c)
+	ctx := &MockContext{
+		hookTimeout: 100 * time.Millisecond,
+	}
+	t0 := time.Now()
+	err := runner.NewRunner(ctx, s.paths).RunHook("something-happened")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(time.Since(t0) < coretesting.LongWait, jc.IsTrue)
+	c.Assert(ctx.flushBadge, gc.Equals, "something-happened")
+	c.Assert(ctx.flushFailure, gc.ErrorMatches, "something-happened hook timed out after 100ms")
+	c.Assert(runner.IsHookTimedOutError(ctx.flushFailure), jc.IsTrue)
+	s.assertRecordedPid(c, ctx.expectPid)
+
+	// The whole process group was killed.
+	c.Assert(processExists(ctx.expectPid), jc.IsFalse)
+}
+
+func (s *RunMockContextSuite) TestRunHookCharmTimeout(c *gc.C) {
+	s.makeSleepingHook(c)
+	metadata := "name: sleepy\nsummary: s\ndescription: d\nhook-timeout: 100ms\n"
+	err := ioutil.WriteFile(filepath.Join(s.paths.GetCharmDir(), "metadata.yaml"), []byte(metadata), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The charm's timeout takes precedence over the model's.
+	ctx := &MockContext{
+		hookTimeout: time.Hour,
+	}
+	err = runner.NewRunner(ctx, s.paths).RunHook("something-happened")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.flushFailure, gc.ErrorMatches, "something-happened hook timed out after 100ms")
+}
+
+func (s *RunMockContextSuite) TestRunHookInvalidCharmTimeout(c *gc.C) {
+	s.makeSleepingHook(c)
+	metadata := "name: sleepy\nsummary: s\ndescription: d\nhook-timeout: whenever\n"
+	err := ioutil.WriteFile(filepath.Join(s.paths.GetCharmDir(), "metadata.yaml"), []byte(metadata), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The model's timeout is used instead.
+	ctx := &MockContext{
+		hookTimeout: 100 * time.Millisecond,
+	}
+	err = runner.NewRunner(ctx, s.paths).RunHook("something-happened")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.flushFailure, gc.ErrorMatches, "something-happened hook timed out after 100ms")
+}
+
+func (s *RunMockContextSuite) TestRunHookInvalidCharmMetadata(c *gc.C) {
+	s.makeSleepingHook(c)
+	metadata := "hook-timeout: [1m\n"
+	err := ioutil.WriteFile(filepath.Join(s.paths.GetCharmDir(), "metadata.yaml"), []byte(metadata), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The model's timeout is used instead.
+	ctx := &MockContext{
+		hookTimeout: 100 * time.Millisecond,
+	}
+	err = runner.NewRunner(ctx, s.paths).RunHook("something-happened")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.flushFailure, gc.ErrorMatches, "something-happened hook timed out after 100ms")
+}
+
+func (s *RunMockContextSuite) TestRunHookCharmTimeoutReadOncePerCharm(c *gc.C) {
+	s.makeSleepingHook(c)
+	charmDir := s.paths.GetCharmDir()
+	writeCharm := func(url, timeout string) {
+		err := charm.WriteCharmURL(filepath.Join(charmDir, charm.CharmURLPath), corecharm.MustParseURL(url))
+		c.Assert(err, jc.ErrorIsNil)
+		metadata := "name: sleepy\nsummary: s\ndescription: d\nhook-timeout: " + timeout + "\n"
+		err = ioutil.WriteFile(filepath.Join(charmDir, "metadata.yaml"), []byte(metadata), 0644)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	runHook := func(rnr runner.Runner, ctx *MockContext, expectTimeout string) {
+		err := rnr.RunHook("something-happened")
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(ctx.flushFailure, gc.ErrorMatches, "something-happened hook timed out after "+expectTimeout)
+	}
+
+	writeCharm("cs:quantal/sleepy-1", "100ms")
+	ctx := &MockContext{hookTimeout: time.Hour}
+	first := runner.NewRunner(ctx, s.paths)
+	runHook(first, ctx, "100ms")
+
+	// The timeout is not read again for the same charm.
+	writeCharm("cs:quantal/sleepy-1", "150ms")
+	ctx = &MockContext{hookTimeout: time.Hour}
+	runHook(runner.NewRunnerSharingHookTimeouts(ctx, s.paths, first), ctx, "100ms")
+
+	// It is read again once the charm is upgraded.
+	writeCharm("cs:quantal/sleepy-2", "150ms")
+	ctx = &MockContext{hookTimeout: time.Hour}
+	runHook(runner.NewRunnerSharingHookTimeouts(ctx, s.paths, first), ctx, "150ms")
+}
+
+func (s *RunMockContextSuite) TestRunHookNoTimeout(c *gc.C) {
+	ctx := &MockContext{
+		hookTimeout: time.Hour,
+	}
+	makeCharm(c, hookSpec{
+		dir:  "hooks",
+		name: hookName,
+		perm: 0700,
+	}, s.paths.GetCharmDir())
+	err := runner.NewRunner(ctx, s.paths).RunHook("something-happened")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.flushFailure, gc.IsNil)
+}
@@ -0,0 +1,62 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+	goyaml "gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/worker/uniter/operation"
+	"github.com/juju/juju/worker/uniter/remotestate"
+	"github.com/juju/juju/worker/uniter/resolver"
+	"github.com/juju/juju/worker/uniter/runner/debug"
+)
+
+// debugStepper implements resolver.Stepper by presenting each
+// operation to a "juju debug-hooks --step" client, if one is
+// connected to the unit.
+type debugStepper struct {
+	debugctx *debug.HooksContext
+	abort    <-chan struct{}
+}
+
+// Step is part of the resolver.Stepper interface.
+func (s *debugStepper) Step(
+	op operation.Operation,
+	remoteState remotestate.Snapshot,
+	runErr error,
+) (resolver.StepDecision, error) {
+	session, err := s.debugctx.FindStepSession()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if session == nil {
+		return resolver.StepRun, nil
+	}
+	req := debug.StepRequest{
+		Operation:   op.String(),
+		RemoteState: renderSnapshot(remoteState),
+	}
+	if runErr != nil {
+		req.Error = runErr.Error()
+	}
+	logger.Infof("waiting for debug-hooks decision on %v", op)
+	decision, err := session.Prompt(req, s.abort)
+	if err == debug.ErrStepAborted {
+		return "", resolver.ErrLoopAborted
+	} else if err != nil {
+		return "", errors.Trace(err)
+	}
+	return resolver.StepDecision(decision), nil
+}
+
+func renderSnapshot(remoteState remotestate.Snapshot) string {
+	data, err := goyaml.Marshal(remoteState)
+	if err != nil {
+		return fmt.Sprintf("%+v\n", remoteState)
+	}
+	return string(data)
+}
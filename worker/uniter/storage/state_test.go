@@ -160,6 +160,19 @@ func (s *stateSuite) TestCommitHook(c *gc.C) {
 		c.Assert(stateFile, jc.IsNonEmptyFile)
 	}
 
+	for i := 0; i < 2; i++ {
+		err := state.CommitHook(hook.Info{
+			Kind:        hook.StorageResized,
+			StorageId:   "data-0",
+			StorageSize: 2048,
+		})
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(storage.StateSize(state), gc.Equals, uint64(2048))
+		data, err := ioutil.ReadFile(stateFile)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(string(data), gc.Equals, "attached: true\nsize: 2048\n")
+	}
+
 	for i := 0; i < 2; i++ {
 		err := state.CommitHook(hook.Info{
 			Kind:      hooks.StorageDetaching,
@@ -199,4 +212,6 @@ func (s *stateSuite) TestValidateHook(c *gc.C) {
 	assertValidates(true, hooks.StorageDetaching)
 	assertValidateFails(false, hooks.StorageDetaching, `inappropriate "storage-detaching" hook for storage "data/0": storage not attached`)
 	assertValidateFails(true, hooks.StorageAttached, `inappropriate "storage-attached" hook for storage "data/0": storage already attached`)
+	assertValidates(true, hook.StorageResized)
+	assertValidateFails(false, hook.StorageResized, `inappropriate "storage-resized" hook for storage "data/0": storage not attached`)
 }
@@ -36,6 +36,7 @@ import (
 	"github.com/juju/juju/worker/uniter/runcommands"
 	"github.com/juju/juju/worker/uniter/runner"
 	"github.com/juju/juju/worker/uniter/runner/context"
+	"github.com/juju/juju/worker/uniter/runner/debug"
 	"github.com/juju/juju/worker/uniter/runner/jujuc"
 	"github.com/juju/juju/worker/uniter/storage"
 	jujuos "github.com/juju/utils/os"
@@ -100,6 +101,14 @@ type Uniter struct {
 	// downloader is the downloader that should be used to get the charm
 	// archive.
 	downloader charm.Downloader
+
+	// hookRuns, if not nil, records the hooks and actions run by the
+	// uniter so that they can be reported by the unit agent.
+	hookRuns *HookRuns
+
+	// failedHookDuration holds how long the last hook ran for, if it
+	// failed, so that it can be reported in the hook's error status.
+	failedHookDuration string
 }
 
 // UniterParams hold all the necessary parameters for a new Uniter.
@@ -115,6 +124,7 @@ type UniterParams struct {
 	HookRetryStrategy    params.RetryStrategy
 	NewOperationExecutor NewExecutorFunc
 	Clock                clock.Clock
+	HookRuns             *HookRuns
 	// TODO (mattyw, wallyworld, fwereade) Having the observer here make this approach a bit more legitimate, but it isn't.
 	// the observer is only a stop gap to be used in tests. A better approach would be to have the uniter tests start hooks
 	// that write to files, and have the tests watch the output to know that hooks have finished.
@@ -139,6 +149,7 @@ func NewUniter(uniterParams *UniterParams) (*Uniter, error) {
 		observer:             uniterParams.Observer,
 		clock:                uniterParams.Clock,
 		downloader:           uniterParams.Downloader,
+		hookRuns:             uniterParams.HookRuns,
 	}
 	err := catacomb.Invoke(catacomb.Plan{
 		Site: &u.catacomb,
@@ -310,6 +321,10 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 				Abort:         u.catacomb.Dying(),
 				OnIdle:        onIdle,
 				CharmDirGuard: u.charmDirGuard,
+				Stepper: &debugStepper{
+					debugctx: debug.NewHooksContext(u.unit.Name()),
+					abort:    u.catacomb.Dying(),
+				},
 			}, &localState)
 			switch cause := errors.Cause(err); cause {
 			case nil:
@@ -546,6 +561,12 @@ func (u *Uniter) reportHookError(hookInfo hook.Info) error {
 		hookName = fmt.Sprintf("%s-%s", relationName, hookInfo.Kind)
 	}
 	statusData["hook"] = hookName
+	if u.failedHookDuration != "" {
+		statusData["duration"] = u.failedHookDuration
+	}
 	statusMessage := fmt.Sprintf("hook failed: %q", hookName)
+	if u.operationExecutor.State().HookTimedOut {
+		statusMessage = fmt.Sprintf("hook timed out: %q", hookName)
+	}
 	return setAgentStatus(u, status.StatusError, statusMessage, statusData)
 }